@@ -0,0 +1,132 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/config"
+	"Mikrotik-Layer/database"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+// App - Dependensi bersama yang dibangun sekali di main lalu di-inject ke route builder REST
+// dan WebSocket serta worker background. Dirakit eksplisit (bukan singleton) supaya bisa
+// dirakit ulang, mis. untuk test atau mode single-port.
+type App struct {
+	Config *config.Config
+	DB     *database.Database
+
+	Routers *repository.RouterRepository
+	Users   *repository.UserRepository
+	Traffic *repository.TrafficRepository
+	Events  *repository.EventRepository
+	Alerts  *repository.AlertRepository
+	Audit   *repository.AuditRepository
+	Usage   *repository.UsageRepository
+
+	Mikrotik      *services.MikrotikService
+	Hub           *services.Hub
+	Sampler       *services.TrafficSampler
+	Webhooks      *services.WebhookDispatcher
+	Beacons       *services.BeaconMonitor
+	Naming        *services.NamingPolicy  // diset main setelah regex NAMING_POLICY_* dicek
+	Tasks         *services.TaskScheduler // diset main setelah TASK_SCHEDULE_TIMEZONE dicek
+	AlertRouting  *services.AlertRouter
+	Authenticator *auth.Authenticator
+}
+
+// New - Rakit dependensi dari konfigurasi. Koneksi router (auto-connect, health check)
+// baru dimulai setelah retry policy dan dial config diterapkan.
+func New(cfg *config.Config, db *database.Database) *App {
+	retention := time.Duration(cfg.HistoryRetentionDays) * 24 * time.Hour
+
+	a := &App{
+		Config:  cfg,
+		DB:      db,
+		Routers: repository.NewRouterRepository(db.DB),
+		Users:   repository.NewUserRepository(db.DB),
+		Traffic: repository.NewTrafficRepository(db.DB),
+		Events:  repository.NewEventRepository(db.DB),
+		Alerts:  repository.NewAlertRepository(db.DB),
+		Audit:   repository.NewAuditRepository(db.DB),
+		Usage:   repository.NewUsageRepository(db.DB),
+		Hub:     services.GetHub(),
+	}
+
+	// Routing alert baru ke channel notifikasi per tag router (webhook / email); dipakai
+	// setiap EventRecorder sehingga dibuat sebelum service lain
+	a.AlertRouting = services.NewAlertRouter(repository.NewNotificationChannelRepository(db.DB), a.Routers,
+		services.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+
+	a.Mikrotik = services.NewMikrotikService(a.Routers)
+	// Retry read RouterOS untuk error transient
+	a.Mikrotik.SetRetryPolicy(services.RetryPolicy{
+		Attempts:   cfg.RetryAttempts,
+		Backoff:    cfg.RetryBackoff,
+		MaxBackoff: cfg.RetryMaxBackoff,
+	})
+	// Batas Listen stream bersamaan per router
+	a.Mikrotik.SetStreamLimit(services.StreamLimit{
+		PerRouter:    cfg.RouterOSMaxStreams,
+		QueueTimeout: cfg.RouterOSStreamQueueTimeout,
+	})
+	// Source IP / interface VRF untuk dial ke router (override per router di kolom routers)
+	// dan resolve hostname DNS
+	a.Mikrotik.SetDialConfig(services.DialConfig{
+		SourceAddress:    cfg.RouterOSSourceAddress,
+		BindInterface:    cfg.RouterOSBindInterface,
+		PreferFamily:     cfg.RouterOSPreferFamily,
+		ResolveInterval:  cfg.RouterOSResolveInterval,
+		FailbackInterval: cfg.RouterOSFailbackInterval,
+	})
+	// Ambang level degraded health check
+	services.GetHealthScorer().SetThresholds(services.HealthThresholds{
+		Latency: cfg.HealthDegradedLatency,
+		LossPct: cfg.HealthDegradedLossPct,
+	})
+	// Event failover / failback path koneksi (alamat tambahan <-> hostname) dan level kesehatan
+	a.Mikrotik.SetEventRecorder(a.EventRecorder())
+	a.Mikrotik.Start()
+
+	// Background sampler untuk traffic history
+	a.Sampler = services.GetTrafficSampler(a.Mikrotik, a.Traffic, retention)
+
+	// Webhook provisioning: satu dispatcher untuk Emit (handler, worker) dan Run (main)
+	a.Webhooks = services.NewWebhookDispatcher(cfg.WebhookInterval, cfg.WebhookMaxAttempts, retention,
+		repository.NewWebhookRepository(db.DB))
+
+	// Beacon heartbeat: satu monitor untuk install/remove & heartbeat (handler) dan Run (main),
+	// supaya state klasifikasi yang direset saat install ikut dipakai loop klasifikasi
+	a.Beacons = services.NewBeaconMonitor(cfg.BeaconInterval, cfg.BeaconStaleAfter, cfg.BeaconBaseURL, a.Mikrotik,
+		a.Routers, repository.NewBeaconRepository(db.DB), a.EventRecorder())
+
+	// Dipakai listener REST dan WebSocket
+	a.Authenticator = auth.NewAuthenticator(cfg.AuthEnabled, cfg.AuthAdminToken, a.Users).
+		RequireTOTP(strings.Split(cfg.AuthTOTPRequiredRoles, ",")).
+		RestrictAdminToken(strings.Split(cfg.AuthAdminTokenAllowedCIDRs, ","))
+
+	return a
+}
+
+// EventRecorder - Recorder event + alert yang dipublish ke hub
+func (a *App) EventRecorder() *services.EventRecorder {
+	return services.NewEventRecorder(a.Events, a.Alerts, a.Hub).WithAlertRouting(a.AlertRouting)
+}
+
+// AuditLogger - Logger audit log
+func (a *App) AuditLogger() *services.AuditLogger {
+	return services.NewAuditLogger(a.Audit)
+}
+
+// Retention - Retensi data history dari HISTORY_RETENTION_DAYS
+func (a *App) Retention() time.Duration {
+	return time.Duration(a.Config.HistoryRetentionDays) * 24 * time.Hour
+}
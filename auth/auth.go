@@ -0,0 +1,327 @@
+// Package auth - Autentikasi token API dan pembatasan akses per router / interface.
+//
+// Token dikirim lewat header "Authorization: Bearer <token>" atau query ?access_token=
+// (WebSocket dari browser tidak bisa mengirim header). Jika auth nonaktif, request tidak
+// membawa Principal dan semua cek akses lolos.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/middleware"
+	"Mikrotik-Layer/models"
+)
+
+// Principal - Identitas pemanggil beserta router/interface yang boleh diakses
+type Principal struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+
+	ResellerID *int `json:"reseller_id,omitempty"`
+
+	// TOTP sudah diaktifkan user (policy AUTH_TOTP_REQUIRED_ROLES)
+	TOTP bool `json:"totp_enabled"`
+
+	// Sesi dashboard yang dipakai request (0 = API token / token bootstrap)
+	SessionID int `json:"session_id,omitempty"`
+
+	// Allow-list IP token / sesi yang dipakai (kosong = semua IP)
+	AllowedCIDRs []string `json:"allowed_cidrs,omitempty"`
+
+	// router_id -> interface yang diizinkan (kosong = semua interface); nil untuk admin
+	scopes map[int][]string
+}
+
+// NewPrincipal - Principal dari user; admin tidak dibatasi scope
+func NewPrincipal(user *models.User) *Principal {
+	p := &Principal{UserID: user.ID, Username: user.Username, Role: user.Role, ResellerID: user.ResellerID, TOTP: user.TOTP,
+		AllowedCIDRs: user.AllowedCIDRs}
+	if user.Role != models.RoleAdmin {
+		p.scopes = make(map[int][]string, len(user.Scopes))
+		for _, scope := range user.Scopes {
+			p.scopes[scope.RouterID] = scope.Interfaces
+		}
+	}
+	return p
+}
+
+// Unrestricted - True untuk admin atau saat auth nonaktif (principal nil)
+func (p *Principal) Unrestricted() bool {
+	return p == nil || p.scopes == nil
+}
+
+// IsAdmin - Boleh kelola user dan endpoint administratif
+func (p *Principal) IsAdmin() bool {
+	return p == nil || p.Role == models.RoleAdmin
+}
+
+// CanAccessReseller - Admin, atau user milik reseller tersebut
+func (p *Principal) CanAccessReseller(resellerID int) bool {
+	if p.IsAdmin() {
+		return true
+	}
+	return p.ResellerID != nil && *p.ResellerID == resellerID
+}
+
+// CanAccessRouter - Router ada di scope
+func (p *Principal) CanAccessRouter(routerID int) bool {
+	if p.Unrestricted() {
+		return true
+	}
+	_, ok := p.scopes[routerID]
+	return ok
+}
+
+// CanMonitor - Interface router boleh dimonitor
+func (p *Principal) CanMonitor(routerID int, iface string) bool {
+	if p.Unrestricted() {
+		return true
+	}
+	allowed, ok := p.scopes[routerID]
+	if !ok {
+		return false
+	}
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, name := range allowed {
+		if name == iface {
+			return true
+		}
+	}
+	return false
+}
+
+// RouterIDs - Router dalam scope (nil = tidak dibatasi)
+func (p *Principal) RouterIDs() []int {
+	if p.Unrestricted() {
+		return nil
+	}
+	ids := make([]int, 0, len(p.scopes))
+	for id := range p.scopes {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+type principalKey struct{}
+
+// FromRequest - Principal request (nil jika auth nonaktif)
+func FromRequest(r *http.Request) *Principal {
+	p, _ := r.Context().Value(principalKey{}).(*Principal)
+	return p
+}
+
+// UserStore - Sumber user dari hash token (repository.UserRepository)
+type UserStore interface {
+	UserByTokenHash(hash string) (*models.User, error)
+	UserBySessionHash(hash string) (*models.User, int, error)
+}
+
+// Authenticator - Middleware autentikasi token
+type Authenticator struct {
+	enabled    bool
+	adminToken string // token bootstrap dari env, berlaku sebagai admin
+	store      UserStore
+	totpRoles  map[string]bool // role yang wajib enroll TOTP
+
+	adminAllowed []string // allow-list IP token bootstrap
+}
+
+func NewAuthenticator(enabled bool, adminToken string, store UserStore) *Authenticator {
+	if enabled && adminToken == "" {
+		log.Println("⚠ AUTH_ENABLED without AUTH_ADMIN_TOKEN: only database tokens are accepted")
+	}
+	return &Authenticator{enabled: enabled, adminToken: adminToken, store: store}
+}
+
+// RequireTOTP - Policy 2FA: user dengan role ini yang belum enroll TOTP hanya boleh mengakses
+// /api/auth/* (enrollment, sesi) sampai TOTP aktif
+func (a *Authenticator) RequireTOTP(roles []string) *Authenticator {
+	a.totpRoles = make(map[string]bool, len(roles))
+	for _, role := range roles {
+		if role = strings.TrimSpace(role); role != "" {
+			a.totpRoles[role] = true
+		}
+	}
+	return a
+}
+
+// RestrictAdminToken - Allow-list IP / CIDR untuk token bootstrap AUTH_ADMIN_TOKEN
+func (a *Authenticator) RestrictAdminToken(cidrs []string) *Authenticator {
+	for _, cidr := range cidrs {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			a.adminAllowed = append(a.adminAllowed, cidr)
+		}
+	}
+	return a
+}
+
+// IPAllowed - IP termasuk allow-list (IP tunggal atau CIDR); list kosong = semua IP.
+// Entry yang tidak valid diabaikan (divalidasi saat token dibuat).
+func IPAllowed(ip net.IP, cidrs []string) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+	for _, cidr := range cidrs {
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+			return true
+		} else if err != nil && ip.Equal(net.ParseIP(cidr)) {
+			return true
+		}
+	}
+	return false
+}
+
+// TOTPRequired - Role wajib TOTP menurut policy
+func (a *Authenticator) TOTPRequired(role string) bool {
+	return a.totpRoles[role]
+}
+
+// authPath - Endpoint /api/auth/* (juga lewat /api/v1) yang tetap terbuka sebelum enroll TOTP
+func authPath(path string) bool {
+	return strings.HasPrefix(path, "/api/auth/") || strings.HasPrefix(path, "/api/v1/auth/")
+}
+
+// publicPaths - Endpoint yang tetap bisa diakses tanpa token
+var publicPaths = map[string]bool{
+	"/health":                  true,
+	"/ws/health":               true,
+	"/api/connections/healthz": true, // ringkasan angka saja, untuk monitor uptime eksternal
+	"/api/auth/refresh":        true, // diautentikasi oleh refresh token di body
+	"/api/v1/auth/refresh":     true,
+	"/api/beacon":              true, // heartbeat router, diautentikasi token beacon
+	"/api/v1/beacon":           true,
+}
+
+// Middleware - Tolak request tanpa token valid (401), simpan Principal di context
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.enabled || publicPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := tokenFromRequest(r)
+		if token == "" {
+			writeError(w, r, http.StatusUnauthorized, i18n.Unauthorized)
+			return
+		}
+
+		var principal *Principal
+		if a.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.adminToken)) == 1 {
+			principal = &Principal{Username: "admin", Role: models.RoleAdmin, AllowedCIDRs: a.adminAllowed}
+		} else if strings.HasPrefix(token, sessionTokenPrefix) {
+			user, sessionID, err := a.store.UserBySessionHash(HashToken(token))
+			if err != nil {
+				writeError(w, r, http.StatusUnauthorized, i18n.Unauthorized)
+				return
+			}
+			principal = NewPrincipal(user)
+			principal.SessionID = sessionID
+		} else {
+			user, err := a.store.UserByTokenHash(HashToken(token))
+			if err != nil {
+				writeError(w, r, http.StatusUnauthorized, i18n.Unauthorized)
+				return
+			}
+			principal = NewPrincipal(user)
+		}
+
+		if !IPAllowed(middleware.ClientIP(r), principal.AllowedCIDRs) {
+			writeError(w, r, http.StatusForbidden, i18n.TokenIPNotAllowed)
+			return
+		}
+
+		if principal.UserID != 0 && a.totpRoles[principal.Role] && !authPath(r.URL.Path) {
+			if !principal.TOTP {
+				writeError(w, r, http.StatusForbidden, i18n.TOTPEnrollmentRequired)
+				return
+			}
+			// Token API statis tidak melewati TOTP; role wajib 2FA hanya lewat token session
+			if principal.SessionID == 0 {
+				writeError(w, r, http.StatusForbidden, i18n.TOTPSessionRequired)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+// RequireAdmin - Batasi handler untuk admin (403 untuk role lain)
+func RequireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !FromRequest(r).IsAdmin() {
+			writeError(w, r, http.StatusForbidden, i18n.Forbidden)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// Forbidden - Tulis response 403 standar (untuk cek scope di handler)
+func Forbidden(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusForbidden, i18n.Forbidden)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: false,
+		Code:    code,
+		Error:   i18n.T(r, code),
+	})
+}
+
+func tokenFromRequest(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); h != "" {
+		if token, ok := strings.CutPrefix(h, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	return r.URL.Query().Get("access_token")
+}
+
+// GenerateToken - Token acak baru (plaintext, hanya ditampilkan sekali)
+func GenerateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "mtl_" + hex.EncodeToString(buf), nil
+}
+
+// Prefix token sesi dashboard: access token (dicari di auth_sessions) dan refresh token
+const (
+	sessionTokenPrefix = "mts_"
+	refreshTokenPrefix = "mtr_"
+)
+
+// GenerateSessionTokens - Pasangan access & refresh token sesi baru (plaintext)
+func GenerateSessionTokens() (access, refresh string, err error) {
+	buf := make([]byte, 64)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	return sessionTokenPrefix + hex.EncodeToString(buf[:32]), refreshTokenPrefix + hex.EncodeToString(buf[32:]), nil
+}
+
+// HashToken - Hash SHA-256 token untuk disimpan / dicari di database
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
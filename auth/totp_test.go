@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret - Secret SHA1 dari RFC 6238 Appendix B ("12345678901234567890") dalam base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPRFC6238Vectors(t *testing.T) {
+	// Kode 8 digit RFC dipotong ke 6 digit terakhir (totpDigits)
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	key := []byte("12345678901234567890")
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			step := tt.unix / totpPeriod
+			if got := totpCode(key, step); got != tt.want {
+				t.Errorf("totpCode(step %d) = %s, want %s", step, got, tt.want)
+			}
+			gotStep, ok := VerifyTOTP(rfcSecret, tt.want, time.Unix(tt.unix, 0))
+			if !ok || gotStep != step {
+				t.Errorf("VerifyTOTP(%s) = %d, %v, want %d, true", tt.want, gotStep, ok, step)
+			}
+		})
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfcSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1234567890, 0) // awal window step 41152263
+	current := now.Unix() / totpPeriod
+	codeAt := func(step int64) string { return totpCode(key, step) }
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		now      time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", secret: rfcSecret, code: codeAt(current), now: now, wantStep: current, wantOK: true},
+		{name: "previous step within skew", secret: rfcSecret, code: codeAt(current - 1), now: now, wantStep: current - 1, wantOK: true},
+		{name: "next step within skew", secret: rfcSecret, code: codeAt(current + 1), now: now, wantStep: current + 1, wantOK: true},
+		{name: "two steps old", secret: rfcSecret, code: codeAt(current - 2), now: now, wantOK: false},
+		{name: "two steps ahead", secret: rfcSecret, code: codeAt(current + 2), now: now, wantOK: false},
+		// Kode yang sama sepanjang window menghasilkan step yang sama, sehingga UseTOTPStep
+		// bisa menolak pemakaian ulang
+		{name: "replay later in the same window", secret: rfcSecret, code: codeAt(current), now: now.Add(29 * time.Second), wantStep: current, wantOK: true},
+		{name: "replay in the next window keeps the old step", secret: rfcSecret, code: codeAt(current), now: now.Add(totpPeriod * time.Second), wantStep: current, wantOK: true},
+		{name: "lowercase secret with spaces", secret: " gezdgnbvgy3tqojqgezdgnbvgy3tqojq ", code: codeAt(current), now: now, wantStep: current, wantOK: true},
+		{name: "wrong code", secret: rfcSecret, code: "000000", now: now, wantOK: false},
+		{name: "too short", secret: rfcSecret, code: codeAt(current)[:5], now: now, wantOK: false},
+		{name: "too long", secret: rfcSecret, code: codeAt(current) + "0", now: now, wantOK: false},
+		{name: "empty code", secret: rfcSecret, code: "", now: now, wantOK: false},
+		{name: "invalid secret", secret: "not-base32!", code: codeAt(current), now: now, wantOK: false},
+		{name: "empty secret", secret: "", code: codeAt(current), now: now, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := VerifyTOTP(tt.secret, tt.code, tt.now)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("VerifyTOTP() = %d, %v, want %d, %v", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -15,6 +17,199 @@ type Config struct {
 	MikrotikUser     string
 	MikrotikPassword string
 	DatabaseDSN      string
+
+	// Syslog receiver (kosong = nonaktif) dan alamat yang diarahkan ke router
+	SyslogAddr          string
+	SyslogAdvertiseHost string
+
+	// NetFlow/IPFIX collector (kosong = nonaktif) dan alamat tujuan export router
+	NetFlowAddr          string
+	NetFlowAdvertiseHost string
+
+	// Traffic history & deteksi anomali
+	HistoryRetentionDays int
+	AnomalyInterval      time.Duration
+	AnomalyFactor        float64
+	AnomalyAlpha         float64
+	AnomalyBaselineDays  int
+	AnomalyMinDays       int
+
+	// Top-talkers (torch pada interface WAN)
+	TopTalkersInterval time.Duration
+	TopTalkersDuration time.Duration
+	TopTalkersLimit    int
+
+	// Sampling counter simple queue untuk usage per pelanggan
+	QueueUsageInterval time.Duration
+
+	// Ping antar router (monitored paths)
+	MeshInterval time.Duration
+
+	// Alert laju error interface (rx/tx error + FCS per detik), interval 0 = nonaktif
+	InterfaceErrorInterval time.Duration
+	InterfaceErrorRate     float64
+
+	// Sampling kualitas link wireless (signal, rate, CCQ) untuk history (0 = nonaktif)
+	WirelessSampleInterval time.Duration
+
+	// Ambang /api/connections/healthz: persen router online minimum dan umur
+	// health check sukses tertua sebelum dilaporkan 503
+	HealthzMinOnlinePct float64
+	HealthzMaxPingAge   time.Duration
+
+	// Ambang level degraded: latency health check dan persen check gagal (0 = tidak dipakai)
+	HealthDegradedLatency time.Duration
+	HealthDegradedLossPct float64
+
+	// Poll neighbor OSPF / peer BGP untuk event putus-pulih (0 = nonaktif)
+	RoutingInterval time.Duration
+
+	// Watchdog route statik primary/backup (0 = nonaktif)
+	RouteFailoverInterval time.Duration
+
+	// Poll /ip/neighbor untuk peta topologi jaringan (0 = nonaktif)
+	TopologyInterval time.Duration
+
+	// Jadwal bandwidth per plan/queue: interval cek (0 = nonaktif) dan zona waktu jam jadwal
+	// (nama IANA mis. Asia/Jakarta, kosong = zona waktu lokal server)
+	BandwidthScheduleInterval time.Duration
+	BandwidthScheduleTimezone string
+
+	// Jadwal task cron (backup / reboot / sync queue): interval cek (0 = nonaktif) dan zona waktu
+	// ekspresi cron (kosong = zona waktu lokal server)
+	TaskScheduleInterval time.Duration
+	TaskScheduleTimezone string
+
+	// Webhook tujuan notifikasi event (JSON POST, kosong = nonaktif)
+	NotifyWebhookURL string
+
+	// SMTP untuk channel notifikasi alert tipe email (host kosong = email nonaktif)
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// Pengiriman webhook provisioning (billing): interval worker dan batas retry per delivery
+	WebhookInterval    time.Duration
+	WebhookMaxAttempts int
+
+	// Riwayat sesi PPP dari polling /ppp/active (0 = nonaktif) dan umur simpan (0 = selamanya)
+	PPPSessionInterval      time.Duration
+	PPPSessionRetentionDays int
+
+	// Riwayat lease DHCP (IP <-> MAC) untuk lookup IP historis (0 = nonaktif / simpan selamanya)
+	LeaseHistoryInterval      time.Duration
+	LeaseHistoryRetentionDays int
+
+	// Transfer file router: port FTP (file besar / biner) dan batas ukuran upload
+	FileFTPPort     int
+	FileUploadMaxMB int
+
+	// Kunci enkripsi backup / export router di DB (kosong = fitur backup nonaktif).
+	// Mengganti kunci membuat backup lama tidak bisa dibuka.
+	BackupEncryptionKey string
+
+	// Suspend customer: strategi default (disable_secret / address_list / throttle),
+	// address-list blokir, dan max-limit queue saat throttle
+	SuspendStrategy      string
+	SuspendAddressList   string
+	SuspendThrottleLimit string
+
+	// Naming policy (regex, kosong = bebas) untuk nama queue, nama PPP secret dan comment
+	// firewall yang dibuat lewat layer; objek lama yang tidak sesuai ada di /api/naming/report
+	NamingPolicyQueue           string
+	NamingPolicyPPPSecret       string
+	NamingPolicyFirewallComment string
+
+	// Job fleet-wide: jumlah router yang dieksekusi paralel per job (default request)
+	JobConcurrency int
+
+	// Retry read RouterOS untuk error transient (timeout, koneksi reset, router sibuk);
+	// attempts termasuk percobaan pertama, 1 = tanpa retry
+	RetryAttempts   int
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+
+	// Listen stream bersamaan per router (0 = tanpa batas) dan lama antre slot (0 = langsung ditolak)
+	RouterOSMaxStreams         int
+	RouterOSStreamQueueTimeout time.Duration
+
+	// Prefix yang dikecualikan dari audit konflik IP (comma-separated CIDR)
+	IPConflictIgnore string
+
+	// Refresh database vendor MAC (URL / path oui.txt IEEE, kosong = embedded saja)
+	OUISource          string
+	OUIRefreshInterval time.Duration
+
+	// Sinkronisasi threat feed ke address-list (interval cek jatuh tempo, batas entry per feed)
+	ThreatFeedInterval   time.Duration
+	ThreatFeedMaxEntries int
+
+	// Beacon heartbeat router: URL layer yang dijangkau router (/tool/fetch), interval klasifikasi
+	// (0 = tanpa event) dan umur heartbeat terakhir sebelum router dianggap mati
+	BeaconBaseURL    string
+	BeaconInterval   time.Duration
+	BeaconStaleAfter time.Duration
+
+	// Pencatatan availability router dan laporan PDF bulanan otomatis
+	AvailabilityInterval time.Duration
+	ReportAutoGenerate   bool
+
+	// Bahasa default pesan API (id/en) jika Accept-Language tidak dikirim
+	APILang string
+
+	// Autentikasi token API (nonaktif = semua endpoint terbuka seperti sebelumnya).
+	// AuthAdminToken berlaku sebagai admin untuk bootstrap user & token pertama.
+	AuthEnabled    bool
+	AuthAdminToken string
+
+	// Sesi dashboard: umur access token dan refresh token (dirotasi setiap refresh)
+	AuthAccessTokenTTL  time.Duration
+	AuthRefreshTokenTTL time.Duration
+
+	// 2FA TOTP: role yang wajib enroll (comma-separated, kosong = opsional untuk semua)
+	// dan nama issuer di aplikasi authenticator
+	AuthTOTPRequiredRoles string
+	AuthTOTPIssuer        string
+
+	// Allow-list IP / CIDR per listener (comma-separated, kosong = semua IP), proxy tepercaya
+	// yang X-Forwarded-For-nya dipakai sebagai IP klien, dan allow-list token bootstrap
+	APIAllowedCIDRs            string
+	WSAllowedCIDRs             string
+	APITrustedProxies          string
+	AuthAdminTokenAllowedCIDRs string
+
+	// Laporan panic ke Sentry (kosong = hanya log)
+	SentryDSN         string
+	SentryEnvironment string
+
+	// Export trace OpenTelemetry via OTLP/HTTP (kosong = tracing nonaktif), nama service dan
+	// porsi trace baru yang di-sample (0..1)
+	OTelEndpoint    string
+	OTelServiceName string
+	OTelSampleRatio float64
+
+	// Masa berlaku token konfirmasi aksi destruktif (hapus router, job fleet)
+	ConfirmTokenTTL time.Duration
+
+	// Sumber koneksi keluar ke router (kosong = dipilih kernel); bisa di-override per router
+	RouterOSSourceAddress string
+	RouterOSBindInterface string
+
+	// Hostname DNS router: family yang didahulukan (ipv4/ipv6) dan interval resolve ulang
+	RouterOSPreferFamily    string
+	RouterOSResolveInterval time.Duration
+
+	// Interval tes path primary untuk koneksi lewat alamat tambahan (failback), 0 = nonaktif
+	RouterOSFailbackInterval time.Duration
+
+	// Poll concentrator untuk router dengan tunnel balik (alamat tunnel -> hostname), 0 = nonaktif
+	TunnelWatchInterval time.Duration
+
+	// Lease leader election untuk deploy multi instance: worker background hanya berjalan di
+	// instance pemegang lease (0 = nonaktif, satu instance)
+	LeaderLeaseTTL time.Duration
 }
 
 func LoadConfig() *Config {
@@ -38,6 +233,141 @@ func LoadConfig() *Config {
 		MikrotikUser:     getEnv("MIKROTIK_USER", "admin"),
 		MikrotikPassword: getEnv("MIKROTIK_PASS", "password"),
 		DatabaseDSN:      dsn,
+
+		SyslogAddr:          getEnv("SYSLOG_ADDR", ""),
+		SyslogAdvertiseHost: getEnv("SYSLOG_ADVERTISE_HOST", ""),
+
+		NetFlowAddr:          getEnv("NETFLOW_ADDR", ""),
+		NetFlowAdvertiseHost: getEnv("NETFLOW_ADVERTISE_HOST", getEnv("SYSLOG_ADVERTISE_HOST", "")),
+
+		HistoryRetentionDays: getEnvInt("HISTORY_RETENTION_DAYS", 30),
+		AnomalyInterval:      getEnvDuration("ANOMALY_INTERVAL", 5*time.Minute),
+		AnomalyFactor:        getEnvFloat("ANOMALY_FACTOR", 3),
+		AnomalyAlpha:         getEnvFloat("ANOMALY_ALPHA", 0.3),
+		AnomalyBaselineDays:  getEnvInt("ANOMALY_BASELINE_DAYS", 14),
+		AnomalyMinDays:       getEnvInt("ANOMALY_MIN_DAYS", 3),
+
+		TopTalkersInterval: getEnvDuration("TOP_TALKERS_INTERVAL", 5*time.Minute),
+		TopTalkersDuration: getEnvDuration("TOP_TALKERS_DURATION", 10*time.Second),
+		TopTalkersLimit:    getEnvInt("TOP_TALKERS_LIMIT", 20),
+
+		QueueUsageInterval: getEnvDuration("QUEUE_USAGE_INTERVAL", 5*time.Minute),
+
+		MeshInterval: getEnvDuration("MESH_INTERVAL", time.Minute),
+
+		InterfaceErrorInterval: getEnvDuration("INTERFACE_ERROR_INTERVAL", time.Minute),
+		InterfaceErrorRate:     getEnvFloat("INTERFACE_ERROR_RATE", 1),
+
+		WirelessSampleInterval: getEnvDuration("WIRELESS_SAMPLE_INTERVAL", time.Minute),
+
+		HealthzMinOnlinePct: getEnvFloat("HEALTHZ_MIN_ONLINE_PCT", 80),
+		HealthzMaxPingAge:   getEnvDuration("HEALTHZ_MAX_PING_AGE", 2*time.Minute),
+
+		HealthDegradedLatency: getEnvDuration("HEALTH_DEGRADED_LATENCY", time.Second),
+		HealthDegradedLossPct: getEnvFloat("HEALTH_DEGRADED_LOSS_PCT", 20),
+
+		RoutingInterval: getEnvDuration("ROUTING_INTERVAL", time.Minute),
+
+		RouteFailoverInterval: getEnvDuration("ROUTE_FAILOVER_INTERVAL", 30*time.Second),
+
+		TopologyInterval: getEnvDuration("TOPOLOGY_INTERVAL", 5*time.Minute),
+
+		BandwidthScheduleInterval: getEnvDuration("BANDWIDTH_SCHEDULE_INTERVAL", time.Minute),
+		BandwidthScheduleTimezone: getEnv("BANDWIDTH_SCHEDULE_TIMEZONE", ""),
+
+		TaskScheduleInterval: getEnvDuration("TASK_SCHEDULE_INTERVAL", time.Minute),
+		TaskScheduleTimezone: getEnv("TASK_SCHEDULE_TIMEZONE", ""),
+
+		NotifyWebhookURL: getEnv("NOTIFY_WEBHOOK_URL", ""),
+
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnvInt("SMTP_PORT", 587),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", "mikrotik-layer@localhost"),
+
+		WebhookInterval:    getEnvDuration("WEBHOOK_INTERVAL", 10*time.Second),
+		WebhookMaxAttempts: getEnvInt("WEBHOOK_MAX_ATTEMPTS", 10),
+
+		PPPSessionInterval:      getEnvDuration("PPP_SESSION_INTERVAL", time.Minute),
+		PPPSessionRetentionDays: getEnvInt("PPP_SESSION_RETENTION_DAYS", 365),
+
+		LeaseHistoryInterval:      getEnvDuration("LEASE_HISTORY_INTERVAL", 5*time.Minute),
+		LeaseHistoryRetentionDays: getEnvInt("LEASE_HISTORY_RETENTION_DAYS", 365),
+
+		FileFTPPort:     getEnvInt("FILE_FTP_PORT", 21),
+		FileUploadMaxMB: getEnvInt("FILE_UPLOAD_MAX_MB", 64),
+
+		BackupEncryptionKey: getEnv("BACKUP_ENCRYPTION_KEY", ""),
+
+		SuspendStrategy:      getEnv("SUSPEND_STRATEGY", "disable_secret"),
+		SuspendAddressList:   getEnv("SUSPEND_ADDRESS_LIST", "suspended"),
+		SuspendThrottleLimit: getEnv("SUSPEND_THROTTLE_LIMIT", "64k/64k"),
+
+		NamingPolicyQueue:           getEnv("NAMING_POLICY_QUEUE", ""),
+		NamingPolicyPPPSecret:       getEnv("NAMING_POLICY_PPP_SECRET", ""),
+		NamingPolicyFirewallComment: getEnv("NAMING_POLICY_FIREWALL_COMMENT", ""),
+
+		JobConcurrency: getEnvInt("JOB_CONCURRENCY", 10),
+
+		RetryAttempts:   getEnvInt("ROUTEROS_RETRY_ATTEMPTS", 3),
+		RetryBackoff:    getEnvDuration("ROUTEROS_RETRY_BACKOFF", 500*time.Millisecond),
+		RetryMaxBackoff: getEnvDuration("ROUTEROS_RETRY_MAX_BACKOFF", 5*time.Second),
+
+		RouterOSMaxStreams:         getEnvInt("ROUTEROS_MAX_STREAMS", 0),
+		RouterOSStreamQueueTimeout: getEnvDuration("ROUTEROS_STREAM_QUEUE_TIMEOUT", 0),
+
+		IPConflictIgnore: getEnv("IP_CONFLICT_IGNORE", ""),
+
+		OUISource:          getEnv("OUI_SOURCE", ""),
+		OUIRefreshInterval: getEnvDuration("OUI_REFRESH_INTERVAL", 7*24*time.Hour),
+
+		ThreatFeedInterval:   getEnvDuration("THREAT_FEED_INTERVAL", time.Minute),
+		ThreatFeedMaxEntries: getEnvInt("THREAT_FEED_MAX_ENTRIES", 50000),
+
+		BeaconBaseURL:    getEnv("BEACON_BASE_URL", ""),
+		BeaconInterval:   getEnvDuration("BEACON_INTERVAL", time.Minute),
+		BeaconStaleAfter: getEnvDuration("BEACON_STALE_AFTER", 3*time.Minute),
+
+		AvailabilityInterval: getEnvDuration("AVAILABILITY_INTERVAL", time.Minute),
+		ReportAutoGenerate:   getEnvBool("REPORT_AUTO_GENERATE", true),
+
+		APILang: getEnv("API_LANG", "id"),
+
+		AuthEnabled:    getEnvBool("AUTH_ENABLED", false),
+		AuthAdminToken: getEnv("AUTH_ADMIN_TOKEN", ""),
+
+		AuthAccessTokenTTL:  getEnvDuration("AUTH_ACCESS_TOKEN_TTL", 15*time.Minute),
+		AuthRefreshTokenTTL: getEnvDuration("AUTH_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+
+		AuthTOTPRequiredRoles: getEnv("AUTH_TOTP_REQUIRED_ROLES", "admin"),
+		AuthTOTPIssuer:        getEnv("AUTH_TOTP_ISSUER", "Mikrotik-Layer"),
+
+		APIAllowedCIDRs:            getEnv("API_ALLOWED_CIDRS", ""),
+		WSAllowedCIDRs:             getEnv("WS_ALLOWED_CIDRS", ""),
+		APITrustedProxies:          getEnv("API_TRUSTED_PROXIES", ""),
+		AuthAdminTokenAllowedCIDRs: getEnv("AUTH_ADMIN_TOKEN_ALLOWED_CIDRS", ""),
+
+		SentryDSN:         getEnv("SENTRY_DSN", ""),
+		SentryEnvironment: getEnv("SENTRY_ENVIRONMENT", "production"),
+
+		OTelEndpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		OTelServiceName: getEnv("OTEL_SERVICE_NAME", "mikrotik-layer"),
+		OTelSampleRatio: getEnvFloat("OTEL_TRACES_SAMPLER_RATIO", 1),
+
+		ConfirmTokenTTL: getEnvDuration("CONFIRM_TOKEN_TTL", 2*time.Minute),
+
+		RouterOSSourceAddress: getEnv("ROUTEROS_SOURCE_ADDRESS", ""),
+		RouterOSBindInterface: getEnv("ROUTEROS_BIND_INTERFACE", ""),
+
+		RouterOSPreferFamily:    getEnv("ROUTEROS_PREFER_FAMILY", ""),
+		RouterOSResolveInterval: getEnvDuration("ROUTEROS_RESOLVE_INTERVAL", 5*time.Minute),
+
+		RouterOSFailbackInterval: getEnvDuration("ROUTEROS_FAILBACK_INTERVAL", 5*time.Minute),
+
+		TunnelWatchInterval: getEnvDuration("TUNNEL_WATCH_INTERVAL", time.Minute),
+
+		LeaderLeaseTTL: getEnvDuration("LEADER_LEASE_TTL", 0),
 	}
 }
 
@@ -46,4 +376,32 @@ func getEnv(key, defaultVal string) string {
 		return val
 	}
 	return defaultVal
-}
\ No newline at end of file
+}
+
+func getEnvInt(key string, defaultVal int) int {
+	if val, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvFloat(key string, defaultVal float64) float64 {
+	if val, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	if val, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvBool(key string, defaultVal bool) bool {
+	if val, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return val
+	}
+	return defaultVal
+}
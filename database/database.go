@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 
+	"Mikrotik-Layer/tracing"
+
 	_ "github.com/go-sql-driver/mysql"
 )
 
@@ -13,7 +15,13 @@ type Database struct {
 }
 
 func NewDatabase(dsn string) (*Database, error) {
-	db, err := sql.Open("mysql", dsn)
+	// Query di dalam trace aktif dicatat sebagai span jika tracing dikonfigurasi
+	driverName := "mysql"
+	if tracing.Enabled() {
+		driverName = tracedDriverName
+	}
+
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("error opening database: %w", err)
 	}
@@ -2,7 +2,7 @@ CREATE TABLE IF NOT EXISTS routers (
     id INT AUTO_INCREMENT PRIMARY KEY,
     uuid VARCHAR(36) NOT NULL UNIQUE DEFAULT (UUID()),
     name VARCHAR(100) NOT NULL,
-    hostname VARCHAR(45) NOT NULL,
+    hostname VARCHAR(255) NOT NULL,
     username VARCHAR(50) NOT NULL,
     password VARCHAR(255) NOT NULL,
     keepalive BOOLEAN DEFAULT TRUE,
@@ -10,18 +10,649 @@ CREATE TABLE IF NOT EXISTS routers (
     port INT DEFAULT 8728,
     location VARCHAR(100),
     description TEXT,
+    wan_interfaces VARCHAR(255),
+    tags VARCHAR(255),
+    contact_name VARCHAR(100),
+    contact_phone VARCHAR(30),
+    circuit_id VARCHAR(100),
+    monitoring_url VARCHAR(255),
+    notes JSON,
+    source_address VARCHAR(45),
+    bind_interface VARCHAR(64),
+    jump_type VARCHAR(10),
+    jump_address VARCHAR(255),
+    jump_username VARCHAR(100),
+    jump_password VARCHAR(255),
+    jump_private_key TEXT,
+    jump_host_key VARCHAR(1000),
+    tunnel_concentrator_id INT NULL,
+    tunnel_type VARCHAR(10),
+    tunnel_peer VARCHAR(255),
     is_active BOOLEAN DEFAULT TRUE,
+    is_virtual BOOLEAN DEFAULT FALSE,
+    auto_connect BOOLEAN DEFAULT TRUE,
     last_seen TIMESTAMP NULL,
     status VARCHAR(20) DEFAULT 'offline',
+    active_address VARCHAR(255),
     version VARCHAR(50),
     uptime VARCHAR(50),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     INDEX idx_hostname (hostname),
     INDEX idx_status (status),
-    INDEX idx_is_active (is_active)
+    INDEX idx_is_active (is_active),
+    INDEX idx_circuit_id (circuit_id),
+    CONSTRAINT fk_routers_tunnel_concentrator FOREIGN KEY (tunnel_concentrator_id) REFERENCES routers(id) ON DELETE SET NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
 INSERT INTO routers (name, username, password, hostname, port) VALUES
 ('Aeng Panas', 'fandi1', '001', '103.139.193.128', 1012),
-('Test', 'admin', 'r00t', '103.139.193.128', 1251);
\ No newline at end of file
+('Test', 'admin', 'r00t', '103.139.193.128', 1251);
+CREATE TABLE IF NOT EXISTS events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NULL,
+    type VARCHAR(50) NOT NULL,
+    severity VARCHAR(20) NOT NULL DEFAULT 'info',
+    source VARCHAR(100),
+    message TEXT NOT NULL,
+    data JSON,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_events_router (router_id, created_at),
+    INDEX idx_events_type (type, created_at),
+    CONSTRAINT fk_events_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS traffic_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    interface VARCHAR(100) NOT NULL,
+    rx_bps BIGINT NOT NULL DEFAULT 0,
+    tx_bps BIGINT NOT NULL DEFAULT 0,
+    rx_bytes BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    tx_bytes BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    rx_errors BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    tx_errors BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    rx_drops BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    tx_drops BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    fcs_errors BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    sampled_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_traffic_history_iface (router_id, interface, sampled_at),
+    INDEX idx_traffic_history_time (sampled_at),
+    CONSTRAINT fk_traffic_history_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Alamat management tambahan per router (out-of-band dsb), dicoba setelah hostname sesuai priority
+CREATE TABLE IF NOT EXISTS router_addresses (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    address VARCHAR(255) NOT NULL,
+    label VARCHAR(50) NOT NULL DEFAULT 'secondary',
+    priority INT NOT NULL DEFAULT 10,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uniq_router_address (router_id, address),
+    CONSTRAINT fk_router_addresses_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Lease leader election antar instance layer (worker background hanya di pemegang lease)
+CREATE TABLE IF NOT EXISTS leader_leases (
+    name VARCHAR(64) PRIMARY KEY,
+    holder VARCHAR(150) NOT NULL,
+    expires_at TIMESTAMP(3) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Sampler traffic yang aktif, dimuat ulang saat layer start supaya sampling lanjut setelah restart
+CREATE TABLE IF NOT EXISTS traffic_samplers (
+    router_id INT NOT NULL,
+    interface VARCHAR(100) NOT NULL,
+    interval_seconds INT NOT NULL,
+    monitored BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (router_id, interface),
+    CONSTRAINT fk_traffic_samplers_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS wireless_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    interface VARCHAR(100) NOT NULL,
+    signal_strength INT NOT NULL DEFAULT 0,
+    signal_to_noise INT NOT NULL DEFAULT 0,
+    tx_rate_mbps DOUBLE NOT NULL DEFAULT 0,
+    rx_rate_mbps DOUBLE NOT NULL DEFAULT 0,
+    tx_ccq INT NOT NULL DEFAULT 0,
+    rx_ccq INT NOT NULL DEFAULT 0,
+    sampled_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_wireless_history_iface (router_id, interface, sampled_at),
+    INDEX idx_wireless_history_time (sampled_at),
+    CONSTRAINT fk_wireless_history_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS top_talkers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    interface VARCHAR(100) NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    direction VARCHAR(3) NOT NULL,
+    address VARCHAR(45) NOT NULL,
+    rx_bps_sum DOUBLE NOT NULL DEFAULT 0,
+    tx_bps_sum DOUBLE NOT NULL DEFAULT 0,
+    samples INT NOT NULL DEFAULT 0,
+    UNIQUE KEY uq_top_talkers_bucket (router_id, interface, bucket_start, direction, address),
+    INDEX idx_top_talkers_time (bucket_start),
+    CONSTRAINT fk_top_talkers_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS flow_records (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    src_address VARCHAR(45) NOT NULL,
+    dst_address VARCHAR(45) NOT NULL,
+    protocol TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    src_port SMALLINT UNSIGNED NOT NULL DEFAULT 0,
+    dst_port SMALLINT UNSIGNED NOT NULL DEFAULT 0,
+    bytes BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    packets BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    flows INT NOT NULL DEFAULT 0,
+    UNIQUE KEY uq_flow_records_bucket (router_id, bucket_start, src_address, dst_address, protocol, src_port, dst_port),
+    INDEX idx_flow_records_src (router_id, src_address, bucket_start),
+    INDEX idx_flow_records_dst (router_id, dst_address, bucket_start),
+    INDEX idx_flow_records_time (bucket_start),
+    CONSTRAINT fk_flow_records_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS queue_usage (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    queue_name VARCHAR(100) NOT NULL,
+    period VARCHAR(5) NOT NULL,
+    period_start DATE NOT NULL,
+    upload_bytes BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    download_bytes BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_queue_usage_period (router_id, queue_name, period, period_start),
+    INDEX idx_queue_usage_period (router_id, period, period_start),
+    CONSTRAINT fk_queue_usage_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS queue_quotas (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    queue_name VARCHAR(100) NOT NULL,
+    monthly_quota_bytes BIGINT UNSIGNED NOT NULL,
+    action VARCHAR(20) NOT NULL DEFAULT 'alert',
+    throttle_limit VARCHAR(50),
+    address_list VARCHAR(100),
+    alerted_period DATE NULL,
+    enforced_period DATE NULL,
+    original_max_limit VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_queue_quotas (router_id, queue_name),
+    CONSTRAINT fk_queue_quotas_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    actor VARCHAR(100) NOT NULL,
+    action VARCHAR(100) NOT NULL,
+    router_id INT NULL,
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL DEFAULT TRUE,
+    error TEXT,
+    details JSON,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_audit_router (router_id, created_at),
+    INDEX idx_audit_action (action, created_at),
+    CONSTRAINT fk_audit_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS plans (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    rate_limit VARCHAR(50) NOT NULL,
+    burst_limit VARCHAR(50),
+    burst_threshold VARCHAR(50),
+    burst_time VARCHAR(20),
+    quota_bytes BIGINT UNSIGNED NULL,
+    ppp_profile VARCHAR(100),
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS customers (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    plan_id INT NULL,
+    queue_name VARCHAR(100),
+    ppp_secret VARCHAR(100),
+    address VARCHAR(45),
+    phone VARCHAR(30),
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    notes TEXT,
+    suspend_strategy VARCHAR(20) NULL,
+    suspend_original VARCHAR(50) NULL,
+    suspended_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_customers_queue (router_id, queue_name),
+    UNIQUE KEY uq_customers_secret (router_id, ppp_secret),
+    INDEX idx_customers_plan (plan_id),
+    CONSTRAINT fk_customers_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_customers_plan FOREIGN KEY (plan_id) REFERENCES plans(id) ON DELETE RESTRICT
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS monitored_paths (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    source_router_id INT NOT NULL,
+    target_router_id INT NULL,
+    target_address VARCHAR(255) NULL,
+    ping_count INT NOT NULL DEFAULT 5,
+    latency_threshold_ms DOUBLE NOT NULL DEFAULT 0,
+    loss_threshold_pct DOUBLE NOT NULL DEFAULT 20,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    state VARCHAR(20) NOT NULL DEFAULT 'unknown',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_paths_source FOREIGN KEY (source_router_id) REFERENCES routers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_paths_target FOREIGN KEY (target_router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS path_samples (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    path_id INT NOT NULL,
+    sent INT NOT NULL,
+    received INT NOT NULL,
+    loss_pct DOUBLE NOT NULL,
+    min_rtt_ms DOUBLE NOT NULL,
+    avg_rtt_ms DOUBLE NOT NULL,
+    max_rtt_ms DOUBLE NOT NULL,
+    sampled_at TIMESTAMP NOT NULL,
+    INDEX idx_path_samples (path_id, sampled_at),
+    INDEX idx_path_samples_time (sampled_at),
+    CONSTRAINT fk_path_samples_path FOREIGN KEY (path_id) REFERENCES monitored_paths(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS router_availability (
+    router_id INT NOT NULL,
+    day DATE NOT NULL,
+    checks INT NOT NULL DEFAULT 0,
+    up_checks INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (router_id, day),
+    CONSTRAINT fk_availability_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS queue_samples (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    queue_name VARCHAR(100) NOT NULL,
+    upload_bps BIGINT UNSIGNED NOT NULL,
+    download_bps BIGINT UNSIGNED NOT NULL,
+    sampled_at TIMESTAMP NOT NULL,
+    INDEX idx_queue_samples (router_id, queue_name, sampled_at),
+    INDEX idx_queue_samples_time (sampled_at),
+    CONSTRAINT fk_queue_samples_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS reports (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    scope VARCHAR(20) NOT NULL,
+    target_id INT NOT NULL,
+    target_name VARCHAR(100) NOT NULL,
+    period DATE NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error TEXT NULL,
+    size_bytes INT NOT NULL DEFAULT 0,
+    content MEDIUMBLOB NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_reports_target (scope, target_id, period),
+    INDEX idx_reports_period (period)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS router_status_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    from_status VARCHAR(20) NULL,
+    status VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMP NOT NULL,
+    INDEX idx_status_history (router_id, changed_at),
+    CONSTRAINT fk_status_history_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NULL,
+    type VARCHAR(50) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    message TEXT NOT NULL,
+    data JSON NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    occurrences INT NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMP NOT NULL,
+    last_seen_at TIMESTAMP NOT NULL,
+    acknowledged_by VARCHAR(100) NULL,
+    acknowledged_at TIMESTAMP NULL,
+    resolved_by VARCHAR(100) NULL,
+    resolved_at TIMESTAMP NULL,
+    note TEXT NULL,
+    INDEX idx_alerts_status (status, severity),
+    INDEX idx_alerts_open (router_id, type, status),
+    CONSTRAINT fk_alerts_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS resellers (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    contact_name VARCHAR(100) NULL,
+    contact_phone VARCHAR(30) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS reseller_routers (
+    router_id INT PRIMARY KEY,
+    reseller_id INT NOT NULL,
+    INDEX idx_reseller_routers (reseller_id),
+    CONSTRAINT fk_reseller_routers_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_reseller_routers_reseller FOREIGN KEY (reseller_id) REFERENCES resellers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS users (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    username VARCHAR(50) NOT NULL UNIQUE,
+    role VARCHAR(20) NOT NULL,
+    reseller_id INT NULL,
+    totp_secret VARCHAR(64) NULL,
+    totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    totp_last_step BIGINT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_users_reseller FOREIGN KEY (reseller_id) REFERENCES resellers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    allowed_cidrs VARCHAR(1000) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP NULL,
+    CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    allowed_cidrs VARCHAR(1000) NULL,
+    access_hash CHAR(64) NOT NULL UNIQUE,
+    refresh_hash CHAR(64) NOT NULL UNIQUE,
+    previous_refresh_hash CHAR(64) NULL,
+    access_expires_at TIMESTAMP NOT NULL,
+    refresh_expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    INDEX idx_auth_sessions_user (user_id, revoked_at),
+    INDEX idx_auth_sessions_previous (previous_refresh_hash),
+    CONSTRAINT fk_auth_sessions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS user_scopes (
+    user_id INT NOT NULL,
+    router_id INT NOT NULL,
+    interfaces VARCHAR(1000) NULL,
+    PRIMARY KEY (user_id, router_id),
+    CONSTRAINT fk_user_scopes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_scopes_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS route_failovers (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    router_id INT NOT NULL,
+    dst_address VARCHAR(50) NOT NULL,
+    primary_gateway VARCHAR(100) NOT NULL,
+    backup_gateway VARCHAR(100) NOT NULL,
+    notify BOOLEAN NOT NULL DEFAULT FALSE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    state VARCHAR(20) NOT NULL DEFAULT 'unknown',
+    active_gateway VARCHAR(100) NULL,
+    last_change_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_route_failovers_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    events VARCHAR(500) NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id INT NOT NULL,
+    event_id CHAR(32) NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    payload JSON NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    response_code INT NULL,
+    last_error VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP NULL,
+    INDEX idx_webhook_deliveries_due (status, next_attempt_at),
+    INDEX idx_webhook_deliveries_webhook (webhook_id, created_at),
+    CONSTRAINT fk_webhook_deliveries_webhook FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS ppp_sessions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    session_ref VARCHAR(20) NOT NULL,
+    username VARCHAR(100) NOT NULL,
+    service VARCHAR(20) NOT NULL DEFAULT '',
+    caller_id VARCHAR(100) NOT NULL DEFAULT '',
+    address VARCHAR(45) NOT NULL DEFAULT '',
+    bytes_in BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    bytes_out BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP NULL,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_ppp_sessions_open (router_id, ended_at),
+    INDEX idx_ppp_sessions_user (username, started_at),
+    INDEX idx_ppp_sessions_address (address, started_at),
+    CONSTRAINT fk_ppp_sessions_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS dhcp_lease_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    address VARCHAR(45) NOT NULL,
+    mac_address VARCHAR(17) NOT NULL,
+    host_name VARCHAR(255) NOT NULL DEFAULT '',
+    server VARCHAR(100) NOT NULL DEFAULT '',
+    first_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP NULL,
+    INDEX idx_dhcp_lease_history_open (router_id, ended_at),
+    INDEX idx_dhcp_lease_history_address (address, first_seen_at),
+    CONSTRAINT fk_dhcp_lease_history_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    action VARCHAR(20) NOT NULL,
+    command TEXT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    created_by VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP NULL,
+    INDEX idx_jobs_status (status, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS job_tasks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    job_id BIGINT NOT NULL,
+    router_id INT NOT NULL,
+    router_name VARCHAR(100) NOT NULL DEFAULT '',
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    output MEDIUMTEXT NULL,
+    error VARCHAR(500) NULL,
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    INDEX idx_job_tasks_job (job_id, status),
+    CONSTRAINT fk_job_tasks_job FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS router_backups (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    size_bytes INT NOT NULL DEFAULT 0,
+    sha256 CHAR(64) NOT NULL,
+    key_id CHAR(16) NOT NULL,
+    content MEDIUMBLOB NOT NULL,
+    created_by VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_router_backups_router (router_id, created_at),
+    CONSTRAINT fk_router_backups_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS bandwidth_schedules (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    plan_id INT NULL,
+    router_id INT NULL,
+    queue_name VARCHAR(100) NULL,
+    rate_limit VARCHAR(50) NOT NULL,
+    start_time CHAR(5) NOT NULL,
+    end_time CHAR(5) NOT NULL,
+    days VARCHAR(50) NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    applied_limit VARCHAR(50) NULL,
+    original_limit VARCHAR(50) NULL,
+    last_applied_at TIMESTAMP NULL,
+    last_error VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT fk_bandwidth_schedules_plan FOREIGN KEY (plan_id) REFERENCES plans(id) ON DELETE CASCADE,
+    CONSTRAINT fk_bandwidth_schedules_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS topology_links (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    router_id INT NOT NULL,
+    interface VARCHAR(100) NOT NULL,
+    neighbor_mac VARCHAR(17) NOT NULL DEFAULT '',
+    neighbor_identity VARCHAR(255) NOT NULL DEFAULT '',
+    neighbor_address VARCHAR(45) NOT NULL DEFAULT '',
+    neighbor_interface VARCHAR(100) NOT NULL DEFAULT '',
+    neighbor_router_id INT NULL,
+    platform VARCHAR(100) NOT NULL DEFAULT '',
+    board VARCHAR(100) NOT NULL DEFAULT '',
+    version VARCHAR(100) NOT NULL DEFAULT '',
+    discovered_by VARCHAR(50) NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    first_seen TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_topology_links (router_id, interface, neighbor_mac, neighbor_identity),
+    INDEX idx_topology_links_active (active),
+    CONSTRAINT fk_topology_links_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_topology_links_neighbor FOREIGN KEY (neighbor_router_id) REFERENCES routers(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS ipam_subnets (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    prefix VARCHAR(49) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NULL,
+    router_id INT NULL,
+    vlan_id INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_ipam_subnets_prefix (prefix),
+    CONSTRAINT fk_ipam_subnets_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS notification_channels (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    target VARCHAR(1000) NOT NULL,
+    router_tags VARCHAR(500) NOT NULL DEFAULT '',
+    min_severity VARCHAR(20) NOT NULL DEFAULT 'warning',
+    quiet_start CHAR(5) NULL,
+    quiet_end CHAR(5) NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS threat_feeds (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    address_list VARCHAR(64) NOT NULL,
+    interval_minutes INT NOT NULL DEFAULT 60,
+    max_entries INT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_synced_at TIMESTAMP NULL,
+    last_entry_count INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(500) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS threat_feed_routers (
+    feed_id INT NOT NULL,
+    router_id INT NOT NULL,
+    PRIMARY KEY (feed_id, router_id),
+    CONSTRAINT fk_threat_feed_routers_feed FOREIGN KEY (feed_id) REFERENCES threat_feeds(id) ON DELETE CASCADE,
+    CONSTRAINT fk_threat_feed_routers_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS router_beacons (
+    router_id INT PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL,
+    installed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NULL,
+    last_source_ip VARCHAR(45) NULL,
+    UNIQUE KEY uk_router_beacons_token (token_hash),
+    CONSTRAINT fk_router_beacons_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS task_schedules (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    router_id INT NOT NULL,
+    cron VARCHAR(100) NOT NULL,
+    params TEXT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP NULL,
+    last_run_at TIMESTAMP NULL,
+    last_status VARCHAR(10) NULL,
+    last_output TEXT NULL,
+    last_error VARCHAR(500) NULL,
+    created_by VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_task_schedules_due (enabled, next_run_at),
+    CONSTRAINT fk_task_schedules_router FOREIGN KEY (router_id) REFERENCES routers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
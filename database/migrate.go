@@ -0,0 +1,243 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// init.sql hanya dijalankan MySQL saat volume pertama kali dibuat; deployment lama
+// disusulkan lewat migrasi bertingkat di bawah ini saat layer start.
+//
+//go:embed init.sql
+var schemaSQL string
+
+// migrateLock - Named lock MySQL supaya hanya satu instance yang menjalankan migrasi
+const migrateLock = "mikrotik_layer_migrate"
+
+// migration - Satu versi schema; setiap step idempotent (cek information_schema dulu)
+// sehingga aman untuk database yang sudah dibuat dari init.sql terbaru
+type migration struct {
+	version int
+	name    string
+	steps   []migrationStep
+}
+
+type migrationStep func(ctx context.Context, conn *sql.Conn) error
+
+// migrations - Urut berdasarkan version; jangan ubah/hapus versi yang sudah rilis,
+// tambahkan versi baru di akhir
+var migrations = []migration{
+	{1, "create missing tables", []migrationStep{createMissingTables}},
+	{2, "router connection and metadata columns", []migrationStep{
+		addColumn("routers", "is_virtual", "BOOLEAN DEFAULT FALSE"),
+		addColumn("routers", "contact_name", "VARCHAR(100)"),
+		addColumn("routers", "contact_phone", "VARCHAR(30)"),
+		addColumn("routers", "circuit_id", "VARCHAR(100)"),
+		addColumn("routers", "monitoring_url", "VARCHAR(255)"),
+		addColumn("routers", "notes", "JSON"),
+		addColumn("routers", "wan_interfaces", "VARCHAR(255)"),
+		addColumn("routers", "auto_connect", "BOOLEAN DEFAULT TRUE"),
+		addColumn("routers", "source_address", "VARCHAR(45)"),
+		addColumn("routers", "bind_interface", "VARCHAR(64)"),
+		addColumn("routers", "jump_type", "VARCHAR(10)"),
+		addColumn("routers", "jump_address", "VARCHAR(255)"),
+		addColumn("routers", "jump_username", "VARCHAR(100)"),
+		addColumn("routers", "jump_password", "VARCHAR(255)"),
+		addColumn("routers", "jump_private_key", "TEXT"),
+		addColumn("routers", "jump_host_key", "VARCHAR(1000)"),
+		addColumn("routers", "tunnel_concentrator_id", "INT NULL"),
+		addColumn("routers", "tunnel_type", "VARCHAR(10)"),
+		addColumn("routers", "tunnel_peer", "VARCHAR(255)"),
+		addColumn("routers", "active_address", "VARCHAR(255)"),
+		addColumn("routers", "tags", "VARCHAR(255)"),
+		addIndex("routers", "idx_circuit_id", "INDEX idx_circuit_id (circuit_id)"),
+		addForeignKey("routers", "fk_routers_tunnel_concentrator",
+			"FOREIGN KEY (tunnel_concentrator_id) REFERENCES routers(id) ON DELETE SET NULL"),
+	}},
+	{3, "columns added to tables after creation", []migrationStep{
+		addColumn("queue_quotas", "action", "VARCHAR(20) NOT NULL DEFAULT 'alert'"),
+		addColumn("queue_quotas", "throttle_limit", "VARCHAR(50)"),
+		addColumn("queue_quotas", "address_list", "VARCHAR(100)"),
+		addColumn("queue_quotas", "enforced_period", "DATE NULL"),
+		addColumn("queue_quotas", "original_max_limit", "VARCHAR(50)"),
+		addColumn("users", "reseller_id", "INT NULL"),
+		addForeignKey("users", "fk_users_reseller",
+			"FOREIGN KEY (reseller_id) REFERENCES resellers(id) ON DELETE CASCADE"),
+		addColumn("users", "totp_secret", "VARCHAR(64) NULL"),
+		addColumn("users", "totp_enabled", "BOOLEAN NOT NULL DEFAULT FALSE"),
+		addColumn("users", "totp_last_step", "BIGINT NULL"),
+		addColumn("customers", "suspend_strategy", "VARCHAR(20) NULL"),
+		addColumn("customers", "suspend_original", "VARCHAR(50) NULL"),
+		addColumn("customers", "suspended_at", "TIMESTAMP NULL"),
+		addColumn("traffic_history", "rx_errors", "BIGINT UNSIGNED NOT NULL DEFAULT 0"),
+		addColumn("traffic_history", "tx_errors", "BIGINT UNSIGNED NOT NULL DEFAULT 0"),
+		addColumn("traffic_history", "rx_drops", "BIGINT UNSIGNED NOT NULL DEFAULT 0"),
+		addColumn("traffic_history", "tx_drops", "BIGINT UNSIGNED NOT NULL DEFAULT 0"),
+		addColumn("traffic_history", "fcs_errors", "BIGINT UNSIGNED NOT NULL DEFAULT 0"),
+		addColumn("api_tokens", "allowed_cidrs", "VARCHAR(1000) NULL"),
+		addColumn("auth_sessions", "allowed_cidrs", "VARCHAR(1000) NULL"),
+		addColumn("traffic_samplers", "monitored", "BOOLEAN NOT NULL DEFAULT FALSE"),
+		addColumn("job_tasks", "target", "VARCHAR(255) NOT NULL DEFAULT ''"),
+	}},
+	{4, "router hostname fits DNS names", []migrationStep{
+		widenColumn("routers", "hostname", 255, "VARCHAR(255) NOT NULL"),
+	}},
+}
+
+// Migrate - Bawa schema database ke versi terbaru. Versi yang sudah jalan dicatat di
+// tabel schema_migrations; antar instance diserialisasi dengan GET_LOCK.
+func (d *Database) Migrate() error {
+	ctx := context.Background()
+	conn, err := d.DB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migration connection failed: %w", err)
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 60)", migrateLock).Scan(&locked); err != nil {
+		return fmt.Errorf("migration lock failed: %w", err)
+	}
+	if locked.Int64 != 1 {
+		return fmt.Errorf("migration lock timeout")
+	}
+	defer conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", migrateLock)
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4`); err != nil {
+		return fmt.Errorf("error creating schema_migrations: %w", err)
+	}
+
+	applied := make(map[int]bool)
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return fmt.Errorf("error reading schema_migrations: %w", err)
+	}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return err
+		}
+		applied[version] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		start := time.Now()
+		for _, step := range m.steps {
+			if err := step(ctx, conn); err != nil {
+				return fmt.Errorf("migration %d (%s) failed: %w", m.version, m.name, err)
+			}
+		}
+		if _, err := conn.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)",
+			m.version, m.name); err != nil {
+			return fmt.Errorf("error recording migration %d: %w", m.version, err)
+		}
+		log.Printf("✓ Migration %d applied: %s (%v)", m.version, m.name, time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+// createMissingTables - Jalankan semua CREATE TABLE IF NOT EXISTS dari init.sql
+// (seed data di init.sql tidak ikut dijalankan)
+func createMissingTables(ctx context.Context, conn *sql.Conn) error {
+	for _, stmt := range schemaTables(schemaSQL) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaTables - Statement CREATE TABLE dari script SQL, baris komentar dibuang
+func schemaTables(script string) []string {
+	var tables []string
+	for _, stmt := range strings.Split(strings.ReplaceAll(script, "\r\n", "\n"), ";") {
+		var lines []string
+		for _, line := range strings.Split(stmt, "\n") {
+			if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[0]), "CREATE TABLE") {
+			tables = append(tables, strings.Join(lines, "\n"))
+		}
+	}
+	return tables
+}
+
+// addColumn - ALTER TABLE ADD COLUMN jika kolom belum ada
+func addColumn(table, column, definition string) migrationStep {
+	return func(ctx context.Context, conn *sql.Conn) error {
+		exists, err := schemaExists(ctx, conn, `SELECT COUNT(*) FROM information_schema.COLUMNS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`, table, column)
+		if err != nil || exists {
+			return err
+		}
+		_, err = conn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+		return err
+	}
+}
+
+// widenColumn - ALTER TABLE MODIFY COLUMN jika panjang kolom masih di bawah length
+func widenColumn(table, column string, length int, definition string) migrationStep {
+	return func(ctx context.Context, conn *sql.Conn) error {
+		var current sql.NullInt64
+		err := conn.QueryRowContext(ctx, `SELECT CHARACTER_MAXIMUM_LENGTH FROM information_schema.COLUMNS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`, table, column).Scan(&current)
+		if err != nil || current.Int64 >= int64(length) {
+			return err
+		}
+		_, err = conn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s", table, column, definition))
+		return err
+	}
+}
+
+// addIndex - ALTER TABLE ADD <definition> jika index belum ada
+func addIndex(table, name, definition string) migrationStep {
+	return func(ctx context.Context, conn *sql.Conn) error {
+		exists, err := schemaExists(ctx, conn, `SELECT COUNT(*) FROM information_schema.STATISTICS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`, table, name)
+		if err != nil || exists {
+			return err
+		}
+		_, err = conn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD %s", table, definition))
+		return err
+	}
+}
+
+// addForeignKey - ALTER TABLE ADD CONSTRAINT jika constraint belum ada
+func addForeignKey(table, name, definition string) migrationStep {
+	return func(ctx context.Context, conn *sql.Conn) error {
+		exists, err := schemaExists(ctx, conn, `SELECT COUNT(*) FROM information_schema.TABLE_CONSTRAINTS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND CONSTRAINT_NAME = ?`, table, name)
+		if err != nil || exists {
+			return err
+		}
+		_, err = conn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s", table, name, definition))
+		return err
+	}
+}
+
+func schemaExists(ctx context.Context, conn *sql.Conn, query string, args ...interface{}) (bool, error) {
+	var n int
+	if err := conn.QueryRowContext(ctx, query, args...).Scan(&n); err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
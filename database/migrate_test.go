@@ -0,0 +1,67 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchemaTables(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "skips seed data and comments",
+			script: "-- komentar\nCREATE TABLE IF NOT EXISTS a (\n    id INT\n);\n\nINSERT INTO a (id) VALUES (1);\n",
+			want:   []string{"CREATE TABLE IF NOT EXISTS a (\n    id INT\n)"},
+		},
+		{
+			name:   "crlf line endings",
+			script: "CREATE TABLE IF NOT EXISTS a (id INT);\r\n-- b\r\nCREATE TABLE IF NOT EXISTS b (id INT);\r\n",
+			want:   []string{"CREATE TABLE IF NOT EXISTS a (id INT)", "CREATE TABLE IF NOT EXISTS b (id INT)"},
+		},
+		{
+			name:   "empty script",
+			script: "",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schemaTables(tt.script)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d statements %q, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("statement %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaTablesInitSQL(t *testing.T) {
+	tables := schemaTables(schemaSQL)
+	if want := strings.Count(schemaSQL, "CREATE TABLE IF NOT EXISTS"); len(tables) != want {
+		t.Fatalf("got %d CREATE TABLE statements, want %d", len(tables), want)
+	}
+	if !strings.Contains(tables[0], "routers") {
+		t.Errorf("first table must be routers (referenced by later foreign keys), got %q", tables[0][:40])
+	}
+	for _, stmt := range tables {
+		if strings.Contains(stmt, "INSERT") {
+			t.Errorf("seed data leaked into schema statements: %q", stmt)
+		}
+	}
+}
+
+func TestMigrationVersionsOrdered(t *testing.T) {
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Errorf("migration %q has version %d, want %d", m.name, m.version, i+1)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+
+	"Mikrotik-Layer/tracing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// tracedDriverName - Driver MySQL yang membuat span per query/exec di dalam trace aktif
+const tracedDriverName = "mysql-traced"
+
+func init() {
+	sql.Register(tracedDriverName, tracedDriver{mysql.MySQLDriver{}})
+}
+
+type tracedDriver struct {
+	driver.Driver
+}
+
+func (d tracedDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{conn}, nil
+}
+
+// tracedConn - Teruskan interface opsional koneksi MySQL supaya database/sql tetap memakai
+// jalur context / prepared statement yang sama seperti tanpa tracing
+type tracedConn struct {
+	driver.Conn
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	span := startQuerySpan(query)
+	rows, err := q.QueryContext(ctx, query, args)
+	endQuerySpan(span, err)
+	return rows, err
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	span := startQuerySpan(query)
+	result, err := e.ExecContext(ctx, query, args)
+	endQuerySpan(span, err)
+	return result, err
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *tracedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// startQuerySpan - Span "db <VERB>" dengan statement (dipotong, tanpa nilai argumen)
+func startQuerySpan(query string) *tracing.Span {
+	statement := strings.Join(strings.Fields(query), " ")
+	verb := statement
+	if i := strings.IndexByte(verb, ' '); i > 0 {
+		verb = verb[:i]
+	}
+	span := tracing.StartChild("db "+strings.ToUpper(verb), tracing.KindClient)
+	if span == nil {
+		return nil
+	}
+	if len(statement) > 200 {
+		statement = statement[:200] + "..."
+	}
+	span.SetAttr("db.system", "mysql")
+	span.SetAttr("db.statement", statement)
+	return span
+}
+
+func endQuerySpan(span *tracing.Span, err error) {
+	if err != driver.ErrSkip {
+		span.SetError(err)
+	}
+	span.End()
+}
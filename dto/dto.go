@@ -0,0 +1,150 @@
+// Package dto - Bentuk response API v1 (/api/v1): JSON snake_case konsisten dan nilai bertipe,
+// terlepas dari nama property RouterOS yang dipakai model internal.
+package dto
+
+import (
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// Interface - /interface pada router
+type Interface struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Running    bool   `json:"running"`
+	Disabled   bool   `json:"disabled"`
+	RxBytes    int64  `json:"rx_bytes"`
+	TxBytes    int64  `json:"tx_bytes"`
+	RxPackets  int64  `json:"rx_packets"`
+	TxPackets  int64  `json:"tx_packets"`
+	RxErrors   int64  `json:"rx_errors"`
+	TxErrors   int64  `json:"tx_errors"`
+	RxDrops    int64  `json:"rx_drops"`
+	TxDrops    int64  `json:"tx_drops"`
+	FCSErrors  int64  `json:"fcs_errors"`
+	Comment    string `json:"comment,omitempty"`
+	MacAddress string `json:"mac_address,omitempty"`
+	MTU        int    `json:"mtu,omitempty"`
+	LastLinkUp string `json:"last_link_up_time,omitempty"`
+}
+
+// Queue - Simple queue; limit tetap format upload/download RouterOS (mis. 10M/20M)
+type Queue struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Target     string `json:"target"`
+	MaxLimit   string `json:"max_limit"`
+	BurstLimit string `json:"burst_limit,omitempty"`
+	Disabled   bool   `json:"disabled"`
+}
+
+// SystemResource - Snapshot /system/resource
+type SystemResource struct {
+	Version      string `json:"version"`
+	Uptime       string `json:"uptime"`
+	CPULoad      int    `json:"cpu_load"`
+	FreeMemory   int64  `json:"free_memory"`
+	TotalMemory  int64  `json:"total_memory"`
+	BoardName    string `json:"board_name,omitempty"`
+	Architecture string `json:"architecture,omitempty"`
+}
+
+// TrafficStats - Satu pembacaan counter & rate interface
+type TrafficStats struct {
+	RouterID  int       `json:"router_id"`
+	Interface string    `json:"interface"`
+	RxBytes   int64     `json:"rx_bytes"`
+	TxBytes   int64     `json:"tx_bytes"`
+	RxPackets int64     `json:"rx_packets"`
+	TxPackets int64     `json:"tx_packets"`
+	RxBps     int64     `json:"rx_bps"`
+	TxBps     int64     `json:"tx_bps"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewInterface - Konversi model interface ke DTO v1
+func NewInterface(i *models.Interface) *Interface {
+	mtu, _ := strconv.Atoi(i.MTU)
+	return &Interface{
+		Name:       i.Name,
+		Type:       i.Type,
+		Running:    i.Running,
+		Disabled:   i.Disabled,
+		RxBytes:    toInt64(i.RxBytes),
+		TxBytes:    toInt64(i.TxBytes),
+		RxPackets:  toInt64(i.RxPackets),
+		TxPackets:  toInt64(i.TxPackets),
+		RxErrors:   toInt64(i.RxErrors),
+		TxErrors:   toInt64(i.TxErrors),
+		RxDrops:    toInt64(i.RxDrops),
+		TxDrops:    toInt64(i.TxDrops),
+		FCSErrors:  toInt64(i.FCSErrors),
+		Comment:    i.Comment,
+		MacAddress: i.MacAddress,
+		MTU:        mtu,
+		LastLinkUp: i.LastLinkUp,
+	}
+}
+
+// NewInterfaces - Konversi list interface (nil tetap jadi array kosong)
+func NewInterfaces(list []*models.Interface) []*Interface {
+	out := make([]*Interface, 0, len(list))
+	for _, i := range list {
+		out = append(out, NewInterface(i))
+	}
+	return out
+}
+
+// NewQueues - Konversi list simple queue
+func NewQueues(list []*models.Queue) []*Queue {
+	out := make([]*Queue, 0, len(list))
+	for _, q := range list {
+		out = append(out, &Queue{
+			ID:         q.ID,
+			Name:       q.Name,
+			Target:     q.Target,
+			MaxLimit:   q.MaxLimit,
+			BurstLimit: q.BurstLimit,
+			Disabled:   q.Disabled,
+		})
+	}
+	return out
+}
+
+// NewSystemResource - Konversi snapshot resource
+func NewSystemResource(r *models.SystemResource) *SystemResource {
+	cpu, _ := strconv.Atoi(r.CPULoad)
+	return &SystemResource{
+		Version:      r.Version,
+		Uptime:       r.Uptime,
+		CPULoad:      cpu,
+		FreeMemory:   toInt64(r.FreeMemory),
+		TotalMemory:  toInt64(r.TotalMemory),
+		BoardName:    r.BoardName,
+		Architecture: r.Architecture,
+	}
+}
+
+// NewTrafficStats - Konversi hasil monitor-traffic
+func NewTrafficStats(s *services.TrafficStats) *TrafficStats {
+	return &TrafficStats{
+		RouterID:  s.RouterID,
+		Interface: s.InterfaceName,
+		RxBytes:   toInt64(s.RxBytes),
+		TxBytes:   toInt64(s.TxBytes),
+		RxPackets: toInt64(s.RxPackets),
+		TxPackets: toInt64(s.TxPackets),
+		RxBps:     toInt64(s.RxBitsPerSec),
+		TxBps:     toInt64(s.TxBitsPerSec),
+		Timestamp: s.Timestamp,
+	}
+}
+
+// toInt64 - Counter RouterOS (string) ke angka, 0 jika kosong/invalid
+func toInt64(v string) int64 {
+	n, _ := strconv.ParseInt(v, 10, 64)
+	return n
+}
@@ -3,30 +3,33 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
+	"Mikrotik-Layer/i18n"
 	"Mikrotik-Layer/models"
 	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
 )
 
+// GetAddresses - GET /api/addresses?router_id=&filter=interface=ether1
 func GetAddresses(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		filter, ok := queryFilter(w, r, services.AddressFilterFields)
+		if !ok {
 			return
 		}
 
-		addresses, err := ms.GetAddresses(routerID)
+		addresses, err := ms.GetAddressesFiltered(routerID, filter)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
@@ -40,54 +43,46 @@ func GetAddresses(ms *services.MikrotikService) http.HandlerFunc {
 
 func AddAddress(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
 			return
 		}
 
 		iface := r.URL.Query().Get("interface")
 		address := r.URL.Query().Get("address")
 
-		if iface == "" || address == "" {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'interface' dan 'address' diperlukan",
-			})
+		errs := validation.Var("interface", iface, "required")
+		errs = append(errs, validation.Var("address", address, "required,cidr")...)
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
 			return
 		}
 
-		err = ms.AddAddress(routerID, iface, address)
+		dryRun := isDryRun(r)
+		plan, err := ms.AddAddress(routerID, iface, address, dryRun)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Message: "Address berhasil ditambahkan",
+			Code:    planCode(dryRun, i18n.AddressAdded),
+			Message: planMessage(r, dryRun, i18n.AddressAdded),
+			Data:    plan,
 		})
 	}
 }
 
 func RemoveAddress(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
 			return
 		}
 
@@ -96,24 +91,29 @@ func RemoveAddress(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'id' diperlukan",
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'id'"),
 			})
 			return
 		}
 
-		err = ms.RemoveAddress(routerID, id)
+		dryRun := isDryRun(r)
+		plan, err := ms.RemoveAddress(routerID, id, dryRun)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Message: "Address berhasil dihapus",
+			Code:    planCode(dryRun, i18n.AddressRemoved),
+			Message: planMessage(r, dryRun, i18n.AddressRemoved),
+			Data:    plan,
 		})
 	}
 }
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// GetAggregateTraffic - GET /api/traffic/aggregate?router_id=1&interface=bond1
+// Snapshot traffic bonding/bridge sebagai jumlah rate member; versi stream: /ws/traffic/monitor?aggregate_of=
+func GetAggregateTraffic(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		name := r.URL.Query().Get("interface")
+		if name == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'interface'"),
+			})
+			return
+		}
+
+		// Izin monitor bonding/bridge berlaku untuk member-nya
+		if !auth.FromRequest(r).CanMonitor(routerID, name) {
+			auth.Forbidden(w, r)
+			return
+		}
+
+		traffic, err := ms.GetAggregateTraffic(routerID, name)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    traffic,
+		})
+	}
+}
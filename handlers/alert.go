@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+type AlertHandler struct {
+	repo *repository.AlertRepository
+}
+
+func NewAlertHandler(repo *repository.AlertRepository) *AlertHandler {
+	return &AlertHandler{repo: repo}
+}
+
+// GetActiveAlerts - GET /api/alerts/active
+// Alert open + acknowledged dikelompokkan per router, beserta jumlah per severity untuk badge.
+// User dengan scope hanya melihat alert router miliknya.
+func (h *AlertHandler) GetActiveAlerts(w http.ResponseWriter, r *http.Request) {
+	alerts, err := h.repo.ListActive()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	alerts = visibleAlerts(alerts, auth.FromRequest(r))
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    groupAlerts(alerts),
+	})
+}
+
+// AcknowledgeAlert - POST /api/alerts/{id}/acknowledge, body {"user": "...", "note": "..."}
+func (h *AlertHandler) AcknowledgeAlert(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, models.AlertStatusAcknowledged)
+}
+
+// ResolveAlert - POST /api/alerts/{id}/resolve, body {"user": "...", "note": "..."}
+func (h *AlertHandler) ResolveAlert(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, models.AlertStatusResolved)
+}
+
+func (h *AlertHandler) transition(w http.ResponseWriter, r *http.Request, status string) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/alerts/")
+	id, err := strconv.ParseInt(strings.Split(path, "/")[0], 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "alert"),
+		})
+		return
+	}
+
+	var req models.AlertActionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	alert, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	if !alertVisible(alert, auth.FromRequest(r)) {
+		auth.Forbidden(w, r)
+		return
+	}
+
+	// Acknowledge hanya dari open; resolve dari open / acknowledged
+	if alert.Status == models.AlertStatusResolved ||
+		(status == models.AlertStatusAcknowledged && alert.Status != models.AlertStatusOpen) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.AlertNotActionable,
+			Error:   i18n.T(r, i18n.AlertNotActionable, alert.Status),
+		})
+		return
+	}
+
+	code := i18n.AlertResolved
+	if status == models.AlertStatusAcknowledged {
+		code = i18n.AlertAcknowledged
+		err = h.repo.Acknowledge(id, req.User, req.Note)
+	} else {
+		err = h.repo.Resolve(id, req.User, req.Note)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.Conflict),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	alert, err = h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	services.GetHub().Publish("alerts", alert.RouterID, alert)
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    code,
+		Message: i18n.T(r, code),
+		Data:    alert,
+	})
+}
+
+// alertVisible - Alert router dalam scope; alert tanpa router hanya untuk user tanpa batasan
+func alertVisible(alert *models.Alert, principal *auth.Principal) bool {
+	if principal.Unrestricted() {
+		return true
+	}
+	return alert.RouterID != nil && principal.CanAccessRouter(*alert.RouterID)
+}
+
+// visibleAlerts - Saring alert sesuai scope principal
+func visibleAlerts(alerts []*models.Alert, principal *auth.Principal) []*models.Alert {
+	if principal.Unrestricted() {
+		return alerts
+	}
+	filtered := []*models.Alert{}
+	for _, alert := range alerts {
+		if alertVisible(alert, principal) {
+			filtered = append(filtered, alert)
+		}
+	}
+	return filtered
+}
+
+// groupAlerts - Kelompokkan alert (sudah urut severity) per router; router dengan alert terparah dulu
+func groupAlerts(alerts []*models.Alert) *models.ActiveAlerts {
+	result := &models.ActiveAlerts{
+		BySeverity: make(map[string]int),
+		Routers:    []*models.RouterAlerts{},
+	}
+	for _, s := range models.AlertSeverities {
+		result.BySeverity[s] = 0
+	}
+
+	groups := make(map[int]*models.RouterAlerts) // key 0 = alert tanpa router
+	for _, alert := range alerts {
+		result.Total++
+		result.BySeverity[alert.Severity]++
+		if alert.Status == models.AlertStatusOpen {
+			result.Unacknowledged++
+		}
+
+		key := 0
+		if alert.RouterID != nil {
+			key = *alert.RouterID
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &models.RouterAlerts{
+				RouterID:   alert.RouterID,
+				RouterName: alert.RouterName,
+				BySeverity: make(map[string]int),
+				Alerts:     []*models.Alert{},
+			}
+			groups[key] = group
+			result.Routers = append(result.Routers, group)
+		}
+		group.BySeverity[alert.Severity]++
+		group.Alerts = append(group.Alerts, alert)
+	}
+
+	// Urutan pertama kali muncul sudah mengikuti severity terparah; jumlah alert jadi tie-breaker
+	sort.SliceStable(result.Routers, func(i, j int) bool {
+		si, sj := severityRank(result.Routers[i].Alerts[0].Severity), severityRank(result.Routers[j].Alerts[0].Severity)
+		if si != sj {
+			return si < sj
+		}
+		return len(result.Routers[i].Alerts) > len(result.Routers[j].Alerts)
+	})
+	return result
+}
+
+func severityRank(severity string) int {
+	for i, s := range models.AlertSeverities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(models.AlertSeverities)
+}
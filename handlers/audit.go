@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// GetAuditLogs - GET /api/audit?router_id=&actor=&action=&from=&to=&limit=
+func GetAuditLogs(repo *repository.AuditRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter := models.AuditFilter{
+			Actor:  query.Get("actor"),
+			Action: query.Get("action"),
+		}
+
+		if v := query.Get("router_id"); v != "" {
+			routerID, err := strconv.Atoi(v)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.InvalidParameter,
+					Error:   i18n.T(r, i18n.InvalidParameter, "router_id"),
+				})
+				return
+			}
+			filter.RouterID = &routerID
+		}
+
+		from, err := parseTimeParam(query.Get("from"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidTimeFormat,
+				Error:   i18n.ErrorText(r, errInvalidTimeParam("from")),
+			})
+			return
+		}
+		to, err := parseTimeParam(query.Get("to"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidTimeFormat,
+				Error:   i18n.ErrorText(r, errInvalidTimeParam("to")),
+			})
+			return
+		}
+		filter.From, filter.To = from, to
+		filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+		entries, err := repo.List(filter)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    entries,
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/middleware"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// SessionHandler - Sesi dashboard: API token ditukar dengan access token berumur pendek dan
+// refresh token yang dirotasi setiap dipakai
+type SessionHandler struct {
+	repo       *repository.UserRepository
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	auth       *auth.Authenticator // policy TOTP per role
+	totpIssuer string
+}
+
+func NewSessionHandler(repo *repository.UserRepository, accessTTL, refreshTTL time.Duration, authenticator *auth.Authenticator, totpIssuer string) *SessionHandler {
+	return &SessionHandler{repo: repo, accessTTL: accessTTL, refreshTTL: refreshTTL, auth: authenticator, totpIssuer: totpIssuer}
+}
+
+// CreateSession - POST /api/auth/sessions, body SessionCreateRequest
+// Dipanggil dengan API token user; token bootstrap / auth nonaktif tidak punya user untuk sesi.
+// User yang sudah enroll TOTP wajib mengirim totp_code.
+func (h *SessionHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	principal := auth.FromRequest(r)
+	if principal == nil || principal.UserID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.SessionUnavailable,
+			Error:   i18n.T(r, i18n.SessionUnavailable),
+		})
+		return
+	}
+
+	var req models.SessionCreateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	secret, enabled, err := h.repo.TOTPState(principal.UserID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	if !enabled && h.auth.TOTPRequired(principal.Role) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.TOTPEnrollmentRequired,
+			Error:   i18n.T(r, i18n.TOTPEnrollmentRequired),
+		})
+		return
+	}
+	if enabled && !h.checkTOTP(w, r, principal.UserID, secret, req.TOTPCode) {
+		return
+	}
+
+	access, refresh, err := auth.GenerateSessionTokens()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	now := time.Now()
+	session, err := h.repo.CreateSession(&models.AuthSession{
+		UserID:           principal.UserID,
+		Name:             req.Name,
+		IPAddress:        middleware.ClientIP(r).String(),
+		UserAgent:        truncate(r.UserAgent(), 255),
+		AllowedCIDRs:     principal.AllowedCIDRs,
+		AccessExpiresAt:  now.Add(h.accessTTL),
+		RefreshExpiresAt: now.Add(h.refreshTTL),
+	}, auth.HashToken(access), auth.HashToken(refresh))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.SessionCreated,
+		Message: i18n.T(r, i18n.SessionCreated),
+		Data:    &models.SessionTokens{Session: session, AccessToken: access, RefreshToken: refresh},
+	})
+}
+
+// RefreshSession - POST /api/auth/refresh, body SessionRefreshRequest (tanpa Authorization)
+// Refresh token lama tidak berlaku lagi; dipakai ulang = sesi dicabut
+func (h *SessionHandler) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	var req models.SessionRefreshRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	access, refresh, err := auth.GenerateSessionTokens()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	now := time.Now()
+	clientIP := middleware.ClientIP(r)
+	session, ok, err := h.repo.RotateSession(auth.HashToken(req.RefreshToken), auth.HashToken(access), auth.HashToken(refresh),
+		now.Add(h.accessTTL), now.Add(h.refreshTTL), func(allowed []string) bool { return auth.IPAllowed(clientIP, allowed) })
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidRefreshToken,
+			Error:   i18n.T(r, i18n.InvalidRefreshToken),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.SessionRefreshed,
+		Message: i18n.T(r, i18n.SessionRefreshed),
+		Data:    &models.SessionTokens{Session: session, AccessToken: access, RefreshToken: refresh},
+	})
+}
+
+// ListSessions - GET /api/auth/sessions[?user_id=] (user_id hanya untuk admin)
+func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := sessionUserID(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.repo.ListSessions(userID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	if p := auth.FromRequest(r); p != nil && p.SessionID != 0 {
+		for _, s := range sessions {
+			s.Current = s.ID == p.SessionID
+		}
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    sessions,
+	})
+}
+
+// RevokeSession - DELETE /api/auth/sessions/{id}[?user_id=]
+func (h *SessionHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := sessionUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/auth/sessions/"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "session"),
+		})
+		return
+	}
+
+	if err := h.repo.RevokeSession(userID, id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.SessionRevoked,
+		Message: i18n.T(r, i18n.SessionRevoked),
+	})
+}
+
+// RevokeAllSessions - POST /api/auth/sessions/revoke-all[?user_id=][&keep_current=true]
+// keep_current mempertahankan sesi yang dipakai request ini ("logout perangkat lain")
+func (h *SessionHandler) RevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := sessionUserID(w, r)
+	if !ok {
+		return
+	}
+
+	except := 0
+	if keep, _ := strconv.ParseBool(r.URL.Query().Get("keep_current")); keep {
+		if p := auth.FromRequest(r); p != nil && p.UserID == userID {
+			except = p.SessionID
+		}
+	}
+
+	n, err := h.repo.RevokeAllSessions(userID, except)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.SessionsRevoked,
+		Message: i18n.T(r, i18n.SessionsRevoked, n),
+		Data:    map[string]int64{"revoked": n},
+	})
+}
+
+// sessionUserID - User pemilik sesi yang dikelola: diri sendiri, atau ?user_id= untuk admin
+func sessionUserID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	principal := auth.FromRequest(r)
+	if v := r.URL.Query().Get("user_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.NotANumber,
+				Error:   i18n.T(r, i18n.NotANumber, "user_id"),
+			})
+			return 0, false
+		}
+		if self := principal != nil && principal.UserID == id; !self && !principal.IsAdmin() {
+			auth.Forbidden(w, r)
+			return 0, false
+		}
+		return id, true
+	}
+
+	if principal == nil || principal.UserID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.SessionUnavailable,
+			Error:   i18n.T(r, i18n.SessionUnavailable),
+		})
+		return 0, false
+	}
+	return principal.UserID, true
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+// requestActor - Username pemanggil untuk audit ("api" jika auth nonaktif)
+func requestActor(r *http.Request) string {
+	if p := auth.FromRequest(r); p != nil && p.Username != "" {
+		return p.Username
+	}
+	return "api"
+}
+
+// backupErrorStatus - Backup nonaktif 503, kunci berbeda 409, selebihnya fallback
+func backupErrorStatus(err error, fallback int) int {
+	switch i18n.ErrorCode(err, "") {
+	case i18n.BackupDisabled:
+		return http.StatusServiceUnavailable
+	case i18n.BackupKeyMismatch:
+		return http.StatusConflict
+	}
+	return errorStatus(err, fallback)
+}
+
+// Backups - /api/backups?router_id=
+// GET: list metadata backup router, POST body BackupRequest: buat backup / export sekarang
+func Backups(svc *services.BackupService, repo *repository.BackupRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			backups, err := repo.List(routerID)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    backups,
+			})
+
+		case http.MethodPost:
+			var req models.BackupRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+
+			backup, err := svc.Create(routerID, &req, requestActor(r))
+			if err != nil {
+				status := backupErrorStatus(err, http.StatusInternalServerError)
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.StatusCode(status)),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.BackupCreated,
+				Message: i18n.T(r, i18n.BackupCreated),
+				Data:    backup,
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// Backup - /api/backups/{id}: GET metadata, DELETE; /api/backups/{id}/download: GET isi
+// (hanya untuk request dengan token API, setiap download diaudit)
+func Backup(svc *services.BackupService, repo *repository.BackupRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/backups/"), "/")
+		id, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) > 2 || (len(parts) == 2 && parts[1] != "download") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidID,
+				Error:   i18n.T(r, i18n.InvalidID, "backup"),
+			})
+			return
+		}
+
+		backup, err := repo.GetByID(id)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.NotFound),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+		if !auth.FromRequest(r).CanAccessRouter(backup.RouterID) {
+			auth.Forbidden(w, r)
+			return
+		}
+
+		if len(parts) == 2 {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			downloadBackup(w, r, svc, backup)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    backup,
+			})
+
+		case http.MethodDelete:
+			if err := svc.Delete(backup, requestActor(r)); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.BackupDeleted,
+				Message: i18n.T(r, i18n.BackupDeleted),
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func downloadBackup(w http.ResponseWriter, r *http.Request, svc *services.BackupService, backup *models.RouterBackup) {
+	// Tanpa auth (AUTH_ENABLED=false) tidak ada identitas untuk audit: download ditolak
+	if auth.FromRequest(r) == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.BackupAuthRequired,
+			Error:   i18n.T(r, i18n.BackupAuthRequired),
+		})
+		return
+	}
+
+	content, err := svc.Content(backup, requestActor(r))
+	if err != nil {
+		w.WriteHeader(backupErrorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", backup.Filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(content)
+}
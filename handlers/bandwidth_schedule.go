@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// BandwidthSchedules - /api/bandwidth-schedules
+// GET: list jadwal (dalam scope), POST body BandwidthScheduleRequest, mis.
+// {"name":"Malam 2x","plan_id":3,"rate_limit":"20M/40M","start_time":"22:00","end_time":"06:00"}
+func BandwidthSchedules(repo *repository.BandwidthScheduleRepository, plans *repository.PlanRepository, routers *repository.RouterRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal := auth.FromRequest(r)
+
+		switch r.Method {
+		case http.MethodGet:
+			schedules, err := repo.List()
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			visible := []*models.BandwidthSchedule{}
+			for _, s := range schedules {
+				if canAccessSchedule(principal, s) {
+					visible = append(visible, s)
+				}
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    visible,
+			})
+
+		case http.MethodPost:
+			var req models.BandwidthScheduleRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+
+			schedule := &models.BandwidthSchedule{Enabled: true, Days: []string{}}
+			if errs := mergeBandwidthScheduleRequest(schedule, &req, plans, routers); len(errs) > 0 {
+				writeValidationError(w, r, errs)
+				return
+			}
+			if !canAccessSchedule(principal, schedule) {
+				auth.Forbidden(w, r)
+				return
+			}
+
+			created, err := repo.Create(schedule)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.ScheduleCreated,
+				Message: i18n.T(r, i18n.ScheduleCreated),
+				Data:    created,
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// BandwidthSchedule - /api/bandwidth-schedules/{id}: GET, PUT (field yang diisi saja), DELETE.
+// Jadwal yang sedang aktif dikembalikan ke limit normal dulu sebelum dihapus atau targetnya diganti.
+func BandwidthSchedule(repo *repository.BandwidthScheduleRepository, plans *repository.PlanRepository, routers *repository.RouterRepository, scheduler *services.BandwidthScheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/bandwidth-schedules/"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidID,
+				Error:   i18n.T(r, i18n.InvalidID, "bandwidth schedule"),
+			})
+			return
+		}
+
+		if r.Method != http.MethodGet && r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		schedule, err := repo.GetByID(id)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.NotFound),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		principal := auth.FromRequest(r)
+		if !canAccessSchedule(principal, schedule) {
+			auth.Forbidden(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    schedule,
+			})
+
+		case http.MethodPut:
+			var req models.BandwidthScheduleRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+
+			updated := *schedule
+			if errs := mergeBandwidthScheduleRequest(&updated, &req, plans, routers); len(errs) > 0 {
+				writeValidationError(w, r, errs)
+				return
+			}
+			if !canAccessSchedule(principal, &updated) {
+				auth.Forbidden(w, r)
+				return
+			}
+
+			if !sameScheduleTarget(schedule, &updated) {
+				if err := scheduler.Release(schedule); err != nil {
+					w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+					json.NewEncoder(w).Encode(models.ApiResponse{
+						Success: false,
+						Code:    i18n.ErrorCode(err, i18n.InternalError),
+						Error:   i18n.ErrorText(r, err),
+					})
+					return
+				}
+				updated.AppliedLimit, updated.OriginalLimit = nil, nil
+			}
+
+			if err := repo.Update(&updated); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.ScheduleUpdated,
+				Message: i18n.T(r, i18n.ScheduleUpdated),
+				Data:    &updated,
+			})
+
+		case http.MethodDelete:
+			if err := scheduler.Release(schedule); err != nil {
+				w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			if err := repo.Delete(id); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.ScheduleDeleted,
+				Message: i18n.T(r, i18n.ScheduleDeleted),
+			})
+		}
+	}
+}
+
+// canAccessSchedule - Jadwal per plan menyentuh banyak router sehingga hanya untuk user tanpa
+// batasan scope; jadwal per queue mengikuti scope router
+func canAccessSchedule(p *auth.Principal, s *models.BandwidthSchedule) bool {
+	if s.PlanID != nil || s.RouterID == nil {
+		return p.Unrestricted()
+	}
+	return p.CanAccessRouter(*s.RouterID)
+}
+
+func sameScheduleTarget(a, b *models.BandwidthSchedule) bool {
+	samePlan := a.PlanID == nil && b.PlanID == nil || a.PlanID != nil && b.PlanID != nil && *a.PlanID == *b.PlanID
+	sameRouter := a.RouterID == nil && b.RouterID == nil || a.RouterID != nil && b.RouterID != nil && *a.RouterID == *b.RouterID
+	sameQueue := a.QueueName == nil && b.QueueName == nil || a.QueueName != nil && b.QueueName != nil && *a.QueueName == *b.QueueName
+	return samePlan && sameRouter && sameQueue
+}
+
+// mergeBandwidthScheduleRequest - Terapkan field request yang diisi ke jadwal lalu cek field wajib.
+// Target plan_id dan router_id + queue_name saling menggantikan.
+func mergeBandwidthScheduleRequest(s *models.BandwidthSchedule, req *models.BandwidthScheduleRequest, plans *repository.PlanRepository, routers *repository.RouterRepository) validation.Errors {
+	if req.Name != "" {
+		s.Name = req.Name
+	}
+	if req.PlanID != nil {
+		s.PlanID, s.RouterID, s.QueueName = req.PlanID, nil, nil
+	} else if req.RouterID != nil || req.QueueName != nil {
+		s.PlanID = nil
+		if req.RouterID != nil {
+			s.RouterID = req.RouterID
+		}
+		if req.QueueName != nil {
+			s.QueueName = req.QueueName
+		}
+	}
+	if req.RateLimit != "" {
+		s.RateLimit = req.RateLimit
+	}
+	if req.StartTime != "" {
+		s.StartTime = req.StartTime
+	}
+	if req.EndTime != "" {
+		s.EndTime = req.EndTime
+	}
+	if req.Days != nil {
+		s.Days = *req.Days
+	}
+	if req.Enabled != nil {
+		s.Enabled = *req.Enabled
+	}
+
+	var errs validation.Errors
+	if s.Name == "" {
+		errs.Add("name", validation.RuleRequired, "")
+	}
+	switch {
+	case s.PlanID != nil:
+		if _, err := plans.GetByID(*s.PlanID); err != nil {
+			errs.Add("plan_id", validation.RuleExists, "plan")
+		}
+	case s.RouterID == nil && (s.QueueName == nil || *s.QueueName == ""):
+		errs.Add("plan_id", validation.RuleRequired, "")
+	case s.RouterID == nil:
+		errs.Add("router_id", validation.RuleRequired, "")
+	default:
+		if _, err := routers.GetByID(*s.RouterID); err != nil {
+			errs.Add("router_id", validation.RuleExists, "router")
+		}
+		if s.QueueName == nil || *s.QueueName == "" {
+			errs.Add("queue_name", validation.RuleRequired, "")
+		}
+	}
+	if s.RateLimit == "" {
+		errs.Add("rate_limit", validation.RuleRequired, "")
+	}
+	errs = append(errs, validation.Var("start_time", s.StartTime, "required,clock")...)
+	errs = append(errs, validation.Var("end_time", s.EndTime, "required,clock")...)
+	if s.StartTime != "" && s.StartTime == s.EndTime {
+		errs.Add("end_time", validation.RuleDiffers, "start_time")
+	}
+	for i, day := range s.Days {
+		errs = append(errs, validation.Var("days["+strconv.Itoa(i)+"]", strings.ToLower(day),
+			"oneof="+strings.Join(models.ScheduleDays, " "))...)
+	}
+	return errs
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/middleware"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+type BeaconHandler struct {
+	monitor *services.BeaconMonitor
+}
+
+func NewBeaconHandler(monitor *services.BeaconMonitor) *BeaconHandler {
+	return &BeaconHandler{monitor: monitor}
+}
+
+// InstallBeacon - POST /api/routers/{id}/beacon[?dry_run=true], body BeaconInstallRequest (opsional)
+// Token lama langsung tidak berlaku; router_script di response berisi token baru
+func (h *BeaconHandler) InstallBeacon(w http.ResponseWriter, r *http.Request) {
+	routerID, ok := routerPathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.BeaconInstallRequest
+	if r.ContentLength != 0 && !decodeRequest(w, r, &req) {
+		return
+	}
+
+	dryRun := isDryRun(r)
+	result, err := h.monitor.Install(routerID, req.BaseURL, req.Manual, dryRun)
+	if err != nil {
+		w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	// Response berisi token beacon: jangan di-cache proxy / browser
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.BeaconInstalled),
+		Message: planMessage(r, dryRun, i18n.BeaconInstalled),
+		Data:    result,
+	})
+}
+
+// RemoveBeacon - DELETE /api/routers/{id}/beacon[?dry_run=true]
+func (h *BeaconHandler) RemoveBeacon(w http.ResponseWriter, r *http.Request) {
+	routerID, ok := routerPathID(w, r)
+	if !ok {
+		return
+	}
+
+	dryRun := isDryRun(r)
+	plan, err := h.monitor.Remove(routerID, dryRun)
+	if err != nil {
+		w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.BeaconRemoved),
+		Message: planMessage(r, dryRun, i18n.BeaconRemoved),
+		Data:    plan,
+	})
+}
+
+// GetReachability - GET /api/routers/{id}/reachability
+// online / api_unreachable (router hidup, beacon masuk) / down (beacon berhenti) / unknown
+func (h *BeaconHandler) GetReachability(w http.ResponseWriter, r *http.Request) {
+	routerID, ok := routerPathID(w, r)
+	if !ok {
+		return
+	}
+
+	reach, err := h.monitor.Reachability(routerID)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    reach,
+	})
+}
+
+// Heartbeat - POST /api/beacon?token=
+// Dipanggil scheduler di router (tanpa token API); diautentikasi oleh token beacon
+func (h *BeaconHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, err := h.monitor.Heartbeat(r.URL.Query().Get("token"), middleware.ClientIP(r).String()); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.Unauthorized,
+			Error:   i18n.T(r, i18n.Unauthorized),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+	})
+}
+
+// routerPathID - {id} router dari path /api/routers/{id}/... dengan cek akses
+func routerPathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/routers/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
+		})
+		return 0, false
+	}
+	if !auth.FromRequest(r).CanAccessRouter(id) {
+		auth.Forbidden(w, r)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// GetRouterCapabilities - GET /api/routers/{id}/capabilities?refresh=true
+func (h *RouterHandler) GetRouterCapabilities(w http.ResponseWriter, r *http.Request) {
+	id, ok := routerPathID(w, r)
+	if !ok {
+		return
+	}
+
+	refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+	caps, err := h.ms.GetCapabilities(id, refresh)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    caps,
+	})
+}
+
+// CheckRouterPermissions - GET /api/routers/{id}/permissions-check
+// Matriks hak akses kredensial API: probe read yang aman + policy group untuk aksi tulis
+func (h *RouterHandler) CheckRouterPermissions(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/routers/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
+		})
+		return
+	}
+
+	if !auth.FromRequest(r).CanAccessRouter(id) {
+		auth.Forbidden(w, r)
+		return
+	}
+
+	permissions, err := h.ms.CheckPermissions(id)
+	if err != nil {
+		w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    permissions,
+	})
+}
+
+// errorStatus - 422 untuk feature yang tidak didukung router, 503 untuk error transient
+// yang tetap gagal setelah retry, 429 untuk slot stream penuh, 409 untuk konflik reservasi
+// IP statis, selain itu fallback
+func errorStatus(err error, fallback int) int {
+	var unsupported *services.UnsupportedFeatureError
+	if errors.As(err, &unsupported) {
+		return http.StatusUnprocessableEntity
+	}
+	var transient *services.TransientError
+	if errors.As(err, &transient) {
+		return http.StatusServiceUnavailable
+	}
+	var streamLimit *services.StreamLimitError
+	if errors.As(err, &streamLimit) {
+		return http.StatusTooManyRequests
+	}
+	var conflict *services.ReservationConflictError
+	if errors.As(err, &conflict) {
+		return http.StatusConflict
+	}
+	return fallback
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// GetCAPsMANRemoteCAPs - GET /api/capsman/remote-caps?router_id=X
+// AP yang dikelola CAPsMAN router (identity, board, versi, state)
+func GetCAPsMANRemoteCAPs(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		caps, err := ms.GetCAPsMANRemoteCAPs(routerID)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    caps,
+		})
+	}
+}
+
+// GetCAPsMANRegistrations - GET /api/capsman/registrations?router_id=X[&interface=cap1]
+// Client wireless di semua AP CAPsMAN
+func GetCAPsMANRegistrations(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		clients, err := ms.GetCAPsMANRegistrations(routerID, r.URL.Query().Get("interface"))
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    clients,
+		})
+	}
+}
+
+// GetCAPsMANStatus - GET /api/capsman/status?router_id=X
+// Manager aktif, jumlah CAP / client, radio yang belum ter-provisioning dan rule provisioning
+func GetCAPsMANStatus(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		status, err := ms.GetCAPsMANStatus(routerID)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    status,
+		})
+	}
+}
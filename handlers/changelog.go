@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+// GetRouterChanges - GET /api/routers/{id}/changes?from=&to=&limit=
+// Perubahan lewat layer (audit log) dan langsung di router (log config via syslog) dalam satu
+// feed kronologis, terbaru dulu; default 24 jam terakhir
+func GetRouterChanges(routerRepo *repository.RouterRepository, audit *repository.AuditRepository, events *repository.EventRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := routerPathID(w, r)
+		if !ok {
+			return
+		}
+
+		from, to, err := parseTimeRange(r, 24*time.Hour)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		router, err := routerRepo.GetByID(id)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.NotFound),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		changelog, err := services.RouterChangelog(audit, events, router, from, to, limit)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    changelog,
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// requireConfirmation - Alur dua langkah aksi destruktif. Tanpa ?confirm_token= (atau token
+// tidak valid) tulis 409 berisi token baru + dampak lalu return false; token valid = lanjut.
+func requireConfirmation(w http.ResponseWriter, r *http.Request, store *services.ConfirmationStore, action, target string, impact interface{}, code string) bool {
+	username := ""
+	if p := auth.FromRequest(r); p != nil {
+		username = p.Username
+	}
+
+	token := r.URL.Query().Get("confirm_token")
+	if token != "" {
+		if store.Consume(token, action, target, username) {
+			return true
+		}
+		code = i18n.ConfirmationInvalid
+	}
+
+	confirmation, err := store.Issue(action, target, username, impact)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return false
+	}
+
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: false,
+		Code:    code,
+		Error:   i18n.T(r, code),
+		Data:    confirmation,
+	})
+	return false
+}
@@ -0,0 +1,470 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type CustomerHandler struct {
+	repo         *repository.CustomerRepository
+	plans        *repository.PlanRepository
+	service      *services.PlanService
+	suspension   *services.SuspensionService
+	reservations *services.ReservationService
+}
+
+func NewCustomerHandler(repo *repository.CustomerRepository, plans *repository.PlanRepository, service *services.PlanService, suspension *services.SuspensionService, reservations *services.ReservationService) *CustomerHandler {
+	return &CustomerHandler{repo: repo, plans: plans, service: service, suspension: suspension, reservations: reservations}
+}
+
+// customerResult - Customer beserta hasil penerapan plan (jika ada)
+type customerResult struct {
+	Customer *models.Customer          `json:"customer"`
+	Results  []*models.PlanApplyResult `json:"plan_results,omitempty"`
+}
+
+// CreateCustomer - POST /api/customers[?dry_run=true] (plan langsung diterapkan jika plan_id diisi)
+// Dry run tidak menyimpan customer, hanya menampilkan command plan per router.
+func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var req models.CustomerCreateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if !auth.FromRequest(r).CanAccessRouter(req.RouterID) {
+		auth.Forbidden(w, r)
+		return
+	}
+
+	dryRun := isDryRun(r)
+
+	var customer *models.Customer
+	var err error
+	if dryRun {
+		customer = &models.Customer{
+			RouterID:  req.RouterID,
+			Name:      req.Name,
+			PlanID:    req.PlanID,
+			QueueName: req.QueueName,
+			PPPSecret: req.PPPSecret,
+			Address:   req.Address,
+			Phone:     req.Phone,
+			Status:    models.CustomerActive,
+			Notes:     req.Notes,
+		}
+	} else {
+		customer, err = h.repo.Create(&req)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.CustomerCreated),
+		Message: planMessage(r, dryRun, i18n.CustomerCreated),
+		Data:    customerResult{Customer: customer, Results: h.applyPlan(customer, dryRun)},
+	})
+}
+
+// GetCustomers - GET /api/customers?router_id=&plan_id=&status=&q=
+func (h *CustomerHandler) GetCustomers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.CustomerFilter{
+		RouterIDs: auth.FromRequest(r).RouterIDs(),
+		Status:    query.Get("status"),
+		Query:     strings.TrimSpace(query.Get("q")),
+	}
+
+	for name, dst := range map[string]**int{"router_id": &filter.RouterID, "plan_id": &filter.PlanID} {
+		if v := query.Get(name); v != "" {
+			id, err := strconv.Atoi(v)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.InvalidParameter,
+					Error:   i18n.T(r, i18n.InvalidParameter, name),
+				})
+				return
+			}
+			*dst = &id
+		}
+	}
+
+	customers, err := h.repo.List(filter)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    customers,
+	})
+}
+
+// GetCustomerByID - GET /api/customers/{id}
+func (h *CustomerHandler) GetCustomerByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := customerIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	customer, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	if !auth.FromRequest(r).CanAccessRouter(customer.RouterID) {
+		auth.Forbidden(w, r)
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    customer,
+	})
+}
+
+// UpdateCustomer - PUT /api/customers/{id}[?dry_run=true] (plan diterapkan ulang jika plan/queue/secret berubah)
+// Dry run tidak menyimpan perubahan, hanya menampilkan command plan per router.
+func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
+	id, ok := customerIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.CustomerUpdateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if !h.customerInScope(w, r, id) {
+		return
+	}
+
+	dryRun := isDryRun(r)
+
+	var customer *models.Customer
+	var err error
+	if dryRun {
+		customer, err = h.repo.GetByID(id)
+		if err == nil {
+			mergeCustomerUpdate(customer, &req)
+		}
+	} else {
+		customer, err = h.repo.Update(id, &req)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	var results []*models.PlanApplyResult
+	if req.PlanID != nil || req.QueueName != nil || req.PPPSecret != nil {
+		results = h.applyPlan(customer, dryRun)
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.CustomerUpdated),
+		Message: planMessage(r, dryRun, i18n.CustomerUpdated),
+		Data:    customerResult{Customer: customer, Results: results},
+	})
+}
+
+// DeleteCustomer - DELETE /api/customers/{id} (object RouterOS tidak dihapus)
+func (h *CustomerHandler) DeleteCustomer(w http.ResponseWriter, r *http.Request) {
+	id, ok := customerIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.customerInScope(w, r, id) {
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.CustomerDeleted,
+		Message: i18n.T(r, i18n.CustomerDeleted),
+	})
+}
+
+// SuspendCustomer - POST /api/customers/{id}/suspend?strategy=disable_secret|address_list|throttle&dry_run=
+// Strategi kosong = SUSPEND_STRATEGY. Customer yang sudah suspended tidak diubah (changed=false).
+func (h *CustomerHandler) SuspendCustomer(w http.ResponseWriter, r *http.Request) {
+	customer, ok := h.customerFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	strategy := r.URL.Query().Get("strategy")
+	errs := validation.Var("strategy", strategy, "oneof="+strings.Join(models.SuspendStrategies, " "))
+	if len(errs) == 0 && customer.Status != models.CustomerSuspended {
+		if strategy == "" {
+			strategy = h.suspension.DefaultStrategy()
+		}
+		errs = suspendPrerequisites(customer, strategy)
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	dryRun := isDryRun(r)
+	result, err := h.suspension.Suspend(customer, strategy, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.CustomerSuspended),
+		Message: planMessage(r, dryRun, i18n.CustomerSuspended),
+		Data:    result,
+	})
+}
+
+// UnsuspendCustomer - POST /api/customers/{id}/unsuspend?dry_run=
+// Membalikkan strategi yang dipakai saat suspend. Customer yang tidak suspended tidak diubah.
+func (h *CustomerHandler) UnsuspendCustomer(w http.ResponseWriter, r *http.Request) {
+	customer, ok := h.customerFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	dryRun := isDryRun(r)
+	result, err := h.suspension.Unsuspend(customer, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.CustomerUnsuspended),
+		Message: planMessage(r, dryRun, i18n.CustomerUnsuspended),
+		Data:    result,
+	})
+}
+
+// ReserveCustomer - POST /api/customers/{id}/reservation?dry_run=
+// Body: {"mac_address":"AA:BB:CC:DD:EE:FF","address":"10.1.0.50"} -> static lease, ARP statis dan
+// simple queue (limit plan customer) di router customer. Konflik IP / MAC / queue dijawab 409
+// sebelum ada yang diubah; jika satu command gagal, item yang sudah dibuat dihapus lagi.
+func (h *CustomerHandler) ReserveCustomer(w http.ResponseWriter, r *http.Request) {
+	customer, ok := h.customerFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.StaticReservationRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	var errs validation.Errors
+	if (req.Address == nil || *req.Address == "") && (customer.Address == nil || *customer.Address == "") {
+		errs.Add("address", validation.RuleRequired, "")
+	}
+	if customer.PlanID == nil && (req.RateLimit == nil || *req.RateLimit == "") {
+		errs.Add("rate_limit", validation.RuleRequired, "")
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	dryRun := isDryRun(r)
+	result, err := h.reservations.Provision(customer, &req, dryRun)
+	if err != nil {
+		w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.CustomerReserved),
+		Message: planMessage(r, dryRun, i18n.CustomerReserved),
+		Data:    result,
+	})
+}
+
+// suspendPrerequisites - Field customer yang wajib ada untuk strategi suspend
+func suspendPrerequisites(customer *models.Customer, strategy string) validation.Errors {
+	var errs validation.Errors
+	switch strategy {
+	case models.SuspendDisableSecret:
+		if customer.PPPSecret == nil || *customer.PPPSecret == "" {
+			errs.Add("ppp_secret", validation.RuleRequired, "")
+		}
+	case models.SuspendThrottle:
+		if customer.QueueName == nil || *customer.QueueName == "" {
+			errs.Add("queue_name", validation.RuleRequired, "")
+		}
+	case models.SuspendAddressList:
+		if (customer.Address == nil || *customer.Address == "") && (customer.QueueName == nil || *customer.QueueName == "") {
+			errs.Add("address", validation.RuleRequired, "")
+		}
+	}
+	return errs
+}
+
+// customerFromPath - Ambil customer {id} yang dalam scope pemanggil (tulis 400/404/403 jika gagal)
+func (h *CustomerHandler) customerFromPath(w http.ResponseWriter, r *http.Request) (*models.Customer, bool) {
+	id, ok := customerIDFromPath(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	customer, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return nil, false
+	}
+	if !auth.FromRequest(r).CanAccessRouter(customer.RouterID) {
+		auth.Forbidden(w, r)
+		return nil, false
+	}
+	return customer, true
+}
+
+// customerInScope - Cek customer ada dan router-nya dalam scope pemanggil (tulis 404/403 jika tidak)
+func (h *CustomerHandler) customerInScope(w http.ResponseWriter, r *http.Request, id int) bool {
+	principal := auth.FromRequest(r)
+	if principal.Unrestricted() {
+		return true
+	}
+
+	customer, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return false
+	}
+	if !principal.CanAccessRouter(customer.RouterID) {
+		auth.Forbidden(w, r)
+		return false
+	}
+	return true
+}
+
+// mergeCustomerUpdate - Terapkan field update ke customer di memori (untuk dry run)
+func mergeCustomerUpdate(customer *models.Customer, req *models.CustomerUpdateRequest) {
+	if req.Name != nil {
+		customer.Name = *req.Name
+	}
+	if req.PlanID != nil {
+		customer.PlanID = req.PlanID
+	}
+	if req.QueueName != nil {
+		customer.QueueName = req.QueueName
+	}
+	if req.PPPSecret != nil {
+		customer.PPPSecret = req.PPPSecret
+	}
+	if req.Address != nil {
+		customer.Address = req.Address
+	}
+	if req.Phone != nil {
+		customer.Phone = req.Phone
+	}
+	if req.Notes != nil {
+		customer.Notes = req.Notes
+	}
+}
+
+// applyPlan - Terapkan plan customer ke router; nil jika customer tanpa plan
+func (h *CustomerHandler) applyPlan(customer *models.Customer, dryRun bool) []*models.PlanApplyResult {
+	if customer.PlanID == nil {
+		return nil
+	}
+
+	plan, err := h.plans.GetByID(*customer.PlanID)
+	if err != nil {
+		return []*models.PlanApplyResult{{RouterID: customer.RouterID, Customers: 1, Error: err.Error()}}
+	}
+
+	return h.service.Apply(plan, []*models.Customer{customer}, dryRun)
+}
+
+// customerIDFromPath - Ambil {id} dari /api/customers/{id}[/...]
+func customerIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/customers/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "customer"),
+		})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+// GetDashboardSummary - GET /api/dashboard/summary[?limit=10]
+// Jumlah router per status, rata-rata skor kesehatan dan router terburuk dulu
+func GetDashboardSummary(repo *repository.RouterRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 10
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.NotANumber,
+					Error:   i18n.T(r, i18n.NotANumber, "limit"),
+				})
+				return
+			}
+			limit = n
+		}
+
+		routers, err := repo.GetAll()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		summary := &models.DashboardSummary{
+			TotalRouters: len(routers),
+			ByStatus:     make(map[string]int),
+			ByHealth:     make(map[string]int),
+		}
+		known := make(map[int]bool, len(routers))
+		for _, rt := range routers {
+			known[rt.ID] = true
+			summary.ByStatus[rt.Status]++
+			if rt.IsActive {
+				summary.ActiveRouters++
+			}
+		}
+
+		// Hanya router yang masih terdaftar (state scorer bisa tertinggal setelah router dihapus)
+		var total float64
+		scores := services.GetHealthScorer().Worst(0)
+		summary.Worst = make([]*models.RouterHealth, 0, limit)
+		scored := 0
+		for _, h := range scores {
+			if !known[h.RouterID] {
+				continue
+			}
+			scored++
+			total += h.Score
+			summary.ByHealth[h.Level]++
+			if len(summary.Worst) < limit {
+				summary.Worst = append(summary.Worst, h)
+			}
+		}
+		if scored > 0 {
+			avg := float64(int(total/float64(scored)*10+0.5)) / 10
+			summary.AvgHealthScore = &avg
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    summary,
+		})
+	}
+}
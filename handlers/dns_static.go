@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// GetDNSStatic - GET /api/dns/static?router_id=
+func GetDNSStatic(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		entries, err := ms.GetDNSStatic(routerID)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    entries,
+		})
+	}
+}
+
+// AddDNSStatic - POST /api/dns/static?router_id=[&dry_run=true], body DNSStaticRequest
+// A butuh address IPv4, AAAA address IPv6, CNAME butuh cname
+func AddDNSStatic(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		var req models.DNSStaticRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		if req.Type == "" {
+			req.Type = "A"
+		}
+
+		var errs validation.Errors
+		switch req.Type {
+		case "CNAME":
+			if req.CNAME == nil || *req.CNAME == "" {
+				errs.Add("cname", validation.RuleRequired, "")
+			}
+		default:
+			if req.Address == nil || *req.Address == "" {
+				errs.Add("address", validation.RuleRequired, "")
+			} else if ip := net.ParseIP(*req.Address); ip != nil && (ip.To4() != nil) != (req.Type == "A") {
+				errs.Add("address", validation.RuleIP, "")
+			}
+		}
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.AddDNSStatic(routerID, &req, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		if !dryRun && len(plan.Commands) > 0 {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.DNSStaticAdded),
+			Message: planMessage(r, dryRun, i18n.DNSStaticAdded),
+			Data:    plan,
+		})
+	}
+}
+
+// RemoveDNSStatic - DELETE /api/dns/static?router_id=&id=[&dry_run=true]
+func RemoveDNSStatic(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, id, ok := firewallRuleParams(w, r)
+		if !ok {
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.RemoveDNSStatic(routerID, id, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.DNSStaticRemoved),
+			Message: planMessage(r, dryRun, i18n.DNSStaticRemoved),
+			Data:    plan,
+		})
+	}
+}
+
+// SetDNSStaticDisabled - POST /api/dns/static/enable|disable?router_id=&id=[&dry_run=true]
+func SetDNSStaticDisabled(ms *services.MikrotikService, disabled bool) http.HandlerFunc {
+	code := i18n.DNSStaticEnabled
+	if disabled {
+		code = i18n.DNSStaticDisabled
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		routerID, id, ok := firewallRuleParams(w, r)
+		if !ok {
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.SetDNSStaticDisabled(routerID, id, disabled, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, code),
+			Message: planMessage(r, dryRun, code),
+			Data:    plan,
+		})
+	}
+}
+
+// GetDNSSettings - GET /api/dns/settings?router_id=
+// Server upstream (statis & dinamis) dan pemakaian cache resolver router
+func GetDNSSettings(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		settings, err := ms.GetDNSSettings(routerID)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    settings,
+		})
+	}
+}
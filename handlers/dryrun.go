@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"Mikrotik-Layer/i18n"
+)
+
+// isDryRun - Cek parameter ?dry_run=true pada endpoint mutasi
+func isDryRun(r *http.Request) bool {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	return dryRun
+}
+
+// planCode - Code response sesuai mode eksekusi
+func planCode(dryRun bool, success string) string {
+	if dryRun {
+		return i18n.DryRun
+	}
+	return success
+}
+
+// planMessage - Pesan response sesuai mode eksekusi, dalam bahasa request
+func planMessage(r *http.Request, dryRun bool, success string) string {
+	return i18n.T(r, planCode(dryRun, success))
+}
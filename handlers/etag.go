@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// writeCached - Tulis response GET beserta ETag (hash body); jika If-None-Match cocok balas
+// 304 tanpa body supaya poller agresif tidak mengunduh ulang data yang sama.
+// no-cache: klien boleh menyimpan tapi wajib revalidasi setiap request.
+func writeCached(w http.ResponseWriter, r *http.Request, resp models.ApiResponse) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Del("Content-Type")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write(buf.Bytes())
+}
+
+// etagMatch - Perbandingan weak (RFC 9110) terhadap daftar ETag di If-None-Match
+func etagMatch(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
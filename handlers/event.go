@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// GetEvents - GET /api/events?router_id=&type=&severity=&from=&to=&limit=
+func GetEvents(repo *repository.EventRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		principal := auth.FromRequest(r)
+		filter := models.EventFilter{
+			RouterIDs: principal.RouterIDs(),
+			Type:      query.Get("type"),
+			Severity:  query.Get("severity"),
+		}
+
+		if v := query.Get("router_id"); v != "" {
+			routerID, err := strconv.Atoi(v)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.InvalidParameter,
+					Error:   i18n.T(r, i18n.InvalidParameter, "router_id"),
+				})
+				return
+			}
+			if !principal.CanAccessRouter(routerID) {
+				auth.Forbidden(w, r)
+				return
+			}
+			filter.RouterID = &routerID
+		}
+
+		from, err := parseTimeParam(query.Get("from"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidTimeFormat,
+				Error:   i18n.T(r, i18n.InvalidTimeFormat, "from"),
+			})
+			return
+		}
+		to, err := parseTimeParam(query.Get("to"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidTimeFormat,
+				Error:   i18n.T(r, i18n.InvalidTimeFormat, "to"),
+			})
+			return
+		}
+		filter.From, filter.To = from, to
+		filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+		events, err := repo.List(filter)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    events,
+		})
+	}
+}
+
+// parseTimeParam - Parse query parameter waktu RFC3339 (kosong = nil)
+func parseTimeParam(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+var errInvalidTimeRange = i18n.NewError(i18n.InvalidTimeRange)
+
+func errInvalidTimeParam(name string) error {
+	return i18n.NewError(i18n.InvalidTimeFormat, name)
+}
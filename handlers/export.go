@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+)
+
+// Format export untuk ?export=
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+)
+
+// exportFlushRows - Jumlah baris sebelum buffer CSV di-flush ke client
+const exportFlushRows = 500
+
+// exportFormat - Parse ?export=csv|xlsx; kosong berarti response JSON biasa
+func exportFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("export"); format {
+	case "", ExportCSV, ExportXLSX:
+		return format, nil
+	default:
+		return "", i18n.NewError(i18n.InvalidChoice, "export", "csv/xlsx")
+	}
+}
+
+// tableWriter - Penulis tabel export baris per baris langsung ke response
+type tableWriter interface {
+	WriteRow(values ...interface{}) error
+	Close() error
+}
+
+// newTableWriter - Set header download lalu tulis baris header kolom.
+// Setelah ini status 200 sudah terkirim; error berikutnya hanya bisa memutus stream.
+func newTableWriter(w http.ResponseWriter, format, name string, columns []string) (tableWriter, error) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	var tw tableWriter
+	if format == ExportXLSX {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		xw, err := newXLSXWriter(w, name)
+		if err != nil {
+			return nil, err
+		}
+		tw = xw
+	} else {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		tw = &csvTableWriter{w: csv.NewWriter(w)}
+	}
+
+	header := make([]interface{}, len(columns))
+	for i, c := range columns {
+		header[i] = c
+	}
+	return tw, tw.WriteRow(header...)
+}
+
+// streamExport - Tulis file export; fill menulis baris data. Karena header response sudah
+// terkirim, error di tengah stream hanya dicatat di log (file terpotong).
+func streamExport(w http.ResponseWriter, format, name string, columns []string, fill func(tableWriter) error) {
+	tw, err := newTableWriter(w, format, name, columns)
+	if err == nil {
+		err = fill(tw)
+	}
+	if err == nil {
+		err = tw.Close()
+	}
+	if err != nil {
+		log.Printf("[EXPORT] ❌ Export %s (%s) failed: %v", name, format, err)
+	}
+}
+
+// formatCell - Representasi teks nilai sel (waktu dalam RFC3339, pointer nil kosong)
+func formatCell(v interface{}) string {
+	if n, ok := numericCell(v); ok {
+		return n
+	}
+
+	switch val := v.(type) {
+	case nil, *uint64:
+		return ""
+	case string:
+		return val
+	case *string:
+		if val == nil {
+			return ""
+		}
+		return *val
+	case time.Time:
+		return val.Format(time.RFC3339)
+	case *time.Time:
+		if val == nil {
+			return ""
+		}
+		return val.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// numericCell - Nilai numerik untuk sel angka XLSX
+func numericCell(v interface{}) (string, bool) {
+	switch val := v.(type) {
+	case int:
+		return strconv.Itoa(val), true
+	case int64:
+		return strconv.FormatInt(val, 10), true
+	case uint64:
+		return strconv.FormatUint(val, 10), true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case *uint64:
+		if val == nil {
+			return "", false
+		}
+		return strconv.FormatUint(*val, 10), true
+	}
+	return "", false
+}
+
+type csvTableWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func (c *csvTableWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = formatCell(v)
+	}
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+
+	c.rows++
+	if c.rows%exportFlushRows == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvTableWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// xlsxWriter - Workbook XLSX satu sheet yang ditulis streaming: part statis lebih dulu,
+// sheet terakhir dengan baris inline string / angka sehingga tidak perlu shared strings.
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet *bufio.Writer
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+func newXLSXWriter(w io.Writer, sheetName string) (*xlsxWriter, error) {
+	// Nama sheet Excel maksimal 31 karakter
+	if len(sheetName) > 31 {
+		sheetName = sheetName[:31]
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xmlEscape(sheetName))},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheet := bufio.NewWriter(f)
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	return &xlsxWriter{zw: zw, sheet: sheet}, nil
+}
+
+func (x *xlsxWriter) WriteRow(values ...interface{}) error {
+	x.sheet.WriteString("<row>")
+	for _, v := range values {
+		if n, ok := numericCell(v); ok {
+			x.sheet.WriteString("<c><v>" + n + "</v></c>")
+			continue
+		}
+		x.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		x.sheet.WriteString(xmlEscape(formatCell(v)))
+		x.sheet.WriteString("</t></is></c>")
+	}
+	_, err := x.sheet.WriteString("</row>")
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	x.sheet.WriteString("</sheetData></worksheet>")
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zw.Close()
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// GetFiles - GET /api/files?router_id=
+func GetFiles(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		files, err := ms.GetFiles(routerID)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    files,
+		})
+	}
+}
+
+// DownloadFile - GET /api/files/download?router_id=&name= (isi file mentah sebagai attachment)
+func DownloadFile(files *services.FileService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+		name := r.URL.Query().Get("name")
+		if errs := validation.Var("name", name, "required,printable"); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		content, err := files.Download(routerID, name)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(name)))
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.Write(content)
+	}
+}
+
+// UploadFile - POST /api/files/upload?router_id=&name=&dry_run=
+// Body multipart (field "file", name default nama file) atau body mentah (name wajib)
+func UploadFile(files *services.FileService, maxBytes int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MethodNotAllowed,
+				Error:   i18n.T(r, i18n.MethodNotAllowed),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		name, content, err := readUpload(w, r, maxBytes)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			var errs validation.Errors
+			errs.Add("file", validation.RuleMax, fmt.Sprintf("%d bytes", maxBytes))
+			writeValidationError(w, r, errs)
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidRequestBody,
+				Error:   i18n.T(r, i18n.InvalidRequestBody, err),
+			})
+			return
+		}
+		if errs := validation.Var("name", name, "required,max=255,printable"); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := files.Upload(routerID, name, content, dryRun)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.FileUploaded),
+			Message: planMessage(r, dryRun, i18n.FileUploaded),
+			Data:    plan,
+		})
+	}
+}
+
+// RemoveFile - POST /api/files/remove?router_id=&name=&dry_run=
+func RemoveFile(files *services.FileService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+		name := r.URL.Query().Get("name")
+		if errs := validation.Var("name", name, "required,printable"); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := files.Remove(routerID, name, dryRun)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.FileRemoved),
+			Message: planMessage(r, dryRun, i18n.FileRemoved),
+			Data:    plan,
+		})
+	}
+}
+
+// readUpload - Baca isi upload (multipart field "file" atau body mentah) maksimal maxBytes
+func readUpload(w http.ResponseWriter, r *http.Request, maxBytes int64) (string, []byte, error) {
+	name := r.URL.Query().Get("name")
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+	if mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && mediaType == "multipart/form-data" {
+		reader := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				if err == io.EOF {
+					return "", nil, fmt.Errorf("multipart field 'file' missing")
+				}
+				return "", nil, err
+			}
+			if part.FormName() != "file" {
+				continue
+			}
+			content, err := io.ReadAll(part)
+			if name == "" {
+				name = part.FileName()
+			}
+			return name, content, err
+		}
+	}
+
+	content, err := io.ReadAll(r.Body)
+	return name, content, err
+}
+
+// scopedRouterID - Ambil ?router_id= yang dalam scope pemanggil (tulis 400/403 jika gagal)
+func scopedRouterID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
+	if err != nil || routerID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.MissingParameter,
+			Error:   i18n.T(r, i18n.MissingParameter, "'router_id'"),
+		})
+		return 0, false
+	}
+	if !auth.FromRequest(r).CanAccessRouter(routerID) {
+		auth.Forbidden(w, r)
+		return 0, false
+	}
+	return routerID, true
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// queryFilter - Parse ?filter= untuk list endpoint; false jika tidak valid (response 400 sudah ditulis)
+func queryFilter(w http.ResponseWriter, r *http.Request, fields []string) (*services.QueryFilter, bool) {
+	filter, err := services.ParseQueryFilter(r.URL.Query().Get("filter"), fields)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidFilter,
+			Error:   i18n.T(r, i18n.InvalidFilter, err),
+		})
+		return nil, false
+	}
+	return filter, true
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// GetAddressLists - GET /api/firewall/address-list?router_id=&filter=list="blocked"
+func GetAddressLists(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		filter, ok := queryFilter(w, r, services.AddressListFilterFields)
+		if !ok {
+			return
+		}
+
+		entries, err := ms.GetAddressListEntries(routerID, filter)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    entries,
+		})
+	}
+}
+
+// GetFirewallRules - GET /api/firewall/filter?router_id=&chain=
+// Rule filter sesuai urutan evaluasi di router, beserta counter byte / paket
+func GetFirewallRules(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		rules, err := ms.GetFirewallRules(routerID, r.URL.Query().Get("chain"))
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    rules,
+		})
+	}
+}
+
+// AddFirewallRule - POST /api/firewall/filter?router_id=[&dry_run=true], body FirewallRuleRequest
+// Comment rule dicek terhadap naming policy comment firewall
+func AddFirewallRule(ms *services.MikrotikService, naming *services.NamingPolicy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		var req models.FirewallRuleRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+
+		var errs validation.Errors
+		errs = append(errs, validation.Var("action", req.Action, "oneof="+strings.Join(models.FirewallActions, " "))...)
+		if req.Action == "jump" && (req.JumpTarget == nil || *req.JumpTarget == "") {
+			errs.Add("jump_target", validation.RuleRequired, "")
+		}
+		hasPort := (req.SrcPort != nil && *req.SrcPort != "") || (req.DstPort != nil && *req.DstPort != "")
+		if hasPort && (req.Protocol == nil || *req.Protocol == "") {
+			errs.Add("protocol", validation.RuleRequired, "")
+		}
+		if req.Comment != nil && *req.Comment != "" && !naming.Allows(services.NamingFirewallComment, *req.Comment) {
+			errs.Add("comment", validation.RulePattern, naming.Pattern(services.NamingFirewallComment))
+		}
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.AddFirewallRule(routerID, &req, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		if !dryRun {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.FirewallRuleAdded),
+			Message: planMessage(r, dryRun, i18n.FirewallRuleAdded),
+			Data:    plan,
+		})
+	}
+}
+
+// RemoveFirewallRule - DELETE /api/firewall/filter?router_id=&id=[&dry_run=true]
+func RemoveFirewallRule(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, id, ok := firewallRuleParams(w, r)
+		if !ok {
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.RemoveFirewallRule(routerID, id, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.FirewallRuleRemoved),
+			Message: planMessage(r, dryRun, i18n.FirewallRuleRemoved),
+			Data:    plan,
+		})
+	}
+}
+
+// SetFirewallRuleDisabled - POST /api/firewall/filter/enable|disable?router_id=&id=[&dry_run=true]
+func SetFirewallRuleDisabled(ms *services.MikrotikService, disabled bool) http.HandlerFunc {
+	code := i18n.FirewallRuleEnabled
+	if disabled {
+		code = i18n.FirewallRuleDisabled
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		routerID, id, ok := firewallRuleParams(w, r)
+		if !ok {
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.SetFirewallRuleDisabled(routerID, id, disabled, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, code),
+			Message: planMessage(r, dryRun, code),
+			Data:    plan,
+		})
+	}
+}
+
+// MoveFirewallRule - POST /api/firewall/filter/move?router_id=&id=&before=[&dry_run=true]
+// Rule dipindah ke posisi sebelum rule "before"; tanpa before dipindah ke akhir daftar
+func MoveFirewallRule(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		routerID, id, ok := firewallRuleParams(w, r)
+		if !ok {
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.MoveFirewallRule(routerID, id, r.URL.Query().Get("before"), dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.FirewallRuleMoved),
+			Message: planMessage(r, dryRun, i18n.FirewallRuleMoved),
+			Data:    plan,
+		})
+	}
+}
+
+// firewallRuleParams - router_id (dengan cek akses) dan id item (.id RouterOS) dari query string
+func firewallRuleParams(w http.ResponseWriter, r *http.Request) (int, string, bool) {
+	routerID, ok := scopedRouterID(w, r)
+	if !ok {
+		return 0, "", false
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.MissingParameter,
+			Error:   i18n.T(r, i18n.MissingParameter, "'id'"),
+		})
+		return 0, "", false
+	}
+	return routerID, id, true
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// TrafficFlowRequest - Body untuk konfigurasi export NetFlow/IPFIX router
+type TrafficFlowRequest struct {
+	Collector  string `json:"collector" validate:"host"`
+	Port       int    `json:"port" validate:"min=1,max=65535"`
+	Version    string `json:"version" validate:"oneof=9 ipfix"`
+	Interfaces string `json:"interfaces"` // default: all
+}
+
+// ConfigureTrafficFlow - POST /api/traffic-flow/configure?router_id=X
+// Tanpa "collector" di body, export diarahkan ke flow collector layer sendiri.
+func ConfigureTrafficFlow(ms *services.MikrotikService, defaultCollector string, defaultPort int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MethodNotAllowed,
+				Error:   i18n.T(r, i18n.MethodNotAllowed),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		var req TrafficFlowRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.InvalidRequestBody,
+					Error:   i18n.T(r, i18n.InvalidRequestBody, err),
+				})
+				return
+			}
+		}
+
+		if req.Collector == "" {
+			req.Collector = defaultCollector
+			if req.Port == 0 {
+				req.Port = defaultPort
+			}
+		}
+		if req.Port == 0 {
+			req.Port = 2055
+		}
+		if req.Version == "" {
+			req.Version = "9"
+		}
+		if req.Interfaces == "" {
+			req.Interfaces = "all"
+		}
+
+		// collector wajib jika flow collector layer tidak dikonfigurasi
+		errs := validation.Struct(&req)
+		if req.Collector == "" {
+			errs.Add("collector", validation.RuleRequired, "")
+		}
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.ConfigureTrafficFlow(routerID, req.Collector, req.Port, req.Version, req.Interfaces, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.TrafficFlowConfigured),
+			Message: planMessage(r, dryRun, i18n.TrafficFlowConfigured),
+			Data:    plan,
+		})
+	}
+}
+
+// GetFlows - GET /api/flows?router_id=X&from=&to=&src=&dst=&port=&protocol=&group_by=src|dst|port&limit=
+// Default rentang: 1 jam terakhir
+func GetFlows(repo *repository.FlowRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		from, to, err := parseTimeRange(r, time.Hour)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		filter := &models.FlowFilter{
+			RouterID:   routerID,
+			From:       from,
+			To:         to,
+			SrcAddress: q.Get("src"),
+			DstAddress: q.Get("dst"),
+			GroupBy:    q.Get("group_by"),
+		}
+
+		if !repository.ValidFlowGroupBy(filter.GroupBy) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidChoice,
+				Error:   i18n.T(r, i18n.InvalidChoice, "group_by", "src/dst/port"),
+			})
+			return
+		}
+
+		for name, dst := range map[string]*int{"port": &filter.Port, "protocol": &filter.Protocol, "limit": &filter.Limit} {
+			if v := q.Get(name); v != "" {
+				if *dst, err = strconv.Atoi(v); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ApiResponse{
+						Success: false,
+						Code:    i18n.NotANumber,
+						Error:   i18n.T(r, i18n.NotANumber, name),
+					})
+					return
+				}
+			}
+		}
+
+		flows, err := repo.Query(filter)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    flows,
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// ImportGraphing - POST /api/graphing/import?router_id=&interval=5m&dry_run=
+// Baca rule /tool/graphing router dan jalankan sampling padanannya di layer
+func ImportGraphing(sampler *services.TrafficSampler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MethodNotAllowed,
+				Error:   i18n.T(r, i18n.MethodNotAllowed),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		// Default 5 menit = resolusi grafik harian /tool/graphing
+		interval := 5 * time.Minute
+		if v := r.URL.Query().Get("interval"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d < 5*time.Second {
+				var errs validation.Errors
+				errs.Add("interval", validation.RuleMin, "5s")
+				writeValidationError(w, r, errs)
+				return
+			}
+			interval = d
+		}
+
+		dryRun := isDryRun(r)
+		items, err := sampler.ImportGraphing(routerID, interval, dryRun)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.GraphingImported),
+			Message: planMessage(r, dryRun, i18n.GraphingImported),
+			Data:    items,
+		})
+	}
+}
+
+// GetInterfaceGraph - GET /api/graphs/interface?router_id=&interface=&from=&to=&format=svg|png&width=&height=
+// Sparkline rx/tx dari traffic_history (default 24 jam terakhir) untuk di-embed di halaman status
+func GetInterfaceGraph(repo *repository.TrafficRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		query := r.URL.Query()
+		iface := query.Get("interface")
+		format := query.Get("format")
+		errs := validation.Var("interface", iface, "required")
+		errs = append(errs, validation.Var("format", format, "oneof=svg png")...)
+
+		size := map[string]int{"width": 300, "height": 60}
+		for _, name := range []string{"width", "height"} {
+			if v := query.Get(name); v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					errs.Add(name, validation.RuleMin, "10")
+					continue
+				}
+				errs = append(errs, validation.Var(name, n, "min=10,max=2000")...)
+				size[name] = n
+			}
+		}
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		from, to, err := parseTimeRange(r, 24*time.Hour)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		samples, err := repo.GetHistory(routerID, iface, from, to)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		var image []byte
+		if format == "png" {
+			if image, err = services.RenderSparklinePNG(samples, size["width"], size["height"]); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+			w.Header().Set("Content-Type", "image/png")
+		} else {
+			image = services.RenderSparklineSVG(samples, size["width"], size["height"])
+			w.Header().Set("Content-Type", "image/svg+xml")
+		}
+
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Content-Length", strconv.Itoa(len(image)))
+		w.Write(image)
+	}
+}
@@ -2,14 +2,106 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"Mikrotik-Layer/i18n"
 	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
 )
 
 func HealthCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(models.ApiResponse{
 		Success: true,
-		Message: "API berjalan normal",
+		Code:    i18n.APIHealthy,
+		Message: i18n.T(r, i18n.APIHealthy),
+		Data:    services.LeaderStatus(),
 	})
-}
\ No newline at end of file
+}
+// GetConnectionsHealthz - GET /api/connections/healthz
+// Ringkasan status router aktif untuk monitor uptime eksternal; 503 jika persen online di bawah
+// min_online_pct (router suspended tidak dihitung) atau health check sukses tertua lebih tua dari maxPingAge.
+// ?min_online_pct= menimpa ambang dari konfigurasi.
+func GetConnectionsHealthz(ms *services.MikrotikService, routerRepo *repository.RouterRepository, minOnlinePct float64, maxPingAge time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		threshold := minOnlinePct
+		if v := r.URL.Query().Get("min_online_pct"); v != "" {
+			var err error
+			if threshold, err = strconv.ParseFloat(v, 64); err != nil || threshold < 0 || threshold > 100 {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.OutOfRange,
+					Error:   i18n.T(r, i18n.OutOfRange, "min_online_pct", 0, 100, "%"),
+				})
+				return
+			}
+		}
+
+		routers, err := routerRepo.GetActiveRouters()
+		if err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		health := &models.ConnectionsHealth{Total: len(routers), OnlinePct: 100, MinOnlinePct: threshold}
+		for _, router := range routers {
+			switch router.Status {
+			case "online":
+				health.Online++
+			case "error":
+				health.Error++
+			case "suspended":
+				health.Suspended++
+			default:
+				health.Offline++
+			}
+		}
+		if monitored := health.Total - health.Suspended; monitored > 0 {
+			health.OnlinePct = float64(health.Online) / float64(monitored) * 100
+		}
+		if health.OnlinePct < threshold {
+			health.Reasons = append(health.Reasons, fmt.Sprintf("online %.1f%% below %.1f%%", health.OnlinePct, threshold))
+		}
+
+		scorer := services.GetHealthScorer()
+		for routerID, conn := range ms.GetAllConnections() {
+			if !conn.IsHealthy || conn.LastPing.IsZero() {
+				continue
+			}
+			if h := scorer.Get(routerID); h != nil && h.Level == models.HealthLevelDegraded {
+				health.Degraded++
+			}
+			if health.OldestLastPing == nil || conn.LastPing.Before(*health.OldestLastPing) {
+				lastPing, id := conn.LastPing, routerID
+				health.OldestLastPing, health.OldestRouterID = &lastPing, &id
+			}
+		}
+		if health.OldestLastPing != nil && maxPingAge > 0 {
+			if age := time.Since(*health.OldestLastPing); age > maxPingAge {
+				health.Reasons = append(health.Reasons, fmt.Sprintf("router %d last health check %s ago", *health.OldestRouterID, age.Round(time.Second)))
+			}
+		}
+		health.Healthy = len(health.Reasons) == 0
+
+		code := i18n.ConnectionsHealthy
+		if !health.Healthy {
+			code = i18n.ConnectionsDegraded
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: health.Healthy,
+			Code:    code,
+			Message: i18n.T(r, code),
+			Data:    health,
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/services"
+
+	"github.com/gorilla/websocket"
+)
+
+// EventsWS - WebSocket subscribe ke topic hub
+// Pattern: /ws/events?topics=syslog,events&router_id=1 (tanpa topics = semua)
+// Topic connections: state koneksi API router (connected, disconnected, reconnecting, auth_failed).
+// Token ber-scope hanya menerima pesan dari router dalam scope-nya. Pesan pertama hello berisi
+// versi protokol & topic yang tersedia; client bisa meminta versi lewat &protocol=N atau hello.
+func EventsWS(hub *services.Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("[WS-EVENTS] Error upgrade WebSocket: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		proto, ok := newWSProtocol(r)
+		if !ok {
+			conn.WriteJSON(unsupportedProtocol(r, r.URL.Query().Get("protocol")))
+			return
+		}
+		conn.WriteJSON(proto.hello(r, services.HubTopics))
+
+		var topics []string
+		for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+			if topic = strings.TrimSpace(topic); topic != "" {
+				topics = append(topics, topic)
+			}
+		}
+		routerID, _ := strconv.Atoi(r.URL.Query().Get("router_id"))
+
+		// User ber-scope hanya menerima pesan dari router miliknya
+		principal := auth.FromRequest(r)
+		if routerID != 0 && !principal.CanAccessRouter(routerID) {
+			conn.WriteJSON(map[string]interface{}{
+				"type":      "error",
+				"code":      i18n.Forbidden,
+				"error":     i18n.T(r, i18n.Forbidden),
+				"timestamp": time.Now(),
+			})
+			return
+		}
+
+		sub := hub.SubscribeScoped(topics, routerID, principal.RouterIDs(), 256)
+		defer hub.Unsubscribe(sub)
+
+		log.Printf("[WS-EVENTS] Subscriber %s - topics: %v, router: %d", r.RemoteAddr, topics, routerID)
+
+		var wsMutex sync.Mutex
+		done := make(chan struct{})
+
+		// Reader: deteksi disconnect & jawab ping
+		go func() {
+			defer services.Recover("ws-events-reader")
+			defer close(done)
+			for {
+				messageType, message, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if messageType != websocket.TextMessage {
+					continue
+				}
+				var cmd map[string]interface{}
+				if err := json.Unmarshal(message, &cmd); err != nil {
+					continue
+				}
+				switch cmd["type"] {
+				case "ping":
+					wsMutex.Lock()
+					conn.WriteJSON(map[string]interface{}{"type": "pong", "timestamp": time.Now()})
+					wsMutex.Unlock()
+				case "hello":
+					reply := proto.handleHello(r, cmd, services.HubTopics)
+					wsMutex.Lock()
+					conn.WriteJSON(reply)
+					wsMutex.Unlock()
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-done:
+				log.Printf("[WS-EVENTS] Subscriber %s disconnected", r.RemoteAddr)
+				return
+			case msg, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				wsMutex.Lock()
+				err := conn.WriteJSON(msg)
+				wsMutex.Unlock()
+				if err != nil {
+					log.Printf("[WS-EVENTS] Error sending to %s: %v", r.RemoteAddr, err)
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+const (
+	importMaxBytes = 5 << 20
+	importMaxRows  = 5000
+)
+
+type ImportHandler struct {
+	ms         *services.MikrotikService
+	routerRepo *repository.RouterRepository
+	planRepo   *repository.PlanRepository
+	imports    *services.ImportService
+	naming     *services.NamingPolicy
+}
+
+func NewImportHandler(ms *services.MikrotikService, routerRepo *repository.RouterRepository, planRepo *repository.PlanRepository, imports *services.ImportService, naming *services.NamingPolicy) *ImportHandler {
+	return &ImportHandler{ms: ms, routerRepo: routerRepo, planRepo: planRepo, imports: imports, naming: naming}
+}
+
+// ImportQueues - POST /api/queues/import?router_id=[&dry_run=true]
+// Body CSV dengan header: name,target,plan (plan = nama atau ID plan; limit queue dari plan)
+func (h *ImportHandler) ImportQueues(w http.ResponseWriter, r *http.Request) {
+	h.importCSV(w, r, models.JobActionQueueImport, "/queue/simple", "target")
+}
+
+// ImportPPPSecrets - POST /api/ppp/secrets/import?router_id=[&dry_run=true]
+// Body CSV dengan header: name,password,plan (secret memakai /ppp/profile plan)
+func (h *ImportHandler) ImportPPPSecrets(w http.ResponseWriter, r *http.Request) {
+	h.importCSV(w, r, models.JobActionPPPSecretImport, "/ppp/secret", "password")
+}
+
+// importCSV - Validasi semua baris dulu (422 dengan error per baris, tidak ada yang diprovisioning),
+// lalu provisioning di job background (202). Dry run mengembalikan baris yang valid beserta command plan-nya.
+func (h *ImportHandler) importCSV(w http.ResponseWriter, r *http.Request, action, menu, column string) {
+	routerID, ok := scopedRouterID(w, r)
+	if !ok {
+		return
+	}
+
+	router, err := h.routerRepo.GetByID(routerID)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	records, err := readImportCSV(w, r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidCSV,
+			Error:   i18n.T(r, i18n.InvalidCSV, err),
+		})
+		return
+	}
+
+	plans, err := h.planRepo.GetAll()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	existing, err := h.ms.ExistingNames(routerID, menu)
+	if err != nil {
+		w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	rows, byID, errs := parseImportRows(records, column, plans, existing, h.naming)
+	if len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	if isDryRun(r) {
+		previews, err := h.imports.Preview(action, routerID, rows, byID)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    i18n.DryRun,
+			Message: i18n.T(r, i18n.DryRun),
+			Data:    &models.ImportPreview{RouterID: routerID, Action: action, Rows: rows, Plans: previews},
+		})
+		return
+	}
+
+	createdBy := "api"
+	if p := auth.FromRequest(r); p != nil && p.Username != "" {
+		createdBy = p.Username
+	}
+
+	job, err := h.imports.Start(action, router, rows, byID, createdBy)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.JobStarted,
+		Message: i18n.T(r, i18n.JobStarted),
+		Data:    job,
+	})
+}
+
+// readImportCSV - Semua record CSV body (termasuk header), dibatasi importMaxBytes
+func readImportCSV(w http.ResponseWriter, r *http.Request) ([][]string, error) {
+	reader := csv.NewReader(http.MaxBytesReader(w, r.Body, importMaxBytes))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty file")
+	}
+	return records, nil
+}
+
+// parseImportRows - Validasi header dan semua baris. Field error memakai nomor baris file,
+// mis. "line[3].plan". Nama yang dobel di file, sudah ada di router atau tidak sesuai naming
+// policy ditolak.
+func parseImportRows(records [][]string, column string, plans []*models.Plan, existing map[string]bool, naming *services.NamingPolicy) ([]*models.ImportRow, map[int]*models.Plan, validation.Errors) {
+	var errs validation.Errors
+
+	kind := services.NamingPPPSecret
+	if column == "target" {
+		kind = services.NamingQueue
+	}
+
+	index := make(map[string]int)
+	for i, name := range records[0] {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"name", column, "plan"} {
+		if _, ok := index[name]; !ok {
+			errs.Add("header."+name, validation.RuleRequired, "")
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+
+	data := records[1:]
+	if len(data) == 0 {
+		errs.Add("rows", validation.RuleRequired, "")
+		return nil, nil, errs
+	}
+	if len(data) > importMaxRows {
+		errs.Add("rows", validation.RuleMax, strconv.Itoa(importMaxRows))
+		return nil, nil, errs
+	}
+
+	plansByName := make(map[string]*models.Plan, len(plans))
+	plansByID := make(map[int]*models.Plan, len(plans))
+	for _, p := range plans {
+		plansByName[p.Name] = p
+		plansByID[p.ID] = p
+	}
+
+	value := func(record []string, name string) string {
+		if i := index[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	rows := make([]*models.ImportRow, 0, len(data))
+	used := make(map[int]*models.Plan)
+	seen := make(map[string]int)
+	for i, record := range data {
+		line := i + 2
+		field := func(name string) string { return fmt.Sprintf("line[%d].%s", line, name) }
+
+		row := &models.ImportRow{Line: line, Name: value(record, "name"), Plan: value(record, "plan")}
+		errs = append(errs, validation.Var(field("name"), row.Name, "required,max=100")...)
+		if row.Name != "" && !naming.Allows(kind, row.Name) {
+			errs.Add(field("name"), validation.RulePattern, naming.Pattern(kind))
+		}
+		if first, ok := seen[row.Name]; ok && row.Name != "" {
+			errs.Add(field("name"), validation.RuleUnique, fmt.Sprintf("line %d", first))
+		} else if existing[row.Name] {
+			errs.Add(field("name"), validation.RuleUnique, "router")
+		}
+		seen[row.Name] = line
+
+		if column == "target" {
+			row.Target = value(record, "target")
+			if row.Target == "" {
+				errs.Add(field("target"), validation.RuleRequired, "")
+			} else if !validQueueTarget(row.Target) {
+				errs.Add(field("target"), validation.RuleIP, "")
+			}
+		} else {
+			row.Password = value(record, "password")
+			errs = append(errs, validation.Var(field("password"), row.Password, "required,max=100")...)
+		}
+
+		plan := plansByName[row.Plan]
+		if plan == nil {
+			if id, err := strconv.Atoi(row.Plan); err == nil {
+				plan = plansByID[id]
+			}
+		}
+		if row.Plan == "" {
+			errs.Add(field("plan"), validation.RuleRequired, "")
+		} else if plan == nil {
+			errs.Add(field("plan"), validation.RuleExists, "plan")
+		} else {
+			row.PlanID, row.Plan = plan.ID, plan.Name
+			used[plan.ID] = plan
+		}
+
+		rows = append(rows, row)
+	}
+	return rows, used, errs
+}
+
+// validQueueTarget - Target simple queue: IP atau CIDR, boleh beberapa dipisah koma
+func validQueueTarget(target string) bool {
+	for _, part := range strings.Split(target, ",") {
+		part = strings.TrimSpace(part)
+		if net.ParseIP(part) == nil {
+			if _, _, err := net.ParseCIDR(part); err != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
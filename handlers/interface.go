@@ -4,50 +4,81 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
+	"Mikrotik-Layer/dto"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/middleware"
 	"Mikrotik-Layer/models"
 	"Mikrotik-Layer/services"
 )
 
+// GetInterfaces - GET /api/interfaces?router_id=&filter=name~"wlan",running=true
 func GetInterfaces(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		filter, ok := queryFilter(w, r, services.InterfaceFilterFields)
+		if !ok {
+			return
+		}
+
+		interfaces, err := ms.GetInterfacesFiltered(routerID, filter)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'router_id' diperlukan dan harus valid",
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
-		interfaces, err := ms.GetInterfaces(routerID)
+		var data interface{} = interfaces
+		if middleware.IsV1(r) {
+			data = dto.NewInterfaces(interfaces)
+		}
+
+		writeCached(w, r, models.ApiResponse{
+			Success: true,
+			Data:    data,
+		})
+	}
+}
+
+// GetInterfaceTree - GET /api/interfaces/tree?router_id=1
+// Hierarki bridge→port, bonding→slave, parent→VLAN untuk render topologi di UI
+func GetInterfaceTree(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		tree, err := ms.GetInterfaceTree(routerID)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
-		json.NewEncoder(w).Encode(models.ApiResponse{
+		writeCached(w, r, models.ApiResponse{
 			Success: true,
-			Data:    interfaces,
+			Data:    tree,
 		})
 	}
 }
 
 func EnableInterface(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
 			return
 		}
 
@@ -56,37 +87,37 @@ func EnableInterface(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'name' diperlukan",
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'name'"),
 			})
 			return
 		}
 
-		err = ms.EnableInterface(routerID, name)
+		dryRun := isDryRun(r)
+		plan, err := ms.EnableInterface(routerID, name, dryRun)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Message: "Interface diaktifkan",
+			Code:    planCode(dryRun, i18n.InterfaceEnabled),
+			Message: planMessage(r, dryRun, i18n.InterfaceEnabled),
+			Data:    plan,
 		})
 	}
 }
 
 func DisableInterface(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
 			return
 		}
 
@@ -95,24 +126,29 @@ func DisableInterface(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'name' diperlukan",
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'name'"),
 			})
 			return
 		}
 
-		err = ms.DisableInterface(routerID, name)
+		dryRun := isDryRun(r)
+		plan, err := ms.DisableInterface(routerID, name, dryRun)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Message: "Interface dinonaktifkan",
+			Code:    planCode(dryRun, i18n.InterfaceDisabled),
+			Message: planMessage(r, dryRun, i18n.InterfaceDisabled),
+			Data:    plan,
 		})
 	}
 }
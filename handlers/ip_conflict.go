@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// GetIPConflicts - GET /api/audit/ip-conflicts?ignore=10.255.0.0/16,192.0.2.1&include_dynamic=true
+// Prefix di "ignore" ditambahkan ke IP_CONFLICT_IGNORE dari konfigurasi
+func GetIPConflicts(ms *services.MikrotikService, defaultIgnore string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ignore, err := services.ParseCIDRList(defaultIgnore + "," + r.URL.Query().Get("ignore"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidCIDRList,
+				Error:   i18n.T(r, i18n.InvalidCIDRList, "ignore", err),
+			})
+			return
+		}
+
+		includeDynamic, _ := strconv.ParseBool(r.URL.Query().Get("include_dynamic"))
+		report := ms.DetectIPConflicts(services.IPConflictOptions{
+			Ignore:         ignore,
+			IncludeDynamic: includeDynamic,
+		})
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    report,
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// GetRoutes - GET /api/routes?router_id=[&dynamic=true|false][&active=true|false][&routing_table=main][&dst_address=0.0.0.0/0]
+func GetRoutes(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		query := r.URL.Query()
+		filter := models.RouteFilter{
+			RoutingTable: query.Get("routing_table"),
+			DstAddress:   query.Get("dst_address"),
+		}
+		var err error
+		if filter.Dynamic, err = optionalBool(query, "dynamic"); err == nil {
+			filter.Active, err = optionalBool(query, "active")
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InvalidParameter),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		routes, err := ms.ListRoutes(routerID, filter)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    routes,
+		})
+	}
+}
+
+// AddRoute - POST /api/routes?router_id=[&dry_run=true], body RouteRequest
+// dst_address kosong = default route 0.0.0.0/0; untuk banyak router sekaligus pakai job route_add
+func AddRoute(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		var req models.RouteRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		if errs := normalizeRouteRequest(&req, "dst_address"); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.AddRoute(routerID, &req, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		if !dryRun && len(plan.Commands) > 0 {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.RouteAdded),
+			Message: planMessage(r, dryRun, i18n.RouteAdded),
+			Data:    plan,
+		})
+	}
+}
+
+// RemoveRoute - DELETE /api/routes?router_id=&id=[&dry_run=true]
+func RemoveRoute(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, id, ok := firewallRuleParams(w, r)
+		if !ok {
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.RemoveRoute(routerID, id, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.RouteRemoved),
+			Message: planMessage(r, dryRun, i18n.RouteRemoved),
+			Data:    plan,
+		})
+	}
+}
+
+// SetRouteDisabled - POST /api/routes/enable|disable?router_id=&id=[&dry_run=true]
+func SetRouteDisabled(ms *services.MikrotikService, disabled bool) http.HandlerFunc {
+	code := i18n.RouteEnabled
+	if disabled {
+		code = i18n.RouteDisabled
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		routerID, id, ok := firewallRuleParams(w, r)
+		if !ok {
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.SetRouteDisabled(routerID, id, disabled, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, code),
+			Message: planMessage(r, dryRun, code),
+			Data:    plan,
+		})
+	}
+}
+
+// normalizeRouteRequest - dst-address default 0.0.0.0/0 dan dinormalisasi ke alamat network
+// (seperti tampilan RouterOS) supaya cek duplikat cocok; hanya IPv4 (/ip/route)
+func normalizeRouteRequest(req *models.RouteRequest, field string) validation.Errors {
+	if req.DstAddress == "" {
+		req.DstAddress = "0.0.0.0/0"
+	}
+
+	var errs validation.Errors
+	_, network, err := net.ParseCIDR(req.DstAddress)
+	if err != nil || network.IP.To4() == nil {
+		errs.Add(field, validation.RuleCIDR, "")
+		return errs
+	}
+	req.DstAddress = network.String()
+	return errs
+}
+
+// optionalBool - Query boolean opsional: nil jika tidak diisi
+func optionalBool(query url.Values, name string) (*bool, error) {
+	v := query.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, i18n.NewError(i18n.InvalidParameter, name)
+	}
+	return &b, nil
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type IPAMHandler struct {
+	repo       *repository.SubnetRepository
+	routerRepo *repository.RouterRepository
+	ms         *services.MikrotikService
+}
+
+func NewIPAMHandler(repo *repository.SubnetRepository, routerRepo *repository.RouterRepository, ms *services.MikrotikService) *IPAMHandler {
+	return &IPAMHandler{repo: repo, routerRepo: routerRepo, ms: ms}
+}
+
+// GetSubnets - GET /api/ipam/subnets?router_id=
+func (h *IPAMHandler) GetSubnets(w http.ResponseWriter, r *http.Request) {
+	routerID := 0
+	if v := r.URL.Query().Get("router_id"); v != "" {
+		var err error
+		if routerID, err = strconv.Atoi(v); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.NotANumber,
+				Error:   i18n.T(r, i18n.NotANumber, "router_id"),
+			})
+			return
+		}
+	}
+
+	subnets, err := h.repo.List(routerID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    subnets,
+	})
+}
+
+// CreateSubnet - POST /api/ipam/subnets, mis. {"prefix":"10.20.0.0/22","name":"Pelanggan POP Timur","router_id":3}
+func (h *IPAMHandler) CreateSubnet(w http.ResponseWriter, r *http.Request) {
+	var req models.SubnetRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	subnet := &models.Subnet{}
+	if errs := h.mergeSubnetRequest(subnet, &req); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	created, err := h.repo.Create(subnet)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.SubnetCreated,
+		Message: i18n.T(r, i18n.SubnetCreated),
+		Data:    created,
+	})
+}
+
+// GetSubnet - GET /api/ipam/subnets/{id}
+func (h *IPAMHandler) GetSubnet(w http.ResponseWriter, r *http.Request) {
+	subnet, ok := h.subnetFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    subnet,
+	})
+}
+
+// UpdateSubnet - PUT /api/ipam/subnets/{id} (field yang diisi saja)
+func (h *IPAMHandler) UpdateSubnet(w http.ResponseWriter, r *http.Request) {
+	subnet, ok := h.subnetFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.SubnetRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if errs := h.mergeSubnetRequest(subnet, &req); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	if err := h.repo.Update(subnet); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.SubnetUpdated,
+		Message: i18n.T(r, i18n.SubnetUpdated),
+		Data:    subnet,
+	})
+}
+
+// DeleteSubnet - DELETE /api/ipam/subnets/{id}
+func (h *IPAMHandler) DeleteSubnet(w http.ResponseWriter, r *http.Request) {
+	id, ok := subnetIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.SubnetDeleted,
+		Message: i18n.T(r, i18n.SubnetDeleted),
+	})
+}
+
+// GetIPAMReport - GET /api/ipam/report
+// Utilisasi tiap subnet dari alamat interface dan pool live, plus alamat / pool router yang
+// tidak terdokumentasi di rencana IP
+func (h *IPAMHandler) GetIPAMReport(w http.ResponseWriter, r *http.Request) {
+	subnets, err := h.repo.List(0)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    h.ms.IPAMReport(subnets),
+	})
+}
+
+// mergeSubnetRequest - Terapkan field request yang diisi lalu cek field wajib, prefix unik
+// (disimpan sebagai alamat network) dan router
+func (h *IPAMHandler) mergeSubnetRequest(subnet *models.Subnet, req *models.SubnetRequest) validation.Errors {
+	var errs validation.Errors
+	if req.Prefix != "" {
+		if prefix, err := netip.ParsePrefix(req.Prefix); err == nil {
+			subnet.Prefix = prefix.Masked().String()
+		}
+	}
+	if req.Name != "" {
+		subnet.Name = req.Name
+	}
+	if req.Description != nil {
+		subnet.Description = req.Description
+	}
+	if req.RouterID != nil {
+		subnet.RouterID = req.RouterID
+		if *req.RouterID == 0 {
+			subnet.RouterID = nil
+		}
+	}
+	if req.VLANID != nil {
+		subnet.VLANID = req.VLANID
+		if *req.VLANID == 0 {
+			subnet.VLANID = nil
+		}
+	}
+
+	if subnet.Prefix == "" {
+		errs.Add("prefix", validation.RuleRequired, "")
+	} else if existing, err := h.repo.GetByPrefix(subnet.Prefix); err == nil && existing.ID != subnet.ID {
+		errs.Add("prefix", validation.RuleUnique, "")
+	}
+	if subnet.Name == "" {
+		errs.Add("name", validation.RuleRequired, "")
+	}
+	if subnet.RouterID != nil {
+		if _, err := h.routerRepo.GetByID(*subnet.RouterID); err != nil {
+			errs.Add("router_id", validation.RuleExists, "router")
+		}
+	}
+	return errs
+}
+
+// subnetFromPath - Ambil subnet {id} (tulis 400/404 jika gagal)
+func (h *IPAMHandler) subnetFromPath(w http.ResponseWriter, r *http.Request) (*models.Subnet, bool) {
+	id, ok := subnetIDFromPath(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	subnet, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return nil, false
+	}
+	return subnet, true
+}
+
+// subnetIDFromPath - Ambil {id} dari /api/ipam/subnets/{id}
+func subnetIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/ipam/subnets/"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "subnet"),
+		})
+		return 0, false
+	}
+	return id, true
+}
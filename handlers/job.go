@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type JobHandler struct {
+	repo       *repository.JobRepository
+	routerRepo *repository.RouterRepository
+	runner     *services.JobRunner
+	ms         *services.MikrotikService
+	confirms   *services.ConfirmationStore
+}
+
+func NewJobHandler(repo *repository.JobRepository, routerRepo *repository.RouterRepository, runner *services.JobRunner, ms *services.MikrotikService, confirms *services.ConfirmationStore) *JobHandler {
+	return &JobHandler{repo: repo, routerRepo: routerRepo, runner: runner, ms: ms, confirms: confirms}
+}
+
+// CreateJob - POST /api/jobs
+// Body: {"action":"command","command":["/system/identity/print"],"router_ids":[1,2]}
+// router_ids kosong = semua router yang terkoneksi. Job berjalan di background (202);
+// progres task lewat GET /api/jobs/{id} atau WebSocket /ws/events?topics=jobs.
+// Job route_add: {"action":"route_add","route":{"gateway":"10.0.0.1","distance":10},"router_ids":[1,2]}.
+// Job yang mengubah router (upgrade / route_add / command selain print) butuh konfirmasi dua langkah:
+// request pertama dijawab 409 + confirm_token, ulangi request yang sama dengan ?confirm_token=.
+// ?dry_run=true tidak membuat job, hanya mengembalikan command plan per router (tanpa konfirmasi).
+func (h *JobHandler) CreateJob(w http.ResponseWriter, r *http.Request) {
+	var req models.JobRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	var errs validation.Errors
+	if req.Action == models.JobActionCommand && len(req.Command) == 0 {
+		errs.Add("command", validation.RuleRequired, "")
+	}
+	for i, word := range req.Command {
+		if word == "" {
+			errs.Add("command["+strconv.Itoa(i)+"]", validation.RuleRequired, "")
+		}
+	}
+	if req.Action == models.JobActionRouteAdd {
+		if req.Route == nil {
+			errs.Add("route", validation.RuleRequired, "")
+		} else if routeErrs := validation.Struct(req.Route); len(routeErrs) > 0 {
+			for _, fe := range routeErrs {
+				errs.Add("route."+fe.Field, fe.Rule, fe.Param)
+			}
+		} else {
+			errs = append(errs, normalizeRouteRequest(req.Route, "route.dst_address")...)
+		}
+	}
+
+	var routers []*models.Router
+	for i, routerID := range req.RouterIDs {
+		field := "router_ids[" + strconv.Itoa(i) + "]"
+		if routerID < 1 {
+			errs.Add(field, validation.RuleRequired, "")
+		} else if router, err := h.routerRepo.GetByID(routerID); err != nil {
+			errs.Add(field, validation.RuleExists, "router")
+		} else {
+			routers = append(routers, router)
+		}
+	}
+	if len(req.RouterIDs) == 0 {
+		for _, conn := range h.ms.GetAllConnections() {
+			if conn.Router != nil {
+				routers = append(routers, conn.Router)
+			}
+		}
+		sort.Slice(routers, func(i, j int) bool { return routers[i].ID < routers[j].ID })
+		if len(routers) == 0 {
+			errs.Add("router_ids", validation.RuleRequired, "")
+		}
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	if isDryRun(r) {
+		previews, err := h.runner.Preview(&req, routers)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    i18n.DryRun,
+			Message: i18n.T(r, i18n.DryRun),
+			Data:    previews,
+		})
+		return
+	}
+
+	if jobNeedsConfirmation(&req) {
+		ids := make([]string, 0, len(routers))
+		targets := make([]map[string]interface{}, 0, len(routers))
+		for _, router := range routers {
+			ids = append(ids, strconv.Itoa(router.ID))
+			targets = append(targets, map[string]interface{}{"id": router.ID, "name": router.Name})
+		}
+		target := req.Action + "|" + strings.Join(req.Command, " ") + "|" + strings.Join(ids, ",")
+		impact := map[string]interface{}{
+			"action":  req.Action,
+			"command": req.Command,
+			"routers": targets,
+		}
+		if req.Route != nil {
+			route, _ := json.Marshal(req.Route)
+			target += "|" + string(route)
+			impact["route"] = req.Route
+		}
+		if !requireConfirmation(w, r, h.confirms, "job_create", target, impact, i18n.ConfirmationRequired) {
+			return
+		}
+	}
+
+	createdBy := "api"
+	if p := auth.FromRequest(r); p != nil && p.Username != "" {
+		createdBy = p.Username
+	}
+
+	job, err := h.runner.Start(&req, routers, createdBy)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.JobStarted,
+		Message: i18n.T(r, i18n.JobStarted),
+		Data:    job,
+	})
+}
+
+// jobNeedsConfirmation - Upgrade (reboot router) dan command yang bukan print dianggap destruktif
+func jobNeedsConfirmation(req *models.JobRequest) bool {
+	if req.Action != models.JobActionCommand {
+		return true
+	}
+	return len(req.Command) > 0 && !strings.HasSuffix(req.Command[0], "/print")
+}
+
+// GetJobs - GET /api/jobs?status=running|completed|failed|cancelled&limit=
+func (h *JobHandler) GetJobs(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	errs := validation.Var("status", status,
+		"oneof="+models.JobRunning+" "+models.JobCompleted+" "+models.JobFailed+" "+models.JobCancelled)
+	limit := 100
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			errs.Add("limit", validation.RuleMin, "1")
+		} else {
+			errs = append(errs, validation.Var("limit", limit, "min=1,max=1000")...)
+		}
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	jobs, err := h.repo.List(status, limit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    jobs,
+	})
+}
+
+// GetJob - GET /api/jobs/{id} (job beserta status & output per router)
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := h.jobFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    job,
+	})
+}
+
+// CancelJob - POST /api/jobs/{id}/cancel
+func (h *JobHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := h.jobFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.runner.Cancel(job.ID) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.JobNotRunning,
+			Error:   i18n.T(r, i18n.JobNotRunning, job.Status),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.JobCancelled,
+		Message: i18n.T(r, i18n.JobCancelled),
+	})
+}
+
+// jobFromPath - Ambil job {id} dari /api/jobs/{id}[/...] (tulis 400/404 jika gagal)
+func (h *JobHandler) jobFromPath(w http.ResponseWriter, r *http.Request) (*models.Job, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/jobs/")
+	id, err := strconv.ParseInt(strings.Split(path, "/")[0], 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "job"),
+		})
+		return nil, false
+	}
+
+	job, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return nil, false
+	}
+	return job, true
+}
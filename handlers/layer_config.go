@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type LayerConfigHandler struct {
+	routers  *repository.RouterRepository
+	plans    *repository.PlanRepository
+	channels *repository.NotificationChannelRepository
+	ms       *services.MikrotikService
+	audit    *services.AuditLogger
+}
+
+func NewLayerConfigHandler(routers *repository.RouterRepository, plans *repository.PlanRepository, channels *repository.NotificationChannelRepository, ms *services.MikrotikService, audit *services.AuditLogger) *LayerConfigHandler {
+	return &LayerConfigHandler{routers: routers, plans: plans, channels: channels, ms: ms, audit: audit}
+}
+
+// ExportConfig - GET /api/config/export (tanpa kredensial router) atau POST dengan body
+// {"secrets":"encrypted","passphrase":"..."}; data response adalah bundle untuk /api/config/import
+func (h *LayerConfigHandler) ExportConfig(w http.ResponseWriter, r *http.Request) {
+	req := models.ConfigExportRequest{Secrets: models.ConfigSecretsNone}
+	if r.Method == http.MethodPost && !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Secrets == "" {
+		req.Secrets = models.ConfigSecretsNone
+	}
+
+	passphrase := ""
+	if req.Secrets == models.ConfigSecretsEncrypted {
+		if errs := validation.Var("passphrase", req.Passphrase, "required,min=12"); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+		passphrase = req.Passphrase
+	}
+
+	bundle, err := services.ExportLayerConfig(h.routers, h.plans, h.channels, passphrase)
+	h.audit.Log(requestActor(r), "config_export", 0, "", map[string]string{"secrets": req.Secrets}, err)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    bundle,
+	})
+}
+
+// ImportConfig - POST /api/config/import[?dry_run=true]
+// Router, plan dan channel dicocokkan by name: yang belum ada dibuat, yang sudah ada diupdate
+// hanya jika overwrite=true. Router baru butuh password (bundle encrypted). Plan yang diupdate
+// tidak dipropagasi ke router; jalankan /api/plans/{id}/apply bila perlu.
+func (h *LayerConfigHandler) ImportConfig(w http.ResponseWriter, r *http.Request) {
+	var req models.ConfigImportRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := services.OpenLayerConfigSecrets(req.Bundle, req.Passphrase); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.BadRequest),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	routers, err := h.routers.GetAll()
+	var plans []*models.Plan
+	if err == nil {
+		plans, err = h.plans.GetAll()
+	}
+	var channels []*models.NotificationChannel
+	if err == nil {
+		channels, err = h.channels.List(false)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	dryRun := isDryRun(r)
+	actor := requestActor(r)
+	result := &models.ConfigImportResult{
+		DryRun:        dryRun,
+		Routers:       []*models.ConfigImportItem{},
+		Plans:         []*models.ConfigImportItem{},
+		AlertChannels: []*models.ConfigImportItem{},
+	}
+
+	routerByName := make(map[string]*models.Router, len(routers))
+	for _, router := range routers {
+		routerByName[router.Name] = router
+	}
+	for _, rt := range req.Bundle.Routers {
+		result.Routers = append(result.Routers, h.importRouter(rt, routerByName[rt.Name], req.Overwrite, dryRun, actor))
+	}
+
+	planByName := make(map[string]*models.Plan, len(plans))
+	for _, plan := range plans {
+		planByName[plan.Name] = plan
+	}
+	for _, p := range req.Bundle.Plans {
+		result.Plans = append(result.Plans, h.importPlan(p, planByName[p.Name], req.Overwrite, dryRun))
+	}
+
+	channelByName := make(map[string]*models.NotificationChannel, len(channels))
+	for _, channel := range channels {
+		channelByName[channel.Name] = channel
+	}
+	for _, c := range req.Bundle.AlertChannels {
+		result.AlertChannels = append(result.AlertChannels, h.importChannel(c, channelByName[c.Name], req.Overwrite, dryRun))
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.ConfigImported),
+		Message: planMessage(r, dryRun, i18n.ConfigImported),
+		Data:    result,
+	})
+}
+
+// importRouter - Buat / update satu router dari bundle; koneksi router yang diupdate di-dial ulang
+func (h *LayerConfigHandler) importRouter(rt *models.ConfigRouter, existing *models.Router, overwrite, dryRun bool, actor string) *models.ConfigImportItem {
+	item := &models.ConfigImportItem{Name: rt.Name}
+	if existing != nil && !overwrite {
+		item.Result = models.ConfigItemSkipped
+		return item
+	}
+
+	if existing == nil {
+		item.Result = models.ConfigItemCreated
+		if errs := validation.Struct(&rt.RouterCreateRequest); len(errs) > 0 {
+			return importFailed(item, errs)
+		}
+		if dryRun {
+			return item
+		}
+
+		router, err := h.routers.Create(&rt.RouterCreateRequest)
+		if err != nil {
+			return importFailed(item, err)
+		}
+		if router.IsActive != rt.IsActive {
+			err = h.routers.SetActive(router.ID, rt.IsActive)
+		}
+		h.audit.Log(actor, "config_import_router", router.ID, router.Name, map[string]string{"result": item.Result}, err)
+		if err != nil {
+			return importFailed(item, err)
+		}
+		return item
+	}
+
+	item.Result = models.ConfigItemUpdated
+	req := models.RouterUpdateRequest{
+		Name:                  &rt.Name,
+		Hostname:              &rt.Hostname,
+		Username:              &rt.Username,
+		Keepalive:             rt.Keepalive,
+		Timeout:               rt.Timeout,
+		Port:                  rt.Port,
+		Location:              rt.Location,
+		Description:           rt.Description,
+		WANInterfaces:         rt.WANInterfaces,
+		Tags:                  rt.Tags,
+		IsActive:              &rt.IsActive,
+		IsVirtual:             rt.IsVirtual,
+		AutoConnect:           rt.AutoConnect,
+		RouterContact:         rt.RouterContact,
+		RouterDial:            rt.RouterDial,
+		RouterJumpCredentials: rt.RouterJumpCredentials,
+	}
+	// Bundle tanpa kredensial: password lama dipertahankan
+	if rt.Password != "" {
+		req.Password = &rt.Password
+	}
+	if errs := validation.Struct(&req); len(errs) > 0 {
+		return importFailed(item, errs)
+	}
+	if dryRun {
+		return item
+	}
+
+	router, err := h.routers.Update(existing.ID, &req)
+	h.audit.Log(actor, "config_import_router", existing.ID, rt.Name, map[string]string{"result": item.Result}, err)
+	if err != nil {
+		return importFailed(item, err)
+	}
+	h.ms.ReloadRouter(router)
+	return item
+}
+
+// importPlan - Buat / update satu plan dari bundle (field kosong tidak menghapus nilai lama)
+func (h *LayerConfigHandler) importPlan(p *models.PlanCreateRequest, existing *models.Plan, overwrite, dryRun bool) *models.ConfigImportItem {
+	item := &models.ConfigImportItem{Name: p.Name}
+	if existing != nil && !overwrite {
+		item.Result = models.ConfigItemSkipped
+		return item
+	}
+	if errs := validation.Struct(p); len(errs) > 0 {
+		return importFailed(item, errs)
+	}
+
+	var err error
+	if existing == nil {
+		item.Result = models.ConfigItemCreated
+		if !dryRun {
+			_, err = h.plans.Create(p)
+		}
+	} else {
+		item.Result = models.ConfigItemUpdated
+		if !dryRun {
+			_, err = h.plans.Update(existing.ID, &models.PlanUpdateRequest{
+				Name:           &p.Name,
+				RateLimit:      &p.RateLimit,
+				BurstLimit:     p.BurstLimit,
+				BurstThreshold: p.BurstThreshold,
+				BurstTime:      p.BurstTime,
+				QuotaBytes:     p.QuotaBytes,
+				PPPProfile:     p.PPPProfile,
+				Description:    p.Description,
+			})
+		}
+	}
+	if err != nil {
+		return importFailed(item, err)
+	}
+	return item
+}
+
+// importChannel - Buat / update satu channel notifikasi alert dari bundle
+func (h *LayerConfigHandler) importChannel(c *models.NotificationChannelRequest, existing *models.NotificationChannel, overwrite, dryRun bool) *models.ConfigImportItem {
+	item := &models.ConfigImportItem{Name: c.Name}
+	if existing != nil && !overwrite {
+		item.Result = models.ConfigItemSkipped
+		return item
+	}
+
+	channel := &models.NotificationChannel{MinSeverity: "warning", RouterTags: []string{}, Enabled: true}
+	item.Result = models.ConfigItemCreated
+	if existing != nil {
+		updated := *existing
+		channel = &updated
+		item.Result = models.ConfigItemUpdated
+	}
+	errs := validation.Struct(c)
+	if len(errs) == 0 {
+		errs = mergeChannelRequest(channel, c)
+	}
+	if len(errs) > 0 {
+		return importFailed(item, errs)
+	}
+	if dryRun {
+		return item
+	}
+
+	var err error
+	if existing == nil {
+		_, err = h.channels.Create(channel)
+	} else {
+		err = h.channels.Update(channel)
+	}
+	if err != nil {
+		return importFailed(item, err)
+	}
+	return item
+}
+
+func importFailed(item *models.ConfigImportItem, err error) *models.ConfigImportItem {
+	item.Result = models.ConfigItemFailed
+	item.Error = err.Error()
+	return item
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// RemoteLoggingRequest - Body untuk konfigurasi forward log router
+type RemoteLoggingRequest struct {
+	Remote string   `json:"remote" validate:"host"`
+	Port   int      `json:"port" validate:"min=1,max=65535"`
+	Topics []string `json:"topics"`
+}
+
+// ConfigureRemoteLogging - POST /api/logging/remote?router_id=X
+// Tanpa "remote" di body, log diarahkan ke syslog receiver layer sendiri.
+func ConfigureRemoteLogging(ms *services.MikrotikService, defaultRemote string, defaultPort int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MethodNotAllowed,
+				Error:   i18n.T(r, i18n.MethodNotAllowed),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		var req RemoteLoggingRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.InvalidRequestBody,
+					Error:   i18n.T(r, i18n.InvalidRequestBody, err),
+				})
+				return
+			}
+		}
+
+		if req.Remote == "" {
+			req.Remote = defaultRemote
+			if req.Port == 0 {
+				req.Port = defaultPort
+			}
+		}
+		if req.Port == 0 {
+			req.Port = 514
+		}
+		if len(req.Topics) == 0 {
+			req.Topics = []string{"info", "warning", "error", "critical"}
+		}
+
+		// remote wajib jika syslog receiver layer tidak dikonfigurasi
+		errs := validation.Struct(&req)
+		if req.Remote == "" {
+			errs.Add("remote", validation.RuleRequired, "")
+		}
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.ConfigureRemoteLogging(routerID, req.Remote, req.Port, req.Topics, dryRun)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.RemoteLoggingConfigured),
+			Message: planMessage(r, dryRun, i18n.RemoteLoggingConfigured),
+			Data:    plan,
+		})
+	}
+}
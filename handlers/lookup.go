@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/validation"
+)
+
+// GetIPHistory - GET /api/lookup/ip-history?ip=&at= (at RFC3339, default sekarang)
+// Customer / PPP secret / MAC yang memegang IP pada waktu tsb dari riwayat sesi PPP dan lease DHCP
+func GetIPHistory(sessions *repository.PPPSessionRepository, leases *repository.LeaseHistoryRepository, customers *repository.CustomerRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ip := r.URL.Query().Get("ip")
+		if errs := validation.Var("ip", ip, "required,ip"); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		at := time.Now()
+		if v, err := parseTimeParam(r.URL.Query().Get("at")); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidTimeFormat,
+				Error:   i18n.T(r, i18n.InvalidTimeFormat, "at"),
+			})
+			return
+		} else if v != nil {
+			at = *v
+		}
+
+		routerIDs := auth.FromRequest(r).RouterIDs()
+		pppSessions, err := sessions.List(models.PPPSessionFilter{
+			RouterIDs: routerIDs,
+			Address:   ip,
+			From:      &at,
+			To:        &at,
+			Limit:     1000,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		dhcpLeases, err := leases.At(ip, at, routerIDs)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		// Customer dicocokkan lewat PPP secret (sesi) atau IP statis (lease); tidak cocok = nil
+		result := &models.IPHistoryResult{IP: ip, At: at, Matches: []*models.IPHistoryMatch{}}
+		for _, s := range pppSessions {
+			match := &models.IPHistoryMatch{
+				Source:   models.IPSourcePPPSession,
+				RouterID: s.RouterID,
+				Username: s.Username,
+				CallerID: s.CallerID,
+				From:     s.StartedAt,
+				To:       s.EndedAt,
+			}
+			match.Customer, _ = customers.GetByPPPSecret(s.RouterID, s.Username)
+			result.Matches = append(result.Matches, match)
+		}
+		for _, l := range dhcpLeases {
+			match := &models.IPHistoryMatch{
+				Source:     models.IPSourceDHCPLease,
+				RouterID:   l.RouterID,
+				MacAddress: l.MacAddress,
+				HostName:   l.HostName,
+				From:       l.FirstSeenAt,
+				To:         l.EndedAt,
+			}
+			match.Customer, _ = customers.GetByAddress(l.RouterID, l.Address)
+			result.Matches = append(result.Matches, match)
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    result,
+		})
+	}
+}
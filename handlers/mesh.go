@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// MeshPaths - /api/mesh/paths
+// GET: list semua monitored path, POST body MonitoredPathRequest: tambah path
+func MeshPaths(repo *repository.MeshRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			paths, err := repo.ListPaths(false)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    paths,
+			})
+
+		case http.MethodPost:
+			var req models.MonitoredPathRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+
+			path := &models.MonitoredPath{PingCount: services.DefaultPingCount, LossThresholdPct: 20, Enabled: true}
+			if errs := mergePathRequest(path, &req); len(errs) > 0 {
+				writeValidationError(w, r, errs)
+				return
+			}
+
+			created, err := repo.CreatePath(path)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.PathCreated,
+				Message: i18n.T(r, i18n.PathCreated),
+				Data:    created,
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// MeshPath - /api/mesh/paths/{id}: GET, PUT (field yang diisi saja), DELETE
+func MeshPath(repo *repository.MeshRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/mesh/paths/"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidID,
+				Error:   i18n.T(r, i18n.InvalidID, "path"),
+			})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodPut:
+			path, err := repo.GetPath(id)
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.NotFound),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			if r.Method == http.MethodGet {
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: true,
+					Data:    path,
+				})
+				return
+			}
+
+			var req models.MonitoredPathRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+
+			if errs := mergePathRequest(path, &req); len(errs) > 0 {
+				writeValidationError(w, r, errs)
+				return
+			}
+
+			if err := repo.UpdatePath(path); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.PathUpdated,
+				Message: i18n.T(r, i18n.PathUpdated),
+				Data:    path,
+			})
+
+		case http.MethodDelete:
+			if err := repo.DeletePath(id); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.PathDeleted,
+				Message: i18n.T(r, i18n.PathDeleted),
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// mergePathRequest - Terapkan field request yang diisi ke path lalu validasi; return pesan error
+func mergePathRequest(path *models.MonitoredPath, req *models.MonitoredPathRequest) validation.Errors {
+	if req.Name != "" {
+		path.Name = req.Name
+	}
+	if req.SourceRouterID != 0 {
+		path.SourceRouterID = req.SourceRouterID
+	}
+	if req.TargetRouterID != nil {
+		path.TargetRouterID = req.TargetRouterID
+	}
+	if req.TargetAddress != nil {
+		path.TargetAddress = req.TargetAddress
+	}
+	if req.PingCount != 0 {
+		path.PingCount = req.PingCount
+	}
+	if req.LatencyThresholdMs != nil {
+		path.LatencyThresholdMs = *req.LatencyThresholdMs
+	}
+	if req.LossThresholdPct != nil {
+		path.LossThresholdPct = *req.LossThresholdPct
+	}
+	if req.Enabled != nil {
+		path.Enabled = *req.Enabled
+	}
+
+	// Rentang nilai sudah dicek tag validate; di sini field wajib & lintas field setelah merge
+	var errs validation.Errors
+	if path.Name == "" {
+		errs.Add("name", validation.RuleRequired, "")
+	}
+	if path.SourceRouterID == 0 {
+		errs.Add("source_router_id", validation.RuleRequired, "")
+	}
+	switch {
+	case path.TargetRouterID == nil && (path.TargetAddress == nil || *path.TargetAddress == ""):
+		errs.Add("target_router_id", validation.RuleRequired, "")
+	case path.TargetRouterID != nil && *path.TargetRouterID == path.SourceRouterID:
+		errs.Add("target_router_id", validation.RuleDiffers, "source_router_id")
+	}
+	return errs
+}
+
+// GetMeshMatrix - GET /api/mesh/matrix
+// Status & sample terakhir semua path, plus matrix[source_router_id][target] untuk tampilan grid
+func GetMeshMatrix(repo *repository.MeshRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		paths, err := repo.ListPaths(false)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		latest, err := repo.LatestSamples()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		cells := make([]*models.MeshCell, 0, len(paths))
+		matrix := make(map[string]map[string]*models.MeshCell)
+		for _, p := range paths {
+			cell := &models.MeshCell{
+				PathID:         p.ID,
+				Name:           p.Name,
+				SourceRouterID: p.SourceRouterID,
+				TargetRouterID: p.TargetRouterID,
+				Target:         pathTargetLabel(p),
+				State:          p.State,
+				Latest:         latest[p.ID],
+			}
+			cells = append(cells, cell)
+
+			source := strconv.Itoa(p.SourceRouterID)
+			if matrix[source] == nil {
+				matrix[source] = make(map[string]*models.MeshCell)
+			}
+			matrix[source][cell.Target] = cell
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data: map[string]interface{}{
+				"paths":  cells,
+				"matrix": matrix,
+			},
+		})
+	}
+}
+
+// pathTargetLabel - Kunci kolom matrix: ID router tujuan, atau alamat bebas
+func pathTargetLabel(p *models.MonitoredPath) string {
+	if p.TargetRouterID != nil {
+		return strconv.Itoa(*p.TargetRouterID)
+	}
+	if p.TargetAddress != nil {
+		return *p.TargetAddress
+	}
+	return fmt.Sprintf("path-%d", p.ID)
+}
+
+// GetPathHistory - GET /api/mesh/history?path_id=X&from=&to= (default 24 jam terakhir)
+func GetPathHistory(repo *repository.MeshRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pathID, err := strconv.Atoi(r.URL.Query().Get("path_id"))
+		if err != nil || pathID == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'path_id'"),
+			})
+			return
+		}
+
+		from, to, err := parseTimeRange(r, 24*time.Hour)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		samples, err := repo.History(pathID, from, to)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    samples,
+		})
+	}
+}
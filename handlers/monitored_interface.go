@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+)
+
+// defaultMonitoredInterval - Interval sampling monitored interface jika tidak disebutkan
+const defaultMonitoredInterval = 30
+
+// GetMonitoredInterfaces - GET /api/routers/{id}/monitored-interfaces
+// Interface yang sampler-nya selalu berjalan (warm standby) beserta status sampling terakhir
+func (h *RouterHandler) GetMonitoredInterfaces(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := routerAddressPath(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    h.sampler.Monitored(id),
+	})
+}
+
+// SetMonitoredInterfaces - PUT /api/routers/{id}/monitored-interfaces, body MonitoredInterfacesRequest
+// Mengganti seluruh daftar; daftar kosong menghentikan semua monitored interface router
+func (h *RouterHandler) SetMonitoredInterfaces(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := routerAddressPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.MonitoredInterfacesRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if _, err := h.repo.GetByID(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	interval := defaultMonitoredInterval
+	if req.IntervalSeconds != nil {
+		interval = *req.IntervalSeconds
+	}
+
+	seen := make(map[string]bool)
+	ifaces := []string{}
+	for _, iface := range req.Interfaces {
+		if iface = strings.TrimSpace(iface); iface != "" && !seen[iface] {
+			seen[iface] = true
+			ifaces = append(ifaces, iface)
+		}
+	}
+
+	if err := h.sampler.SetMonitored(id, ifaces, time.Duration(interval)*time.Second); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.MonitoredIfacesUpdated,
+		Message: i18n.T(r, i18n.MonitoredIfacesUpdated),
+		Data:    h.sampler.Monitored(id),
+	})
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// GetNamingReport - GET /api/naming/report?router_id=
+// Queue, PPP secret dan comment firewall yang sudah ada di router tapi tidak sesuai naming
+// policy (NAMING_POLICY_*), untuk dirapikan. Tipe tanpa policy tidak dicek.
+func GetNamingReport(ms *services.MikrotikService, naming *services.NamingPolicy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		report, err := ms.NamingReport(routerID, naming)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    report,
+		})
+	}
+}
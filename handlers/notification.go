@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type NotificationChannelHandler struct {
+	repo    *repository.NotificationChannelRepository
+	routing *services.AlertRouter
+}
+
+func NewNotificationChannelHandler(repo *repository.NotificationChannelRepository, routing *services.AlertRouter) *NotificationChannelHandler {
+	return &NotificationChannelHandler{repo: repo, routing: routing}
+}
+
+// GetAllChannels - GET /api/notification-channels
+func (h *NotificationChannelHandler) GetAllChannels(w http.ResponseWriter, r *http.Request) {
+	channels, err := h.repo.List(false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    channels,
+	})
+}
+
+// CreateChannel - POST /api/notification-channels
+func (h *NotificationChannelHandler) CreateChannel(w http.ResponseWriter, r *http.Request) {
+	var req models.NotificationChannelRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	channel := &models.NotificationChannel{MinSeverity: "warning", RouterTags: []string{}, Enabled: true}
+	if errs := mergeChannelRequest(channel, &req); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	created, err := h.repo.Create(channel)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ChannelCreated,
+		Message: i18n.T(r, i18n.ChannelCreated),
+		Data:    created,
+	})
+}
+
+// GetChannel - GET /api/notification-channels/{id}
+func (h *NotificationChannelHandler) GetChannel(w http.ResponseWriter, r *http.Request) {
+	channel, ok := h.channelFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    channel,
+	})
+}
+
+// UpdateChannel - PUT /api/notification-channels/{id} (field yang diisi saja)
+func (h *NotificationChannelHandler) UpdateChannel(w http.ResponseWriter, r *http.Request) {
+	channel, ok := h.channelFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.NotificationChannelRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if errs := mergeChannelRequest(channel, &req); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	if err := h.repo.Update(channel); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ChannelUpdated,
+		Message: i18n.T(r, i18n.ChannelUpdated),
+		Data:    channel,
+	})
+}
+
+// DeleteChannel - DELETE /api/notification-channels/{id}
+func (h *NotificationChannelHandler) DeleteChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := channelIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ChannelDeleted,
+		Message: i18n.T(r, i18n.ChannelDeleted),
+	})
+}
+
+// TestChannel - POST /api/notification-channels/{id}/test
+// Kirim alert contoh langsung ke channel (mengabaikan tag, severity dan jam tenang) untuk
+// mengecek URL webhook / konfigurasi SMTP
+func (h *NotificationChannelHandler) TestChannel(w http.ResponseWriter, r *http.Request) {
+	channel, ok := h.channelFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	alert := &models.Alert{
+		Type:        "notification_test",
+		Severity:    channel.MinSeverity,
+		Message:     "Test notification for channel " + channel.Name,
+		Status:      models.AlertStatusOpen,
+		Occurrences: 1,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	}
+	if err := h.routing.Send(channel, alert); err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ChannelTested,
+		Message: i18n.T(r, i18n.ChannelTested),
+	})
+}
+
+// mergeChannelRequest - Terapkan field request yang diisi lalu cek field wajib, target sesuai
+// tipe channel dan jam tenang (start dan end harus diisi bersamaan)
+func mergeChannelRequest(channel *models.NotificationChannel, req *models.NotificationChannelRequest) validation.Errors {
+	if req.Name != "" {
+		channel.Name = req.Name
+	}
+	if req.Type != "" {
+		channel.Type = req.Type
+	}
+	if req.Target != "" {
+		channel.Target = req.Target
+	}
+	if req.RouterTags != nil {
+		channel.RouterTags = []string{}
+		for _, tag := range *req.RouterTags {
+			channel.RouterTags = append(channel.RouterTags, strings.TrimSpace(tag))
+		}
+	}
+	if req.MinSeverity != "" {
+		channel.MinSeverity = req.MinSeverity
+	}
+	if req.QuietStart != nil {
+		channel.QuietStart = req.QuietStart
+		if *req.QuietStart == "" {
+			channel.QuietStart = nil
+		}
+	}
+	if req.QuietEnd != nil {
+		channel.QuietEnd = req.QuietEnd
+		if *req.QuietEnd == "" {
+			channel.QuietEnd = nil
+		}
+	}
+	if req.Enabled != nil {
+		channel.Enabled = *req.Enabled
+	}
+
+	var errs validation.Errors
+	if channel.Name == "" {
+		errs.Add("name", validation.RuleRequired, "")
+	}
+	if channel.Type == "" {
+		errs.Add("type", validation.RuleRequired, "")
+	}
+	switch {
+	case channel.Target == "":
+		errs.Add("target", validation.RuleRequired, "")
+	case channel.Type == models.ChannelWebhook:
+		errs = append(errs, validation.Var("target", channel.Target, "url")...)
+	case channel.Type == models.ChannelEmail:
+		for _, address := range strings.Split(channel.Target, ",") {
+			errs = append(errs, validation.Var("target", strings.TrimSpace(address), "required,email")...)
+		}
+	}
+	for i, tag := range channel.RouterTags {
+		errs = append(errs, validation.Var("router_tags["+strconv.Itoa(i)+"]", tag, "required,max=50")...)
+	}
+	if (channel.QuietStart == nil) != (channel.QuietEnd == nil) {
+		if channel.QuietStart == nil {
+			errs.Add("quiet_start", validation.RuleRequired, "")
+		} else {
+			errs.Add("quiet_end", validation.RuleRequired, "")
+		}
+	}
+	if channel.QuietStart != nil {
+		errs = append(errs, validation.Var("quiet_start", *channel.QuietStart, "clock")...)
+	}
+	if channel.QuietEnd != nil {
+		errs = append(errs, validation.Var("quiet_end", *channel.QuietEnd, "clock")...)
+	}
+	return errs
+}
+
+// channelFromPath - Ambil channel {id} (tulis 400/404 jika gagal)
+func (h *NotificationChannelHandler) channelFromPath(w http.ResponseWriter, r *http.Request) (*models.NotificationChannel, bool) {
+	id, ok := channelIDFromPath(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	channel, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return nil, false
+	}
+	return channel, true
+}
+
+// channelIDFromPath - Ambil {id} dari /api/notification-channels/{id}[/...]
+func channelIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/notification-channels/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "notification channel"),
+		})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+type PlanHandler struct {
+	repo    *repository.PlanRepository
+	service *services.PlanService
+}
+
+func NewPlanHandler(repo *repository.PlanRepository, service *services.PlanService) *PlanHandler {
+	return &PlanHandler{repo: repo, service: service}
+}
+
+// planUpdateResult - Plan setelah update beserta hasil propagasi per router
+type planUpdateResult struct {
+	Plan    *models.Plan              `json:"plan"`
+	Results []*models.PlanApplyResult `json:"results"`
+}
+
+// CreatePlan - POST /api/plans
+func (h *PlanHandler) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	var req models.PlanCreateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	plan, err := h.repo.Create(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.PlanCreated,
+		Message: i18n.T(r, i18n.PlanCreated),
+		Data:    plan,
+	})
+}
+
+// GetAllPlans - GET /api/plans
+func (h *PlanHandler) GetAllPlans(w http.ResponseWriter, r *http.Request) {
+	plans, err := h.repo.GetAll()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    plans,
+	})
+}
+
+// GetPlanByID - GET /api/plans/{id}
+func (h *PlanHandler) GetPlanByID(w http.ResponseWriter, r *http.Request) {
+	id, ok := planIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    plan,
+	})
+}
+
+// UpdatePlan - PUT /api/plans/{id}[?dry_run=true]
+// Perubahan langsung dipropagasi ke queue/PPP secret semua customer yang memakai plan.
+// Dry run tidak menyimpan plan, hanya menampilkan command per router.
+func (h *PlanHandler) UpdatePlan(w http.ResponseWriter, r *http.Request) {
+	id, ok := planIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.PlanUpdateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	dryRun := isDryRun(r)
+
+	var plan *models.Plan
+	var err error
+	if dryRun {
+		plan, err = h.repo.GetByID(id)
+		if err == nil {
+			mergePlanUpdate(plan, &req)
+		}
+	} else {
+		plan, err = h.repo.Update(id, &req)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	results, err := h.service.Propagate(plan, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.PlanUpdated),
+		Message: planMessage(r, dryRun, i18n.PlanUpdated),
+		Data:    planUpdateResult{Plan: plan, Results: results},
+	})
+}
+
+// ApplyPlan - POST /api/plans/{id}/apply[?dry_run=true]
+// Terapkan ulang plan ke semua customer (mis. setelah router offline saat update)
+func (h *PlanHandler) ApplyPlan(w http.ResponseWriter, r *http.Request) {
+	id, ok := planIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	plan, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	dryRun := isDryRun(r)
+	results, err := h.service.Propagate(plan, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.PlanApplied),
+		Message: planMessage(r, dryRun, i18n.PlanApplied),
+		Data:    planUpdateResult{Plan: plan, Results: results},
+	})
+}
+
+// DeletePlan - DELETE /api/plans/{id}
+func (h *PlanHandler) DeletePlan(w http.ResponseWriter, r *http.Request) {
+	id, ok := planIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.PlanDeleted,
+		Message: i18n.T(r, i18n.PlanDeleted),
+	})
+}
+
+// planIDFromPath - Ambil {id} dari /api/plans/{id}[/...]
+func planIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/plans/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "plan"),
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// mergePlanUpdate - Terapkan field update ke plan di memori (untuk dry run)
+func mergePlanUpdate(plan *models.Plan, req *models.PlanUpdateRequest) {
+	if req.Name != nil {
+		plan.Name = *req.Name
+	}
+	if req.RateLimit != nil {
+		plan.RateLimit = *req.RateLimit
+	}
+	if req.BurstLimit != nil {
+		plan.BurstLimit = req.BurstLimit
+	}
+	if req.BurstThreshold != nil {
+		plan.BurstThreshold = req.BurstThreshold
+	}
+	if req.BurstTime != nil {
+		plan.BurstTime = req.BurstTime
+	}
+	if req.QuotaBytes != nil {
+		plan.QuotaBytes = req.QuotaBytes
+	}
+	if req.PPPProfile != nil {
+		plan.PPPProfile = req.PPPProfile
+	}
+	if req.Description != nil {
+		plan.Description = req.Description
+	}
+}
@@ -3,92 +3,153 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
+	"strings"
 
+	"Mikrotik-Layer/dto"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/middleware"
 	"Mikrotik-Layer/models"
 	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
 )
 
+// GetQueues - GET /api/queues?router_id=&filter=disabled=false,name~"cust"
 func GetQueues(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		filter, ok := queryFilter(w, r, services.QueueFilterFields)
+		if !ok {
 			return
 		}
 
-		queues, err := ms.GetQueues(routerID)
+		queues, err := ms.GetQueuesFiltered(routerID, filter)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
+		var data interface{} = queues
+		if middleware.IsV1(r) {
+			data = dto.NewQueues(queues)
+		}
+
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Data:    queues,
+			Data:    data,
 		})
 	}
 }
 
-func AddQueue(ms *services.MikrotikService) http.HandlerFunc {
+// GetQueuesByTarget - GET /api/queues/by-target?router_id=&address= (IP, CIDR atau target lain)
+func GetQueuesByTarget(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		address := strings.TrimSpace(r.URL.Query().Get("address"))
+		if address == "" {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'address'"),
 			})
 			return
 		}
 
-		name := r.URL.Query().Get("name")
-		target := r.URL.Query().Get("target")
-		maxLimit := r.URL.Query().Get("max-limit")
-
-		if name == "" || target == "" || maxLimit == "" {
-			w.WriteHeader(http.StatusBadRequest)
+		queues, err := ms.GetQueuesByTarget(routerID, address)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'name', 'target', dan 'max-limit' diperlukan",
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
-		err = ms.AddQueue(routerID, name, target, maxLimit)
+		var data interface{} = queues
+		if middleware.IsV1(r) {
+			data = dto.NewQueues(queues)
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    data,
+		})
+	}
+}
+
+func AddQueue(ms *services.MikrotikService, webhooks *services.WebhookDispatcher, naming *services.NamingPolicy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		name := r.URL.Query().Get("name")
+		target := r.URL.Query().Get("target")
+		maxLimitParam := "max-limit"
+		if middleware.IsV1(r) {
+			maxLimitParam = "max_limit"
+		}
+		maxLimit := r.URL.Query().Get(maxLimitParam)
+
+		errs := validation.Var("name", name, "required")
+		if name != "" && !naming.Allows(services.NamingQueue, name) {
+			errs.Add("name", validation.RulePattern, naming.Pattern(services.NamingQueue))
+		}
+		errs = append(errs, validation.Var("target", target, "required")...)
+		errs = append(errs, validation.Var(maxLimitParam, maxLimit, "required,rate_limit")...)
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.AddQueue(routerID, name, target, maxLimit, dryRun)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
+		if !dryRun {
+			webhooks.Emit(models.WebhookQueueCreated, &routerID, map[string]string{
+				"name":      name,
+				"target":    target,
+				"max_limit": maxLimit,
+			})
+		}
+
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Message: "Queue berhasil ditambahkan",
+			Code:    planCode(dryRun, i18n.QueueAdded),
+			Message: planMessage(r, dryRun, i18n.QueueAdded),
+			Data:    plan,
 		})
 	}
 }
 
-func RemoveQueue(ms *services.MikrotikService) http.HandlerFunc {
+func RemoveQueue(ms *services.MikrotikService, webhooks *services.WebhookDispatcher) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
 			return
 		}
 
@@ -97,24 +158,33 @@ func RemoveQueue(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'id' diperlukan",
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'id'"),
 			})
 			return
 		}
 
-		err = ms.RemoveQueue(routerID, id)
+		dryRun := isDryRun(r)
+		plan, name, err := ms.RemoveQueue(routerID, id, dryRun)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
+		if !dryRun {
+			webhooks.Emit(models.WebhookQueueRemoved, &routerID, map[string]string{"id": id, "name": name})
+		}
+
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Message: "Queue berhasil dihapus",
+			Code:    planCode(dryRun, i18n.QueueRemoved),
+			Message: planMessage(r, dryRun, i18n.QueueRemoved),
+			Data:    plan,
 		})
 	}
 }
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+// Reports - /api/reports
+// GET ?scope=&target_id=&month=YYYY-MM: list metadata, POST body ReportRequest: generate on demand
+func Reports(gen *services.ReportGenerator, repo *repository.ReportRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			filter := &models.ReportFilter{Scope: r.URL.Query().Get("scope")}
+			if v := r.URL.Query().Get("target_id"); v != "" {
+				id, err := strconv.Atoi(v)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ApiResponse{
+						Success: false,
+						Code:    i18n.NotANumber,
+						Error:   i18n.T(r, i18n.NotANumber, "target_id"),
+					})
+					return
+				}
+				filter.TargetID = id
+			}
+			if v := r.URL.Query().Get("month"); v != "" {
+				month, err := time.ParseInLocation("2006-01", v, time.Local)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ApiResponse{
+						Success: false,
+						Code:    i18n.InvalidMonth,
+						Error:   i18n.T(r, i18n.InvalidMonth, "month"),
+					})
+					return
+				}
+				filter.Period = &month
+			}
+
+			reports, err := repo.List(filter)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    reports,
+			})
+
+		case http.MethodPost:
+			var req models.ReportRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+
+			now := time.Now()
+			month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).AddDate(0, -1, 0)
+			if req.Month != "" {
+				parsed, err := time.ParseInLocation("2006-01", req.Month, time.Local)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ApiResponse{
+						Success: false,
+						Code:    i18n.InvalidMonth,
+						Error:   i18n.T(r, i18n.InvalidMonth, "month"),
+					})
+					return
+				}
+				month = parsed
+			}
+
+			report, err := gen.Generate(req.Scope, req.TargetID, month)
+			if err != nil {
+				status := http.StatusInternalServerError
+				if strings.HasSuffix(err.Error(), "not found") {
+					status = http.StatusNotFound
+				}
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.StatusCode(status)),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			if report.Status == models.ReportStatusFailed {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ReportFailed,
+					Error:   i18n.T(r, i18n.ReportFailed),
+					Data:    report,
+				})
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.ReportCreated,
+				Message: i18n.T(r, i18n.ReportCreated),
+				Data:    report,
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// Report - /api/reports/{id}: GET metadata, DELETE; /api/reports/{id}/download: GET PDF
+func Report(repo *repository.ReportRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/reports/"), "/")
+		id, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) > 2 || (len(parts) == 2 && parts[1] != "download") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidID,
+				Error:   i18n.T(r, i18n.InvalidID, "report"),
+			})
+			return
+		}
+
+		if len(parts) == 2 {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			downloadReport(w, r, repo, id)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			report, err := repo.GetByID(id)
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.NotFound),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    report,
+			})
+
+		case http.MethodDelete:
+			if err := repo.Delete(id); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.ReportDeleted,
+				Message: i18n.T(r, i18n.ReportDeleted),
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func downloadReport(w http.ResponseWriter, r *http.Request, repo *repository.ReportRepository, id int) {
+	report, err := repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	if report.Status != models.ReportStatusReady {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ReportNotReady,
+			Error:   i18n.T(r, i18n.ReportNotReady, report.Status),
+		})
+		return
+	}
+
+	content, err := repo.GetContent(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%d-%s.pdf", report.Scope, report.TargetID, report.Period.Format("2006-01"))
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Write(content)
+}
@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/validation"
+)
+
+type ResellerHandler struct {
+	repo       *repository.ResellerRepository
+	routerRepo *repository.RouterRepository
+	usageRepo  *repository.UsageRepository
+	alertRepo  *repository.AlertRepository
+}
+
+func NewResellerHandler(repo *repository.ResellerRepository, routerRepo *repository.RouterRepository,
+	usageRepo *repository.UsageRepository, alertRepo *repository.AlertRepository) *ResellerHandler {
+	return &ResellerHandler{repo: repo, routerRepo: routerRepo, usageRepo: usageRepo, alertRepo: alertRepo}
+}
+
+// GetAllResellers - GET /api/resellers
+func (h *ResellerHandler) GetAllResellers(w http.ResponseWriter, r *http.Request) {
+	resellers, err := h.repo.GetAll()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    resellers,
+	})
+}
+
+// CreateReseller - POST /api/resellers
+func (h *ResellerHandler) CreateReseller(w http.ResponseWriter, r *http.Request) {
+	var req models.ResellerRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	reseller, err := h.repo.Create(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ResellerCreated,
+		Message: i18n.T(r, i18n.ResellerCreated),
+		Data:    reseller,
+	})
+}
+
+// GetReseller - GET /api/resellers/{id} (admin atau user milik reseller)
+func (h *ResellerHandler) GetReseller(w http.ResponseWriter, r *http.Request) {
+	reseller, ok := h.resellerFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    reseller,
+	})
+}
+
+// UpdateReseller - PUT /api/resellers/{id}
+func (h *ResellerHandler) UpdateReseller(w http.ResponseWriter, r *http.Request) {
+	id, ok := resellerIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ResellerRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	reseller, err := h.repo.Update(id, &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.HasSuffix(err.Error(), "not found") {
+			status = http.StatusNotFound
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.StatusCode(status)),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ResellerUpdated,
+		Message: i18n.T(r, i18n.ResellerUpdated),
+		Data:    reseller,
+	})
+}
+
+// DeleteReseller - DELETE /api/resellers/{id} (router dilepas, user reseller ikut terhapus)
+func (h *ResellerHandler) DeleteReseller(w http.ResponseWriter, r *http.Request) {
+	id, ok := resellerIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ResellerDeleted,
+		Message: i18n.T(r, i18n.ResellerDeleted),
+	})
+}
+
+// SetResellerRouters - PUT /api/resellers/{id}/routers
+// Body {"router_ids": [1, 2]} menggantikan semua router reseller; router milik reseller lain dipindahkan
+func (h *ResellerHandler) SetResellerRouters(w http.ResponseWriter, r *http.Request) {
+	id, ok := resellerIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ResellerRoutersRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	var errs validation.Errors
+	for i, routerID := range req.RouterIDs {
+		field := "router_ids[" + strconv.Itoa(i) + "]"
+		if routerID < 1 {
+			errs.Add(field, validation.RuleRequired, "")
+		} else if _, err := h.routerRepo.GetByID(routerID); err != nil {
+			errs.Add(field, validation.RuleExists, "router")
+		}
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	if _, err := h.repo.GetByID(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	if err := h.repo.SetRouters(id, req.RouterIDs); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	reseller, _ := h.repo.GetByID(id)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ResellerRoutersUpdated,
+		Message: i18n.T(r, i18n.ResellerRoutersUpdated),
+		Data:    reseller,
+	})
+}
+
+// GetResellerUsage - GET /api/resellers/{id}/usage?period=day|month&from=&to=
+// Total pemakaian queue per router milik reseller (default period month, rentang 1 tahun)
+func (h *ResellerHandler) GetResellerUsage(w http.ResponseWriter, r *http.Request) {
+	reseller, ok := h.resellerFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	period, span, ok := usagePeriod(w, r)
+	if !ok {
+		return
+	}
+
+	from, to, err := parseTimeRange(r, span)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.BadRequest),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	totals, err := h.usageRepo.RouterTotals(reseller.RouterIDs, period, from, to)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	usage := &models.ResellerUsage{
+		ResellerID: reseller.ID,
+		Period:     period,
+		From:       from,
+		To:         to,
+		Routers:    totals,
+	}
+	for _, t := range totals {
+		usage.UploadBytes += t.UploadBytes
+		usage.DownloadBytes += t.DownloadBytes
+	}
+	usage.TotalBytes = usage.UploadBytes + usage.DownloadBytes
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    usage,
+	})
+}
+
+// GetResellerAlerts - GET /api/resellers/{id}/alerts
+// Alert aktif router milik reseller, format sama dengan /api/alerts/active
+func (h *ResellerHandler) GetResellerAlerts(w http.ResponseWriter, r *http.Request) {
+	reseller, ok := h.resellerFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	alerts, err := h.alertRepo.ListActive()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	owned := make(map[int]bool, len(reseller.RouterIDs))
+	for _, id := range reseller.RouterIDs {
+		owned[id] = true
+	}
+	filtered := []*models.Alert{}
+	for _, alert := range alerts {
+		if alert.RouterID != nil && owned[*alert.RouterID] {
+			filtered = append(filtered, alert)
+		}
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    groupAlerts(filtered),
+	})
+}
+
+// resellerFromPath - Ambil reseller {id} yang boleh diakses pemanggil (tulis 400/403/404 jika gagal)
+func (h *ResellerHandler) resellerFromPath(w http.ResponseWriter, r *http.Request) (*models.Reseller, bool) {
+	id, ok := resellerIDFromPath(w, r)
+	if !ok {
+		return nil, false
+	}
+	if !auth.FromRequest(r).CanAccessReseller(id) {
+		auth.Forbidden(w, r)
+		return nil, false
+	}
+
+	reseller, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return nil, false
+	}
+	return reseller, true
+}
+
+// resellerIDFromPath - Ambil {id} dari /api/resellers/{id}[/...]
+func resellerIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/resellers/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "reseller"),
+		})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// RouteFailovers - /api/route-failovers
+// GET: list route failover (router dalam scope), POST body RouteFailoverRequest: tambah pasangan route
+func RouteFailovers(repo *repository.RouteFailoverRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal := auth.FromRequest(r)
+
+		switch r.Method {
+		case http.MethodGet:
+			failovers, err := repo.List(false)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			visible := []*models.RouteFailover{}
+			for _, f := range failovers {
+				if principal.CanAccessRouter(f.RouterID) {
+					visible = append(visible, f)
+				}
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    visible,
+			})
+
+		case http.MethodPost:
+			var req models.RouteFailoverRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+
+			failover := &models.RouteFailover{Enabled: true, State: models.RouteStateUnknown}
+			if errs := mergeRouteFailoverRequest(failover, &req); len(errs) > 0 {
+				writeValidationError(w, r, errs)
+				return
+			}
+			if !principal.CanAccessRouter(failover.RouterID) {
+				auth.Forbidden(w, r)
+				return
+			}
+
+			created, err := repo.Create(failover)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.RouteFailoverCreated,
+				Message: i18n.T(r, i18n.RouteFailoverCreated),
+				Data:    created,
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// RouteFailover - /api/route-failovers/{id}: GET, PUT (field yang diisi saja), DELETE
+func RouteFailover(repo *repository.RouteFailoverRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/route-failovers/"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidID,
+				Error:   i18n.T(r, i18n.InvalidID, "route failover"),
+			})
+			return
+		}
+
+		if r.Method != http.MethodGet && r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		failover, err := repo.GetByID(id)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.NotFound),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		principal := auth.FromRequest(r)
+		if !principal.CanAccessRouter(failover.RouterID) {
+			auth.Forbidden(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    failover,
+			})
+
+		case http.MethodPut:
+			var req models.RouteFailoverRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+
+			if errs := mergeRouteFailoverRequest(failover, &req); len(errs) > 0 {
+				writeValidationError(w, r, errs)
+				return
+			}
+			if !principal.CanAccessRouter(failover.RouterID) {
+				auth.Forbidden(w, r)
+				return
+			}
+
+			if err := repo.Update(failover); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.RouteFailoverUpdated,
+				Message: i18n.T(r, i18n.RouteFailoverUpdated),
+				Data:    failover,
+			})
+
+		case http.MethodDelete:
+			if err := repo.Delete(id); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.RouteFailoverDeleted,
+				Message: i18n.T(r, i18n.RouteFailoverDeleted),
+			})
+		}
+	}
+}
+
+// mergeRouteFailoverRequest - Terapkan field request yang diisi ke failover lalu cek field wajib
+func mergeRouteFailoverRequest(f *models.RouteFailover, req *models.RouteFailoverRequest) validation.Errors {
+	if req.Name != "" {
+		f.Name = req.Name
+	}
+	if req.RouterID != 0 {
+		f.RouterID = req.RouterID
+	}
+	if req.DstAddress != "" {
+		f.DstAddress = req.DstAddress
+	}
+	if req.PrimaryGateway != "" {
+		f.PrimaryGateway = req.PrimaryGateway
+	}
+	if req.BackupGateway != "" {
+		f.BackupGateway = req.BackupGateway
+	}
+	if req.Notify != nil {
+		f.Notify = *req.Notify
+	}
+	if req.Enabled != nil {
+		f.Enabled = *req.Enabled
+	}
+
+	var errs validation.Errors
+	if f.Name == "" {
+		errs.Add("name", validation.RuleRequired, "")
+	}
+	if f.RouterID == 0 {
+		errs.Add("router_id", validation.RuleRequired, "")
+	}
+	if f.DstAddress == "" {
+		errs.Add("dst_address", validation.RuleRequired, "")
+	}
+	if f.PrimaryGateway == "" {
+		errs.Add("primary_gateway", validation.RuleRequired, "")
+	}
+	switch {
+	case f.BackupGateway == "":
+		errs.Add("backup_gateway", validation.RuleRequired, "")
+	case f.BackupGateway == f.PrimaryGateway:
+		errs.Add("backup_gateway", validation.RuleDiffers, "primary_gateway")
+	}
+	return errs
+}
+
+// GetWANStatus - GET /api/wan/status?router_id=X
+// Ringkasan multi-WAN: default route aktif, state route failover dan status netwatch
+func GetWANStatus(ms *services.MikrotikService, repo *repository.RouteFailoverRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		failovers, err := repo.List(false)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		status, err := ms.GetWANStatus(routerID, failovers)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    status,
+		})
+	}
+}
+
+// SwitchRouteFailover - POST /api/route-failovers/{id}/switchover | /restore (?dry_run=true)
+// switchover: disable route primary sehingga trafik lewat backup; restore: enable lagi
+func SwitchRouteFailover(repo *repository.RouteFailoverRepository, watchdog *services.FailoverWatchdog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/route-failovers/"), "/")
+		id, err := strconv.Atoi(parts[0])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidID,
+				Error:   i18n.T(r, i18n.InvalidID, "route failover"),
+			})
+			return
+		}
+		restore := parts[len(parts)-1] == "restore"
+
+		failover, err := repo.GetByID(id)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.NotFound),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		if !auth.FromRequest(r).CanAccessRouter(failover.RouterID) {
+			auth.Forbidden(w, r)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := watchdog.Switch(failover, restore, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		code := i18n.RouteSwitchedOver
+		if restore {
+			code = i18n.RouteRestored
+		}
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, code),
+			Message: planMessage(r, dryRun, code),
+			Data:    plan,
+		})
+	}
+}
@@ -2,31 +2,35 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
 	"Mikrotik-Layer/models"
 	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
 )
 
 type RouterHandler struct {
-	repo *repository.RouterRepository
+	repo     *repository.RouterRepository
+	ms       *services.MikrotikService
+	sampler  *services.TrafficSampler
+	confirms *services.ConfirmationStore
 }
 
-func NewRouterHandler(repo *repository.RouterRepository) *RouterHandler {
-	return &RouterHandler{repo: repo}
+func NewRouterHandler(repo *repository.RouterRepository, ms *services.MikrotikService, sampler *services.TrafficSampler, confirms *services.ConfirmationStore) *RouterHandler {
+	return &RouterHandler{repo: repo, ms: ms, sampler: sampler, confirms: confirms}
 }
 
 // CreateRouter - POST /api/routers
 func (h *RouterHandler) CreateRouter(w http.ResponseWriter, r *http.Request) {
 	var req models.RouterCreateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.ApiResponse{
-			Success: false,
-			Error:   "Invalid request body: " + err.Error(),
-		})
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
@@ -35,47 +39,127 @@ func (h *RouterHandler) CreateRouter(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   err.Error(),
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
 
 	json.NewEncoder(w).Encode(models.ApiResponse{
 		Success: true,
-		Message: "Router berhasil ditambahkan",
+		Code:    i18n.RouterCreated,
+		Message: i18n.T(r, i18n.RouterCreated),
 		Data:    router,
 	})
 }
 
-// GetAllRouters - GET /api/routers
+// GetAllRouters - GET /api/routers (opsional ?q= untuk pencarian, ?sort=health terburuk dulu)
 func (h *RouterHandler) GetAllRouters(w http.ResponseWriter, r *http.Request) {
-	routers, err := h.repo.GetAll()
+	format, err := exportFormat(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.BadRequest),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	var routers []*models.Router
+	if q := strings.TrimSpace(r.URL.Query().Get("q")); q != "" {
+		if errs := validation.Var("q", q, fmt.Sprintf("min=%d", repository.RouterSearchMinLength)); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+		routers, err = h.repo.Search(q)
+	} else {
+		routers, err = h.repo.GetAll()
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   err.Error(),
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
 
-	json.NewEncoder(w).Encode(models.ApiResponse{
+	routers = scopeRouters(r, routers)
+	attachHealth(r, routers)
+
+	if format != "" {
+		streamExport(w, format, "routers", routerExportColumns, func(tw tableWriter) error {
+			for _, rt := range routers {
+				var score interface{}
+				if rt.Health != nil {
+					score = rt.Health.Score
+				}
+				if err := tw.WriteRow(rt.ID, rt.UUID, rt.Name, rt.Hostname, rt.Port, rt.Location, rt.Status,
+					rt.IsActive, rt.IsVirtual, rt.Version, rt.Uptime, rt.LastSeen, rt.CreatedAt, score); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		return
+	}
+
+	writeCached(w, r, models.ApiResponse{
 		Success: true,
 		Data:    routers,
 	})
 }
 
+// routerExportColumns - Kolom export daftar router (tanpa kredensial)
+var routerExportColumns = []string{"id", "uuid", "name", "hostname", "port", "location", "status",
+	"is_active", "is_virtual", "version", "uptime", "last_seen", "created_at", "health_score"}
+
+// scopeRouters - Router dalam scope pemanggil (reseller / user ber-scope), tanpa password untuk non-admin
+func scopeRouters(r *http.Request, routers []*models.Router) []*models.Router {
+	principal := auth.FromRequest(r)
+	visible := make([]*models.Router, 0, len(routers))
+	for _, rt := range routers {
+		if principal.CanAccessRouter(rt.ID) {
+			visible = append(visible, redactRouter(r, rt))
+		}
+	}
+	return visible
+}
+
+// redactRouter - Kosongkan password RouterOS jika pemanggil bukan admin
+func redactRouter(r *http.Request, router *models.Router) *models.Router {
+	if !auth.FromRequest(r).IsAdmin() {
+		router.Password = ""
+	}
+	return router
+}
+
+// attachHealth - Isi skor kesehatan tiap router; ?sort=health mengurutkan terburuk dulu
+// (router tanpa skor, mis. belum terkoneksi/suspended, di akhir)
+func attachHealth(r *http.Request, routers []*models.Router) {
+	scorer := services.GetHealthScorer()
+	for _, rt := range routers {
+		rt.Health = scorer.Get(rt.ID)
+	}
+
+	if r.URL.Query().Get("sort") != "health" {
+		return
+	}
+	sort.SliceStable(routers, func(i, j int) bool {
+		a, b := routers[i].Health, routers[j].Health
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Score < b.Score
+	})
+}
+
 // GetRouterByID - GET /api/routers/{id}
 func (h *RouterHandler) GetRouterByID(w http.ResponseWriter, r *http.Request) {
-	// Extract ID from URL path
-	path := strings.TrimPrefix(r.URL.Path, "/api/routers/")
-	id, err := strconv.Atoi(path)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.ApiResponse{
-			Success: false,
-			Error:   "Invalid router ID",
-		})
+	id, ok := routerPathID(w, r)
+	if !ok {
 		return
 	}
 
@@ -84,36 +168,42 @@ func (h *RouterHandler) GetRouterByID(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   err.Error(),
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
 
-	json.NewEncoder(w).Encode(models.ApiResponse{
+	writeCached(w, r, models.ApiResponse{
 		Success: true,
-		Data:    router,
+		Data:    redactRouter(r, router),
 	})
 }
 
-// GetActiveRouters - GET /api/routers/active
+// GetActiveRouters - GET /api/routers/active (?sort=health terburuk dulu)
 func (h *RouterHandler) GetActiveRouters(w http.ResponseWriter, r *http.Request) {
 	routers, err := h.repo.GetActiveRouters()
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   err.Error(),
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
 
-	json.NewEncoder(w).Encode(models.ApiResponse{
+	routers = scopeRouters(r, routers)
+	attachHealth(r, routers)
+
+	writeCached(w, r, models.ApiResponse{
 		Success: true,
 		Data:    routers,
 	})
 }
 
 // UpdateRouter - PUT /api/routers/{id}
+// Perubahan hostname/port/kredensial langsung diterapkan ke koneksi aktif (reconnect)
 func (h *RouterHandler) UpdateRouter(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/api/routers/")
 	id, err := strconv.Atoi(path)
@@ -121,18 +211,14 @@ func (h *RouterHandler) UpdateRouter(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   "Invalid router ID",
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
 		})
 		return
 	}
 
 	var req models.RouterUpdateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.ApiResponse{
-			Success: false,
-			Error:   "Invalid request body: " + err.Error(),
-		})
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
@@ -141,14 +227,19 @@ func (h *RouterHandler) UpdateRouter(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   err.Error(),
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
 
+	// Alamat / kredensial berubah: koneksi aktif di-dial ulang dan monitor ikut pindah
+	h.ms.ReloadRouter(router)
+
 	json.NewEncoder(w).Encode(models.ApiResponse{
 		Success: true,
-		Message: "Router berhasil diupdate",
+		Code:    i18n.RouterUpdated,
+		Message: i18n.T(r, i18n.RouterUpdated),
 		Data:    router,
 	})
 }
@@ -161,7 +252,8 @@ func (h *RouterHandler) UpdateRouterStatus(w http.ResponseWriter, r *http.Reques
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   "Invalid URL",
+			Code:    i18n.InvalidURL,
+			Error:   i18n.T(r, i18n.InvalidURL),
 		})
 		return
 	}
@@ -171,18 +263,14 @@ func (h *RouterHandler) UpdateRouterStatus(w http.ResponseWriter, r *http.Reques
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   "Invalid router ID",
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
 		})
 		return
 	}
 
 	var req models.RouterStatusUpdate
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.ApiResponse{
-			Success: false,
-			Error:   "Invalid request body: " + err.Error(),
-		})
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
@@ -190,14 +278,16 @@ func (h *RouterHandler) UpdateRouterStatus(w http.ResponseWriter, r *http.Reques
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   err.Error(),
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
 
 	json.NewEncoder(w).Encode(models.ApiResponse{
 		Success: true,
-		Message: "Status router berhasil diupdate",
+		Code:    i18n.RouterStatusUpdated,
+		Message: i18n.T(r, i18n.RouterStatusUpdated),
 	})
 }
 
@@ -209,7 +299,8 @@ func (h *RouterHandler) SetActiveRouter(w http.ResponseWriter, r *http.Request)
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   "Invalid URL",
+			Code:    i18n.InvalidURL,
+			Error:   i18n.T(r, i18n.InvalidURL),
 		})
 		return
 	}
@@ -219,7 +310,8 @@ func (h *RouterHandler) SetActiveRouter(w http.ResponseWriter, r *http.Request)
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   "Invalid router ID",
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
 		})
 		return
 	}
@@ -227,12 +319,7 @@ func (h *RouterHandler) SetActiveRouter(w http.ResponseWriter, r *http.Request)
 	var req struct {
 		IsActive bool `json:"is_active"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(models.ApiResponse{
-			Success: false,
-			Error:   "Invalid request body: " + err.Error(),
-		})
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
@@ -240,23 +327,30 @@ func (h *RouterHandler) SetActiveRouter(w http.ResponseWriter, r *http.Request)
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   err.Error(),
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
 
-	status := "diaktifkan"
+	code := i18n.RouterActivated
 	if !req.IsActive {
-		status = "dinonaktifkan"
+		code = i18n.RouterDeactivated
+		// Router nonaktif tidak ikut health check: lepas koneksinya
+		h.ms.DisconnectRouter(id)
 	}
 
 	json.NewEncoder(w).Encode(models.ApiResponse{
 		Success: true,
-		Message: "Router berhasil " + status,
+		Code:    code,
+		Message: i18n.T(r, code),
 	})
 }
 
-// DeleteRouter - DELETE /api/routers/{id}
+// DeleteRouter - DELETE /api/routers/{id}[?confirm_token=]
+// Tanpa token hanya mengembalikan dampak penghapusan + confirm_token (409). Dengan token yang
+// valid, stream monitor, sampler dan koneksi router dibersihkan dulu lalu router (beserta data
+// turunannya) dihapus.
 func (h *RouterHandler) DeleteRouter(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/api/routers/")
 	id, err := strconv.Atoi(path)
@@ -264,22 +358,107 @@ func (h *RouterHandler) DeleteRouter(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   "Invalid router ID",
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
+		})
+		return
+	}
+
+	if _, err := h.repo.GetByID(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	impact, err := h.repo.DeleteImpact(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
+	_, impact.Connected = h.ms.GetAllConnections()[id]
+	impact.ActiveStreams = h.ms.ActiveStreams(id)
+	impact.TrafficSamplers = h.sampler.CountRouter(id)
+
+	// Langkah pertama mengembalikan dampak + token; hapus hanya dengan ?confirm_token=
+	if !requireConfirmation(w, r, h.confirms, "router_delete", "router:"+strconv.Itoa(id), impact, i18n.RouterDeleteUnconfirmed) {
+		return
+	}
+
+	h.sampler.StopRouter(id)
+	h.ms.RemoveRouter(id)
 
 	if err := h.repo.Delete(id); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: false,
-			Error:   err.Error(),
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.RouterDeleted,
+		Message: i18n.T(r, i18n.RouterDeleted),
+		Data:    impact,
+	})
+}
+
+// SuspendRouter - POST /api/routers/{id}/suspend
+func (h *RouterHandler) SuspendRouter(w http.ResponseWriter, r *http.Request) {
+	h.toggleSuspend(w, r, true)
+}
+
+// ResumeRouter - POST /api/routers/{id}/resume
+func (h *RouterHandler) ResumeRouter(w http.ResponseWriter, r *http.Request) {
+	h.toggleSuspend(w, r, false)
+}
+
+func (h *RouterHandler) toggleSuspend(w http.ResponseWriter, r *http.Request, suspend bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/routers/")
+	parts := strings.Split(path, "/")
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
+		})
+		return
+	}
+
+	code := i18n.RouterSuspended
+	if suspend {
+		err = h.ms.SuspendRouter(id)
+	} else {
+		err = h.ms.ResumeRouter(id)
+		code = i18n.RouterResumed
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
 		})
 		return
 	}
 
 	json.NewEncoder(w).Encode(models.ApiResponse{
 		Success: true,
-		Message: "Router berhasil dihapus",
+		Code:    code,
+		Message: i18n.T(r, code),
 	})
-}
\ No newline at end of file
+}
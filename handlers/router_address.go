@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+)
+
+// routerAddressPath - Router ID dan (opsional) address ID dari /api/routers/{id}/addresses[/{addressID}]
+func routerAddressPath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/routers/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
+		})
+		return 0, 0, false
+	}
+
+	addressID := 0
+	if len(parts) > 2 {
+		if addressID, err = strconv.Atoi(parts[2]); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidID,
+				Error:   i18n.T(r, i18n.InvalidID, "address"),
+			})
+			return 0, 0, false
+		}
+	}
+
+	if !auth.FromRequest(r).CanAccessRouter(id) {
+		auth.Forbidden(w, r)
+		return 0, 0, false
+	}
+	return id, addressID, true
+}
+
+// ListRouterAddresses - GET /api/routers/{id}/addresses
+// Alamat management tambahan yang dicoba setelah hostname saat connect
+func (h *RouterHandler) ListRouterAddresses(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := routerAddressPath(w, r)
+	if !ok {
+		return
+	}
+
+	addresses, err := h.repo.ListAddresses(id)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InternalError,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    addresses,
+	})
+}
+
+// AddRouterAddress - POST /api/routers/{id}/addresses, body RouterAddressRequest
+// Berlaku pada connect / reconnect berikutnya
+func (h *RouterHandler) AddRouterAddress(w http.ResponseWriter, r *http.Request) {
+	id, _, ok := routerAddressPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.RouterAddressRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if _, err := h.repo.GetByID(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.NotFound,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	address, err := h.repo.AddAddress(id, &req)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InternalError,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.RouterAddressAdded,
+		Message: i18n.T(r, i18n.RouterAddressAdded),
+		Data:    address,
+	})
+}
+
+// DeleteRouterAddress - DELETE /api/routers/{id}/addresses/{addressID}
+func (h *RouterHandler) DeleteRouterAddress(w http.ResponseWriter, r *http.Request) {
+	id, addressID, ok := routerAddressPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteAddress(id, addressID); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.NotFound,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.RouterAddressDeleted,
+		Message: i18n.T(r, i18n.RouterAddressDeleted),
+	})
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+
+	"github.com/gorilla/websocket"
+)
+
+// GetRouterLogs - GET /api/logs?router_id=X[&topics=firewall,error][&limit=200]
+// Tail memory log router (/log/print), default 100 entry terakhir, urut lama ke baru
+func GetRouterLogs(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		var errs validation.Errors
+		limit := 100
+		if v := r.URL.Query().Get("limit"); v != "" {
+			var err error
+			if limit, err = strconv.Atoi(v); err != nil {
+				errs.Add("limit", validation.RuleMin, "1")
+			} else {
+				errs = append(errs, validation.Var("limit", limit, "min=1,max=1000")...)
+			}
+		}
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		logs, err := ms.GetRouterLogs(routerID, logTopics(r), limit)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    logs,
+		})
+	}
+}
+
+// LogMessage - Message stream /ws/logs/follow
+type LogMessage struct {
+	Type      string                 `json:"type"`
+	Data      *models.RouterLogEntry `json:"data,omitempty"`
+	Code      string                 `json:"code,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+	Message   string                 `json:"message,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// FollowRouterLogsWS - WebSocket entry log router yang baru masuk
+// Pattern: /ws/logs/follow?router_id=1[&topics=firewall,error]
+// Mengirim log_entry per entry plus lifecycle stream_started, stream_error, stream_ended,
+// router_offline, stream_resumed dan stream_queued seperti /ws/traffic/monitor, diawali hello.
+func FollowRouterLogsWS(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("[WS-LOGS] Error upgrade WebSocket: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		proto, ok := newWSProtocol(r)
+		if !ok {
+			conn.WriteJSON(unsupportedProtocol(r, r.URL.Query().Get("protocol")))
+			return
+		}
+		conn.WriteJSON(proto.hello(r, nil))
+
+		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
+		if err != nil || routerID == 0 {
+			conn.WriteJSON(LogMessage{
+				Type:      "error",
+				Code:      i18n.MissingParameter,
+				Error:     i18n.T(r, i18n.MissingParameter, "'router_id'"),
+				Timestamp: time.Now(),
+			})
+			return
+		}
+
+		principal := auth.FromRequest(r)
+		if !principal.CanAccessRouter(routerID) {
+			log.Printf("[WS-LOGS] User %s not allowed to follow logs of router %d", principal.Username, routerID)
+			conn.WriteJSON(LogMessage{
+				Type:      "error",
+				Code:      i18n.Forbidden,
+				Error:     i18n.T(r, i18n.Forbidden),
+				Timestamp: time.Now(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var wsMutex sync.Mutex
+		wsOpen := true
+		send := func(msg LogMessage) {
+			wsMutex.Lock()
+			defer wsMutex.Unlock()
+			if !wsOpen {
+				return
+			}
+			if err := conn.WriteJSON(msg); err != nil {
+				log.Printf("[WS-LOGS] Error sending data (router %d): %v", routerID, err)
+				wsOpen = false
+				cancel()
+			}
+		}
+
+		// Stream ditutup server jika router dihapus
+		unregister := ms.RegisterStream(routerID, func(reason string) {
+			send(LogMessage{
+				Type:      services.StreamEnded,
+				Code:      services.StreamEnded,
+				Message:   i18n.T(r, services.StreamEnded) + " (" + reason + ")",
+				Timestamp: time.Now(),
+			})
+			cancel()
+			conn.Close()
+		})
+		defer unregister()
+
+		callback := func(entry *models.RouterLogEntry) {
+			send(LogMessage{Type: "log_entry", Data: entry, Timestamp: time.Now()})
+		}
+		onStatus := func(status, reason string) {
+			msg := LogMessage{
+				Type:      status,
+				Code:      status,
+				Message:   i18n.T(r, status),
+				Timestamp: time.Now(),
+			}
+			if status == services.StreamError {
+				msg.Error = reason
+			} else if reason != "" {
+				msg.Message += " (" + reason + ")"
+			}
+			send(msg)
+			if status == services.StreamEnded {
+				cancel()
+			}
+		}
+
+		if err := ms.FollowRouterLogs(ctx, routerID, logTopics(r), callback, onStatus); err != nil {
+			log.Printf("[WS-LOGS] Failed to follow logs of router %d: %v", routerID, err)
+			send(LogMessage{
+				Type:      services.StreamError,
+				Code:      i18n.ErrorCode(err, services.StreamError),
+				Error:     i18n.ErrorText(r, err),
+				Timestamp: time.Now(),
+			})
+			return
+		}
+		log.Printf("[WS-LOGS] Following logs of router %d", routerID)
+
+		// Baca message client sampai disconnect (ping dibalas pong) atau stream berakhir
+		go func() {
+			defer services.Recover("ws-logs-reader")
+			defer cancel()
+			for {
+				messageType, message, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if messageType != websocket.TextMessage {
+					continue
+				}
+				var cmd map[string]interface{}
+				if err := json.Unmarshal(message, &cmd); err != nil {
+					continue
+				}
+				switch cmd["type"] {
+				case "ping":
+					send(LogMessage{Type: "pong", Timestamp: time.Now()})
+				case "hello":
+					reply := proto.handleHello(r, cmd, nil)
+					wsMutex.Lock()
+					if wsOpen {
+						conn.WriteJSON(reply)
+					}
+					wsMutex.Unlock()
+				}
+			}
+		}()
+
+		<-ctx.Done()
+		wsMutex.Lock()
+		wsOpen = false
+		wsMutex.Unlock()
+		log.Printf("[WS-LOGS] Log follow stopped - Router %d", routerID)
+	}
+}
+
+// logTopics - Query topics dipisah koma (kosong = semua topic)
+func logTopics(r *http.Request) []string {
+	var topics []string
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		if topic = strings.TrimSpace(topic); topic != "" && !slices.Contains(topics, topic) {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// GetRoutingStatus - GET /api/routing?router_id=X
+// OSPF (neighbor + ringkasan LSDB) dan peer BGP; section yang gagal dibaca dilaporkan di "errors"
+func GetRoutingStatus(ms *services.MikrotikService) http.HandlerFunc {
+	return routingHandler(func(routerID int) (interface{}, error) {
+		return ms.GetRoutingStatus(routerID)
+	})
+}
+
+// GetOSPFStatus - GET /api/routing/ospf?router_id=X
+func GetOSPFStatus(ms *services.MikrotikService) http.HandlerFunc {
+	return routingHandler(func(routerID int) (interface{}, error) {
+		neighbors, err := ms.GetOSPFNeighbors(routerID)
+		if err != nil {
+			return nil, err
+		}
+		lsdb, err := ms.GetOSPFLSDB(routerID)
+		if err != nil {
+			return nil, err
+		}
+		return &models.OSPFStatus{Neighbors: neighbors, LSDB: lsdb}, nil
+	})
+}
+
+// GetBGPPeers - GET /api/routing/bgp?router_id=X
+func GetBGPPeers(ms *services.MikrotikService) http.HandlerFunc {
+	return routingHandler(func(routerID int) (interface{}, error) {
+		return ms.GetBGPPeers(routerID)
+	})
+}
+
+// routingHandler - Validasi router_id + scope, lalu tulis hasil fetch
+func routingHandler(fetch func(routerID int) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		data, err := fetch(routerID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    data,
+		})
+	}
+}
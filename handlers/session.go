@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// GetPPPActive - GET /api/ppp/active?router_id=&user=
+// Sesi PPP aktif (user, alamat, uptime, caller-id, counter byte); "id" dipakai untuk disconnect per sesi
+func GetPPPActive(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		sessions, err := ms.GetPPPActiveSessions(routerID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		if user := r.URL.Query().Get("user"); user != "" {
+			filtered := []*models.ActivePPPSession{}
+			for _, s := range sessions {
+				if s.User == user {
+					filtered = append(filtered, s)
+				}
+			}
+			sessions = filtered
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    sessions,
+		})
+	}
+}
+
+// DisconnectPPPSessions - POST /api/ppp/active/disconnect?router_id=&id=&user=&profile=&address_list=&dry_run=
+func DisconnectPPPSessions(ms *services.MikrotikService) http.HandlerFunc {
+	return disconnectSessions(ms.KickPPPSessions)
+}
+
+// DisconnectHotspotSessions - POST /api/hotspot/active/disconnect?router_id=&id=&user=&profile=&address_list=&dry_run=
+func DisconnectHotspotSessions(ms *services.MikrotikService) http.HandlerFunc {
+	return disconnectSessions(ms.KickHotspotSessions)
+}
+
+// disconnectSessions - Hapus entry sesi aktif yang cocok dengan semua filter yang diisi
+// (minimal satu filter) sehingga user dipaksa reconnect
+func disconnectSessions(kick func(int, models.SessionFilter, bool) (*models.CommandPlan, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MethodNotAllowed,
+				Error:   i18n.T(r, i18n.MethodNotAllowed),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		query := r.URL.Query()
+		filter := models.SessionFilter{
+			ID:          query.Get("id"),
+			User:        query.Get("user"),
+			Profile:     query.Get("profile"),
+			AddressList: query.Get("address_list"),
+		}
+		if filter.Empty() {
+			var errs validation.Errors
+			errs.Add("user", validation.RuleRequired, "")
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := kick(routerID, filter, dryRun)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.SessionsDisconnected),
+			Message: planMessage(r, dryRun, i18n.SessionsDisconnected),
+			Data:    plan,
+		})
+	}
+}
+
+// GetPPPSessions - GET /api/ppp/sessions?router_id=&user=&ip=&from=&to=&limit=
+// Riwayat sesi PPP; from/to memilih sesi yang berlangsung (overlap) di rentang tsb
+func GetPPPSessions(repo *repository.PPPSessionRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter := models.PPPSessionFilter{
+			RouterIDs: auth.FromRequest(r).RouterIDs(),
+			User:      query.Get("user"),
+			Address:   query.Get("ip"),
+		}
+
+		if v := query.Get("router_id"); v != "" {
+			routerID, err := strconv.Atoi(v)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.InvalidParameter,
+					Error:   i18n.T(r, i18n.InvalidParameter, "router_id"),
+				})
+				return
+			}
+			filter.RouterID = &routerID
+		}
+		if errs := validation.Var("ip", filter.Address, "ip"); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		for name, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+			t, err := parseTimeParam(query.Get(name))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.InvalidTimeFormat,
+					Error:   i18n.T(r, i18n.InvalidTimeFormat, name),
+				})
+				return
+			}
+			*dst = t
+		}
+		filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+		sessions, err := repo.List(filter)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    sessions,
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// GetRouterStatusHistory - GET /api/routers/{id}/status-history?from=&to=
+// Periode online/offline/error/suspended beserta durasinya; default 7 hari terakhir
+func (h *RouterHandler) GetRouterStatusHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := routerPathID(w, r)
+	if !ok {
+		return
+	}
+
+	from, to, err := parseTimeRange(r, 7*24*time.Hour)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.BadRequest),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	router, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	history, err := services.StatusTimeline(h.repo, router, from, to)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    history,
+	})
+}
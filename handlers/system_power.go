@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// RebootRouter - POST /api/system/reboot?router_id=X[&delay=60][&dry_run=true]
+// Konfirmasi dua langkah: request pertama dijawab 409 + confirm_token, ulangi dengan ?confirm_token=.
+// Status router "rebooting" sampai router ter-connect lagi.
+func RebootRouter(ms *services.MikrotikService, confirms *services.ConfirmationStore, audit *services.AuditLogger) http.HandlerFunc {
+	return powerRouter(ms, confirms, audit, services.PowerReboot, i18n.RouterRebooting)
+}
+
+// ShutdownRouter - POST /api/system/shutdown?router_id=X[&delay=60][&dry_run=true]
+// Konfirmasi dua langkah seperti reboot; router hanya kembali lewat power cycle di lokasi.
+func ShutdownRouter(ms *services.MikrotikService, confirms *services.ConfirmationStore, audit *services.AuditLogger) http.HandlerFunc {
+	return powerRouter(ms, confirms, audit, services.PowerShutdown, i18n.RouterShuttingDown)
+}
+
+// powerRouter - delay dalam detik (0-3600), dieksekusi oleh router sendiri
+func powerRouter(ms *services.MikrotikService, confirms *services.ConfirmationStore, audit *services.AuditLogger, action, code string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MethodNotAllowed,
+				Error:   i18n.T(r, i18n.MethodNotAllowed),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		delay := 0
+		if v := r.URL.Query().Get("delay"); v != "" {
+			var err error
+			var errs validation.Errors
+			if delay, err = strconv.Atoi(v); err != nil {
+				errs.Add("delay", validation.RuleMin, "0")
+			} else {
+				errs = validation.Var("delay", delay, "min=0,max=3600")
+			}
+			if len(errs) > 0 {
+				writeValidationError(w, r, errs)
+				return
+			}
+		}
+
+		dryRun := isDryRun(r)
+		if !dryRun {
+			impact := map[string]interface{}{"action": action, "router_id": routerID, "delay": delay}
+			if conn := ms.GetAllConnections()[routerID]; conn != nil {
+				impact["router_name"] = conn.Router.Name
+				impact["active_streams"] = ms.ActiveStreams(routerID)
+			}
+			target := "router:" + strconv.Itoa(routerID) + "|" + strconv.Itoa(delay)
+			if !requireConfirmation(w, r, confirms, "system_"+action, target, impact, i18n.ConfirmationRequired) {
+				return
+			}
+		}
+
+		plan, err := ms.PowerRouter(routerID, action, time.Duration(delay)*time.Second, dryRun)
+		if !dryRun {
+			audit.LogPlan(requestActor(r), "system_"+action, routerID, action, plan, err)
+		}
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, code),
+			Message: planMessage(r, dryRun, code),
+			Data:    plan,
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type TaskScheduleHandler struct {
+	repo       *repository.TaskScheduleRepository
+	routerRepo *repository.RouterRepository
+	scheduler  *services.TaskScheduler
+	confirms   *services.ConfirmationStore
+}
+
+func NewTaskScheduleHandler(repo *repository.TaskScheduleRepository, routerRepo *repository.RouterRepository, scheduler *services.TaskScheduler, confirms *services.ConfirmationStore) *TaskScheduleHandler {
+	return &TaskScheduleHandler{repo: repo, routerRepo: routerRepo, scheduler: scheduler, confirms: confirms}
+}
+
+// ListSchedules - GET /api/schedules?router_id= (router_id opsional)
+func (h *TaskScheduleHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	routerID := 0
+	if v := r.URL.Query().Get("router_id"); v != "" {
+		var err error
+		if routerID, err = strconv.Atoi(v); err != nil || routerID < 1 {
+			writeValidationError(w, r, validation.Errors{{Field: "router_id", Rule: validation.RuleMin, Param: "1"}})
+			return
+		}
+	}
+
+	schedules, err := h.repo.List(routerID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    schedules,
+	})
+}
+
+// CreateSchedule - POST /api/schedules
+// Body TaskScheduleRequest, mis. {"name":"Backup malam","action":"backup","router_id":1,"cron":"0 2 * * *"}
+// atau {"name":"Reboot mingguan","action":"reboot","router_id":2,"cron":"0 4 * * sun"}.
+// Jam cron mengikuti TASK_SCHEDULE_TIMEZONE.
+func (h *TaskScheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	var req models.TaskScheduleRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	schedule := &models.TaskSchedule{Enabled: true, CreatedBy: requestActor(r)}
+	if errs := mergeTaskScheduleRequest(schedule, &req, h.routerRepo); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+	schedule.NextRunAt = h.scheduler.NextRun(schedule, time.Now())
+
+	created, err := h.repo.Create(schedule)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TaskScheduleCreated,
+		Message: i18n.T(r, i18n.TaskScheduleCreated),
+		Data:    created,
+	})
+}
+
+// GetSchedule - GET /api/schedules/{id} (beserta hasil run terakhir)
+func (h *TaskScheduleHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := h.scheduleFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    schedule,
+	})
+}
+
+// UpdateSchedule - PUT /api/schedules/{id} (field yang diisi saja); waktu run berikutnya dihitung ulang
+func (h *TaskScheduleHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := h.scheduleFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.TaskScheduleRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	updated := *schedule
+	if errs := mergeTaskScheduleRequest(&updated, &req, h.routerRepo); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+	updated.NextRunAt = h.scheduler.NextRun(&updated, time.Now())
+
+	if err := h.repo.Update(&updated); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TaskScheduleUpdated,
+		Message: i18n.T(r, i18n.TaskScheduleUpdated),
+		Data:    &updated,
+	})
+}
+
+// DeleteSchedule - DELETE /api/schedules/{id}
+func (h *TaskScheduleHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := h.scheduleFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(schedule.ID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TaskScheduleDeleted,
+		Message: i18n.T(r, i18n.TaskScheduleDeleted),
+	})
+}
+
+// RunSchedule - POST /api/schedules/{id}/run
+// Jalankan jadwal sekarang tanpa mengubah waktu run berikutnya; response menunggu run selesai.
+// Jadwal reboot butuh konfirmasi dua langkah seperti /api/system/reboot.
+func (h *TaskScheduleHandler) RunSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := h.scheduleFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if schedule.Action == models.TaskScheduleReboot {
+		target := "schedule:" + strconv.Itoa(schedule.ID) + "|router:" + strconv.Itoa(schedule.RouterID)
+		impact := map[string]interface{}{
+			"schedule": schedule.Name,
+			"action":   schedule.Action,
+			"router":   schedule.RouterID,
+		}
+		if !requireConfirmation(w, r, h.confirms, "schedule_run", target, impact, i18n.ConfirmationRequired) {
+			return
+		}
+	}
+
+	run, err := h.scheduler.RunNow(schedule, requestActor(r))
+	if err != nil {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.TaskScheduleBusy),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	if run.Status == models.TaskScheduleFailed {
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.TaskScheduleFailed,
+			Error:   i18n.T(r, i18n.TaskScheduleFailed, run.Error),
+			Data:    run,
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TaskScheduleRan,
+		Message: i18n.T(r, i18n.TaskScheduleRan),
+		Data:    run,
+	})
+}
+
+// scheduleFromPath - Ambil jadwal {id} dari /api/schedules/{id}[/...] (tulis 400/404 jika gagal)
+func (h *TaskScheduleHandler) scheduleFromPath(w http.ResponseWriter, r *http.Request) (*models.TaskSchedule, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/schedules/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "task schedule"),
+		})
+		return nil, false
+	}
+
+	schedule, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return nil, false
+	}
+	return schedule, true
+}
+
+// mergeTaskScheduleRequest - Terapkan field request yang diisi ke jadwal lalu cek field wajib.
+// Parameter backup hanya dipakai action backup; password backup tidak diterima karena akan
+// tersimpan apa adanya di database.
+func mergeTaskScheduleRequest(s *models.TaskSchedule, req *models.TaskScheduleRequest, routers *repository.RouterRepository) validation.Errors {
+	if req.Name != "" {
+		s.Name = req.Name
+	}
+	if req.Action != "" {
+		s.Action = req.Action
+	}
+	if req.RouterID != nil {
+		s.RouterID = *req.RouterID
+	}
+	if req.Cron != "" {
+		s.Cron = req.Cron
+	}
+	if req.Backup != nil {
+		s.Backup = req.Backup
+	}
+	if req.Enabled != nil {
+		s.Enabled = *req.Enabled
+	}
+
+	var errs validation.Errors
+	if s.Name == "" {
+		errs.Add("name", validation.RuleRequired, "")
+	}
+	if s.Action == "" {
+		errs.Add("action", validation.RuleRequired, "")
+	}
+	if s.RouterID == 0 {
+		errs.Add("router_id", validation.RuleRequired, "")
+	} else if _, err := routers.GetByID(s.RouterID); err != nil {
+		errs.Add("router_id", validation.RuleExists, "router")
+	}
+	if s.Cron == "" {
+		errs.Add("cron", validation.RuleRequired, "")
+	}
+	if s.Action != models.TaskScheduleBackup {
+		s.Backup = nil
+	} else if s.Backup != nil {
+		if s.Backup.Password != nil && *s.Backup.Password != "" {
+			errs.Add("backup.password", validation.RuleMax, "0")
+		}
+		for _, fe := range validation.Struct(s.Backup) {
+			errs.Add("backup."+fe.Field, fe.Rule, fe.Param)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type ThreatFeedHandler struct {
+	repo       *repository.ThreatFeedRepository
+	routerRepo *repository.RouterRepository
+	syncer     *services.ThreatFeedSyncer
+}
+
+func NewThreatFeedHandler(repo *repository.ThreatFeedRepository, routerRepo *repository.RouterRepository, syncer *services.ThreatFeedSyncer) *ThreatFeedHandler {
+	return &ThreatFeedHandler{repo: repo, routerRepo: routerRepo, syncer: syncer}
+}
+
+// GetAllFeeds - GET /api/threat-feeds
+func (h *ThreatFeedHandler) GetAllFeeds(w http.ResponseWriter, r *http.Request) {
+	feeds, err := h.repo.List(false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    feeds,
+	})
+}
+
+// CreateFeed - POST /api/threat-feeds
+// Feed baru disinkronkan pada tick syncer berikutnya (atau langsung via /sync)
+func (h *ThreatFeedHandler) CreateFeed(w http.ResponseWriter, r *http.Request) {
+	var req models.ThreatFeedRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	feed := &models.ThreatFeed{IntervalMinutes: 60, MaxEntries: h.syncer.MaxEntries(), RouterIDs: []int{}, Enabled: true}
+	if errs := h.mergeFeedRequest(feed, &req); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	created, err := h.repo.Create(feed)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ThreatFeedCreated,
+		Message: i18n.T(r, i18n.ThreatFeedCreated),
+		Data:    created,
+	})
+}
+
+// GetFeed - GET /api/threat-feeds/{id}
+func (h *ThreatFeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	feed, ok := h.feedFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    feed,
+	})
+}
+
+// UpdateFeed - PUT /api/threat-feeds/{id} (field yang diisi saja)
+// Entry feed di router yang dilepas (atau semua router jika address-list diganti) dihapus dulu
+func (h *ThreatFeedHandler) UpdateFeed(w http.ResponseWriter, r *http.Request) {
+	feed, ok := h.feedFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ThreatFeedRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	updated := *feed
+	if errs := h.mergeFeedRequest(&updated, &req); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	var released []int
+	for _, routerID := range feed.RouterIDs {
+		if updated.AddressList != feed.AddressList || !slices.Contains(updated.RouterIDs, routerID) {
+			released = append(released, routerID)
+		}
+	}
+	if err := h.syncer.Purge(feed, released); err != nil {
+		w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	if err := h.repo.Update(&updated); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ThreatFeedUpdated,
+		Message: i18n.T(r, i18n.ThreatFeedUpdated),
+		Data:    &updated,
+	})
+}
+
+// DeleteFeed - DELETE /api/threat-feeds/{id}
+// Entry feed dihapus dari address-list semua router tujuan sebelum feed dihapus
+func (h *ThreatFeedHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
+	feed, ok := h.feedFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.syncer.Purge(feed, feed.RouterIDs); err != nil {
+		w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	if err := h.repo.Delete(feed.ID); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.ThreatFeedDeleted,
+		Message: i18n.T(r, i18n.ThreatFeedDeleted),
+	})
+}
+
+// SyncFeed - POST /api/threat-feeds/{id}/sync[?dry_run=true]
+// Ambil feed dan sinkronkan sekarang; dry run mengembalikan plan add/remove per router
+func (h *ThreatFeedHandler) SyncFeed(w http.ResponseWriter, r *http.Request) {
+	feed, ok := h.feedFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	dryRun := isDryRun(r)
+	result, err := h.syncer.Sync(feed, dryRun)
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.ThreatFeedSynced),
+		Message: planMessage(r, dryRun, i18n.ThreatFeedSynced),
+		Data:    result,
+	})
+}
+
+// mergeFeedRequest - Terapkan field request yang diisi lalu cek field wajib, router tujuan
+// dan batas entry (tidak boleh melebihi batas global)
+func (h *ThreatFeedHandler) mergeFeedRequest(feed *models.ThreatFeed, req *models.ThreatFeedRequest) validation.Errors {
+	if req.Name != "" {
+		feed.Name = req.Name
+	}
+	if req.URL != "" {
+		feed.URL = req.URL
+	}
+	if req.AddressList != "" {
+		feed.AddressList = strings.TrimSpace(req.AddressList)
+	}
+	if req.RouterIDs != nil {
+		feed.RouterIDs = []int{}
+		for _, id := range *req.RouterIDs {
+			if !slices.Contains(feed.RouterIDs, id) {
+				feed.RouterIDs = append(feed.RouterIDs, id)
+			}
+		}
+	}
+	if req.IntervalMinutes != nil {
+		feed.IntervalMinutes = *req.IntervalMinutes
+	}
+	if req.MaxEntries != nil {
+		feed.MaxEntries = *req.MaxEntries
+	}
+	if req.Enabled != nil {
+		feed.Enabled = *req.Enabled
+	}
+
+	var errs validation.Errors
+	if feed.Name == "" {
+		errs.Add("name", validation.RuleRequired, "")
+	}
+	if feed.URL == "" {
+		errs.Add("url", validation.RuleRequired, "")
+	}
+	if feed.AddressList == "" {
+		errs.Add("address_list", validation.RuleRequired, "")
+	}
+	if len(feed.RouterIDs) == 0 {
+		errs.Add("router_ids", validation.RuleRequired, "")
+	}
+	for i, id := range feed.RouterIDs {
+		if _, err := h.routerRepo.GetByID(id); err != nil {
+			errs.Add("router_ids["+strconv.Itoa(i)+"]", validation.RuleExists, "router")
+		}
+	}
+	if limit := h.syncer.MaxEntries(); limit > 0 {
+		errs = append(errs, validation.Var("max_entries", feed.MaxEntries, "max="+strconv.Itoa(limit))...)
+	}
+	return errs
+}
+
+// feedFromPath - Ambil feed {id} (tulis 400/404 jika gagal)
+func (h *ThreatFeedHandler) feedFromPath(w http.ResponseWriter, r *http.Request) (*models.ThreatFeed, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/threat-feeds/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "threat feed"),
+		})
+		return nil, false
+	}
+
+	feed, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return nil, false
+	}
+	return feed, true
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+// GetTopTalkers - GET /api/traffic/top-talkers?router_id=X&from=&to=&interface=&direction=src|dst&address=&limit=
+// Default rentang: 1 jam terakhir
+func GetTopTalkers(repo *repository.TopTalkersRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		from, to, err := parseTimeRange(r, time.Hour)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		direction := r.URL.Query().Get("direction")
+		if direction != "" && direction != "src" && direction != "dst" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InvalidChoice,
+				Error:   i18n.T(r, i18n.InvalidChoice, "direction", "src/dst"),
+			})
+			return
+		}
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		talkers, err := repo.Query(&models.TopTalkerFilter{
+			RouterID:  routerID,
+			Interface: r.URL.Query().Get("interface"),
+			Direction: direction,
+			Address:   r.URL.Query().Get("address"),
+			From:      from,
+			To:        to,
+			Limit:     limit,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    talkers,
+		})
+	}
+}
+
+// Torch - GET /api/traffic/torch?router_id=X&interface=Y&duration=5
+// Snapshot torch langsung (tanpa disimpan), durasi maksimal 30 detik
+func Torch(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+		iface := r.URL.Query().Get("interface")
+		if iface == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'interface'"),
+			})
+			return
+		}
+
+		duration := 5
+		if v := r.URL.Query().Get("duration"); v != "" {
+			var err error
+			if duration, err = strconv.Atoi(v); err != nil || duration < 1 || duration > 30 {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.OutOfRange,
+					Error:   i18n.T(r, i18n.OutOfRange, "duration", 1, 30, "s"),
+				})
+				return
+			}
+		}
+
+		result, err := ms.Torch(routerID, iface, time.Duration(duration)*time.Second)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    result,
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+)
+
+// GetTopology - GET /api/topology?include_inactive=true
+// Peta jaringan dari /ip/neighbor semua router (dalam scope): nodes router + perangkat tetangga,
+// links antar node. Default hanya link yang masih terlihat.
+func GetTopology(routerRepo *repository.RouterRepository, repo *repository.TopologyRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		includeInactive, _ := strconv.ParseBool(r.URL.Query().Get("include_inactive"))
+
+		routers, err := routerRepo.GetAll()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+		links, err := repo.List(0, !includeInactive)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		principal := auth.FromRequest(r)
+		scoped := make([]*models.Router, 0, len(routers))
+		for _, router := range routers {
+			if principal.CanAccessRouter(router.ID) {
+				scoped = append(scoped, router)
+			}
+		}
+		scopedLinks := make([]*models.TopologyLink, 0, len(links))
+		for _, l := range links {
+			if principal.CanAccessRouter(l.RouterID) {
+				scopedLinks = append(scopedLinks, l)
+			}
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    services.BuildTopology(scoped, scopedLinks),
+		})
+	}
+}
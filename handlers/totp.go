@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+)
+
+// EnrollTOTP - POST /api/auth/totp/enroll
+// Buat secret baru untuk user pemanggil; aktif setelah diverifikasi lewat /api/auth/totp/verify.
+// User yang TOTP-nya sudah aktif harus menonaktifkan dulu (butuh kode yang valid).
+func (h *SessionHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	principal, ok := totpPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	_, enabled, err := h.repo.TOTPState(principal.UserID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	if enabled {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.TOTPAlreadyEnabled,
+			Error:   i18n.T(r, i18n.TOTPAlreadyEnabled),
+		})
+		return
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err == nil {
+		err = h.repo.SetTOTPSecret(principal.UserID, secret)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TOTPEnrollmentStarted,
+		Message: i18n.T(r, i18n.TOTPEnrollmentStarted),
+		Data: &models.TOTPEnrollment{
+			Secret:     secret,
+			OTPAuthURI: auth.TOTPURI(h.totpIssuer, principal.Username, secret),
+		},
+	})
+}
+
+// VerifyTOTP - POST /api/auth/totp/verify, body TOTPCodeRequest: aktifkan enrollment
+func (h *SessionHandler) VerifyTOTP(w http.ResponseWriter, r *http.Request) {
+	principal, ok := totpPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.TOTPCodeRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	secret, enabled, err := h.repo.TOTPState(principal.UserID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	if enabled || secret == "" {
+		code := i18n.TOTPAlreadyEnabled
+		if secret == "" {
+			code = i18n.TOTPNotEnrolled
+		}
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    code,
+			Error:   i18n.T(r, code),
+		})
+		return
+	}
+
+	if !h.checkTOTP(w, r, principal.UserID, secret, req.Code) {
+		return
+	}
+	if err := h.repo.EnableTOTP(principal.UserID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TOTPEnabled,
+		Message: i18n.T(r, i18n.TOTPEnabled),
+	})
+}
+
+// DisableTOTP - POST /api/auth/totp/disable, body TOTPCodeRequest (kode valid wajib).
+// Role yang diwajibkan policy harus enroll ulang sebelum bisa memakai API lagi.
+func (h *SessionHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	principal, ok := totpPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.TOTPCodeRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	secret, enabled, err := h.repo.TOTPState(principal.UserID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	if !enabled {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.TOTPNotEnrolled,
+			Error:   i18n.T(r, i18n.TOTPNotEnrolled),
+		})
+		return
+	}
+
+	if !h.checkTOTP(w, r, principal.UserID, secret, req.Code) {
+		return
+	}
+	if err := h.repo.DisableTOTP(principal.UserID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TOTPDisabled,
+		Message: i18n.T(r, i18n.TOTPDisabled),
+	})
+}
+
+// checkTOTP - Verifikasi kode dan tandai step-nya terpakai; tulis 401 jika salah / dipakai ulang
+func (h *SessionHandler) checkTOTP(w http.ResponseWriter, r *http.Request, userID int, secret, code string) bool {
+	step, valid := auth.VerifyTOTP(secret, code, time.Now())
+	if valid {
+		fresh, err := h.repo.UseTOTPStep(userID, step)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return false
+		}
+		valid = fresh
+	}
+	if !valid {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidTOTPCode,
+			Error:   i18n.T(r, i18n.InvalidTOTPCode),
+		})
+	}
+	return valid
+}
+
+// totpPrincipal - TOTP hanya untuk user database (bukan token bootstrap / auth nonaktif)
+func totpPrincipal(w http.ResponseWriter, r *http.Request) (*auth.Principal, bool) {
+	principal := auth.FromRequest(r)
+	if principal == nil || principal.UserID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.SessionUnavailable,
+			Error:   i18n.T(r, i18n.SessionUnavailable),
+		})
+		return nil, false
+	}
+	return principal, true
+}
+
+// ResetUserTOTP - DELETE /api/users/{id}/totp (admin): reset TOTP user yang kehilangan perangkat
+func (h *UserHandler) ResetUserTOTP(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DisableTOTP(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TOTPDisabled,
+		Message: i18n.T(r, i18n.TOTPDisabled),
+	})
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+// GetTrafficHistory - GET /api/traffic/history?router_id=X&interface=Y&from=&to=&export=csv|xlsx
+// Default rentang: 1 jam terakhir
+func GetTrafficHistory(repo *repository.TrafficRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format, err := exportFormat(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		iface := r.URL.Query().Get("interface")
+		if iface == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'interface'"),
+			})
+			return
+		}
+
+		from, to, err := parseTimeRange(r, time.Hour)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		if format != "" {
+			columns := []string{"router_id", "interface", "sampled_at", "rx_bps", "tx_bps", "rx_bytes", "tx_bytes",
+				"rx_errors", "tx_errors", "rx_drops", "tx_drops", "fcs_errors"}
+			streamExport(w, format, "traffic-history", columns, func(tw tableWriter) error {
+				return repo.EachHistory(routerID, iface, from, to, func(s *models.TrafficSample) error {
+					return tw.WriteRow(s.RouterID, s.Interface, s.SampledAt, s.RxBps, s.TxBps, s.RxBytes, s.TxBytes,
+						s.RxErrors, s.TxErrors, s.RxDrops, s.TxDrops, s.FCSErrors)
+				})
+			})
+			return
+		}
+
+		samples, err := repo.GetHistory(routerID, iface, from, to)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    samples,
+		})
+	}
+}
+
+// TrafficSamplers - /api/traffic/samplers
+// GET: list, POST ?router_id=&interface=&interval=60: start, DELETE ?router_id=&interface=: stop
+func TrafficSamplers(sampler *services.TrafficSampler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal := auth.FromRequest(r)
+		if r.Method == http.MethodGet {
+			// Hanya sampler router / interface dalam scope user
+			samplers := []models.SamplerInfo{}
+			for _, s := range sampler.List() {
+				if principal.CanMonitor(s.RouterID, s.Interface) {
+					samplers = append(samplers, s)
+				}
+			}
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    samplers,
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+		iface := r.URL.Query().Get("interface")
+		if iface == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'interface'"),
+			})
+			return
+		}
+		if !principal.CanMonitor(routerID, iface) {
+			auth.Forbidden(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			interval := 60
+			if v := r.URL.Query().Get("interval"); v != "" {
+				var err error
+				if interval, err = strconv.Atoi(v); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ApiResponse{
+						Success: false,
+						Code:    i18n.NotANumber,
+						Error:   i18n.T(r, i18n.NotANumber, "interval"),
+					})
+					return
+				}
+			}
+
+			if err := sampler.Start(routerID, iface, time.Duration(interval)*time.Second); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.BadRequest),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.SamplerStarted,
+				Message: i18n.T(r, i18n.SamplerStarted),
+			})
+
+		case http.MethodDelete:
+			if err := sampler.Stop(routerID, iface); err != nil {
+				status := http.StatusNotFound
+				if i18n.ErrorCode(err, "") == i18n.SamplerMonitored {
+					status = http.StatusConflict
+				}
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.NotFound),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.SamplerStopped,
+				Message: i18n.T(r, i18n.SamplerStopped),
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// parseTimeRange - Parse ?from=&to= (RFC3339); default [now-defaultSpan, now]
+func parseTimeRange(r *http.Request, defaultSpan time.Duration) (time.Time, time.Time, error) {
+	to := time.Now()
+	if v, err := parseTimeParam(r.URL.Query().Get("to")); err != nil {
+		return time.Time{}, time.Time{}, errInvalidTimeParam("to")
+	} else if v != nil {
+		to = *v
+	}
+
+	from := to.Add(-defaultSpan)
+	if v, err := parseTimeParam(r.URL.Query().Get("from")); err != nil {
+		return time.Time{}, time.Time{}, errInvalidTimeParam("from")
+	} else if v != nil {
+		from = *v
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errInvalidTimeRange
+	}
+
+	return from, to, nil
+}
+
+// trafficCompareMaxSeries - Batas pasangan router/interface per perbandingan
+const trafficCompareMaxSeries = 10
+
+// CompareTraffic - GET /api/traffic/compare?series=1:ether1,2:sfp-sfpplus1&from=&to=&step=5m
+// History beberapa interface (boleh beda router) di grid waktu yang sama; default 24 jam terakhir,
+// step kosong = resolusi otomatis sesuai rentang
+func CompareTraffic(repo *repository.TrafficRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		from, to, err := parseTimeRange(r, 24*time.Hour)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		var errs validation.Errors
+		var pairs []models.SampledInterface
+		for _, value := range r.URL.Query()["series"] {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item == "" {
+					continue
+				}
+				field := "series[" + strconv.Itoa(len(pairs)) + "]"
+				router, iface, _ := strings.Cut(item, ":")
+				routerID, err := strconv.Atoi(router)
+				if err != nil || routerID < 1 || iface == "" {
+					errs.Add(field, validation.RuleRequired, "")
+				}
+				pairs = append(pairs, models.SampledInterface{RouterID: routerID, Interface: iface})
+			}
+		}
+		if len(pairs) == 0 {
+			errs.Add("series", validation.RuleRequired, "")
+		}
+		errs = append(errs, validation.Var("series", pairs, "max="+strconv.Itoa(trafficCompareMaxSeries))...)
+
+		step := services.TrafficCompareStep(from, to)
+		if v := r.URL.Query().Get("step"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d < 10*time.Second {
+				errs.Add("step", validation.RuleMin, "10s")
+			} else {
+				step = d
+			}
+		}
+		if points := int(to.Sub(from)/step) + 1; points > services.TrafficCompareMaxPoints {
+			errs.Add("step", validation.RuleMin, (to.Sub(from) / services.TrafficCompareMaxPoints).Round(time.Second).String())
+		}
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		principal := auth.FromRequest(r)
+		for _, pair := range pairs {
+			if !principal.CanMonitor(pair.RouterID, pair.Interface) {
+				auth.Forbidden(w, r)
+				return
+			}
+		}
+
+		comparison, err := services.CompareTraffic(repo, pairs, from, to, step)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    comparison,
+		})
+	}
+}
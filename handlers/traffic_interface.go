@@ -11,7 +11,12 @@ import (
 	"sync"
 	"time"
 
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/dto"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/middleware"
 	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
 	"Mikrotik-Layer/services"
 
 	"github.com/gorilla/websocket"
@@ -26,19 +31,33 @@ var upgrader = websocket.Upgrader{
 }
 
 type TrafficMessage struct {
-	Type      string                 `json:"type"`
-	Interface string                 `json:"interface,omitempty"`
-	Data      *services.TrafficStats `json:"data,omitempty"`
-	Error     string                 `json:"error,omitempty"`
-	Message   string                 `json:"message,omitempty"`
-	Timestamp time.Time              `json:"timestamp"`
+	Type      string                   `json:"type"`
+	Interface string                   `json:"interface,omitempty"`
+	Data      interface{}              `json:"data,omitempty"`      // protokol 1: *services.TrafficStats, 2: *dto.TrafficStats
+	History   []*models.TrafficSample  `json:"history,omitempty"`   // hanya untuk type history
+	Breakdown *models.VLANBreakdown    `json:"breakdown,omitempty"` // hanya untuk type vlan_breakdown
+	Aggregate *models.AggregateTraffic `json:"aggregate,omitempty"` // hanya untuk type aggregate_update
+	Code      string                   `json:"code,omitempty"`      // code i18n untuk error & status message
+	Error     string                   `json:"error,omitempty"`
+	Message   string                   `json:"message,omitempty"`
+	Timestamp time.Time                `json:"timestamp"`
 }
 
 // MonitorTrafficWS - WebSocket untuk monitoring traffic multiple interfaces (same router)
 // Patterns:
 // - Single interface: /ws/traffic/monitor?router_id=1&interface=ether1
 // - Multiple interfaces: /ws/traffic/monitor?router_id=1&interfaces=ether1,ether2,ether3
-func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
+// - Backfill: &backfill=N kirim history N menit terakhir (type history) sebelum data live
+// - VLAN: ?router_id=1&vlans_of=ether1 monitor parent + semua VLAN di atasnya; tiap update
+//   parent juga dikirim vlan_breakdown (rate per VLAN, porsi & sisa untagged)
+// - Bonding/bridge: ?router_id=1&aggregate_of=bond1 monitor master + semua member; tiap update
+//   master juga dikirim aggregate_update (jumlah rate member sebagai satu series + breakdown)
+// Selain traffic_update, tiap interface mengirim stream_started, stream_error (field error
+// berisi alasan), stream_ended, router_offline dan stream_resumed; stream_queued jika batas stream
+// router penuh dan antrean aktif (penuh tanpa antrean: stream_error code stream_limit_reached).
+// Dengan auth aktif (token via &access_token=), interface di luar scope user ditolak (forbidden).
+// Pesan pertama selalu hello (versi protokol); &protocol=2 memilih data traffic bertipe angka.
+func MonitorTrafficWS(ms *services.MikrotikService, trafficRepo *repository.TrafficRepository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("[WS] New connection attempt from %s", r.RemoteAddr)
 		
@@ -49,32 +68,133 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 		}
 		defer conn.Close()
 
+		proto, ok := newWSProtocol(r)
+		if !ok {
+			conn.WriteJSON(unsupportedProtocol(r, r.URL.Query().Get("protocol")))
+			return
+		}
+		conn.WriteJSON(proto.hello(r, nil))
+
 		// Parse router_id
 		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
 		if err != nil || routerID == 0 {
 			log.Printf("[WS] Invalid router_id parameter")
 			sendMessage(conn, TrafficMessage{
 				Type:      "error",
-				Error:     "parameter 'router_id' diperlukan dan harus valid",
+				Code:      i18n.MissingParameter,
+				Error:     i18n.T(r, i18n.MissingParameter, "'router_id'"),
 				Timestamp: time.Now(),
 			})
 			return
 		}
 
-		// Parse interfaces
+		// Scope router dicek sebelum ada panggilan API ke router (lookup VLAN / agregat)
+		principal := auth.FromRequest(r)
+		if !principal.CanAccessRouter(routerID) {
+			log.Printf("[WS] User %s not allowed to access router %d", principal.Username, routerID)
+			sendForbidden(conn, r, "")
+			return
+		}
+
+		// Parse interfaces (mode VLAN: parent + VLAN child dari router)
 		interfaces := parseInterfaceList(r)
+		vlanParent := strings.TrimSpace(r.URL.Query().Get("vlans_of"))
+		var vlans []*models.VLANInterface
+		if vlanParent != "" {
+			if !principal.CanMonitor(routerID, vlanParent) {
+				sendForbidden(conn, r, vlanParent)
+				return
+			}
+			if vlans, err = ms.GetVLANInterfaces(routerID, vlanParent); err != nil {
+				sendMessage(conn, TrafficMessage{
+					Type:      "error",
+					Interface: vlanParent,
+					Code:      i18n.ErrorCode(err, i18n.InternalError),
+					Error:     i18n.ErrorText(r, err),
+					Timestamp: time.Now(),
+				})
+				return
+			}
+			interfaces = []string{vlanParent}
+			for _, v := range vlans {
+				if !v.Disabled {
+					interfaces = append(interfaces, v.Name)
+				}
+			}
+		}
+		aggregateOf := strings.TrimSpace(r.URL.Query().Get("aggregate_of"))
+		var aggregate *models.AggregateInterface
+		if aggregateOf != "" {
+			if vlanParent != "" {
+				sendMessage(conn, TrafficMessage{
+					Type:      "error",
+					Code:      i18n.InvalidParameter,
+					Error:     i18n.T(r, i18n.InvalidParameter, "aggregate_of"),
+					Timestamp: time.Now(),
+				})
+				return
+			}
+			if !principal.CanMonitor(routerID, aggregateOf) {
+				sendForbidden(conn, r, aggregateOf)
+				return
+			}
+			if aggregate, err = ms.GetAggregateInterface(routerID, aggregateOf); err != nil {
+				sendMessage(conn, TrafficMessage{
+					Type:      "error",
+					Interface: aggregateOf,
+					Code:      i18n.ErrorCode(err, i18n.InternalError),
+					Error:     i18n.ErrorText(r, err),
+					Timestamp: time.Now(),
+				})
+				return
+			}
+			interfaces = append([]string{aggregateOf}, aggregate.Members...)
+		}
 		if len(interfaces) == 0 {
 			log.Printf("[WS] No interfaces specified")
 			sendMessage(conn, TrafficMessage{
 				Type:      "error",
-				Error:     "parameter 'interface' atau 'interfaces' diperlukan",
+				Code:      i18n.MissingParameter,
+				Error:     i18n.T(r, i18n.MissingParameter, "'interface'/'interfaces'"),
 				Timestamp: time.Now(),
 			})
 			return
 		}
 
+		// Scope user: semua interface yang diminta harus diizinkan (mode VLAN: izin parent
+		// berlaku juga untuk VLAN di bawahnya; mode agregat: master dan tiap member dicek)
+		scoped := interfaces
+		if vlanParent != "" {
+			scoped = []string{vlanParent}
+		}
+		for _, iface := range scoped {
+			if !principal.CanMonitor(routerID, iface) {
+				log.Printf("[WS] User %s not allowed to monitor router %d, interface %s", principal.Username, routerID, iface)
+				sendForbidden(conn, r, iface)
+				return
+			}
+		}
+
+		backfill := 0
+		if v := r.URL.Query().Get("backfill"); v != "" {
+			if backfill, err = strconv.Atoi(v); err != nil || backfill < 1 || backfill > 1440 {
+				sendMessage(conn, TrafficMessage{
+					Type:      "error",
+					Code:      i18n.OutOfRange,
+					Error:     i18n.T(r, i18n.OutOfRange, "backfill", 1, 1440, "min"),
+					Timestamp: time.Now(),
+				})
+				return
+			}
+		}
+
 		log.Printf("[WS] Connection established - Router ID: %d, Interfaces: %v", routerID, interfaces)
 
+		// History dikirim sebelum monitor dimulai sehingga selalu mendahului data live
+		if backfill > 0 {
+			sendBackfill(conn, trafficRepo, i18n.FromRequest(r), routerID, interfaces, time.Duration(backfill)*time.Minute)
+		}
+
 		// Context untuk cancel semua monitoring
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
@@ -90,8 +210,31 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 		updateCounters := make(map[string]int)
 		var counterMutex sync.Mutex
 
+		// Rate terakhir per interface untuk vlan_breakdown / aggregate_update
+		latest := make(map[string]*services.TrafficStats)
+		var latestMutex sync.Mutex
+
+		// Stream ditutup server jika router dihapus: kirim stream_ended lalu tutup socket
+		unregister := ms.RegisterStream(routerID, func(reason string) {
+			wsMutex.Lock()
+			if wsOpen {
+				sendMessage(conn, TrafficMessage{
+					Type:      services.StreamEnded,
+					Code:      services.StreamEnded,
+					Message:   i18n.T(r, services.StreamEnded) + " (" + reason + ")",
+					Timestamp: time.Now(),
+				})
+				wsOpen = false
+			}
+			wsMutex.Unlock()
+			cancel()
+			conn.Close()
+		})
+		defer unregister()
+
 		// Goroutine untuk baca message dari client (keep-alive & detect disconnect)
 		go func() {
+			defer services.Recover("ws-traffic-reader")
 			defer func() {
 				log.Printf("[WS] Read goroutine stopping for router %d", routerID)
 				cancel() // Cancel all monitoring when client disconnects
@@ -117,6 +260,13 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 								})
 							}
 							wsMutex.Unlock()
+						} else if cmdType == "hello" {
+							reply := proto.handleHello(r, cmd, nil)
+							wsMutex.Lock()
+							if wsOpen {
+								conn.WriteJSON(reply)
+							}
+							wsMutex.Unlock()
 						}
 					}
 				}
@@ -131,6 +281,7 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 		for _, iface := range interfaces {
 			wg.Add(1)
 			go func(interfaceName string) {
+				defer services.Recover("ws-traffic-monitor")
 				defer wg.Done()
 
 				log.Printf("[WS] Starting monitor for router %d, interface %s", routerID, interfaceName)
@@ -153,7 +304,7 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 					msg := TrafficMessage{
 						Type:      "traffic_update",
 						Interface: interfaceName,
-						Data:      &stats,
+						Data:      trafficData(proto.Version(), &stats),
 						Timestamp: time.Now(),
 					}
 
@@ -167,11 +318,87 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 						}
 					}
 					wsMutex.Unlock()
+
+					if vlanParent == "" && aggregate == nil {
+						return
+					}
+					latestMutex.Lock()
+					latest[interfaceName] = &stats
+					var (
+						breakdown  *models.VLANBreakdown
+						aggregated *models.AggregateTraffic
+					)
+					if vlanParent != "" && interfaceName == vlanParent {
+						breakdown = services.BuildVLANBreakdown(routerID, vlanParent, vlans, latest)
+					}
+					if aggregate != nil && interfaceName == aggregate.Name {
+						aggregated = services.BuildAggregateTraffic(routerID, aggregate, latest)
+					}
+					latestMutex.Unlock()
+
+					if aggregated != nil {
+						wsMutex.Lock()
+						if wsOpen {
+							sendMessage(conn, TrafficMessage{
+								Type:      "aggregate_update",
+								Interface: aggregate.Name,
+								Aggregate: aggregated,
+								Timestamp: time.Now(),
+							})
+						}
+						wsMutex.Unlock()
+					}
+
+					if breakdown != nil {
+						wsMutex.Lock()
+						if wsOpen {
+							sendMessage(conn, TrafficMessage{
+								Type:      "vlan_breakdown",
+								Interface: vlanParent,
+								Breakdown: breakdown,
+								Timestamp: time.Now(),
+							})
+						}
+						wsMutex.Unlock()
+					}
+				}
+
+				// Lifecycle stream per interface (started/error/ended/router_offline/resumed)
+				onStatus := func(status, reason string) {
+					msg := TrafficMessage{
+						Type:      status,
+						Interface: interfaceName,
+						Code:      status, // status lifecycle sekaligus code katalog
+						Message:   i18n.T(r, status),
+						Timestamp: time.Now(),
+					}
+					if status == services.StreamError {
+						msg.Error = reason
+					} else if reason != "" {
+						msg.Message += " (" + reason + ")"
+					}
+
+					wsMutex.Lock()
+					if wsOpen {
+						sendMessage(conn, msg)
+					}
+					wsMutex.Unlock()
 				}
 
 				// Start monitoring dengan context
-				if err := ms.MonitorInterfaceTrafficWithContext(ctx, routerID, interfaceName, callback); err != nil {
+				if err := ms.MonitorInterfaceTrafficWithContext(ctx, routerID, interfaceName, callback, onStatus); err != nil {
 					log.Printf("[WS] Failed to start monitoring interface %s: %v", interfaceName, err)
+					wsMutex.Lock()
+					if wsOpen {
+						sendMessage(conn, TrafficMessage{
+							Type:      services.StreamError,
+							Interface: interfaceName,
+							Code:      i18n.ErrorCode(err, services.StreamError),
+							Error:     i18n.ErrorText(r, err),
+							Timestamp: time.Now(),
+						})
+					}
+					wsMutex.Unlock()
 					
 					startErrorMutex.Lock()
 					startErrors = append(startErrors, fmt.Sprintf("%s: %v", interfaceName, err))
@@ -186,13 +413,13 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 		// Send status message
 		wsMutex.Lock()
 		if len(startErrors) > 0 {
-			errMsg := fmt.Sprintf("Failed to start %d interface(s): %s", 
-				len(startErrors), strings.Join(startErrors, "; "))
+			errMsg := i18n.T(r, i18n.MonitoringStartFailed, len(startErrors), strings.Join(startErrors, "; "))
 			log.Printf("[WS] %s", errMsg)
 			
 			if wsOpen {
 				sendMessage(conn, TrafficMessage{
 					Type:      "error",
+					Code:      i18n.MonitoringStartFailed,
 					Error:     errMsg,
 					Timestamp: time.Now(),
 				})
@@ -210,8 +437,8 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 		if successCount > 0 && wsOpen {
 			successMsg := TrafficMessage{
 				Type:      "connected",
-				Message:   fmt.Sprintf("Monitoring started for router %d: %s (%d interface(s))", 
-					routerID, strings.Join(interfaces, ", "), successCount),
+				Code:      i18n.MonitoringStarted,
+				Message:   i18n.T(r, i18n.MonitoringStarted, routerID, strings.Join(interfaces, ", "), successCount),
 				Timestamp: time.Now(),
 			}
 			sendMessage(conn, successMsg)
@@ -240,6 +467,44 @@ func MonitorTrafficWS(ms *services.MikrotikService) http.HandlerFunc {
 	}
 }
 
+// trafficData - Payload traffic_update sesuai versi protokol koneksi
+func trafficData(version int, stats *services.TrafficStats) interface{} {
+	if version >= wsProtocolTyped {
+		return dto.NewTrafficStats(stats)
+	}
+	return stats
+}
+
+// sendBackfill - Kirim history traffic per interface dari traffic history store
+func sendBackfill(conn *websocket.Conn, repo *repository.TrafficRepository, lang string, routerID int, interfaces []string, span time.Duration) {
+	to := time.Now()
+	from := to.Add(-span)
+
+	for _, iface := range interfaces {
+		samples, err := repo.GetHistory(routerID, iface, from, to)
+		if err != nil {
+			log.Printf("[WS] Error loading backfill for router %d, interface %s: %v", routerID, iface, err)
+			sendMessage(conn, TrafficMessage{
+				Type:      "error",
+				Interface: iface,
+				Code:      i18n.HistoryLoadFailed,
+				Error:     i18n.Translate(lang, i18n.HistoryLoadFailed, err),
+				Timestamp: time.Now(),
+			})
+			continue
+		}
+
+		sendMessage(conn, TrafficMessage{
+			Type:      "history",
+			Interface: iface,
+			History:   samples,
+			Code:      i18n.HistoryBackfill,
+			Message:   i18n.Translate(lang, i18n.HistoryBackfill, len(samples), from.Format(time.RFC3339)),
+			Timestamp: time.Now(),
+		})
+	}
+}
+
 // parseInterfaceList parses interface parameter(s) from URL
 func parseInterfaceList(r *http.Request) []string {
 	query := r.URL.Query()
@@ -272,6 +537,17 @@ func sendMessage(conn *websocket.Conn, msg TrafficMessage) {
 	}
 }
 
+// sendForbidden - Error forbidden untuk router / interface di luar scope user
+func sendForbidden(conn *websocket.Conn, r *http.Request, iface string) {
+	sendMessage(conn, TrafficMessage{
+		Type:      "error",
+		Interface: iface,
+		Code:      i18n.Forbidden,
+		Error:     i18n.T(r, i18n.Forbidden),
+		Timestamp: time.Now(),
+	})
+}
+
 // GetTrafficOnce - HTTP endpoint untuk get traffic stats
 func GetTrafficOnce(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -283,7 +559,8 @@ func GetTrafficOnce(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'router_id'"),
 			})
 			return
 		}
@@ -294,11 +571,17 @@ func GetTrafficOnce(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusBadRequest)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "parameter 'interface' diperlukan",
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'interface'"),
 			})
 			return
 		}
 
+		if !auth.FromRequest(r).CanMonitor(routerID, interfaceName) {
+			auth.Forbidden(w, r)
+			return
+		}
+
 		log.Printf("[HTTP] Getting traffic stats for router %d, interface %s", routerID, interfaceName)
 
 		stats, err := ms.GetInterfaceTrafficOnce(routerID, interfaceName)
@@ -307,7 +590,8 @@ func GetTrafficOnce(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
@@ -315,9 +599,14 @@ func GetTrafficOnce(ms *services.MikrotikService) http.HandlerFunc {
 		log.Printf("[HTTP] Traffic stats retrieved successfully: RX=%s, TX=%s", 
 			stats.RxBytes, stats.TxBytes)
 
+		var data interface{} = stats
+		if middleware.IsV1(r) {
+			data = dto.NewTrafficStats(stats)
+		}
+
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Data:    stats,
+			Data:    data,
 		})
 	}
 }
@@ -327,37 +616,42 @@ func ListAvailableInterfaces(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("[HTTP] ListAvailableInterfaces request")
 		
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan dan harus valid",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
 			return
 		}
 
+		principal := auth.FromRequest(r)
 		interfaces, err := ms.GetInterfaces(routerID)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
 
-		// Filter only running interfaces
-		var available []map[string]interface{}
+		// Filter only running interfaces (dan yang boleh dimonitor user)
+		var available []interface{}
 		for _, iface := range interfaces {
-			if iface.Running && !iface.Disabled {
+			if iface.Running && !iface.Disabled && principal.CanMonitor(routerID, iface.Name) {
+				if middleware.IsV1(r) {
+					available = append(available, dto.NewInterface(iface))
+					continue
+				}
 				available = append(available, map[string]interface{}{
-					"name":       iface.Name,
-					"type":       iface.Type,
-					"rx_bytes":   iface.RxBytes,
-					"tx_bytes":   iface.TxBytes,
-					"rx_packets": iface.RxPackets,
-					"tx_packets": iface.TxPackets,
+					"name":              iface.Name,
+					"type":              iface.Type,
+					"rx_bytes":          iface.RxBytes,
+					"tx_bytes":          iface.TxBytes,
+					"rx_packets":        iface.RxPackets,
+					"tx_packets":        iface.TxPackets,
+					"comment":           iface.Comment,
+					"mac_address":       iface.MacAddress,
+					"mtu":               iface.MTU,
+					"last_link_up_time": iface.LastLinkUp,
 				})
 			}
 		}
@@ -367,34 +661,76 @@ func ListAvailableInterfaces(ms *services.MikrotikService) http.HandlerFunc {
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
 			Data:    available,
-			Message: fmt.Sprintf("Found %d available interfaces", len(available)),
+			Code:    i18n.InterfacesFound,
+			Message: i18n.T(r, i18n.InterfacesFound, len(available)),
 		})
 	}
 }
 
 // GetConnectionStatus - Get status semua router connections
+// ?probe=true: tambahan liveness probe paralel (?timeout=ms, default 2000, max 10000)
 func GetConnectionStatus(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("[HTTP] GetConnectionStatus request")
+
+		var probes map[int]*models.ProbeResult
+		if probe, _ := strconv.ParseBool(r.URL.Query().Get("probe")); probe {
+			timeout := 2000
+			if v := r.URL.Query().Get("timeout"); v != "" {
+				var err error
+				if timeout, err = strconv.Atoi(v); err != nil || timeout < 100 || timeout > 10000 {
+					w.WriteHeader(http.StatusBadRequest)
+					json.NewEncoder(w).Encode(models.ApiResponse{
+						Success: false,
+						Code:    i18n.OutOfRange,
+						Error:   i18n.T(r, i18n.OutOfRange, "timeout", 100, 10000, "ms"),
+					})
+					return
+				}
+			}
+			probes = ms.ProbeConnections(time.Duration(timeout) * time.Millisecond)
+		}
 		
 		connections := ms.GetAllConnections()
 
 		type ConnectionInfo struct {
-			RouterID   int       `json:"router_id"`
-			RouterName string    `json:"router_name"`
-			Hostname   string    `json:"hostname"`
-			IsHealthy  bool      `json:"is_healthy"`
-			LastPing   time.Time `json:"last_ping"`
+			RouterID       int                    `json:"router_id"`
+			RouterName     string                 `json:"router_name"`
+			Hostname       string                 `json:"hostname"`
+			ActivePath     string                 `json:"active_path,omitempty"`      // primary / label alamat tambahan
+			ActiveAddress  string                 `json:"active_address,omitempty"`   // alamat management yang dipakai
+			ResolvedAddr   string                 `json:"resolved_address,omitempty"` // IP hasil resolve hostname DNS
+			IsHealthy      bool                   `json:"is_healthy"`
+			HealthLevel    string                 `json:"health_level,omitempty"` // healthy, degraded, down
+			LastPing       time.Time              `json:"last_ping"`
+			Probe          *models.ProbeResult    `json:"probe,omitempty"`
+			CommandLatency *models.CommandLatency `json:"command_latency,omitempty"` // persentil 200 command terakhir
+			Streams        int                    `json:"streams"`                   // Listen stream RouterOS aktif
+			StreamLimit    int                    `json:"stream_limit,omitempty"`    // 0 = tanpa batas
 		}
 
+		scorer := services.GetHealthScorer()
+		streamLimit := ms.StreamLimitPerRouter()
 		var result []ConnectionInfo
 		for _, conn := range connections {
+			var level string
+			if health := scorer.Get(conn.RouterID); health != nil {
+				level = health.Level
+			}
 			result = append(result, ConnectionInfo{
-				RouterID:   conn.RouterID,
-				RouterName: conn.Router.Name,
-				Hostname:   conn.Router.Hostname,
-				IsHealthy:  conn.IsHealthy,
-				LastPing:   conn.LastPing,
+				RouterID:       conn.RouterID,
+				RouterName:     conn.Router.Name,
+				Hostname:       conn.Router.Hostname,
+				ActivePath:     conn.ActivePath,
+				ActiveAddress:  conn.ActiveAddress,
+				ResolvedAddr:   conn.ResolvedAddress,
+				IsHealthy:      conn.IsHealthy,
+				HealthLevel:    level,
+				LastPing:       conn.LastPing,
+				Probe:          probes[conn.RouterID],
+				CommandLatency: conn.CommandLatency(),
+				Streams:        ms.ListenStreams(conn.RouterID),
+				StreamLimit:    streamLimit,
 			})
 		}
 
@@ -410,13 +746,8 @@ func GetConnectionStatus(ms *services.MikrotikService) http.HandlerFunc {
 // ConnectRouterHandler - Manual connect ke router dengan timeout
 func ConnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
 			return
 		}
 
@@ -442,7 +773,8 @@ func ConnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 				w.WriteHeader(http.StatusInternalServerError)
 				json.NewEncoder(w).Encode(models.ApiResponse{
 					Success: false,
-					Error:   err.Error(),
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
 				})
 				return
 			}
@@ -450,7 +782,8 @@ func ConnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 			log.Printf("[HTTP] Successfully connected to router ID: %d", routerID)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: true,
-				Message: "Router berhasil terkoneksi",
+				Code:    i18n.RouterConnected,
+				Message: i18n.T(r, i18n.RouterConnected),
 			})
 
 		case <-ctx.Done():
@@ -458,7 +791,8 @@ func ConnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusRequestTimeout)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   "Connection timeout after 30 seconds",
+				Code:    i18n.ConnectionTimeout,
+				Error:   i18n.T(r, i18n.ConnectionTimeout),
 			})
 		}
 	}
@@ -467,13 +801,8 @@ func ConnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 // DisconnectRouterHandler - Manual disconnect dari router
 func DisconnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
-		if err != nil || routerID == 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(models.ApiResponse{
-				Success: false,
-				Error:   "parameter 'router_id' diperlukan",
-			})
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
 			return
 		}
 
@@ -484,7 +813,8 @@ func DisconnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(models.ApiResponse{
 				Success: false,
-				Error:   err.Error(),
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
 			})
 			return
 		}
@@ -492,7 +822,8 @@ func DisconnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 		log.Printf("[HTTP] Successfully disconnected router ID: %d", routerID)
 		json.NewEncoder(w).Encode(models.ApiResponse{
 			Success: true,
-			Message: "Router berhasil didisconnect",
+			Code:    i18n.RouterDisconnected,
+			Message: i18n.T(r, i18n.RouterDisconnected),
 		})
 	}
 }
@@ -501,7 +832,8 @@ func DisconnectRouterHandler(ms *services.MikrotikService) http.HandlerFunc {
 func WsHealthCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(models.ApiResponse{
 		Success: true,
-		Message: "WebSocket server is healthy",
+		Code:    i18n.WSHealthy,
+		Message: i18n.T(r, i18n.WSHealthy),
 		Data: map[string]interface{}{
 			"timestamp": time.Now(),
 			"status":    "ok",
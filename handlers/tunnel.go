@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+)
+
+// RegisterRouterTunnel - POST /api/routers/{id}/tunnel, body TunnelRegisterRequest
+// Router di belakang CGNAT dial keluar ke concentrator; hostname diganti alamat tunnel begitu
+// tunnel terhubung. Untuk WireGuard response berisi script router + private key (sekali tampil).
+func (h *RouterHandler) RegisterRouterTunnel(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/routers/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
+		})
+		return
+	}
+
+	var req models.TunnelRegisterRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	principal := auth.FromRequest(r)
+	if !principal.CanAccessRouter(id) || !principal.CanAccessRouter(req.ConcentratorRouterID) {
+		auth.Forbidden(w, r)
+		return
+	}
+
+	dryRun := isDryRun(r)
+	result, err := h.ms.RegisterTunnel(id, &req, dryRun)
+	if err != nil {
+		w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	// Response WireGuard berisi private key: jangan di-cache proxy / browser
+	w.Header().Set("Cache-Control", "no-store")
+	if !dryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    planCode(dryRun, i18n.TunnelRegistered),
+		Message: planMessage(r, dryRun, i18n.TunnelRegistered),
+		Data:    result,
+	})
+}
+
+// UnregisterRouterTunnel - DELETE /api/routers/{id}/tunnel
+// Hanya menghapus registrasi; peer di concentrator dan hostname router tidak diubah
+func (h *RouterHandler) UnregisterRouterTunnel(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/routers/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "router"),
+		})
+		return
+	}
+
+	if !auth.FromRequest(r).CanAccessRouter(id) {
+		auth.Forbidden(w, r)
+		return
+	}
+
+	if err := h.ms.UnregisterTunnel(id); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TunnelUnregistered,
+		Message: i18n.T(r, i18n.TunnelUnregistered),
+	})
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/validation"
+)
+
+// QueueQuotaRequest - Body set kuota bulanan queue
+type QueueQuotaRequest struct {
+	RouterID          int     `json:"router_id" validate:"required,min=1"`
+	QueueName         string  `json:"queue_name" validate:"required"`
+	MonthlyQuotaBytes uint64  `json:"monthly_quota_bytes" validate:"required"`
+	Action            string  `json:"action" validate:"oneof=alert throttle address_list"` // default alert
+	ThrottleLimit     *string `json:"throttle_limit" validate:"rate_limit"`                // wajib untuk action throttle
+	AddressList       *string `json:"address_list"`                                        // default over-quota
+}
+
+// GetQueueUsage - GET /api/usage/queues?router_id=X&period=day|month&queue=&from=&to=&export=csv|xlsx
+// Default: period month, rentang 1 tahun (day: 31 hari)
+func GetQueueUsage(repo *repository.UsageRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		format, err := exportFormat(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		period, span, ok := usagePeriod(w, r)
+		if !ok {
+			return
+		}
+
+		from, to, err := parseTimeRange(r, span)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		filter := &models.UsageFilter{
+			RouterID:  routerID,
+			QueueName: r.URL.Query().Get("queue"),
+			Period:    period,
+			From:      from,
+			To:        to,
+		}
+
+		if format != "" {
+			columns := []string{"router_id", "queue_name", "period", "period_start",
+				"upload_bytes", "download_bytes", "total_bytes", "quota_bytes", "updated_at"}
+			streamExport(w, format, "queue-usage", columns, func(tw tableWriter) error {
+				return repo.Each(filter, func(u *models.QueueUsage) error {
+					return tw.WriteRow(u.RouterID, u.QueueName, u.Period, u.PeriodStart.Format("2006-01-02"),
+						u.UploadBytes, u.DownloadBytes, u.TotalBytes, u.QuotaBytes, u.UpdatedAt)
+				})
+			})
+			return
+		}
+
+		usages, err := repo.List(filter)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    usages,
+		})
+	}
+}
+
+// usagePeriod - Parse ?period=day|month beserta rentang default-nya (month: 1 tahun, day: 31 hari)
+func usagePeriod(w http.ResponseWriter, r *http.Request) (string, time.Duration, bool) {
+	switch period := r.URL.Query().Get("period"); period {
+	case "", "month":
+		return "month", 366 * 24 * time.Hour, true
+	case "day":
+		return period, 31 * 24 * time.Hour, true
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidChoice,
+			Error:   i18n.T(r, i18n.InvalidChoice, "period", "day/month"),
+		})
+		return "", 0, false
+	}
+}
+
+// QueueQuotas - /api/usage/quotas
+// GET ?router_id=X: list, POST body QueueQuotaRequest: set, DELETE ?router_id=X&queue=Y: hapus
+func QueueQuotas(repo *repository.UsageRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			routerID, ok := scopedRouterID(w, r)
+			if !ok {
+				return
+			}
+
+			quotas, err := repo.ListQuotas(routerID)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Data:    quotas,
+			})
+
+		case http.MethodPost:
+			var req QueueQuotaRequest
+			if !decodeRequest(w, r, &req) {
+				return
+			}
+			if !auth.FromRequest(r).CanAccessRouter(req.RouterID) {
+				auth.Forbidden(w, r)
+				return
+			}
+
+			if req.Action == "" {
+				req.Action = models.QuotaActionAlert
+			}
+			// throttle_limit wajib untuk action throttle
+			if req.Action == models.QuotaActionThrottle && (req.ThrottleLimit == nil || *req.ThrottleLimit == "") {
+				var errs validation.Errors
+				errs.Add("throttle_limit", validation.RuleRequired, "")
+				writeValidationError(w, r, errs)
+				return
+			}
+
+			if err := repo.UpsertQuota(&models.QueueQuota{
+				RouterID:          req.RouterID,
+				QueueName:         req.QueueName,
+				MonthlyQuotaBytes: req.MonthlyQuotaBytes,
+				Action:            req.Action,
+				ThrottleLimit:     req.ThrottleLimit,
+				AddressList:       req.AddressList,
+			}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.QuotaSaved,
+				Message: i18n.T(r, i18n.QuotaSaved),
+			})
+
+		case http.MethodDelete:
+			routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
+			queue := r.URL.Query().Get("queue")
+			if err != nil || routerID == 0 || queue == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.MissingParameter,
+					Error:   i18n.T(r, i18n.MissingParameter, "'router_id', 'queue'"),
+				})
+				return
+			}
+			if !auth.FromRequest(r).CanAccessRouter(routerID) {
+				auth.Forbidden(w, r)
+				return
+			}
+
+			if err := repo.DeleteQuota(routerID, queue); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.ErrorCode(err, i18n.InternalError),
+					Error:   i18n.ErrorText(r, err),
+				})
+				return
+			}
+
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: true,
+				Code:    i18n.QuotaDeleted,
+				Message: i18n.T(r, i18n.QuotaDeleted),
+			})
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
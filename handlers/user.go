@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type UserHandler struct {
+	repo         *repository.UserRepository
+	routerRepo   *repository.RouterRepository
+	resellerRepo *repository.ResellerRepository
+}
+
+func NewUserHandler(repo *repository.UserRepository, routerRepo *repository.RouterRepository,
+	resellerRepo *repository.ResellerRepository) *UserHandler {
+	return &UserHandler{repo: repo, routerRepo: routerRepo, resellerRepo: resellerRepo}
+}
+
+// GetMe - GET /api/auth/me
+// Identitas token yang dipakai beserta router yang boleh diakses (router_ids kosong = semua)
+func GetMe(w http.ResponseWriter, r *http.Request) {
+	principal := auth.FromRequest(r)
+	if principal == nil {
+		principal = &auth.Principal{Role: models.RoleAdmin}
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"user":       principal,
+			"router_ids": principal.RouterIDs(),
+		},
+	})
+}
+
+// GetAllUsers - GET /api/users
+func (h *UserHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.repo.GetAll()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    users,
+	})
+}
+
+// CreateUser - POST /api/users
+// Role reseller wajib reseller_id; role lain mengabaikannya
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req models.UserCreateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if req.Role != models.RoleReseller {
+		req.ResellerID = nil
+	} else {
+		var errs validation.Errors
+		if req.ResellerID == nil {
+			errs.Add("reseller_id", validation.RuleRequired, "")
+		} else if _, err := h.resellerRepo.GetByID(*req.ResellerID); err != nil {
+			errs.Add("reseller_id", validation.RuleExists, "reseller")
+		}
+		if len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+	}
+
+	user, err := h.repo.Create(&req)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.UserCreated,
+		Message: i18n.T(r, i18n.UserCreated),
+		Data:    user,
+	})
+}
+
+// GetUser - GET /api/users/{id}
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    user,
+	})
+}
+
+// DeleteUser - DELETE /api/users/{id}
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.UserDeleted,
+		Message: i18n.T(r, i18n.UserDeleted),
+	})
+}
+
+// SetUserScopes - PUT /api/users/{id}/scopes
+// Body {"scopes": [{"router_id": 1, "interfaces": ["ether1"]}]}; interfaces kosong = semua interface
+func (h *UserHandler) SetUserScopes(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UserScopesRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	var errs validation.Errors
+	for i, scope := range req.Scopes {
+		field := "scopes[" + strconv.Itoa(i) + "].router_id"
+		if scope.RouterID < 1 {
+			errs.Add(field, validation.RuleRequired, "")
+		} else if _, err := h.routerRepo.GetByID(scope.RouterID); err != nil {
+			errs.Add(field, validation.RuleExists, "router")
+		}
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	if _, err := h.repo.GetByID(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	if err := h.repo.SetScopes(id, req.Scopes); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	user, _ := h.repo.GetByID(id)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.UserScopesUpdated,
+		Message: i18n.T(r, i18n.UserScopesUpdated),
+		Data:    user,
+	})
+}
+
+// UserTokens - /api/users/{id}/tokens
+// GET: list token (tanpa nilai), POST body TokenCreateRequest: buat token baru
+func (h *UserHandler) UserTokens(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.repo.GetByID(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		tokens, err := h.repo.ListTokens(id)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    tokens,
+		})
+		return
+	}
+
+	var req models.TokenCreateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	networks, err := services.ParseCIDRList(strings.Join(req.AllowedCIDRs, ","))
+	if err != nil {
+		var errs validation.Errors
+		errs.Add("allowed_cidrs", validation.RuleCIDR, "")
+		writeValidationError(w, r, errs)
+		return
+	}
+	allowed := make([]string, len(networks))
+	for i, network := range networks {
+		allowed[i] = network.String()
+	}
+
+	plain, err := auth.GenerateToken()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	token, err := h.repo.CreateToken(id, req.Name, auth.HashToken(plain), allowed)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+	token.Token = plain
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TokenCreated,
+		Message: i18n.T(r, i18n.TokenCreated),
+		Data:    token,
+	})
+}
+
+// RevokeToken - DELETE /api/users/{id}/tokens/{token_id}
+func (h *UserHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	id, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/users/"), "/")
+	tokenID, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "token"),
+		})
+		return
+	}
+
+	if err := h.repo.DeleteToken(id, tokenID); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.TokenRevoked,
+		Message: i18n.T(r, i18n.TokenRevoked),
+	})
+}
+
+// userIDFromPath - Ambil {id} dari /api/users/{id}[/...]
+func userIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/users/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "user"),
+		})
+		return 0, false
+	}
+	return id, true
+}
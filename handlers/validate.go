@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/validation"
+)
+
+// decodeRequest - Decode body JSON ke v lalu validasi tag `validate`.
+// Jika false, response 400 (body rusak) atau 422 (validasi) sudah ditulis.
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidRequestBody,
+			Error:   i18n.T(r, i18n.InvalidRequestBody, err),
+		})
+		return false
+	}
+
+	if errs := validation.Struct(v); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return false
+	}
+	return true
+}
+
+// writeValidationError - Response 422 dengan detail per field dalam bahasa request
+func writeValidationError(w http.ResponseWriter, r *http.Request, errs validation.Errors) {
+	lang := i18n.FromRequest(r)
+	fields := make([]models.FieldError, len(errs))
+	for i, fe := range errs {
+		fields[i] = models.FieldError{
+			Field: fe.Field,
+			Rule:  fe.Rule,
+			Error: validation.Message(lang, fe),
+		}
+	}
+
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: false,
+		Code:    i18n.ValidationFailed,
+		Error:   i18n.Translate(lang, i18n.ValidationFailed),
+		Errors:  fields,
+	})
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// GetVLANTraffic - GET /api/traffic/vlans?router_id=1&interface=ether1
+// Snapshot traffic parent dipecah per VLAN di atasnya; versi stream: /ws/traffic/monitor?vlans_of=
+func GetVLANTraffic(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		parent := r.URL.Query().Get("interface")
+		if parent == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'interface'"),
+			})
+			return
+		}
+
+		// Izin monitor parent berlaku untuk VLAN di atasnya
+		if !auth.FromRequest(r).CanMonitor(routerID, parent) {
+			auth.Forbidden(w, r)
+			return
+		}
+
+		breakdown, err := ms.GetVLANBreakdown(routerID, parent)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    breakdown,
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+)
+
+type WebhookHandler struct {
+	repo *repository.WebhookRepository
+}
+
+func NewWebhookHandler(repo *repository.WebhookRepository) *WebhookHandler {
+	return &WebhookHandler{repo: repo}
+}
+
+// webhookWithSecret - Response create: secret hanya ditampilkan sekali
+type webhookWithSecret struct {
+	*models.Webhook
+	Secret string `json:"secret"`
+}
+
+// GetAllWebhooks - GET /api/webhooks
+func (h *WebhookHandler) GetAllWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.repo.List(false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    webhooks,
+	})
+}
+
+// CreateWebhook - POST /api/webhooks (secret dibangkitkan jika tidak diisi)
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req models.WebhookRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	webhook := &models.Webhook{Enabled: true, Events: []string{}}
+	if errs := mergeWebhookRequest(webhook, &req); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+	if webhook.Secret == "" {
+		secret, err := services.NewWebhookSecret()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+		webhook.Secret = secret
+	}
+
+	created, err := h.repo.Create(webhook)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.WebhookCreated,
+		Message: i18n.T(r, i18n.WebhookCreated),
+		Data:    webhookWithSecret{Webhook: created, Secret: created.Secret},
+	})
+}
+
+// GetWebhook - GET /api/webhooks/{id}
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := h.webhookFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    webhook,
+	})
+}
+
+// UpdateWebhook - PUT /api/webhooks/{id} (field yang diisi saja; secret bisa dirotasi)
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := h.webhookFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.WebhookRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if errs := mergeWebhookRequest(webhook, &req); len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	if err := h.repo.Update(webhook); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.WebhookUpdated,
+		Message: i18n.T(r, i18n.WebhookUpdated),
+		Data:    webhook,
+	})
+}
+
+// DeleteWebhook - DELETE /api/webhooks/{id} (delivery yang belum terkirim ikut terhapus)
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Delete(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.WebhookDeleted,
+		Message: i18n.T(r, i18n.WebhookDeleted),
+	})
+}
+
+// GetWebhookDeliveries - GET /api/webhooks/{id}/deliveries?status=pending|delivered|failed&limit=
+func (h *WebhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhook, ok := h.webhookFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	errs := validation.Var("status", status, "oneof="+models.DeliveryPending+" "+models.DeliveryDelivered+" "+models.DeliveryFailed)
+	limit := 100
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			errs.Add("limit", validation.RuleMin, "1")
+		} else {
+			errs = append(errs, validation.Var("limit", limit, "min=1,max=1000")...)
+		}
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, r, errs)
+		return
+	}
+
+	deliveries, err := h.repo.Deliveries(webhook.ID, status, limit)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.InternalError),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Data:    deliveries,
+	})
+}
+
+// RedeliverWebhook - POST /api/webhooks/{id}/deliveries/{delivery_id}/redeliver
+// Jadwalkan ulang delivery (termasuk yang sudah failed) dengan hitungan attempt dari awal
+func (h *WebhookHandler) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/webhooks/"), "/")
+	deliveryID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "delivery"),
+		})
+		return
+	}
+
+	if err := h.repo.Redeliver(id, deliveryID); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return
+	}
+
+	json.NewEncoder(w).Encode(models.ApiResponse{
+		Success: true,
+		Code:    i18n.WebhookRedelivered,
+		Message: i18n.T(r, i18n.WebhookRedelivered),
+	})
+}
+
+// mergeWebhookRequest - Terapkan field request yang diisi lalu cek field wajib & nama event
+func mergeWebhookRequest(webhook *models.Webhook, req *models.WebhookRequest) validation.Errors {
+	if req.Name != "" {
+		webhook.Name = req.Name
+	}
+	if req.URL != "" {
+		webhook.URL = req.URL
+	}
+	if req.Secret != nil && *req.Secret != "" {
+		webhook.Secret = *req.Secret
+	}
+	if req.Events != nil {
+		webhook.Events = *req.Events
+	}
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+
+	var errs validation.Errors
+	if webhook.Name == "" {
+		errs.Add("name", validation.RuleRequired, "")
+	}
+	if webhook.URL == "" {
+		errs.Add("url", validation.RuleRequired, "")
+	}
+	for i, event := range webhook.Events {
+		errs = append(errs, validation.Var("events["+strconv.Itoa(i)+"]", event,
+			"required,oneof="+strings.Join(models.WebhookEvents, " "))...)
+	}
+	return errs
+}
+
+// webhookFromPath - Ambil webhook {id} (tulis 400/404 jika gagal)
+func (h *WebhookHandler) webhookFromPath(w http.ResponseWriter, r *http.Request) (*models.Webhook, bool) {
+	id, ok := webhookIDFromPath(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	webhook, err := h.repo.GetByID(id)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.ErrorCode(err, i18n.NotFound),
+			Error:   i18n.ErrorText(r, err),
+		})
+		return nil, false
+	}
+	return webhook, true
+}
+
+// webhookIDFromPath - Ambil {id} dari /api/webhooks/{id}[/...]
+func webhookIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/webhooks/")
+	id, err := strconv.Atoi(strings.Split(path, "/")[0])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: false,
+			Code:    i18n.InvalidID,
+			Error:   i18n.T(r, i18n.InvalidID, "webhook"),
+		})
+		return 0, false
+	}
+	return id, true
+}
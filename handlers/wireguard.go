@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// CreateWireGuardPeer - POST /api/wireguard/peers?router_id=X, body WireGuardPeerRequest
+// Keypair client dibangkitkan di server dan hanya dikembalikan sekali di response (tidak disimpan).
+func CreateWireGuardPeer(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MethodNotAllowed,
+				Error:   i18n.T(r, i18n.MethodNotAllowed),
+			})
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		var req models.WireGuardPeerRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+
+		dryRun := isDryRun(r)
+		result, err := ms.CreateWireGuardPeer(routerID, &req, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		// Response berisi private key: jangan di-cache proxy / browser
+		w.Header().Set("Cache-Control", "no-store")
+		if !dryRun {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.WireGuardPeerCreated),
+			Message: planMessage(r, dryRun, i18n.WireGuardPeerCreated),
+			Data:    result,
+		})
+	}
+}
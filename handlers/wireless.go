@@ -0,0 +1,407 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/auth"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/validation"
+
+	"github.com/gorilla/websocket"
+)
+
+// WirelessMessage - Message stream /ws/wireless/monitor
+type WirelessMessage struct {
+	Type      string                  `json:"type"`
+	Interface string                  `json:"interface,omitempty"`
+	Data      *models.WirelessMonitor `json:"data,omitempty"`
+	Code      string                  `json:"code,omitempty"`
+	Error     string                  `json:"error,omitempty"`
+	Message   string                  `json:"message,omitempty"`
+	Timestamp time.Time               `json:"timestamp"`
+}
+
+func sendWirelessMessage(conn *websocket.Conn, msg WirelessMessage) {
+	if err := conn.WriteJSON(msg); err != nil {
+		log.Printf("[WS-WIRELESS] Error sending message: %v", err)
+	}
+}
+
+// MonitorWirelessWS - WebSocket kualitas link wireless (signal, tx/rx rate, CCQ)
+// Pattern: /ws/wireless/monitor?router_id=1&interface=wlan1[&interval=1] (detik, 1-60)
+// Mengirim wireless_update tiap interval plus lifecycle stream_started, stream_error,
+// stream_ended, router_offline dan stream_resumed seperti /ws/traffic/monitor, diawali hello.
+func MonitorWirelessWS(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Printf("[WS-WIRELESS] Error upgrade WebSocket: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		proto, ok := newWSProtocol(r)
+		if !ok {
+			conn.WriteJSON(unsupportedProtocol(r, r.URL.Query().Get("protocol")))
+			return
+		}
+		conn.WriteJSON(proto.hello(r, nil))
+
+		routerID, err := strconv.Atoi(r.URL.Query().Get("router_id"))
+		if err != nil || routerID == 0 {
+			sendWirelessMessage(conn, WirelessMessage{
+				Type:      "error",
+				Code:      i18n.MissingParameter,
+				Error:     i18n.T(r, i18n.MissingParameter, "'router_id'"),
+				Timestamp: time.Now(),
+			})
+			return
+		}
+
+		iface := r.URL.Query().Get("interface")
+		if iface == "" {
+			sendWirelessMessage(conn, WirelessMessage{
+				Type:      "error",
+				Code:      i18n.MissingParameter,
+				Error:     i18n.T(r, i18n.MissingParameter, "'interface'"),
+				Timestamp: time.Now(),
+			})
+			return
+		}
+
+		principal := auth.FromRequest(r)
+		if !principal.CanMonitor(routerID, iface) {
+			log.Printf("[WS-WIRELESS] User %s not allowed to monitor router %d, interface %s", principal.Username, routerID, iface)
+			sendWirelessMessage(conn, WirelessMessage{
+				Type:      "error",
+				Interface: iface,
+				Code:      i18n.Forbidden,
+				Error:     i18n.T(r, i18n.Forbidden),
+				Timestamp: time.Now(),
+			})
+			return
+		}
+
+		interval := 1
+		if v := r.URL.Query().Get("interval"); v != "" {
+			if interval, err = strconv.Atoi(v); err != nil || interval < 1 || interval > 60 {
+				sendWirelessMessage(conn, WirelessMessage{
+					Type:      "error",
+					Code:      i18n.OutOfRange,
+					Error:     i18n.T(r, i18n.OutOfRange, "interval", 1, 60, "s"),
+					Timestamp: time.Now(),
+				})
+				return
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var wsMutex sync.Mutex
+		wsOpen := true
+		send := func(msg WirelessMessage) {
+			wsMutex.Lock()
+			defer wsMutex.Unlock()
+			if !wsOpen {
+				return
+			}
+			if err := conn.WriteJSON(msg); err != nil {
+				log.Printf("[WS-WIRELESS] Error sending data (%s): %v", iface, err)
+				wsOpen = false
+				cancel()
+			}
+		}
+
+		// Stream ditutup server jika router dihapus
+		unregister := ms.RegisterStream(routerID, func(reason string) {
+			send(WirelessMessage{
+				Type:      services.StreamEnded,
+				Code:      services.StreamEnded,
+				Message:   i18n.T(r, services.StreamEnded) + " (" + reason + ")",
+				Timestamp: time.Now(),
+			})
+			cancel()
+			conn.Close()
+		})
+		defer unregister()
+
+		callback := func(stats *models.WirelessMonitor) {
+			send(WirelessMessage{
+				Type:      "wireless_update",
+				Interface: iface,
+				Data:      stats,
+				Timestamp: time.Now(),
+			})
+		}
+		onStatus := func(status, reason string) {
+			msg := WirelessMessage{
+				Type:      status,
+				Interface: iface,
+				Code:      status,
+				Message:   i18n.T(r, status),
+				Timestamp: time.Now(),
+			}
+			if status == services.StreamError {
+				msg.Error = reason
+			} else if reason != "" {
+				msg.Message += " (" + reason + ")"
+			}
+			send(msg)
+			if status == services.StreamEnded {
+				cancel()
+			}
+		}
+
+		err = ms.MonitorWirelessWithContext(ctx, routerID, iface, time.Duration(interval)*time.Second, callback, onStatus)
+		if err != nil {
+			log.Printf("[WS-WIRELESS] Failed to start monitor router %d, interface %s: %v", routerID, iface, err)
+			send(WirelessMessage{
+				Type:      services.StreamError,
+				Interface: iface,
+				Code:      i18n.ErrorCode(err, services.StreamError),
+				Error:     i18n.ErrorText(r, err),
+				Timestamp: time.Now(),
+			})
+			return
+		}
+		log.Printf("[WS-WIRELESS] Monitoring router %d, interface %s every %ds", routerID, iface, interval)
+
+		// Baca message client sampai disconnect (ping dibalas pong) atau stream berakhir
+		go func() {
+			defer services.Recover("ws-wireless-reader")
+			defer cancel()
+			for {
+				messageType, message, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				if messageType != websocket.TextMessage {
+					continue
+				}
+				var cmd map[string]interface{}
+				if err := json.Unmarshal(message, &cmd); err != nil {
+					continue
+				}
+				switch cmd["type"] {
+				case "ping":
+					send(WirelessMessage{Type: "pong", Timestamp: time.Now()})
+				case "hello":
+					reply := proto.handleHello(r, cmd, nil)
+					wsMutex.Lock()
+					if wsOpen {
+						conn.WriteJSON(reply)
+					}
+					wsMutex.Unlock()
+				}
+			}
+		}()
+
+		<-ctx.Done()
+		wsMutex.Lock()
+		wsOpen = false
+		wsMutex.Unlock()
+		log.Printf("[WS-WIRELESS] Monitoring stopped - Router %d, interface %s", routerID, iface)
+	}
+}
+
+// GetWirelessHistory - GET /api/wireless/history?router_id=X&interface=Y&from=&to=
+// History kualitas link dari wireless sampler, default 24 jam terakhir
+func GetWirelessHistory(repo *repository.WirelessRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		iface := r.URL.Query().Get("interface")
+		if iface == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.MissingParameter,
+				Error:   i18n.T(r, i18n.MissingParameter, "'interface'"),
+			})
+			return
+		}
+
+		if !auth.FromRequest(r).CanMonitor(routerID, iface) {
+			auth.Forbidden(w, r)
+			return
+		}
+
+		from, to, err := parseTimeRange(r, 24*time.Hour)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.BadRequest),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		samples, err := repo.GetHistory(routerID, iface, from, to)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    samples,
+		})
+	}
+}
+
+// GetWirelessRegistrations - GET /api/wireless/registrations?router_id=X[&interface=wlan1]
+// Client terdaftar (MAC, signal, CCQ, tx/rx rate) untuk monitoring pelanggan WISP
+func GetWirelessRegistrations(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		clients, err := ms.GetWirelessRegistrations(routerID, r.URL.Query().Get("interface"))
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    clients,
+		})
+	}
+}
+
+// KickWirelessClient - POST /api/wireless/registrations/kick?router_id=X&mac=AA:BB:CC:DD:EE:FF[&dry_run=true]
+func KickWirelessClient(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		mac := r.URL.Query().Get("mac")
+		if errs := validation.Var("mac", mac, "required,mac"); len(errs) > 0 {
+			writeValidationError(w, r, errs)
+			return
+		}
+		hw, _ := net.ParseMAC(mac)
+
+		dryRun := isDryRun(r)
+		plan, err := ms.KickWirelessClient(routerID, strings.ToUpper(hw.String()), dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.WirelessClientKicked),
+			Message: planMessage(r, dryRun, i18n.WirelessClientKicked),
+			Data:    plan,
+		})
+	}
+}
+
+// GetWirelessInterfaceList - GET /api/wireless/interfaces?router_id=X
+// Mode, SSID, band dan security profile semua interface wireless
+func GetWirelessInterfaceList(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		ifaces, err := ms.ListWirelessInterfaces(routerID)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Data:    ifaces,
+		})
+	}
+}
+
+// UpdateWirelessInterface - PUT /api/wireless/interfaces?router_id=X[&dry_run=true], body WirelessInterfaceRequest
+// Ganti SSID dan/atau security profile; client yang terhubung akan reconnect
+func UpdateWirelessInterface(ms *services.MikrotikService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routerID, ok := scopedRouterID(w, r)
+		if !ok {
+			return
+		}
+
+		var req models.WirelessInterfaceRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		if req.SSID == nil && req.SecurityProfile == nil {
+			var errs validation.Errors
+			errs.Add("ssid", validation.RuleRequired, "")
+			writeValidationError(w, r, errs)
+			return
+		}
+
+		dryRun := isDryRun(r)
+		plan, err := ms.SetWirelessInterface(routerID, &req, dryRun)
+		if err != nil {
+			w.WriteHeader(errorStatus(err, http.StatusInternalServerError))
+			json.NewEncoder(w).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.ErrorCode(err, i18n.InternalError),
+				Error:   i18n.ErrorText(r, err),
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(models.ApiResponse{
+			Success: true,
+			Code:    planCode(dryRun, i18n.WirelessIfaceUpdated),
+			Message: planMessage(r, dryRun, i18n.WirelessIfaceUpdated),
+			Data:    plan,
+		})
+	}
+}
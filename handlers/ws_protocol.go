@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+)
+
+// Versi protokol pesan WebSocket. Client tanpa handshake tetap di versi 1 (format lama),
+// versi baru hanya dipakai jika diminta client lewat ?protocol=N atau pesan hello.
+const (
+	wsProtocolLegacy  = 1 // traffic_update.data: field RouterOS apa adanya (counter string)
+	wsProtocolTyped   = 2 // traffic_update.data: snake_case bertipe angka (seperti DTO /api/v1)
+	wsProtocolCurrent = wsProtocolTyped
+)
+
+// wsProtocols - Versi yang didukung server, urut naik
+var wsProtocols = []int{wsProtocolLegacy, wsProtocolTyped}
+
+// wsEncodings - Encoding frame yang didukung (disiapkan untuk encoding ringkas di versi berikutnya)
+var wsEncodings = []string{"json"}
+
+// WSHello - Handshake protokol: dikirim server sebagai pesan pertama setelah connect dan
+// sebagai jawaban pesan {"type":"hello","protocol":N} dari client
+type WSHello struct {
+	Type      string    `json:"type"`     // selalu "hello"
+	Protocol  int       `json:"protocol"` // versi yang dipakai untuk pesan berikutnya
+	Supported []int     `json:"supported_protocols"`
+	Encodings []string  `json:"encodings"`
+	Topics    []string  `json:"topics,omitempty"` // topic hub yang bisa di-subscribe (/ws/events)
+	Code      string    `json:"code"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// wsProtocol - Versi protokol yang disepakati satu koneksi; dibaca goroutine pengirim,
+// diganti reader saat client mengirim hello
+type wsProtocol struct {
+	version atomic.Int32
+}
+
+// newWSProtocol - Versi dari ?protocol= (kosong = legacy); false jika versi di bawah minimum
+func newWSProtocol(r *http.Request) (*wsProtocol, bool) {
+	p := &wsProtocol{}
+	p.version.Store(wsProtocolLegacy)
+	if v := r.URL.Query().Get("protocol"); v != "" {
+		requested, err := strconv.Atoi(v)
+		if err != nil || !p.negotiate(requested) {
+			return nil, false
+		}
+	}
+	return p, true
+}
+
+// negotiate - Pakai versi yang diminta client; versi di atas yang didukung diturunkan ke
+// versi terbaru server, di bawah minimum ditolak (versi tidak berubah)
+func (p *wsProtocol) negotiate(requested int) bool {
+	if requested < wsProtocols[0] {
+		return false
+	}
+	if requested > wsProtocolCurrent {
+		requested = wsProtocolCurrent
+	}
+	p.version.Store(int32(requested))
+	return true
+}
+
+// Version - Versi protokol aktif koneksi
+func (p *wsProtocol) Version() int {
+	return int(p.version.Load())
+}
+
+// hello - Pesan hello dengan versi aktif; topics hanya diisi untuk /ws/events
+func (p *wsProtocol) hello(r *http.Request, topics []string) WSHello {
+	return WSHello{
+		Type:      "hello",
+		Protocol:  p.Version(),
+		Supported: wsProtocols,
+		Encodings: wsEncodings,
+		Topics:    topics,
+		Code:      i18n.ProtocolNegotiated,
+		Message:   i18n.T(r, i18n.ProtocolNegotiated, p.Version()),
+		Timestamp: time.Now(),
+	}
+}
+
+// handleHello - Proses pesan hello dari client; hasilnya pesan yang dikirim balik
+// (hello dengan versi baru, atau error jika versi tidak didukung)
+func (p *wsProtocol) handleHello(r *http.Request, cmd map[string]interface{}, topics []string) interface{} {
+	requested, _ := cmd["protocol"].(float64)
+	if !p.negotiate(int(requested)) {
+		return unsupportedProtocol(r, cmd["protocol"])
+	}
+	return p.hello(r, topics)
+}
+
+// unsupportedProtocol - Pesan error versi protokol yang tidak didukung
+func unsupportedProtocol(r *http.Request, requested interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":      "error",
+		"code":      i18n.UnsupportedProtocol,
+		"error":     i18n.T(r, i18n.UnsupportedProtocol, requested, wsProtocols),
+		"timestamp": time.Now(),
+	}
+}
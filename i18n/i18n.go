@@ -0,0 +1,152 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Bahasa yang didukung katalog
+const (
+	LangID = "id"
+	LangEN = "en"
+)
+
+// defaultLang - Bahasa jika Accept-Language kosong / tidak didukung (API_LANG)
+var defaultLang = LangID
+
+// SetDefaultLang - Set bahasa default; bahasa yang tidak didukung diabaikan
+func SetDefaultLang(lang string) {
+	if _, ok := catalogs[normalize(lang)]; ok {
+		defaultLang = normalize(lang)
+	}
+}
+
+// FromRequest - Pilih bahasa dari header Accept-Language (mendukung q-value)
+func FromRequest(r *http.Request) string {
+	header := r.Header.Get("Accept-Language")
+	if header == "" {
+		return defaultLang
+	}
+
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		q := 1.0
+		for _, f := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(f), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		candidates = append(candidates, candidate{lang: normalize(fields[0]), q: q})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if _, ok := catalogs[c.lang]; ok && c.q > 0 {
+			return c.lang
+		}
+	}
+	return defaultLang
+}
+
+// normalize - "en-US" -> "en"
+func normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// Translate - Pesan untuk code dalam bahasa tertentu; fallback ke bahasa default lalu code itu sendiri
+func Translate(lang, code string, args ...interface{}) string {
+	format, ok := catalogs[lang][code]
+	if !ok {
+		if format, ok = catalogs[defaultLang][code]; !ok {
+			return code
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return strings.TrimSpace(fmt.Sprintf(format, args...))
+}
+
+// T - Pesan untuk code dalam bahasa request
+func T(r *http.Request, code string, args ...interface{}) string {
+	return Translate(FromRequest(r), code, args...)
+}
+
+// Error - Error dengan code katalog, diterjemahkan saat ditulis ke response
+type Error struct {
+	Code string
+	Args []interface{}
+}
+
+// NewError - Error terlokalisasi dengan code dan argumen pesan
+func NewError(code string, args ...interface{}) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+func (e *Error) Error() string {
+	return Translate(defaultLang, e.Code, e.Args...)
+}
+
+// coder - Error yang membawa code machine-readable sendiri (mis. UnsupportedFeatureError)
+type coder interface {
+	ErrorCode() string
+}
+
+func (e *Error) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorCode - Code machine-readable untuk error; fallback untuk error biasa dari service
+func ErrorCode(err error, fallback string) string {
+	var c coder
+	if errors.As(err, &c) {
+		return c.ErrorCode()
+	}
+	return fallback
+}
+
+// ErrorText - Pesan error dalam bahasa request; error biasa dikembalikan apa adanya
+func ErrorText(r *http.Request, err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return T(r, e.Code, e.Args...)
+	}
+	return err.Error()
+}
+
+// StatusCode - Code generik untuk status HTTP error
+func StatusCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return BadRequest
+	case http.StatusUnauthorized:
+		return Unauthorized
+	case http.StatusForbidden:
+		return Forbidden
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusUnprocessableEntity:
+		return Unprocessable
+	case http.StatusServiceUnavailable:
+		return Unavailable
+	default:
+		return InternalError
+	}
+}
@@ -0,0 +1,632 @@
+package i18n
+
+// Code pesan machine-readable (field "code" pada response). Nilai code stabil;
+// teks pesan boleh berubah / diterjemahkan.
+const (
+	// Generik per status HTTP (dipakai untuk error dari service)
+	BadRequest       = "bad_request"
+	NotFound         = "not_found"
+	Conflict         = "conflict"
+	Unprocessable    = "unprocessable"
+	Unavailable      = "unavailable"
+	InternalError    = "internal_error"
+	MethodNotAllowed = "method_not_allowed"
+	Unauthorized     = "unauthorized"
+	Forbidden        = "forbidden"
+
+	// Fitur RouterOS tidak tersedia (services.UnsupportedFeatureError, pesan dari router)
+	UnsupportedFeature = "unsupported_feature"
+
+	// Batas Listen stream bersamaan per router tercapai (services.StreamLimitError, HTTP 429)
+	StreamLimitReached = "stream_limit_reached"
+
+	// IP / MAC / queue reservasi statis sudah dipakai (services.ReservationConflictError, HTTP 409)
+	ReservationConflict = "reservation_conflict"
+
+	// Validasi request
+	InvalidRequestBody = "invalid_request_body"
+	InvalidURL         = "invalid_url"
+	InvalidID          = "invalid_id"
+	MissingParameter   = "missing_parameter"
+	InvalidParameter   = "invalid_parameter"
+	NotANumber         = "not_a_number"
+	InvalidCIDRList    = "invalid_cidr_list"
+	InvalidTimeFormat  = "invalid_time_format"
+	InvalidTimeRange   = "invalid_time_range"
+	InvalidMonth       = "invalid_month"
+	OutOfRange         = "out_of_range"
+	InvalidChoice      = "invalid_choice"
+	InvalidFilter      = "invalid_filter"
+	InvalidCSV         = "invalid_csv"
+	ValidationFailed   = "validation_failed" // detail per field di "errors", pesan rule: rule_<nama>
+
+	// Hasil operasi
+	APIHealthy              = "api_healthy"
+	ConnectionsHealthy      = "connections_healthy"
+	ConnectionsDegraded     = "connections_degraded"
+	WSHealthy               = "ws_healthy"
+	DryRun                  = "dry_run"
+	RouterCreated           = "router_created"
+	RouterUpdated           = "router_updated"
+	RouterDeleted           = "router_deleted"
+	RouterDeleteUnconfirmed = "router_delete_unconfirmed"
+	RouterStatusUpdated     = "router_status_updated"
+	RouterActivated         = "router_activated"
+	RouterDeactivated       = "router_deactivated"
+	RouterSuspended         = "router_suspended"
+	RouterResumed           = "router_resumed"
+	RouterConnected         = "router_connected"
+	RouterDisconnected      = "router_disconnected"
+	ConnectionTimeout       = "connection_timeout"
+	InterfacesFound         = "interfaces_found"
+	InterfaceEnabled        = "interface_enabled"
+	InterfaceDisabled       = "interface_disabled"
+	AddressAdded            = "address_added"
+	AddressRemoved          = "address_removed"
+	QueueAdded              = "queue_added"
+	QueueRemoved            = "queue_removed"
+	RemoteLoggingConfigured = "remote_logging_configured"
+	TrafficFlowConfigured   = "traffic_flow_configured"
+	PlanCreated             = "plan_created"
+	PlanUpdated             = "plan_updated"
+	PlanApplied             = "plan_applied"
+	PlanDeleted             = "plan_deleted"
+	CustomerCreated         = "customer_created"
+	CustomerUpdated         = "customer_updated"
+	CustomerDeleted         = "customer_deleted"
+	CustomerSuspended       = "customer_suspended"
+	CustomerUnsuspended     = "customer_unsuspended"
+	CustomerReserved        = "customer_reserved"
+	SessionsDisconnected    = "sessions_disconnected"
+	FileUploaded            = "file_uploaded"
+	FileRemoved             = "file_removed"
+	GraphingImported        = "graphing_imported"
+	JobStarted              = "job_started"
+	JobCancelled            = "job_cancelled"
+	JobNotRunning           = "job_not_running"
+	ConfirmationRequired    = "confirmation_required"
+	ConfirmationInvalid     = "confirmation_invalid"
+	PathCreated             = "path_created"
+	PathUpdated             = "path_updated"
+	PathDeleted             = "path_deleted"
+	QuotaSaved              = "quota_saved"
+	QuotaDeleted            = "quota_deleted"
+	SamplerStarted          = "sampler_started"
+	SamplerStopped          = "sampler_stopped"
+	SamplerMonitored        = "sampler_monitored"
+	MonitoredIfacesUpdated  = "monitored_interfaces_updated"
+	ReportCreated           = "report_created"
+	ReportDeleted           = "report_deleted"
+	ReportFailed            = "report_failed"
+	ReportNotReady          = "report_not_ready"
+	AlertAcknowledged       = "alert_acknowledged"
+	AlertResolved           = "alert_resolved"
+	AlertNotActionable      = "alert_not_actionable"
+	UserCreated             = "user_created"
+	UserDeleted             = "user_deleted"
+	UserScopesUpdated       = "user_scopes_updated"
+	TokenCreated            = "token_created"
+	TokenRevoked            = "token_revoked"
+	SessionCreated          = "session_created"
+	SessionRefreshed        = "session_refreshed"
+	SessionRevoked          = "session_revoked"
+	SessionsRevoked         = "sessions_revoked"
+	SessionUnavailable      = "session_unavailable"
+	InvalidRefreshToken     = "invalid_refresh_token"
+	TOTPEnrollmentStarted   = "totp_enrollment_started"
+	TOTPEnabled             = "totp_enabled"
+	TOTPDisabled            = "totp_disabled"
+	TOTPAlreadyEnabled      = "totp_already_enabled"
+	TOTPNotEnrolled         = "totp_not_enrolled"
+	TOTPEnrollmentRequired  = "totp_enrollment_required"
+	TOTPSessionRequired     = "totp_session_required"
+	InvalidTOTPCode         = "invalid_totp_code"
+	IPNotAllowed            = "ip_not_allowed"
+	TokenIPNotAllowed       = "token_ip_not_allowed"
+	ResellerCreated         = "reseller_created"
+	ResellerUpdated         = "reseller_updated"
+	ResellerDeleted         = "reseller_deleted"
+	ResellerRoutersUpdated  = "reseller_routers_updated"
+	WireGuardPeerCreated    = "wireguard_peer_created"
+	TunnelRegistered        = "tunnel_registered"
+	TunnelUnregistered      = "tunnel_unregistered"
+	BeaconInstalled         = "beacon_installed"
+	BeaconRemoved           = "beacon_removed"
+	RouterAddressAdded      = "router_address_added"
+	RouterAddressDeleted    = "router_address_deleted"
+	BackupCreated           = "backup_created"
+	BackupDeleted           = "backup_deleted"
+	BackupDisabled          = "backup_disabled"
+	BackupKeyMismatch       = "backup_key_mismatch"
+	BackupAuthRequired      = "backup_auth_required"
+	RouteFailoverCreated    = "route_failover_created"
+	RouteFailoverUpdated    = "route_failover_updated"
+	RouteFailoverDeleted    = "route_failover_deleted"
+	RouteSwitchedOver       = "route_switched_over"
+	RouteRestored           = "route_restored"
+	ScheduleCreated         = "bandwidth_schedule_created"
+	ScheduleUpdated         = "bandwidth_schedule_updated"
+	ScheduleDeleted         = "bandwidth_schedule_deleted"
+	TaskScheduleCreated     = "task_schedule_created"
+	TaskScheduleUpdated     = "task_schedule_updated"
+	TaskScheduleDeleted     = "task_schedule_deleted"
+	TaskScheduleRan         = "task_schedule_ran"
+	TaskScheduleFailed      = "task_schedule_failed"
+	TaskScheduleBusy        = "task_schedule_busy"
+	SubnetCreated           = "subnet_created"
+	SubnetUpdated           = "subnet_updated"
+	SubnetDeleted           = "subnet_deleted"
+	FirewallRuleAdded       = "firewall_rule_added"
+	FirewallRuleRemoved     = "firewall_rule_removed"
+	FirewallRuleEnabled     = "firewall_rule_enabled"
+	FirewallRuleDisabled    = "firewall_rule_disabled"
+	FirewallRuleMoved       = "firewall_rule_moved"
+	DNSStaticAdded          = "dns_static_added"
+	DNSStaticRemoved        = "dns_static_removed"
+	DNSStaticEnabled        = "dns_static_enabled"
+	DNSStaticDisabled       = "dns_static_disabled"
+	WirelessIfaceUpdated    = "wireless_interface_updated"
+	WirelessClientKicked    = "wireless_client_kicked"
+	RouteAdded              = "route_added"
+	RouteRemoved            = "route_removed"
+	RouteEnabled            = "route_enabled"
+	RouteDisabled           = "route_disabled"
+	RouterRebooting         = "router_rebooting"
+	RouterShuttingDown      = "router_shutting_down"
+	ChannelCreated          = "notification_channel_created"
+	ChannelUpdated          = "notification_channel_updated"
+	ChannelDeleted          = "notification_channel_deleted"
+	ChannelTested           = "notification_channel_tested"
+	WebhookCreated          = "webhook_created"
+	WebhookUpdated          = "webhook_updated"
+	WebhookDeleted          = "webhook_deleted"
+	WebhookRedelivered      = "webhook_redelivered"
+	ThreatFeedCreated       = "threat_feed_created"
+	ThreatFeedUpdated       = "threat_feed_updated"
+	ThreatFeedDeleted       = "threat_feed_deleted"
+	ThreatFeedSynced        = "threat_feed_synced"
+
+	// Export/import konfigurasi layer
+	ConfigImported           = "config_imported"
+	ConfigVersionUnsupported = "config_version_unsupported"
+	ConfigPassphraseRequired = "config_passphrase_required"
+	ConfigPassphraseInvalid  = "config_passphrase_invalid"
+
+	// WebSocket traffic monitor (code lifecycle sama dengan type message)
+	MonitoringStarted     = "monitoring_started"
+	MonitoringStartFailed = "monitoring_start_failed"
+	HistoryBackfill       = "history_backfill"
+	HistoryLoadFailed     = "history_load_failed"
+	StreamStarted         = "stream_started"
+	StreamError           = "stream_error"
+	StreamEnded           = "stream_ended"
+	StreamResumed         = "stream_resumed"
+	RouterOffline         = "router_offline"
+	StreamQueued          = "stream_queued"
+	ProtocolNegotiated    = "protocol_negotiated"
+	UnsupportedProtocol   = "unsupported_protocol"
+)
+
+// catalogs - Teks pesan per bahasa; argumen mengikuti format fmt
+var catalogs = map[string]map[string]string{
+	LangEN: {
+		BadRequest:       "Bad request",
+		NotFound:         "Resource not found",
+		Conflict:         "Request conflicts with the current state",
+		Unprocessable:    "Request cannot be processed",
+		Unavailable:      "Service unavailable",
+		InternalError:    "Internal server error",
+		MethodNotAllowed: "Method not allowed",
+		Unauthorized:     "Missing or invalid API token",
+		Forbidden:        "Access to this resource is not allowed",
+
+		InvalidRequestBody: "Invalid request body: %v",
+		InvalidURL:         "Invalid URL",
+		InvalidID:          "Invalid %s ID",
+		MissingParameter:   "Parameter %s is required",
+		InvalidParameter:   "Parameter '%s' is invalid",
+		NotANumber:         "Parameter '%s' must be a number",
+		InvalidCIDRList:    "Parameter '%s' is invalid: %v",
+		InvalidTimeFormat:  "Parameter '%s' must be in RFC3339 format",
+		InvalidTimeRange:   "Parameter 'from' must be before 'to'",
+		InvalidMonth:       "'%s' must be in YYYY-MM format",
+		OutOfRange:         "'%s' must be between %v and %v %s",
+		InvalidChoice:      "'%s' must be one of %s",
+		InvalidFilter:      "Parameter 'filter' is invalid: %v",
+		InvalidCSV:         "Invalid CSV: %v",
+		ValidationFailed:   "Validation failed",
+
+		"rule_required":   "is required",
+		"rule_min":        "must be at least %s",
+		"rule_max":        "must be at most %s",
+		"rule_oneof":      "must be one of %s",
+		"rule_rate_limit": "must match NN[KMG]/NN[KMG]",
+		"rule_ip":         "must be a valid IP address",
+		"rule_cidr":       "must be an address with prefix, e.g. 10.0.0.1/24",
+		"rule_host":       "must be a valid IP address or hostname",
+		"rule_month":      "must be in YYYY-MM format",
+		"rule_clock":      "must be a time in HH:MM format",
+		"rule_url":        "must be an absolute http(s) URL",
+		"rule_email":      "must be a valid email address",
+		"rule_mac":        "must be a MAC address, e.g. AA:BB:CC:DD:EE:FF",
+		"rule_cron":       "must be a 5-field cron expression, e.g. 0 2 * * *",
+		"rule_printable":  "must not contain control characters",
+		"rule_differs":    "must differ from %s",
+		"rule_exists":     "must refer to an existing %s",
+		"rule_unique":     "is already used by %s",
+		"rule_pattern":    "must match the naming policy %s",
+
+		APIHealthy:              "API is running normally",
+		ConnectionsHealthy:      "Router connections are healthy",
+		ConnectionsDegraded:     "Router connections are degraded",
+		WSHealthy:               "WebSocket server is healthy",
+		DryRun:                  "Dry run: no changes were executed",
+		RouterCreated:           "Router created successfully",
+		RouterUpdated:           "Router updated successfully",
+		RouterDeleted:           "Router deleted successfully",
+		RouterDeleteUnconfirmed: "Deleting the router affects the data below; repeat with ?confirm_token= to proceed",
+		RouterStatusUpdated:     "Router status updated successfully",
+		RouterActivated:         "Router activated successfully",
+		RouterDeactivated:       "Router deactivated successfully",
+		RouterSuspended:         "Router suspended successfully",
+		RouterResumed:           "Router resumed successfully",
+		RouterConnected:         "Router connected successfully",
+		RouterDisconnected:      "Router disconnected successfully",
+		ConnectionTimeout:       "Connection timeout after 30 seconds",
+		InterfacesFound:         "Found %d available interfaces",
+		InterfaceEnabled:        "Interface enabled",
+		InterfaceDisabled:       "Interface disabled",
+		AddressAdded:            "Address added successfully",
+		AddressRemoved:          "Address removed successfully",
+		QueueAdded:              "Queue added successfully",
+		QueueRemoved:            "Queue removed successfully",
+		RemoteLoggingConfigured: "Remote logging configured successfully",
+		TrafficFlowConfigured:   "Traffic flow configured successfully",
+		PlanCreated:             "Plan created successfully",
+		PlanUpdated:             "Plan updated and propagated successfully",
+		PlanApplied:             "Plan applied successfully",
+		PlanDeleted:             "Plan deleted successfully",
+		CustomerCreated:         "Customer created successfully",
+		CustomerUpdated:         "Customer updated successfully",
+		CustomerDeleted:         "Customer deleted successfully",
+		CustomerSuspended:       "Customer suspended successfully",
+		CustomerUnsuspended:     "Customer unsuspended successfully",
+		CustomerReserved:        "Static DHCP lease, ARP entry and queue provisioned for customer",
+		SessionsDisconnected:    "Active sessions disconnected successfully",
+		FileUploaded:            "File uploaded successfully",
+		FileRemoved:             "File removed successfully",
+		GraphingImported:        "Graphing rules imported successfully",
+		JobStarted:              "Job started",
+		JobCancelled:            "Job cancelled; running tasks finish, pending tasks are skipped",
+		JobNotRunning:           "Job is already %s",
+		ConfirmationRequired:    "This action is destructive; review the impact below and repeat the request with ?confirm_token= to proceed",
+		ConfirmationInvalid:     "Confirmation token is invalid, expired or issued for a different request; a new token has been issued",
+		PathCreated:             "Path created successfully",
+		PathUpdated:             "Path updated successfully",
+		PathDeleted:             "Path deleted successfully",
+		QuotaSaved:              "Quota saved successfully",
+		QuotaDeleted:            "Quota deleted successfully",
+		SamplerStarted:          "Sampler started",
+		SamplerStopped:          "Sampler stopped",
+		SamplerMonitored:        "Interface %s is a monitored interface of this router; remove it from the monitored interfaces instead",
+		MonitoredIfacesUpdated:  "Monitored interfaces updated",
+		ReportCreated:           "Report created successfully",
+		ReportDeleted:           "Report deleted successfully",
+		ReportFailed:            "Report generation failed",
+		ReportNotReady:          "Report is not available (status %s)",
+		AlertAcknowledged:       "Alert acknowledged",
+		AlertResolved:           "Alert resolved",
+		AlertNotActionable:      "Alert is already %s",
+		UserCreated:             "User created successfully",
+		UserDeleted:             "User deleted successfully",
+		UserScopesUpdated:       "User access scope updated",
+		TokenCreated:            "Token created; store it now, it will not be shown again",
+		TokenRevoked:            "Token revoked",
+		SessionCreated:          "Session created; store the refresh token now, it will not be shown again",
+		SessionRefreshed:        "Session refreshed; the previous refresh token is no longer valid",
+		SessionRevoked:          "Session revoked",
+		SessionsRevoked:         "%d session(s) revoked",
+		SessionUnavailable:      "This requires a user API token (not the bootstrap admin token)",
+		InvalidRefreshToken:     "Refresh token is invalid, expired or already used",
+		TOTPEnrollmentStarted:   "Add the secret to an authenticator app, then confirm with a code via /api/auth/totp/verify",
+		TOTPEnabled:             "Two-factor authentication enabled",
+		TOTPDisabled:            "Two-factor authentication disabled",
+		TOTPAlreadyEnabled:      "Two-factor authentication is already enabled",
+		TOTPNotEnrolled:         "Two-factor authentication is not enrolled",
+		TOTPEnrollmentRequired:  "Your role requires two-factor authentication; enroll via /api/auth/totp/enroll",
+		TOTPSessionRequired:     "Your role requires two-factor authentication; sign in via /api/auth/sessions and use the session token",
+		InvalidTOTPCode:         "Authentication code is invalid or already used",
+		IPNotAllowed:            "Address %s is not allowed to access this API",
+		TokenIPNotAllowed:       "This token cannot be used from your address",
+		ResellerCreated:         "Reseller created successfully",
+		ResellerUpdated:         "Reseller updated successfully",
+		ResellerDeleted:         "Reseller deleted successfully",
+		ResellerRoutersUpdated:  "Reseller routers updated",
+		WireGuardPeerCreated:    "WireGuard peer created; store the private key now, it will not be shown again",
+		TunnelRegistered:        "Tunnel registered; the router address is updated once the tunnel connects",
+		TunnelUnregistered:      "Tunnel registration removed",
+		BeaconInstalled:         "Heartbeat beacon installed; the token is shown only in this response",
+		BeaconRemoved:           "Heartbeat beacon removed",
+		RouterAddressAdded:      "Management address added; used from the next connect",
+		RouterAddressDeleted:    "Management address deleted",
+		BackupCreated:           "Backup stored encrypted",
+		BackupDeleted:           "Backup deleted successfully",
+		BackupDisabled:          "Backups are disabled: BACKUP_ENCRYPTION_KEY is not set",
+		BackupKeyMismatch:       "Backup was encrypted with another key (%s)",
+		BackupAuthRequired:      "Backup download requires an authenticated API token",
+		RouteFailoverCreated:    "Route failover created successfully",
+		RouteFailoverUpdated:    "Route failover updated successfully",
+		RouteFailoverDeleted:    "Route failover deleted successfully",
+		RouteSwitchedOver:       "Traffic switched over to the backup gateway",
+		RouteRestored:           "Traffic restored to the primary gateway",
+		ScheduleCreated:         "Bandwidth schedule created successfully",
+		ScheduleUpdated:         "Bandwidth schedule updated successfully",
+		ScheduleDeleted:         "Bandwidth schedule deleted, normal rate limit restored",
+		TaskScheduleCreated:     "Task schedule created successfully",
+		TaskScheduleUpdated:     "Task schedule updated successfully",
+		TaskScheduleDeleted:     "Task schedule deleted successfully",
+		TaskScheduleRan:         "Task schedule executed",
+		TaskScheduleFailed:      "Task schedule executed but failed: %s",
+		TaskScheduleBusy:        "Task schedule is already running",
+		SubnetCreated:           "Subnet added to the IP plan",
+		SubnetUpdated:           "Subnet updated successfully",
+		SubnetDeleted:           "Subnet removed from the IP plan",
+		FirewallRuleAdded:       "Firewall rule added successfully",
+		FirewallRuleRemoved:     "Firewall rule removed successfully",
+		FirewallRuleEnabled:     "Firewall rule enabled",
+		FirewallRuleDisabled:    "Firewall rule disabled",
+		FirewallRuleMoved:       "Firewall rule moved successfully",
+		DNSStaticAdded:          "DNS static entry added successfully",
+		DNSStaticRemoved:        "DNS static entry removed successfully",
+		DNSStaticEnabled:        "DNS static entry enabled",
+		DNSStaticDisabled:       "DNS static entry disabled",
+		WirelessIfaceUpdated:    "Wireless interface updated",
+		WirelessClientKicked:    "Wireless client disconnected",
+		RouteAdded:              "Route added successfully",
+		RouteRemoved:            "Route removed successfully",
+		RouteEnabled:            "Route enabled",
+		RouteDisabled:           "Route disabled",
+		RouterRebooting:         "Router is rebooting",
+		RouterShuttingDown:      "Router is shutting down",
+		ChannelCreated:          "Notification channel created successfully",
+		ChannelUpdated:          "Notification channel updated successfully",
+		ChannelDeleted:          "Notification channel deleted successfully",
+		ChannelTested:           "Test notification sent",
+		WebhookCreated:          "Webhook created; store the secret now, it will not be shown again",
+		WebhookUpdated:          "Webhook updated successfully",
+		WebhookDeleted:          "Webhook deleted successfully",
+		WebhookRedelivered:      "Delivery scheduled for redelivery",
+		ThreatFeedCreated:       "Threat feed created successfully",
+		ThreatFeedUpdated:       "Threat feed updated successfully",
+		ThreatFeedDeleted:       "Threat feed deleted and its entries removed from routers",
+		ThreatFeedSynced:        "Threat feed synced",
+
+		ConfigImported:           "Layer configuration imported",
+		ConfigVersionUnsupported: "Configuration bundle version %v is not supported (expected %v)",
+		ConfigPassphraseRequired: "Bundle contains encrypted secrets; passphrase is required",
+		ConfigPassphraseInvalid:  "Wrong passphrase or corrupted secrets for router %s",
+
+		MonitoringStarted:     "Monitoring started for router %d: %s (%d interface(s))",
+		MonitoringStartFailed: "Failed to start %d interface(s): %s",
+		HistoryBackfill:       "%d samples since %s",
+		HistoryLoadFailed:     "Failed to load history: %v",
+		StreamStarted:         "Interface monitoring started",
+		StreamError:           "Interface monitoring failed",
+		StreamEnded:           "Interface monitoring stopped",
+		StreamResumed:         "Monitoring resumed after router reconnect",
+		RouterOffline:         "Router offline, waiting for reconnect",
+		StreamQueued:          "Router stream limit reached, waiting for a free slot",
+		ProtocolNegotiated:    "Using message protocol version %d",
+		UnsupportedProtocol:   "Protocol version %v is not supported (supported: %v)",
+	},
+	LangID: {
+		BadRequest:       "Request tidak valid",
+		NotFound:         "Data tidak ditemukan",
+		Conflict:         "Request konflik dengan kondisi saat ini",
+		Unprocessable:    "Request tidak dapat diproses",
+		Unavailable:      "Layanan tidak tersedia",
+		InternalError:    "Terjadi kesalahan internal",
+		MethodNotAllowed: "Method tidak diizinkan",
+		Unauthorized:     "Token API tidak ada atau tidak valid",
+		Forbidden:        "Tidak memiliki akses ke resource ini",
+
+		InvalidRequestBody: "Body request tidak valid: %v",
+		InvalidURL:         "URL tidak valid",
+		InvalidID:          "ID %s tidak valid",
+		MissingParameter:   "parameter %s diperlukan",
+		InvalidParameter:   "parameter '%s' harus valid",
+		NotANumber:         "parameter '%s' harus angka",
+		InvalidCIDRList:    "parameter '%s' tidak valid: %v",
+		InvalidTimeFormat:  "parameter '%s' harus format RFC3339",
+		InvalidTimeRange:   "parameter 'from' harus sebelum 'to'",
+		InvalidMonth:       "'%s' harus format YYYY-MM",
+		OutOfRange:         "'%s' harus antara %v dan %v %s",
+		InvalidChoice:      "'%s' harus salah satu dari %s",
+		InvalidFilter:      "parameter 'filter' tidak valid: %v",
+		InvalidCSV:         "CSV tidak valid: %v",
+		ValidationFailed:   "Validasi gagal",
+
+		"rule_required":   "wajib diisi",
+		"rule_min":        "minimal %s",
+		"rule_max":        "maksimal %s",
+		"rule_oneof":      "harus salah satu dari %s",
+		"rule_rate_limit": "harus format NN[KMG]/NN[KMG]",
+		"rule_ip":         "harus alamat IP valid",
+		"rule_cidr":       "harus alamat dengan prefix, mis. 10.0.0.1/24",
+		"rule_host":       "harus alamat IP atau hostname valid",
+		"rule_month":      "harus format YYYY-MM",
+		"rule_clock":      "harus jam format HH:MM",
+		"rule_url":        "harus URL http(s) lengkap",
+		"rule_email":      "harus alamat email valid",
+		"rule_mac":        "harus MAC address, mis. AA:BB:CC:DD:EE:FF",
+		"rule_cron":       "harus ekspresi cron 5 field, mis. 0 2 * * *",
+		"rule_printable":  "tidak boleh mengandung karakter kontrol",
+		"rule_differs":    "harus berbeda dari %s",
+		"rule_exists":     "harus merujuk ke %s yang ada",
+		"rule_unique":     "sudah dipakai oleh %s",
+		"rule_pattern":    "harus sesuai naming policy %s",
+
+		APIHealthy:              "API berjalan normal",
+		ConnectionsHealthy:      "Koneksi router sehat",
+		ConnectionsDegraded:     "Koneksi router terganggu",
+		WSHealthy:               "WebSocket server berjalan normal",
+		DryRun:                  "Dry run: tidak ada perubahan yang dieksekusi",
+		RouterCreated:           "Router berhasil ditambahkan",
+		RouterUpdated:           "Router berhasil diupdate",
+		RouterDeleted:           "Router berhasil dihapus",
+		RouterDeleteUnconfirmed: "Penghapusan router berdampak pada data berikut; ulangi dengan ?confirm_token= untuk melanjutkan",
+		RouterStatusUpdated:     "Status router berhasil diupdate",
+		RouterActivated:         "Router berhasil diaktifkan",
+		RouterDeactivated:       "Router berhasil dinonaktifkan",
+		RouterSuspended:         "Router berhasil disuspend",
+		RouterResumed:           "Router berhasil diresume",
+		RouterConnected:         "Router berhasil terkoneksi",
+		RouterDisconnected:      "Router berhasil didisconnect",
+		ConnectionTimeout:       "Koneksi timeout setelah 30 detik",
+		InterfacesFound:         "Ditemukan %d interface",
+		InterfaceEnabled:        "Interface diaktifkan",
+		InterfaceDisabled:       "Interface dinonaktifkan",
+		AddressAdded:            "Address berhasil ditambahkan",
+		AddressRemoved:          "Address berhasil dihapus",
+		QueueAdded:              "Queue berhasil ditambahkan",
+		QueueRemoved:            "Queue berhasil dihapus",
+		RemoteLoggingConfigured: "Remote logging berhasil dikonfigurasi",
+		TrafficFlowConfigured:   "Traffic flow berhasil dikonfigurasi",
+		PlanCreated:             "Plan berhasil ditambahkan",
+		PlanUpdated:             "Plan berhasil diupdate dan dipropagasi",
+		PlanApplied:             "Plan berhasil diterapkan",
+		PlanDeleted:             "Plan berhasil dihapus",
+		CustomerCreated:         "Customer berhasil ditambahkan",
+		CustomerUpdated:         "Customer berhasil diupdate",
+		CustomerDeleted:         "Customer berhasil dihapus",
+		CustomerSuspended:       "Customer berhasil disuspend",
+		CustomerUnsuspended:     "Customer berhasil diaktifkan kembali",
+		CustomerReserved:        "Static lease DHCP, ARP dan queue customer berhasil dibuat",
+		SessionsDisconnected:    "Sesi aktif berhasil diputus",
+		FileUploaded:            "File berhasil diupload",
+		FileRemoved:             "File berhasil dihapus",
+		GraphingImported:        "Rule graphing berhasil diimport",
+		JobStarted:              "Job dimulai",
+		JobCancelled:            "Job dibatalkan; task yang berjalan diselesaikan, task pending dilewati",
+		JobNotRunning:           "Job sudah berstatus %s",
+		ConfirmationRequired:    "Aksi ini destruktif; periksa dampak berikut lalu ulangi request dengan ?confirm_token= untuk melanjutkan",
+		ConfirmationInvalid:     "Token konfirmasi tidak valid, kedaluwarsa atau untuk request lain; token baru sudah diterbitkan",
+		PathCreated:             "Path berhasil ditambahkan",
+		PathUpdated:             "Path berhasil diupdate",
+		PathDeleted:             "Path berhasil dihapus",
+		QuotaSaved:              "Kuota berhasil disimpan",
+		QuotaDeleted:            "Kuota berhasil dihapus",
+		SamplerStarted:          "Sampler dimulai",
+		SamplerStopped:          "Sampler dihentikan",
+		SamplerMonitored:        "Interface %s adalah monitored interface router ini; keluarkan dari daftar monitored interface",
+		MonitoredIfacesUpdated:  "Monitored interface diperbarui",
+		ReportCreated:           "Laporan berhasil dibuat",
+		ReportDeleted:           "Laporan berhasil dihapus",
+		ReportFailed:            "Laporan gagal dibuat",
+		ReportNotReady:          "Laporan belum tersedia (status %s)",
+		AlertAcknowledged:       "Alert sudah di-acknowledge",
+		AlertResolved:           "Alert sudah di-resolve",
+		AlertNotActionable:      "Alert sudah berstatus %s",
+		UserCreated:             "User berhasil dibuat",
+		UserDeleted:             "User berhasil dihapus",
+		UserScopesUpdated:       "Scope akses user diperbarui",
+		TokenCreated:            "Token dibuat; simpan sekarang, token tidak akan ditampilkan lagi",
+		TokenRevoked:            "Token dicabut",
+		SessionCreated:          "Sesi dibuat; simpan refresh token sekarang, token tidak akan ditampilkan lagi",
+		SessionRefreshed:        "Sesi diperbarui; refresh token sebelumnya tidak berlaku lagi",
+		SessionRevoked:          "Sesi dicabut",
+		SessionsRevoked:         "%d sesi dicabut",
+		SessionUnavailable:      "Memerlukan API token milik user (bukan token admin bootstrap)",
+		InvalidRefreshToken:     "Refresh token tidak valid, kedaluwarsa atau sudah dipakai",
+		TOTPEnrollmentStarted:   "Tambahkan secret ke aplikasi authenticator, lalu konfirmasi dengan kode via /api/auth/totp/verify",
+		TOTPEnabled:             "Autentikasi dua faktor diaktifkan",
+		TOTPDisabled:            "Autentikasi dua faktor dinonaktifkan",
+		TOTPAlreadyEnabled:      "Autentikasi dua faktor sudah aktif",
+		TOTPNotEnrolled:         "Autentikasi dua faktor belum di-enroll",
+		TOTPEnrollmentRequired:  "Role Anda wajib memakai autentikasi dua faktor; enroll via /api/auth/totp/enroll",
+		TOTPSessionRequired:     "Role Anda wajib memakai autentikasi dua faktor; login via /api/auth/sessions dan gunakan token session",
+		InvalidTOTPCode:         "Kode autentikasi tidak valid atau sudah dipakai",
+		IPNotAllowed:            "Alamat %s tidak diizinkan mengakses API ini",
+		TokenIPNotAllowed:       "Token ini tidak bisa dipakai dari alamat Anda",
+		ResellerCreated:         "Reseller berhasil dibuat",
+		ResellerUpdated:         "Reseller berhasil diupdate",
+		ResellerDeleted:         "Reseller berhasil dihapus",
+		ResellerRoutersUpdated:  "Router reseller diperbarui",
+		WireGuardPeerCreated:    "Peer WireGuard dibuat; simpan private key sekarang, key tidak akan ditampilkan lagi",
+		TunnelRegistered:        "Tunnel terdaftar; alamat router diperbarui begitu tunnel terhubung",
+		TunnelUnregistered:      "Registrasi tunnel dihapus",
+		BeaconInstalled:         "Beacon heartbeat terpasang; token hanya ditampilkan di response ini",
+		BeaconRemoved:           "Beacon heartbeat dihapus",
+		RouterAddressAdded:      "Alamat management ditambahkan; dipakai mulai connect berikutnya",
+		RouterAddressDeleted:    "Alamat management dihapus",
+		BackupCreated:           "Backup disimpan terenkripsi",
+		BackupDeleted:           "Backup berhasil dihapus",
+		BackupDisabled:          "Backup nonaktif: BACKUP_ENCRYPTION_KEY belum diset",
+		BackupKeyMismatch:       "Backup dienkripsi dengan kunci lain (%s)",
+		BackupAuthRequired:      "Download backup wajib memakai token API yang terautentikasi",
+		RouteFailoverCreated:    "Route failover berhasil ditambahkan",
+		RouteFailoverUpdated:    "Route failover berhasil diupdate",
+		RouteFailoverDeleted:    "Route failover berhasil dihapus",
+		RouteSwitchedOver:       "Trafik dipindahkan ke gateway backup",
+		RouteRestored:           "Trafik dikembalikan ke gateway primary",
+		ScheduleCreated:         "Jadwal bandwidth berhasil ditambahkan",
+		ScheduleUpdated:         "Jadwal bandwidth berhasil diupdate",
+		ScheduleDeleted:         "Jadwal bandwidth dihapus, rate limit normal dikembalikan",
+		TaskScheduleCreated:     "Jadwal task berhasil ditambahkan",
+		TaskScheduleUpdated:     "Jadwal task berhasil diupdate",
+		TaskScheduleDeleted:     "Jadwal task berhasil dihapus",
+		TaskScheduleRan:         "Jadwal task dijalankan",
+		TaskScheduleFailed:      "Jadwal task dijalankan tetapi gagal: %s",
+		TaskScheduleBusy:        "Jadwal task sedang berjalan",
+		SubnetCreated:           "Subnet ditambahkan ke rencana IP",
+		SubnetUpdated:           "Subnet berhasil diupdate",
+		SubnetDeleted:           "Subnet dihapus dari rencana IP",
+		FirewallRuleAdded:       "Rule firewall berhasil ditambahkan",
+		FirewallRuleRemoved:     "Rule firewall berhasil dihapus",
+		FirewallRuleEnabled:     "Rule firewall diaktifkan",
+		FirewallRuleDisabled:    "Rule firewall dinonaktifkan",
+		FirewallRuleMoved:       "Rule firewall berhasil dipindah",
+		DNSStaticAdded:          "Entry DNS static berhasil ditambahkan",
+		DNSStaticRemoved:        "Entry DNS static berhasil dihapus",
+		DNSStaticEnabled:        "Entry DNS static diaktifkan",
+		DNSStaticDisabled:       "Entry DNS static dinonaktifkan",
+		WirelessIfaceUpdated:    "Interface wireless berhasil diperbarui",
+		WirelessClientKicked:    "Client wireless berhasil diputus",
+		RouteAdded:              "Route berhasil ditambahkan",
+		RouteRemoved:            "Route berhasil dihapus",
+		RouteEnabled:            "Route diaktifkan",
+		RouteDisabled:           "Route dinonaktifkan",
+		RouterRebooting:         "Router sedang reboot",
+		RouterShuttingDown:      "Router sedang dimatikan",
+		ChannelCreated:          "Channel notifikasi berhasil ditambahkan",
+		ChannelUpdated:          "Channel notifikasi berhasil diupdate",
+		ChannelDeleted:          "Channel notifikasi berhasil dihapus",
+		ChannelTested:           "Notifikasi tes terkirim",
+		WebhookCreated:          "Webhook ditambahkan; simpan secret sekarang, secret tidak akan ditampilkan lagi",
+		WebhookUpdated:          "Webhook berhasil diupdate",
+		WebhookDeleted:          "Webhook berhasil dihapus",
+		WebhookRedelivered:      "Delivery dijadwalkan untuk dikirim ulang",
+		ThreatFeedCreated:       "Threat feed berhasil dibuat",
+		ThreatFeedUpdated:       "Threat feed berhasil diupdate",
+		ThreatFeedDeleted:       "Threat feed dihapus beserta entry-nya di router",
+		ThreatFeedSynced:        "Threat feed berhasil disinkronkan",
+
+		ConfigImported:           "Konfigurasi layer berhasil diimport",
+		ConfigVersionUnsupported: "Versi bundle konfigurasi %v tidak didukung (seharusnya %v)",
+		ConfigPassphraseRequired: "Bundle berisi kredensial terenkripsi; passphrase wajib diisi",
+		ConfigPassphraseInvalid:  "Passphrase salah atau kredensial router %s rusak",
+
+		MonitoringStarted:     "Monitoring dimulai untuk router %d: %s (%d interface)",
+		MonitoringStartFailed: "Gagal memulai %d interface: %s",
+		HistoryBackfill:       "%d sample sejak %s",
+		HistoryLoadFailed:     "gagal memuat history: %v",
+		StreamStarted:         "Monitoring interface dimulai",
+		StreamError:           "Monitoring interface gagal",
+		StreamEnded:           "Monitoring interface berhenti",
+		StreamResumed:         "Monitoring dilanjutkan setelah router reconnect",
+		RouterOffline:         "Router offline, menunggu reconnect",
+		StreamQueued:          "Batas stream router tercapai, menunggu slot kosong",
+		ProtocolNegotiated:    "Memakai protokol pesan versi %d",
+		UnsupportedProtocol:   "Protokol versi %v tidak didukung (didukung: %v)",
+	},
+}
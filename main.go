@@ -3,10 +3,16 @@ package main
 import (
 	"log"
 	"net/http"
+	"time"
 
+	"Mikrotik-Layer/app"
 	"Mikrotik-Layer/config"
 	"Mikrotik-Layer/database"
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/repository"
 	"Mikrotik-Layer/routes"
+	"Mikrotik-Layer/services"
+	"Mikrotik-Layer/tracing"
 )
 
 func main() {
@@ -15,6 +21,23 @@ func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 	log.Println("✓ Configuration loaded")
+	i18n.SetDefaultLang(cfg.APILang)
+
+	// Panic yang di-recover (handler & goroutine service) dilaporkan ke Sentry (opsional)
+	if cfg.SentryDSN != "" {
+		reporter, err := services.NewSentryReporter(cfg.SentryDSN, cfg.SentryEnvironment)
+		if err != nil {
+			log.Fatal("❌ Invalid SENTRY_DSN:", err)
+		}
+		services.SetPanicReporter(reporter)
+		log.Println("✓ Sentry panic reporting enabled")
+	}
+
+	// Trace request HTTP, command RouterOS, tunggu lock router dan query DB (opsional)
+	if cfg.OTelEndpoint != "" {
+		tracing.Init(cfg.OTelEndpoint, cfg.OTelServiceName, cfg.OTelSampleRatio)
+		log.Printf("✓ OpenTelemetry tracing enabled (%s)", cfg.OTelEndpoint)
+	}
 
 	// Initialize database
 	db, err := database.NewDatabase(cfg.DatabaseDSN)
@@ -24,11 +47,189 @@ func main() {
 	defer db.Close()
 	log.Println("✓ Database connected")
 
+	// init.sql hanya jalan saat volume baru; schema lama disusulkan di sini
+	if err := db.Migrate(); err != nil {
+		log.Fatal("❌ Failed to migrate database schema:", err)
+	}
+
+	// Job yang terputus karena restart tidak dilanjutkan
+	if n, err := repository.NewJobRepository(db.DB).FailInterrupted(); err != nil {
+		log.Println("⚠ Failed to close interrupted jobs:", err)
+	} else if n > 0 {
+		log.Printf("⚠ %d interrupted jobs marked failed", n)
+	}
+
+	// Leader election: worker background hanya berjalan di satu instance
+	go services.Supervise("leader-election", services.NewLeaderElector(cfg.LeaderLeaseTTL, repository.NewLeaderRepository(db.DB)).Run)
+
+	// Dependensi bersama REST, WebSocket dan worker background
+	a := app.New(cfg, db)
+
+	// Naming policy nama queue / PPP secret / comment firewall yang dibuat lewat layer
+	a.Naming, err = services.NewNamingPolicy(map[string]string{
+		services.NamingQueue:           cfg.NamingPolicyQueue,
+		services.NamingPPPSecret:       cfg.NamingPolicyPPPSecret,
+		services.NamingFirewallComment: cfg.NamingPolicyFirewallComment,
+	})
+	if err != nil {
+		log.Fatal("❌ Invalid NAMING_POLICY_*:", err)
+	}
+
+	// Jadwal task cron per router (backup malam, reboot mingguan, sync queue harian). Satu scheduler
+	// untuk run-now (handler) dan Run (worker) supaya run ganda jadwal yang sama tetap dicegah
+	taskLocation := time.Local
+	if cfg.TaskScheduleTimezone != "" {
+		if taskLocation, err = time.LoadLocation(cfg.TaskScheduleTimezone); err != nil {
+			log.Fatal("❌ Invalid TASK_SCHEDULE_TIMEZONE:", err)
+		}
+	}
+	customers := repository.NewCustomerRepository(db.DB)
+	plans := repository.NewPlanRepository(db.DB)
+	planService := services.NewPlanService(a.Mikrotik, customers, a.Usage, a.AuditLogger(), a.Webhooks)
+	backups := services.NewBackupService(cfg.BackupEncryptionKey, a.Mikrotik,
+		services.NewFileService(a.Mikrotik, cfg.FileFTPPort), repository.NewBackupRepository(db.DB), a.AuditLogger())
+	a.Tasks = services.NewTaskScheduler(cfg.TaskScheduleInterval, taskLocation, a.Mikrotik,
+		repository.NewTaskScheduleRepository(db.DB), backups, plans, customers, planService, a.AuditLogger())
+
 	// Setup REST API router (port 8080)
-	restRouter := routes.SetupRoutes(db)
+	restRouter := routes.SetupRoutes(a)
 
 	// Setup WebSocket router (port 8081)
-	wsRouter := routes.SetupWebSocketRoutes(db)
+	wsRouter := routes.SetupWebSocketRoutes(a)
+
+	// Embedded syslog receiver (opsional)
+	if cfg.SyslogAddr != "" {
+		receiver := services.NewSyslogReceiver(cfg.SyslogAddr, a.Routers, a.Events, a.Hub)
+		go func() {
+			if err := receiver.Run(); err != nil {
+				log.Println("❌ Syslog receiver error:", err)
+			}
+		}()
+	}
+
+	// Embedded NetFlow v9/IPFIX collector (opsional)
+	if cfg.NetFlowAddr != "" {
+		collector := services.NewFlowCollector(cfg.NetFlowAddr, a.Routers, repository.NewFlowRepository(db.DB), a.Retention())
+		go func() {
+			if err := collector.Run(); err != nil {
+				log.Println("❌ NetFlow collector error:", err)
+			}
+		}()
+	}
+
+	// Deteksi anomali traffic berbasis baseline history
+	detector := services.NewAnomalyDetector(services.AnomalyConfig{
+		Interval:     cfg.AnomalyInterval,
+		Factor:       cfg.AnomalyFactor,
+		Alpha:        cfg.AnomalyAlpha,
+		BaselineDays: cfg.AnomalyBaselineDays,
+		MinDays:      cfg.AnomalyMinDays,
+	}, a.Traffic, a.EventRecorder())
+	go services.Supervise("anomaly-detector", detector.Run)
+
+	// Top-talkers dari torch pada interface WAN
+	topTalkers := services.NewTopTalkersCollector(services.TopTalkersConfig{
+		Interval:  cfg.TopTalkersInterval,
+		Duration:  cfg.TopTalkersDuration,
+		Limit:     cfg.TopTalkersLimit,
+		Retention: a.Retention(),
+	}, a.Mikrotik, a.Routers, repository.NewTopTalkersRepository(db.DB))
+	go services.Supervise("top-talkers", topTalkers.Run)
+
+	// Pemakaian per queue (harian/bulanan) + alert & enforcement kuota
+	enforcer := services.NewQuotaEnforcer(a.Mikrotik, a.Usage, a.EventRecorder(), a.AuditLogger(), a.Webhooks)
+	usageSampler := services.NewQueueUsageSampler(cfg.QueueUsageInterval, a.Retention(), a.Mikrotik, a.Usage, enforcer)
+	go services.Supervise("queue-usage", usageSampler.Run)
+
+	// Pengiriman webhook provisioning (signature HMAC + retry backoff)
+	go services.Supervise("webhooks", a.Webhooks.Run)
+
+	// Latency mesh antar router
+	mesh := services.NewMeshMonitor(cfg.MeshInterval, a.Retention(), a.Mikrotik, a.Routers,
+		repository.NewMeshRepository(db.DB), a.EventRecorder())
+	go services.Supervise("mesh", mesh.Run)
+
+	// Alert laju error interface (link kotor yang tidak terlihat dari bps)
+	ifaceErrors := services.NewInterfaceErrorMonitor(services.InterfaceErrorConfig{
+		Interval: cfg.InterfaceErrorInterval,
+		Rate:     cfg.InterfaceErrorRate,
+	}, a.Mikrotik, a.EventRecorder())
+	go services.Supervise("interface-errors", ifaceErrors.Run)
+
+	// History kualitas link wireless (backhaul PtP)
+	wirelessSampler := services.NewWirelessSampler(cfg.WirelessSampleInterval, a.Retention(), a.Mikrotik,
+		repository.NewWirelessRepository(db.DB))
+	go services.Supervise("wireless-sampler", wirelessSampler.Run)
+
+	// Event neighbor OSPF / peer BGP putus & pulih
+	routingMonitor := services.NewRoutingMonitor(cfg.RoutingInterval, a.Mikrotik, a.EventRecorder())
+	go services.Supervise("routing-monitor", routingMonitor.Run)
+
+	// Watchdog failover route statik
+	failoverWatchdog := services.NewFailoverWatchdog(cfg.RouteFailoverInterval, a.Mikrotik,
+		repository.NewRouteFailoverRepository(db.DB), a.EventRecorder(),
+		services.NewNotifier(cfg.NotifyWebhookURL), a.AuditLogger())
+	go services.Supervise("route-failover", failoverWatchdog.Run)
+
+	// Peta topologi jaringan + event link muncul / hilang
+	topologyBuilder := services.NewTopologyBuilder(cfg.TopologyInterval, a.Mikrotik, a.Routers,
+		repository.NewTopologyRepository(db.DB), a.EventRecorder())
+	go services.Supervise("topology", topologyBuilder.Run)
+
+	// Jadwal bandwidth (limit jam sibuk / malam) per plan atau queue
+	scheduleLocation := time.Local
+	if cfg.BandwidthScheduleTimezone != "" {
+		if scheduleLocation, err = time.LoadLocation(cfg.BandwidthScheduleTimezone); err != nil {
+			log.Fatal("❌ Invalid BANDWIDTH_SCHEDULE_TIMEZONE:", err)
+		}
+	}
+	bandwidthScheduler := services.NewBandwidthScheduler(cfg.BandwidthScheduleInterval, scheduleLocation, a.Mikrotik,
+		repository.NewBandwidthScheduleRepository(db.DB), plans, customers, planService, a.AuditLogger())
+	go services.Supervise("bandwidth-scheduler", bandwidthScheduler.Run)
+
+	// Jadwal task cron per router (scheduler dibuat sebelum route, lihat a.Tasks)
+	go services.Supervise("task-scheduler", a.Tasks.Run)
+
+	// Address-list blokir dari threat feed eksternal
+	feedSyncer := services.NewThreatFeedSyncer(cfg.ThreatFeedInterval, cfg.ThreatFeedMaxEntries, a.Mikrotik,
+		repository.NewThreatFeedRepository(db.DB), a.AuditLogger())
+	go services.Supervise("threat-feeds", feedSyncer.Run)
+
+	// Klasifikasi outage dari beacon heartbeat: API mati tapi router hidup vs router mati
+	go services.Supervise("beacons", a.Beacons.Run)
+
+	// Router di belakang CGNAT: alamat tunnel di concentrator dipakai sebagai hostname
+	tunnelWatcher := services.NewTunnelWatcher(cfg.TunnelWatchInterval, a.Mikrotik, a.Routers, a.EventRecorder())
+	go services.Supervise("tunnel-watcher", tunnelWatcher.Run)
+
+	// Riwayat sesi PPP (start/stop, IP, byte) dari polling /ppp/active
+	pppSessions := services.NewPPPSessionTracker(cfg.PPPSessionInterval, time.Duration(cfg.PPPSessionRetentionDays)*24*time.Hour,
+		a.Mikrotik, repository.NewPPPSessionRepository(db.DB))
+	go services.Supervise("ppp-sessions", pppSessions.Run)
+
+	// Riwayat lease DHCP untuk lookup IP -> customer historis
+	leaseHistory := services.NewLeaseHistoryTracker(cfg.LeaseHistoryInterval, time.Duration(cfg.LeaseHistoryRetentionDays)*24*time.Hour,
+		a.Mikrotik, repository.NewLeaseHistoryRepository(db.DB))
+	go services.Supervise("lease-history", leaseHistory.Run)
+
+	// Availability router + laporan PDF bulanan
+	availabilityTracker := services.NewAvailabilityTracker(cfg.AvailabilityInterval, a.Mikrotik, a.Routers,
+		repository.NewAvailabilityRepository(db.DB))
+	go services.Supervise("availability", availabilityTracker.Run)
+
+	if cfg.ReportAutoGenerate {
+		reportGen := services.NewReportGenerator(a.Routers, repository.NewCustomerRepository(db.DB),
+			a.Usage, a.Traffic, repository.NewAvailabilityRepository(db.DB),
+			repository.NewReportRepository(db.DB))
+		reportScheduler := services.NewReportScheduler(reportGen, a.Routers,
+			repository.NewCustomerRepository(db.DB), repository.NewReportRepository(db.DB))
+		go services.Supervise("report-scheduler", reportScheduler.Run)
+	}
+
+	// Refresh database vendor MAC (OUI)
+	if cfg.OUISource != "" {
+		go services.Supervise("oui-refresh", func() { services.GetOUIResolver().Run(cfg.OUISource, cfg.OUIRefreshInterval) })
+	}
 
 	// Run REST API server
 	go func() {
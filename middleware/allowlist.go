@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+)
+
+type clientIPKey struct{}
+
+// AllowList - Tolak (403) request dari IP di luar allowed; allowed kosong = semua IP boleh.
+// Jika peer termasuk trustedProxies, IP klien diambil dari X-Forwarded-For (entry paling
+// kanan yang bukan proxy tepercaya). IP klien disimpan di context untuk ClientIP.
+func AllowList(allowed, trustedProxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolveClientIP(r, trustedProxies)
+			if len(allowed) > 0 && !IPInNetworks(ip, allowed) {
+				log.Printf("[ALLOWLIST] Rejected %s %s from %s", r.Method, r.URL.Path, ip)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(models.ApiResponse{
+					Success: false,
+					Code:    i18n.IPNotAllowed,
+					Error:   i18n.T(r, i18n.IPNotAllowed, ip),
+				})
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, ip)))
+		})
+	}
+}
+
+// ClientIP - IP klien hasil AllowList; tanpa AllowList dari RemoteAddr
+func ClientIP(r *http.Request) net.IP {
+	if ip, ok := r.Context().Value(clientIPKey{}).(net.IP); ok {
+		return ip
+	}
+	return resolveClientIP(r, nil)
+}
+
+// IPInNetworks - IP termasuk salah satu network
+func IPInNetworks(ip net.IP, networks []*net.IPNet) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveClientIP(r *http.Request, trustedProxies []*net.IPNet) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || len(trustedProxies) == 0 || !IPInNetworks(ip, trustedProxies) {
+		return ip
+	}
+
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		ip = hop
+		if !IPInNetworks(hop, trustedProxies) {
+			break
+		}
+	}
+	return ip
+}
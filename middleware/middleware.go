@@ -1,16 +1,40 @@
 package middleware
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"strings"
 	"time"
+
+	"Mikrotik-Layer/i18n"
 )
 
 func JSONMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Language", i18n.FromRequest(r))
+		w.Header().Add("Vary", "Accept-Language")
 		start := time.Now()
 		log.Printf("[%s] %s - %s", r.Method, r.RequestURI, time.Since(start))
 		next(w, r)
 	}
+}
+
+type apiVersionKey struct{}
+
+// APIv1 - Layani /api/v1/... dengan handler /api/... yang sama; handler memakai IsV1
+// untuk memilih DTO snake_case (package dto) alih-alih model internal
+func APIv1(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r2 := r.Clone(context.WithValue(r.Context(), apiVersionKey{}, 1))
+		r2.URL.Path = "/api/" + strings.TrimPrefix(r.URL.Path, "/api/v1/")
+		r2.URL.RawPath = ""
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// IsV1 - Request masuk lewat /api/v1
+func IsV1(r *http.Request) bool {
+	return r.Context().Value(apiVersionKey{}) == 1
 }
\ No newline at end of file
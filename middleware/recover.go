@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"runtime/debug"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/services"
+)
+
+// Recover - Panic di handler dicatat (dengan stack trace, dan dilaporkan ke Sentry jika
+// dikonfigurasi) lalu dijawab 500, bukan menghentikan proses. http.ErrAbortHandler diteruskan.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &recoverWriter{ResponseWriter: w}
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(v)
+			}
+
+			services.ReportPanic("http", v, debug.Stack(), map[string]string{
+				"method": r.Method,
+				"path":   r.URL.Path,
+			})
+			if rw.wroteHeader || rw.hijacked {
+				return
+			}
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(rw).Encode(models.ApiResponse{
+				Success: false,
+				Code:    i18n.InternalError,
+				Error:   i18n.T(r, i18n.InternalError),
+			})
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// recoverWriter - Catat apakah header sudah terkirim; Hijack/Flush diteruskan untuk WebSocket
+type recoverWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	hijacked    bool
+}
+
+func (w *recoverWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recoverWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recoverWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+func (w *recoverWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	w.hijacked = true
+	return h.Hijack()
+}
+
+func (w *recoverWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
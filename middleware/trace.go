@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+
+	"Mikrotik-Layer/tracing"
+)
+
+// Trace - Span server per request HTTP (melanjutkan traceparent W3C dari klien jika ada).
+// Trace ID dikembalikan di header X-Trace-Id untuk korelasi laporan user dengan trace.
+func Trace(next http.Handler) http.Handler {
+	if !tracing.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := tracing.StartRemote("HTTP "+r.Method+" "+r.URL.Path, tracing.KindServer, r.Header.Get("traceparent"))
+		if span == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		defer span.End()
+
+		span.SetAttr("http.method", r.Method)
+		span.SetAttr("http.target", r.URL.Path)
+		w.Header().Set("X-Trace-Id", span.TraceID())
+
+		tw := &traceWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(tw, r)
+
+		span.SetAttr("http.status_code", tw.status)
+		if tw.status >= http.StatusInternalServerError {
+			span.SetError(errorStatus(tw.status))
+		}
+	})
+}
+
+type errorStatus int
+
+func (s errorStatus) Error() string {
+	return http.StatusText(int(s))
+}
+
+// traceWriter - Catat status code response; Hijack/Flush diteruskan untuk WebSocket
+type traceWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *traceWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *traceWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+func (w *traceWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	w.status = http.StatusSwitchingProtocols
+	w.wroteHeader = true
+	return h.Hijack()
+}
+
+func (w *traceWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
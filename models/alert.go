@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status alert
+const (
+	AlertStatusOpen         = "open"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusResolved     = "resolved"
+)
+
+// AlertSeverities - Severity event yang menjadi alert, urut dari paling parah
+var AlertSeverities = []string{"critical", "error", "warning"}
+
+// Alert - Alarm aktif dari event (satu alert per router + type selama belum resolved)
+type Alert struct {
+	ID             int64           `json:"id" db:"id"`
+	RouterID       *int            `json:"router_id,omitempty" db:"router_id"`
+	RouterName     *string         `json:"router_name,omitempty" db:"-"`
+	Type           string          `json:"type" db:"type"`
+	Severity       string          `json:"severity" db:"severity"`
+	Message        string          `json:"message" db:"message"` // pesan event terakhir
+	Data           json.RawMessage `json:"data,omitempty" db:"data"`
+	Status         string          `json:"status" db:"status"` // open, acknowledged, resolved
+	Occurrences    int             `json:"occurrences" db:"occurrences"`
+	FirstSeenAt    time.Time       `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt     time.Time       `json:"last_seen_at" db:"last_seen_at"`
+	AcknowledgedBy *string         `json:"acknowledged_by,omitempty" db:"acknowledged_by"`
+	AcknowledgedAt *time.Time      `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	ResolvedBy     *string         `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty" db:"resolved_at"`
+	Note           *string         `json:"note,omitempty" db:"note"`
+}
+
+// AlertActionRequest - Body acknowledge / resolve
+type AlertActionRequest struct {
+	User string  `json:"user" validate:"required,max=100"`
+	Note *string `json:"note,omitempty" validate:"max=500"`
+}
+
+// ActiveAlerts - Alert open + acknowledged untuk panel alarm / badge
+type ActiveAlerts struct {
+	Total          int             `json:"total"`
+	Unacknowledged int             `json:"unacknowledged"`
+	BySeverity     map[string]int  `json:"by_severity"`
+	Routers        []*RouterAlerts `json:"routers"` // router dengan alert terparah dulu
+}
+
+// RouterAlerts - Alert aktif satu router (router_id kosong = alert tanpa router)
+type RouterAlerts struct {
+	RouterID   *int           `json:"router_id,omitempty"`
+	RouterName *string        `json:"router_name,omitempty"`
+	BySeverity map[string]int `json:"by_severity"`
+	Alerts     []*Alert       `json:"alerts"`
+}
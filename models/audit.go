@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog - Jejak perubahan yang dilakukan layer ke router (oleh user atau sistem)
+type AuditLog struct {
+	ID        int64           `json:"id" db:"id"`
+	Actor     string          `json:"actor" db:"actor"` // "system" untuk aksi otomatis
+	Action    string          `json:"action" db:"action"`
+	RouterID  *int            `json:"router_id,omitempty" db:"router_id"`
+	Target    string          `json:"target" db:"target"`
+	Success   bool            `json:"success" db:"success"`
+	Error     *string         `json:"error,omitempty" db:"error"`
+	Details   json.RawMessage `json:"details,omitempty" db:"details"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditFilter - Filter query audit log
+type AuditFilter struct {
+	RouterID *int
+	Actor    string
+	Action   string
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+}
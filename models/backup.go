@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Jenis backup router
+const (
+	BackupBinary = "backup" // /system/backup/save (.backup)
+	BackupExport = "export" // /export (.rsc)
+)
+
+// RouterBackup - Metadata backup / export router; isi disimpan terenkripsi dan diunduh terpisah
+type RouterBackup struct {
+	ID        int       `json:"id" db:"id"`
+	RouterID  int       `json:"router_id" db:"router_id"`
+	Kind      string    `json:"kind" db:"kind"` // backup, export
+	Filename  string    `json:"filename" db:"filename"`
+	SizeBytes int       `json:"size_bytes" db:"size_bytes"` // ukuran sebelum enkripsi
+	SHA256    string    `json:"sha256" db:"sha256"`         // hash isi sebelum enkripsi
+	KeyID     string    `json:"key_id" db:"key_id"`         // sidik jari kunci enkripsi yang dipakai
+	CreatedBy string    `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// BackupRequest - Body buat backup router
+type BackupRequest struct {
+	Kind     string  `json:"kind" validate:"required,oneof=backup export"`
+	Password *string `json:"password,omitempty" validate:"max=100"` // enkripsi bawaan RouterOS (hanya kind backup)
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ScheduleDays - Hari yang valid untuk BandwidthSchedule.Days
+var ScheduleDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// BandwidthSchedule - Limit berbeda pada jendela waktu tertentu (mis. kecepatan 2x malam hari)
+// untuk semua customer satu plan (plan_id) atau satu simple queue (router_id + queue_name).
+// Dijalankan oleh layer sendiri: di luar jendela limit kembali ke plan / max-limit semula.
+type BandwidthSchedule struct {
+	ID            int        `json:"id" db:"id"`
+	Name          string     `json:"name" db:"name"`
+	PlanID        *int       `json:"plan_id,omitempty" db:"plan_id"`
+	RouterID      *int       `json:"router_id,omitempty" db:"router_id"`
+	QueueName     *string    `json:"queue_name,omitempty" db:"queue_name"`
+	RateLimit     string     `json:"rate_limit" db:"rate_limit"` // limit selama jendela, mis. "20M/40M"
+	StartTime     string     `json:"start_time" db:"start_time"` // HH:MM
+	EndTime       string     `json:"end_time" db:"end_time"`     // HH:MM, lebih awal dari start = lewat tengah malam
+	Days          []string   `json:"days" db:"days"`             // hari mulai jendela, kosong = setiap hari
+	Enabled       bool       `json:"enabled" db:"enabled"`
+	AppliedLimit  *string    `json:"applied_limit,omitempty" db:"applied_limit"`   // non-nil = limit jadwal sedang diterapkan
+	OriginalLimit *string    `json:"original_limit,omitempty" db:"original_limit"` // max-limit queue sebelum jendela
+	LastAppliedAt *time.Time `json:"last_applied_at,omitempty" db:"last_applied_at"`
+	LastError     *string    `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// InWindow - True jika now berada di jendela jadwal. Jendela yang lewat tengah malam
+// dihitung milik hari mulainya (Jumat 22:00-06:00 berlaku sampai Sabtu 06:00).
+func (s *BandwidthSchedule) InWindow(now time.Time) bool {
+	start, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", s.EndTime)
+	if err != nil {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	startMin, endMin := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+
+	if startMin < endMin {
+		return minute >= startMin && minute < endMin && s.onDay(now.Weekday())
+	}
+	if minute >= startMin {
+		return s.onDay(now.Weekday())
+	}
+	return minute < endMin && s.onDay((now.Weekday()+6)%7)
+}
+
+func (s *BandwidthSchedule) onDay(day time.Weekday) bool {
+	if len(s.Days) == 0 {
+		return true
+	}
+	for _, d := range s.Days {
+		if strings.EqualFold(d, ScheduleDays[day]) {
+			return true
+		}
+	}
+	return false
+}
+
+// BandwidthScheduleRequest - Body create/update jadwal (update: field yang diisi saja)
+type BandwidthScheduleRequest struct {
+	Name      string    `json:"name" validate:"max=100"`
+	PlanID    *int      `json:"plan_id,omitempty" validate:"min=1"`
+	RouterID  *int      `json:"router_id,omitempty" validate:"min=1"`
+	QueueName *string   `json:"queue_name,omitempty" validate:"max=100"`
+	RateLimit string    `json:"rate_limit" validate:"rate_limit"`
+	StartTime string    `json:"start_time"`
+	EndTime   string    `json:"end_time"`
+	Days      *[]string `json:"days,omitempty"`
+	Enabled   *bool     `json:"enabled,omitempty"`
+}
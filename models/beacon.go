@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Klasifikasi keterjangkauan router dari health check API + heartbeat beacon
+const (
+	ReachabilityOnline         = "online"          // API sehat
+	ReachabilityAPIUnreachable = "api_unreachable" // API gagal tapi beacon masih masuk (router hidup)
+	ReachabilityDown           = "down"            // API gagal dan beacon berhenti
+	ReachabilityUnknown        = "unknown"         // API gagal, beacon belum terpasang / belum pernah masuk
+)
+
+// RouterBeacon - Scheduler di router yang POST heartbeat ke layer via /tool/fetch tiap menit
+// (tabel router_beacons, token hanya disimpan hash-nya)
+type RouterBeacon struct {
+	RouterID     int        `json:"router_id" db:"router_id"`
+	InstalledAt  time.Time  `json:"installed_at" db:"installed_at"`
+	LastSeenAt   *time.Time `json:"last_seen_at,omitempty" db:"last_seen_at"`
+	LastSourceIP *string    `json:"last_source_ip,omitempty" db:"last_source_ip"`
+}
+
+// BeaconInstallRequest - Body POST /api/routers/{id}/beacon (opsional)
+type BeaconInstallRequest struct {
+	BaseURL string `json:"base_url" validate:"url,max=255"` // default BEACON_BASE_URL
+	Manual  bool   `json:"manual"`                          // true = tidak lewat API, pasang router_script sendiri
+}
+
+// BeaconInstallResult - Plan pemasangan scheduler; router_script untuk dipasang manual jika
+// API router sedang tidak bisa diakses (token hanya ada di response ini)
+type BeaconInstallResult struct {
+	Plan         *CommandPlan  `json:"plan"`
+	Beacon       *RouterBeacon `json:"beacon,omitempty"`
+	RouterScript []string      `json:"router_script"`
+}
+
+// RouterReachability - Status API dan beacon satu router beserta klasifikasinya
+type RouterReachability struct {
+	RouterID   int           `json:"router_id"`
+	Class      string        `json:"class"` // online, api_unreachable, down, unknown
+	APIHealthy bool          `json:"api_healthy"`
+	LastPing   *time.Time    `json:"last_ping,omitempty"` // health check API sukses terakhir
+	Beacon     *RouterBeacon `json:"beacon,omitempty"`
+	StaleAfter int64         `json:"stale_after_seconds"` // beacon lebih tua dari ini = router dianggap mati
+}
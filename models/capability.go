@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// RouterPackage - Satu package RouterOS terpasang
+type RouterPackage struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Disabled bool   `json:"disabled"`
+}
+
+// RouterCapabilities - Versi, arsitektur dan package router (di-cache saat connect)
+type RouterCapabilities struct {
+	RouterID     int             `json:"router_id"`
+	Version      string          `json:"version"`
+	VersionMajor int             `json:"version_major"`
+	VersionMinor int             `json:"version_minor"`
+	Architecture string          `json:"architecture"`
+	BoardName    string          `json:"board_name,omitempty"`
+	Packages     []RouterPackage `json:"packages"`
+	Features     map[string]bool `json:"features"` // hasil evaluasi feature gate
+	DetectedAt   time.Time       `json:"detected_at"`
+}
+
+// PermissionCheck - Hasil satu probe hak akses kredensial API router
+type PermissionCheck struct {
+	Name    string `json:"name"`              // mis. read_interfaces, write_config, file_transfer
+	Command string `json:"command,omitempty"` // sentence probe (read) yang dijalankan
+	Policy  string `json:"policy,omitempty"`  // policy user group yang dibutuhkan
+	Allowed bool   `json:"allowed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RouterPermissions - Matriks hak akses kredensial yang dipakai layer ke router
+type RouterPermissions struct {
+	RouterID  int                `json:"router_id"`
+	Username  string             `json:"username"`
+	Group     string             `json:"group,omitempty"`
+	Policies  []string           `json:"policies"` // policy group yang aktif (tanpa "!")
+	Checks    []*PermissionCheck `json:"checks"`
+	CheckedAt time.Time          `json:"checked_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+// CAPsMANRemoteCAP - AP (CAP) yang terhubung ke CAPsMAN router (/caps-man/remote-cap)
+type CAPsMANRemoteCAP struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Identity string `json:"identity,omitempty"`
+	Address  string `json:"address,omitempty"` // MAC / IP:port CAP
+	Board    string `json:"board,omitempty"`
+	Version  string `json:"version,omitempty"`
+	BaseMAC  string `json:"base_mac,omitempty"`
+	State    string `json:"state,omitempty"` // mis. Run, Join, Authorizing
+	Radios   int    `json:"radios"`
+}
+
+// CAPsMANRegistration - Client wireless di AP yang dikelola CAPsMAN (/caps-man/registration-table)
+type CAPsMANRegistration struct {
+	Interface  string  `json:"interface"` // interface CAP, mis. cap1
+	SSID       string  `json:"ssid,omitempty"`
+	MACAddress string  `json:"mac_address"`
+	RxSignal   int     `json:"rx_signal"` // dBm
+	TxRate     string  `json:"tx_rate,omitempty"`
+	RxRate     string  `json:"rx_rate,omitempty"`
+	TxRateMbps float64 `json:"tx_rate_mbps"`
+	RxRateMbps float64 `json:"rx_rate_mbps"`
+	Uptime     string  `json:"uptime,omitempty"`
+	Comment    string  `json:"comment,omitempty"`
+}
+
+// CAPsMANRadio - Radio CAP beserta status provisioning-nya (/caps-man/radio)
+type CAPsMANRadio struct {
+	RadioMAC    string `json:"radio_mac"`
+	RemoteCAP   string `json:"remote_cap,omitempty"` // identity CAP
+	Interface   string `json:"interface,omitempty"`  // interface CAPsMAN hasil provisioning
+	Provisioned bool   `json:"provisioned"`
+}
+
+// CAPsMANProvisioningRule - Rule /caps-man/provisioning
+type CAPsMANProvisioningRule struct {
+	ID                  string `json:"id"`
+	RadioMAC            string `json:"radio_mac,omitempty"` // 00:00:00:00:00:00 = semua radio
+	Action              string `json:"action"`
+	MasterConfiguration string `json:"master_configuration,omitempty"`
+	SlaveConfigurations string `json:"slave_configurations,omitempty"`
+	NameFormat          string `json:"name_format,omitempty"`
+	IdentityRegexp      string `json:"identity_regexp,omitempty"`
+	Comment             string `json:"comment,omitempty"`
+	Disabled            bool   `json:"disabled"`
+}
+
+// CAPsMANStatus - Status CAPsMAN satu router: manager aktif, jumlah CAP / client dan
+// radio yang belum ter-provisioning
+type CAPsMANStatus struct {
+	RouterID      int                        `json:"router_id"`
+	Enabled       bool                       `json:"enabled"`
+	RemoteCAPs    int                        `json:"remote_caps"`
+	Clients       int                        `json:"clients"`
+	Radios        []*CAPsMANRadio            `json:"radios"`
+	Unprovisioned int                        `json:"unprovisioned"`
+	Provisioning  []*CAPsMANProvisioningRule `json:"provisioning"`
+}
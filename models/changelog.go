@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ChangeSourceLayer    = "layer"    // lewat layer, dari audit log
+	ChangeSourceExternal = "external" // langsung di router (Winbox, WebFig, SSH, API lain), dari log config via syslog
+)
+
+// RouterChange - Satu perubahan konfigurasi router
+type RouterChange struct {
+	Time    time.Time       `json:"time"`
+	Source  string          `json:"source"`
+	Actor   string          `json:"actor"`             // user layer / "system"; external: user@alamat dari log router
+	Action  string          `json:"action"`            // external: added, changed, removed, moved
+	Target  string          `json:"target"`            // external: objek RouterOS, mis. "ip address"
+	Success bool            `json:"success"`           // external selalu true
+	Error   *string         `json:"error,omitempty"`   // hanya layer
+	Command string          `json:"command,omitempty"` // external RouterOS v7: perintah yang dijalankan
+	Details json.RawMessage `json:"details,omitempty"`
+	AuditID *int64          `json:"audit_id,omitempty"`
+	EventID *int64          `json:"event_id,omitempty"`
+}
+
+// RouterChangelog - Feed perubahan satu router dalam rentang waktu, terbaru dulu
+type RouterChangelog struct {
+	RouterID  int             `json:"router_id"`
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Layer     int             `json:"layer"`
+	External  int             `json:"external"`
+	Truncated bool            `json:"truncated"` // ada perubahan lebih lama yang terpotong limit
+	Changes   []*RouterChange `json:"changes"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// ConfirmationRequired - Response langkah pertama aksi destruktif: token sekali pakai
+// beserta ringkasan dampak. Aksi dijalankan dengan mengulang request + ?confirm_token=.
+type ConfirmationRequired struct {
+	ConfirmToken string      `json:"confirm_token"`
+	Action       string      `json:"action"`
+	ExpiresAt    time.Time   `json:"expires_at"`
+	Impact       interface{} `json:"impact"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: "@daily"},
+		{expr: " @Weekly "},
+		{expr: "0 2 * * sun"},
+		{expr: "*/15 0-6 1,15 * mon-fri"},
+		{expr: "0-30/10 * * * *"},
+		{expr: "59 23 31 12 7"},
+		{expr: "0 0 1 1 0"},
+		{expr: "", wantErr: true},
+		{expr: "* * * *", wantErr: true},
+		{expr: "* * * * * *", wantErr: true},
+		{expr: "@reboot", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "-1 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * 32 * *", wantErr: true},
+		{expr: "* * * 0 *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * jan *", wantErr: true},
+		{expr: "* * * * 8", wantErr: true},
+		{expr: "* * * * sunday", wantErr: true},
+		{expr: "5-1 * * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "*/x * * * *", wantErr: true},
+		{expr: "1, * * * *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Jumat, 16 Oktober 2026 10:30
+	after := time.Date(2026, 10, 16, 10, 30, 0, 0, time.UTC)
+	wib := time.FixedZone("WIB", 7*3600)
+
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{name: "every minute", expr: "* * * * *", after: after, want: time.Date(2026, 10, 16, 10, 31, 0, 0, time.UTC)},
+		{name: "strictly after", expr: "30 10 * * *", after: after, want: time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)},
+		{name: "seconds are truncated", expr: "31 10 * * *", after: after.Add(59 * time.Second), want: time.Date(2026, 10, 16, 10, 31, 0, 0, time.UTC)},
+		{name: "minute step", expr: "*/15 * * * *", after: after, want: time.Date(2026, 10, 16, 10, 45, 0, 0, time.UTC)},
+		{name: "hour range with step", expr: "0 9-17/4 * * mon-fri", after: after, want: time.Date(2026, 10, 16, 13, 0, 0, 0, time.UTC)},
+		{name: "daily rolls to next day", expr: "0 2 * * *", after: after, want: time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC)},
+		{name: "sunday as 0", expr: "0 0 * * 0", after: after, want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "sunday as 7", expr: "0 0 * * 7", after: after, want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "sunday by name", expr: "0 0 * * sun", after: after, want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "day of week only", expr: "0 0 * * wed", after: after, want: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month only", expr: "0 0 1 * *", after: after, want: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "dom or dow, dow first", expr: "0 0 1 * mon", after: after, want: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "dom or dow, dom first", expr: "0 0 17 * mon", after: after, want: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{name: "month rollover", expr: "@monthly", after: after, want: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "year rollover", expr: "0 0 1 1 *", after: after, want: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", expr: "0 0 29 2 *", after: after, want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "impossible date", expr: "0 0 30 2 *", after: after, want: time.Time{}},
+		{name: "scheduler time zone", expr: "0 2 * * *", after: after.In(wib), want: time.Date(2026, 10, 17, 2, 0, 0, 0, wib)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+			}
+			if got := c.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.after, got, tt.want)
+			}
+		})
+	}
+}
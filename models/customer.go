@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+// Customer - Pelanggan pada satu router, terhubung ke queue dan/atau PPP secret
+type Customer struct {
+	ID        int       `json:"id" db:"id"`
+	RouterID  int       `json:"router_id" db:"router_id"`
+	Name      string    `json:"name" db:"name"`
+	PlanID    *int      `json:"plan_id,omitempty" db:"plan_id"`
+	QueueName *string   `json:"queue_name,omitempty" db:"queue_name"` // /queue/simple name
+	PPPSecret *string   `json:"ppp_secret,omitempty" db:"ppp_secret"` // /ppp/secret name
+	Address   *string   `json:"address,omitempty" db:"address"`       // IP pelanggan (opsional)
+	Phone     *string   `json:"phone,omitempty" db:"phone"`
+	Status    string    `json:"status" db:"status"` // active, suspended
+	Notes     *string   `json:"notes,omitempty" db:"notes"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	SuspendStrategy *string    `json:"suspend_strategy,omitempty" db:"suspend_strategy"` // strategi saat suspend, dipakai unsuspend
+	SuspendOriginal *string    `json:"-" db:"suspend_original"`                          // max-limit sebelum throttle
+	SuspendedAt     *time.Time `json:"suspended_at,omitempty" db:"suspended_at"`
+}
+
+// Status customer
+const (
+	CustomerActive    = "active"
+	CustomerSuspended = "suspended"
+)
+
+// Throttled - True jika customer sedang di-suspend dengan strategi throttle
+func (c *Customer) Throttled() bool {
+	return c.Status == CustomerSuspended && c.SuspendStrategy != nil && *c.SuspendStrategy == SuspendThrottle
+}
+
+// Strategi suspend customer di router
+const (
+	SuspendDisableSecret = "disable_secret" // disable PPP secret + putus sesi aktif
+	SuspendAddressList   = "address_list"   // masukkan IP pelanggan ke address-list blokir
+	SuspendThrottle      = "throttle"       // turunkan max-limit queue
+)
+
+// SuspendStrategies - Semua strategi suspend yang valid
+var SuspendStrategies = []string{SuspendDisableSecret, SuspendAddressList, SuspendThrottle}
+
+// CustomerSuspendResult - Hasil suspend/unsuspend; Changed false jika customer sudah di status tujuan
+type CustomerSuspendResult struct {
+	Customer *Customer    `json:"customer"`
+	Strategy string       `json:"strategy,omitempty"`
+	Changed  bool         `json:"changed"`
+	Plan     *CommandPlan `json:"plan,omitempty"`
+}
+
+type CustomerCreateRequest struct {
+	RouterID  int     `json:"router_id" validate:"required,min=1"`
+	Name      string  `json:"name" validate:"required,max=100"`
+	PlanID    *int    `json:"plan_id,omitempty" validate:"min=1"`
+	QueueName *string `json:"queue_name,omitempty"`
+	PPPSecret *string `json:"ppp_secret,omitempty"`
+	Address   *string `json:"address,omitempty"`
+	Phone     *string `json:"phone,omitempty"`
+	Notes     *string `json:"notes,omitempty"`
+}
+
+type CustomerUpdateRequest struct {
+	Name      *string `json:"name,omitempty" validate:"max=100"`
+	PlanID    *int    `json:"plan_id,omitempty" validate:"min=1"`
+	QueueName *string `json:"queue_name,omitempty"`
+	PPPSecret *string `json:"ppp_secret,omitempty"`
+	Address   *string `json:"address,omitempty"`
+	Phone     *string `json:"phone,omitempty"`
+	Notes     *string `json:"notes,omitempty"`
+}
+
+// CustomerFilter - Filter list customer
+type CustomerFilter struct {
+	RouterID  *int
+	RouterIDs []int // scope akses (nil = tidak dibatasi)
+	PlanID    *int
+	Status    string
+	Query     string
+}
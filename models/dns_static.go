@@ -0,0 +1,39 @@
+package models
+
+// DNSRecordTypes - Tipe record /ip/dns/static yang bisa dibuat lewat layer
+var DNSRecordTypes = []string{"A", "AAAA", "CNAME"}
+
+// DNSStaticEntry - Entry /ip/dns/static (override DNS lokal di router)
+type DNSStaticEntry struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Regexp   string `json:"regexp,omitempty"` // entry berbasis regexp (dibuat di luar layer)
+	Type     string `json:"type"`             // A jika RouterOS tidak mengirim type (versi lama)
+	Address  string `json:"address,omitempty"`
+	CNAME    string `json:"cname,omitempty"`
+	TTL      string `json:"ttl,omitempty"` // format RouterOS, mis. 1d, 5m
+	Comment  string `json:"comment,omitempty"`
+	Disabled bool   `json:"disabled"`
+	Dynamic  bool   `json:"dynamic"`
+}
+
+// DNSStaticRequest - Body POST /api/dns/static; A/AAAA butuh address, CNAME butuh cname
+type DNSStaticRequest struct {
+	Name     string  `json:"name" validate:"required,host,max=255"`
+	Type     string  `json:"type" validate:"oneof=A AAAA CNAME"` // default A
+	Address  *string `json:"address,omitempty" validate:"ip"`
+	CNAME    *string `json:"cname,omitempty" validate:"host,max=255"`
+	TTL      *string `json:"ttl,omitempty" validate:"max=20"`
+	Comment  *string `json:"comment,omitempty" validate:"max=255"`
+	Disabled *bool   `json:"disabled,omitempty"`
+}
+
+// DNSSettings - Ringkasan /ip/dns (resolver router)
+type DNSSettings struct {
+	Servers             []string `json:"servers"`
+	DynamicServers      []string `json:"dynamic_servers"` // dari DHCP / PPP
+	AllowRemoteRequests bool     `json:"allow_remote_requests"`
+	CacheSizeKiB        int64    `json:"cache_size_kib"`
+	CacheUsedKiB        int64    `json:"cache_used_kib"`
+	CacheMaxTTL         string   `json:"cache_max_ttl,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event - Kejadian yang tercatat oleh layer (syslog router, deteksi, dsb)
+type Event struct {
+	ID        int64           `json:"id" db:"id"`
+	RouterID  *int            `json:"router_id,omitempty" db:"router_id"`
+	Type      string          `json:"type" db:"type"`         // syslog, ...
+	Severity  string          `json:"severity" db:"severity"` // debug, info, warning, error, critical
+	Source    *string         `json:"source,omitempty" db:"source"`
+	Message   string          `json:"message" db:"message"`
+	Data      json.RawMessage `json:"data,omitempty" db:"data"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// EventFilter - Filter untuk query list events
+type EventFilter struct {
+	RouterID  *int
+	RouterIDs []int // scope akses (nil = tidak dibatasi)
+	Type      string
+	Severity  string
+	Query     string // potongan teks message
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+}
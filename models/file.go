@@ -0,0 +1,10 @@
+package models
+
+// RouterFile - Entry /file router (script, halaman hotspot, backup, paket firmware)
+type RouterFile struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"` // path lengkap, mis. "hotspot/login.html"
+	Type         string `json:"type"`
+	Size         int64  `json:"size"`
+	CreationTime string `json:"creation_time,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+// FirewallActions - Action /ip/firewall/filter yang bisa dibuat lewat layer
+var FirewallActions = []string{
+	"accept", "drop", "reject", "jump", "return", "passthrough", "log", "tarpit", "fasttrack-connection",
+	"add-src-to-address-list", "add-dst-to-address-list",
+}
+
+// FirewallRule - Rule /ip/firewall/filter; urutan daftar = urutan evaluasi di router
+type FirewallRule struct {
+	ID              string `json:"id"`
+	Chain           string `json:"chain"`
+	Action          string `json:"action"`
+	SrcAddress      string `json:"src_address,omitempty"`
+	DstAddress      string `json:"dst_address,omitempty"`
+	SrcAddressList  string `json:"src_address_list,omitempty"`
+	DstAddressList  string `json:"dst_address_list,omitempty"`
+	Protocol        string `json:"protocol,omitempty"`
+	SrcPort         string `json:"src_port,omitempty"`
+	DstPort         string `json:"dst_port,omitempty"`
+	InInterface     string `json:"in_interface,omitempty"`
+	OutInterface    string `json:"out_interface,omitempty"`
+	ConnectionState string `json:"connection_state,omitempty"`
+	JumpTarget      string `json:"jump_target,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+	Disabled        bool   `json:"disabled"`
+	Dynamic         bool   `json:"dynamic"` // dibuat RouterOS / service lain, tidak bisa diubah
+	Invalid         bool   `json:"invalid"`
+	Bytes           int64  `json:"bytes"`
+	Packets         int64  `json:"packets"`
+}
+
+// FirewallRuleRequest - Body POST /api/firewall/filter. Field match kosong = tidak dipakai;
+// nilai diteruskan apa adanya ke RouterOS (mis. "!10.0.0.0/8", "80,443", "established,related").
+type FirewallRuleRequest struct {
+	Chain           string  `json:"chain" validate:"required,max=50"` // input, forward, output atau chain custom
+	Action          string  `json:"action" validate:"required"`
+	SrcAddress      *string `json:"src_address,omitempty" validate:"max=100"`
+	DstAddress      *string `json:"dst_address,omitempty" validate:"max=100"`
+	SrcAddressList  *string `json:"src_address_list,omitempty" validate:"max=100"`
+	DstAddressList  *string `json:"dst_address_list,omitempty" validate:"max=100"`
+	Protocol        *string `json:"protocol,omitempty" validate:"max=20"`
+	SrcPort         *string `json:"src_port,omitempty" validate:"max=100"` // butuh protocol tcp/udp
+	DstPort         *string `json:"dst_port,omitempty" validate:"max=100"`
+	InInterface     *string `json:"in_interface,omitempty" validate:"max=100"`
+	OutInterface    *string `json:"out_interface,omitempty" validate:"max=100"`
+	ConnectionState *string `json:"connection_state,omitempty" validate:"max=100"`
+	JumpTarget      *string `json:"jump_target,omitempty" validate:"max=50"` // wajib untuk action jump
+	Comment         *string `json:"comment,omitempty" validate:"max=255"`
+	Disabled        *bool   `json:"disabled,omitempty"`
+	PlaceBefore     *string `json:"place_before,omitempty"` // .id rule tujuan; kosong = di akhir
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// FlowRecord - Agregat flow NetFlow/IPFIX dalam satu bucket waktu
+type FlowRecord struct {
+	RouterID    int       `json:"router_id"`
+	BucketStart time.Time `json:"bucket_start"`
+	SrcAddress  string    `json:"src_address"`
+	DstAddress  string    `json:"dst_address"`
+	Protocol    int       `json:"protocol"`
+	SrcPort     int       `json:"src_port"`
+	DstPort     int       `json:"dst_port"`
+	Bytes       uint64    `json:"bytes"`
+	Packets     uint64    `json:"packets"`
+	Flows       int       `json:"flows"`
+}
+
+// FlowSummary - Hasil query flow; field yang tidak di-group bernilai nil
+type FlowSummary struct {
+	SrcAddress *string   `json:"src_address,omitempty"`
+	DstAddress *string   `json:"dst_address,omitempty"`
+	Protocol   *int      `json:"protocol,omitempty"`
+	SrcPort    *int      `json:"src_port,omitempty"`
+	DstPort    *int      `json:"dst_port,omitempty"`
+	Bytes      uint64    `json:"bytes"`
+	Packets    uint64    `json:"packets"`
+	Flows      int       `json:"flows"`
+	FirstSeen  time.Time `json:"first_seen"`
+	LastSeen   time.Time `json:"last_seen"`
+}
+
+// FlowFilter - Filter query flow records
+type FlowFilter struct {
+	RouterID   int
+	From       time.Time
+	To         time.Time
+	SrcAddress string
+	DstAddress string
+	Port       int    // cocok dengan src_port atau dst_port
+	Protocol   int    // 0 = semua
+	GroupBy    string // "", src, dst, port
+	Limit      int
+}
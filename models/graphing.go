@@ -0,0 +1,27 @@
+package models
+
+// Jenis rule /tool/graphing
+const (
+	GraphingInterface = "interface"
+	GraphingQueue     = "queue"
+	GraphingResource  = "resource"
+)
+
+// Hasil import rule graphing ke sampling layer
+const (
+	GraphingSamplerStarted = "sampler_started" // traffic sampler interface dijalankan
+	GraphingSamplerRunning = "sampler_running" // sampler interface sudah berjalan
+	GraphingCovered        = "covered"         // sudah tercakup sampler lain (mis. queue usage)
+	GraphingUnsupported    = "unsupported"     // metrik tidak punya padanan di layer
+)
+
+// GraphingImportItem - Satu target graphing RouterOS beserta padanannya di layer
+type GraphingImportItem struct {
+	Type         string `json:"type"`   // interface, queue, resource
+	Target       string `json:"target"` // nama interface / queue, "all" sudah di-expand
+	AllowAddress string `json:"allow_address,omitempty"`
+	StoreOnDisk  bool   `json:"store_on_disk"`
+	Disabled     bool   `json:"disabled"`
+	Action       string `json:"action"`
+	Note         string `json:"note,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Level kesehatan router dari health check
+const (
+	HealthLevelHealthy  = "healthy"
+	HealthLevelDegraded = "degraded" // terkoneksi tapi latency / loss health check melewati ambang
+	HealthLevelDown     = "down"     // health check terakhir gagal
+)
+
+// RouterHealth - Skor kesehatan komposit router (0 = terburuk, 100 = sehat)
+type RouterHealth struct {
+	RouterID      int       `json:"router_id"`
+	Score         float64   `json:"score"`
+	Healthy       bool      `json:"healthy"`           // hasil health check terakhir
+	Level         string    `json:"level"`             // healthy, degraded, down
+	Reasons       []string  `json:"reasons,omitempty"` // alasan degraded
+	Flaps         int       `json:"flaps"`             // transisi up -> down dalam window
+	CPULoad       float64   `json:"cpu_load"`          // persen
+	MemoryUsedPct float64   `json:"memory_used_pct"`   // persen
+	ErrorRate     float64   `json:"error_rate"`        // rasio command gagal (0-1) dalam window
+	LatencyMs     float64   `json:"latency_ms"`        // rata-rata bergerak round-trip health check
+	LossPct       float64   `json:"loss_pct"`          // persen health check gagal dari 10 check terakhir
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ConnectionsHealth - Ringkasan ringkas koneksi router untuk monitor uptime eksternal
+type ConnectionsHealth struct {
+	Healthy        bool       `json:"healthy"`
+	Total          int        `json:"total"` // router aktif
+	Online         int        `json:"online"`
+	Degraded       int        `json:"degraded"` // online tapi level kesehatan degraded
+	Offline        int        `json:"offline"`
+	Error          int        `json:"error"`
+	Suspended      int        `json:"suspended"`
+	OnlinePct      float64    `json:"online_pct"` // online / (total - suspended)
+	MinOnlinePct   float64    `json:"min_online_pct"`
+	OldestLastPing *time.Time `json:"oldest_last_ping,omitempty"` // health check sukses tertua di antara koneksi sehat
+	OldestRouterID *int       `json:"oldest_router_id,omitempty"`
+	Reasons        []string   `json:"reasons,omitempty"` // alasan tidak sehat
+}
+
+// DashboardSummary - Ringkasan armada router untuk dashboard
+type DashboardSummary struct {
+	TotalRouters   int             `json:"total_routers"`
+	ActiveRouters  int             `json:"active_routers"`
+	ByStatus       map[string]int  `json:"by_status"`
+	ByHealth       map[string]int  `json:"by_health"` // level kesehatan router yang dipantau
+	AvgHealthScore *float64        `json:"avg_health_score,omitempty"`
+	Worst          []*RouterHealth `json:"worst"` // skor terendah dulu
+}
@@ -0,0 +1,36 @@
+package models
+
+// ARPEntry - Entry tabel ARP router, diperkaya nama vendor dari OUI MAC
+type ARPEntry struct {
+	ID         string `json:"id"`
+	Address    string `json:"address"`
+	MacAddress string `json:"mac_address"`
+	Interface  string `json:"interface"`
+	Vendor     string `json:"vendor,omitempty"`
+	Dynamic    bool   `json:"dynamic"`
+	Complete   bool   `json:"complete"`
+}
+
+// DHCPLease - Lease DHCP server, diperkaya nama vendor dari OUI MAC
+type DHCPLease struct {
+	ID           string `json:"id"`
+	Address      string `json:"address"`
+	MacAddress   string `json:"mac_address"`
+	HostName     string `json:"host_name,omitempty"`
+	Server       string `json:"server"`
+	Status       string `json:"status"`
+	ExpiresAfter string `json:"expires_after,omitempty"`
+	Vendor       string `json:"vendor,omitempty"`
+	Dynamic      bool   `json:"dynamic"`
+}
+
+// BridgeHost - MAC yang dipelajari bridge, diperkaya nama vendor dari OUI MAC
+type BridgeHost struct {
+	ID          string `json:"id"`
+	MacAddress  string `json:"mac_address"`
+	Bridge      string `json:"bridge"`
+	OnInterface string `json:"on_interface"`
+	Vendor      string `json:"vendor,omitempty"`
+	Dynamic     bool   `json:"dynamic"`
+	Local       bool   `json:"local"`
+}
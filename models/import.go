@@ -0,0 +1,19 @@
+package models
+
+// ImportRow - Satu baris CSV import queue / PPP secret yang sudah lolos validasi
+type ImportRow struct {
+	Line     int    `json:"line"` // nomor baris di file (header = baris 1)
+	Name     string `json:"name"`
+	Target   string `json:"target,omitempty"` // import queue
+	Password string `json:"-"`                // import PPP secret, tidak pernah dikembalikan
+	Plan     string `json:"plan"`
+	PlanID   int    `json:"plan_id"`
+}
+
+// ImportPreview - Response dry run import: baris yang akan diprovisioning beserta command plan-nya
+type ImportPreview struct {
+	RouterID int            `json:"router_id"`
+	Action   string         `json:"action"`
+	Rows     []*ImportRow   `json:"rows"`
+	Plans    []*CommandPlan `json:"plans"` // profile PPP (sekali per plan) lalu satu plan per baris
+}
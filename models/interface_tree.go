@@ -0,0 +1,26 @@
+package models
+
+// Relasi interface terhadap master-nya di tree
+const (
+	InterfaceRelationPort  = "port"  // port bridge
+	InterfaceRelationSlave = "slave" // slave bonding
+	InterfaceRelationVLAN  = "vlan"  // VLAN di atas parent
+)
+
+// InterfaceNode - Satu interface beserta interface yang menempel padanya (port, slave, VLAN)
+type InterfaceNode struct {
+	Name     string           `json:"name"`
+	Type     string           `json:"type"`
+	Relation string           `json:"relation,omitempty"` // kosong untuk root
+	VLANID   int              `json:"vlan_id,omitempty"`
+	Running  bool             `json:"running"`
+	Disabled bool             `json:"disabled"`
+	Comment  string           `json:"comment,omitempty"`
+	Children []*InterfaceNode `json:"children"`
+}
+
+// InterfaceTree - Hierarki interface satu router; root adalah interface tanpa master
+type InterfaceTree struct {
+	RouterID int              `json:"router_id"`
+	Roots    []*InterfaceNode `json:"roots"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// FleetAddress - Satu IP address router dalam audit lintas fleet
+type FleetAddress struct {
+	RouterID   int    `json:"router_id"`
+	RouterName string `json:"router_name"`
+	Address    string `json:"address"`
+	Interface  string `json:"interface"`
+}
+
+// IPConflict - Alamat duplikat atau subnet tumpang tindih antar router
+type IPConflict struct {
+	Type    string          `json:"type"` // duplicate_address, overlapping_subnet
+	Subject string          `json:"subject"`
+	Entries []*FleetAddress `json:"entries"`
+}
+
+// Jenis konflik IP
+const (
+	ConflictDuplicateAddress  = "duplicate_address"
+	ConflictOverlappingSubnet = "overlapping_subnet"
+)
+
+// IPConflictReport - Hasil audit konflik IP seluruh router yang terhubung
+type IPConflictReport struct {
+	RoutersChecked int            `json:"routers_checked"`
+	Addresses      int            `json:"addresses"`
+	Conflicts      []*IPConflict  `json:"conflicts"`
+	Skipped        map[int]string `json:"skipped,omitempty"` // router_id -> error
+	CheckedAt      time.Time      `json:"checked_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// DHCPLeaseRecord - Riwayat satu lease DHCP (IP + MAC) selama berstatus bound
+type DHCPLeaseRecord struct {
+	ID          int64      `json:"id" db:"id"`
+	RouterID    int        `json:"router_id" db:"router_id"`
+	Address     string     `json:"address" db:"address"`
+	MacAddress  string     `json:"mac_address" db:"mac_address"`
+	HostName    string     `json:"host_name,omitempty" db:"host_name"`
+	Server      string     `json:"server" db:"server"`
+	FirstSeenAt time.Time  `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty" db:"ended_at"` // nil = masih bound
+}
+
+// Sumber pemetaan IP -> pelanggan
+const (
+	IPSourcePPPSession = "ppp_session"
+	IPSourceDHCPLease  = "dhcp_lease"
+)
+
+// IPHistoryMatch - Pemegang IP pada waktu tertentu beserta customer yang cocok (jika ada)
+type IPHistoryMatch struct {
+	Source     string     `json:"source"` // ppp_session, dhcp_lease
+	RouterID   int        `json:"router_id"`
+	Username   string     `json:"username,omitempty"` // PPP secret
+	CallerID   string     `json:"caller_id,omitempty"`
+	MacAddress string     `json:"mac_address,omitempty"`
+	HostName   string     `json:"host_name,omitempty"`
+	From       time.Time  `json:"from"`
+	To         *time.Time `json:"to,omitempty"` // nil = masih dipegang
+	Customer   *Customer  `json:"customer,omitempty"`
+}
+
+// IPHistoryResult - Jawaban "siapa memegang IP ini pada waktu tsb"
+type IPHistoryResult struct {
+	IP      string            `json:"ip"`
+	At      time.Time         `json:"at"`
+	Matches []*IPHistoryMatch `json:"matches"`
+}
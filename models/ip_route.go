@@ -0,0 +1,27 @@
+package models
+
+// RouteFilter - Filter list /ip/route (nil / kosong = tidak difilter)
+type RouteFilter struct {
+	Dynamic      *bool
+	Active       *bool
+	RoutingTable string // "main" juga cocok dengan route v6 tanpa routing-mark
+	DstAddress   string
+}
+
+// RouteRequest - Body POST /api/routes dan job route_add: route static IPv4
+type RouteRequest struct {
+	DstAddress   string  `json:"dst_address" validate:"cidr"`                 // default 0.0.0.0/0
+	Gateway      string  `json:"gateway" validate:"required,max=100"`         // IP, interface, atau IP%interface
+	Distance     *int    `json:"distance,omitempty" validate:"min=1,max=255"` // default 1
+	RoutingTable *string `json:"routing_table,omitempty" validate:"max=64"`   // default main (v6: routing-mark)
+	Comment      *string `json:"comment,omitempty" validate:"max=255"`
+	Disabled     *bool   `json:"disabled,omitempty"`
+}
+
+// Table - Routing table tujuan, "main" jika tidak diisi
+func (r *RouteRequest) Table() string {
+	if r.RoutingTable == nil || *r.RoutingTable == "" {
+		return "main"
+	}
+	return *r.RoutingTable
+}
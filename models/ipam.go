@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Subnet - Prefix yang dialokasikan dan didokumentasikan di rencana IP (tabel ipam_subnets)
+type Subnet struct {
+	ID          int       `json:"id" db:"id"`
+	Prefix      string    `json:"prefix" db:"prefix"` // selalu alamat network, mis. 10.20.0.0/24
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	RouterID    *int      `json:"router_id,omitempty" db:"router_id"` // router/site pemilik (opsional)
+	VLANID      *int      `json:"vlan_id,omitempty" db:"vlan_id"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// SubnetRequest - Body create/update subnet (update: field yang diisi saja)
+type SubnetRequest struct {
+	Prefix      string  `json:"prefix" validate:"cidr"`
+	Name        string  `json:"name" validate:"max=100"`
+	Description *string `json:"description,omitempty" validate:"max=500"`
+	RouterID    *int    `json:"router_id,omitempty" validate:"min=0"` // 0 = lepas dari router
+	VLANID      *int    `json:"vlan_id,omitempty" validate:"min=0,max=4094"`
+}
+
+// IPPool - Pool alamat router (/ip/pool) beserta jumlah alamat terpakai (/ip/pool/used)
+type IPPool struct {
+	Name   string   `json:"name"`
+	Ranges []string `json:"ranges"`
+	Size   int64    `json:"size"`
+	Used   int      `json:"used"`
+}
+
+// IPAMPoolUsage - Pool router yang berada di dalam subnet
+type IPAMPoolUsage struct {
+	RouterID   int    `json:"router_id"`
+	RouterName string `json:"router_name"`
+	*IPPool
+}
+
+// SubnetUsage - Pemakaian live satu subnet: alamat interface router dan pool di dalamnya
+type SubnetUsage struct {
+	*Subnet
+	Size           int64            `json:"size"` // jumlah host yang bisa dipakai
+	Used           int64            `json:"used"` // alamat interface + alamat pool terpakai
+	UtilizationPct float64          `json:"utilization_pct"`
+	Addresses      []*FleetAddress  `json:"addresses"`
+	Pools          []*IPAMPoolUsage `json:"pools"`
+}
+
+// Jenis pemakaian yang tidak terdokumentasi
+const (
+	UndocumentedAddress = "address"
+	UndocumentedPool    = "pool"
+)
+
+// UndocumentedUsage - Alamat / pool router yang tidak masuk subnet mana pun di rencana IP
+type UndocumentedUsage struct {
+	Type       string `json:"type"`
+	RouterID   int    `json:"router_id"`
+	RouterName string `json:"router_name"`
+	Subject    string `json:"subject"` // address CIDR atau nama pool
+	Interface  string `json:"interface,omitempty"`
+	Ranges     string `json:"ranges,omitempty"`
+}
+
+// IPAMReport - Rencana IP dicocokkan dengan alamat dan pool live semua router terhubung
+type IPAMReport struct {
+	RoutersChecked int                  `json:"routers_checked"`
+	Subnets        []*SubnetUsage       `json:"subnets"`
+	Undocumented   []*UndocumentedUsage `json:"undocumented"`
+	Skipped        map[int]string       `json:"skipped,omitempty"` // router_id -> error
+	CheckedAt      time.Time            `json:"checked_at"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+// Action job fleet-wide
+const (
+	JobActionCommand  = "command"   // sentence RouterOS bebas
+	JobActionUpgrade  = "upgrade"   // /system/package/update check + install
+	JobActionRouteAdd = "route_add" // route static yang sama ke semua router (idempoten)
+
+	// Import CSV onboarding customer ke satu router (satu task per baris)
+	JobActionQueueImport     = "queue_import"
+	JobActionPPPSecretImport = "ppp_secret_import"
+)
+
+// Status job
+const (
+	JobRunning   = "running"
+	JobCompleted = "completed" // semua task ok
+	JobFailed    = "failed"    // minimal satu task gagal
+	JobCancelled = "cancelled"
+)
+
+// Status task per router
+const (
+	TaskPending   = "pending"
+	TaskRunning   = "running"
+	TaskOK        = "ok"
+	TaskFailed    = "failed"
+	TaskCancelled = "cancelled"
+)
+
+// Job - Eksekusi satu aksi ke banyak router secara paralel
+type Job struct {
+	ID         int64      `json:"id" db:"id"`
+	Action     string     `json:"action" db:"action"`
+	Command    []string   `json:"command,omitempty" db:"command"` // hanya action command
+	Status     string     `json:"status" db:"status"`
+	CreatedBy  string     `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+	Summary    JobSummary `json:"summary"`
+	Tasks      []*JobTask `json:"tasks,omitempty"` // hanya di detail job
+}
+
+// JobSummary - Jumlah task per status
+type JobSummary struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Running   int `json:"running"`
+	OK        int `json:"ok"`
+	Failed    int `json:"failed"`
+	Cancelled int `json:"cancelled"`
+}
+
+// Add - Hitung satu task dengan status tsb
+func (s *JobSummary) Add(status string, n int) {
+	s.Total += n
+	switch status {
+	case TaskPending:
+		s.Pending += n
+	case TaskRunning:
+		s.Running += n
+	case TaskOK:
+		s.OK += n
+	case TaskFailed:
+		s.Failed += n
+	case TaskCancelled:
+		s.Cancelled += n
+	}
+}
+
+// JobTask - Status eksekusi job di satu router (job import: satu baris CSV)
+type JobTask struct {
+	ID         int64      `json:"id" db:"id"`
+	JobID      int64      `json:"job_id" db:"job_id"`
+	RouterID   int        `json:"router_id" db:"router_id"`
+	RouterName string     `json:"router_name" db:"router_name"`
+	Target     string     `json:"target,omitempty" db:"target"` // nama queue / secret untuk job import
+	Status     string     `json:"status" db:"status"`
+	Output     *string    `json:"output,omitempty" db:"output"`
+	Error      *string    `json:"error,omitempty" db:"error"`
+	StartedAt  *time.Time `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// JobPreview - Hasil dry run job untuk satu router
+type JobPreview struct {
+	RouterID   int          `json:"router_id"`
+	RouterName string       `json:"router_name"`
+	Plan       *CommandPlan `json:"plan,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// JobRequest - Body POST /api/jobs
+type JobRequest struct {
+	Action      string        `json:"action" validate:"required,oneof=command upgrade route_add"`
+	RouterIDs   []int         `json:"router_ids"`                                     // kosong = semua router yang terkoneksi
+	Command     []string      `json:"command,omitempty"`                              // wajib untuk action command, mis. ["/system/identity/print"]
+	Concurrency int           `json:"concurrency,omitempty" validate:"min=1,max=100"` // default dari konfigurasi
+	Route       *RouteRequest `json:"route,omitempty"`                                // wajib untuk action route_add
+}
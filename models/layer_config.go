@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// LayerConfigVersion - Versi format bundle konfigurasi; import menolak versi lain
+const LayerConfigVersion = 1
+
+// Mode kredensial router di bundle
+const (
+	ConfigSecretsNone      = "none"      // password & kunci jump host dikosongkan
+	ConfigSecretsEncrypted = "encrypted" // dienkripsi dengan passphrase export
+)
+
+// LayerConfigBundle - Konfigurasi layer (router, plan, channel notifikasi alert) untuk promosi
+// staging -> production atau pemulihan instance layer
+type LayerConfigBundle struct {
+	Version       int                           `json:"version"`
+	ExportedAt    time.Time                     `json:"exported_at"`
+	Secrets       string                        `json:"secrets"` // none / encrypted
+	Encryption    *ConfigEncryption             `json:"encryption,omitempty"`
+	Routers       []*ConfigRouter               `json:"routers"`
+	Plans         []*PlanCreateRequest          `json:"plans"`
+	AlertChannels []*NotificationChannelRequest `json:"alert_channels"`
+}
+
+// ConfigEncryption - Parameter enkripsi kredensial router di bundle
+type ConfigEncryption struct {
+	KDF        string `json:"kdf"` // pbkdf2-sha256
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"`   // base64
+	Cipher     string `json:"cipher"` // aes-256-gcm
+}
+
+// ConfigRouter - Router di bundle (dicocokkan by name saat import); kredensial kosong atau
+// terenkripsi di Secrets
+type ConfigRouter struct {
+	RouterCreateRequest
+	IsActive bool   `json:"is_active"`
+	Secrets  string `json:"secrets,omitempty"` // base64(nonce || AES-GCM JSON password & kunci jump host)
+}
+
+// ConfigExportRequest - Body POST /api/config/export
+type ConfigExportRequest struct {
+	Secrets    string `json:"secrets" validate:"oneof=none encrypted"` // default none
+	Passphrase string `json:"passphrase,omitempty" validate:"max=200"` // wajib (min 12) untuk encrypted
+}
+
+// ConfigImportRequest - Body POST /api/config/import[?dry_run=true]
+type ConfigImportRequest struct {
+	Bundle     *LayerConfigBundle `json:"bundle" validate:"required"`
+	Passphrase string             `json:"passphrase,omitempty"` // wajib untuk bundle encrypted
+	Overwrite  bool               `json:"overwrite"`            // false = item yang namanya sudah ada dilewati
+}
+
+// Hasil import per item
+const (
+	ConfigItemCreated = "created"
+	ConfigItemUpdated = "updated"
+	ConfigItemSkipped = "skipped" // sudah ada dan overwrite=false
+	ConfigItemFailed  = "failed"
+)
+
+// ConfigImportItem - Hasil import satu router / plan / channel
+type ConfigImportItem struct {
+	Name   string `json:"name"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ConfigImportResult - Hasil import bundle; dry run tidak menulis apa pun
+type ConfigImportResult struct {
+	DryRun        bool                `json:"dry_run"`
+	Routers       []*ConfigImportItem `json:"routers"`
+	Plans         []*ConfigImportItem `json:"plans"`
+	AlertChannels []*ConfigImportItem `json:"alert_channels"`
+}
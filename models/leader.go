@@ -0,0 +1,8 @@
+package models
+
+// LeaderStatus - Status leader election instance ini (ditampilkan di /health)
+type LeaderStatus struct {
+	Enabled  bool   `json:"enabled"` // false = satu instance, semua worker background jalan lokal
+	Instance string `json:"instance"`
+	Leader   bool   `json:"leader"`
+}
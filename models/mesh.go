@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// MonitoredPath - Jalur yang dipantau: ping dari router sumber ke router tujuan (atau alamat bebas)
+type MonitoredPath struct {
+	ID                 int       `json:"id" db:"id"`
+	Name               string    `json:"name" db:"name"`
+	SourceRouterID     int       `json:"source_router_id" db:"source_router_id"`
+	TargetRouterID     *int      `json:"target_router_id,omitempty" db:"target_router_id"`
+	TargetAddress      *string   `json:"target_address,omitempty" db:"target_address"` // default hostname router tujuan
+	PingCount          int       `json:"ping_count" db:"ping_count"`
+	LatencyThresholdMs float64   `json:"latency_threshold_ms" db:"latency_threshold_ms"` // 0 = tidak dicek
+	LossThresholdPct   float64   `json:"loss_threshold_pct" db:"loss_threshold_pct"`
+	Enabled            bool      `json:"enabled" db:"enabled"`
+	State              string    `json:"state" db:"state"` // unknown, ok, degraded
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Status jalur mesh
+const (
+	PathStateUnknown  = "unknown"
+	PathStateOK       = "ok"
+	PathStateDegraded = "degraded"
+)
+
+// MonitoredPathRequest - Body create/update monitored path
+type MonitoredPathRequest struct {
+	Name               string   `json:"name" validate:"max=100"`
+	SourceRouterID     int      `json:"source_router_id" validate:"min=1"`
+	TargetRouterID     *int     `json:"target_router_id" validate:"min=1"`
+	TargetAddress      *string  `json:"target_address" validate:"host"`
+	PingCount          int      `json:"ping_count" validate:"min=1,max=100"`
+	LatencyThresholdMs *float64 `json:"latency_threshold_ms" validate:"min=0"`
+	LossThresholdPct   *float64 `json:"loss_threshold_pct" validate:"min=0,max=100"`
+	Enabled            *bool    `json:"enabled"`
+}
+
+// PingResult - Ringkasan /ping dari router
+type PingResult struct {
+	Sent     int     `json:"sent"`
+	Received int     `json:"received"`
+	LossPct  float64 `json:"loss_pct"`
+	MinRttMs float64 `json:"min_rtt_ms"`
+	AvgRttMs float64 `json:"avg_rtt_ms"`
+	MaxRttMs float64 `json:"max_rtt_ms"`
+}
+
+// PathSample - Hasil satu putaran ping jalur
+type PathSample struct {
+	PathID int `json:"path_id" db:"path_id"`
+	PingResult
+	SampledAt time.Time `json:"sampled_at" db:"sampled_at"`
+}
+
+// MeshCell - Status terkini satu jalur untuk matrix
+type MeshCell struct {
+	PathID         int         `json:"path_id"`
+	Name           string      `json:"name"`
+	SourceRouterID int         `json:"source_router_id"`
+	TargetRouterID *int        `json:"target_router_id,omitempty"`
+	Target         string      `json:"target"`
+	State          string      `json:"state"`
+	Latest         *PathSample `json:"latest,omitempty"`
+}
@@ -9,6 +9,24 @@ type Interface struct {
 	TxBytes    string `json:"tx-bytes,omitempty"`
 	RxPackets  string `json:"rx-packets,omitempty"`
 	TxPackets  string `json:"tx-packets,omitempty"`
+	RxErrors   string `json:"rx-error,omitempty"`
+	TxErrors   string `json:"tx-error,omitempty"`
+	RxDrops    string `json:"rx-drop,omitempty"`
+	TxDrops    string `json:"tx-drop,omitempty"`
+	FCSErrors  string `json:"rx-fcs-error,omitempty"` // dari /interface/ethernet stats, kosong untuk non-ethernet
+	Comment    string `json:"comment,omitempty"`      // label, mis. "Uplink to POP3"
+	MacAddress string `json:"mac-address,omitempty"`
+	MTU        string `json:"mtu,omitempty"`
+	LastLinkUp string `json:"last-link-up-time,omitempty"`
+}
+
+// InterfaceErrors - Counter error/drop interface (FCS hanya untuk ethernet)
+type InterfaceErrors struct {
+	RxErrors  int64 `json:"rx_errors"`
+	TxErrors  int64 `json:"tx_errors"`
+	RxDrops   int64 `json:"rx_drops"`
+	TxDrops   int64 `json:"tx_drops"`
+	FCSErrors int64 `json:"fcs_errors"`
 }
 
 type Address struct {
@@ -17,6 +35,7 @@ type Address struct {
 	Interface string `json:"interface"`
 	Network   string `json:"network"`
 	Disabled  bool   `json:"disabled"`
+	Dynamic   bool   `json:"dynamic"`
 }
 
 type Queue struct {
@@ -28,9 +47,83 @@ type Queue struct {
 	Disabled bool   `json:"disabled"`
 }
 
+// AddressListEntry - Entry /ip/firewall/address-list
+type AddressListEntry struct {
+	ID       string `json:"id"`
+	List     string `json:"list"`
+	Address  string `json:"address"`
+	Comment  string `json:"comment,omitempty"`
+	Timeout  string `json:"timeout,omitempty"`
+	Disabled bool   `json:"disabled"`
+	Dynamic  bool   `json:"dynamic"`
+}
+
 type ApiResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-}
\ No newline at end of file
+	Success bool         `json:"success"`
+	Code    string       `json:"code,omitempty"` // code machine-readable (lihat package i18n)
+	Message string       `json:"message,omitempty"`
+	Data    interface{}  `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"` // detail validasi per field (422)
+}
+
+// FieldError - Pelanggaran validasi satu field request
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Error string `json:"error"`
+}
+
+// CommandPlan - Hasil resolve operasi mutasi: sentence RouterOS yang (akan) dieksekusi
+type CommandPlan struct {
+	RouterID int        `json:"router_id"`
+	Action   string     `json:"action"`
+	DryRun   bool       `json:"dry_run"`
+	Checks   []string   `json:"checks,omitempty"`
+	Commands [][]string `json:"commands"`
+}
+
+type SystemResource struct {
+	Version      string `json:"version"`
+	Uptime       string `json:"uptime"`
+	CPULoad      string `json:"cpu-load"`
+	FreeMemory   string `json:"free-memory"`
+	TotalMemory  string `json:"total-memory"`
+	BoardName    string `json:"board-name,omitempty"`
+	Architecture string `json:"architecture-name,omitempty"`
+}
+
+// ProbeResult - Hasil liveness probe langsung ke router
+type ProbeResult struct {
+	Alive     bool    `json:"alive"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// CommandLatency - Persentil waktu eksekusi command RouterOS dalam window terakhir
+type CommandLatency struct {
+	Samples int     `json:"samples"`
+	LastMs  float64 `json:"last_ms"`
+	P50Ms   float64 `json:"p50_ms"`
+	P90Ms   float64 `json:"p90_ms"`
+	P99Ms   float64 `json:"p99_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
+
+// State koneksi API router (topic hub "connections")
+const (
+	ConnectionConnected    = "connected"
+	ConnectionDisconnected = "disconnected"
+	ConnectionReconnecting = "reconnecting"
+	ConnectionAuthFailed   = "auth_failed" // login ditolak: username / password salah
+)
+
+// ConnectionEvent - Perubahan state koneksi API satu router
+type ConnectionEvent struct {
+	RouterID      int    `json:"router_id"`
+	RouterName    string `json:"router_name"`
+	State         string `json:"state"`
+	Reason        string `json:"reason,omitempty"`
+	ActivePath    string `json:"active_path,omitempty"`    // hanya connected
+	ActiveAddress string `json:"active_address,omitempty"` // hanya connected
+}
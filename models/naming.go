@@ -0,0 +1,18 @@
+package models
+
+// NamingViolation - Objek router yang nama / comment-nya tidak sesuai naming policy
+type NamingViolation struct {
+	Type    string `json:"type"`           // queue / ppp_secret / firewall_comment
+	Menu    string `json:"menu,omitempty"` // menu firewall, mis. /ip/firewall/filter
+	ID      string `json:"id"`
+	Value   string `json:"value"` // nama atau comment yang melanggar
+	Pattern string `json:"pattern"`
+}
+
+// NamingReport - Objek lama yang tidak sesuai naming policy di satu router, untuk cleanup
+type NamingReport struct {
+	RouterID   int                `json:"router_id"`
+	Policies   map[string]string  `json:"policies"` // regex per tipe objek yang aktif
+	Checked    int                `json:"checked"`  // jumlah objek yang dicek terhadap policy
+	Violations []*NamingViolation `json:"violations"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Tipe channel notifikasi alert
+const (
+	ChannelWebhook = "webhook" // JSON POST alert ke URL (mis. paging on-call)
+	ChannelEmail   = "email"   // email via SMTP ke daftar alamat
+)
+
+// NotificationChannel - Tujuan notifikasi alert baru, dipilih berdasarkan tag router,
+// severity minimum dan jam tenang (tabel notification_channels)
+type NotificationChannel struct {
+	ID          int       `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Type        string    `json:"type" db:"type"`               // webhook / email
+	Target      string    `json:"target" db:"target"`           // URL webhook atau alamat email comma-separated
+	RouterTags  []string  `json:"router_tags" db:"router_tags"` // kosong = semua router (termasuk alert tanpa router)
+	MinSeverity string    `json:"min_severity" db:"min_severity"`
+	QuietStart  *string   `json:"quiet_start,omitempty" db:"quiet_start"` // HH:MM, waktu lokal server
+	QuietEnd    *string   `json:"quiet_end,omitempty" db:"quiet_end"`
+	Enabled     bool      `json:"enabled" db:"enabled"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NotificationChannelRequest - Body create/update channel (update: field yang diisi saja)
+type NotificationChannelRequest struct {
+	Name        string    `json:"name" validate:"max=100"`
+	Type        string    `json:"type" validate:"oneof=webhook email"`
+	Target      string    `json:"target" validate:"max=1000"`
+	RouterTags  *[]string `json:"router_tags,omitempty" validate:"max=20"`
+	MinSeverity string    `json:"min_severity" validate:"oneof=warning error critical"` // default warning
+	QuietStart  *string   `json:"quiet_start,omitempty"`                                // "" = hapus jam tenang
+	QuietEnd    *string   `json:"quiet_end,omitempty"`
+	Enabled     *bool     `json:"enabled,omitempty"`
+}
+
+// MatchesTags - True jika channel menerima alert router dengan tag tsb. Channel tanpa tag
+// menerima semua alert; channel bertag hanya router yang punya salah satu tag-nya.
+func (c *NotificationChannel) MatchesTags(tags []string) bool {
+	if len(c.RouterTags) == 0 {
+		return true
+	}
+	for _, want := range c.RouterTags {
+		for _, tag := range tags {
+			if strings.EqualFold(want, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MeetsSeverity - True jika severity alert sama atau lebih parah dari severity minimum channel
+func (c *NotificationChannel) MeetsSeverity(severity string) bool {
+	rank := func(s string) int {
+		for i, v := range AlertSeverities {
+			if v == s {
+				return len(AlertSeverities) - i
+			}
+		}
+		return 0
+	}
+	return rank(severity) > 0 && rank(severity) >= rank(c.MinSeverity)
+}
+
+// InQuietHours - True jika now berada di jam tenang channel (jendela boleh lewat tengah malam,
+// mis. 22:00-07:00). Tanpa jam tenang selalu false.
+func (c *NotificationChannel) InQuietHours(now time.Time) bool {
+	if c.QuietStart == nil || c.QuietEnd == nil {
+		return false
+	}
+	start, err := time.Parse("15:04", *c.QuietStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", *c.QuietEnd)
+	if err != nil {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	startMin, endMin := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+
+	if startMin <= endMin {
+		return minute >= startMin && minute < endMin
+	}
+	return minute >= startMin || minute < endMin
+}
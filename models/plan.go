@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Plan - Paket layanan (kecepatan, burst, kuota) yang dipakai customer, queue & PPP secret
+type Plan struct {
+	ID             int       `json:"id" db:"id"`
+	Name           string    `json:"name" db:"name"`
+	RateLimit      string    `json:"rate_limit" db:"rate_limit"`                     // upload/download, mis. "10M/20M"
+	BurstLimit     *string   `json:"burst_limit,omitempty" db:"burst_limit"`         // mis. "15M/30M"
+	BurstThreshold *string   `json:"burst_threshold,omitempty" db:"burst_threshold"` // mis. "8M/16M"
+	BurstTime      *string   `json:"burst_time,omitempty" db:"burst_time"`           // mis. "16s/16s"
+	QuotaBytes     *uint64   `json:"quota_bytes,omitempty" db:"quota_bytes"`         // kuota bulanan, nil = tanpa kuota
+	PPPProfile     *string   `json:"ppp_profile,omitempty" db:"ppp_profile"`         // default "plan-<name>"
+	Description    *string   `json:"description,omitempty" db:"description"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ProfileName - Nama /ppp/profile yang dikelola untuk plan ini
+func (p *Plan) ProfileName() string {
+	if p.PPPProfile != nil && *p.PPPProfile != "" {
+		return *p.PPPProfile
+	}
+	return fmt.Sprintf("plan-%s", p.Name)
+}
+
+// PPPRateLimit - Format rate-limit /ppp/profile: "rx/tx [burst [threshold [time]]]"
+func (p *Plan) PPPRateLimit() string {
+	rateLimit := p.RateLimit
+	if p.BurstLimit != nil && *p.BurstLimit != "" {
+		rateLimit += " " + *p.BurstLimit
+		if p.BurstThreshold != nil && *p.BurstThreshold != "" {
+			rateLimit += " " + *p.BurstThreshold
+			if p.BurstTime != nil && *p.BurstTime != "" {
+				rateLimit += " " + *p.BurstTime
+			}
+		}
+	}
+	return rateLimit
+}
+
+type PlanCreateRequest struct {
+	Name           string  `json:"name" validate:"required,max=100"`
+	RateLimit      string  `json:"rate_limit" validate:"required,rate_limit"`
+	BurstLimit     *string `json:"burst_limit,omitempty" validate:"rate_limit"`
+	BurstThreshold *string `json:"burst_threshold,omitempty" validate:"rate_limit"`
+	BurstTime      *string `json:"burst_time,omitempty"`
+	QuotaBytes     *uint64 `json:"quota_bytes,omitempty"`
+	PPPProfile     *string `json:"ppp_profile,omitempty"`
+	Description    *string `json:"description,omitempty"`
+}
+
+type PlanUpdateRequest struct {
+	Name           *string `json:"name,omitempty" validate:"max=100"`
+	RateLimit      *string `json:"rate_limit,omitempty" validate:"rate_limit"`
+	BurstLimit     *string `json:"burst_limit,omitempty" validate:"rate_limit"`
+	BurstThreshold *string `json:"burst_threshold,omitempty" validate:"rate_limit"`
+	BurstTime      *string `json:"burst_time,omitempty"`
+	QuotaBytes     *uint64 `json:"quota_bytes,omitempty"`
+	PPPProfile     *string `json:"ppp_profile,omitempty"`
+	Description    *string `json:"description,omitempty"`
+}
+
+// PlanApplyResult - Hasil propagasi plan ke satu router
+type PlanApplyResult struct {
+	RouterID  int          `json:"router_id"`
+	Customers int          `json:"customers"`
+	Plan      *CommandPlan `json:"plan,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Scope laporan bulanan
+const (
+	ReportScopeRouter   = "router"
+	ReportScopeCustomer = "customer"
+)
+
+// Status laporan
+const (
+	ReportStatusReady  = "ready"
+	ReportStatusFailed = "failed"
+)
+
+// Report - Metadata laporan PDF bulanan (isi PDF diunduh terpisah)
+type Report struct {
+	ID         int       `json:"id" db:"id"`
+	Scope      string    `json:"scope" db:"scope"` // router, customer
+	TargetID   int       `json:"target_id" db:"target_id"`
+	TargetName string    `json:"target_name" db:"target_name"`
+	Period     time.Time `json:"period" db:"period"` // awal bulan
+	Status     string    `json:"status" db:"status"` // ready, failed
+	Error      *string   `json:"error,omitempty" db:"error"`
+	SizeBytes  int       `json:"size_bytes" db:"size_bytes"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ReportRequest - Body generate laporan on demand
+type ReportRequest struct {
+	Scope    string `json:"scope" validate:"required,oneof=router customer"`
+	TargetID int    `json:"target_id" validate:"required,min=1"` // router_id / customer_id
+	Month    string `json:"month" validate:"month"`              // YYYY-MM, default bulan lalu
+}
+
+// ReportFilter - Filter list laporan
+type ReportFilter struct {
+	Scope    string
+	TargetID int
+	Period   *time.Time
+}
+
+// QueueRateSample - Rata-rata rate simple queue dalam satu interval sampling usage
+type QueueRateSample struct {
+	UploadBps   uint64    `json:"upload_bps"`
+	DownloadBps uint64    `json:"download_bps"`
+	SampledAt   time.Time `json:"sampled_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Reseller - Sub-akun (mis. mitra ISP) yang memiliki sebagian router beserta customer di dalamnya
+type Reseller struct {
+	ID           int       `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	ContactName  *string   `json:"contact_name,omitempty" db:"contact_name"`
+	ContactPhone *string   `json:"contact_phone,omitempty" db:"contact_phone"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	RouterIDs    []int     `json:"router_ids" db:"-"`
+}
+
+// ResellerRequest - Body POST /api/resellers dan PUT /api/resellers/{id}
+type ResellerRequest struct {
+	Name         string  `json:"name" validate:"required,max=100"`
+	ContactName  *string `json:"contact_name,omitempty" validate:"max=100"`
+	ContactPhone *string `json:"contact_phone,omitempty" validate:"max=30"`
+}
+
+// ResellerRoutersRequest - Body PUT /api/resellers/{id}/routers (menggantikan semua router reseller)
+type ResellerRoutersRequest struct {
+	RouterIDs []int `json:"router_ids"`
+}
+
+// RouterUsageTotal - Total pemakaian queue satu router dalam rentang periode
+type RouterUsageTotal struct {
+	RouterID      int    `json:"router_id"`
+	RouterName    string `json:"router_name"`
+	Queues        int    `json:"queues"`
+	UploadBytes   uint64 `json:"upload_bytes"`
+	DownloadBytes uint64 `json:"download_bytes"`
+	TotalBytes    uint64 `json:"total_bytes"`
+}
+
+// ResellerUsage - Ringkasan pemakaian semua router milik reseller
+type ResellerUsage struct {
+	ResellerID    int                 `json:"reseller_id"`
+	Period        string              `json:"period"`
+	From          time.Time           `json:"from"`
+	To            time.Time           `json:"to"`
+	UploadBytes   uint64              `json:"upload_bytes"`
+	DownloadBytes uint64              `json:"download_bytes"`
+	TotalBytes    uint64              `json:"total_bytes"`
+	Routers       []*RouterUsageTotal `json:"routers"`
+}
@@ -0,0 +1,32 @@
+package models
+
+// StaticReservationRequest - Body POST /api/customers/{id}/reservation
+// Field kosong diambil dari data customer: address, queue_name (fallback nama customer) dan
+// rate limit plan customer.
+type StaticReservationRequest struct {
+	MacAddress string  `json:"mac_address" validate:"required,mac"`
+	Address    *string `json:"address,omitempty" validate:"ip"`
+	Server     *string `json:"server,omitempty" validate:"max=64"` // DHCP server lease, kosong = all
+	QueueName  *string `json:"queue_name,omitempty" validate:"max=100"`
+	RateLimit  *string `json:"rate_limit,omitempty" validate:"rate_limit"` // wajib jika customer tanpa plan
+}
+
+// StaticReservation - Satu customer IP tetap di router: static lease (MAC→IP), ARP statis di
+// interface subnet IP tsb dan simple queue dengan target IP/32
+type StaticReservation struct {
+	CustomerID int      `json:"customer_id"`
+	Comment    string   `json:"comment"`
+	Address    string   `json:"address"`
+	MacAddress string   `json:"mac_address"`
+	Server     string   `json:"server,omitempty"`
+	Interface  string   `json:"interface"` // diisi dari subnet /ip/address yang memuat address
+	QueueName  string   `json:"queue_name"`
+	Limits     []string `json:"-"` // parameter limit queue, mis. =max-limit=10M/20M
+}
+
+// StaticReservationResult - Hasil provisioning; Customer sudah memuat address & queue baru
+type StaticReservationResult struct {
+	Customer    *Customer          `json:"customer"`
+	Reservation *StaticReservation `json:"reservation"`
+	Plan        *CommandPlan       `json:"plan"`
+}
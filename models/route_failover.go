@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// RouteFailover - Pasangan route primary/backup (dst-address sama) yang diawasi watchdog
+type RouteFailover struct {
+	ID             int        `json:"id" db:"id"`
+	Name           string     `json:"name" db:"name"`
+	RouterID       int        `json:"router_id" db:"router_id"`
+	DstAddress     string     `json:"dst_address" db:"dst_address"`
+	PrimaryGateway string     `json:"primary_gateway" db:"primary_gateway"`
+	BackupGateway  string     `json:"backup_gateway" db:"backup_gateway"`
+	Notify         bool       `json:"notify" db:"notify"` // kirim juga ke webhook notifikasi
+	Enabled        bool       `json:"enabled" db:"enabled"`
+	State          string     `json:"state" db:"state"` // unknown, primary, backup, down
+	ActiveGateway  *string    `json:"active_gateway,omitempty" db:"active_gateway"`
+	LastChangeAt   *time.Time `json:"last_change_at,omitempty" db:"last_change_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Status route failover
+const (
+	RouteStateUnknown = "unknown"
+	RouteStatePrimary = "primary"
+	RouteStateBackup  = "backup" // route aktif bukan primary
+	RouteStateDown    = "down"   // tidak ada route aktif untuk dst-address
+)
+
+// RouteFailoverRequest - Body create/update route failover
+type RouteFailoverRequest struct {
+	Name           string `json:"name" validate:"max=100"`
+	RouterID       int    `json:"router_id" validate:"min=1"`
+	DstAddress     string `json:"dst_address" validate:"cidr"`
+	PrimaryGateway string `json:"primary_gateway" validate:"max=100"`
+	BackupGateway  string `json:"backup_gateway" validate:"max=100"`
+	Notify         *bool  `json:"notify"`
+	Enabled        *bool  `json:"enabled"`
+}
+
+// NetwatchEntry - Host yang dipantau /tool/netwatch (status up/down)
+type NetwatchEntry struct {
+	ID       string `json:"id"`
+	Host     string `json:"host"`
+	Status   string `json:"status"` // up, down, unknown
+	Since    string `json:"since,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	Disabled bool   `json:"disabled"`
+}
+
+// WANStatus - Ringkasan multi-WAN satu router: default route (aktif yang mana),
+// state route failover yang diawasi watchdog dan status netwatch
+type WANStatus struct {
+	RouterID      int              `json:"router_id"`
+	ActiveGateway *string          `json:"active_gateway,omitempty"` // gateway default route aktif
+	DefaultRoutes []*RouteEntry    `json:"default_routes"`
+	Failovers     []*RouteFailover `json:"failovers"`
+	Netwatch      []*NetwatchEntry `json:"netwatch"`
+	CheckedAt     time.Time        `json:"checked_at"`
+}
+
+// RouteEntry - Entry /ip/route
+type RouteEntry struct {
+	ID           string `json:"id"`
+	DstAddress   string `json:"dst_address"`
+	Gateway      string `json:"gateway"`
+	Distance     int    `json:"distance"`
+	CheckGateway string `json:"check_gateway,omitempty"`
+	RoutingTable string `json:"routing_table,omitempty"` // v6: routing-mark; kosong = main
+	PrefSrc      string `json:"pref_src,omitempty"`
+	ImmediateGW  string `json:"immediate_gw,omitempty"`
+	Comment      string `json:"comment,omitempty"`
+	Active       bool   `json:"active"`
+	Disabled     bool   `json:"disabled"`
+	Dynamic      bool   `json:"dynamic"` // connected, DHCP, PPP, protokol routing
+	Static       bool   `json:"static"`
+}
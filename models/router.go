@@ -2,6 +2,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -17,38 +18,98 @@ type Router struct {
 	Port        int       `json:"port" db:"port"`
 	Location    *string   `json:"location,omitempty" db:"location"`
 	Description *string   `json:"description,omitempty" db:"description"`
+	WANInterfaces *string `json:"wan_interfaces,omitempty" db:"wan_interfaces"` // comma-separated, dipakai top-talkers
+	Tags        *string   `json:"tags,omitempty" db:"tags"` // comma-separated, mis. "core,jakarta"; dipakai routing notifikasi alert
 	IsActive    bool      `json:"is_active" db:"is_active"`
+	IsVirtual   bool      `json:"is_virtual" db:"is_virtual"` // data di-generate simulator, tanpa koneksi RouterOS
+	AutoConnect bool      `json:"auto_connect" db:"auto_connect"` // false = hanya connect lewat /api/connections/connect
+	RouterContact
+	RouterDial
+	RouterJumpCredentials `json:"-"` // write-only, lihat JumpCredentialsSet
+	JumpCredentialsSet    bool `json:"jump_credentials_set" db:"-"` // jump_password / jump_private_key terisi
+	RouterTunnel
 	LastSeen    *time.Time `json:"last_seen,omitempty" db:"last_seen"`
-	Status      string    `json:"status" db:"status"` // online, offline, error
+	Status      string    `json:"status" db:"status"` // online, offline, error, suspended, rebooting
+	ActiveAddress *string `json:"active_address,omitempty" db:"active_address"` // alamat management koneksi terakhir
 	Version     *string   `json:"version,omitempty" db:"version"`
 	Uptime      *string   `json:"uptime,omitempty" db:"uptime"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	Health      *RouterHealth `json:"health,omitempty" db:"-"` // diisi handler list dari health scorer
 }
 
+// RouterContact - Metadata site untuk on-call (kontak, circuit, catatan bebas)
+type RouterContact struct {
+	ContactName   *string         `json:"contact_name,omitempty" db:"contact_name"`
+	ContactPhone  *string         `json:"contact_phone,omitempty" db:"contact_phone"`
+	CircuitID     *string         `json:"circuit_id,omitempty" db:"circuit_id"`
+	MonitoringURL *string         `json:"monitoring_url,omitempty" db:"monitoring_url"`
+	Notes         json.RawMessage `json:"notes,omitempty" db:"notes"` // JSON bebas
+}
+
+// RouterDial - Override cara layer men-dial router (kosong = default dari konfigurasi)
+type RouterDial struct {
+	SourceAddress *string `json:"source_address,omitempty" db:"source_address" validate:"max=45"` // IP lokal sumber koneksi
+	BindInterface *string `json:"bind_interface,omitempty" db:"bind_interface" validate:"max=64"` // interface / device VRF (Linux)
+
+	// Jump host untuk router di belakang NAT / jaringan management terisolasi
+	JumpType       *string `json:"jump_type,omitempty" db:"jump_type" validate:"oneof=none socks5 ssh"`
+	JumpAddress    *string `json:"jump_address,omitempty" db:"jump_address" validate:"max=255"` // host:port proxy / server SSH
+	JumpUsername   *string `json:"jump_username,omitempty" db:"jump_username" validate:"max=100"`
+	JumpHostKey    *string `json:"jump_host_key,omitempty" db:"jump_host_key"` // format authorized_keys; kosong = tidak diverifikasi
+}
+
+// RouterJumpCredentials - Kredensial jump host. Hanya diterima di request create/update; tidak
+// pernah dikirim di response router (seperti secret webhook)
+type RouterJumpCredentials struct {
+	JumpPassword   *string `json:"jump_password,omitempty" db:"jump_password"`
+	JumpPrivateKey *string `json:"jump_private_key,omitempty" db:"jump_private_key"` // PEM, khusus ssh
+}
+
+// Jenis jump host router
+const (
+	JumpNone   = "none"
+	JumpSOCKS5 = "socks5"
+	JumpSSH    = "ssh"
+)
+
 type RouterCreateRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Hostname    string  `json:"hostname" binding:"required"`
-	Username    string  `json:"username" binding:"required"`
-	Password    string  `json:"password" binding:"required"`
+	Name        string  `json:"name" validate:"required,max=100"`
+	Hostname    string  `json:"hostname" validate:"required,host"`
+	Username    string  `json:"username" validate:"required,max=100"`
+	Password    string  `json:"password" validate:"required"`
 	Keepalive   *bool   `json:"keepalive,omitempty"`
-	Timeout     *int    `json:"timeout,omitempty"`
-	Port        *int    `json:"port,omitempty"`
+	Timeout     *int    `json:"timeout,omitempty" validate:"min=1000,max=3600000"`
+	Port        *int    `json:"port,omitempty" validate:"min=1,max=65535"`
 	Location    *string `json:"location,omitempty"`
 	Description *string `json:"description,omitempty"`
+	WANInterfaces *string `json:"wan_interfaces,omitempty"`
+	Tags        *string `json:"tags,omitempty" validate:"max=255"`
+	IsVirtual   *bool   `json:"is_virtual,omitempty"`
+	AutoConnect *bool   `json:"auto_connect,omitempty"`
+	RouterContact
+	RouterDial
+	RouterJumpCredentials
 }
 
 type RouterUpdateRequest struct {
-	Name        *string `json:"name,omitempty"`
-	Hostname    *string `json:"hostname,omitempty"`
-	Username    *string `json:"username,omitempty"`
+	Name        *string `json:"name,omitempty" validate:"max=100"`
+	Hostname    *string `json:"hostname,omitempty" validate:"host"`
+	Username    *string `json:"username,omitempty" validate:"max=100"`
 	Password    *string `json:"password,omitempty"`
 	Keepalive   *bool   `json:"keepalive,omitempty"`
-	Timeout     *int    `json:"timeout,omitempty"`
-	Port        *int    `json:"port,omitempty"`
+	Timeout     *int    `json:"timeout,omitempty" validate:"min=1000,max=3600000"`
+	Port        *int    `json:"port,omitempty" validate:"min=1,max=65535"`
 	Location    *string `json:"location,omitempty"`
 	Description *string `json:"description,omitempty"`
+	WANInterfaces *string `json:"wan_interfaces,omitempty"`
+	Tags        *string `json:"tags,omitempty" validate:"max=255"`
 	IsActive    *bool   `json:"is_active,omitempty"`
+	IsVirtual   *bool   `json:"is_virtual,omitempty"`
+	AutoConnect *bool   `json:"auto_connect,omitempty"`
+	RouterContact
+	RouterDial
+	RouterJumpCredentials
 }
 
 type RouterStatusUpdate struct {
@@ -57,3 +118,39 @@ type RouterStatusUpdate struct {
 	Uptime   *string    `json:"uptime,omitempty"`
 	LastSeen *time.Time `json:"last_seen,omitempty"`
 }
+
+// RouterAddress - Alamat management tambahan (mis. out-of-band); hostname router selalu dicoba
+// lebih dulu, lalu alamat ini urut priority
+type RouterAddress struct {
+	ID        int       `json:"id" db:"id"`
+	RouterID  int       `json:"router_id" db:"router_id"`
+	Address   string    `json:"address" db:"address"`
+	Label     string    `json:"label" db:"label"`
+	Priority  int       `json:"priority" db:"priority"` // kecil = dicoba lebih dulu
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RouterAddressRequest - Body POST /api/routers/{id}/addresses
+type RouterAddressRequest struct {
+	Address  string  `json:"address" validate:"required,host"`
+	Label    *string `json:"label,omitempty" validate:"max=50"`
+	Priority *int    `json:"priority,omitempty" validate:"min=0,max=1000"`
+}
+
+// MonitoredInterfacesRequest - Body PUT /api/routers/{id}/monitored-interfaces (mengganti seluruh daftar)
+type MonitoredInterfacesRequest struct {
+	Interfaces      []string `json:"interfaces" validate:"max=50"`
+	IntervalSeconds *int     `json:"interval_seconds,omitempty" validate:"min=5,max=3600"` // default 30
+}
+
+// RouterDeleteImpact - Hal yang ikut terdampak saat router dihapus (preview sebelum konfirmasi)
+type RouterDeleteImpact struct {
+	RouterID        int              `json:"router_id"`
+	Connected       bool             `json:"connected"`
+	ActiveStreams   int              `json:"active_streams"`   // WebSocket monitor yang akan ditutup
+	TrafficSamplers int              `json:"traffic_samplers"` // sampler traffic history yang dihentikan
+	MonitoredPaths  int              `json:"monitored_paths"`  // path mesh sebagai source / target
+	QueueQuotas     int              `json:"queue_quotas"`
+	Customers       int              `json:"customers"`
+	StoredRows      map[string]int64 `json:"stored_rows"` // tabel -> jumlah baris yang ikut terhapus
+}
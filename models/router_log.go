@@ -0,0 +1,16 @@
+package models
+
+// RouterLogEntry - Entry memory log router (/log/print)
+type RouterLogEntry struct {
+	ID      string   `json:"id"`
+	Time    string   `json:"time"` // format router, mis. "jan/02 10:11:12" atau "10:11:12" untuk hari ini
+	Topics  []string `json:"topics"`
+	Message string   `json:"message"`
+}
+
+// RouterLogs - Tail log router
+type RouterLogs struct {
+	RouterID int               `json:"router_id"`
+	Topics   []string          `json:"topics,omitempty"` // filter yang dipakai, kosong = semua
+	Entries  []*RouterLogEntry `json:"entries"`          // urut lama ke baru
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// OSPFNeighbor - Neighbor OSPF (/routing/ospf/neighbor)
+type OSPFNeighbor struct {
+	Instance     string `json:"instance"`
+	Area         string `json:"area,omitempty"`      // v7
+	Interface    string `json:"interface,omitempty"` // v6
+	Address      string `json:"address"`
+	NeighborID   string `json:"neighbor_id"` // router-id OSPF neighbor
+	State        string `json:"state"`
+	StateChanges int    `json:"state_changes"`
+	Adjacency    string `json:"adjacency,omitempty"` // lama adjacency terbentuk
+}
+
+// Up - Adjacency penuh
+func (n *OSPFNeighbor) Up() bool {
+	return n.State == "Full"
+}
+
+// OSPFLSDBSummary - Jumlah LSA per type dan area (/routing/ospf/lsa)
+type OSPFLSDBSummary struct {
+	Total  int            `json:"total"`
+	ByType map[string]int `json:"by_type"`
+	ByArea map[string]int `json:"by_area"`
+}
+
+// OSPFStatus - Neighbor + ringkasan LSDB
+type OSPFStatus struct {
+	Neighbors []*OSPFNeighbor  `json:"neighbors"`
+	LSDB      *OSPFLSDBSummary `json:"lsdb"`
+}
+
+// BGPPeer - Peer BGP (v6 /routing/bgp/peer, v7 /routing/bgp/connection + session)
+type BGPPeer struct {
+	Name          string `json:"name"`
+	RemoteAddress string `json:"remote_address"`
+	RemoteAS      string `json:"remote_as"`
+	State         string `json:"state"`
+	Established   bool   `json:"established"`
+	PrefixCount   int    `json:"prefix_count"`
+	Uptime        string `json:"uptime,omitempty"`
+	Disabled      bool   `json:"disabled"`
+}
+
+// RoutingStatus - Status protokol routing satu router; section yang gagal dibaca masuk Errors
+type RoutingStatus struct {
+	RouterID    int               `json:"router_id"`
+	OSPF        *OSPFStatus       `json:"ospf,omitempty"`
+	BGP         []*BGPPeer        `json:"bgp,omitempty"`
+	Errors      map[string]string `json:"errors,omitempty"`
+	CollectedAt time.Time         `json:"collected_at"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// SessionFilter - Pemilih sesi aktif PPP/hotspot yang akan diputus; semua field yang diisi harus cocok
+type SessionFilter struct {
+	ID          string // .id entry sesi aktif
+	User        string // nama user / PPP secret
+	Profile     string // profile PPP secret / hotspot user
+	AddressList string // address-list firewall yang memuat IP sesi
+}
+
+// Empty - True jika tidak ada pemilih (kick semua sesi tidak diizinkan)
+func (f SessionFilter) Empty() bool {
+	return f.ID == "" && f.User == "" && f.Profile == "" && f.AddressList == ""
+}
+
+// ActivePPPSession - Snapshot satu entry /ppp/active beserta counter interface dinamisnya
+type ActivePPPSession struct {
+	ID         string        `json:"id"` // .id entry /ppp/active
+	User       string        `json:"user"`
+	Service    string        `json:"service"`
+	CallerID   string        `json:"caller_id"`
+	Address    string        `json:"address"`
+	Uptime     time.Duration `json:"-"`
+	UptimeText string        `json:"uptime"`    // format RouterOS, mis. 1d2h3m4s
+	BytesIn    uint64        `json:"bytes_in"`  // dari pelanggan (rx interface)
+	BytesOut   uint64        `json:"bytes_out"` // ke pelanggan (tx interface)
+}
+
+// PPPSession - Riwayat satu sesi PPP (start/stop) hasil polling sesi aktif
+type PPPSession struct {
+	ID         int64      `json:"id" db:"id"`
+	RouterID   int        `json:"router_id" db:"router_id"`
+	SessionRef string     `json:"-" db:"session_ref"` // .id /ppp/active selama sesi berjalan
+	Username   string     `json:"username" db:"username"`
+	Service    string     `json:"service" db:"service"`
+	CallerID   string     `json:"caller_id" db:"caller_id"`
+	Address    string     `json:"address" db:"address"`
+	BytesIn    uint64     `json:"bytes_in" db:"bytes_in"`
+	BytesOut   uint64     `json:"bytes_out" db:"bytes_out"`
+	StartedAt  time.Time  `json:"started_at" db:"started_at"`
+	EndedAt    *time.Time `json:"ended_at,omitempty" db:"ended_at"` // nil = masih aktif
+	LastSeenAt time.Time  `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// PPPSessionFilter - Filter riwayat sesi PPP; From/To memilih sesi yang overlap dengan rentang
+type PPPSessionFilter struct {
+	RouterID  *int
+	RouterIDs []int // scope akses (nil = tidak dibatasi)
+	User      string
+	Address   string
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+}
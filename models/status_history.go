@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// RouterStatusChange - Satu transisi status router (online, offline, error, suspended)
+type RouterStatusChange struct {
+	ID         int64     `json:"id"`
+	RouterID   int       `json:"router_id"`
+	FromStatus *string   `json:"from_status,omitempty"`
+	Status     string    `json:"status"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// StatusPeriod - Rentang waktu router berada di satu status (dipotong ke rentang query)
+type StatusPeriod struct {
+	Status          string    `json:"status"`
+	StartedAt       time.Time `json:"started_at"`
+	EndedAt         time.Time `json:"ended_at"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	Ongoing         bool      `json:"ongoing,omitempty"` // status saat ini, belum berakhir
+}
+
+// RouterStatusHistory - Timeline status router beserta total durasi per status
+type RouterStatusHistory struct {
+	RouterID    int                   `json:"router_id"`
+	From        time.Time             `json:"from"`
+	To          time.Time             `json:"to"`
+	Periods     []StatusPeriod        `json:"periods"`
+	Durations   map[string]int64      `json:"durations"` // detik per status
+	Transitions []*RouterStatusChange `json:"transitions"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Action jadwal task
+const (
+	TaskScheduleBackup    = "backup"     // backup / export router, disimpan terenkripsi seperti /api/backups
+	TaskScheduleReboot    = "reboot"     // /system/reboot
+	TaskScheduleQueueSync = "queue_sync" // terapkan ulang plan ke queue / secret semua customer router
+)
+
+// Hasil run terakhir jadwal task
+const (
+	TaskScheduleOK     = "ok"
+	TaskScheduleFailed = "failed"
+)
+
+// TaskSchedule - Aksi berulang ke satu router yang dijalankan oleh layer sendiri berdasarkan
+// ekspresi cron, mis. backup router 1 tiap malam ("0 2 * * *") atau reboot mingguan ("0 4 * * sun")
+type TaskSchedule struct {
+	ID         int            `json:"id" db:"id"`
+	Name       string         `json:"name" db:"name"`
+	Action     string         `json:"action" db:"action"` // backup, reboot, queue_sync
+	RouterID   int            `json:"router_id" db:"router_id"`
+	Cron       string         `json:"cron" db:"cron"`
+	Backup     *BackupRequest `json:"backup,omitempty" db:"params"` // hanya action backup
+	Enabled    bool           `json:"enabled" db:"enabled"`
+	NextRunAt  *time.Time     `json:"next_run_at,omitempty" db:"next_run_at"` // nil = nonaktif / tidak ada waktu cocok
+	LastRunAt  *time.Time     `json:"last_run_at,omitempty" db:"last_run_at"`
+	LastStatus *string        `json:"last_status,omitempty" db:"last_status"` // ok, failed
+	LastOutput *string        `json:"last_output,omitempty" db:"last_output"`
+	LastError  *string        `json:"last_error,omitempty" db:"last_error"`
+	CreatedBy  string         `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// TaskScheduleRequest - Body create/update jadwal task (update: field yang diisi saja)
+type TaskScheduleRequest struct {
+	Name     string         `json:"name" validate:"max=100"`
+	Action   string         `json:"action" validate:"oneof=backup reboot queue_sync"`
+	RouterID *int           `json:"router_id,omitempty" validate:"min=1"`
+	Cron     string         `json:"cron" validate:"cron"`
+	Backup   *BackupRequest `json:"backup,omitempty"` // action backup, default {"kind":"export"}
+	Enabled  *bool          `json:"enabled,omitempty"`
+}
+
+// TaskScheduleRun - Hasil satu kali eksekusi jadwal (run terjadwal atau run-now)
+type TaskScheduleRun struct {
+	ScheduleID int       `json:"schedule_id"`
+	Status     string    `json:"status"`
+	Output     string    `json:"output,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
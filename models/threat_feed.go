@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// ThreatFeed - Daftar IP blokir eksternal (mis. Spamhaus DROP) yang diambil berkala lalu
+// disinkronkan ke address-list firewall router terpilih (tabel threat_feeds)
+type ThreatFeed struct {
+	ID              int        `json:"id" db:"id"`
+	Name            string     `json:"name" db:"name"`
+	URL             string     `json:"url" db:"url"`                   // teks satu IP/CIDR per baris, komentar ; atau #
+	AddressList     string     `json:"address_list" db:"address_list"` // address-list tujuan di router
+	RouterIDs       []int      `json:"router_ids" db:"router_ids"`
+	IntervalMinutes int        `json:"interval_minutes" db:"interval_minutes"`
+	MaxEntries      int        `json:"max_entries" db:"max_entries"` // feed lebih besar tidak disinkronkan
+	Enabled         bool       `json:"enabled" db:"enabled"`
+	LastSyncedAt    *time.Time `json:"last_synced_at,omitempty" db:"last_synced_at"`
+	LastEntryCount  int        `json:"last_entry_count" db:"last_entry_count"`
+	LastError       *string    `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Due - True jika feed aktif dan interval sejak sync terakhir sudah lewat
+func (f *ThreatFeed) Due(now time.Time) bool {
+	if !f.Enabled {
+		return false
+	}
+	return f.LastSyncedAt == nil || now.Sub(*f.LastSyncedAt) >= time.Duration(f.IntervalMinutes)*time.Minute
+}
+
+// ThreatFeedRequest - Body create/update feed (update: field yang diisi saja)
+type ThreatFeedRequest struct {
+	Name            string `json:"name" validate:"max=100"`
+	URL             string `json:"url" validate:"url,max=500"`
+	AddressList     string `json:"address_list" validate:"max=64"`
+	RouterIDs       *[]int `json:"router_ids,omitempty" validate:"max=100"`
+	IntervalMinutes *int   `json:"interval_minutes,omitempty" validate:"min=5,max=10080"` // default 60
+	MaxEntries      *int   `json:"max_entries,omitempty" validate:"min=1"`                // default & batas: THREAT_FEED_MAX_ENTRIES
+	Enabled         *bool  `json:"enabled,omitempty"`
+}
+
+// ThreatFeedRouterResult - Hasil sinkronisasi feed ke satu router
+type ThreatFeedRouterResult struct {
+	RouterID  int          `json:"router_id"`
+	Added     int          `json:"added"`
+	Removed   int          `json:"removed"`
+	Unchanged int          `json:"unchanged"`
+	Plan      *CommandPlan `json:"plan,omitempty"` // hanya dry run
+	Error     string       `json:"error,omitempty"`
+}
+
+// ThreatFeedSyncResult - Hasil satu kali sinkronisasi feed
+type ThreatFeedSyncResult struct {
+	FeedID   int                       `json:"feed_id"`
+	Entries  int                       `json:"entries"` // IP/CIDR unik dari feed
+	Skipped  int                       `json:"skipped"` // baris tidak valid atau IPv6
+	DryRun   bool                      `json:"dry_run"`
+	Routers  []*ThreatFeedRouterResult `json:"routers"`
+	SyncedAt time.Time                 `json:"synced_at"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Neighbor - Perangkat tetangga hasil discovery (/ip/neighbor: MNDP, CDP, LLDP)
+type Neighbor struct {
+	Interface         string `json:"interface"`
+	Address           string `json:"address,omitempty"`
+	MACAddress        string `json:"mac_address,omitempty"`
+	Identity          string `json:"identity,omitempty"`
+	Platform          string `json:"platform,omitempty"`
+	Board             string `json:"board,omitempty"`
+	Version           string `json:"version,omitempty"`
+	NeighborInterface string `json:"neighbor_interface,omitempty"` // port di sisi tetangga (interface-name)
+	DiscoveredBy      string `json:"discovered_by,omitempty"`      // v7: mndp, cdp, lldp
+}
+
+// TopologyLink - Link router -> tetangga yang tersimpan (tabel topology_links)
+type TopologyLink struct {
+	ID                int       `json:"id" db:"id"`
+	RouterID          int       `json:"router_id" db:"router_id"`
+	Interface         string    `json:"interface" db:"interface"`
+	NeighborMAC       string    `json:"neighbor_mac" db:"neighbor_mac"`
+	NeighborIdentity  string    `json:"neighbor_identity" db:"neighbor_identity"`
+	NeighborAddress   string    `json:"neighbor_address" db:"neighbor_address"`
+	NeighborInterface string    `json:"neighbor_interface" db:"neighbor_interface"`
+	NeighborRouterID  *int      `json:"neighbor_router_id,omitempty" db:"neighbor_router_id"` // tetangga = router terkelola
+	Platform          string    `json:"platform" db:"platform"`
+	Board             string    `json:"board" db:"board"`
+	Version           string    `json:"version" db:"version"`
+	DiscoveredBy      string    `json:"discovered_by" db:"discovered_by"`
+	Active            bool      `json:"active" db:"active"`
+	FirstSeen         time.Time `json:"first_seen" db:"first_seen"`
+	LastSeen          time.Time `json:"last_seen" db:"last_seen"`
+	ChangedAt         time.Time `json:"changed_at" db:"changed_at"` // terakhir muncul / hilang
+}
+
+// Jenis node topologi
+const (
+	TopologyNodeRouter = "router" // router terkelola
+	TopologyNodeDevice = "device" // tetangga di luar layer (switch, AP, CPE, ...)
+)
+
+// TopologyNode - Node graf peta jaringan
+type TopologyNode struct {
+	ID       string `json:"id"` // router-<id> / mac-<mac> / device-<identity>
+	Type     string `json:"type"`
+	RouterID *int   `json:"router_id,omitempty"`
+	Label    string `json:"label"`
+	Address  string `json:"address,omitempty"`
+	MAC      string `json:"mac_address,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	Board    string `json:"board,omitempty"`
+	Status   string `json:"status,omitempty"` // status koneksi untuk node router
+}
+
+// TopologyEdge - Link antar node; link yang terlihat dari kedua sisi digabung
+type TopologyEdge struct {
+	Source          string    `json:"source"`
+	Target          string    `json:"target"`
+	SourceInterface string    `json:"source_interface"`
+	TargetInterface string    `json:"target_interface,omitempty"`
+	DiscoveredBy    string    `json:"discovered_by,omitempty"`
+	Bidirectional   bool      `json:"bidirectional"` // terlihat dari kedua router
+	Active          bool      `json:"active"`
+	LastSeen        time.Time `json:"last_seen"`
+}
+
+// Topology - Graf nodes + links untuk render peta jaringan
+type Topology struct {
+	Nodes       []*TopologyNode `json:"nodes"`
+	Links       []*TopologyEdge `json:"links"`
+	GeneratedAt time.Time       `json:"generated_at"`
+}
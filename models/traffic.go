@@ -0,0 +1,167 @@
+package models
+
+import "time"
+
+// TrafficSample - Satu titik history traffic interface
+type TrafficSample struct {
+	RouterID  int       `json:"router_id" db:"router_id"`
+	Interface string    `json:"interface" db:"interface"`
+	RxBps     int64     `json:"rx_bps" db:"rx_bps"`
+	TxBps     int64     `json:"tx_bps" db:"tx_bps"`
+	RxBytes   int64     `json:"rx_bytes" db:"rx_bytes"`
+	TxBytes   int64     `json:"tx_bytes" db:"tx_bytes"`
+	RxErrors  int64     `json:"rx_errors" db:"rx_errors"` // counter kumulatif seperti rx/tx bytes
+	TxErrors  int64     `json:"tx_errors" db:"tx_errors"`
+	RxDrops   int64     `json:"rx_drops" db:"rx_drops"`
+	TxDrops   int64     `json:"tx_drops" db:"tx_drops"`
+	FCSErrors int64     `json:"fcs_errors" db:"fcs_errors"`
+	SampledAt time.Time `json:"sampled_at" db:"sampled_at"`
+}
+
+// HourlyTraffic - Rata-rata traffic per jam dari history
+type HourlyTraffic struct {
+	Hour  time.Time `json:"hour"`
+	RxBps float64   `json:"rx_bps"`
+	TxBps float64   `json:"tx_bps"`
+}
+
+// SampledInterface - Pasangan router/interface yang punya history
+type SampledInterface struct {
+	RouterID  int    `json:"router_id"`
+	Interface string `json:"interface"`
+}
+
+// SamplerInfo - Status background sampler satu interface
+type SamplerInfo struct {
+	RouterID        int        `json:"router_id"`
+	Interface       string     `json:"interface"`
+	IntervalSeconds int        `json:"interval_seconds"`
+	Monitored       bool       `json:"monitored"` // default monitored interface router (warm standby)
+	StartedAt       time.Time  `json:"started_at"`
+	LastSampleAt    *time.Time `json:"last_sample_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	Samples         int        `json:"samples"`
+}
+
+// TopTalker - Rata-rata traffic satu alamat (hasil torch atau agregasi bucket)
+type TopTalker struct {
+	Address   string     `json:"address"`
+	Direction string     `json:"direction"` // src, dst
+	Interface string     `json:"interface,omitempty"`
+	RxBps     float64    `json:"rx_bps"`
+	TxBps     float64    `json:"tx_bps"`
+	Samples   int        `json:"samples,omitempty"`
+	FirstSeen *time.Time `json:"first_seen,omitempty"`
+	LastSeen  *time.Time `json:"last_seen,omitempty"`
+}
+
+// TorchResult - Ringkasan satu sesi /tool/torch per alamat sumber & tujuan
+type TorchResult struct {
+	RouterID     int          `json:"router_id"`
+	Interface    string       `json:"interface"`
+	Duration     int          `json:"duration_seconds"`
+	Sources      []*TopTalker `json:"sources"`
+	Destinations []*TopTalker `json:"destinations"`
+}
+
+// TopTalkerFilter - Filter query top-talkers historis
+type TopTalkerFilter struct {
+	RouterID  int
+	Interface string
+	Direction string
+	Address   string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+// VLANInterface - Interface VLAN di atas satu parent
+type VLANInterface struct {
+	Name     string `json:"name"`
+	VLANID   int    `json:"vlan_id"`
+	Parent   string `json:"parent"`
+	Running  bool   `json:"running"`
+	Disabled bool   `json:"disabled"`
+}
+
+// VLANTraffic - Rate satu VLAN beserta porsinya terhadap parent
+type VLANTraffic struct {
+	Interface string  `json:"interface"`
+	VLANID    int     `json:"vlan_id"`
+	Running   bool    `json:"running"`
+	RxBps     int64   `json:"rx_bps"`
+	TxBps     int64   `json:"tx_bps"`
+	RxShare   float64 `json:"rx_share"` // 0-1 dari rx parent
+	TxShare   float64 `json:"tx_share"`
+}
+
+// VLANBreakdown - Traffic parent dipecah per VLAN (urut rx terbesar), sisanya untagged
+type VLANBreakdown struct {
+	RouterID      int            `json:"router_id"`
+	Parent        string         `json:"parent"`
+	RxBps         int64          `json:"rx_bps"`
+	TxBps         int64          `json:"tx_bps"`
+	VLANs         []*VLANTraffic `json:"vlans"`
+	UntaggedRxBps int64          `json:"untagged_rx_bps"` // parent dikurangi total VLAN (native + overhead)
+	UntaggedTxBps int64          `json:"untagged_tx_bps"`
+	Timestamp     time.Time      `json:"timestamp"`
+}
+
+// Jenis interface agregat
+const (
+	AggregateBonding = "bonding"
+	AggregateBridge  = "bridge"
+)
+
+// AggregateInterface - Bonding / bridge beserta interface member-nya
+type AggregateInterface struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`    // bonding | bridge
+	Members []string `json:"members"` // bonding: slaves, bridge: port yang tidak disabled
+}
+
+// AggregateMemberTraffic - Rate satu member beserta porsinya terhadap total member
+type AggregateMemberTraffic struct {
+	Interface string  `json:"interface"`
+	RxBps     int64   `json:"rx_bps"`
+	TxBps     int64   `json:"tx_bps"`
+	RxShare   float64 `json:"rx_share"` // 0-1 dari total rx member
+	TxShare   float64 `json:"tx_share"`
+}
+
+// AggregateTraffic - Traffic bonding/bridge sebagai satu series logis: jumlah rate semua member
+// (termasuk traffic yang di-switch hardware dan tidak terlihat di master), urut rx terbesar
+type AggregateTraffic struct {
+	RouterID    int                       `json:"router_id"`
+	Interface   string                    `json:"interface"`
+	Kind        string                    `json:"kind"`
+	RxBps       int64                     `json:"rx_bps"` // total member
+	TxBps       int64                     `json:"tx_bps"`
+	MasterRxBps int64                     `json:"master_rx_bps"` // rate yang terlihat di interface master
+	MasterTxBps int64                     `json:"master_tx_bps"`
+	Members     []*AggregateMemberTraffic `json:"members"`
+	Timestamp   time.Time                 `json:"timestamp"`
+}
+
+// TrafficComparison - History beberapa interface di grid waktu yang sama (bucket rata-rata)
+// untuk membandingkan mis. uplink redundan; nilai nil = tidak ada sample di bucket tsb
+type TrafficComparison struct {
+	From        time.Time                  `json:"from"`
+	To          time.Time                  `json:"to"`
+	StepSeconds int                        `json:"step_seconds"`
+	Timestamps  []time.Time                `json:"timestamps"` // awal tiap bucket
+	Series      []*TrafficComparisonSeries `json:"series"`
+}
+
+// TrafficComparisonSeries - Satu router/interface, sejajar dengan TrafficComparison.Timestamps
+type TrafficComparisonSeries struct {
+	RouterID  int        `json:"router_id"`
+	Interface string     `json:"interface"`
+	RxBps     []*float64 `json:"rx_bps"`
+	TxBps     []*float64 `json:"tx_bps"`
+	Samples   int        `json:"samples"`
+	AvgRxBps  float64    `json:"avg_rx_bps"`
+	AvgTxBps  float64    `json:"avg_tx_bps"`
+	MaxRxBps  int64      `json:"max_rx_bps"`
+	MaxTxBps  int64      `json:"max_tx_bps"`
+}
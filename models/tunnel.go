@@ -0,0 +1,36 @@
+package models
+
+// Jenis tunnel balik router ke concentrator
+const (
+	TunnelWireGuard = "wireguard"
+	TunnelPPP       = "ppp" // SSTP / L2TP / OVPN, alamat dari /ppp/active
+)
+
+// RouterTunnel - Router di belakang CGNAT yang dial keluar ke concentrator (router lain yang
+// dikelola layer); alamat tunnel dipelajari dari concentrator dan dipakai sebagai hostname
+type RouterTunnel struct {
+	TunnelConcentratorID *int    `json:"tunnel_concentrator_id,omitempty" db:"tunnel_concentrator_id"`
+	TunnelType           *string `json:"tunnel_type,omitempty" db:"tunnel_type"`
+	TunnelPeer           *string `json:"tunnel_peer,omitempty" db:"tunnel_peer"` // public key WireGuard / nama secret PPP
+}
+
+// TunnelRegisterRequest - Body POST /api/routers/{id}/tunnel
+type TunnelRegisterRequest struct {
+	ConcentratorRouterID int     `json:"concentrator_router_id" validate:"required"`
+	Type                 string  `json:"type" validate:"required,oneof=wireguard ppp"`
+	Interface            string  `json:"interface,omitempty"`                   // wireguard: interface di concentrator
+	Endpoint             *string `json:"endpoint,omitempty" validate:"host"`    // wireguard: default hostname concentrator
+	PPPName              string  `json:"ppp_name,omitempty" validate:"max=100"` // ppp: nama secret yang dipakai router
+}
+
+// TunnelRegistration - Hasil registrasi; untuk WireGuard berisi script yang dijalankan di router
+// (private key hanya ada di response ini)
+type TunnelRegistration struct {
+	RouterID       int                  `json:"router_id"`
+	ConcentratorID int                  `json:"concentrator_router_id"`
+	Type           string               `json:"type"`
+	Peer           string               `json:"peer"`
+	Address        string               `json:"address,omitempty"` // tunnel IP yang dialokasikan (wireguard)
+	WireGuard      *WireGuardPeerResult `json:"wireguard,omitempty"`
+	RouterScript   []string             `json:"router_script,omitempty"`
+}
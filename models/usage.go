@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// QueueStats - Counter byte simple queue saat ini (upload/download dari sisi target)
+type QueueStats struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Target        string `json:"target"`
+	UploadBytes   uint64 `json:"upload_bytes"`
+	DownloadBytes uint64 `json:"download_bytes"`
+}
+
+// QueueUsage - Total pemakaian satu queue dalam satu periode (day/month)
+type QueueUsage struct {
+	RouterID      int       `json:"router_id" db:"router_id"`
+	QueueName     string    `json:"queue_name" db:"queue_name"`
+	Period        string    `json:"period" db:"period"` // day, month
+	PeriodStart   time.Time `json:"period_start" db:"period_start"`
+	UploadBytes   uint64    `json:"upload_bytes" db:"upload_bytes"`
+	DownloadBytes uint64    `json:"download_bytes" db:"download_bytes"`
+	TotalBytes    uint64    `json:"total_bytes"`
+	QuotaBytes    *uint64   `json:"quota_bytes,omitempty"` // hanya untuk period month
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// QueueQuota - Kuota bulanan (upload+download) untuk satu queue beserta aksi enforcement
+type QueueQuota struct {
+	ID                int        `json:"id" db:"id"`
+	RouterID          int        `json:"router_id" db:"router_id"`
+	QueueName         string     `json:"queue_name" db:"queue_name"`
+	MonthlyQuotaBytes uint64     `json:"monthly_quota_bytes" db:"monthly_quota_bytes"`
+	Action            string     `json:"action" db:"action"`                                   // alert, throttle, address_list
+	ThrottleLimit     *string    `json:"throttle_limit,omitempty" db:"throttle_limit"`         // max-limit saat throttle, mis. "1M/2M"
+	AddressList       *string    `json:"address_list,omitempty" db:"address_list"`             // default over-quota
+	AlertedPeriod     *time.Time `json:"alerted_period,omitempty" db:"alerted_period"`         // bulan terakhir alert dikirim
+	EnforcedPeriod    *time.Time `json:"enforced_period,omitempty" db:"enforced_period"`       // bulan enforcement aktif
+	OriginalMaxLimit  *string    `json:"original_max_limit,omitempty" db:"original_max_limit"` // max-limit sebelum throttle
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Aksi enforcement kuota
+const (
+	QuotaActionAlert       = "alert"
+	QuotaActionThrottle    = "throttle"
+	QuotaActionAddressList = "address_list"
+)
+
+// QuotaStatus - Kuota beserta pemakaian bulan berjalan
+type QuotaStatus struct {
+	QueueQuota
+	UsedBytes uint64 `json:"used_bytes"`
+}
+
+// UsageFilter - Filter query pemakaian queue
+type UsageFilter struct {
+	RouterID  int
+	QueueName string
+	Period    string
+	From      time.Time
+	To        time.Time
+}
@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+// Role user API
+const (
+	RoleAdmin    = "admin"    // akses penuh + kelola user
+	RoleOperator = "operator" // terbatas pada router/interface di user_scopes
+	RoleReseller = "reseller" // router milik reseller + router/interface di user_scopes
+)
+
+// User - Akun pemakai API (dashboard, NOC, integrasi)
+type User struct {
+	ID         int         `json:"id" db:"id"`
+	Username   string      `json:"username" db:"username"`
+	Role       string      `json:"role" db:"role"`
+	ResellerID *int        `json:"reseller_id,omitempty" db:"reseller_id"`
+	TOTP       bool        `json:"totp_enabled" db:"totp_enabled"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+	Scopes     []UserScope `json:"scopes,omitempty" db:"-"`
+
+	// Allow-list IP dari token / sesi yang dipakai request (bukan properti akun)
+	AllowedCIDRs []string `json:"-" db:"-"`
+}
+
+// UserScope - Router yang boleh diakses user; Interfaces kosong = semua interface router
+type UserScope struct {
+	RouterID   int      `json:"router_id"`
+	Interfaces []string `json:"interfaces,omitempty"`
+}
+
+// UserCreateRequest - Body POST /api/users
+type UserCreateRequest struct {
+	Username   string `json:"username" validate:"required,max=50"`
+	Role       string `json:"role" validate:"required,oneof=admin operator reseller"`
+	ResellerID *int   `json:"reseller_id,omitempty" validate:"min=1"` // wajib untuk role reseller
+}
+
+// UserScopesRequest - Body PUT /api/users/{id}/scopes (menggantikan semua scope)
+type UserScopesRequest struct {
+	Scopes []UserScope `json:"scopes"`
+}
+
+// APIToken - Token bearer milik user; Token plaintext hanya dikembalikan saat dibuat
+type APIToken struct {
+	ID           int        `json:"id" db:"id"`
+	UserID       int        `json:"user_id" db:"user_id"`
+	Name         string     `json:"name" db:"name"`
+	Token        string     `json:"token,omitempty" db:"-"`
+	AllowedCIDRs []string   `json:"allowed_cidrs,omitempty" db:"allowed_cidrs"` // kosong = semua IP
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+}
+
+// TokenCreateRequest - Body POST /api/users/{id}/tokens
+type TokenCreateRequest struct {
+	Name         string   `json:"name" validate:"required,max=100"`
+	AllowedCIDRs []string `json:"allowed_cidrs,omitempty"` // IP / CIDR asal yang boleh memakai token
+}
+
+// AuthSession - Sesi dashboard: access token berumur pendek + refresh token yang dirotasi
+type AuthSession struct {
+	ID               int        `json:"id" db:"id"`
+	UserID           int        `json:"user_id" db:"user_id"`
+	Name             string     `json:"name" db:"name"`
+	IPAddress        string     `json:"ip_address" db:"ip_address"`
+	UserAgent        string     `json:"user_agent" db:"user_agent"`
+	AllowedCIDRs     []string   `json:"allowed_cidrs,omitempty" db:"allowed_cidrs"` // diwarisi dari API token
+	AccessExpiresAt  time.Time  `json:"access_expires_at" db:"access_expires_at"`
+	RefreshExpiresAt time.Time  `json:"refresh_expires_at" db:"refresh_expires_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	Current          bool       `json:"current" db:"-"` // sesi yang dipakai request ini
+}
+
+// SessionTokens - Token sesi plaintext, hanya dikembalikan saat sesi dibuat / di-refresh
+type SessionTokens struct {
+	Session      *AuthSession `json:"session"`
+	AccessToken  string       `json:"access_token"`
+	RefreshToken string       `json:"refresh_token"`
+}
+
+// SessionCreateRequest - Body POST /api/auth/sessions (nama perangkat / dashboard, opsional)
+type SessionCreateRequest struct {
+	Name     string `json:"name" validate:"max=100"`
+	TOTPCode string `json:"totp_code" validate:"max=6"` // wajib jika user sudah enroll TOTP
+}
+
+// TOTPEnrollment - Secret TOTP baru (belum aktif sampai diverifikasi); hanya ditampilkan sekali
+type TOTPEnrollment struct {
+	Secret     string `json:"secret"`      // base32, untuk input manual di aplikasi authenticator
+	OTPAuthURI string `json:"otpauth_uri"` // otpauth://totp/..., untuk QR code
+}
+
+// TOTPCodeRequest - Body verifikasi / nonaktifkan TOTP
+type TOTPCodeRequest struct {
+	Code string `json:"code" validate:"required,min=6,max=6"`
+}
+
+// SessionRefreshRequest - Body POST /api/auth/refresh
+type SessionRefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event provisioning yang dikirim ke webhook
+const (
+	WebhookQueueCreated        = "queue.created"
+	WebhookQueueChanged        = "queue.changed"
+	WebhookQueueRemoved        = "queue.removed"
+	WebhookPPPSecretCreated    = "ppp_secret.created"
+	WebhookPPPSecretChanged    = "ppp_secret.changed"
+	WebhookCustomerSuspended   = "customer.suspended"
+	WebhookCustomerUnsuspended = "customer.unsuspended"
+)
+
+// WebhookEvents - Semua event yang bisa di-subscribe
+var WebhookEvents = []string{
+	WebhookQueueCreated, WebhookQueueChanged, WebhookQueueRemoved,
+	WebhookPPPSecretCreated, WebhookPPPSecretChanged,
+	WebhookCustomerSuspended, WebhookCustomerUnsuspended,
+}
+
+// Status delivery webhook
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed" // attempt habis
+)
+
+// Webhook - Endpoint eksternal (mis. sistem billing) penerima event provisioning
+type Webhook struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	URL       string    `json:"url" db:"url"`
+	Secret    string    `json:"-" db:"secret"`      // kunci HMAC, hanya ditampilkan saat dibuat
+	Events    []string  `json:"events" db:"events"` // kosong = semua event
+	Enabled   bool      `json:"enabled" db:"enabled"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Subscribed - True jika webhook menerima event tsb
+func (w *Webhook) Subscribed(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookRequest - Body create/update webhook (update: field yang diisi saja)
+type WebhookRequest struct {
+	Name    string    `json:"name" validate:"max=100"`
+	URL     string    `json:"url" validate:"url,max=500"`
+	Secret  *string   `json:"secret,omitempty" validate:"min=16,max=100"` // default: dibangkitkan
+	Events  *[]string `json:"events,omitempty"`
+	Enabled *bool     `json:"enabled,omitempty"`
+}
+
+// WebhookPayload - Body JSON yang di-POST ke webhook
+type WebhookPayload struct {
+	ID         string      `json:"id"` // sama untuk setiap retry, dipakai penerima untuk dedup
+	Event      string      `json:"event"`
+	RouterID   *int        `json:"router_id,omitempty"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// WebhookDelivery - Satu pengiriman event ke satu webhook beserta status retry
+type WebhookDelivery struct {
+	ID            int64           `json:"id" db:"id"`
+	WebhookID     int             `json:"webhook_id" db:"webhook_id"`
+	EventID       string          `json:"event_id" db:"event_id"`
+	Event         string          `json:"event" db:"event"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Status        string          `json:"status" db:"status"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	ResponseCode  *int            `json:"response_code,omitempty" db:"response_code"`
+	LastError     *string         `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+// WireGuardPeerRequest - Body POST /api/wireguard/peers
+type WireGuardPeerRequest struct {
+	Interface  string  `json:"interface" validate:"required"`                 // interface WireGuard di router
+	Name       string  `json:"name" validate:"required,max=100"`              // disimpan sebagai comment peer
+	Pool       *string `json:"pool,omitempty" validate:"cidr"`                // default: subnet address interface
+	Endpoint   *string `json:"endpoint,omitempty" validate:"host"`            // default: hostname router
+	DNS        *string `json:"dns,omitempty"`                                 // DNS untuk client (opsional)
+	AllowedIPs *string `json:"allowed_ips,omitempty"`                         // AllowedIPs client, default: subnet pool
+	Keepalive  *int    `json:"keepalive,omitempty" validate:"min=0,max=3600"` // PersistentKeepalive, default 25
+}
+
+// WireGuardPeer - Peer yang dibuat di router (tanpa private key)
+type WireGuardPeer struct {
+	Interface string `json:"interface"`
+	Name      string `json:"name"`
+	Address   string `json:"address"` // tunnel IP client, /32
+	PublicKey string `json:"public_key"`
+}
+
+// WireGuardPeerResult - Hasil provisioning; private key & config client hanya ada di response ini
+type WireGuardPeerResult struct {
+	Plan         *CommandPlan  `json:"plan"`
+	Peer         WireGuardPeer `json:"peer"`
+	PrivateKey   string        `json:"private_key"`
+	ServerKey    string        `json:"server_public_key"`
+	Endpoint     string        `json:"endpoint"`    // host:port interface WireGuard router
+	AllowedIPs   string        `json:"allowed_ips"` // AllowedIPs sisi client
+	ClientConfig string        `json:"client_config"`
+	QRPayload    string        `json:"qr_payload"` // isi QR untuk aplikasi WireGuard (= client_config)
+}
@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// WirelessMonitor - Satu pembacaan /interface/wireless/monitor (kualitas link wireless).
+// Mode station (PtP client) mengisi signal & rate; mode AP mengisi CCQ overall dan jumlah client.
+type WirelessMonitor struct {
+	RouterID          int       `json:"router_id"`
+	Interface         string    `json:"interface"`
+	Status            string    `json:"status,omitempty"` // mis. connected-to-ess, running-ap, searching-for-network
+	Frequency         string    `json:"frequency,omitempty"`
+	SSID              string    `json:"ssid,omitempty"`
+	RadioName         string    `json:"radio_name,omitempty"`
+	SignalStrength    int       `json:"signal_strength"`    // dBm
+	TxSignalStrength  int       `json:"tx_signal_strength"` // dBm, sisi remote
+	NoiseFloor        int       `json:"noise_floor"`        // dBm
+	SignalToNoise     int       `json:"signal_to_noise"`    // dB
+	TxRate            string    `json:"tx_rate,omitempty"`  // format RouterOS, mis. 130Mbps-20MHz/2S/SGI
+	RxRate            string    `json:"rx_rate,omitempty"`
+	TxRateMbps        float64   `json:"tx_rate_mbps"`
+	RxRateMbps        float64   `json:"rx_rate_mbps"`
+	TxCCQ             int       `json:"tx_ccq"` // persen; mode AP: overall-tx-ccq
+	RxCCQ             int       `json:"rx_ccq"`
+	RegisteredClients int       `json:"registered_clients,omitempty"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// WirelessSample - Satu titik history kualitas link wireless
+type WirelessSample struct {
+	RouterID       int       `json:"router_id" db:"router_id"`
+	Interface      string    `json:"interface" db:"interface"`
+	SignalStrength int       `json:"signal_strength" db:"signal_strength"`
+	SignalToNoise  int       `json:"signal_to_noise" db:"signal_to_noise"`
+	TxRateMbps     float64   `json:"tx_rate_mbps" db:"tx_rate_mbps"`
+	RxRateMbps     float64   `json:"rx_rate_mbps" db:"rx_rate_mbps"`
+	TxCCQ          int       `json:"tx_ccq" db:"tx_ccq"`
+	RxCCQ          int       `json:"rx_ccq" db:"rx_ccq"`
+	SampledAt      time.Time `json:"sampled_at" db:"sampled_at"`
+}
+
+// WirelessRegistration - Satu client di /interface/wireless/registration-table
+type WirelessRegistration struct {
+	ID             string  `json:"id"`
+	Interface      string  `json:"interface"`
+	MACAddress     string  `json:"mac_address"`
+	RadioName      string  `json:"radio_name,omitempty"`
+	LastIP         string  `json:"last_ip,omitempty"`
+	SignalStrength int     `json:"signal_strength"` // dBm
+	SignalToNoise  int     `json:"signal_to_noise"` // dB
+	TxCCQ          int     `json:"tx_ccq"`          // persen
+	RxCCQ          int     `json:"rx_ccq"`
+	TxRate         string  `json:"tx_rate,omitempty"` // format RouterOS, mis. 130Mbps-20MHz/2S/SGI
+	RxRate         string  `json:"rx_rate,omitempty"`
+	TxRateMbps     float64 `json:"tx_rate_mbps"`
+	RxRateMbps     float64 `json:"rx_rate_mbps"`
+	Uptime         string  `json:"uptime,omitempty"`
+}
+
+// WirelessInterface - Konfigurasi satu interface /interface/wireless
+type WirelessInterface struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Mode            string `json:"mode,omitempty"` // ap-bridge, station, bridge, ...
+	SSID            string `json:"ssid,omitempty"`
+	Band            string `json:"band,omitempty"`
+	Frequency       string `json:"frequency,omitempty"`
+	SecurityProfile string `json:"security_profile,omitempty"`
+	MACAddress      string `json:"mac_address,omitempty"`
+	Running         bool   `json:"running"`
+	Disabled        bool   `json:"disabled"`
+}
+
+// WirelessInterfaceRequest - Body PUT /api/wireless/interfaces (field yang diisi saja)
+type WirelessInterfaceRequest struct {
+	Interface       string  `json:"interface" validate:"required,max=64"`
+	SSID            *string `json:"ssid,omitempty" validate:"max=32"`
+	SecurityProfile *string `json:"security_profile,omitempty" validate:"max=64"`
+}
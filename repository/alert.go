@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// alertColumns - Urutan kolom yang dibaca oleh scanAlert (alias a = alerts, r = routers)
+const alertColumns = `a.id, a.router_id, r.name, a.type, a.severity, a.message, a.data, a.status, a.occurrences,
+	a.first_seen_at, a.last_seen_at, a.acknowledged_by, a.acknowledged_at, a.resolved_by, a.resolved_at, a.note`
+
+type AlertRepository struct {
+	db *sql.DB
+}
+
+func NewAlertRepository(db *sql.DB) *AlertRepository {
+	return &AlertRepository{db: db}
+}
+
+func scanAlert(row rowScanner) (*models.Alert, error) {
+	alert := &models.Alert{}
+	var data []byte
+	err := row.Scan(&alert.ID, &alert.RouterID, &alert.RouterName, &alert.Type, &alert.Severity, &alert.Message,
+		&data, &alert.Status, &alert.Occurrences, &alert.FirstSeenAt, &alert.LastSeenAt,
+		&alert.AcknowledgedBy, &alert.AcknowledgedAt, &alert.ResolvedBy, &alert.ResolvedAt, &alert.Note)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		alert.Data = json.RawMessage(data)
+	}
+	return alert, nil
+}
+
+// Raise - Buka alert dari event, atau tambah occurrences alert router + type yang belum resolved
+// (severity, pesan dan data mengikuti event terakhir)
+func (r *AlertRepository) Raise(event *models.Event) (*models.Alert, error) {
+	now := time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.QueryRow(`
+		SELECT id FROM alerts
+		WHERE router_id <=> ? AND type = ? AND status <> ?
+		ORDER BY id DESC LIMIT 1
+		FOR UPDATE
+	`, event.RouterID, event.Type, models.AlertStatusResolved).Scan(&id)
+
+	switch {
+	case err == sql.ErrNoRows:
+		result, err := tx.Exec(`
+			INSERT INTO alerts (router_id, type, severity, message, data, status, occurrences, first_seen_at, last_seen_at)
+			VALUES (?, ?, ?, ?, ?, ?, 1, ?, ?)
+		`, event.RouterID, event.Type, event.Severity, event.Message, nullableJSON(event.Data),
+			models.AlertStatusOpen, now, now)
+		if err != nil {
+			return nil, err
+		}
+		if id, err = result.LastInsertId(); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		_, err = tx.Exec(`
+			UPDATE alerts
+			SET severity = ?, message = ?, data = ?, occurrences = occurrences + 1, last_seen_at = ?
+			WHERE id = ?
+		`, event.Severity, event.Message, nullableJSON(event.Data), now, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// GetByID - Ambil alert berdasarkan ID
+func (r *AlertRepository) GetByID(id int64) (*models.Alert, error) {
+	query := `SELECT ` + alertColumns + ` FROM alerts a LEFT JOIN routers r ON r.id = a.router_id WHERE a.id = ?`
+
+	alert, err := scanAlert(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("alert not found")
+	}
+	return alert, err
+}
+
+// ListActive - Alert open + acknowledged, severity terparah lalu terbaru dulu
+func (r *AlertRepository) ListActive() ([]*models.Alert, error) {
+	query := `SELECT ` + alertColumns + ` FROM alerts a LEFT JOIN routers r ON r.id = a.router_id
+		WHERE a.status <> ?
+		ORDER BY FIELD(a.severity, 'critical', 'error', 'warning'), a.last_seen_at DESC, a.id DESC`
+
+	rows, err := r.db.Query(query, models.AlertStatusResolved)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := []*models.Alert{}
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+// Acknowledge - Tandai alert open sudah ditangani user (catatan opsional)
+func (r *AlertRepository) Acknowledge(id int64, user string, note *string) error {
+	query := `
+		UPDATE alerts
+		SET status = ?, acknowledged_by = ?, acknowledged_at = ?, note = COALESCE(?, note)
+		WHERE id = ? AND status = ?
+	`
+	return r.transition(query, models.AlertStatusAcknowledged, user, time.Now(), note, id, models.AlertStatusOpen)
+}
+
+// Resolve - Tutup alert open / acknowledged; event berikutnya membuka alert baru
+func (r *AlertRepository) Resolve(id int64, user string, note *string) error {
+	query := `
+		UPDATE alerts
+		SET status = ?, resolved_by = ?, resolved_at = ?, note = COALESCE(?, note)
+		WHERE id = ? AND status <> ?
+	`
+	return r.transition(query, models.AlertStatusResolved, user, time.Now(), note, id, models.AlertStatusResolved)
+}
+
+func (r *AlertRepository) transition(query string, args ...interface{}) error {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("alert status changed concurrently")
+	}
+	return nil
+}
+
+// OpenIDs - ID alert router + type yang belum resolved (untuk auto-resolve)
+func (r *AlertRepository) OpenIDs(routerID *int, alertType string) ([]int64, error) {
+	rows, err := r.db.Query(`SELECT id FROM alerts WHERE router_id <=> ? AND type = ? AND status <> ?`,
+		routerID, alertType, models.AlertStatusResolved)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create - Simpan satu entri audit
+func (r *AuditRepository) Create(entry *models.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs (actor, action, router_id, target, success, error, details)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, entry.Actor, entry.Action, entry.RouterID, entry.Target,
+		entry.Success, entry.Error, nullableJSON(entry.Details))
+	if err != nil {
+		return err
+	}
+
+	entry.ID, err = result.LastInsertId()
+	return err
+}
+
+// List - Ambil audit log terbaru sesuai filter
+func (r *AuditRepository) List(filter models.AuditFilter) ([]*models.AuditLog, error) {
+	var where []string
+	var args []interface{}
+
+	if filter.RouterID != nil {
+		where = append(where, "router_id = ?")
+		args = append(args, *filter.RouterID)
+	}
+	if filter.Actor != "" {
+		where = append(where, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.Action != "" {
+		where = append(where, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.From != nil {
+		where = append(where, "created_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where = append(where, "created_at <= ?")
+		args = append(args, *filter.To)
+	}
+
+	query := `SELECT id, actor, action, router_id, target, success, error, details, created_at FROM audit_logs`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*models.AuditLog
+	for rows.Next() {
+		entry := &models.AuditLog{}
+		var details []byte
+		if err := rows.Scan(&entry.ID, &entry.Actor, &entry.Action, &entry.RouterID, &entry.Target,
+			&entry.Success, &entry.Error, &details, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		if len(details) > 0 {
+			entry.Details = json.RawMessage(details)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+)
+
+type AvailabilityRepository struct {
+	db *sql.DB
+}
+
+func NewAvailabilityRepository(db *sql.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{db: db}
+}
+
+// Record - Tambah satu hasil pengecekan konektivitas router ke counter harian
+func (r *AvailabilityRepository) Record(routerID int, day time.Time, up bool) error {
+	upCheck := 0
+	if up {
+		upCheck = 1
+	}
+
+	query := `
+		INSERT INTO router_availability (router_id, day, checks, up_checks)
+		VALUES (?, ?, 1, ?)
+		ON DUPLICATE KEY UPDATE
+			checks = checks + 1,
+			up_checks = up_checks + VALUES(up_checks)
+	`
+	_, err := r.db.Exec(query, routerID, day, upCheck)
+	return err
+}
+
+// Summary - Total pengecekan dan pengecekan up router dalam rentang hari [from, to)
+func (r *AvailabilityRepository) Summary(routerID int, from, to time.Time) (int, int, error) {
+	query := `
+		SELECT COALESCE(SUM(checks), 0), COALESCE(SUM(up_checks), 0)
+		FROM router_availability
+		WHERE router_id = ? AND day >= ? AND day < ?
+	`
+
+	var checks, up int
+	err := r.db.QueryRow(query, routerID, from, to).Scan(&checks, &up)
+	return checks, up, err
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"Mikrotik-Layer/models"
+)
+
+type BackupRepository struct {
+	db *sql.DB
+}
+
+func NewBackupRepository(db *sql.DB) *BackupRepository {
+	return &BackupRepository{db: db}
+}
+
+// backupColumns - Urutan kolom yang dibaca oleh scanBackup (tanpa content)
+const backupColumns = `id, router_id, kind, filename, size_bytes, sha256, key_id, created_by, created_at`
+
+func scanBackup(row rowScanner) (*models.RouterBackup, error) {
+	b := &models.RouterBackup{}
+	err := row.Scan(&b.ID, &b.RouterID, &b.Kind, &b.Filename, &b.SizeBytes, &b.SHA256, &b.KeyID,
+		&b.CreatedBy, &b.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Create - Simpan backup beserta isi terenkripsi
+func (r *BackupRepository) Create(backup *models.RouterBackup, content []byte) (*models.RouterBackup, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO router_backups (router_id, kind, filename, size_bytes, sha256, key_id, content, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, backup.RouterID, backup.Kind, backup.Filename, backup.SizeBytes, backup.SHA256, backup.KeyID, content,
+		backup.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(int(id))
+}
+
+// GetByID - Metadata backup
+func (r *BackupRepository) GetByID(id int) (*models.RouterBackup, error) {
+	b, err := scanBackup(r.db.QueryRow("SELECT "+backupColumns+" FROM router_backups WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("backup not found")
+	}
+	return b, err
+}
+
+// GetContent - Isi backup (masih terenkripsi)
+func (r *BackupRepository) GetContent(id int) ([]byte, error) {
+	var content []byte
+	err := r.db.QueryRow("SELECT content FROM router_backups WHERE id = ?", id).Scan(&content)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("backup not found")
+	}
+	return content, err
+}
+
+// List - Metadata backup satu router, terbaru lebih dulu
+func (r *BackupRepository) List(routerID int) ([]*models.RouterBackup, error) {
+	rows, err := r.db.Query("SELECT "+backupColumns+" FROM router_backups WHERE router_id = ? ORDER BY id DESC", routerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	backups := []*models.RouterBackup{}
+	for rows.Next() {
+		b, err := scanBackup(rows)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, b)
+	}
+	return backups, rows.Err()
+}
+
+// Delete - Hapus backup
+func (r *BackupRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM router_backups WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("backup not found")
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type BandwidthScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewBandwidthScheduleRepository(db *sql.DB) *BandwidthScheduleRepository {
+	return &BandwidthScheduleRepository{db: db}
+}
+
+// bandwidthScheduleColumns - Urutan kolom yang dibaca oleh scanBandwidthSchedule
+const bandwidthScheduleColumns = `id, name, plan_id, router_id, queue_name, rate_limit, start_time, end_time, days,
+	enabled, applied_limit, original_limit, last_applied_at, last_error, created_at, updated_at`
+
+func scanBandwidthSchedule(row rowScanner) (*models.BandwidthSchedule, error) {
+	s := &models.BandwidthSchedule{}
+	var days string
+	err := row.Scan(&s.ID, &s.Name, &s.PlanID, &s.RouterID, &s.QueueName, &s.RateLimit, &s.StartTime, &s.EndTime,
+		&days, &s.Enabled, &s.AppliedLimit, &s.OriginalLimit, &s.LastAppliedAt, &s.LastError, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.Days = []string{}
+	if days != "" {
+		s.Days = strings.Split(days, ",")
+	}
+	return s, nil
+}
+
+// Create - Tambah jadwal bandwidth
+func (r *BandwidthScheduleRepository) Create(s *models.BandwidthSchedule) (*models.BandwidthSchedule, error) {
+	query := `
+		INSERT INTO bandwidth_schedules (name, plan_id, router_id, queue_name, rate_limit, start_time, end_time, days, enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, s.Name, s.PlanID, s.RouterID, s.QueueName, s.RateLimit, s.StartTime, s.EndTime,
+		strings.Join(s.Days, ","), s.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(int(id))
+}
+
+// GetByID - Ambil jadwal bandwidth by ID
+func (r *BandwidthScheduleRepository) GetByID(id int) (*models.BandwidthSchedule, error) {
+	s, err := scanBandwidthSchedule(r.db.QueryRow("SELECT "+bandwidthScheduleColumns+" FROM bandwidth_schedules WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("bandwidth schedule not found")
+	}
+	return s, err
+}
+
+// List - Semua jadwal bandwidth (termasuk yang nonaktif: limit-nya mungkin masih harus dikembalikan)
+func (r *BandwidthScheduleRepository) List() ([]*models.BandwidthSchedule, error) {
+	rows, err := r.db.Query("SELECT " + bandwidthScheduleColumns + " FROM bandwidth_schedules ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []*models.BandwidthSchedule{}
+	for rows.Next() {
+		s, err := scanBandwidthSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+
+	return schedules, rows.Err()
+}
+
+// Update - Simpan perubahan konfigurasi (state penerapan tidak diubah)
+func (r *BandwidthScheduleRepository) Update(s *models.BandwidthSchedule) error {
+	query := `
+		UPDATE bandwidth_schedules SET name = ?, plan_id = ?, router_id = ?, queue_name = ?, rate_limit = ?,
+			start_time = ?, end_time = ?, days = ?, enabled = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query, s.Name, s.PlanID, s.RouterID, s.QueueName, s.RateLimit, s.StartTime, s.EndTime,
+		strings.Join(s.Days, ","), s.Enabled, time.Now(), s.ID)
+	return err
+}
+
+// Delete - Hapus jadwal bandwidth
+func (r *BandwidthScheduleRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM bandwidth_schedules WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("bandwidth schedule not found")
+	}
+	return nil
+}
+
+// SetApplied - Simpan hasil penerapan scheduler: limit yang sedang berlaku (nil = limit normal)
+// dan max-limit queue semula
+func (r *BandwidthScheduleRepository) SetApplied(id int, appliedLimit, originalLimit *string) error {
+	_, err := r.db.Exec(`UPDATE bandwidth_schedules SET applied_limit = ?, original_limit = ?, last_applied_at = ?,
+		last_error = NULL WHERE id = ?`, appliedLimit, originalLimit, time.Now(), id)
+	return err
+}
+
+// SetError - Simpan error penerapan terakhir (dicoba lagi di tick berikutnya)
+func (r *BandwidthScheduleRepository) SetError(id int, lastError string) error {
+	if len(lastError) > 500 {
+		lastError = lastError[:500]
+	}
+	_, err := r.db.Exec("UPDATE bandwidth_schedules SET last_error = ? WHERE id = ?", lastError, id)
+	return err
+}
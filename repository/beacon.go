@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type BeaconRepository struct {
+	db *sql.DB
+}
+
+func NewBeaconRepository(db *sql.DB) *BeaconRepository {
+	return &BeaconRepository{db: db}
+}
+
+const beaconColumns = `router_id, installed_at, last_seen_at, last_source_ip`
+
+func scanBeacon(row rowScanner) (*models.RouterBeacon, error) {
+	b := &models.RouterBeacon{}
+	if err := row.Scan(&b.RouterID, &b.InstalledAt, &b.LastSeenAt, &b.LastSourceIP); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Install - Simpan token beacon baru router (token lama tidak berlaku lagi)
+func (r *BeaconRepository) Install(routerID int, tokenHash string) (*models.RouterBeacon, error) {
+	_, err := r.db.Exec(`
+		INSERT INTO router_beacons (router_id, token_hash, installed_at) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE token_hash = VALUES(token_hash), installed_at = VALUES(installed_at),
+			last_seen_at = NULL, last_source_ip = NULL
+	`, routerID, tokenHash, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByRouter(routerID)
+}
+
+// GetByRouter - Beacon router (error not found jika belum terpasang)
+func (r *BeaconRepository) GetByRouter(routerID int) (*models.RouterBeacon, error) {
+	b, err := scanBeacon(r.db.QueryRow("SELECT "+beaconColumns+" FROM router_beacons WHERE router_id = ?", routerID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("beacon not found")
+	}
+	return b, err
+}
+
+// List - Semua beacon terpasang
+func (r *BeaconRepository) List() ([]*models.RouterBeacon, error) {
+	rows, err := r.db.Query("SELECT " + beaconColumns + " FROM router_beacons ORDER BY router_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	beacons := []*models.RouterBeacon{}
+	for rows.Next() {
+		b, err := scanBeacon(rows)
+		if err != nil {
+			return nil, err
+		}
+		beacons = append(beacons, b)
+	}
+	return beacons, rows.Err()
+}
+
+// Touch - Catat heartbeat untuk token tsb, mengembalikan router pemilik token
+func (r *BeaconRepository) Touch(tokenHash, sourceIP string) (int, error) {
+	var routerID int
+	err := r.db.QueryRow(`SELECT router_id FROM router_beacons WHERE token_hash = ?`, tokenHash).Scan(&routerID)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("beacon not found")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = r.db.Exec(`UPDATE router_beacons SET last_seen_at = ?, last_source_ip = ? WHERE router_id = ?`,
+		time.Now(), sourceIP, routerID)
+	return routerID, err
+}
+
+// Delete - Hapus beacon router
+func (r *BeaconRepository) Delete(routerID int) error {
+	_, err := r.db.Exec(`DELETE FROM router_beacons WHERE router_id = ?`, routerID)
+	return err
+}
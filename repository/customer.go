@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type CustomerRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerRepository(db *sql.DB) *CustomerRepository {
+	return &CustomerRepository{db: db}
+}
+
+// customerColumns - Urutan kolom yang dibaca oleh scanCustomer
+const customerColumns = `id, router_id, name, plan_id, queue_name, ppp_secret, address, phone,
+	status, notes, created_at, updated_at, suspend_strategy, suspend_original, suspended_at`
+
+func scanCustomer(row rowScanner) (*models.Customer, error) {
+	c := &models.Customer{}
+	err := row.Scan(&c.ID, &c.RouterID, &c.Name, &c.PlanID, &c.QueueName, &c.PPPSecret, &c.Address,
+		&c.Phone, &c.Status, &c.Notes, &c.CreatedAt, &c.UpdatedAt, &c.SuspendStrategy, &c.SuspendOriginal,
+		&c.SuspendedAt)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Create - Tambah customer baru
+func (r *CustomerRepository) Create(req *models.CustomerCreateRequest) (*models.Customer, error) {
+	query := `
+		INSERT INTO customers (router_id, name, plan_id, queue_name, ppp_secret, address, phone, notes)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, req.RouterID, req.Name, req.PlanID, req.QueueName, req.PPPSecret,
+		req.Address, req.Phone, req.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(int(id))
+}
+
+// List - Customer sesuai filter (Query mencari di name, queue, secret, address, phone)
+func (r *CustomerRepository) List(filter models.CustomerFilter) ([]*models.Customer, error) {
+	var where []string
+	var args []interface{}
+
+	if filter.RouterID != nil {
+		where = append(where, "router_id = ?")
+		args = append(args, *filter.RouterID)
+	}
+	if filter.RouterIDs != nil {
+		if len(filter.RouterIDs) == 0 {
+			return []*models.Customer{}, nil
+		}
+		in, ids := inClause(filter.RouterIDs)
+		where = append(where, "router_id IN ("+in+")")
+		args = append(args, ids...)
+	}
+	if filter.PlanID != nil {
+		where = append(where, "plan_id = ?")
+		args = append(args, *filter.PlanID)
+	}
+	if filter.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		where = append(where, "(name LIKE ? OR queue_name LIKE ? OR ppp_secret LIKE ? OR address LIKE ? OR phone LIKE ?)")
+		args = append(args, like, like, like, like, like)
+	}
+
+	query := "SELECT " + customerColumns + " FROM customers"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY router_id, name"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var customers []*models.Customer
+	for rows.Next() {
+		c, err := scanCustomer(rows)
+		if err != nil {
+			return nil, err
+		}
+		customers = append(customers, c)
+	}
+
+	return customers, nil
+}
+
+// GetByID - Ambil customer by ID
+func (r *CustomerRepository) GetByID(id int) (*models.Customer, error) {
+	c, err := scanCustomer(r.db.QueryRow("SELECT "+customerColumns+" FROM customers WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("customer not found")
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetByPPPSecret - Customer pemilik PPP secret di router
+func (r *CustomerRepository) GetByPPPSecret(routerID int, secret string) (*models.Customer, error) {
+	return r.getOne("router_id = ? AND ppp_secret = ?", routerID, secret)
+}
+
+// GetByAddress - Customer dengan IP statis tsb di router
+func (r *CustomerRepository) GetByAddress(routerID int, address string) (*models.Customer, error) {
+	return r.getOne("router_id = ? AND address = ?", routerID, address)
+}
+
+func (r *CustomerRepository) getOne(where string, args ...interface{}) (*models.Customer, error) {
+	c, err := scanCustomer(r.db.QueryRow("SELECT "+customerColumns+" FROM customers WHERE "+where+" LIMIT 1", args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("customer not found")
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update - Update customer (field nil tidak diubah)
+func (r *CustomerRepository) Update(id int, req *models.CustomerUpdateRequest) (*models.Customer, error) {
+	var updates []string
+	var args []interface{}
+
+	if req.Name != nil {
+		updates = append(updates, "name = ?")
+		args = append(args, *req.Name)
+	}
+	if req.PlanID != nil {
+		updates = append(updates, "plan_id = ?")
+		args = append(args, *req.PlanID)
+	}
+	if req.QueueName != nil {
+		updates = append(updates, "queue_name = ?")
+		args = append(args, *req.QueueName)
+	}
+	if req.PPPSecret != nil {
+		updates = append(updates, "ppp_secret = ?")
+		args = append(args, *req.PPPSecret)
+	}
+	if req.Address != nil {
+		updates = append(updates, "address = ?")
+		args = append(args, *req.Address)
+	}
+	if req.Phone != nil {
+		updates = append(updates, "phone = ?")
+		args = append(args, *req.Phone)
+	}
+	if req.Notes != nil {
+		updates = append(updates, "notes = ?")
+		args = append(args, *req.Notes)
+	}
+
+	if len(updates) == 0 {
+		return r.GetByID(id)
+	}
+
+	updates = append(updates, "updated_at = ?")
+	args = append(args, time.Now())
+	args = append(args, id)
+
+	query := fmt.Sprintf("UPDATE customers SET %s WHERE id = ?", strings.Join(updates, ", "))
+	if _, err := r.db.Exec(query, args...); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// MarkSuspended - Set status suspended beserta strategi (dan max-limit asli untuk throttle)
+func (r *CustomerRepository) MarkSuspended(id int, strategy string, original *string) error {
+	_, err := r.db.Exec(`
+		UPDATE customers SET status = ?, suspend_strategy = ?, suspend_original = ?, suspended_at = ?
+		WHERE id = ?
+	`, models.CustomerSuspended, strategy, original, time.Now(), id)
+	return err
+}
+
+// MarkActive - Kembalikan status active dan bersihkan data suspend
+func (r *CustomerRepository) MarkActive(id int) error {
+	_, err := r.db.Exec(`
+		UPDATE customers SET status = ?, suspend_strategy = NULL, suspend_original = NULL, suspended_at = NULL
+		WHERE id = ?
+	`, models.CustomerActive, id)
+	return err
+}
+
+// Delete - Hapus customer
+func (r *CustomerRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM customers WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("customer not found")
+	}
+	return nil
+}
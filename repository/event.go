@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+type EventRepository struct {
+	db *sql.DB
+}
+
+func NewEventRepository(db *sql.DB) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+// Create - Simpan event baru
+func (r *EventRepository) Create(event *models.Event) error {
+	query := `
+		INSERT INTO events (router_id, type, severity, source, message, data)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, event.RouterID, event.Type, event.Severity,
+		event.Source, event.Message, nullableJSON(event.Data))
+	if err != nil {
+		return err
+	}
+
+	event.ID, err = result.LastInsertId()
+	return err
+}
+
+// List - Ambil events terbaru sesuai filter
+func (r *EventRepository) List(filter models.EventFilter) ([]*models.Event, error) {
+	var where []string
+	var args []interface{}
+
+	if filter.RouterID != nil {
+		where = append(where, "router_id = ?")
+		args = append(args, *filter.RouterID)
+	}
+	if filter.RouterIDs != nil {
+		if len(filter.RouterIDs) == 0 {
+			return []*models.Event{}, nil
+		}
+		in, ids := inClause(filter.RouterIDs)
+		where = append(where, "router_id IN ("+in+")")
+		args = append(args, ids...)
+	}
+	if filter.Type != "" {
+		where = append(where, "type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.Severity != "" {
+		where = append(where, "severity = ?")
+		args = append(args, filter.Severity)
+	}
+	if filter.Query != "" {
+		where = append(where, "message LIKE ?")
+		args = append(args, "%"+filter.Query+"%")
+	}
+	if filter.From != nil {
+		where = append(where, "created_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where = append(where, "created_at <= ?")
+		args = append(args, *filter.To)
+	}
+
+	query := `SELECT id, router_id, type, severity, source, message, data, created_at FROM events`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		event := &models.Event{}
+		var data []byte
+		if err := rows.Scan(&event.ID, &event.RouterID, &event.Type, &event.Severity,
+			&event.Source, &event.Message, &data, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			event.Data = json.RawMessage(data)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type FlowRepository struct {
+	db *sql.DB
+}
+
+func NewFlowRepository(db *sql.DB) *FlowRepository {
+	return &FlowRepository{db: db}
+}
+
+// flowGroupColumns - Kolom per mode group_by (urutan: src, dst, protocol, src_port, dst_port)
+var flowGroupColumns = map[string][]string{
+	"":     {"src_address", "dst_address", "protocol", "src_port", "dst_port"},
+	"src":  {"src_address", "NULL", "NULL", "NULL", "NULL"},
+	"dst":  {"NULL", "dst_address", "NULL", "NULL", "NULL"},
+	"port": {"NULL", "NULL", "protocol", "NULL", "dst_port"},
+}
+
+// ValidFlowGroupBy - Apakah mode group_by dikenal
+func ValidFlowGroupBy(groupBy string) bool {
+	_, ok := flowGroupColumns[groupBy]
+	return ok
+}
+
+// UpsertBatch - Tambahkan agregat flow ke bucket masing-masing dalam satu transaksi
+func (r *FlowRepository) UpsertBatch(records []*models.FlowRecord) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO flow_records (router_id, bucket_start, src_address, dst_address, protocol, src_port, dst_port, bytes, packets, flows)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			bytes = bytes + VALUES(bytes),
+			packets = packets + VALUES(packets),
+			flows = flows + VALUES(flows)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, rec := range records {
+		if _, err := stmt.Exec(rec.RouterID, rec.BucketStart, rec.SrcAddress, rec.DstAddress,
+			rec.Protocol, rec.SrcPort, rec.DstPort, rec.Bytes, rec.Packets, rec.Flows); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Query - Total bytes/packets per group dalam rentang waktu, urut bytes terbesar
+func (r *FlowRepository) Query(filter *models.FlowFilter) ([]*models.FlowSummary, error) {
+	columns, ok := flowGroupColumns[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("invalid group_by %q", filter.GroupBy)
+	}
+
+	where := []string{"router_id = ?", "bucket_start BETWEEN ? AND ?"}
+	args := []interface{}{filter.RouterID, filter.From, filter.To}
+
+	if filter.SrcAddress != "" {
+		where = append(where, "src_address = ?")
+		args = append(args, filter.SrcAddress)
+	}
+	if filter.DstAddress != "" {
+		where = append(where, "dst_address = ?")
+		args = append(args, filter.DstAddress)
+	}
+	if filter.Port != 0 {
+		where = append(where, "(src_port = ? OR dst_port = ?)")
+		args = append(args, filter.Port, filter.Port)
+	}
+	if filter.Protocol != 0 {
+		where = append(where, "protocol = ?")
+		args = append(args, filter.Protocol)
+	}
+
+	var groupBy []string
+	for _, col := range columns {
+		if col != "NULL" {
+			groupBy = append(groupBy, col)
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	args = append(args, limit)
+
+	query := `
+		SELECT ` + strings.Join(columns, ", ") + `,
+			SUM(bytes) AS total_bytes, SUM(packets), SUM(flows), MIN(bucket_start), MAX(bucket_start)
+		FROM flow_records
+		WHERE ` + strings.Join(where, " AND ") + `
+		GROUP BY ` + strings.Join(groupBy, ", ") + `
+		ORDER BY total_bytes DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []*models.FlowSummary
+	for rows.Next() {
+		s := &models.FlowSummary{}
+		var src, dst sql.NullString
+		var protocol, srcPort, dstPort sql.NullInt64
+		if err := rows.Scan(&src, &dst, &protocol, &srcPort, &dstPort,
+			&s.Bytes, &s.Packets, &s.Flows, &s.FirstSeen, &s.LastSeen); err != nil {
+			return nil, err
+		}
+		if src.Valid {
+			s.SrcAddress = &src.String
+		}
+		if dst.Valid {
+			s.DstAddress = &dst.String
+		}
+		s.Protocol = nullableInt(protocol)
+		s.SrcPort = nullableInt(srcPort)
+		s.DstPort = nullableInt(dstPort)
+		summaries = append(summaries, s)
+	}
+
+	return summaries, nil
+}
+
+// DeleteOlderThan - Hapus bucket yang lebih tua dari t
+func (r *FlowRepository) DeleteOlderThan(t time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM flow_records WHERE bucket_start < ?", t)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func nullableInt(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
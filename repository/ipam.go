@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"Mikrotik-Layer/models"
+)
+
+type SubnetRepository struct {
+	db *sql.DB
+}
+
+func NewSubnetRepository(db *sql.DB) *SubnetRepository {
+	return &SubnetRepository{db: db}
+}
+
+const subnetColumns = `id, prefix, name, description, router_id, vlan_id, created_at, updated_at`
+
+func scanSubnet(row rowScanner) (*models.Subnet, error) {
+	s := &models.Subnet{}
+	if err := row.Scan(&s.ID, &s.Prefix, &s.Name, &s.Description, &s.RouterID, &s.VLANID, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create - Tambah subnet ke rencana IP
+func (r *SubnetRepository) Create(s *models.Subnet) (*models.Subnet, error) {
+	result, err := r.db.Exec(`INSERT INTO ipam_subnets (prefix, name, description, router_id, vlan_id) VALUES (?, ?, ?, ?, ?)`,
+		s.Prefix, s.Name, s.Description, s.RouterID, s.VLANID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(int(id))
+}
+
+// GetByID - Ambil subnet by ID
+func (r *SubnetRepository) GetByID(id int) (*models.Subnet, error) {
+	s, err := scanSubnet(r.db.QueryRow("SELECT "+subnetColumns+" FROM ipam_subnets WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("subnet not found")
+	}
+	return s, err
+}
+
+// GetByPrefix - Ambil subnet by prefix (cek duplikat)
+func (r *SubnetRepository) GetByPrefix(prefix string) (*models.Subnet, error) {
+	s, err := scanSubnet(r.db.QueryRow("SELECT "+subnetColumns+" FROM ipam_subnets WHERE prefix = ?", prefix))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("subnet not found")
+	}
+	return s, err
+}
+
+// List - Semua subnet (routerID 0 = semua router)
+func (r *SubnetRepository) List(routerID int) ([]*models.Subnet, error) {
+	query := "SELECT " + subnetColumns + " FROM ipam_subnets"
+	var args []interface{}
+	if routerID != 0 {
+		query += " WHERE router_id = ?"
+		args = append(args, routerID)
+	}
+	query += " ORDER BY prefix"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subnets := []*models.Subnet{}
+	for rows.Next() {
+		s, err := scanSubnet(rows)
+		if err != nil {
+			return nil, err
+		}
+		subnets = append(subnets, s)
+	}
+	return subnets, rows.Err()
+}
+
+// Update - Simpan perubahan subnet
+func (r *SubnetRepository) Update(s *models.Subnet) error {
+	_, err := r.db.Exec(`UPDATE ipam_subnets SET prefix = ?, name = ?, description = ?, router_id = ?, vlan_id = ? WHERE id = ?`,
+		s.Prefix, s.Name, s.Description, s.RouterID, s.VLANID, s.ID)
+	return err
+}
+
+// Delete - Hapus subnet dari rencana IP
+func (r *SubnetRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM ipam_subnets WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("subnet not found")
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type JobRepository struct {
+	db *sql.DB
+}
+
+func NewJobRepository(db *sql.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+const jobColumns = `id, action, command, status, created_by, created_at, finished_at`
+
+func scanJob(row rowScanner) (*models.Job, error) {
+	j := &models.Job{}
+	var command sql.NullString
+	if err := row.Scan(&j.ID, &j.Action, &command, &j.Status, &j.CreatedBy, &j.CreatedAt, &j.FinishedAt); err != nil {
+		return nil, err
+	}
+	if command.Valid && command.String != "" {
+		if err := json.Unmarshal([]byte(command.String), &j.Command); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+const jobTaskColumns = `id, job_id, router_id, router_name, target, status, output, error, started_at, finished_at`
+
+func scanJobTask(row rowScanner) (*models.JobTask, error) {
+	t := &models.JobTask{}
+	err := row.Scan(&t.ID, &t.JobID, &t.RouterID, &t.RouterName, &t.Target, &t.Status, &t.Output, &t.Error,
+		&t.StartedAt, &t.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Create - Simpan job beserta task pending-nya dalam satu transaksi (ID diisi ke job & task)
+func (r *JobRepository) Create(job *models.Job) error {
+	var command interface{}
+	if len(job.Command) > 0 {
+		data, err := json.Marshal(job.Command)
+		if err != nil {
+			return err
+		}
+		command = string(data)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`INSERT INTO jobs (action, command, status, created_by, created_at) VALUES (?, ?, ?, ?, ?)`,
+		job.Action, command, job.Status, job.CreatedBy, job.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if job.ID, err = result.LastInsertId(); err != nil {
+		return err
+	}
+
+	for _, t := range job.Tasks {
+		t.JobID = job.ID
+		result, err := tx.Exec(`INSERT INTO job_tasks (job_id, router_id, router_name, target, status) VALUES (?, ?, ?, ?, ?)`,
+			t.JobID, t.RouterID, t.RouterName, t.Target, t.Status)
+		if err != nil {
+			return err
+		}
+		if t.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetByID - Job beserta semua task-nya
+func (r *JobRepository) GetByID(id int64) (*models.Job, error) {
+	job, err := scanJob(r.db.QueryRow("SELECT "+jobColumns+" FROM jobs WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("job not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query("SELECT "+jobTaskColumns+" FROM job_tasks WHERE job_id = ? ORDER BY router_id, id", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	job.Tasks = []*models.JobTask{}
+	for rows.Next() {
+		t, err := scanJobTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		job.Tasks = append(job.Tasks, t)
+		job.Summary.Add(t.Status, 1)
+	}
+	return job, rows.Err()
+}
+
+// List - Job terbaru dulu dengan ringkasan status task (tanpa detail task)
+func (r *JobRepository) List(status string, limit int) ([]*models.Job, error) {
+	query := "SELECT " + jobColumns + " FROM jobs"
+	var args []interface{}
+	if status != "" {
+		query += " WHERE status = ?"
+		args = append(args, status)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []*models.Job{}
+	byID := make(map[int64]*models.Job)
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+		byID[j.ID] = j
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return jobs, nil
+	}
+
+	// Ringkasan dihitung dari rentang ID job yang tampil
+	counts, err := r.db.Query(`SELECT job_id, status, COUNT(*) FROM job_tasks WHERE job_id BETWEEN ? AND ?
+		GROUP BY job_id, status`, jobs[len(jobs)-1].ID, jobs[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	defer counts.Close()
+
+	for counts.Next() {
+		var (
+			jobID  int64
+			status string
+			n      int
+		)
+		if err := counts.Scan(&jobID, &status, &n); err != nil {
+			return nil, err
+		}
+		if j, ok := byID[jobID]; ok {
+			j.Summary.Add(status, n)
+		}
+	}
+	return jobs, counts.Err()
+}
+
+// StartTask - Task mulai dieksekusi
+func (r *JobRepository) StartTask(id int64, startedAt time.Time) error {
+	_, err := r.db.Exec(`UPDATE job_tasks SET status = ?, started_at = ? WHERE id = ?`, models.TaskRunning, startedAt, id)
+	return err
+}
+
+// FinishTask - Simpan hasil akhir task (ok / failed / cancelled)
+func (r *JobRepository) FinishTask(t *models.JobTask) error {
+	_, err := r.db.Exec(`UPDATE job_tasks SET status = ?, output = ?, error = ?, finished_at = ? WHERE id = ?`,
+		t.Status, t.Output, t.Error, t.FinishedAt, t.ID)
+	return err
+}
+
+// Finish - Status akhir job
+func (r *JobRepository) Finish(id int64, status string, finishedAt time.Time) error {
+	_, err := r.db.Exec(`UPDATE jobs SET status = ?, finished_at = ? WHERE id = ?`, status, finishedAt, id)
+	return err
+}
+
+// FailInterrupted - Job yang masih running saat layer mati tidak akan pernah selesai:
+// task pending/running ditandai failed dan job-nya failed. Dipanggil sekali saat startup.
+func (r *JobRepository) FailInterrupted() (int64, error) {
+	now := time.Now()
+	if _, err := r.db.Exec(`
+		UPDATE job_tasks SET status = ?, error = ?, finished_at = ?
+		WHERE status IN (?, ?)
+	`, models.TaskFailed, "interrupted by layer restart", now, models.TaskPending, models.TaskRunning); err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.Exec(`UPDATE jobs SET status = ?, finished_at = ? WHERE status = ?`,
+		models.JobFailed, now, models.JobRunning)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
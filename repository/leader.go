@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+)
+
+type LeaderRepository struct {
+	db *sql.DB
+}
+
+func NewLeaderRepository(db *sql.DB) *LeaderRepository {
+	return &LeaderRepository{db: db}
+}
+
+// Acquire - Ambil atau perpanjang lease. Lease milik holder lain hanya diambil alih jika sudah
+// kedaluwarsa; waktu memakai jam database supaya selisih jam antar instance tidak berpengaruh.
+// Return true jika holder memegang lease setelah query.
+func (r *LeaderRepository) Acquire(name, holder string, ttl time.Duration) (bool, error) {
+	// MySQL mengevaluasi assignment berurutan: expires_at melihat holder yang sudah di-update
+	_, err := r.db.Exec(`
+		INSERT INTO leader_leases (name, holder, expires_at) VALUES (?, ?, NOW(3) + INTERVAL ? MICROSECOND)
+		ON DUPLICATE KEY UPDATE
+			holder = IF(holder = VALUES(holder) OR expires_at < NOW(3), VALUES(holder), holder),
+			expires_at = IF(holder = VALUES(holder), VALUES(expires_at), expires_at)
+	`, name, holder, ttl.Microseconds())
+	if err != nil {
+		return false, err
+	}
+
+	var current string
+	if err := r.db.QueryRow(`SELECT holder FROM leader_leases WHERE name = ?`, name).Scan(&current); err != nil {
+		return false, err
+	}
+	return current == holder, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type LeaseHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewLeaseHistoryRepository(db *sql.DB) *LeaseHistoryRepository {
+	return &LeaseHistoryRepository{db: db}
+}
+
+const leaseHistoryColumns = `id, router_id, address, mac_address, host_name, server, first_seen_at, last_seen_at, ended_at`
+
+func scanLeaseRecord(row rowScanner) (*models.DHCPLeaseRecord, error) {
+	l := &models.DHCPLeaseRecord{}
+	err := row.Scan(&l.ID, &l.RouterID, &l.Address, &l.MacAddress, &l.HostName, &l.Server,
+		&l.FirstSeenAt, &l.LastSeenAt, &l.EndedAt)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Open - Lease router yang belum ditutup (ended_at NULL)
+func (r *LeaseHistoryRepository) Open(routerID int) ([]*models.DHCPLeaseRecord, error) {
+	rows, err := r.db.Query("SELECT "+leaseHistoryColumns+" FROM dhcp_lease_history WHERE router_id = ? AND ended_at IS NULL", routerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectLeaseRecords(rows)
+}
+
+// Start - Catat lease baru
+func (r *LeaseHistoryRepository) Start(l *models.DHCPLeaseRecord) error {
+	_, err := r.db.Exec(`
+		INSERT INTO dhcp_lease_history (router_id, address, mac_address, host_name, server, first_seen_at, last_seen_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, l.RouterID, l.Address, l.MacAddress, l.HostName, l.Server, l.FirstSeenAt, l.LastSeenAt)
+	return err
+}
+
+// Touch - Lease masih bound
+func (r *LeaseHistoryRepository) Touch(id int64, hostName string, seen time.Time) error {
+	_, err := r.db.Exec(`UPDATE dhcp_lease_history SET host_name = ?, last_seen_at = ? WHERE id = ?`, hostName, seen, id)
+	return err
+}
+
+// End - Tutup lease
+func (r *LeaseHistoryRepository) End(id int64, endedAt time.Time) error {
+	_, err := r.db.Exec(`UPDATE dhcp_lease_history SET ended_at = ? WHERE id = ?`, endedAt, id)
+	return err
+}
+
+// At - Lease yang memegang IP pada waktu at (routerIDs nil = tidak dibatasi)
+func (r *LeaseHistoryRepository) At(address string, at time.Time, routerIDs []int) ([]*models.DHCPLeaseRecord, error) {
+	query := "SELECT " + leaseHistoryColumns + ` FROM dhcp_lease_history
+		WHERE address = ? AND first_seen_at <= ? AND (ended_at IS NULL OR ended_at >= ?)`
+	args := []interface{}{address, at, at}
+	if routerIDs != nil {
+		if len(routerIDs) == 0 {
+			return []*models.DHCPLeaseRecord{}, nil
+		}
+		in, ids := inClause(routerIDs)
+		query += " AND router_id IN (" + in + ")"
+		args = append(args, ids...)
+	}
+	query += " ORDER BY first_seen_at DESC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectLeaseRecords(rows)
+}
+
+func collectLeaseRecords(rows *sql.Rows) ([]*models.DHCPLeaseRecord, error) {
+	leases := []*models.DHCPLeaseRecord{}
+	for rows.Next() {
+		l, err := scanLeaseRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		leases = append(leases, l)
+	}
+	return leases, rows.Err()
+}
+
+// Purge - Hapus lease yang sudah selesai sebelum cutoff
+func (r *LeaseHistoryRepository) Purge(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM dhcp_lease_history WHERE ended_at IS NOT NULL AND ended_at < ?`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
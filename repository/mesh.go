@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type MeshRepository struct {
+	db *sql.DB
+}
+
+func NewMeshRepository(db *sql.DB) *MeshRepository {
+	return &MeshRepository{db: db}
+}
+
+// pathColumns - Urutan kolom yang dibaca oleh scanPath
+const pathColumns = `id, name, source_router_id, target_router_id, target_address, ping_count,
+	latency_threshold_ms, loss_threshold_pct, enabled, state, created_at, updated_at`
+
+func scanPath(row rowScanner) (*models.MonitoredPath, error) {
+	p := &models.MonitoredPath{}
+	err := row.Scan(&p.ID, &p.Name, &p.SourceRouterID, &p.TargetRouterID, &p.TargetAddress, &p.PingCount,
+		&p.LatencyThresholdMs, &p.LossThresholdPct, &p.Enabled, &p.State, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreatePath - Tambah monitored path
+func (r *MeshRepository) CreatePath(p *models.MonitoredPath) (*models.MonitoredPath, error) {
+	query := `
+		INSERT INTO monitored_paths (name, source_router_id, target_router_id, target_address, ping_count,
+			latency_threshold_ms, loss_threshold_pct, enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, p.Name, p.SourceRouterID, p.TargetRouterID, p.TargetAddress, p.PingCount,
+		p.LatencyThresholdMs, p.LossThresholdPct, p.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetPath(int(id))
+}
+
+// GetPath - Ambil monitored path by ID
+func (r *MeshRepository) GetPath(id int) (*models.MonitoredPath, error) {
+	p, err := scanPath(r.db.QueryRow("SELECT "+pathColumns+" FROM monitored_paths WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("path not found")
+	}
+	return p, err
+}
+
+// ListPaths - Semua monitored path (enabledOnly untuk worker)
+func (r *MeshRepository) ListPaths(enabledOnly bool) ([]*models.MonitoredPath, error) {
+	query := "SELECT " + pathColumns + " FROM monitored_paths"
+	if enabledOnly {
+		query += " WHERE enabled = TRUE"
+	}
+	query += " ORDER BY source_router_id, name"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []*models.MonitoredPath
+	for rows.Next() {
+		p, err := scanPath(rows)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+
+	return paths, nil
+}
+
+// UpdatePath - Simpan perubahan konfigurasi path (state tidak diubah)
+func (r *MeshRepository) UpdatePath(p *models.MonitoredPath) error {
+	query := `
+		UPDATE monitored_paths SET name = ?, source_router_id = ?, target_router_id = ?, target_address = ?,
+			ping_count = ?, latency_threshold_ms = ?, loss_threshold_pct = ?, enabled = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query, p.Name, p.SourceRouterID, p.TargetRouterID, p.TargetAddress, p.PingCount,
+		p.LatencyThresholdMs, p.LossThresholdPct, p.Enabled, time.Now(), p.ID)
+	return err
+}
+
+// DeletePath - Hapus path beserta sample-nya (FK cascade)
+func (r *MeshRepository) DeletePath(id int) error {
+	result, err := r.db.Exec("DELETE FROM monitored_paths WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("path not found")
+	}
+	return nil
+}
+
+// SetPathState - Simpan state hasil evaluasi terakhir
+func (r *MeshRepository) SetPathState(id int, state string) error {
+	_, err := r.db.Exec("UPDATE monitored_paths SET state = ? WHERE id = ?", state, id)
+	return err
+}
+
+// InsertSample - Simpan hasil satu putaran ping
+func (r *MeshRepository) InsertSample(s *models.PathSample) error {
+	query := `
+		INSERT INTO path_samples (path_id, sent, received, loss_pct, min_rtt_ms, avg_rtt_ms, max_rtt_ms, sampled_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.Exec(query, s.PathID, s.Sent, s.Received, s.LossPct, s.MinRttMs, s.AvgRttMs, s.MaxRttMs, s.SampledAt)
+	return err
+}
+
+// LatestSamples - Sample terakhir tiap path
+func (r *MeshRepository) LatestSamples() (map[int]*models.PathSample, error) {
+	query := `
+		SELECT s.path_id, s.sent, s.received, s.loss_pct, s.min_rtt_ms, s.avg_rtt_ms, s.max_rtt_ms, s.sampled_at
+		FROM path_samples s
+		JOIN (SELECT path_id, MAX(sampled_at) AS sampled_at FROM path_samples GROUP BY path_id) last
+			ON last.path_id = s.path_id AND last.sampled_at = s.sampled_at
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	latest := make(map[int]*models.PathSample)
+	for rows.Next() {
+		s, err := scanPathSample(rows)
+		if err != nil {
+			return nil, err
+		}
+		latest[s.PathID] = s
+	}
+
+	return latest, nil
+}
+
+// History - Sample satu path dalam rentang waktu (urut naik)
+func (r *MeshRepository) History(pathID int, from, to time.Time) ([]*models.PathSample, error) {
+	query := `
+		SELECT path_id, sent, received, loss_pct, min_rtt_ms, avg_rtt_ms, max_rtt_ms, sampled_at
+		FROM path_samples
+		WHERE path_id = ? AND sampled_at BETWEEN ? AND ?
+		ORDER BY sampled_at ASC
+	`
+
+	rows, err := r.db.Query(query, pathID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []*models.PathSample
+	for rows.Next() {
+		s, err := scanPathSample(rows)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, s)
+	}
+
+	return samples, nil
+}
+
+func scanPathSample(row rowScanner) (*models.PathSample, error) {
+	s := &models.PathSample{}
+	if err := row.Scan(&s.PathID, &s.Sent, &s.Received, &s.LossPct, &s.MinRttMs, &s.AvgRttMs,
+		&s.MaxRttMs, &s.SampledAt); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DeleteSamplesOlderThan - Hapus sample lama (retention)
+func (r *MeshRepository) DeleteSamplesOlderThan(t time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM path_samples WHERE sampled_at < ?", t)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+type NotificationChannelRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationChannelRepository(db *sql.DB) *NotificationChannelRepository {
+	return &NotificationChannelRepository{db: db}
+}
+
+const channelColumns = `id, name, type, target, router_tags, min_severity, quiet_start, quiet_end, enabled,
+	created_at, updated_at`
+
+func scanChannel(row rowScanner) (*models.NotificationChannel, error) {
+	c := &models.NotificationChannel{}
+	var tags string
+	err := row.Scan(&c.ID, &c.Name, &c.Type, &c.Target, &tags, &c.MinSeverity, &c.QuietStart, &c.QuietEnd, &c.Enabled,
+		&c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	c.RouterTags = []string{}
+	if tags != "" {
+		c.RouterTags = strings.Split(tags, ",")
+	}
+	return c, nil
+}
+
+// Create - Tambah channel notifikasi
+func (r *NotificationChannelRepository) Create(c *models.NotificationChannel) (*models.NotificationChannel, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO notification_channels (name, type, target, router_tags, min_severity, quiet_start, quiet_end, enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, c.Name, c.Type, c.Target, strings.Join(c.RouterTags, ","), c.MinSeverity, c.QuietStart, c.QuietEnd, c.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(int(id))
+}
+
+// GetByID - Ambil channel by ID
+func (r *NotificationChannelRepository) GetByID(id int) (*models.NotificationChannel, error) {
+	c, err := scanChannel(r.db.QueryRow("SELECT "+channelColumns+" FROM notification_channels WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("notification channel not found")
+	}
+	return c, err
+}
+
+// List - Semua channel (enabledOnly untuk routing alert)
+func (r *NotificationChannelRepository) List(enabledOnly bool) ([]*models.NotificationChannel, error) {
+	query := "SELECT " + channelColumns + " FROM notification_channels"
+	if enabledOnly {
+		query += " WHERE enabled = TRUE"
+	}
+	query += " ORDER BY name"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	channels := []*models.NotificationChannel{}
+	for rows.Next() {
+		c, err := scanChannel(rows)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, c)
+	}
+	return channels, rows.Err()
+}
+
+// Update - Simpan perubahan channel
+func (r *NotificationChannelRepository) Update(c *models.NotificationChannel) error {
+	_, err := r.db.Exec(`
+		UPDATE notification_channels
+		SET name = ?, type = ?, target = ?, router_tags = ?, min_severity = ?, quiet_start = ?, quiet_end = ?, enabled = ?
+		WHERE id = ?
+	`, c.Name, c.Type, c.Target, strings.Join(c.RouterTags, ","), c.MinSeverity, c.QuietStart, c.QuietEnd, c.Enabled, c.ID)
+	return err
+}
+
+// Delete - Hapus channel
+func (r *NotificationChannelRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM notification_channels WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("notification channel not found")
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type PlanRepository struct {
+	db *sql.DB
+}
+
+func NewPlanRepository(db *sql.DB) *PlanRepository {
+	return &PlanRepository{db: db}
+}
+
+// planColumns - Urutan kolom yang dibaca oleh scanPlan
+const planColumns = `id, name, rate_limit, burst_limit, burst_threshold, burst_time, quota_bytes,
+	ppp_profile, description, created_at, updated_at`
+
+func scanPlan(row rowScanner) (*models.Plan, error) {
+	p := &models.Plan{}
+	err := row.Scan(&p.ID, &p.Name, &p.RateLimit, &p.BurstLimit, &p.BurstThreshold, &p.BurstTime,
+		&p.QuotaBytes, &p.PPPProfile, &p.Description, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Create - Tambah plan baru
+func (r *PlanRepository) Create(req *models.PlanCreateRequest) (*models.Plan, error) {
+	query := `
+		INSERT INTO plans (name, rate_limit, burst_limit, burst_threshold, burst_time, quota_bytes, ppp_profile, description)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, req.Name, req.RateLimit, req.BurstLimit, req.BurstThreshold,
+		req.BurstTime, req.QuotaBytes, req.PPPProfile, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(int(id))
+}
+
+// GetAll - Semua plan urut nama
+func (r *PlanRepository) GetAll() ([]*models.Plan, error) {
+	rows, err := r.db.Query("SELECT " + planColumns + " FROM plans ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plans []*models.Plan
+	for rows.Next() {
+		p, err := scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, p)
+	}
+
+	return plans, nil
+}
+
+// GetByID - Ambil plan by ID
+func (r *PlanRepository) GetByID(id int) (*models.Plan, error) {
+	p, err := scanPlan(r.db.QueryRow("SELECT "+planColumns+" FROM plans WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("plan not found")
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// Update - Update plan (field nil tidak diubah)
+func (r *PlanRepository) Update(id int, req *models.PlanUpdateRequest) (*models.Plan, error) {
+	var updates []string
+	var args []interface{}
+
+	if req.Name != nil {
+		updates = append(updates, "name = ?")
+		args = append(args, *req.Name)
+	}
+	if req.RateLimit != nil {
+		updates = append(updates, "rate_limit = ?")
+		args = append(args, *req.RateLimit)
+	}
+	if req.BurstLimit != nil {
+		updates = append(updates, "burst_limit = ?")
+		args = append(args, *req.BurstLimit)
+	}
+	if req.BurstThreshold != nil {
+		updates = append(updates, "burst_threshold = ?")
+		args = append(args, *req.BurstThreshold)
+	}
+	if req.BurstTime != nil {
+		updates = append(updates, "burst_time = ?")
+		args = append(args, *req.BurstTime)
+	}
+	if req.QuotaBytes != nil {
+		updates = append(updates, "quota_bytes = ?")
+		args = append(args, *req.QuotaBytes)
+	}
+	if req.PPPProfile != nil {
+		updates = append(updates, "ppp_profile = ?")
+		args = append(args, *req.PPPProfile)
+	}
+	if req.Description != nil {
+		updates = append(updates, "description = ?")
+		args = append(args, *req.Description)
+	}
+
+	if len(updates) == 0 {
+		return r.GetByID(id)
+	}
+
+	updates = append(updates, "updated_at = ?")
+	args = append(args, time.Now())
+	args = append(args, id)
+
+	query := fmt.Sprintf("UPDATE plans SET %s WHERE id = ?", strings.Join(updates, ", "))
+	if _, err := r.db.Exec(query, args...); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// Delete - Hapus plan (ditolak DB jika masih dipakai customer)
+func (r *PlanRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM plans WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("plan not found")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type PPPSessionRepository struct {
+	db *sql.DB
+}
+
+func NewPPPSessionRepository(db *sql.DB) *PPPSessionRepository {
+	return &PPPSessionRepository{db: db}
+}
+
+const pppSessionColumns = `id, router_id, session_ref, username, service, caller_id, address, bytes_in, bytes_out,
+	started_at, ended_at, last_seen_at`
+
+func scanPPPSession(row rowScanner) (*models.PPPSession, error) {
+	s := &models.PPPSession{}
+	err := row.Scan(&s.ID, &s.RouterID, &s.SessionRef, &s.Username, &s.Service, &s.CallerID, &s.Address,
+		&s.BytesIn, &s.BytesOut, &s.StartedAt, &s.EndedAt, &s.LastSeenAt)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Open - Sesi router yang belum ditutup (ended_at NULL)
+func (r *PPPSessionRepository) Open(routerID int) ([]*models.PPPSession, error) {
+	rows, err := r.db.Query("SELECT "+pppSessionColumns+" FROM ppp_sessions WHERE router_id = ? AND ended_at IS NULL", routerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectPPPSessions(rows)
+}
+
+// Start - Catat sesi baru
+func (r *PPPSessionRepository) Start(s *models.PPPSession) error {
+	_, err := r.db.Exec(`
+		INSERT INTO ppp_sessions (router_id, session_ref, username, service, caller_id, address, bytes_in, bytes_out,
+			started_at, last_seen_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, s.RouterID, s.SessionRef, s.Username, s.Service, s.CallerID, s.Address, s.BytesIn, s.BytesOut,
+		s.StartedAt, s.LastSeenAt)
+	return err
+}
+
+// Touch - Update counter & alamat sesi yang masih aktif
+func (r *PPPSessionRepository) Touch(id int64, address string, bytesIn, bytesOut uint64, seen time.Time) error {
+	_, err := r.db.Exec(`UPDATE ppp_sessions SET address = ?, bytes_in = ?, bytes_out = ?, last_seen_at = ? WHERE id = ?`,
+		address, bytesIn, bytesOut, seen, id)
+	return err
+}
+
+// End - Tutup sesi
+func (r *PPPSessionRepository) End(id int64, endedAt time.Time) error {
+	_, err := r.db.Exec(`UPDATE ppp_sessions SET ended_at = ? WHERE id = ?`, endedAt, id)
+	return err
+}
+
+// List - Riwayat sesi sesuai filter, terbaru dulu
+func (r *PPPSessionRepository) List(filter models.PPPSessionFilter) ([]*models.PPPSession, error) {
+	var where []string
+	var args []interface{}
+
+	if filter.RouterID != nil {
+		where = append(where, "router_id = ?")
+		args = append(args, *filter.RouterID)
+	}
+	if filter.RouterIDs != nil {
+		if len(filter.RouterIDs) == 0 {
+			return []*models.PPPSession{}, nil
+		}
+		in, ids := inClause(filter.RouterIDs)
+		where = append(where, "router_id IN ("+in+")")
+		args = append(args, ids...)
+	}
+	if filter.User != "" {
+		where = append(where, "username = ?")
+		args = append(args, filter.User)
+	}
+	if filter.Address != "" {
+		where = append(where, "address = ?")
+		args = append(args, filter.Address)
+	}
+	if filter.From != nil {
+		where = append(where, "(ended_at IS NULL OR ended_at >= ?)")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where = append(where, "started_at <= ?")
+		args = append(args, *filter.To)
+	}
+
+	query := "SELECT " + pppSessionColumns + " FROM ppp_sessions"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	query += " ORDER BY started_at DESC, id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectPPPSessions(rows)
+}
+
+func collectPPPSessions(rows *sql.Rows) ([]*models.PPPSession, error) {
+	sessions := []*models.PPPSession{}
+	for rows.Next() {
+		s, err := scanPPPSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// Purge - Hapus sesi yang sudah selesai sebelum cutoff
+func (r *PPPSessionRepository) Purge(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM ppp_sessions WHERE ended_at IS NOT NULL AND ended_at < ?`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type ReportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// reportColumns - Urutan kolom yang dibaca oleh scanReport (tanpa content)
+const reportColumns = `id, scope, target_id, target_name, period, status, error, size_bytes, created_at, updated_at`
+
+func scanReport(row rowScanner) (*models.Report, error) {
+	rp := &models.Report{}
+	err := row.Scan(&rp.ID, &rp.Scope, &rp.TargetID, &rp.TargetName, &rp.Period, &rp.Status,
+		&rp.Error, &rp.SizeBytes, &rp.CreatedAt, &rp.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// Save - Simpan laporan; laporan target & periode yang sama ditimpa (generate ulang)
+func (r *ReportRepository) Save(report *models.Report, content []byte) (*models.Report, error) {
+	query := `
+		INSERT INTO reports (scope, target_id, target_name, period, status, error, size_bytes, content)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			target_name = VALUES(target_name),
+			status = VALUES(status),
+			error = VALUES(error),
+			size_bytes = VALUES(size_bytes),
+			content = VALUES(content)
+	`
+	_, err := r.db.Exec(query, report.Scope, report.TargetID, report.TargetName, report.Period,
+		report.Status, report.Error, len(content), content)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRow("SELECT "+reportColumns+" FROM reports WHERE scope = ? AND target_id = ? AND period = ?",
+		report.Scope, report.TargetID, report.Period)
+	return scanReport(row)
+}
+
+// GetByID - Metadata laporan
+func (r *ReportRepository) GetByID(id int) (*models.Report, error) {
+	rp, err := scanReport(r.db.QueryRow("SELECT "+reportColumns+" FROM reports WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("report not found")
+	}
+	return rp, err
+}
+
+// GetContent - Isi PDF laporan
+func (r *ReportRepository) GetContent(id int) ([]byte, error) {
+	var content []byte
+	err := r.db.QueryRow("SELECT content FROM reports WHERE id = ?", id).Scan(&content)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("report not found")
+	}
+	return content, err
+}
+
+// Exists - Apakah laporan target & periode sudah pernah dibuat
+func (r *ReportRepository) Exists(scope string, targetID int, period time.Time) (bool, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM reports WHERE scope = ? AND target_id = ? AND period = ?",
+		scope, targetID, period).Scan(&count)
+	return count > 0, err
+}
+
+// List - Metadata laporan, terbaru lebih dulu
+func (r *ReportRepository) List(filter *models.ReportFilter) ([]*models.Report, error) {
+	query := "SELECT " + reportColumns + " FROM reports WHERE 1=1"
+	var args []interface{}
+
+	if filter.Scope != "" {
+		query += " AND scope = ?"
+		args = append(args, filter.Scope)
+	}
+	if filter.TargetID != 0 {
+		query += " AND target_id = ?"
+		args = append(args, filter.TargetID)
+	}
+	if filter.Period != nil {
+		query += " AND period = ?"
+		args = append(args, *filter.Period)
+	}
+	query += " ORDER BY period DESC, scope, target_name"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*models.Report
+	for rows.Next() {
+		rp, err := scanReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, rp)
+	}
+
+	return reports, nil
+}
+
+// Delete - Hapus laporan
+func (r *ReportRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM reports WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("report not found")
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+type ResellerRepository struct {
+	db *sql.DB
+}
+
+func NewResellerRepository(db *sql.DB) *ResellerRepository {
+	return &ResellerRepository{db: db}
+}
+
+// Create - Tambah reseller baru
+func (r *ResellerRepository) Create(req *models.ResellerRequest) (*models.Reseller, error) {
+	result, err := r.db.Exec(`INSERT INTO resellers (name, contact_name, contact_phone) VALUES (?, ?, ?)`,
+		req.Name, req.ContactName, req.ContactPhone)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(int(id))
+}
+
+// GetAll - Semua reseller beserta router miliknya, urut nama
+func (r *ResellerRepository) GetAll() ([]*models.Reseller, error) {
+	rows, err := r.db.Query(`SELECT id, name, contact_name, contact_phone, created_at FROM resellers ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resellers := []*models.Reseller{}
+	byID := make(map[int]*models.Reseller)
+	for rows.Next() {
+		reseller := &models.Reseller{RouterIDs: []int{}}
+		if err := rows.Scan(&reseller.ID, &reseller.Name, &reseller.ContactName, &reseller.ContactPhone,
+			&reseller.CreatedAt); err != nil {
+			return nil, err
+		}
+		resellers = append(resellers, reseller)
+		byID[reseller.ID] = reseller
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	owned, err := r.db.Query(`SELECT reseller_id, router_id FROM reseller_routers ORDER BY router_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer owned.Close()
+
+	for owned.Next() {
+		var resellerID, routerID int
+		if err := owned.Scan(&resellerID, &routerID); err != nil {
+			return nil, err
+		}
+		if reseller, ok := byID[resellerID]; ok {
+			reseller.RouterIDs = append(reseller.RouterIDs, routerID)
+		}
+	}
+	return resellers, owned.Err()
+}
+
+// GetByID - Ambil reseller beserta router miliknya
+func (r *ResellerRepository) GetByID(id int) (*models.Reseller, error) {
+	reseller := &models.Reseller{}
+	err := r.db.QueryRow(`SELECT id, name, contact_name, contact_phone, created_at FROM resellers WHERE id = ?`, id).
+		Scan(&reseller.ID, &reseller.Name, &reseller.ContactName, &reseller.ContactPhone, &reseller.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("reseller not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if reseller.RouterIDs, err = r.RouterIDs(id); err != nil {
+		return nil, err
+	}
+	return reseller, nil
+}
+
+// Update - Ubah nama / kontak reseller
+func (r *ResellerRepository) Update(id int, req *models.ResellerRequest) (*models.Reseller, error) {
+	if _, err := r.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	_, err := r.db.Exec(`UPDATE resellers SET name = ?, contact_name = ?, contact_phone = ? WHERE id = ?`,
+		req.Name, req.ContactName, req.ContactPhone, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// Delete - Hapus reseller; router dilepas (kembali ke admin), user reseller ikut terhapus
+func (r *ResellerRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM resellers WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("reseller not found")
+	}
+	return nil
+}
+
+// RouterIDs - Router milik reseller
+func (r *ResellerRepository) RouterIDs(resellerID int) ([]int, error) {
+	rows, err := r.db.Query(`SELECT router_id FROM reseller_routers WHERE reseller_id = ? ORDER BY router_id`, resellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// SetRouters - Ganti semua router reseller dalam satu transaksi.
+// Router yang sebelumnya milik reseller lain dipindahkan ke reseller ini.
+func (r *ResellerRepository) SetRouters(resellerID int, routerIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM reseller_routers WHERE reseller_id = ?`, resellerID); err != nil {
+		return err
+	}
+	for _, routerID := range routerIDs {
+		if _, err := tx.Exec(`
+			INSERT INTO reseller_routers (router_id, reseller_id) VALUES (?, ?)
+			ON DUPLICATE KEY UPDATE reseller_id = VALUES(reseller_id)
+		`, routerID, resellerID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// inClause - Placeholder "?, ?, ?" beserta args untuk filter IN
+func inClause(ids []int) (string, []interface{}) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	return strings.Join(placeholders, ", "), args
+}
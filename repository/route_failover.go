@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type RouteFailoverRepository struct {
+	db *sql.DB
+}
+
+func NewRouteFailoverRepository(db *sql.DB) *RouteFailoverRepository {
+	return &RouteFailoverRepository{db: db}
+}
+
+// routeFailoverColumns - Urutan kolom yang dibaca oleh scanRouteFailover
+const routeFailoverColumns = `id, name, router_id, dst_address, primary_gateway, backup_gateway, notify, enabled,
+	state, active_gateway, last_change_at, created_at, updated_at`
+
+func scanRouteFailover(row rowScanner) (*models.RouteFailover, error) {
+	f := &models.RouteFailover{}
+	err := row.Scan(&f.ID, &f.Name, &f.RouterID, &f.DstAddress, &f.PrimaryGateway, &f.BackupGateway, &f.Notify,
+		&f.Enabled, &f.State, &f.ActiveGateway, &f.LastChangeAt, &f.CreatedAt, &f.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Create - Tambah route failover
+func (r *RouteFailoverRepository) Create(f *models.RouteFailover) (*models.RouteFailover, error) {
+	query := `
+		INSERT INTO route_failovers (name, router_id, dst_address, primary_gateway, backup_gateway, notify, enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, f.Name, f.RouterID, f.DstAddress, f.PrimaryGateway, f.BackupGateway, f.Notify, f.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(int(id))
+}
+
+// GetByID - Ambil route failover by ID
+func (r *RouteFailoverRepository) GetByID(id int) (*models.RouteFailover, error) {
+	f, err := scanRouteFailover(r.db.QueryRow("SELECT "+routeFailoverColumns+" FROM route_failovers WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("route failover not found")
+	}
+	return f, err
+}
+
+// List - Semua route failover (enabledOnly untuk watchdog)
+func (r *RouteFailoverRepository) List(enabledOnly bool) ([]*models.RouteFailover, error) {
+	query := "SELECT " + routeFailoverColumns + " FROM route_failovers"
+	if enabledOnly {
+		query += " WHERE enabled = TRUE"
+	}
+	query += " ORDER BY router_id, name"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	failovers := []*models.RouteFailover{}
+	for rows.Next() {
+		f, err := scanRouteFailover(rows)
+		if err != nil {
+			return nil, err
+		}
+		failovers = append(failovers, f)
+	}
+
+	return failovers, rows.Err()
+}
+
+// Update - Simpan perubahan konfigurasi (state tidak diubah)
+func (r *RouteFailoverRepository) Update(f *models.RouteFailover) error {
+	query := `
+		UPDATE route_failovers SET name = ?, router_id = ?, dst_address = ?, primary_gateway = ?, backup_gateway = ?,
+			notify = ?, enabled = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.Exec(query, f.Name, f.RouterID, f.DstAddress, f.PrimaryGateway, f.BackupGateway,
+		f.Notify, f.Enabled, time.Now(), f.ID)
+	return err
+}
+
+// Delete - Hapus route failover
+func (r *RouteFailoverRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM route_failovers WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("route failover not found")
+	}
+	return nil
+}
+
+// SetState - Simpan state hasil evaluasi watchdog beserta gateway aktif
+func (r *RouteFailoverRepository) SetState(id int, state string, activeGateway *string) error {
+	_, err := r.db.Exec("UPDATE route_failovers SET state = ?, active_gateway = ?, last_change_at = ? WHERE id = ?",
+		state, activeGateway, time.Now(), id)
+	return err
+}
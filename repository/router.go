@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,17 @@ import (
 	"Mikrotik-Layer/models"
 )
 
+// routerColumns - Urutan kolom yang dibaca oleh scanRouter
+const routerColumns = `id, uuid, name, hostname, username, password, keepalive, timeout,
+	port, location, description, wan_interfaces, tags, contact_name, contact_phone, circuit_id, monitoring_url, notes,
+	source_address, bind_interface, jump_type, jump_address, jump_username, jump_password, jump_private_key, jump_host_key,
+	tunnel_concentrator_id, tunnel_type, tunnel_peer, is_active, is_virtual, auto_connect, last_seen, status, active_address, version, uptime, created_at, updated_at`
+
+// rowScanner - Abstraksi *sql.Row dan *sql.Rows untuk scanRouter
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type RouterRepository struct {
 	db *sql.DB
 }
@@ -17,11 +29,46 @@ func NewRouterRepository(db *sql.DB) *RouterRepository {
 	return &RouterRepository{db: db}
 }
 
+// scanRouter - Scan satu baris routers sesuai routerColumns
+func scanRouter(row rowScanner) (*models.Router, error) {
+	router := &models.Router{}
+	var notes []byte
+	err := row.Scan(
+		&router.ID, &router.UUID, &router.Name, &router.Hostname,
+		&router.Username, &router.Password, &router.Keepalive, &router.Timeout,
+		&router.Port, &router.Location, &router.Description, &router.WANInterfaces, &router.Tags,
+		&router.ContactName, &router.ContactPhone, &router.CircuitID, &router.MonitoringURL, &notes,
+		&router.SourceAddress, &router.BindInterface, &router.JumpType, &router.JumpAddress, &router.JumpUsername,
+		&router.JumpPassword, &router.JumpPrivateKey, &router.JumpHostKey,
+		&router.TunnelConcentratorID, &router.TunnelType, &router.TunnelPeer, &router.IsActive, &router.IsVirtual, &router.AutoConnect, &router.LastSeen, &router.Status, &router.ActiveAddress, &router.Version, &router.Uptime,
+		&router.CreatedAt, &router.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(notes) > 0 {
+		router.Notes = json.RawMessage(notes)
+	}
+	router.JumpCredentialsSet = (router.JumpPassword != nil && *router.JumpPassword != "") ||
+		(router.JumpPrivateKey != nil && *router.JumpPrivateKey != "")
+	return router, nil
+}
+
+// nullableJSON - Simpan notes kosong sebagai NULL
+func nullableJSON(raw json.RawMessage) interface{} {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return string(raw)
+}
+
 // Create - Tambah router baru
 func (r *RouterRepository) Create(req *models.RouterCreateRequest) (*models.Router, error) {
 	query := `
-		INSERT INTO routers (name, hostname, username, password, keepalive, timeout, port, location, description)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO routers (name, hostname, username, password, keepalive, timeout, port, location, description,
+			is_virtual, auto_connect, wan_interfaces, tags, contact_name, contact_phone, circuit_id, monitoring_url, notes,
+			source_address, bind_interface, jump_type, jump_address, jump_username, jump_password, jump_private_key, jump_host_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	keepalive := true
@@ -39,8 +86,21 @@ func (r *RouterRepository) Create(req *models.RouterCreateRequest) (*models.Rout
 		port = *req.Port
 	}
 
+	isVirtual := false
+	if req.IsVirtual != nil {
+		isVirtual = *req.IsVirtual
+	}
+
+	autoConnect := true
+	if req.AutoConnect != nil {
+		autoConnect = *req.AutoConnect
+	}
+
 	result, err := r.db.Exec(query, req.Name, req.Hostname, req.Username, req.Password,
-		keepalive, timeout, port, req.Location, req.Description)
+		keepalive, timeout, port, req.Location, req.Description, isVirtual, autoConnect, req.WANInterfaces, req.Tags,
+		req.ContactName, req.ContactPhone, req.CircuitID, req.MonitoringURL, nullableJSON(req.Notes),
+		req.SourceAddress, req.BindInterface, req.JumpType, req.JumpAddress, req.JumpUsername, req.JumpPassword,
+		req.JumpPrivateKey, req.JumpHostKey)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +115,7 @@ func (r *RouterRepository) Create(req *models.RouterCreateRequest) (*models.Rout
 
 // GetAll - Ambil semua router
 func (r *RouterRepository) GetAll() ([]*models.Router, error) {
-	query := `SELECT * FROM routers ORDER BY created_at DESC`
+	query := "SELECT " + routerColumns + " FROM routers ORDER BY created_at DESC"
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -65,14 +125,7 @@ func (r *RouterRepository) GetAll() ([]*models.Router, error) {
 
 	var routers []*models.Router
 	for rows.Next() {
-		router := &models.Router{}
-		err := rows.Scan(
-			&router.ID, &router.UUID, &router.Name, &router.Hostname,
-			&router.Username, &router.Password, &router.Keepalive, &router.Timeout,
-			&router.Port, &router.Location, &router.Description, &router.IsActive,
-			&router.LastSeen, &router.Status, &router.Version, &router.Uptime,
-			&router.CreatedAt, &router.UpdatedAt,
-		)
+		router, err := scanRouter(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -84,16 +137,9 @@ func (r *RouterRepository) GetAll() ([]*models.Router, error) {
 
 // GetByID - Ambil router by ID
 func (r *RouterRepository) GetByID(id int) (*models.Router, error) {
-	query := `SELECT * FROM routers WHERE id = ?`
+	query := "SELECT " + routerColumns + " FROM routers WHERE id = ?"
 
-	router := &models.Router{}
-	err := r.db.QueryRow(query, id).Scan(
-		&router.ID, &router.UUID, &router.Name, &router.Hostname,
-		&router.Username, &router.Password, &router.Keepalive, &router.Timeout,
-		&router.Port, &router.Location, &router.Description, &router.IsActive,
-		&router.LastSeen, &router.Status, &router.Version, &router.Uptime,
-		&router.CreatedAt, &router.UpdatedAt,
-	)
+	router, err := scanRouter(r.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("router not found")
@@ -106,16 +152,9 @@ func (r *RouterRepository) GetByID(id int) (*models.Router, error) {
 
 // GetByUUID - Ambil router by UUID
 func (r *RouterRepository) GetByUUID(uuid string) (*models.Router, error) {
-	query := `SELECT * FROM routers WHERE uuid = ?`
+	query := "SELECT " + routerColumns + " FROM routers WHERE uuid = ?"
 
-	router := &models.Router{}
-	err := r.db.QueryRow(query, uuid).Scan(
-		&router.ID, &router.UUID, &router.Name, &router.Hostname,
-		&router.Username, &router.Password, &router.Keepalive, &router.Timeout,
-		&router.Port, &router.Location, &router.Description, &router.IsActive,
-		&router.LastSeen, &router.Status, &router.Version, &router.Uptime,
-		&router.CreatedAt, &router.UpdatedAt,
-	)
+	router, err := scanRouter(r.db.QueryRow(query, uuid))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("router not found")
@@ -126,9 +165,47 @@ func (r *RouterRepository) GetByUUID(uuid string) (*models.Router, error) {
 	return router, nil
 }
 
+// RouterSearchMinLength - Panjang minimal term Search (term pendek memaksa full scan notes)
+const RouterSearchMinLength = 2
+
+// likeEscaper - Escape wildcard LIKE supaya % dan _ dari input dicari apa adanya
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// Search - Cari router berdasarkan nama, hostname, lokasi, kontak, circuit ID atau isi notes
+func (r *RouterRepository) Search(term string) ([]*models.Router, error) {
+	if len([]rune(term)) < RouterSearchMinLength {
+		return nil, fmt.Errorf("search term must be at least %d characters", RouterSearchMinLength)
+	}
+
+	query := "SELECT " + routerColumns + ` FROM routers
+		WHERE name LIKE ? ESCAPE '\\' OR hostname LIKE ? ESCAPE '\\' OR location LIKE ? ESCAPE '\\'
+			OR description LIKE ? ESCAPE '\\' OR contact_name LIKE ? ESCAPE '\\'
+			OR contact_phone LIKE ? ESCAPE '\\' OR circuit_id LIKE ? ESCAPE '\\'
+			OR CAST(notes AS CHAR) LIKE ? ESCAPE '\\'
+		ORDER BY created_at DESC`
+
+	like := "%" + likeEscaper.Replace(term) + "%"
+	rows, err := r.db.Query(query, like, like, like, like, like, like, like, like)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routers []*models.Router
+	for rows.Next() {
+		router, err := scanRouter(rows)
+		if err != nil {
+			return nil, err
+		}
+		routers = append(routers, router)
+	}
+
+	return routers, nil
+}
+
 // GetActiveRouters - Ambil router yang aktif
 func (r *RouterRepository) GetActiveRouters() ([]*models.Router, error) {
-	query := `SELECT * FROM routers WHERE is_active = true ORDER BY created_at DESC`
+	query := "SELECT " + routerColumns + " FROM routers WHERE is_active = true ORDER BY created_at DESC"
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -138,14 +215,7 @@ func (r *RouterRepository) GetActiveRouters() ([]*models.Router, error) {
 
 	var routers []*models.Router
 	for rows.Next() {
-		router := &models.Router{}
-		err := rows.Scan(
-			&router.ID, &router.UUID, &router.Name, &router.Hostname,
-			&router.Username, &router.Password, &router.Keepalive, &router.Timeout,
-			&router.Port, &router.Location, &router.Description, &router.IsActive,
-			&router.LastSeen, &router.Status, &router.Version, &router.Uptime,
-			&router.CreatedAt, &router.UpdatedAt,
-		)
+		router, err := scanRouter(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -201,6 +271,74 @@ func (r *RouterRepository) Update(id int, req *models.RouterUpdateRequest) (*mod
 		updates = append(updates, "is_active = ?")
 		args = append(args, *req.IsActive)
 	}
+	if req.IsVirtual != nil {
+		updates = append(updates, "is_virtual = ?")
+		args = append(args, *req.IsVirtual)
+	}
+	if req.AutoConnect != nil {
+		updates = append(updates, "auto_connect = ?")
+		args = append(args, *req.AutoConnect)
+	}
+	if req.WANInterfaces != nil {
+		updates = append(updates, "wan_interfaces = ?")
+		args = append(args, *req.WANInterfaces)
+	}
+	if req.Tags != nil {
+		updates = append(updates, "tags = ?")
+		args = append(args, *req.Tags)
+	}
+	if req.ContactName != nil {
+		updates = append(updates, "contact_name = ?")
+		args = append(args, *req.ContactName)
+	}
+	if req.ContactPhone != nil {
+		updates = append(updates, "contact_phone = ?")
+		args = append(args, *req.ContactPhone)
+	}
+	if req.CircuitID != nil {
+		updates = append(updates, "circuit_id = ?")
+		args = append(args, *req.CircuitID)
+	}
+	if req.MonitoringURL != nil {
+		updates = append(updates, "monitoring_url = ?")
+		args = append(args, *req.MonitoringURL)
+	}
+	if req.Notes != nil {
+		updates = append(updates, "notes = ?")
+		args = append(args, nullableJSON(req.Notes))
+	}
+	if req.SourceAddress != nil {
+		updates = append(updates, "source_address = ?")
+		args = append(args, *req.SourceAddress)
+	}
+	if req.BindInterface != nil {
+		updates = append(updates, "bind_interface = ?")
+		args = append(args, *req.BindInterface)
+	}
+	if req.JumpType != nil {
+		updates = append(updates, "jump_type = ?")
+		args = append(args, *req.JumpType)
+	}
+	if req.JumpAddress != nil {
+		updates = append(updates, "jump_address = ?")
+		args = append(args, *req.JumpAddress)
+	}
+	if req.JumpUsername != nil {
+		updates = append(updates, "jump_username = ?")
+		args = append(args, *req.JumpUsername)
+	}
+	if req.JumpPassword != nil {
+		updates = append(updates, "jump_password = ?")
+		args = append(args, *req.JumpPassword)
+	}
+	if req.JumpPrivateKey != nil {
+		updates = append(updates, "jump_private_key = ?")
+		args = append(args, *req.JumpPrivateKey)
+	}
+	if req.JumpHostKey != nil {
+		updates = append(updates, "jump_host_key = ?")
+		args = append(args, *req.JumpHostKey)
+	}
 
 	if len(updates) == 0 {
 		return r.GetByID(id)
@@ -220,7 +358,7 @@ func (r *RouterRepository) Update(id int, req *models.RouterUpdateRequest) (*mod
 	return r.GetByID(id)
 }
 
-// UpdateStatus - Update status router
+// UpdateStatus - Update status router (perubahan status dicatat ke router_status_history)
 func (r *RouterRepository) UpdateStatus(id int, status *models.RouterStatusUpdate) error {
 	query := `
 		UPDATE routers 
@@ -228,13 +366,56 @@ func (r *RouterRepository) UpdateStatus(id int, status *models.RouterStatusUpdat
 		WHERE id = ?
 	`
 
-	lastSeen := time.Now()
+	now := time.Now()
+	lastSeen := now
 	if status.LastSeen != nil {
 		lastSeen = *status.LastSeen
 	}
 
-	_, err := r.db.Exec(query, status.Status, status.Version, status.Uptime, lastSeen, time.Now(), id)
-	return err
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := recordStatusChange(tx, id, status.Status, now); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(query, status.Status, status.Version, status.Uptime, lastSeen, now, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SetStatus - Ubah kolom status saja (tanpa menyentuh version/uptime/last_seen), perubahan dicatat ke history
+func (r *RouterRepository) SetStatus(id int, status string) error {
+	query := `UPDATE routers SET status = ?, updated_at = ? WHERE id = ?`
+	now := time.Now()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := recordStatusChange(tx, id, status, now); err != nil {
+		return err
+	}
+	result, err := tx.Exec(query, status, now, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("router not found")
+	}
+
+	return tx.Commit()
 }
 
 // SetActive - Set router sebagai aktif/non-aktif
@@ -264,9 +445,166 @@ func (r *RouterRepository) Delete(id int) error {
 	return nil
 }
 
+// routerOwnedTables - Tabel data yang ikut terhapus (ON DELETE CASCADE) bersama router
+var routerOwnedTables = []string{
+	"traffic_history", "wireless_history", "top_talkers", "flow_records", "queue_usage", "queue_samples",
+	"router_availability", "router_status_history", "alerts", "router_addresses", "router_backups",
+}
+
+// DeleteImpact - Hitung data yang ikut terhapus jika router dihapus
+func (r *RouterRepository) DeleteImpact(id int) (*models.RouterDeleteImpact, error) {
+	impact := &models.RouterDeleteImpact{RouterID: id, StoredRows: make(map[string]int64)}
+
+	counts := []struct {
+		query string
+		args  []interface{}
+		dest  *int
+	}{
+		{`SELECT COUNT(*) FROM customers WHERE router_id = ?`, []interface{}{id}, &impact.Customers},
+		{`SELECT COUNT(*) FROM queue_quotas WHERE router_id = ?`, []interface{}{id}, &impact.QueueQuotas},
+		{`SELECT COUNT(*) FROM monitored_paths WHERE source_router_id = ? OR target_router_id = ?`, []interface{}{id, id}, &impact.MonitoredPaths},
+	}
+	for _, c := range counts {
+		if err := r.db.QueryRow(c.query, c.args...).Scan(c.dest); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, table := range routerOwnedTables {
+		var rows int64
+		if err := r.db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE router_id = ?", id).Scan(&rows); err != nil {
+			return nil, err
+		}
+		impact.StoredRows[table] = rows
+	}
+
+	return impact, nil
+}
+
+// SetTunnel - Simpan registrasi tunnel balik router (field nil = hapus registrasi)
+func (r *RouterRepository) SetTunnel(id int, tunnel *models.RouterTunnel) error {
+	_, err := r.db.Exec(`UPDATE routers SET tunnel_concentrator_id = ?, tunnel_type = ?, tunnel_peer = ?, updated_at = ? WHERE id = ?`,
+		tunnel.TunnelConcentratorID, tunnel.TunnelType, tunnel.TunnelPeer, time.Now(), id)
+	return err
+}
+
+// GetTunneled - Router aktif yang terdaftar di concentrator tunnel
+func (r *RouterRepository) GetTunneled() ([]*models.Router, error) {
+	rows, err := r.db.Query("SELECT " + routerColumns + " FROM routers WHERE tunnel_concentrator_id IS NOT NULL AND is_active = TRUE")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var routers []*models.Router
+	for rows.Next() {
+		router, err := scanRouter(rows)
+		if err != nil {
+			return nil, err
+		}
+		routers = append(routers, router)
+	}
+	return routers, rows.Err()
+}
+
+// UpdateHostname - Ganti alamat dial router (alamat tunnel yang dipelajari)
+func (r *RouterRepository) UpdateHostname(id int, hostname string) error {
+	_, err := r.db.Exec(`UPDATE routers SET hostname = ?, updated_at = ? WHERE id = ?`, hostname, time.Now(), id)
+	return err
+}
+
+// SetActiveAddress - Catat alamat management yang dipakai koneksi saat ini
+func (r *RouterRepository) SetActiveAddress(id int, address string) error {
+	_, err := r.db.Exec(`UPDATE routers SET active_address = ? WHERE id = ?`, address, id)
+	return err
+}
+
+// ListAddresses - Alamat management tambahan router, urut priority
+func (r *RouterRepository) ListAddresses(routerID int) ([]*models.RouterAddress, error) {
+	rows, err := r.db.Query(`SELECT id, router_id, address, label, priority, created_at FROM router_addresses
+		WHERE router_id = ? ORDER BY priority, id`, routerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addresses := []*models.RouterAddress{}
+	for rows.Next() {
+		a := &models.RouterAddress{}
+		if err := rows.Scan(&a.ID, &a.RouterID, &a.Address, &a.Label, &a.Priority, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, a)
+	}
+	return addresses, rows.Err()
+}
+
+// ListAllAddresses - Alamat management tambahan semua router (untuk pencocokan source IP)
+func (r *RouterRepository) ListAllAddresses() ([]*models.RouterAddress, error) {
+	rows, err := r.db.Query(`SELECT id, router_id, address, label, priority, created_at FROM router_addresses
+		ORDER BY router_id, priority, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addresses := []*models.RouterAddress{}
+	for rows.Next() {
+		a := &models.RouterAddress{}
+		if err := rows.Scan(&a.ID, &a.RouterID, &a.Address, &a.Label, &a.Priority, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, a)
+	}
+	return addresses, rows.Err()
+}
+
+// AddAddress - Tambah alamat management router
+func (r *RouterRepository) AddAddress(routerID int, req *models.RouterAddressRequest) (*models.RouterAddress, error) {
+	label, priority := "secondary", 10
+	if req.Label != nil && *req.Label != "" {
+		label = *req.Label
+	}
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+
+	result, err := r.db.Exec(`INSERT INTO router_addresses (router_id, address, label, priority) VALUES (?, ?, ?, ?)`,
+		routerID, req.Address, label, priority)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	a := &models.RouterAddress{}
+	err = r.db.QueryRow(`SELECT id, router_id, address, label, priority, created_at FROM router_addresses WHERE id = ?`, id).
+		Scan(&a.ID, &a.RouterID, &a.Address, &a.Label, &a.Priority, &a.CreatedAt)
+	return a, err
+}
+
+// DeleteAddress - Hapus alamat management router
+func (r *RouterRepository) DeleteAddress(routerID, id int) error {
+	result, err := r.db.Exec(`DELETE FROM router_addresses WHERE id = ? AND router_id = ?`, id, routerID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("router address not found")
+	}
+	return nil
+}
+
 // GetByStatus - Ambil router by status
 func (r *RouterRepository) GetByStatus(status string) ([]*models.Router, error) {
-	query := `SELECT * FROM routers WHERE status = ? ORDER BY created_at DESC`
+	query := "SELECT " + routerColumns + " FROM routers WHERE status = ? ORDER BY created_at DESC"
 
 	rows, err := r.db.Query(query, status)
 	if err != nil {
@@ -276,14 +614,7 @@ func (r *RouterRepository) GetByStatus(status string) ([]*models.Router, error)
 
 	var routers []*models.Router
 	for rows.Next() {
-		router := &models.Router{}
-		err := rows.Scan(
-			&router.ID, &router.UUID, &router.Name, &router.Hostname,
-			&router.Username, &router.Password, &router.Keepalive, &router.Timeout,
-			&router.Port, &router.Location, &router.Description, &router.IsActive,
-			&router.LastSeen, &router.Status, &router.Version, &router.Uptime,
-			&router.CreatedAt, &router.UpdatedAt,
-		)
+		router, err := scanRouter(rows)
 		if err != nil {
 			return nil, err
 		}
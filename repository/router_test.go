@@ -0,0 +1,21 @@
+package repository
+
+import "testing"
+
+func TestLikeEscaper(t *testing.T) {
+	tests := []struct {
+		term string
+		want string
+	}{
+		{"core-01", "core-01"},
+		{"_", `\_`},
+		{"100%", `100\%`},
+		{`a\b`, `a\\b`},
+		{`%_\`, `\%\_\\`},
+	}
+	for _, tt := range tests {
+		if got := likeEscaper.Replace(tt.term); got != tt.want {
+			t.Errorf("likeEscaper.Replace(%q) = %q, want %q", tt.term, got, tt.want)
+		}
+	}
+}
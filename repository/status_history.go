@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// recordStatusChange - Catat transisi status jika berbeda dengan status saat ini.
+// Dipanggil dalam transaksi yang sama dengan UPDATE routers agar status lama terbaca konsisten.
+func recordStatusChange(tx *sql.Tx, routerID int, status string, at time.Time) error {
+	query := `
+		INSERT INTO router_status_history (router_id, from_status, status, changed_at)
+		SELECT id, status, ?, ? FROM routers
+		WHERE id = ? AND (status IS NULL OR status <> ?)
+	`
+	_, err := tx.Exec(query, status, at, routerID, status)
+	return err
+}
+
+// StatusChanges - Transisi status router dalam rentang [from, to), urut waktu
+func (r *RouterRepository) StatusChanges(routerID int, from, to time.Time) ([]*models.RouterStatusChange, error) {
+	query := `
+		SELECT id, router_id, from_status, status, changed_at
+		FROM router_status_history
+		WHERE router_id = ? AND changed_at >= ? AND changed_at < ?
+		ORDER BY changed_at, id
+	`
+
+	rows, err := r.db.Query(query, routerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*models.RouterStatusChange{}
+	for rows.Next() {
+		c := &models.RouterStatusChange{}
+		if err := rows.Scan(&c.ID, &c.RouterID, &c.FromStatus, &c.Status, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+// LastStatusChange - Transisi terakhir sebelum waktu tertentu (nil jika belum ada)
+func (r *RouterRepository) LastStatusChange(routerID int, before time.Time) (*models.RouterStatusChange, error) {
+	query := `
+		SELECT id, router_id, from_status, status, changed_at
+		FROM router_status_history
+		WHERE router_id = ? AND changed_at < ?
+		ORDER BY changed_at DESC, id DESC
+		LIMIT 1
+	`
+
+	c := &models.RouterStatusChange{}
+	err := r.db.QueryRow(query, routerID, before).Scan(&c.ID, &c.RouterID, &c.FromStatus, &c.Status, &c.ChangedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type TaskScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewTaskScheduleRepository(db *sql.DB) *TaskScheduleRepository {
+	return &TaskScheduleRepository{db: db}
+}
+
+// taskScheduleColumns - Urutan kolom yang dibaca oleh scanTaskSchedule
+const taskScheduleColumns = `id, name, action, router_id, cron, params, enabled, next_run_at, last_run_at, last_status,
+	last_output, last_error, created_by, created_at, updated_at`
+
+func scanTaskSchedule(row rowScanner) (*models.TaskSchedule, error) {
+	s := &models.TaskSchedule{}
+	var params sql.NullString
+	err := row.Scan(&s.ID, &s.Name, &s.Action, &s.RouterID, &s.Cron, &params, &s.Enabled, &s.NextRunAt, &s.LastRunAt,
+		&s.LastStatus, &s.LastOutput, &s.LastError, &s.CreatedBy, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if params.Valid && params.String != "" {
+		if err := json.Unmarshal([]byte(params.String), &s.Backup); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// taskScheduleParams - Parameter action dalam bentuk JSON (NULL jika tidak ada)
+func taskScheduleParams(s *models.TaskSchedule) (interface{}, error) {
+	if s.Backup == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(s.Backup)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Create - Tambah jadwal task
+func (r *TaskScheduleRepository) Create(s *models.TaskSchedule) (*models.TaskSchedule, error) {
+	params, err := taskScheduleParams(s)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO task_schedules (name, action, router_id, cron, params, enabled, next_run_at, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.Exec(query, s.Name, s.Action, s.RouterID, s.Cron, params, s.Enabled, s.NextRunAt, s.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(int(id))
+}
+
+// GetByID - Ambil jadwal task by ID
+func (r *TaskScheduleRepository) GetByID(id int) (*models.TaskSchedule, error) {
+	s, err := scanTaskSchedule(r.db.QueryRow("SELECT "+taskScheduleColumns+" FROM task_schedules WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("task schedule not found")
+	}
+	return s, err
+}
+
+// List - Semua jadwal task, opsional per router (routerID 0 = semua)
+func (r *TaskScheduleRepository) List(routerID int) ([]*models.TaskSchedule, error) {
+	query := "SELECT " + taskScheduleColumns + " FROM task_schedules"
+	var args []interface{}
+	if routerID > 0 {
+		query += " WHERE router_id = ?"
+		args = append(args, routerID)
+	}
+	return r.query(query+" ORDER BY name", args...)
+}
+
+// Due - Jadwal aktif yang waktu run-nya sudah lewat
+func (r *TaskScheduleRepository) Due(now time.Time) ([]*models.TaskSchedule, error) {
+	return r.query("SELECT "+taskScheduleColumns+` FROM task_schedules
+		WHERE enabled = TRUE AND next_run_at IS NOT NULL AND next_run_at <= ? ORDER BY next_run_at`, now)
+}
+
+func (r *TaskScheduleRepository) query(query string, args ...interface{}) ([]*models.TaskSchedule, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []*models.TaskSchedule{}
+	for rows.Next() {
+		s, err := scanTaskSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+
+	return schedules, rows.Err()
+}
+
+// Update - Simpan perubahan konfigurasi beserta waktu run berikutnya (hasil run tidak diubah)
+func (r *TaskScheduleRepository) Update(s *models.TaskSchedule) error {
+	params, err := taskScheduleParams(s)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE task_schedules SET name = ?, action = ?, router_id = ?, cron = ?, params = ?, enabled = ?,
+			next_run_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err = r.db.Exec(query, s.Name, s.Action, s.RouterID, s.Cron, params, s.Enabled, s.NextRunAt, time.Now(), s.ID)
+	return err
+}
+
+// Delete - Hapus jadwal task
+func (r *TaskScheduleRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM task_schedules WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("task schedule not found")
+	}
+	return nil
+}
+
+// SetNextRun - Majukan waktu run berikutnya (nil = tidak ada waktu cocok lagi)
+func (r *TaskScheduleRepository) SetNextRun(id int, next *time.Time) error {
+	_, err := r.db.Exec("UPDATE task_schedules SET next_run_at = ? WHERE id = ?", next, id)
+	return err
+}
+
+// SetResult - Simpan hasil run terakhir
+func (r *TaskScheduleRepository) SetResult(id int, run *models.TaskScheduleRun) error {
+	var output, lastError interface{}
+	if run.Output != "" {
+		output = run.Output
+	}
+	if run.Error != "" {
+		msg := run.Error
+		if len(msg) > 500 {
+			msg = msg[:500]
+		}
+		lastError = msg
+	}
+	_, err := r.db.Exec(`UPDATE task_schedules SET last_run_at = ?, last_status = ?, last_output = ?, last_error = ?
+		WHERE id = ?`, run.StartedAt, run.Status, output, lastError, id)
+	return err
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type ThreatFeedRepository struct {
+	db *sql.DB
+}
+
+func NewThreatFeedRepository(db *sql.DB) *ThreatFeedRepository {
+	return &ThreatFeedRepository{db: db}
+}
+
+const threatFeedColumns = `id, name, url, address_list, interval_minutes, max_entries, enabled, last_synced_at,
+	last_entry_count, last_error, created_at, updated_at`
+
+func scanThreatFeed(row rowScanner) (*models.ThreatFeed, error) {
+	f := &models.ThreatFeed{}
+	err := row.Scan(&f.ID, &f.Name, &f.URL, &f.AddressList, &f.IntervalMinutes, &f.MaxEntries, &f.Enabled, &f.LastSyncedAt,
+		&f.LastEntryCount, &f.LastError, &f.CreatedAt, &f.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	f.RouterIDs = []int{}
+	return f, nil
+}
+
+// Create - Tambah feed beserta router tujuannya
+func (r *ThreatFeedRepository) Create(f *models.ThreatFeed) (*models.ThreatFeed, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		INSERT INTO threat_feeds (name, url, address_list, interval_minutes, max_entries, enabled)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, f.Name, f.URL, f.AddressList, f.IntervalMinutes, f.MaxEntries, f.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	if err := setFeedRouters(tx, int(id), f.RouterIDs); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(int(id))
+}
+
+// GetByID - Ambil feed by ID
+func (r *ThreatFeedRepository) GetByID(id int) (*models.ThreatFeed, error) {
+	f, err := scanThreatFeed(r.db.QueryRow("SELECT "+threatFeedColumns+" FROM threat_feeds WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("threat feed not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, r.loadRouters([]*models.ThreatFeed{f})
+}
+
+// List - Semua feed (enabledOnly untuk syncer)
+func (r *ThreatFeedRepository) List(enabledOnly bool) ([]*models.ThreatFeed, error) {
+	query := "SELECT " + threatFeedColumns + " FROM threat_feeds"
+	if enabledOnly {
+		query += " WHERE enabled = TRUE"
+	}
+	query += " ORDER BY name"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	feeds := []*models.ThreatFeed{}
+	for rows.Next() {
+		f, err := scanThreatFeed(rows)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return feeds, r.loadRouters(feeds)
+}
+
+// loadRouters - Isi RouterIDs semua feed dengan satu query
+func (r *ThreatFeedRepository) loadRouters(feeds []*models.ThreatFeed) error {
+	byID := make(map[int]*models.ThreatFeed, len(feeds))
+	for _, f := range feeds {
+		byID[f.ID] = f
+	}
+
+	rows, err := r.db.Query(`SELECT feed_id, router_id FROM threat_feed_routers ORDER BY router_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var feedID, routerID int
+		if err := rows.Scan(&feedID, &routerID); err != nil {
+			return err
+		}
+		if f := byID[feedID]; f != nil {
+			f.RouterIDs = append(f.RouterIDs, routerID)
+		}
+	}
+	return rows.Err()
+}
+
+// Update - Simpan perubahan feed dan ganti router tujuannya
+func (r *ThreatFeedRepository) Update(f *models.ThreatFeed) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE threat_feeds SET name = ?, url = ?, address_list = ?, interval_minutes = ?, max_entries = ?, enabled = ?
+		WHERE id = ?
+	`, f.Name, f.URL, f.AddressList, f.IntervalMinutes, f.MaxEntries, f.Enabled, f.ID); err != nil {
+		return err
+	}
+	if err := setFeedRouters(tx, f.ID, f.RouterIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func setFeedRouters(tx *sql.Tx, feedID int, routerIDs []int) error {
+	if _, err := tx.Exec(`DELETE FROM threat_feed_routers WHERE feed_id = ?`, feedID); err != nil {
+		return err
+	}
+	for _, routerID := range routerIDs {
+		if _, err := tx.Exec(`INSERT IGNORE INTO threat_feed_routers (feed_id, router_id) VALUES (?, ?)`,
+			feedID, routerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetSynced - Simpan hasil sync terakhir (lastError nil = berhasil di semua router)
+func (r *ThreatFeedRepository) SetSynced(id, entries int, lastError *string) error {
+	if lastError != nil && len(*lastError) > 500 {
+		truncated := (*lastError)[:500]
+		lastError = &truncated
+	}
+	_, err := r.db.Exec(`UPDATE threat_feeds SET last_synced_at = ?, last_entry_count = ?, last_error = ? WHERE id = ?`,
+		time.Now(), entries, lastError, id)
+	return err
+}
+
+// Delete - Hapus feed
+func (r *ThreatFeedRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM threat_feeds WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("threat feed not found")
+	}
+	return nil
+}
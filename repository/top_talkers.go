@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type TopTalkersRepository struct {
+	db *sql.DB
+}
+
+func NewTopTalkersRepository(db *sql.DB) *TopTalkersRepository {
+	return &TopTalkersRepository{db: db}
+}
+
+// Upsert - Tambahkan hasil satu sesi torch ke bucket (akumulasi sum & jumlah sample)
+func (r *TopTalkersRepository) Upsert(routerID int, iface string, bucket time.Time, talkers []*models.TopTalker) error {
+	query := `
+		INSERT INTO top_talkers (router_id, interface, bucket_start, direction, address, rx_bps_sum, tx_bps_sum, samples)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 1)
+		ON DUPLICATE KEY UPDATE
+			rx_bps_sum = rx_bps_sum + VALUES(rx_bps_sum),
+			tx_bps_sum = tx_bps_sum + VALUES(tx_bps_sum),
+			samples = samples + 1
+	`
+
+	for _, t := range talkers {
+		if _, err := r.db.Exec(query, routerID, iface, bucket, t.Direction, t.Address, t.RxBps, t.TxBps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Query - Rata-rata bps per alamat dalam rentang waktu, urut dari total terbesar
+func (r *TopTalkersRepository) Query(filter *models.TopTalkerFilter) ([]*models.TopTalker, error) {
+	where := []string{"router_id = ?", "bucket_start BETWEEN ? AND ?"}
+	args := []interface{}{filter.RouterID, filter.From, filter.To}
+
+	if filter.Interface != "" {
+		where = append(where, "interface = ?")
+		args = append(args, filter.Interface)
+	}
+	if filter.Direction != "" {
+		where = append(where, "direction = ?")
+		args = append(args, filter.Direction)
+	}
+	if filter.Address != "" {
+		where = append(where, "address = ?")
+		args = append(args, filter.Address)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	args = append(args, limit)
+
+	query := `
+		SELECT address, direction, SUM(rx_bps_sum) / SUM(samples) AS rx_bps, SUM(tx_bps_sum) / SUM(samples) AS tx_bps,
+			SUM(samples), MIN(bucket_start), MAX(bucket_start)
+		FROM top_talkers
+		WHERE ` + strings.Join(where, " AND ") + `
+		GROUP BY address, direction
+		ORDER BY rx_bps + tx_bps DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var talkers []*models.TopTalker
+	for rows.Next() {
+		t := &models.TopTalker{}
+		var first, last time.Time
+		if err := rows.Scan(&t.Address, &t.Direction, &t.RxBps, &t.TxBps, &t.Samples, &first, &last); err != nil {
+			return nil, err
+		}
+		t.FirstSeen = &first
+		t.LastSeen = &last
+		talkers = append(talkers, t)
+	}
+
+	return talkers, nil
+}
+
+// DeleteOlderThan - Hapus bucket yang lebih tua dari t
+func (r *TopTalkersRepository) DeleteOlderThan(t time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM top_talkers WHERE bucket_start < ?", t)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+type TopologyRepository struct {
+	db *sql.DB
+}
+
+func NewTopologyRepository(db *sql.DB) *TopologyRepository {
+	return &TopologyRepository{db: db}
+}
+
+const topologyLinkColumns = `id, router_id, interface, neighbor_mac, neighbor_identity, neighbor_address,
+	neighbor_interface, neighbor_router_id, platform, board, version, discovered_by, active,
+	first_seen, last_seen, changed_at`
+
+func scanTopologyLink(row rowScanner) (*models.TopologyLink, error) {
+	l := &models.TopologyLink{}
+	err := row.Scan(&l.ID, &l.RouterID, &l.Interface, &l.NeighborMAC, &l.NeighborIdentity, &l.NeighborAddress,
+		&l.NeighborInterface, &l.NeighborRouterID, &l.Platform, &l.Board, &l.Version, &l.DiscoveredBy, &l.Active,
+		&l.FirstSeen, &l.LastSeen, &l.ChangedAt)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// List - Link topologi (routerID 0 = semua router), activeOnly untuk link yang masih terlihat
+func (r *TopologyRepository) List(routerID int, activeOnly bool) ([]*models.TopologyLink, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if routerID != 0 {
+		conditions = append(conditions, "router_id = ?")
+		args = append(args, routerID)
+	}
+	if activeOnly {
+		conditions = append(conditions, "active = TRUE")
+	}
+
+	query := "SELECT " + topologyLinkColumns + " FROM topology_links"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY router_id, interface, neighbor_identity"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*models.TopologyLink{}
+	for rows.Next() {
+		l, err := scanTopologyLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, rows.Err()
+}
+
+// Save - Simpan link yang terlihat di poll terakhir (insert atau aktifkan ulang)
+func (r *TopologyRepository) Save(l *models.TopologyLink) error {
+	// changed_at di-assign sebelum active supaya masih melihat status lama
+	_, err := r.db.Exec(`
+		INSERT INTO topology_links (router_id, interface, neighbor_mac, neighbor_identity, neighbor_address,
+			neighbor_interface, neighbor_router_id, platform, board, version, discovered_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			neighbor_address = VALUES(neighbor_address),
+			neighbor_interface = VALUES(neighbor_interface),
+			neighbor_router_id = VALUES(neighbor_router_id),
+			platform = VALUES(platform),
+			board = VALUES(board),
+			version = VALUES(version),
+			discovered_by = VALUES(discovered_by),
+			last_seen = CURRENT_TIMESTAMP,
+			changed_at = IF(active, changed_at, CURRENT_TIMESTAMP),
+			active = TRUE
+	`, l.RouterID, l.Interface, l.NeighborMAC, l.NeighborIdentity, l.NeighborAddress,
+		l.NeighborInterface, l.NeighborRouterID, l.Platform, l.Board, l.Version, l.DiscoveredBy)
+	return err
+}
+
+// MarkInactive - Tandai link yang tidak terlihat lagi
+func (r *TopologyRepository) MarkInactive(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	in, args := inClause(ids)
+	_, err := r.db.Exec(`UPDATE topology_links SET active = FALSE, changed_at = CURRENT_TIMESTAMP
+		WHERE active = TRUE AND id IN (`+in+`)`, args...)
+	return err
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type TrafficRepository struct {
+	db *sql.DB
+}
+
+func NewTrafficRepository(db *sql.DB) *TrafficRepository {
+	return &TrafficRepository{db: db}
+}
+
+// InsertSample - Simpan satu sample traffic
+func (r *TrafficRepository) InsertSample(sample *models.TrafficSample) error {
+	query := `
+		INSERT INTO traffic_history (router_id, interface, rx_bps, tx_bps, rx_bytes, tx_bytes,
+			rx_errors, tx_errors, rx_drops, tx_drops, fcs_errors, sampled_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.Exec(query, sample.RouterID, sample.Interface, sample.RxBps, sample.TxBps,
+		sample.RxBytes, sample.TxBytes, sample.RxErrors, sample.TxErrors, sample.RxDrops, sample.TxDrops,
+		sample.FCSErrors, sample.SampledAt)
+	return err
+}
+
+// GetHistory - Ambil sample interface dalam rentang waktu (urut naik)
+func (r *TrafficRepository) GetHistory(routerID int, iface string, from, to time.Time) ([]*models.TrafficSample, error) {
+	var samples []*models.TrafficSample
+	err := r.EachHistory(routerID, iface, from, to, func(s *models.TrafficSample) error {
+		samples = append(samples, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return samples, nil
+}
+
+// EachHistory - Iterasi sample interface per baris tanpa menampung semua hasil (untuk export)
+func (r *TrafficRepository) EachHistory(routerID int, iface string, from, to time.Time, fn func(*models.TrafficSample) error) error {
+	query := `
+		SELECT router_id, interface, rx_bps, tx_bps, rx_bytes, tx_bytes,
+			rx_errors, tx_errors, rx_drops, tx_drops, fcs_errors, sampled_at
+		FROM traffic_history
+		WHERE router_id = ? AND interface = ? AND sampled_at BETWEEN ? AND ?
+		ORDER BY sampled_at ASC
+	`
+
+	rows, err := r.db.Query(query, routerID, iface, from, to)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := &models.TrafficSample{}
+		if err := rows.Scan(&s.RouterID, &s.Interface, &s.RxBps, &s.TxBps,
+			&s.RxBytes, &s.TxBytes, &s.RxErrors, &s.TxErrors, &s.RxDrops, &s.TxDrops,
+			&s.FCSErrors, &s.SampledAt); err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// HourlyAverages - Rata-rata rx/tx per jam sejak waktu tertentu (urut naik)
+func (r *TrafficRepository) HourlyAverages(routerID int, iface string, since time.Time) ([]*models.HourlyTraffic, error) {
+	query := `
+		SELECT DATE_FORMAT(sampled_at, '%Y-%m-%d %H:00:00') AS hour, AVG(rx_bps), AVG(tx_bps)
+		FROM traffic_history
+		WHERE router_id = ? AND interface = ? AND sampled_at >= ?
+		GROUP BY hour
+		ORDER BY hour ASC
+	`
+
+	rows, err := r.db.Query(query, routerID, iface, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*models.HourlyTraffic
+	for rows.Next() {
+		h := &models.HourlyTraffic{}
+		var hour string
+		if err := rows.Scan(&hour, &h.RxBps, &h.TxBps); err != nil {
+			return nil, err
+		}
+		h.Hour, _ = time.ParseInLocation("2006-01-02 15:04:05", hour, time.Local)
+		result = append(result, h)
+	}
+
+	return result, nil
+}
+
+// RecentAverage - Rata-rata rx/tx sejak waktu tertentu beserta jumlah sample
+func (r *TrafficRepository) RecentAverage(routerID int, iface string, since time.Time) (float64, float64, int, error) {
+	query := `
+		SELECT COALESCE(AVG(rx_bps), 0), COALESCE(AVG(tx_bps), 0), COUNT(*)
+		FROM traffic_history
+		WHERE router_id = ? AND interface = ? AND sampled_at >= ?
+	`
+
+	var rx, tx float64
+	var count int
+	err := r.db.QueryRow(query, routerID, iface, since).Scan(&rx, &tx, &count)
+	return rx, tx, count, err
+}
+
+// ListSampledInterfaces - Router/interface yang punya sample sejak waktu tertentu
+func (r *TrafficRepository) ListSampledInterfaces(since time.Time) ([]*models.SampledInterface, error) {
+	query := `
+		SELECT DISTINCT router_id, interface
+		FROM traffic_history
+		WHERE sampled_at >= ?
+	`
+
+	rows, err := r.db.Query(query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*models.SampledInterface
+	for rows.Next() {
+		si := &models.SampledInterface{}
+		if err := rows.Scan(&si.RouterID, &si.Interface); err != nil {
+			return nil, err
+		}
+		result = append(result, si)
+	}
+
+	return result, nil
+}
+
+// DeleteOlderThan - Hapus history lama (retention)
+func (r *TrafficRepository) DeleteOlderThan(t time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM traffic_history WHERE sampled_at < ?`, t)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SaveSampler - Simpan (upsert) sampler aktif supaya bisa dilanjutkan setelah restart
+func (r *TrafficRepository) SaveSampler(routerID int, iface string, intervalSeconds int) error {
+	_, err := r.db.Exec(`
+		INSERT INTO traffic_samplers (router_id, interface, interval_seconds) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE interval_seconds = VALUES(interval_seconds)
+	`, routerID, iface, intervalSeconds)
+	return err
+}
+
+// DeleteSampler - Hapus sampler tersimpan
+func (r *TrafficRepository) DeleteSampler(routerID int, iface string) error {
+	_, err := r.db.Exec(`DELETE FROM traffic_samplers WHERE router_id = ? AND interface = ?`, routerID, iface)
+	return err
+}
+
+// DeleteRouterSamplers - Hapus semua sampler tersimpan milik router
+func (r *TrafficRepository) DeleteRouterSamplers(routerID int) error {
+	_, err := r.db.Exec(`DELETE FROM traffic_samplers WHERE router_id = ?`, routerID)
+	return err
+}
+
+// SetMonitoredInterfaces - Ganti daftar monitored interface router: interface di daftar disimpan
+// sebagai sampler monitored, monitored interface lama yang tidak ada di daftar dihapus
+func (r *TrafficRepository) SetMonitoredInterfaces(routerID int, ifaces []string, intervalSeconds int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `DELETE FROM traffic_samplers WHERE router_id = ? AND monitored = TRUE`
+	args := []interface{}{routerID}
+	if len(ifaces) > 0 {
+		query += ` AND interface NOT IN (?` + strings.Repeat(", ?", len(ifaces)-1) + `)`
+		for _, iface := range ifaces {
+			args = append(args, iface)
+		}
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	for _, iface := range ifaces {
+		if _, err := tx.Exec(`
+			INSERT INTO traffic_samplers (router_id, interface, interval_seconds, monitored) VALUES (?, ?, ?, TRUE)
+			ON DUPLICATE KEY UPDATE interval_seconds = VALUES(interval_seconds), monitored = TRUE
+		`, routerID, iface, intervalSeconds); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetSampler - Sampler tersimpan untuk interface router (nil jika tidak ada)
+func (r *TrafficRepository) GetSampler(routerID int, iface string) (*models.SamplerInfo, error) {
+	info := &models.SamplerInfo{}
+	err := r.db.QueryRow(`SELECT router_id, interface, interval_seconds, monitored FROM traffic_samplers
+		WHERE router_id = ? AND interface = ?`, routerID, iface).
+		Scan(&info.RouterID, &info.Interface, &info.IntervalSeconds, &info.Monitored)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// ListSamplers - Semua sampler tersimpan (hanya router_id, interface, interval_seconds, monitored terisi)
+func (r *TrafficRepository) ListSamplers() ([]*models.SamplerInfo, error) {
+	rows, err := r.db.Query(`SELECT router_id, interface, interval_seconds, monitored FROM traffic_samplers ORDER BY router_id, interface`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*models.SamplerInfo
+	for rows.Next() {
+		info := &models.SamplerInfo{}
+		if err := rows.Scan(&info.RouterID, &info.Interface, &info.IntervalSeconds, &info.Monitored); err != nil {
+			return nil, err
+		}
+		result = append(result, info)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type UsageRepository struct {
+	db *sql.DB
+}
+
+func NewUsageRepository(db *sql.DB) *UsageRepository {
+	return &UsageRepository{db: db}
+}
+
+// AddUsage - Tambahkan delta byte ke total harian dan bulanan queue
+func (r *UsageRepository) AddUsage(routerID int, queueName string, day, month time.Time, upload, download uint64) error {
+	query := `
+		INSERT INTO queue_usage (router_id, queue_name, period, period_start, upload_bytes, download_bytes)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			upload_bytes = upload_bytes + VALUES(upload_bytes),
+			download_bytes = download_bytes + VALUES(download_bytes)
+	`
+
+	if _, err := r.db.Exec(query, routerID, queueName, "day", day, upload, download); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(query, routerID, queueName, "month", month, upload, download)
+	return err
+}
+
+// List - Pemakaian queue per periode; untuk period month disertakan kuota jika ada
+func (r *UsageRepository) List(filter *models.UsageFilter) ([]*models.QueueUsage, error) {
+	var usages []*models.QueueUsage
+	err := r.Each(filter, func(u *models.QueueUsage) error {
+		usages = append(usages, u)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+// Each - Iterasi pemakaian queue per baris tanpa menampung semua hasil (untuk export)
+func (r *UsageRepository) Each(filter *models.UsageFilter, fn func(*models.QueueUsage) error) error {
+	query := `
+		SELECT u.router_id, u.queue_name, u.period, u.period_start, u.upload_bytes, u.download_bytes,
+			q.monthly_quota_bytes, u.updated_at
+		FROM queue_usage u
+		LEFT JOIN queue_quotas q ON u.period = 'month' AND q.router_id = u.router_id AND q.queue_name = u.queue_name
+		WHERE u.router_id = ? AND u.period = ? AND u.period_start BETWEEN ? AND ?
+	`
+	args := []interface{}{filter.RouterID, filter.Period, filter.From, filter.To}
+
+	if filter.QueueName != "" {
+		query += " AND u.queue_name = ?"
+		args = append(args, filter.QueueName)
+	}
+	query += " ORDER BY u.period_start DESC, (u.upload_bytes + u.download_bytes) DESC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		u := &models.QueueUsage{}
+		var quota sql.NullInt64
+		if err := rows.Scan(&u.RouterID, &u.QueueName, &u.Period, &u.PeriodStart,
+			&u.UploadBytes, &u.DownloadBytes, &quota, &u.UpdatedAt); err != nil {
+			return err
+		}
+		u.TotalBytes = u.UploadBytes + u.DownloadBytes
+		if quota.Valid {
+			q := uint64(quota.Int64)
+			u.QuotaBytes = &q
+		}
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// RouterTotals - Total pemakaian per router (semua queue) dalam rentang periode
+func (r *UsageRepository) RouterTotals(routerIDs []int, period string, from, to time.Time) ([]*models.RouterUsageTotal, error) {
+	totals := []*models.RouterUsageTotal{}
+	if len(routerIDs) == 0 {
+		return totals, nil
+	}
+
+	in, args := inClause(routerIDs)
+	query := `
+		SELECT u.router_id, r.name, COUNT(DISTINCT u.queue_name),
+			COALESCE(SUM(u.upload_bytes), 0), COALESCE(SUM(u.download_bytes), 0)
+		FROM queue_usage u JOIN routers r ON r.id = u.router_id
+		WHERE u.router_id IN (` + in + `) AND u.period = ? AND u.period_start BETWEEN ? AND ?
+		GROUP BY u.router_id, r.name
+		ORDER BY SUM(u.upload_bytes + u.download_bytes) DESC
+	`
+	args = append(args, period, from, to)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := &models.RouterUsageTotal{}
+		if err := rows.Scan(&t.RouterID, &t.RouterName, &t.Queues, &t.UploadBytes, &t.DownloadBytes); err != nil {
+			return nil, err
+		}
+		t.TotalBytes = t.UploadBytes + t.DownloadBytes
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// quotaColumns - Urutan kolom yang dibaca oleh scanQuota
+const quotaColumns = `q.id, q.router_id, q.queue_name, q.monthly_quota_bytes, q.action, q.throttle_limit, q.address_list,
+	q.alerted_period, q.enforced_period, q.original_max_limit, q.created_at, q.updated_at`
+
+func scanQuota(row rowScanner, extra ...interface{}) (*models.QueueQuota, error) {
+	q := &models.QueueQuota{}
+	dest := []interface{}{&q.ID, &q.RouterID, &q.QueueName, &q.MonthlyQuotaBytes, &q.Action, &q.ThrottleLimit,
+		&q.AddressList, &q.AlertedPeriod, &q.EnforcedPeriod, &q.OriginalMaxLimit, &q.CreatedAt, &q.UpdatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// UpsertQuota - Set kuota bulanan & aksi queue (reset status alert)
+func (r *UsageRepository) UpsertQuota(quota *models.QueueQuota) error {
+	query := `
+		INSERT INTO queue_quotas (router_id, queue_name, monthly_quota_bytes, action, throttle_limit, address_list)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			monthly_quota_bytes = VALUES(monthly_quota_bytes),
+			action = VALUES(action),
+			throttle_limit = VALUES(throttle_limit),
+			address_list = VALUES(address_list),
+			alerted_period = NULL
+	`
+	_, err := r.db.Exec(query, quota.RouterID, quota.QueueName, quota.MonthlyQuotaBytes,
+		quota.Action, quota.ThrottleLimit, quota.AddressList)
+	return err
+}
+
+// GetQuota - Kuota satu queue
+func (r *UsageRepository) GetQuota(routerID int, queueName string) (*models.QueueQuota, error) {
+	row := r.db.QueryRow("SELECT "+quotaColumns+" FROM queue_quotas q WHERE q.router_id = ? AND q.queue_name = ?",
+		routerID, queueName)
+	quota, err := scanQuota(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("quota not found")
+	}
+	return quota, err
+}
+
+// DeleteQuota - Hapus kuota queue
+func (r *UsageRepository) DeleteQuota(routerID int, queueName string) error {
+	result, err := r.db.Exec("DELETE FROM queue_quotas WHERE router_id = ? AND queue_name = ?", routerID, queueName)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("quota not found")
+	}
+	return nil
+}
+
+// ListQuotas - Semua kuota satu router
+func (r *UsageRepository) ListQuotas(routerID int) ([]*models.QueueQuota, error) {
+	rows, err := r.db.Query("SELECT "+quotaColumns+" FROM queue_quotas q WHERE q.router_id = ? ORDER BY q.queue_name", routerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var quotas []*models.QueueQuota
+	for rows.Next() {
+		q, err := scanQuota(rows)
+		if err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, q)
+	}
+
+	return quotas, nil
+}
+
+// ExceededQuotas - Kuota yang terlampaui pada bulan tertentu
+func (r *UsageRepository) ExceededQuotas(month time.Time) ([]*models.QuotaStatus, error) {
+	return r.quotaStatuses(`
+		JOIN queue_usage u ON u.router_id = q.router_id AND u.queue_name = q.queue_name
+			AND u.period = 'month' AND u.period_start = ?
+		WHERE u.upload_bytes + u.download_bytes > q.monthly_quota_bytes
+	`, month)
+}
+
+// EnforcedQuotas - Kuota dengan enforcement aktif beserta pemakaian bulan tertentu
+func (r *UsageRepository) EnforcedQuotas(month time.Time) ([]*models.QuotaStatus, error) {
+	return r.quotaStatuses(`
+		LEFT JOIN queue_usage u ON u.router_id = q.router_id AND u.queue_name = q.queue_name
+			AND u.period = 'month' AND u.period_start = ?
+		WHERE q.enforced_period IS NOT NULL
+	`, month)
+}
+
+func (r *UsageRepository) quotaStatuses(clause string, args ...interface{}) ([]*models.QuotaStatus, error) {
+	query := "SELECT " + quotaColumns + ", COALESCE(u.upload_bytes + u.download_bytes, 0) FROM queue_quotas q " + clause
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []*models.QuotaStatus
+	for rows.Next() {
+		var used uint64
+		q, err := scanQuota(rows, &used)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, &models.QuotaStatus{QueueQuota: *q, UsedBytes: used})
+	}
+
+	return statuses, nil
+}
+
+// MarkQuotaAlerted - Tandai alert kuota sudah dikirim untuk bulan tersebut
+func (r *UsageRepository) MarkQuotaAlerted(quotaID int, month time.Time) error {
+	_, err := r.db.Exec("UPDATE queue_quotas SET alerted_period = ? WHERE id = ?", month, quotaID)
+	return err
+}
+
+// MarkQuotaEnforced - Simpan bulan enforcement dan max-limit asli (untuk revert)
+func (r *UsageRepository) MarkQuotaEnforced(quotaID int, month time.Time, originalMaxLimit *string) error {
+	_, err := r.db.Exec("UPDATE queue_quotas SET enforced_period = ?, original_max_limit = ? WHERE id = ?",
+		month, originalMaxLimit, quotaID)
+	return err
+}
+
+// ClearQuotaEnforcement - Enforcement sudah di-revert
+func (r *UsageRepository) ClearQuotaEnforcement(quotaID int) error {
+	_, err := r.db.Exec("UPDATE queue_quotas SET enforced_period = NULL, original_max_limit = NULL WHERE id = ?", quotaID)
+	return err
+}
+
+// SetQuotaBytes - Set kuota bulanan queue dari plan; aksi enforcement yang sudah ada dipertahankan
+func (r *UsageRepository) SetQuotaBytes(routerID int, queueName string, quotaBytes uint64) error {
+	query := `
+		INSERT INTO queue_quotas (router_id, queue_name, monthly_quota_bytes)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE monthly_quota_bytes = VALUES(monthly_quota_bytes)
+	`
+	_, err := r.db.Exec(query, routerID, queueName, quotaBytes)
+	return err
+}
+
+// InsertQueueSample - Simpan rata-rata rate queue satu interval sampling
+func (r *UsageRepository) InsertQueueSample(routerID int, queueName string, sample *models.QueueRateSample) error {
+	_, err := r.db.Exec(`
+		INSERT INTO queue_samples (router_id, queue_name, upload_bps, download_bps, sampled_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, routerID, queueName, sample.UploadBps, sample.DownloadBps, sample.SampledAt)
+	return err
+}
+
+// EachQueueSample - Iterasi sample rate queue dalam rentang waktu (urut naik)
+func (r *UsageRepository) EachQueueSample(routerID int, queueName string, from, to time.Time, fn func(*models.QueueRateSample) error) error {
+	rows, err := r.db.Query(`
+		SELECT upload_bps, download_bps, sampled_at
+		FROM queue_samples
+		WHERE router_id = ? AND queue_name = ? AND sampled_at >= ? AND sampled_at < ?
+		ORDER BY sampled_at ASC
+	`, routerID, queueName, from, to)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := &models.QueueRateSample{}
+		if err := rows.Scan(&s.UploadBps, &s.DownloadBps, &s.SampledAt); err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// DeleteQueueSamplesOlderThan - Hapus sample rate queue lama (retention)
+func (r *UsageRepository) DeleteQueueSamplesOlderThan(t time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM queue_samples WHERE sampled_at < ?", t)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,509 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type UserRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Create - Tambah user baru
+func (r *UserRepository) Create(req *models.UserCreateRequest) (*models.User, error) {
+	result, err := r.db.Exec(`INSERT INTO users (username, role, reseller_id) VALUES (?, ?, ?)`,
+		req.Username, req.Role, req.ResellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(int(id))
+}
+
+// GetAll - Semua user urut username
+func (r *UserRepository) GetAll() ([]*models.User, error) {
+	rows, err := r.db.Query(`SELECT id, username, role, reseller_id, totp_enabled, created_at FROM users ORDER BY username`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	for rows.Next() {
+		user := &models.User{}
+		if err := rows.Scan(&user.ID, &user.Username, &user.Role, &user.ResellerID, &user.TOTP, &user.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// GetByID - Ambil user beserta scope router-nya
+func (r *UserRepository) GetByID(id int) (*models.User, error) {
+	user := &models.User{}
+	err := r.db.QueryRow(`SELECT id, username, role, reseller_id, totp_enabled, created_at FROM users WHERE id = ?`, id).
+		Scan(&user.ID, &user.Username, &user.Role, &user.ResellerID, &user.TOTP, &user.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Scopes, err = r.Scopes(id); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Delete - Hapus user (token dan scope ikut terhapus)
+func (r *UserRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM users WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+// UserByTokenHash - User pemilik token (hash SHA-256), sekaligus catat last_used_at
+func (r *UserRepository) UserByTokenHash(hash string) (*models.User, error) {
+	var tokenID int
+	var allowed sql.NullString
+	user := &models.User{}
+	err := r.db.QueryRow(`
+		SELECT t.id, t.allowed_cidrs, u.id, u.username, u.role, u.reseller_id, u.totp_enabled, u.created_at
+		FROM api_tokens t JOIN users u ON u.id = t.user_id
+		WHERE t.token_hash = ?
+	`, hash).Scan(&tokenID, &allowed, &user.ID, &user.Username, &user.Role, &user.ResellerID, &user.TOTP, &user.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	user.AllowedCIDRs = splitList(allowed)
+
+	if _, err := r.db.Exec(`UPDATE api_tokens SET last_used_at = ? WHERE id = ?`, time.Now(), tokenID); err != nil {
+		return nil, err
+	}
+
+	if user.Scopes, err = r.EffectiveScopes(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// EffectiveScopes - Scope eksplisit user ditambah semua router milik reseller-nya (semua interface).
+// Scope eksplisit pada router reseller tetap berlaku (membatasi interface).
+func (r *UserRepository) EffectiveScopes(user *models.User) ([]models.UserScope, error) {
+	scopes, err := r.Scopes(user.ID)
+	if err != nil || user.ResellerID == nil {
+		return scopes, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT router_id FROM reseller_routers
+		WHERE reseller_id = ? AND router_id NOT IN (SELECT router_id FROM user_scopes WHERE user_id = ?)
+		ORDER BY router_id
+	`, *user.ResellerID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var scope models.UserScope
+		if err := rows.Scan(&scope.RouterID); err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, rows.Err()
+}
+
+// Scopes - Scope router/interface milik user
+func (r *UserRepository) Scopes(userID int) ([]models.UserScope, error) {
+	rows, err := r.db.Query(`SELECT router_id, interfaces FROM user_scopes WHERE user_id = ? ORDER BY router_id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scopes := []models.UserScope{}
+	for rows.Next() {
+		var scope models.UserScope
+		var interfaces sql.NullString
+		if err := rows.Scan(&scope.RouterID, &interfaces); err != nil {
+			return nil, err
+		}
+		if interfaces.Valid && interfaces.String != "" {
+			scope.Interfaces = strings.Split(interfaces.String, ",")
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, rows.Err()
+}
+
+// SetScopes - Ganti semua scope user dalam satu transaksi
+func (r *UserRepository) SetScopes(userID int, scopes []models.UserScope) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM user_scopes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	for _, scope := range scopes {
+		var interfaces *string
+		if len(scope.Interfaces) > 0 {
+			joined := strings.Join(scope.Interfaces, ",")
+			interfaces = &joined
+		}
+		if _, err := tx.Exec(`INSERT INTO user_scopes (user_id, router_id, interfaces) VALUES (?, ?, ?)`,
+			userID, scope.RouterID, interfaces); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// CreateToken - Simpan hash token baru untuk user (allowed kosong = semua IP)
+func (r *UserRepository) CreateToken(userID int, name, hash string, allowed []string) (*models.APIToken, error) {
+	result, err := r.db.Exec(`INSERT INTO api_tokens (user_id, name, token_hash, allowed_cidrs) VALUES (?, ?, ?, ?)`,
+		userID, name, hash, joinList(allowed))
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanToken(r.db.QueryRow(`SELECT id, user_id, name, allowed_cidrs, created_at, last_used_at FROM api_tokens WHERE id = ?`, id))
+}
+
+func scanToken(row rowScanner) (*models.APIToken, error) {
+	token := &models.APIToken{}
+	var allowed sql.NullString
+	if err := row.Scan(&token.ID, &token.UserID, &token.Name, &allowed, &token.CreatedAt, &token.LastUsedAt); err != nil {
+		return nil, err
+	}
+	token.AllowedCIDRs = splitList(allowed)
+	return token, nil
+}
+
+// joinList / splitList - Simpan daftar sebagai kolom comma-separated (NULL = kosong)
+func joinList(items []string) *string {
+	if len(items) == 0 {
+		return nil
+	}
+	joined := strings.Join(items, ",")
+	return &joined
+}
+
+func splitList(v sql.NullString) []string {
+	if !v.Valid || v.String == "" {
+		return nil
+	}
+	return strings.Split(v.String, ",")
+}
+
+// ListTokens - Token milik user (tanpa nilai token)
+func (r *UserRepository) ListTokens(userID int) ([]*models.APIToken, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, name, allowed_cidrs, created_at, last_used_at FROM api_tokens
+		WHERE user_id = ? ORDER BY id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []*models.APIToken{}
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, rows.Err()
+}
+
+// DeleteToken - Cabut token user
+func (r *UserRepository) DeleteToken(userID, tokenID int) error {
+	result, err := r.db.Exec(`DELETE FROM api_tokens WHERE id = ? AND user_id = ?`, tokenID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("token not found")
+	}
+	return nil
+}
+
+// sessionColumns - Urutan kolom yang dibaca oleh scanSession (tanpa hash token)
+const sessionColumns = `id, user_id, name, ip_address, user_agent, allowed_cidrs, access_expires_at,
+	refresh_expires_at, created_at, last_used_at, revoked_at`
+
+func scanSession(row rowScanner) (*models.AuthSession, error) {
+	s := &models.AuthSession{}
+	var allowed sql.NullString
+	err := row.Scan(&s.ID, &s.UserID, &s.Name, &s.IPAddress, &s.UserAgent, &allowed, &s.AccessExpiresAt,
+		&s.RefreshExpiresAt, &s.CreatedAt, &s.LastUsedAt, &s.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.AllowedCIDRs = splitList(allowed)
+	return s, nil
+}
+
+// CreateSession - Simpan sesi baru (hash access & refresh token); sesi user yang sudah
+// kedaluwarsa / dicabut dibersihkan sekalian
+func (r *UserRepository) CreateSession(s *models.AuthSession, accessHash, refreshHash string) (*models.AuthSession, error) {
+	if _, err := r.db.Exec(`DELETE FROM auth_sessions WHERE user_id = ? AND (refresh_expires_at < ? OR revoked_at IS NOT NULL)`,
+		s.UserID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.Exec(`
+		INSERT INTO auth_sessions (user_id, name, ip_address, user_agent, allowed_cidrs, access_hash, refresh_hash,
+			access_expires_at, refresh_expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, s.UserID, s.Name, s.IPAddress, s.UserAgent, joinList(s.AllowedCIDRs), accessHash, refreshHash,
+		s.AccessExpiresAt, s.RefreshExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetSession(int(id))
+}
+
+// GetSession - Ambil sesi by ID
+func (r *UserRepository) GetSession(id int) (*models.AuthSession, error) {
+	s, err := scanSession(r.db.QueryRow("SELECT "+sessionColumns+" FROM auth_sessions WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session not found")
+	}
+	return s, err
+}
+
+// UserBySessionHash - User pemilik access token sesi yang masih berlaku, sekaligus catat last_used_at
+func (r *UserRepository) UserBySessionHash(hash string) (*models.User, int, error) {
+	var sessionID int
+	var allowed sql.NullString
+	user := &models.User{}
+	now := time.Now()
+	err := r.db.QueryRow(`
+		SELECT s.id, s.allowed_cidrs, u.id, u.username, u.role, u.reseller_id, u.totp_enabled, u.created_at
+		FROM auth_sessions s JOIN users u ON u.id = s.user_id
+		WHERE s.access_hash = ? AND s.revoked_at IS NULL AND s.access_expires_at > ?
+	`, hash, now).Scan(&sessionID, &allowed, &user.ID, &user.Username, &user.Role, &user.ResellerID, &user.TOTP,
+		&user.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, 0, fmt.Errorf("invalid token")
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	user.AllowedCIDRs = splitList(allowed)
+
+	if _, err := r.db.Exec(`UPDATE auth_sessions SET last_used_at = ? WHERE id = ?`, now, sessionID); err != nil {
+		return nil, 0, err
+	}
+
+	if user.Scopes, err = r.EffectiveScopes(user); err != nil {
+		return nil, 0, err
+	}
+	return user, sessionID, nil
+}
+
+// RotateSession - Tukar refresh token dengan pasangan token baru. Refresh token lama yang
+// dipakai ulang (kemungkinan dicuri) mencabut sesinya. ok=false jika token tidak berlaku atau
+// allow menolak allow-list IP sesi.
+func (r *UserRepository) RotateSession(refreshHash, accessHash, newRefreshHash string, accessExpires, refreshExpires time.Time,
+	allow func(allowed []string) bool) (*models.AuthSession, bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var id int
+	var allowed sql.NullString
+	err = tx.QueryRow(`
+		SELECT id, allowed_cidrs FROM auth_sessions
+		WHERE refresh_hash = ? AND revoked_at IS NULL AND refresh_expires_at > ?
+		FOR UPDATE
+	`, refreshHash, now).Scan(&id, &allowed)
+	if err == sql.ErrNoRows {
+		result, err := tx.Exec(`UPDATE auth_sessions SET revoked_at = ? WHERE previous_refresh_hash = ? AND revoked_at IS NULL`,
+			now, refreshHash)
+		if err != nil {
+			return nil, false, err
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			if err := tx.Commit(); err != nil {
+				return nil, false, err
+			}
+		}
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if !allow(splitList(allowed)) {
+		return nil, false, nil
+	}
+
+	_, err = tx.Exec(`
+		UPDATE auth_sessions SET access_hash = ?, refresh_hash = ?, previous_refresh_hash = ?,
+			access_expires_at = ?, refresh_expires_at = ?, last_used_at = ?
+		WHERE id = ?
+	`, accessHash, newRefreshHash, refreshHash, accessExpires, refreshExpires, now, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+
+	s, err := r.GetSession(id)
+	return s, err == nil, err
+}
+
+// ListSessions - Sesi user yang belum kedaluwarsa / dicabut, terbaru dulu
+func (r *UserRepository) ListSessions(userID int) ([]*models.AuthSession, error) {
+	rows, err := r.db.Query("SELECT "+sessionColumns+` FROM auth_sessions
+		WHERE user_id = ? AND revoked_at IS NULL AND refresh_expires_at > ? ORDER BY id DESC`, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*models.AuthSession{}
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// RevokeSession - Cabut satu sesi user
+func (r *UserRepository) RevokeSession(userID, id int) error {
+	result, err := r.db.Exec(`UPDATE auth_sessions SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL`,
+		time.Now(), id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("session not found")
+	}
+	return nil
+}
+
+// RevokeAllSessions - Cabut semua sesi aktif user, kecuali exceptID (0 = semua)
+func (r *UserRepository) RevokeAllSessions(userID, exceptID int) (int64, error) {
+	result, err := r.db.Exec(`UPDATE auth_sessions SET revoked_at = ? WHERE user_id = ? AND id <> ? AND revoked_at IS NULL`,
+		time.Now(), userID, exceptID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// TOTPState - Secret TOTP user (kosong = belum enroll) dan status aktifnya
+func (r *UserRepository) TOTPState(userID int) (string, bool, error) {
+	var secret sql.NullString
+	var enabled bool
+	err := r.db.QueryRow(`SELECT totp_secret, totp_enabled FROM users WHERE id = ?`, userID).Scan(&secret, &enabled)
+	if err == sql.ErrNoRows {
+		return "", false, fmt.Errorf("user not found")
+	}
+	return secret.String, enabled, err
+}
+
+// SetTOTPSecret - Simpan secret enrollment baru (belum aktif sampai diverifikasi)
+func (r *UserRepository) SetTOTPSecret(userID int, secret string) error {
+	_, err := r.db.Exec(`UPDATE users SET totp_secret = ?, totp_enabled = FALSE, totp_last_step = NULL WHERE id = ?`,
+		secret, userID)
+	return err
+}
+
+// EnableTOTP - Aktifkan TOTP setelah kode pertama terverifikasi
+func (r *UserRepository) EnableTOTP(userID int) error {
+	_, err := r.db.Exec(`UPDATE users SET totp_enabled = TRUE WHERE id = ? AND totp_secret IS NOT NULL`, userID)
+	return err
+}
+
+// DisableTOTP - Hapus secret dan nonaktifkan TOTP (reset oleh user sendiri atau admin)
+func (r *UserRepository) DisableTOTP(userID int) error {
+	result, err := r.db.Exec(`UPDATE users SET totp_secret = NULL, totp_enabled = FALSE, totp_last_step = NULL WHERE id = ?`,
+		userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+// UseTOTPStep - Tandai time step kode TOTP terpakai; false jika step ini (atau yang lebih baru)
+// sudah pernah dipakai (proteksi replay kode yang sama dalam window 30 detik)
+func (r *UserRepository) UseTOTPStep(userID int, step int64) (bool, error) {
+	result, err := r.db.Exec(`UPDATE users SET totp_last_step = ? WHERE id = ? AND (totp_last_step IS NULL OR totp_last_step < ?)`,
+		step, userID, step)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+const webhookColumns = `id, name, url, secret, events, enabled, created_at, updated_at`
+
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	w := &models.Webhook{}
+	var events string
+	if err := row.Scan(&w.ID, &w.Name, &w.URL, &w.Secret, &events, &w.Enabled, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return nil, err
+	}
+	w.Events = []string{}
+	if events != "" {
+		w.Events = strings.Split(events, ",")
+	}
+	return w, nil
+}
+
+// Create - Tambah webhook
+func (r *WebhookRepository) Create(w *models.Webhook) (*models.Webhook, error) {
+	result, err := r.db.Exec(`INSERT INTO webhooks (name, url, secret, events, enabled) VALUES (?, ?, ?, ?, ?)`,
+		w.Name, w.URL, w.Secret, strings.Join(w.Events, ","), w.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(int(id))
+}
+
+// GetByID - Ambil webhook by ID
+func (r *WebhookRepository) GetByID(id int) (*models.Webhook, error) {
+	w, err := scanWebhook(r.db.QueryRow("SELECT "+webhookColumns+" FROM webhooks WHERE id = ?", id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("webhook not found")
+	}
+	return w, err
+}
+
+// List - Semua webhook (enabledOnly untuk dispatcher)
+func (r *WebhookRepository) List(enabledOnly bool) ([]*models.Webhook, error) {
+	query := "SELECT " + webhookColumns + " FROM webhooks"
+	if enabledOnly {
+		query += " WHERE enabled = TRUE"
+	}
+	query += " ORDER BY name"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []*models.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+// Update - Simpan perubahan webhook
+func (r *WebhookRepository) Update(w *models.Webhook) error {
+	_, err := r.db.Exec(`UPDATE webhooks SET name = ?, url = ?, secret = ?, events = ?, enabled = ? WHERE id = ?`,
+		w.Name, w.URL, w.Secret, strings.Join(w.Events, ","), w.Enabled, w.ID)
+	return err
+}
+
+// Delete - Hapus webhook beserta riwayat delivery
+func (r *WebhookRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("webhook not found")
+	}
+	return nil
+}
+
+const deliveryColumns = `id, webhook_id, event_id, event, payload, status, attempts, next_attempt_at,
+	response_code, last_error, created_at, delivered_at`
+
+func scanDelivery(row rowScanner) (*models.WebhookDelivery, error) {
+	d := &models.WebhookDelivery{}
+	var payload []byte
+	err := row.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.Event, &payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.ResponseCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	return d, nil
+}
+
+// Enqueue - Antrikan delivery baru (langsung jatuh tempo)
+func (r *WebhookRepository) Enqueue(webhookID int, eventID, event string, payload []byte) error {
+	_, err := r.db.Exec(`
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, webhookID, eventID, event, payload, time.Now())
+	return err
+}
+
+// DueDeliveries - Delivery pending yang sudah jatuh tempo, paling lama dulu
+func (r *WebhookRepository) DueDeliveries(limit int) ([]*models.WebhookDelivery, error) {
+	rows, err := r.db.Query("SELECT "+deliveryColumns+` FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ?`,
+		models.DeliveryPending, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectDeliveries(rows)
+}
+
+// Deliveries - Riwayat delivery satu webhook, terbaru dulu
+func (r *WebhookRepository) Deliveries(webhookID int, status string, limit int) ([]*models.WebhookDelivery, error) {
+	query := "SELECT " + deliveryColumns + " FROM webhook_deliveries WHERE webhook_id = ?"
+	args := []interface{}{webhookID}
+	if status != "" {
+		query += " AND status = ?"
+		args = append(args, status)
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectDeliveries(rows)
+}
+
+func collectDeliveries(rows *sql.Rows) ([]*models.WebhookDelivery, error) {
+	deliveries := []*models.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// MarkDelivered - Delivery berhasil (respons 2xx)
+func (r *WebhookRepository) MarkDelivered(id int64, responseCode int) error {
+	_, err := r.db.Exec(`
+		UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, response_code = ?, last_error = NULL,
+			delivered_at = ?
+		WHERE id = ?
+	`, models.DeliveryDelivered, responseCode, time.Now(), id)
+	return err
+}
+
+// MarkAttemptFailed - Catat attempt gagal; next nil = attempt habis (status failed)
+func (r *WebhookRepository) MarkAttemptFailed(id int64, responseCode *int, lastError string, next *time.Time) error {
+	status, nextAttempt := models.DeliveryFailed, time.Now()
+	if next != nil {
+		status, nextAttempt = models.DeliveryPending, *next
+	}
+	if len(lastError) > 500 {
+		lastError = lastError[:500]
+	}
+
+	_, err := r.db.Exec(`
+		UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, response_code = ?, last_error = ?,
+			next_attempt_at = ?
+		WHERE id = ?
+	`, status, responseCode, lastError, nextAttempt, id)
+	return err
+}
+
+// Redeliver - Jadwalkan ulang delivery (mis. yang sudah failed) untuk dikirim segera
+func (r *WebhookRepository) Redeliver(webhookID int, id int64) error {
+	result, err := r.db.Exec(`
+		UPDATE webhook_deliveries SET status = ?, attempts = 0, next_attempt_at = ?
+		WHERE id = ? AND webhook_id = ?
+	`, models.DeliveryPending, time.Now(), id, webhookID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("webhook delivery not found")
+	}
+	return nil
+}
+
+// PurgeDeliveries - Hapus riwayat delivery selesai (delivered / failed) lebih tua dari cutoff
+func (r *WebhookRepository) PurgeDeliveries(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM webhook_deliveries WHERE status <> ? AND created_at < ?`,
+		models.DeliveryPending, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+type WirelessRepository struct {
+	db *sql.DB
+}
+
+func NewWirelessRepository(db *sql.DB) *WirelessRepository {
+	return &WirelessRepository{db: db}
+}
+
+// InsertSample - Simpan satu sample kualitas link wireless
+func (r *WirelessRepository) InsertSample(s *models.WirelessSample) error {
+	_, err := r.db.Exec(`
+		INSERT INTO wireless_history (router_id, interface, signal_strength, signal_to_noise,
+			tx_rate_mbps, rx_rate_mbps, tx_ccq, rx_ccq, sampled_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, s.RouterID, s.Interface, s.SignalStrength, s.SignalToNoise, s.TxRateMbps, s.RxRateMbps,
+		s.TxCCQ, s.RxCCQ, s.SampledAt)
+	return err
+}
+
+// GetHistory - Sample interface wireless dalam rentang waktu (urut naik)
+func (r *WirelessRepository) GetHistory(routerID int, iface string, from, to time.Time) ([]*models.WirelessSample, error) {
+	rows, err := r.db.Query(`
+		SELECT router_id, interface, signal_strength, signal_to_noise, tx_rate_mbps, rx_rate_mbps,
+			tx_ccq, rx_ccq, sampled_at
+		FROM wireless_history
+		WHERE router_id = ? AND interface = ? AND sampled_at BETWEEN ? AND ?
+		ORDER BY sampled_at ASC
+	`, routerID, iface, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	samples := []*models.WirelessSample{}
+	for rows.Next() {
+		s := &models.WirelessSample{}
+		if err := rows.Scan(&s.RouterID, &s.Interface, &s.SignalStrength, &s.SignalToNoise,
+			&s.TxRateMbps, &s.RxRateMbps, &s.TxCCQ, &s.RxCCQ, &s.SampledAt); err != nil {
+			return nil, err
+		}
+		samples = append(samples, s)
+	}
+	return samples, rows.Err()
+}
+
+// DeleteOlderThan - Hapus sample lebih tua dari t
+func (r *WirelessRepository) DeleteOlderThan(t time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM wireless_history WHERE sampled_at < ?`, t)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -2,38 +2,69 @@ package routes
 
 import (
 	"log"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 
-	"Mikrotik-Layer/database"
+	"Mikrotik-Layer/app"
+	"Mikrotik-Layer/auth"
 	"Mikrotik-Layer/handlers"
 	"Mikrotik-Layer/middleware"
 	"Mikrotik-Layer/repository"
 	"Mikrotik-Layer/services"
 )
 
-func SetupRoutes(db *database.Database) *http.ServeMux {
-	// Initialize repository
-	routerRepo := repository.NewRouterRepository(db.DB)
-	
-	// Initialize MikrotikService dengan repository
-	ms := services.GetMikrotikService(routerRepo)
-	
+func SetupRoutes(a *app.App) *http.ServeMux {
+	db, cfg := a.DB, a.Config
+
+	// Repository & service bersama (lihat app.New)
+	routerRepo := a.Routers
+	eventRepo := a.Events
+	trafficRepo := a.Traffic
+	ms := a.Mikrotik
+	sampler := a.Sampler
+	webhooks := a.Webhooks
+
+	// Plan & customer
+	planRepo := repository.NewPlanRepository(db.DB)
+	customerRepo := repository.NewCustomerRepository(db.DB)
+	usageRepo := a.Usage
+	auditRepo := a.Audit
+	userRepo := a.Users
+	webhookRepo := repository.NewWebhookRepository(db.DB)
+	pppSessionRepo := repository.NewPPPSessionRepository(db.DB)
+	planService := services.NewPlanService(ms, customerRepo, usageRepo, services.NewAuditLogger(auditRepo), webhooks)
+
 	// Initialize handlers
-	routerHandler := handlers.NewRouterHandler(routerRepo)
+	// Token konfirmasi dua langkah untuk aksi destruktif (hapus router, job fleet)
+	confirms := services.NewConfirmationStore(cfg.ConfirmTokenTTL)
+	routerHandler := handlers.NewRouterHandler(routerRepo, ms, sampler, confirms)
+	planHandler := handlers.NewPlanHandler(planRepo, planService)
+	suspension := services.NewSuspensionService(services.SuspendConfig{
+		Strategy:      cfg.SuspendStrategy,
+		AddressList:   cfg.SuspendAddressList,
+		ThrottleLimit: cfg.SuspendThrottleLimit,
+	}, ms, customerRepo, planRepo, services.NewAuditLogger(auditRepo), webhooks)
+	reservations := services.NewReservationService(ms, customerRepo, planRepo, services.NewAuditLogger(auditRepo))
+	customerHandler := handlers.NewCustomerHandler(customerRepo, planRepo, planService, suspension, reservations)
 
 	mux := http.NewServeMux()
 
 	// Health check
 	mux.HandleFunc("/health", middleware.JSONMiddleware(handlers.HealthCheck))
 
+	// Heartbeat beacon dari scheduler router (publik, diautentikasi token beacon)
+	beaconHandler := handlers.NewBeaconHandler(a.Beacons)
+	mux.HandleFunc("/api/beacon", middleware.JSONMiddleware(beaconHandler.Heartbeat))
+
 	// ========== Router Management Routes ==========
 	mux.HandleFunc("/api/routers", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			middleware.JSONMiddleware(routerHandler.GetAllRouters)(w, r)
 		case http.MethodPost:
-			middleware.JSONMiddleware(routerHandler.CreateRouter)(w, r)
+			middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.CreateRouter))(w, r)
 		default:
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
@@ -56,20 +87,125 @@ func SetupRoutes(db *database.Database) *http.ServeMux {
 			case http.MethodGet:
 				middleware.JSONMiddleware(routerHandler.GetRouterByID)(w, r)
 			case http.MethodPut:
-				middleware.JSONMiddleware(routerHandler.UpdateRouter)(w, r)
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.UpdateRouter))(w, r)
 			case http.MethodDelete:
-				middleware.JSONMiddleware(routerHandler.DeleteRouter)(w, r)
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.DeleteRouter))(w, r)
 			default:
 				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			}
 		} else if len(parts) == 2 {
 			if parts[1] == "status" && r.Method == http.MethodPatch {
-				middleware.JSONMiddleware(routerHandler.UpdateRouterStatus)(w, r)
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.UpdateRouterStatus))(w, r)
 			} else if parts[1] == "active" && r.Method == http.MethodPatch {
-				middleware.JSONMiddleware(routerHandler.SetActiveRouter)(w, r)
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.SetActiveRouter))(w, r)
+			} else if parts[1] == "suspend" && r.Method == http.MethodPost {
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.SuspendRouter))(w, r)
+			} else if parts[1] == "resume" && r.Method == http.MethodPost {
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.ResumeRouter))(w, r)
+			} else if parts[1] == "capabilities" && r.Method == http.MethodGet {
+				middleware.JSONMiddleware(routerHandler.GetRouterCapabilities)(w, r)
+			} else if parts[1] == "status-history" && r.Method == http.MethodGet {
+				middleware.JSONMiddleware(routerHandler.GetRouterStatusHistory)(w, r)
+			} else if parts[1] == "permissions-check" && r.Method == http.MethodGet {
+				middleware.JSONMiddleware(routerHandler.CheckRouterPermissions)(w, r)
+			} else if parts[1] == "tunnel" && r.Method == http.MethodPost {
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.RegisterRouterTunnel))(w, r)
+			} else if parts[1] == "tunnel" && r.Method == http.MethodDelete {
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.UnregisterRouterTunnel))(w, r)
+			} else if parts[1] == "beacon" && r.Method == http.MethodPost {
+				middleware.JSONMiddleware(auth.RequireAdmin(beaconHandler.InstallBeacon))(w, r)
+			} else if parts[1] == "beacon" && r.Method == http.MethodDelete {
+				middleware.JSONMiddleware(auth.RequireAdmin(beaconHandler.RemoveBeacon))(w, r)
+			} else if parts[1] == "reachability" && r.Method == http.MethodGet {
+				middleware.JSONMiddleware(beaconHandler.GetReachability)(w, r)
+			} else if parts[1] == "changes" && r.Method == http.MethodGet {
+				middleware.JSONMiddleware(handlers.GetRouterChanges(routerRepo, auditRepo, eventRepo))(w, r)
+			} else if parts[1] == "addresses" && r.Method == http.MethodGet {
+				middleware.JSONMiddleware(routerHandler.ListRouterAddresses)(w, r)
+			} else if parts[1] == "addresses" && r.Method == http.MethodPost {
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.AddRouterAddress))(w, r)
+			} else if parts[1] == "monitored-interfaces" && r.Method == http.MethodGet {
+				middleware.JSONMiddleware(routerHandler.GetMonitoredInterfaces)(w, r)
+			} else if parts[1] == "monitored-interfaces" && r.Method == http.MethodPut {
+				middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.SetMonitoredInterfaces))(w, r)
 			} else {
 				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			}
+		} else if len(parts) == 3 && parts[1] == "addresses" && r.Method == http.MethodDelete {
+			middleware.JSONMiddleware(auth.RequireAdmin(routerHandler.DeleteRouterAddress))(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== Dashboard ==========
+	mux.HandleFunc("/api/dashboard/summary", middleware.JSONMiddleware(handlers.GetDashboardSummary(routerRepo)))
+
+	// ========== Plan Catalog (mutasi admin, propagasi ke semua router) ==========
+	mux.HandleFunc("/api/plans", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(planHandler.GetAllPlans)(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(planHandler.CreatePlan))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/api/plans/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/plans/"), "/")
+
+		if len(parts) == 1 && parts[0] != "" {
+			switch r.Method {
+			case http.MethodGet:
+				middleware.JSONMiddleware(planHandler.GetPlanByID)(w, r)
+			case http.MethodPut:
+				middleware.JSONMiddleware(auth.RequireAdmin(planHandler.UpdatePlan))(w, r)
+			case http.MethodDelete:
+				middleware.JSONMiddleware(auth.RequireAdmin(planHandler.DeletePlan))(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if len(parts) == 2 && parts[1] == "apply" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(planHandler.ApplyPlan))(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== Customers ==========
+	mux.HandleFunc("/api/customers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(customerHandler.GetCustomers)(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(customerHandler.CreateCustomer)(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/api/customers/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/customers/"), "/")
+
+		if len(parts) == 1 && parts[0] != "" {
+			switch r.Method {
+			case http.MethodGet:
+				middleware.JSONMiddleware(customerHandler.GetCustomerByID)(w, r)
+			case http.MethodPut:
+				middleware.JSONMiddleware(customerHandler.UpdateCustomer)(w, r)
+			case http.MethodDelete:
+				middleware.JSONMiddleware(customerHandler.DeleteCustomer)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if len(parts) == 2 && parts[1] == "suspend" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(customerHandler.SuspendCustomer)(w, r)
+		} else if len(parts) == 2 && parts[1] == "unsuspend" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(customerHandler.UnsuspendCustomer)(w, r)
+		} else if len(parts) == 2 && parts[1] == "reservation" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(customerHandler.ReserveCustomer)(w, r)
 		} else {
 			http.Error(w, "Not found", http.StatusNotFound)
 		}
@@ -77,11 +213,14 @@ func SetupRoutes(db *database.Database) *http.ServeMux {
 
 	// ========== Connection Management ==========
 	mux.HandleFunc("/api/connections/status", middleware.JSONMiddleware(handlers.GetConnectionStatus(ms)))
+	mux.HandleFunc("/api/connections/healthz", middleware.JSONMiddleware(handlers.GetConnectionsHealthz(ms, routerRepo,
+		cfg.HealthzMinOnlinePct, cfg.HealthzMaxPingAge)))
 	mux.HandleFunc("/api/connections/connect", middleware.JSONMiddleware(handlers.ConnectRouterHandler(ms)))
 	mux.HandleFunc("/api/connections/disconnect", middleware.JSONMiddleware(handlers.DisconnectRouterHandler(ms)))
 
 	// ========== Interface Routes (require router_id) ==========
 	mux.HandleFunc("/api/interfaces", middleware.JSONMiddleware(handlers.GetInterfaces(ms)))
+	mux.HandleFunc("/api/interfaces/tree", middleware.JSONMiddleware(handlers.GetInterfaceTree(ms)))
 	mux.HandleFunc("/api/interfaces/enable", middleware.JSONMiddleware(handlers.EnableInterface(ms)))
 	mux.HandleFunc("/api/interfaces/disable", middleware.JSONMiddleware(handlers.DisableInterface(ms)))
 
@@ -90,12 +229,591 @@ func SetupRoutes(db *database.Database) *http.ServeMux {
 	mux.HandleFunc("/api/addresses/add", middleware.JSONMiddleware(handlers.AddAddress(ms)))
 	mux.HandleFunc("/api/addresses/remove", middleware.JSONMiddleware(handlers.RemoveAddress(ms)))
 
+	// ========== Host Tables (require router_id, vendor dari OUI) ==========
+	mux.HandleFunc("/api/arp", middleware.JSONMiddleware(handlers.GetARP(ms)))
+	mux.HandleFunc("/api/dhcp/leases", middleware.JSONMiddleware(handlers.GetDHCPLeases(ms)))
+	mux.HandleFunc("/api/bridge/hosts", middleware.JSONMiddleware(handlers.GetBridgeHosts(ms)))
+
 	// ========== Queue Routes (require router_id) ==========
 	mux.HandleFunc("/api/queues", middleware.JSONMiddleware(handlers.GetQueues(ms)))
-	mux.HandleFunc("/api/queues/add", middleware.JSONMiddleware(handlers.AddQueue(ms)))
-	mux.HandleFunc("/api/queues/remove", middleware.JSONMiddleware(handlers.RemoveQueue(ms)))
-	
+	mux.HandleFunc("/api/firewall/address-list", middleware.JSONMiddleware(handlers.GetAddressLists(ms)))
+	mux.HandleFunc("/api/firewall/filter", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(handlers.GetFirewallRules(ms))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(handlers.AddFirewallRule(ms, a.Naming))(w, r)
+		case http.MethodDelete:
+			middleware.JSONMiddleware(handlers.RemoveFirewallRule(ms))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/firewall/filter/enable", middleware.JSONMiddleware(handlers.SetFirewallRuleDisabled(ms, false)))
+	mux.HandleFunc("/api/firewall/filter/disable", middleware.JSONMiddleware(handlers.SetFirewallRuleDisabled(ms, true)))
+	mux.HandleFunc("/api/firewall/filter/move", middleware.JSONMiddleware(handlers.MoveFirewallRule(ms)))
+	mux.HandleFunc("/api/dns/static", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(handlers.GetDNSStatic(ms))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(handlers.AddDNSStatic(ms))(w, r)
+		case http.MethodDelete:
+			middleware.JSONMiddleware(handlers.RemoveDNSStatic(ms))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/dns/static/enable", middleware.JSONMiddleware(handlers.SetDNSStaticDisabled(ms, false)))
+	mux.HandleFunc("/api/dns/static/disable", middleware.JSONMiddleware(handlers.SetDNSStaticDisabled(ms, true)))
+	mux.HandleFunc("/api/dns/settings", middleware.JSONMiddleware(handlers.GetDNSSettings(ms)))
+	mux.HandleFunc("/api/queues/by-target", middleware.JSONMiddleware(handlers.GetQueuesByTarget(ms)))
+	mux.HandleFunc("/api/queues/add", middleware.JSONMiddleware(handlers.AddQueue(ms, webhooks, a.Naming)))
+	mux.HandleFunc("/api/naming/report", middleware.JSONMiddleware(handlers.GetNamingReport(ms, a.Naming)))
+	mux.HandleFunc("/api/queues/remove", middleware.JSONMiddleware(handlers.RemoveQueue(ms, webhooks)))
+
+	// ========== IP Routes ==========
+	mux.HandleFunc("/api/routes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(handlers.GetRoutes(ms))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(handlers.AddRoute(ms))(w, r)
+		case http.MethodDelete:
+			middleware.JSONMiddleware(handlers.RemoveRoute(ms))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/routes/enable", middleware.JSONMiddleware(handlers.SetRouteDisabled(ms, false)))
+	mux.HandleFunc("/api/routes/disable", middleware.JSONMiddleware(handlers.SetRouteDisabled(ms, true)))
+
+	// ========== Active Sessions (require router_id) ==========
+	mux.HandleFunc("/api/ppp/active", middleware.JSONMiddleware(handlers.GetPPPActive(ms)))
+	mux.HandleFunc("/api/ppp/active/disconnect", middleware.JSONMiddleware(handlers.DisconnectPPPSessions(ms)))
+	mux.HandleFunc("/api/hotspot/active/disconnect", middleware.JSONMiddleware(handlers.DisconnectHotspotSessions(ms)))
+	mux.HandleFunc("/api/ppp/sessions", middleware.JSONMiddleware(handlers.GetPPPSessions(pppSessionRepo)))
+
+	// ========== Router Files (require router_id) ==========
+	files := services.NewFileService(ms, cfg.FileFTPPort)
+	mux.HandleFunc("/api/files", middleware.JSONMiddleware(handlers.GetFiles(ms)))
+	mux.HandleFunc("/api/files/download", middleware.JSONMiddleware(handlers.DownloadFile(files)))
+	mux.HandleFunc("/api/files/upload", middleware.JSONMiddleware(handlers.UploadFile(files, int64(cfg.FileUploadMaxMB)<<20)))
+	mux.HandleFunc("/api/files/remove", middleware.JSONMiddleware(handlers.RemoveFile(files)))
+
+	// ========== Router Backups (terenkripsi, require router_id) ==========
+	backupRepo := repository.NewBackupRepository(db.DB)
+	backups := services.NewBackupService(cfg.BackupEncryptionKey, ms, files, backupRepo, services.NewAuditLogger(auditRepo))
+	mux.HandleFunc("/api/backups", middleware.JSONMiddleware(handlers.Backups(backups, backupRepo)))
+	mux.HandleFunc("/api/backups/", middleware.JSONMiddleware(handlers.Backup(backups, backupRepo)))
+
+	// ========== Lookup ==========
+	mux.HandleFunc("/api/lookup/ip-history", middleware.JSONMiddleware(handlers.GetIPHistory(pppSessionRepo,
+		repository.NewLeaseHistoryRepository(db.DB), customerRepo)))
+
+	// ========== Logging & Events ==========
+	syslogPort := 514
+	if _, port, err := net.SplitHostPort(cfg.SyslogAddr); err == nil {
+		syslogPort, _ = strconv.Atoi(port)
+	}
+	mux.HandleFunc("/api/logging/remote", middleware.JSONMiddleware(handlers.ConfigureRemoteLogging(ms, cfg.SyslogAdvertiseHost, syslogPort)))
+	mux.HandleFunc("/api/logs", middleware.JSONMiddleware(handlers.GetRouterLogs(ms)))
+	mux.HandleFunc("/api/events", middleware.JSONMiddleware(handlers.GetEvents(eventRepo)))
+	mux.HandleFunc("/api/audit", middleware.JSONMiddleware(auth.RequireAdmin(handlers.GetAuditLogs(auditRepo))))
+	mux.HandleFunc("/api/audit/ip-conflicts", middleware.JSONMiddleware(auth.RequireAdmin(handlers.GetIPConflicts(ms, cfg.IPConflictIgnore))))
+
+	// ========== IPAM (rencana IP / registry subnet, admin) ==========
+	ipamHandler := handlers.NewIPAMHandler(repository.NewSubnetRepository(db.DB), routerRepo, ms)
+	mux.HandleFunc("/api/ipam/subnets", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(ipamHandler.GetSubnets))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(ipamHandler.CreateSubnet))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/ipam/subnets/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(ipamHandler.GetSubnet))(w, r)
+		case http.MethodPut:
+			middleware.JSONMiddleware(auth.RequireAdmin(ipamHandler.UpdateSubnet))(w, r)
+		case http.MethodDelete:
+			middleware.JSONMiddleware(auth.RequireAdmin(ipamHandler.DeleteSubnet))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/ipam/report", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			middleware.JSONMiddleware(auth.RequireAdmin(ipamHandler.GetIPAMReport))(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// ========== Alerts ==========
+	alertHandler := handlers.NewAlertHandler(repository.NewAlertRepository(db.DB))
+	mux.HandleFunc("/api/alerts/active", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			middleware.JSONMiddleware(alertHandler.GetActiveAlerts)(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/alerts/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/alerts/"), "/")
+
+		if len(parts) == 2 && parts[1] == "acknowledge" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(alertHandler.AcknowledgeAlert)(w, r)
+		} else if len(parts) == 2 && parts[1] == "resolve" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(alertHandler.ResolveAlert)(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== Traffic History ==========
+	mux.HandleFunc("/api/traffic/history", middleware.JSONMiddleware(handlers.GetTrafficHistory(trafficRepo)))
+	mux.HandleFunc("/api/traffic/compare", middleware.JSONMiddleware(handlers.CompareTraffic(trafficRepo)))
+	mux.HandleFunc("/api/traffic/samplers", middleware.JSONMiddleware(handlers.TrafficSamplers(sampler)))
+	mux.HandleFunc("/api/graphing/import", middleware.JSONMiddleware(handlers.ImportGraphing(sampler)))
+	mux.HandleFunc("/api/graphs/interface", middleware.JSONMiddleware(handlers.GetInterfaceGraph(trafficRepo)))
+	mux.HandleFunc("/api/traffic/top-talkers", middleware.JSONMiddleware(handlers.GetTopTalkers(repository.NewTopTalkersRepository(db.DB))))
+	mux.HandleFunc("/api/traffic/torch", middleware.JSONMiddleware(handlers.Torch(ms)))
+	mux.HandleFunc("/api/wireless/history", middleware.JSONMiddleware(handlers.GetWirelessHistory(repository.NewWirelessRepository(db.DB))))
+	mux.HandleFunc("/api/wireless/registrations", middleware.JSONMiddleware(handlers.GetWirelessRegistrations(ms)))
+	mux.HandleFunc("/api/wireless/registrations/kick", middleware.JSONMiddleware(handlers.KickWirelessClient(ms)))
+	mux.HandleFunc("/api/wireless/interfaces", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(handlers.GetWirelessInterfaceList(ms))(w, r)
+		case http.MethodPut:
+			middleware.JSONMiddleware(handlers.UpdateWirelessInterface(ms))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// ========== CAPsMAN ==========
+	mux.HandleFunc("/api/capsman/remote-caps", middleware.JSONMiddleware(handlers.GetCAPsMANRemoteCAPs(ms)))
+	mux.HandleFunc("/api/capsman/registrations", middleware.JSONMiddleware(handlers.GetCAPsMANRegistrations(ms)))
+	mux.HandleFunc("/api/capsman/status", middleware.JSONMiddleware(handlers.GetCAPsMANStatus(ms)))
+
+	// ========== Latency Mesh ==========
+	meshRepo := repository.NewMeshRepository(db.DB)
+	mux.HandleFunc("/api/mesh/paths", middleware.JSONMiddleware(handlers.MeshPaths(meshRepo)))
+	mux.HandleFunc("/api/mesh/paths/", middleware.JSONMiddleware(handlers.MeshPath(meshRepo)))
+	mux.HandleFunc("/api/mesh/matrix", middleware.JSONMiddleware(handlers.GetMeshMatrix(meshRepo)))
+	mux.HandleFunc("/api/mesh/history", middleware.JSONMiddleware(handlers.GetPathHistory(meshRepo)))
+
+	// ========== Queue Usage & Quota ==========
+	mux.HandleFunc("/api/usage/queues", middleware.JSONMiddleware(handlers.GetQueueUsage(usageRepo)))
+	mux.HandleFunc("/api/usage/quotas", middleware.JSONMiddleware(handlers.QueueQuotas(usageRepo)))
+
+	// ========== Monthly Reports (PDF) ==========
+	reportRepo := repository.NewReportRepository(db.DB)
+	reportGen := services.NewReportGenerator(routerRepo, customerRepo, usageRepo, trafficRepo,
+		repository.NewAvailabilityRepository(db.DB), reportRepo)
+	mux.HandleFunc("/api/reports", middleware.JSONMiddleware(handlers.Reports(reportGen, reportRepo)))
+	mux.HandleFunc("/api/reports/", middleware.JSONMiddleware(handlers.Report(reportRepo)))
+
+	// ========== NetFlow / IPFIX ==========
+	flowPort := 2055
+	if _, port, err := net.SplitHostPort(cfg.NetFlowAddr); err == nil {
+		flowPort, _ = strconv.Atoi(port)
+	}
+	mux.HandleFunc("/api/traffic-flow/configure", middleware.JSONMiddleware(handlers.ConfigureTrafficFlow(ms, cfg.NetFlowAdvertiseHost, flowPort)))
+	mux.HandleFunc("/api/flows", middleware.JSONMiddleware(handlers.GetFlows(repository.NewFlowRepository(db.DB))))
+
+	// ========== Routing Protocols (read-only) ==========
+	mux.HandleFunc("/api/routing", middleware.JSONMiddleware(handlers.GetRoutingStatus(ms)))
+	mux.HandleFunc("/api/routing/ospf", middleware.JSONMiddleware(handlers.GetOSPFStatus(ms)))
+	mux.HandleFunc("/api/routing/bgp", middleware.JSONMiddleware(handlers.GetBGPPeers(ms)))
+
+	// ========== Network Map (topologi dari /ip/neighbor) ==========
+	mux.HandleFunc("/api/topology", middleware.JSONMiddleware(handlers.GetTopology(routerRepo, repository.NewTopologyRepository(db.DB))))
+
+	// ========== Static Route Failover ==========
+	routeFailoverRepo := repository.NewRouteFailoverRepository(db.DB)
+	mux.HandleFunc("/api/route-failovers", middleware.JSONMiddleware(handlers.RouteFailovers(routeFailoverRepo)))
+	failoverWatchdog := services.NewFailoverWatchdog(cfg.RouteFailoverInterval, ms, routeFailoverRepo,
+		a.EventRecorder(),
+		services.NewNotifier(cfg.NotifyWebhookURL), services.NewAuditLogger(auditRepo))
+	mux.HandleFunc("/api/route-failovers/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/route-failovers/"), "/")
+
+		if len(parts) == 2 && (parts[1] == "switchover" || parts[1] == "restore") {
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			middleware.JSONMiddleware(handlers.SwitchRouteFailover(routeFailoverRepo, failoverWatchdog))(w, r)
+		} else if len(parts) == 1 {
+			middleware.JSONMiddleware(handlers.RouteFailover(routeFailoverRepo))(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/api/wan/status", middleware.JSONMiddleware(handlers.GetWANStatus(ms, routeFailoverRepo)))
+
+	// ========== Bandwidth Schedule (jam sibuk / malam per plan atau queue) ==========
+	bandwidthScheduleRepo := repository.NewBandwidthScheduleRepository(db.DB)
+	bandwidthScheduler := services.NewBandwidthScheduler(cfg.BandwidthScheduleInterval, nil, ms, bandwidthScheduleRepo,
+		planRepo, customerRepo, planService, services.NewAuditLogger(auditRepo))
+	mux.HandleFunc("/api/bandwidth-schedules", middleware.JSONMiddleware(handlers.BandwidthSchedules(bandwidthScheduleRepo, planRepo, routerRepo)))
+	mux.HandleFunc("/api/bandwidth-schedules/", middleware.JSONMiddleware(handlers.BandwidthSchedule(bandwidthScheduleRepo, planRepo, routerRepo, bandwidthScheduler)))
+
+	// ========== Task Schedules (admin, cron backup / reboot / sync queue) ==========
+	// Scheduler yang sama dengan worker di main (guard run ganda tick + run-now)
+	taskScheduleHandler := handlers.NewTaskScheduleHandler(repository.NewTaskScheduleRepository(db.DB), routerRepo, a.Tasks, confirms)
+	mux.HandleFunc("/api/schedules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(taskScheduleHandler.ListSchedules))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(taskScheduleHandler.CreateSchedule))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/schedules/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/schedules/"), "/")
+
+		switch {
+		case len(parts) == 2 && parts[1] == "run" && r.Method == http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(taskScheduleHandler.RunSchedule))(w, r)
+		case len(parts) != 1 || parts[0] == "":
+			http.Error(w, "Not found", http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(taskScheduleHandler.GetSchedule))(w, r)
+		case r.Method == http.MethodPut:
+			middleware.JSONMiddleware(auth.RequireAdmin(taskScheduleHandler.UpdateSchedule))(w, r)
+		case r.Method == http.MethodDelete:
+			middleware.JSONMiddleware(auth.RequireAdmin(taskScheduleHandler.DeleteSchedule))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// ========== WireGuard ==========
+	mux.HandleFunc("/api/wireguard/peers", middleware.JSONMiddleware(handlers.CreateWireGuardPeer(ms)))
+
+	// ========== System Routes (require router_id) ==========
+	mux.HandleFunc("/api/system/resource", middleware.JSONMiddleware(handlers.GetSystemResource(ms)))
+	mux.HandleFunc("/api/system/reboot", middleware.JSONMiddleware(handlers.RebootRouter(ms, confirms, a.AuditLogger())))
+	mux.HandleFunc("/api/system/shutdown", middleware.JSONMiddleware(handlers.ShutdownRouter(ms, confirms, a.AuditLogger())))
+
+	// ========== Users & API Tokens (admin) ==========
+	resellerRepo := repository.NewResellerRepository(db.DB)
+	userHandler := handlers.NewUserHandler(userRepo, routerRepo, resellerRepo)
+	mux.HandleFunc("/api/auth/me", middleware.JSONMiddleware(handlers.GetMe))
+
+	// Sesi dashboard (access + refresh token); /api/auth/refresh publik, diautentikasi refresh token
+	authenticator := a.Authenticator
+	sessionHandler := handlers.NewSessionHandler(userRepo, cfg.AuthAccessTokenTTL, cfg.AuthRefreshTokenTTL,
+		authenticator, cfg.AuthTOTPIssuer)
+	mux.HandleFunc("/api/auth/refresh", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			middleware.JSONMiddleware(sessionHandler.RefreshSession)(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/auth/sessions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(sessionHandler.ListSessions)(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(sessionHandler.CreateSession)(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/auth/sessions/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/sessions/revoke-all" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(sessionHandler.RevokeAllSessions)(w, r)
+		} else if r.Method == http.MethodDelete {
+			middleware.JSONMiddleware(sessionHandler.RevokeSession)(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// 2FA TOTP user pemanggil (enroll -> verify; disable butuh kode valid)
+	mux.HandleFunc("/api/auth/totp/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		switch strings.TrimPrefix(r.URL.Path, "/api/auth/totp/") {
+		case "enroll":
+			middleware.JSONMiddleware(sessionHandler.EnrollTOTP)(w, r)
+		case "verify":
+			middleware.JSONMiddleware(sessionHandler.VerifyTOTP)(w, r)
+		case "disable":
+			middleware.JSONMiddleware(sessionHandler.DisableTOTP)(w, r)
+		default:
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(userHandler.GetAllUsers))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(userHandler.CreateUser))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/users/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/users/"), "/")
+
+		if len(parts) == 1 && parts[0] != "" {
+			switch r.Method {
+			case http.MethodGet:
+				middleware.JSONMiddleware(auth.RequireAdmin(userHandler.GetUser))(w, r)
+			case http.MethodDelete:
+				middleware.JSONMiddleware(auth.RequireAdmin(userHandler.DeleteUser))(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if len(parts) == 2 && parts[1] == "scopes" && r.Method == http.MethodPut {
+			middleware.JSONMiddleware(auth.RequireAdmin(userHandler.SetUserScopes))(w, r)
+		} else if len(parts) == 2 && parts[1] == "tokens" && (r.Method == http.MethodGet || r.Method == http.MethodPost) {
+			middleware.JSONMiddleware(auth.RequireAdmin(userHandler.UserTokens))(w, r)
+		} else if len(parts) == 3 && parts[1] == "tokens" && r.Method == http.MethodDelete {
+			middleware.JSONMiddleware(auth.RequireAdmin(userHandler.RevokeToken))(w, r)
+		} else if len(parts) == 2 && parts[1] == "totp" && r.Method == http.MethodDelete {
+			middleware.JSONMiddleware(auth.RequireAdmin(userHandler.ResetUserTOTP))(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== Fleet-wide Jobs (admin) ==========
+	jobRepo := repository.NewJobRepository(db.DB)
+	jobRunner := services.NewJobRunner(ms, jobRepo, cfg.JobConcurrency)
+	jobHandler := handlers.NewJobHandler(jobRepo, routerRepo, jobRunner, ms, confirms)
+	mux.HandleFunc("/api/jobs", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(jobHandler.GetJobs))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(jobHandler.CreateJob))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/jobs/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/jobs/"), "/")
+
+		if len(parts) == 1 && parts[0] != "" && r.Method == http.MethodGet {
+			middleware.JSONMiddleware(auth.RequireAdmin(jobHandler.GetJob))(w, r)
+		} else if len(parts) == 2 && parts[1] == "cancel" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(jobHandler.CancelJob))(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== Import CSV Onboarding (admin, provisioning sebagai job) ==========
+	importHandler := handlers.NewImportHandler(ms, routerRepo, planRepo,
+		services.NewImportService(ms, jobRunner, services.NewAuditLogger(auditRepo), webhooks), a.Naming)
+	mux.HandleFunc("/api/queues/import", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(importHandler.ImportQueues))(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/ppp/secrets/import", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(importHandler.ImportPPPSecrets))(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// ========== Provisioning Webhooks (admin) ==========
+	webhookHandler := handlers.NewWebhookHandler(webhookRepo)
+	mux.HandleFunc("/api/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(webhookHandler.GetAllWebhooks))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(webhookHandler.CreateWebhook))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/webhooks/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/webhooks/"), "/")
+
+		if len(parts) == 1 && parts[0] != "" {
+			switch r.Method {
+			case http.MethodGet:
+				middleware.JSONMiddleware(auth.RequireAdmin(webhookHandler.GetWebhook))(w, r)
+			case http.MethodPut:
+				middleware.JSONMiddleware(auth.RequireAdmin(webhookHandler.UpdateWebhook))(w, r)
+			case http.MethodDelete:
+				middleware.JSONMiddleware(auth.RequireAdmin(webhookHandler.DeleteWebhook))(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if len(parts) == 2 && parts[1] == "deliveries" && r.Method == http.MethodGet {
+			middleware.JSONMiddleware(auth.RequireAdmin(webhookHandler.GetWebhookDeliveries))(w, r)
+		} else if len(parts) == 4 && parts[1] == "deliveries" && parts[3] == "redeliver" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(webhookHandler.RedeliverWebhook))(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== Channel Notifikasi Alert per Tag Router (admin) ==========
+	channelHandler := handlers.NewNotificationChannelHandler(repository.NewNotificationChannelRepository(db.DB), a.AlertRouting)
+	mux.HandleFunc("/api/notification-channels", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(channelHandler.GetAllChannels))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(channelHandler.CreateChannel))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/notification-channels/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/notification-channels/"), "/")
+
+		if len(parts) == 1 && parts[0] != "" {
+			switch r.Method {
+			case http.MethodGet:
+				middleware.JSONMiddleware(auth.RequireAdmin(channelHandler.GetChannel))(w, r)
+			case http.MethodPut:
+				middleware.JSONMiddleware(auth.RequireAdmin(channelHandler.UpdateChannel))(w, r)
+			case http.MethodDelete:
+				middleware.JSONMiddleware(auth.RequireAdmin(channelHandler.DeleteChannel))(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if len(parts) == 2 && parts[1] == "test" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(channelHandler.TestChannel))(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== Export/Import Konfigurasi Layer (admin) ==========
+	configHandler := handlers.NewLayerConfigHandler(routerRepo, planRepo, repository.NewNotificationChannelRepository(db.DB),
+		ms, a.AuditLogger())
+	mux.HandleFunc("/api/config/export", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(configHandler.ExportConfig))(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/config/import", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(configHandler.ImportConfig))(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// ========== Threat Feed -> Address-List Sync (admin) ==========
+	feedRepo := repository.NewThreatFeedRepository(db.DB)
+	feedHandler := handlers.NewThreatFeedHandler(feedRepo, routerRepo,
+		services.NewThreatFeedSyncer(cfg.ThreatFeedInterval, cfg.ThreatFeedMaxEntries, ms, feedRepo, a.AuditLogger()))
+	mux.HandleFunc("/api/threat-feeds", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(feedHandler.GetAllFeeds))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(feedHandler.CreateFeed))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/threat-feeds/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/threat-feeds/"), "/")
+
+		if len(parts) == 1 && parts[0] != "" {
+			switch r.Method {
+			case http.MethodGet:
+				middleware.JSONMiddleware(auth.RequireAdmin(feedHandler.GetFeed))(w, r)
+			case http.MethodPut:
+				middleware.JSONMiddleware(auth.RequireAdmin(feedHandler.UpdateFeed))(w, r)
+			case http.MethodDelete:
+				middleware.JSONMiddleware(auth.RequireAdmin(feedHandler.DeleteFeed))(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if len(parts) == 2 && parts[1] == "sync" && r.Method == http.MethodPost {
+			middleware.JSONMiddleware(auth.RequireAdmin(feedHandler.SyncFeed))(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== Resellers (admin; detail, usage & alert juga untuk user reseller) ==========
+	resellerHandler := handlers.NewResellerHandler(resellerRepo, routerRepo, usageRepo, repository.NewAlertRepository(db.DB))
+	mux.HandleFunc("/api/resellers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.JSONMiddleware(auth.RequireAdmin(resellerHandler.GetAllResellers))(w, r)
+		case http.MethodPost:
+			middleware.JSONMiddleware(auth.RequireAdmin(resellerHandler.CreateReseller))(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/resellers/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/resellers/"), "/")
+
+		if len(parts) == 1 && parts[0] != "" {
+			switch r.Method {
+			case http.MethodGet:
+				middleware.JSONMiddleware(resellerHandler.GetReseller)(w, r)
+			case http.MethodPut:
+				middleware.JSONMiddleware(auth.RequireAdmin(resellerHandler.UpdateReseller))(w, r)
+			case http.MethodDelete:
+				middleware.JSONMiddleware(auth.RequireAdmin(resellerHandler.DeleteReseller))(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if len(parts) == 2 && parts[1] == "routers" && r.Method == http.MethodPut {
+			middleware.JSONMiddleware(auth.RequireAdmin(resellerHandler.SetResellerRouters))(w, r)
+		} else if len(parts) == 2 && parts[1] == "usage" && r.Method == http.MethodGet {
+			middleware.JSONMiddleware(resellerHandler.GetResellerUsage)(w, r)
+		} else if len(parts) == 2 && parts[1] == "alerts" && r.Method == http.MethodGet {
+			middleware.JSONMiddleware(resellerHandler.GetResellerAlerts)(w, r)
+		} else {
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	})
+
+	// ========== API v1 (DTO snake_case, endpoint sama dengan /api) ==========
+	mux.Handle("/api/v1/", middleware.APIv1(mux))
+
+	// Semua route di belakang tracing, recovery panic, allow-list IP listener dan autentikasi token (jika AUTH_ENABLED)
+	root := http.NewServeMux()
+	root.Handle("/", middleware.Trace(middleware.Recover(middleware.AllowList(mustParseCIDRs("API_ALLOWED_CIDRS", cfg.APIAllowedCIDRs),
+		mustParseCIDRs("API_TRUSTED_PROXIES", cfg.APITrustedProxies))(authenticator.Middleware(mux)))))
 
 	log.Println("✓ Routes configured successfully")
-	return mux
-}
\ No newline at end of file
+	return root
+}
+
+// mustParseCIDRs - Allow-list dari konfigurasi; nilai tidak valid menghentikan startup
+// supaya management plane tidak terbuka karena salah ketik
+func mustParseCIDRs(name, value string) []*net.IPNet {
+	networks, err := services.ParseCIDRList(value)
+	if err != nil {
+		log.Fatalf("❌ Invalid %s: %v", name, err)
+	}
+	return networks
+}
\ No newline at end of file
@@ -6,16 +6,15 @@ import (
 	"net/http"
 	"time"
 
-	"Mikrotik-Layer/database"
+	"Mikrotik-Layer/app"
 	"Mikrotik-Layer/handlers"
 	"Mikrotik-Layer/middleware"
-	"Mikrotik-Layer/repository"
-	"Mikrotik-Layer/services"
 )
 
-func SetupWebSocketRoutes(db *database.Database) *http.ServeMux {
-	routerRepo := repository.NewRouterRepository(db.DB)
-	ms := services.GetMikrotikService(routerRepo)
+func SetupWebSocketRoutes(a *app.App) *http.ServeMux {
+	cfg := a.Config
+	ms := a.Mikrotik
+	trafficRepo := a.Traffic
 
 	mux := http.NewServeMux()
 
@@ -24,12 +23,34 @@ func SetupWebSocketRoutes(db *database.Database) *http.ServeMux {
 	// Real-time interface traffic monitoring
 	// Single interface: ?router_id=1&interface=ether1
 	// Multiple interfaces: ?router_id=1&interfaces=ether1,ether2,ether3
-	mux.HandleFunc("/ws/traffic/monitor", handlers.MonitorTrafficWS(ms))
+	// History awal: &backfill=15 (menit)
+	// Breakdown VLAN: ?router_id=1&vlans_of=ether1
+	// Agregat bonding/bridge: ?router_id=1&aggregate_of=bond1
+	// Semua endpoint /ws diawali pesan hello; &protocol=2 = data traffic bertipe angka
+	mux.HandleFunc("/ws/traffic/monitor", handlers.MonitorTrafficWS(ms, trafficRepo))
+
+	// Kualitas link wireless (signal, tx/rx rate, CCQ)
+	// ?router_id=1&interface=wlan1&interval=1 (detik)
+	mux.HandleFunc("/ws/wireless/monitor", handlers.MonitorWirelessWS(ms))
+
+	// Event stream dari hub (syslog, dll)
+	// ?topics=syslog&router_id=1, ?topics=connections untuk state koneksi router
+	mux.HandleFunc("/ws/events", handlers.EventsWS(a.Hub))
+
+	// Entry log router yang baru masuk
+	// ?router_id=1&topics=firewall,error
+	mux.HandleFunc("/ws/logs/follow", handlers.FollowRouterLogsWS(ms))
 
 	// ==================== HTTP API Endpoints ====================
 	
 	// Get single interface traffic stats
 	mux.HandleFunc("/api/traffic/once", middleware.JSONMiddleware(handlers.GetTrafficOnce(ms)))
+
+	// Traffic parent dipecah per VLAN child
+	mux.HandleFunc("/api/traffic/vlans", middleware.JSONMiddleware(handlers.GetVLANTraffic(ms)))
+
+	// Traffic bonding/bridge = jumlah rate member
+	mux.HandleFunc("/api/traffic/aggregate", middleware.JSONMiddleware(handlers.GetAggregateTraffic(ms)))
 	
 	// List available interfaces for monitoring
 	mux.HandleFunc("/api/interfaces/list", middleware.JSONMiddleware(handlers.ListAvailableInterfaces(ms)))
@@ -43,26 +64,43 @@ func SetupWebSocketRoutes(db *database.Database) *http.ServeMux {
 	mux.HandleFunc("/api/ws/connections/connect", middleware.JSONMiddleware(handlers.ConnectRouterHandler(ms)))
 	mux.HandleFunc("/api/ws/connections/disconnect", middleware.JSONMiddleware(handlers.DisconnectRouterHandler(ms)))
 
+	// API v1 (DTO snake_case)
+	mux.Handle("/api/v1/", middleware.APIv1(mux))
+
+	// Token via ?access_token= untuk WebSocket; stream dibatasi scope router/interface user
+	root := http.NewServeMux()
+	root.Handle("/", middleware.Trace(middleware.Recover(middleware.AllowList(mustParseCIDRs("WS_ALLOWED_CIDRS", cfg.WSAllowedCIDRs),
+		mustParseCIDRs("API_TRUSTED_PROXIES", cfg.APITrustedProxies))(a.Authenticator.Middleware(mux)))))
+
 	log.Println("✓ WebSocket routes configured successfully")
 	log.Println("  ┌─ WebSocket Endpoint:")
 	log.Println("  │  • /ws/traffic/monitor")
 	log.Println("  │    - Single: ?router_id=1&interface=ether1")
 	log.Println("  │    - Multi:  ?router_id=1&interfaces=ether1,ether2,ether3")
+	log.Println("  │    - Backfill: &backfill=15 (menit history)")
+	log.Println("  │    - VLAN:   ?router_id=1&vlans_of=ether1")
+	log.Println("  │    - Bond:   ?router_id=1&aggregate_of=bond1")
+	log.Println("  │  • /ws/wireless/monitor?router_id=1&interface=wlan1")
+	log.Println("  │  • /ws/events?topics=syslog&router_id=1")
+	log.Println("  │    - Koneksi: ?topics=connections")
+	log.Println("  │  • /ws/logs/follow?router_id=1&topics=firewall,error")
 	log.Println("  │")
 	log.Println("  ├─ HTTP API Endpoints:")
 	log.Println("  │  • /api/traffic/once?router_id=X&interface=Y")
+	log.Println("  │  • /api/traffic/vlans?router_id=X&interface=Y")
+	log.Println("  │  • /api/traffic/aggregate?router_id=X&interface=Y")
 	log.Println("  │  • /api/interfaces/list?router_id=X")
 	log.Println("  │")
 	log.Println("  └─ Management:")
 	log.Println("     • /ws/health")
 	log.Println("     • /api/ws/connections/status")
 
-	return mux
+	return root
 }
 
 // SetupWebSocketServer untuk setup server dengan custom config
-func SetupWebSocketServer(db *database.Database, addr string) *http.Server {
-	mux := SetupWebSocketRoutes(db)
+func SetupWebSocketServer(a *app.App, addr string) *http.Server {
+	mux := SetupWebSocketRoutes(a)
 
 	server := &http.Server{
 		Addr:         addr,
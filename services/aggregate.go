@@ -0,0 +1,110 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// GetAggregateInterface - Member bonding (slaves) atau bridge (port yang tidak disabled)
+func (ms *MikrotikService) GetAggregateInterface(routerID int, name string) (*models.AggregateInterface, error) {
+	r, err := ms.runRead(routerID, "/interface/bonding/print", fmt.Sprintf("?name=%s", name), "=.proplist=name,slaves")
+	if err != nil {
+		return nil, err
+	}
+	agg := &models.AggregateInterface{Name: name, Members: []string{}}
+	if len(r.Re) > 0 {
+		agg.Kind = models.AggregateBonding
+		for _, slave := range strings.Split(r.Re[0].Map["slaves"], ",") {
+			if slave = strings.TrimSpace(slave); slave != "" {
+				agg.Members = append(agg.Members, slave)
+			}
+		}
+	} else {
+		if r, err = ms.runRead(routerID, "/interface/bridge/port/print", fmt.Sprintf("?bridge=%s", name),
+			"=.proplist=interface,disabled"); err != nil {
+			return nil, err
+		}
+		if len(r.Re) == 0 {
+			return nil, fmt.Errorf("bonding or bridge %s not found or has no members", name)
+		}
+		agg.Kind = models.AggregateBridge
+		for _, re := range r.Re {
+			if re.Map["disabled"] != "true" {
+				agg.Members = append(agg.Members, re.Map["interface"])
+			}
+		}
+	}
+
+	if len(agg.Members) == 0 {
+		return nil, fmt.Errorf("%s %s has no active members", agg.Kind, name)
+	}
+	sort.Strings(agg.Members)
+	return agg, nil
+}
+
+// GetAggregateTraffic - Snapshot rate master dan semua member dari satu monitor-traffic once
+func (ms *MikrotikService) GetAggregateTraffic(routerID int, name string) (*models.AggregateTraffic, error) {
+	agg, err := ms.GetAggregateInterface(routerID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := ms.runRead(routerID, "/interface/monitor-traffic",
+		fmt.Sprintf("=interface=%s", strings.Join(append([]string{name}, agg.Members...), ",")), "=once=")
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*TrafficStats, len(r.Re))
+	for _, re := range r.Re {
+		stats[re.Map["name"]] = &TrafficStats{
+			RouterID:      routerID,
+			InterfaceName: re.Map["name"],
+			RxBitsPerSec:  re.Map["rx-bits-per-second"],
+			TxBitsPerSec:  re.Map["tx-bits-per-second"],
+		}
+	}
+	return BuildAggregateTraffic(routerID, agg, stats), nil
+}
+
+// BuildAggregateTraffic - Jumlahkan rate terakhir member (stats by nama interface); member tanpa
+// data dihitung 0. Rate master disertakan terpisah sebagai pembanding.
+func BuildAggregateTraffic(routerID int, agg *models.AggregateInterface, stats map[string]*TrafficStats) *models.AggregateTraffic {
+	t := &models.AggregateTraffic{
+		RouterID:  routerID,
+		Interface: agg.Name,
+		Kind:      agg.Kind,
+		Members:   make([]*models.AggregateMemberTraffic, 0, len(agg.Members)),
+		Timestamp: time.Now(),
+	}
+	if s := stats[agg.Name]; s != nil {
+		t.MasterRxBps, _ = strconv.ParseInt(s.RxBitsPerSec, 10, 64)
+		t.MasterTxBps, _ = strconv.ParseInt(s.TxBitsPerSec, 10, 64)
+	}
+
+	for _, name := range agg.Members {
+		m := &models.AggregateMemberTraffic{Interface: name}
+		if s := stats[name]; s != nil {
+			m.RxBps, _ = strconv.ParseInt(s.RxBitsPerSec, 10, 64)
+			m.TxBps, _ = strconv.ParseInt(s.TxBitsPerSec, 10, 64)
+		}
+		t.RxBps += m.RxBps
+		t.TxBps += m.TxBps
+		t.Members = append(t.Members, m)
+	}
+	for _, m := range t.Members {
+		if t.RxBps > 0 {
+			m.RxShare = float64(m.RxBps) / float64(t.RxBps)
+		}
+		if t.TxBps > 0 {
+			m.TxShare = float64(m.TxBps) / float64(t.TxBps)
+		}
+	}
+	sort.SliceStable(t.Members, func(i, j int) bool { return t.Members[i].RxBps > t.Members[j].RxBps })
+	return t
+}
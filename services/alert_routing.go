@@ -0,0 +1,171 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// SMTPConfig - Server SMTP untuk channel email (host kosong = channel email tidak bisa dikirim)
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // kosong = tanpa AUTH
+	Password string
+	From     string
+}
+
+// AlertRouter - Kirim alert yang baru terbuka ke channel notifikasi (tabel notification_channels)
+// yang cocok dengan tag router dan severity-nya, kecuali channel yang sedang di jam tenang.
+// Mis. router "core" ke webhook paging on-call, router "cpe" hanya email.
+type AlertRouter struct {
+	channels *repository.NotificationChannelRepository
+	routers  *repository.RouterRepository
+	smtp     SMTPConfig
+	client   *http.Client
+}
+
+func NewAlertRouter(channels *repository.NotificationChannelRepository, routers *repository.RouterRepository, smtp SMTPConfig) *AlertRouter {
+	return &AlertRouter{
+		channels: channels,
+		routers:  routers,
+		smtp:     smtp,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Route - Routing alert secara async; kegagalan kirim hanya di-log
+func (ar *AlertRouter) Route(alert *models.Alert) {
+	if ar == nil {
+		return
+	}
+	go func() {
+		defer Recover("alert-router")
+		ar.route(alert, time.Now())
+	}()
+}
+
+func (ar *AlertRouter) route(alert *models.Alert, now time.Time) {
+	channels, err := ar.channels.List(true)
+	if err != nil {
+		log.Printf("[ALERT] Error loading notification channels: %v", err)
+		return
+	}
+	if len(channels) == 0 {
+		return
+	}
+
+	// Salinan: alert yang sama juga dipublish ke hub
+	copied := *alert
+	alert = &copied
+
+	var tags []string
+	if alert.RouterID != nil {
+		if router, err := ar.routers.GetByID(*alert.RouterID); err == nil {
+			tags = splitTags(router.Tags)
+			alert.RouterName = &router.Name
+		}
+	}
+
+	for _, c := range channels {
+		if !c.MeetsSeverity(alert.Severity) || !c.MatchesTags(tags) {
+			continue
+		}
+		if c.InQuietHours(now) {
+			log.Printf("[ALERT] %s alert %d not sent to %s (quiet hours)", alert.Type, alert.ID, c.Name)
+			continue
+		}
+		if err := ar.Send(c, alert); err != nil {
+			log.Printf("[ALERT] Error sending %s alert %d to %s: %v", alert.Type, alert.ID, c.Name, err)
+		}
+	}
+}
+
+// Send - Kirim satu alert ke channel (dipakai juga untuk tes channel)
+func (ar *AlertRouter) Send(c *models.NotificationChannel, alert *models.Alert) error {
+	switch c.Type {
+	case models.ChannelWebhook:
+		return ar.postWebhook(c.Target, alert)
+	case models.ChannelEmail:
+		return ar.sendEmail(splitTags(&c.Target), alert)
+	}
+	return fmt.Errorf("unknown channel type %s", c.Type)
+}
+
+func (ar *AlertRouter) postWebhook(url string, alert *models.Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	resp, err := ar.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (ar *AlertRouter) sendEmail(to []string, alert *models.Alert) error {
+	if ar.smtp.Host == "" {
+		return fmt.Errorf("SMTP is not configured")
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("channel has no recipients")
+	}
+
+	source := "-"
+	if alert.RouterName != nil {
+		source = *alert.RouterName
+	} else if alert.RouterID != nil {
+		source = "router " + strconv.Itoa(*alert.RouterID)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", ar.smtp.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: [%s] %s: %s\r\n", strings.ToUpper(alert.Severity), source, alert.Type)
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n", alert.Message)
+	fmt.Fprintf(&msg, "Router:   %s\r\n", source)
+	fmt.Fprintf(&msg, "Type:     %s\r\n", alert.Type)
+	fmt.Fprintf(&msg, "Severity: %s\r\n", alert.Severity)
+	fmt.Fprintf(&msg, "Since:    %s\r\n", alert.FirstSeenAt.Format(time.RFC3339))
+	fmt.Fprintf(&msg, "Alert ID: %d\r\n", alert.ID)
+
+	var auth smtp.Auth
+	if ar.smtp.Username != "" {
+		auth = smtp.PlainAuth("", ar.smtp.Username, ar.smtp.Password, ar.smtp.Host)
+	}
+	addr := fmt.Sprintf("%s:%d", ar.smtp.Host, ar.smtp.Port)
+	return smtp.SendMail(addr, auth, ar.smtp.From, to, msg.Bytes())
+}
+
+// splitTags - Parse daftar comma-separated (tag router, alamat email)
+func splitTags(v *string) []string {
+	if v == nil {
+		return nil
+	}
+
+	var tags []string
+	for _, tag := range strings.Split(*v, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// AnomalyConfig - Parameter detektor anomali traffic
+type AnomalyConfig struct {
+	Interval     time.Duration // jarak antar evaluasi
+	Factor       float64       // deviasi (kelipatan stddev) yang dianggap anomali
+	Alpha        float64       // bobot EWMA
+	BaselineDays int           // panjang history untuk baseline
+	MinDays      int           // minimal jumlah hari dengan data di jam yang sama
+}
+
+// baseline - Mean & stddev EWMA untuk satu jam-dalam-hari
+type baseline struct {
+	mean   float64
+	stddev float64
+	days   int
+}
+
+// AnomalyDetector - Bandingkan traffic terkini dengan baseline per jam (EWMA + stddev)
+// dan catat event "traffic_anomaly" saat deviasi melebihi Factor.
+type AnomalyDetector struct {
+	cfg      AnomalyConfig
+	repo     *repository.TrafficRepository
+	recorder *EventRecorder
+
+	mu     sync.Mutex
+	active map[string]bool // key interface+arah yang sedang anomali (hindari event berulang)
+}
+
+func NewAnomalyDetector(cfg AnomalyConfig, repo *repository.TrafficRepository, recorder *EventRecorder) *AnomalyDetector {
+	return &AnomalyDetector{
+		cfg:      cfg,
+		repo:     repo,
+		recorder: recorder,
+		active:   make(map[string]bool),
+	}
+}
+
+// Run - Loop evaluasi periodik (blocking)
+func (d *AnomalyDetector) Run() {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		d.evaluate()
+	}
+}
+
+func (d *AnomalyDetector) evaluate() {
+	now := time.Now()
+	window := now.Add(-d.cfg.Interval)
+
+	interfaces, err := d.repo.ListSampledInterfaces(window)
+	if err != nil {
+		log.Printf("[ANOMALY] Error listing sampled interfaces: %v", err)
+		return
+	}
+
+	for _, si := range interfaces {
+		rx, tx, count, err := d.repo.RecentAverage(si.RouterID, si.Interface, window)
+		if err != nil || count == 0 {
+			continue
+		}
+
+		hourly, err := d.repo.HourlyAverages(si.RouterID, si.Interface,
+			now.AddDate(0, 0, -d.cfg.BaselineDays))
+		if err != nil {
+			log.Printf("[ANOMALY] Error loading baseline router %d %s: %v", si.RouterID, si.Interface, err)
+			continue
+		}
+
+		rxBase, txBase := d.baselines(hourly, now)
+		d.check(si, "rx", rx, rxBase)
+		d.check(si, "tx", tx, txBase)
+	}
+}
+
+// baselines - EWMA mean/variance rata-rata jam yang sama pada hari-hari sebelumnya
+func (d *AnomalyDetector) baselines(hourly []*models.HourlyTraffic, now time.Time) (baseline, baseline) {
+	var rx, tx baseline
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	for _, h := range hourly {
+		if h.Hour.Hour() != now.Hour() || !h.Hour.Before(today) {
+			continue
+		}
+		rx = d.update(rx, h.RxBps)
+		tx = d.update(tx, h.TxBps)
+	}
+	return rx, tx
+}
+
+func (d *AnomalyDetector) update(b baseline, value float64) baseline {
+	if b.days == 0 {
+		return baseline{mean: value, days: 1}
+	}
+	diff := value - b.mean
+	mean := b.mean + d.cfg.Alpha*diff
+	variance := (1 - d.cfg.Alpha) * (b.stddev*b.stddev + d.cfg.Alpha*diff*diff)
+	return baseline{mean: mean, stddev: math.Sqrt(variance), days: b.days + 1}
+}
+
+func (d *AnomalyDetector) check(si *models.SampledInterface, direction string, current float64, base baseline) {
+	if base.days < d.cfg.MinDays {
+		return
+	}
+
+	// Stddev minimal 5% dari mean supaya baseline yang sangat datar tidak terlalu sensitif
+	stddev := math.Max(base.stddev, base.mean*0.05)
+	if stddev == 0 {
+		return
+	}
+
+	deviation := (current - base.mean) / stddev
+	key := fmt.Sprintf("%d/%s/%s", si.RouterID, si.Interface, direction)
+
+	d.mu.Lock()
+	wasActive := d.active[key]
+	isAnomaly := math.Abs(deviation) > d.cfg.Factor
+	d.active[key] = isAnomaly
+	d.mu.Unlock()
+
+	if !isAnomaly || wasActive {
+		return
+	}
+
+	kind := "spike"
+	if deviation < 0 {
+		kind = "drop"
+	}
+
+	routerID := si.RouterID
+	d.recorder.Record(&models.Event{
+		RouterID: &routerID,
+		Type:     "traffic_anomaly",
+		Severity: "warning",
+		Message: fmt.Sprintf("Traffic %s %s on %s: %.0f bps vs baseline %.0f bps (%.1fσ)",
+			direction, kind, si.Interface, current, base.mean, deviation),
+		Data: mustJSON(map[string]interface{}{
+			"interface": si.Interface,
+			"direction": direction,
+			"kind":      kind,
+			"current":   current,
+			"baseline":  base.mean,
+			"stddev":    stddev,
+			"deviation": deviation,
+			"days":      base.days,
+		}),
+	})
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"log"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// AuditLogger - Catat perubahan ke router ke tabel audit_logs
+type AuditLogger struct {
+	repo *repository.AuditRepository
+}
+
+func NewAuditLogger(repo *repository.AuditRepository) *AuditLogger {
+	return &AuditLogger{repo: repo}
+}
+
+// LogPlan - Audit hasil eksekusi CommandPlan (error eksekusi ikut dicatat)
+func (a *AuditLogger) LogPlan(actor, action string, routerID int, target string, plan *models.CommandPlan, execErr error) {
+	entry := &models.AuditLog{
+		Actor:    actor,
+		Action:   action,
+		RouterID: &routerID,
+		Target:   target,
+		Success:  execErr == nil,
+	}
+	if execErr != nil {
+		msg := execErr.Error()
+		entry.Error = &msg
+	}
+	if plan != nil {
+		entry.Details = mustJSON(plan)
+	}
+
+	if err := a.repo.Create(entry); err != nil {
+		log.Printf("[AUDIT] Error storing %s by %s: %v", action, actor, err)
+	}
+}
+
+// Log - Audit aksi yang bukan CommandPlan (mis. download backup), details opsional.
+// routerID 0 untuk aksi tanpa router (mis. export konfigurasi layer).
+func (a *AuditLogger) Log(actor, action string, routerID int, target string, details interface{}, err error) {
+	entry := &models.AuditLog{
+		Actor:   actor,
+		Action:  action,
+		Target:  target,
+		Success: err == nil,
+	}
+	if routerID != 0 {
+		entry.RouterID = &routerID
+	}
+	if err != nil {
+		msg := err.Error()
+		entry.Error = &msg
+	}
+	if details != nil {
+		entry.Details = mustJSON(details)
+	}
+
+	if err := a.repo.Create(entry); err != nil {
+		log.Printf("[AUDIT] Error storing %s by %s: %v", action, actor, err)
+	}
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"Mikrotik-Layer/repository"
+)
+
+// AvailabilityTracker - Catat status konektivitas router aktif tiap interval ke counter
+// harian; dipakai untuk persentase availability di laporan bulanan.
+type AvailabilityTracker struct {
+	interval   time.Duration
+	ms         *MikrotikService
+	routerRepo *repository.RouterRepository
+	repo       *repository.AvailabilityRepository
+}
+
+func NewAvailabilityTracker(interval time.Duration, ms *MikrotikService, routerRepo *repository.RouterRepository, repo *repository.AvailabilityRepository) *AvailabilityTracker {
+	return &AvailabilityTracker{
+		interval:   interval,
+		ms:         ms,
+		routerRepo: routerRepo,
+		repo:       repo,
+	}
+}
+
+// Run - Loop pencatatan (blocking)
+func (t *AvailabilityTracker) Run() {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		t.record(now)
+	}
+}
+
+func (t *AvailabilityTracker) record(now time.Time) {
+	routers, err := t.routerRepo.GetActiveRouters()
+	if err != nil {
+		log.Printf("[AVAILABILITY] Error loading active routers: %v", err)
+		return
+	}
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	connections := t.ms.GetAllConnections()
+
+	for _, router := range routers {
+		// Suspend adalah downtime yang disengaja, tidak dihitung
+		if router.Status == "suspended" {
+			continue
+		}
+
+		conn, ok := connections[router.ID]
+		up := ok && conn.IsHealthy
+		if err := t.repo.Record(router.ID, day, up); err != nil {
+			log.Printf("[AVAILABILITY] Error recording router %d: %v", router.ID, err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// BackupService - Ambil backup / export router lalu simpan terenkripsi (AES-256-GCM) di DB.
+// Export dan backup berisi kredensial, secret PPP dan PSK wireless, jadi isi tidak pernah
+// disimpan plaintext dan setiap download dicatat di audit log.
+type BackupService struct {
+	ms    *MikrotikService
+	files *FileService
+	repo  *repository.BackupRepository
+	audit *AuditLogger
+	aead  cipher.AEAD // nil = kunci belum diset, backup nonaktif
+	keyID string
+}
+
+// NewBackupService - Kunci deployment (string bebas) diturunkan ke kunci AES-256 via SHA-256.
+// Kunci yang diganti membuat backup lama tidak bisa dibuka (key_id berbeda).
+func NewBackupService(key string, ms *MikrotikService, files *FileService, repo *repository.BackupRepository, audit *AuditLogger) *BackupService {
+	s := &BackupService{ms: ms, files: files, repo: repo, audit: audit}
+	if key == "" {
+		return s
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		log.Printf("[BACKUP] Error initializing cipher: %v", err)
+		return s
+	}
+	if s.aead, err = cipher.NewGCM(block); err != nil {
+		log.Printf("[BACKUP] Error initializing cipher: %v", err)
+		return s
+	}
+	id := sha256.Sum256(sum[:])
+	s.keyID = hex.EncodeToString(id[:8])
+	return s
+}
+
+// Create - Buat backup di router, unduh, hapus file sementara di router, simpan terenkripsi
+func (s *BackupService) Create(routerID int, req *models.BackupRequest, actor string) (*models.RouterBackup, error) {
+	if s.aead == nil {
+		return nil, i18n.NewError(i18n.BackupDisabled)
+	}
+
+	name := fmt.Sprintf("mikrotik-layer-%s", time.Now().Format("20060102-150405"))
+	filename, err := s.ms.saveBackupFile(routerID, req.Kind, name, derefString(req.Password))
+	var content []byte
+	if err == nil {
+		content, err = s.files.Download(routerID, filename)
+		if _, rmErr := s.files.Remove(routerID, filename, false); rmErr != nil {
+			log.Printf("[BACKUP] Error removing %s from router %d: %v", filename, routerID, rmErr)
+		}
+	}
+	if err != nil {
+		s.audit.Log(actor, "backup_create", routerID, name, map[string]string{"kind": req.Kind}, err)
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	backup := &models.RouterBackup{
+		RouterID:  routerID,
+		Kind:      req.Kind,
+		Filename:  filename,
+		SizeBytes: len(content),
+		SHA256:    hex.EncodeToString(sum[:]),
+		KeyID:     s.keyID,
+		CreatedBy: actor,
+	}
+	sealed, err := s.seal(backup, content)
+	if err == nil {
+		backup, err = s.repo.Create(backup, sealed)
+	}
+	s.audit.Log(actor, "backup_create", routerID, filename, map[string]interface{}{
+		"kind":       req.Kind,
+		"size_bytes": len(content),
+		"sha256":     hex.EncodeToString(sum[:]),
+	}, err)
+	return backup, err
+}
+
+// Content - Isi backup yang sudah didekripsi; setiap pemanggilan (berhasil atau gagal) diaudit
+func (s *BackupService) Content(backup *models.RouterBackup, actor string) ([]byte, error) {
+	content, err := s.open(backup)
+	s.audit.Log(actor, "backup_download", backup.RouterID, backup.Filename, map[string]interface{}{
+		"backup_id": backup.ID,
+		"kind":      backup.Kind,
+	}, err)
+	return content, err
+}
+
+// Delete - Hapus backup (diaudit)
+func (s *BackupService) Delete(backup *models.RouterBackup, actor string) error {
+	err := s.repo.Delete(backup.ID)
+	s.audit.Log(actor, "backup_delete", backup.RouterID, backup.Filename, map[string]int{"backup_id": backup.ID}, err)
+	return err
+}
+
+// backupAAD - Data tambahan GCM supaya ciphertext tidak bisa dipindah ke baris backup lain
+func backupAAD(backup *models.RouterBackup) []byte {
+	return []byte(fmt.Sprintf("%d/%s/%s", backup.RouterID, backup.Filename, backup.SHA256))
+}
+
+// seal - nonce || ciphertext
+func (s *BackupService) seal(backup *models.RouterBackup, content []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(content)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, content, backupAAD(backup)), nil
+}
+
+func (s *BackupService) open(backup *models.RouterBackup) ([]byte, error) {
+	if s.aead == nil {
+		return nil, i18n.NewError(i18n.BackupDisabled)
+	}
+	if backup.KeyID != s.keyID {
+		return nil, i18n.NewError(i18n.BackupKeyMismatch, backup.KeyID)
+	}
+
+	sealed, err := s.repo.GetContent(backup.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, fmt.Errorf("backup %d is corrupted", backup.ID)
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	content, err := s.aead.Open(nil, nonce, ciphertext, backupAAD(backup))
+	if err != nil {
+		return nil, fmt.Errorf("backup %d failed integrity check: %w", backup.ID, err)
+	}
+	return content, nil
+}
+
+// saveBackupFile - Tulis backup (.backup) / export (.rsc) ke /file router, kembalikan nama file
+func (ms *MikrotikService) saveBackupFile(routerID int, kind, name, password string) (string, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return "", err
+	}
+	if conn.IsVirtual() {
+		return "", fmt.Errorf("backup not supported on virtual router")
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	switch kind {
+	case models.BackupBinary:
+		args := []string{"/system/backup/save", fmt.Sprintf("=name=%s", name)}
+		if password != "" {
+			args = append(args, fmt.Sprintf("=password=%s", password))
+		}
+		if _, err := conn.Run(args...); err != nil {
+			return "", err
+		}
+		return name + ".backup", nil
+	case models.BackupExport:
+		if _, err := conn.Run("/export", fmt.Sprintf("=file=%s", name)); err != nil {
+			return "", err
+		}
+		return name + ".rsc", nil
+	default:
+		return "", fmt.Errorf("unknown backup kind %s", kind)
+	}
+}
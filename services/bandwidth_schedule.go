@@ -0,0 +1,173 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// BandwidthScheduler - Terapkan jadwal bandwidth (bandwidth_schedules) oleh layer sendiri, bukan
+// script /system/scheduler di router, supaya jadwal terpusat dan limit normal selalu diketahui.
+// Hanya transisi masuk/keluar jendela yang menyentuh router; penerapan gagal dicoba lagi tiap tick.
+type BandwidthScheduler struct {
+	interval  time.Duration
+	location  *time.Location
+	ms        *MikrotikService
+	repo      *repository.BandwidthScheduleRepository
+	plans     *repository.PlanRepository
+	customers *repository.CustomerRepository
+	planSvc   *PlanService
+	audit     *AuditLogger
+}
+
+func NewBandwidthScheduler(interval time.Duration, location *time.Location, ms *MikrotikService, repo *repository.BandwidthScheduleRepository, plans *repository.PlanRepository, customers *repository.CustomerRepository, planSvc *PlanService, audit *AuditLogger) *BandwidthScheduler {
+	if location == nil {
+		location = time.Local
+	}
+	return &BandwidthScheduler{
+		interval:  interval,
+		location:  location,
+		ms:        ms,
+		repo:      repo,
+		plans:     plans,
+		customers: customers,
+		planSvc:   planSvc,
+		audit:     audit,
+	}
+}
+
+// Run - Loop scheduler (blocking)
+func (s *BandwidthScheduler) Run() {
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		schedules, err := s.repo.List()
+		if err != nil {
+			log.Printf("[SCHEDULE] Error loading bandwidth schedules: %v", err)
+			continue
+		}
+
+		now := time.Now().In(s.location)
+		for _, sch := range schedules {
+			var desired *string
+			if sch.Enabled && sch.InWindow(now) {
+				desired = &sch.RateLimit
+			}
+			if sameLimit(desired, sch.AppliedLimit) {
+				continue
+			}
+			if err := s.apply(sch, desired); err != nil {
+				log.Printf("[SCHEDULE] Error applying %s: %v", sch.Name, err)
+				if err := s.repo.SetError(sch.ID, err.Error()); err != nil {
+					log.Printf("[SCHEDULE] Error saving state of %s: %v", sch.Name, err)
+				}
+			}
+		}
+	}
+}
+
+// Release - Kembalikan limit normal jadwal yang sedang diterapkan (sebelum jadwal dihapus
+// atau targetnya diganti). No-op jika jadwal tidak sedang aktif.
+func (s *BandwidthScheduler) Release(sch *models.BandwidthSchedule) error {
+	if sch.AppliedLimit == nil {
+		return nil
+	}
+	return s.apply(sch, nil)
+}
+
+// apply - Terapkan limit (nil = limit normal) ke target jadwal lalu simpan state
+func (s *BandwidthScheduler) apply(sch *models.BandwidthSchedule, limit *string) error {
+	original := sch.OriginalLimit
+	var err error
+	if sch.PlanID != nil {
+		err = s.applyPlan(sch, limit)
+	} else {
+		original, err = s.applyQueue(sch, limit)
+	}
+	if err != nil {
+		return err
+	}
+
+	if limit != nil {
+		log.Printf("[SCHEDULE] %s started: rate limit %s", sch.Name, *limit)
+	} else {
+		log.Printf("[SCHEDULE] %s ended: normal rate limit restored", sch.Name)
+	}
+	sch.AppliedLimit, sch.OriginalLimit = limit, original
+	return s.repo.SetApplied(sch.ID, limit, original)
+}
+
+// applyPlan - Terapkan plan dengan rate limit jadwal (tanpa burst) ke semua customer plan,
+// atau plan apa adanya untuk kembali normal
+func (s *BandwidthScheduler) applyPlan(sch *models.BandwidthSchedule, limit *string) error {
+	plan, err := s.plans.GetByID(*sch.PlanID)
+	if err != nil {
+		return err
+	}
+	if limit != nil {
+		scheduled := *plan
+		scheduled.RateLimit = *limit
+		scheduled.BurstLimit, scheduled.BurstThreshold, scheduled.BurstTime = nil, nil, nil
+		plan = &scheduled
+	}
+
+	customers, err := s.customers.List(models.CustomerFilter{PlanID: sch.PlanID})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, result := range s.planSvc.Apply(plan, customers, false) {
+		if result.Error != "" {
+			errs = append(errs, fmt.Errorf("router %d: %s", result.RouterID, result.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applyQueue - Set max-limit queue; saat masuk jendela max-limit semula dikembalikan untuk disimpan
+func (s *BandwidthScheduler) applyQueue(sch *models.BandwidthSchedule, limit *string) (*string, error) {
+	if sch.RouterID == nil || sch.QueueName == nil {
+		return nil, fmt.Errorf("schedule %s has no target", sch.Name)
+	}
+
+	maxLimit, action := "", "bandwidth_schedule_end"
+	if limit != nil {
+		maxLimit, action = *limit, "bandwidth_schedule_start"
+	} else if sch.OriginalLimit != nil {
+		maxLimit = *sch.OriginalLimit
+	} else {
+		return nil, fmt.Errorf("schedule %s has no max-limit to restore", sch.Name)
+	}
+
+	plan, previous, err := s.ms.SetQueueMaxLimit(*sch.RouterID, *sch.QueueName, maxLimit, false)
+	s.audit.LogPlan("scheduler", action, *sch.RouterID, *sch.QueueName, plan, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit jadwal diubah saat jendela aktif: max-limit semula tetap yang pertama
+	if limit != nil && sch.AppliedLimit == nil {
+		return &previous, nil
+	}
+	if limit == nil {
+		return nil, nil
+	}
+	return sch.OriginalLimit, nil
+}
+
+func sameLimit(a, b *string) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
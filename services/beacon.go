@@ -0,0 +1,346 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// BeaconSchedulerName - Nama /system/scheduler heartbeat yang dikelola layer
+const BeaconSchedulerName = "mikrotik-layer-beacon"
+
+// BeaconMonitor - Heartbeat dari router (scheduler /tool/fetch tiap menit) dipadukan dengan health
+// check API untuk membedakan "API tidak bisa diakses tapi router hidup" dari "router mati".
+// Perubahan klasifikasi dicatat sebagai event.
+type BeaconMonitor struct {
+	interval   time.Duration
+	staleAfter time.Duration
+	baseURL    string // URL layer yang bisa dijangkau router, mis. https://layer.example.com
+	ms         *MikrotikService
+	routers    *repository.RouterRepository
+	repo       *repository.BeaconRepository
+	recorder   *EventRecorder
+
+	mu   sync.Mutex
+	last map[int]string // klasifikasi terakhir per router
+}
+
+func NewBeaconMonitor(interval, staleAfter time.Duration, baseURL string, ms *MikrotikService, routers *repository.RouterRepository, repo *repository.BeaconRepository, recorder *EventRecorder) *BeaconMonitor {
+	return &BeaconMonitor{
+		interval:   interval,
+		staleAfter: staleAfter,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		ms:         ms,
+		routers:    routers,
+		repo:       repo,
+		recorder:   recorder,
+		last:       make(map[int]string),
+	}
+}
+
+// Install - Buat token baru lalu pasang scheduler heartbeat di router. manual = hanya simpan token
+// dan kembalikan script (untuk router yang API-nya sedang tidak bisa diakses).
+func (m *BeaconMonitor) Install(routerID int, baseURL string, manual, dryRun bool) (*models.BeaconInstallResult, error) {
+	if baseURL = strings.TrimRight(baseURL, "/"); baseURL == "" {
+		baseURL = m.baseURL
+	}
+	if baseURL == "" {
+		return nil, fmt.Errorf("base_url is required when BEACON_BASE_URL is not configured")
+	}
+	if _, err := m.routers.GetByID(routerID); err != nil {
+		return nil, err
+	}
+
+	token, err := newBeaconToken()
+	if err != nil {
+		return nil, err
+	}
+	onEvent := beaconOnEvent(baseURL, token)
+	// Script terminal: " dan ? di dalam on-event di-escape
+	result := &models.BeaconInstallResult{
+		Plan: newCommandPlan(routerID, "install_beacon", dryRun),
+		RouterScript: []string{
+			fmt.Sprintf("/system/scheduler/remove [find name=%s]", BeaconSchedulerName),
+			fmt.Sprintf(`/system/scheduler/add name=%s interval=1m start-time=startup on-event="%s"`,
+				BeaconSchedulerName, strings.NewReplacer(`"`, `\"`, "?", `\?`).Replace(onEvent)),
+		},
+	}
+
+	if !manual {
+		if result.Plan, err = m.ms.InstallBeaconScheduler(routerID, onEvent, dryRun); err != nil {
+			return nil, err
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if result.Beacon, err = m.repo.Install(routerID, hashBeaconToken(token)); err != nil {
+		return nil, err
+	}
+	m.forget(routerID)
+	return result, nil
+}
+
+// Remove - Hapus scheduler heartbeat dari router lalu lupakan beacon-nya
+func (m *BeaconMonitor) Remove(routerID int, dryRun bool) (*models.CommandPlan, error) {
+	plan, err := m.ms.RemoveBeaconScheduler(routerID, dryRun)
+	if err != nil || dryRun {
+		return plan, err
+	}
+	if err := m.repo.Delete(routerID); err != nil {
+		return plan, err
+	}
+	m.forget(routerID)
+	return plan, nil
+}
+
+// Heartbeat - Catat heartbeat dari router pemilik token
+func (m *BeaconMonitor) Heartbeat(token, sourceIP string) (int, error) {
+	if token == "" {
+		return 0, fmt.Errorf("beacon not found")
+	}
+	return m.repo.Touch(hashBeaconToken(token), sourceIP)
+}
+
+// Reachability - Status API dan beacon router saat ini
+func (m *BeaconMonitor) Reachability(routerID int) (*models.RouterReachability, error) {
+	if _, err := m.routers.GetByID(routerID); err != nil {
+		return nil, err
+	}
+	beacon, err := m.repo.GetByRouter(routerID)
+	if err != nil {
+		beacon = nil
+	}
+	return m.reachability(routerID, beacon, time.Now()), nil
+}
+
+func (m *BeaconMonitor) reachability(routerID int, beacon *models.RouterBeacon, now time.Time) *models.RouterReachability {
+	result := &models.RouterReachability{
+		RouterID:   routerID,
+		Beacon:     beacon,
+		StaleAfter: int64(m.staleAfter / time.Second),
+	}
+	if conn := m.ms.GetAllConnections()[routerID]; conn != nil {
+		result.APIHealthy = conn.IsHealthy
+		if !conn.LastPing.IsZero() {
+			lastPing := conn.LastPing
+			result.LastPing = &lastPing
+		}
+	}
+	result.Class = classifyReachability(result.APIHealthy, beacon, m.staleAfter, now)
+	return result
+}
+
+// classifyReachability - Beacon yang belum pernah masuk tidak dipakai untuk menyatakan router mati
+// (URL layer mungkin tidak terjangkau dari router)
+func classifyReachability(apiHealthy bool, beacon *models.RouterBeacon, staleAfter time.Duration, now time.Time) string {
+	switch {
+	case apiHealthy:
+		return models.ReachabilityOnline
+	case beacon == nil || beacon.LastSeenAt == nil:
+		return models.ReachabilityUnknown
+	case now.Sub(*beacon.LastSeenAt) <= staleAfter:
+		return models.ReachabilityAPIUnreachable
+	default:
+		return models.ReachabilityDown
+	}
+}
+
+// Run - Loop klasifikasi router yang terpasang beacon (blocking)
+func (m *BeaconMonitor) Run() {
+	if m.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		m.check()
+	}
+}
+
+func (m *BeaconMonitor) check() {
+	beacons, err := m.repo.List()
+	if err != nil {
+		log.Printf("[BEACON] Error loading beacons: %v", err)
+		return
+	}
+	routers, err := m.routers.GetActiveRouters()
+	if err != nil {
+		log.Printf("[BEACON] Error loading routers: %v", err)
+		return
+	}
+	active := make(map[int]*models.Router, len(routers))
+	for _, router := range routers {
+		if router.Status != "suspended" {
+			active[router.ID] = router
+		}
+	}
+
+	now := time.Now()
+	for _, beacon := range beacons {
+		router := active[beacon.RouterID]
+		if router == nil {
+			m.forget(beacon.RouterID)
+			continue
+		}
+		m.transition(router, m.reachability(router.ID, beacon, now))
+	}
+}
+
+// transition - Catat event saat klasifikasi berubah. Router yang baru dipantau dianggap online,
+// "unknown" tidak mengubah state.
+func (m *BeaconMonitor) transition(router *models.Router, reach *models.RouterReachability) {
+	if reach.Class == models.ReachabilityUnknown {
+		return
+	}
+
+	m.mu.Lock()
+	prev, ok := m.last[router.ID]
+	m.last[router.ID] = reach.Class
+	m.mu.Unlock()
+	if !ok {
+		prev = models.ReachabilityOnline
+	}
+	if prev == reach.Class {
+		return
+	}
+
+	record := func(eventType, severity, message string) {
+		log.Printf("[BEACON] Router %s: %s", router.Name, message)
+		routerID := router.ID
+		m.recorder.Record(&models.Event{
+			RouterID: &routerID,
+			Type:     eventType,
+			Severity: severity,
+			Message:  message,
+			Data: mustJSON(map[string]interface{}{
+				"class":     reach.Class,
+				"previous":  prev,
+				"last_ping": reach.LastPing,
+				"last_seen": reach.Beacon.LastSeenAt,
+			}),
+		})
+	}
+
+	if prev == models.ReachabilityDown {
+		record("router_up", "info", "Beacon router masuk lagi, router hidup")
+	}
+	switch reach.Class {
+	case models.ReachabilityAPIUnreachable:
+		if prev == models.ReachabilityOnline {
+			record("router_api_unreachable", "warning", "API router tidak bisa diakses, beacon masih masuk (router hidup)")
+		}
+	case models.ReachabilityDown:
+		record("router_down", "critical",
+			fmt.Sprintf("API router tidak bisa diakses dan beacon berhenti lebih dari %s", m.staleAfter))
+	case models.ReachabilityOnline:
+		record("router_api_recovered", "info", "API router bisa diakses lagi")
+	}
+}
+
+// forget - Reset state klasifikasi router (beacon dipasang ulang / dihapus)
+func (m *BeaconMonitor) forget(routerID int) {
+	m.mu.Lock()
+	delete(m.last, routerID)
+	m.mu.Unlock()
+}
+
+// beaconOnEvent - Script scheduler: POST heartbeat ke layer, hasil fetch dibuang
+// (sintaks "/tool fetch" supaya jalan di RouterOS v6 dan v7)
+func beaconOnEvent(baseURL, token string) string {
+	return fmt.Sprintf(`/tool fetch url="%s/api/beacon?token=%s" http-method=post output=none`, baseURL, token)
+}
+
+func newBeaconToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "mtb_" + hex.EncodeToString(buf), nil
+}
+
+func hashBeaconToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// InstallBeaconScheduler - Pasang / perbarui scheduler heartbeat (idempotent by nama)
+func (ms *MikrotikService) InstallBeaconScheduler(routerID int, onEvent string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/system/scheduler/print", fmt.Sprintf("?name=%s", BeaconSchedulerName), "=.proplist=.id")
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "install_beacon", dryRun)
+	if len(r.Re) > 0 {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("scheduler %s exists, updating", BeaconSchedulerName))
+		plan.Commands = append(plan.Commands, []string{
+			"/system/scheduler/set",
+			fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+			"=interval=1m",
+			fmt.Sprintf("=on-event=%s", onEvent),
+			"=disabled=no",
+		})
+	} else {
+		plan.Commands = append(plan.Commands, []string{
+			"/system/scheduler/add",
+			fmt.Sprintf("=name=%s", BeaconSchedulerName),
+			"=interval=1m",
+			"=start-time=startup",
+			fmt.Sprintf("=on-event=%s", onEvent),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// RemoveBeaconScheduler - Hapus scheduler heartbeat (tidak ada = plan kosong)
+func (ms *MikrotikService) RemoveBeaconScheduler(routerID int, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/system/scheduler/print", fmt.Sprintf("?name=%s", BeaconSchedulerName), "=.proplist=.id")
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "remove_beacon", dryRun)
+	if len(r.Re) == 0 {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("scheduler %s not found", BeaconSchedulerName))
+	}
+	for _, re := range r.Re {
+		plan.Commands = append(plan.Commands, []string{
+			"/system/scheduler/remove",
+			fmt.Sprintf("=.id=%s", re.Map[".id"]),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+)
+
+// Feature yang bergantung pada versi / package RouterOS
+const (
+	FeatureWireGuard = "wireguard"
+	FeatureWireless  = "wireless"
+	FeatureContainer = "container"
+	FeatureIPFIX     = "ipfix"
+	FeatureCAPsMAN   = "capsman" // CAPsMAN /caps-man, bagian dari package wireless
+)
+
+// featureRequirement - Syarat minimal sebuah feature
+type featureRequirement struct {
+	minMajor int
+	minMinor int
+	pkg      string // package yang harus terpasang & aktif, kosong = bawaan
+}
+
+var routerFeatures = map[string]featureRequirement{
+	FeatureWireGuard: {minMajor: 7},
+	FeatureWireless:  {pkg: "wireless"},
+	FeatureContainer: {minMajor: 7, minMinor: 4, pkg: "container"},
+	FeatureIPFIX:     {minMajor: 6, minMinor: 43},
+	FeatureCAPsMAN:   {pkg: "wireless"},
+}
+
+// UnsupportedFeatureError - Feature tidak tersedia di router (versi terlalu lama / package tidak ada)
+type UnsupportedFeatureError struct {
+	Feature string
+	Reason  string
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s unsupported on this router: %s", e.Feature, e.Reason)
+}
+
+// ErrorCode - Code machine-readable untuk response API
+func (e *UnsupportedFeatureError) ErrorCode() string {
+	return i18n.UnsupportedFeature
+}
+
+var versionPattern = regexp.MustCompile(`^(\d+)\.(\d+)`)
+
+// detectCapabilities - Baca /system/resource dan /system/package; caller memegang conn.mu
+func detectCapabilities(conn *MikrotikConnection) (*models.RouterCapabilities, error) {
+	r, err := conn.Run("/system/resource/print")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("no system info")
+	}
+
+	caps := &models.RouterCapabilities{
+		RouterID:     conn.RouterID,
+		Version:      r.Re[0].Map["version"],
+		Architecture: r.Re[0].Map["architecture-name"],
+		BoardName:    r.Re[0].Map["board-name"],
+		Features:     make(map[string]bool),
+		DetectedAt:   time.Now(),
+	}
+	if m := versionPattern.FindStringSubmatch(caps.Version); m != nil {
+		caps.VersionMajor, _ = strconv.Atoi(m[1])
+		caps.VersionMinor, _ = strconv.Atoi(m[2])
+	}
+
+	r, err = conn.Run("/system/package/print", "=.proplist=name,version,disabled")
+	if err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		caps.Packages = append(caps.Packages, models.RouterPackage{
+			Name:     re.Map["name"],
+			Version:  re.Map["version"],
+			Disabled: re.Map["disabled"] == "true",
+		})
+	}
+
+	for feature := range routerFeatures {
+		caps.Features[feature] = checkFeature(caps, feature) == nil
+	}
+
+	return caps, nil
+}
+
+// checkFeature - Evaluasi syarat feature terhadap capability router
+func checkFeature(caps *models.RouterCapabilities, feature string) error {
+	req, ok := routerFeatures[feature]
+	if !ok {
+		return fmt.Errorf("unknown feature %s", feature)
+	}
+
+	if caps.VersionMajor < req.minMajor || (caps.VersionMajor == req.minMajor && caps.VersionMinor < req.minMinor) {
+		return &UnsupportedFeatureError{
+			Feature: feature,
+			Reason:  fmt.Sprintf("requires RouterOS %d.%d or newer (router runs %s)", req.minMajor, req.minMinor, caps.Version),
+		}
+	}
+
+	if req.pkg != "" {
+		for _, p := range caps.Packages {
+			if p.Name == req.pkg && !p.Disabled {
+				return nil
+			}
+		}
+		return &UnsupportedFeatureError{
+			Feature: feature,
+			Reason:  fmt.Sprintf("package %s is not installed or disabled", req.pkg),
+		}
+	}
+
+	return nil
+}
+
+// GetCapabilities - Capability router dari cache koneksi; refresh memaksa deteksi ulang
+func (ms *MikrotikService) GetCapabilities(routerID int, refresh bool) (*models.RouterCapabilities, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	caps := conn.caps
+	conn.mu.RUnlock()
+	if caps != nil && !refresh {
+		return caps, nil
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	caps, err = detectCapabilities(conn)
+	if err != nil {
+		return nil, fmt.Errorf("capability detection failed: %v", err)
+	}
+	conn.caps = caps
+
+	return caps, nil
+}
+
+// RequireFeature - Error *UnsupportedFeatureError jika router tidak mendukung feature
+func (ms *MikrotikService) RequireFeature(routerID int, feature string) error {
+	caps, err := ms.GetCapabilities(routerID, false)
+	if err != nil {
+		return err
+	}
+	return checkFeature(caps, feature)
+}
+
+// cacheCapabilities - Deteksi saat connect; gagal tidak membatalkan koneksi (dicoba lagi saat dibutuhkan)
+func cacheCapabilities(conn *MikrotikConnection) {
+	caps, err := detectCapabilities(conn)
+	if err != nil {
+		log.Printf("Capability detection for router %d failed: %v", conn.RouterID, err)
+		return
+	}
+	conn.caps = caps
+	log.Printf("Router %d capabilities: RouterOS %s (%s), %d packages", conn.RouterID, caps.Version, caps.Architecture, len(caps.Packages))
+}
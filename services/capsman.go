@@ -0,0 +1,138 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+
+	"Mikrotik-Layer/models"
+)
+
+// GetCAPsMANRemoteCAPs - CAP yang terhubung ke CAPsMAN router
+func (ms *MikrotikService) GetCAPsMANRemoteCAPs(routerID int) ([]*models.CAPsMANRemoteCAP, error) {
+	if err := ms.RequireFeature(routerID, FeatureCAPsMAN); err != nil {
+		return nil, err
+	}
+
+	r, err := ms.runRead(routerID, "/caps-man/remote-cap/print")
+	if err != nil {
+		return nil, err
+	}
+
+	caps := make([]*models.CAPsMANRemoteCAP, 0, len(r.Re))
+	for _, re := range r.Re {
+		m := re.Map
+		radios, _ := strconv.Atoi(m["radios"])
+		caps = append(caps, &models.CAPsMANRemoteCAP{
+			ID:       m[".id"],
+			Name:     m["name"],
+			Identity: m["identity"],
+			Address:  m["address"],
+			Board:    m["board"],
+			Version:  m["version"],
+			BaseMAC:  m["base-mac"],
+			State:    m["state"],
+			Radios:   radios,
+		})
+	}
+	return caps, nil
+}
+
+// GetCAPsMANRegistrations - Client di semua AP CAPsMAN (iface kosong = semua interface CAP)
+func (ms *MikrotikService) GetCAPsMANRegistrations(routerID int, iface string) ([]*models.CAPsMANRegistration, error) {
+	if err := ms.RequireFeature(routerID, FeatureCAPsMAN); err != nil {
+		return nil, err
+	}
+
+	args := []string{"/caps-man/registration-table/print"}
+	if iface != "" {
+		args = append(args, fmt.Sprintf("?interface=%s", iface))
+	}
+	r, err := ms.runRead(routerID, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]*models.CAPsMANRegistration, 0, len(r.Re))
+	for _, re := range r.Re {
+		m := re.Map
+		clients = append(clients, &models.CAPsMANRegistration{
+			Interface:  m["interface"],
+			SSID:       m["ssid"],
+			MACAddress: m["mac-address"],
+			RxSignal:   parseLeadingInt(m["rx-signal"]),
+			TxRate:     m["tx-rate"],
+			RxRate:     m["rx-rate"],
+			TxRateMbps: parseWirelessRate(m["tx-rate"]),
+			RxRateMbps: parseWirelessRate(m["rx-rate"]),
+			Uptime:     m["uptime"],
+			Comment:    m["comment"],
+		})
+	}
+	return clients, nil
+}
+
+// GetCAPsMANStatus - Manager, radio (provisioned atau belum) dan rule provisioning CAPsMAN
+func (ms *MikrotikService) GetCAPsMANStatus(routerID int) (*models.CAPsMANStatus, error) {
+	if err := ms.RequireFeature(routerID, FeatureCAPsMAN); err != nil {
+		return nil, err
+	}
+
+	status := &models.CAPsMANStatus{
+		RouterID:     routerID,
+		Radios:       []*models.CAPsMANRadio{},
+		Provisioning: []*models.CAPsMANProvisioningRule{},
+	}
+
+	r, err := ms.runRead(routerID, "/caps-man/manager/print")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) > 0 {
+		status.Enabled = r.Re[0].Map["enabled"] == "true"
+	}
+
+	if r, err = ms.runRead(routerID, "/caps-man/remote-cap/print", "=.proplist=.id"); err != nil {
+		return nil, err
+	}
+	status.RemoteCAPs = len(r.Re)
+
+	if r, err = ms.runRead(routerID, "/caps-man/registration-table/print", "=.proplist=.id"); err != nil {
+		return nil, err
+	}
+	status.Clients = len(r.Re)
+
+	if r, err = ms.runRead(routerID, "/caps-man/radio/print"); err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		radio := &models.CAPsMANRadio{
+			RadioMAC:    re.Map["radio-mac"],
+			RemoteCAP:   re.Map["remote-cap-identity"],
+			Interface:   re.Map["interface"],
+			Provisioned: re.Map["provisioned"] == "true",
+		}
+		if !radio.Provisioned {
+			status.Unprovisioned++
+		}
+		status.Radios = append(status.Radios, radio)
+	}
+
+	if r, err = ms.runRead(routerID, "/caps-man/provisioning/print"); err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		m := re.Map
+		status.Provisioning = append(status.Provisioning, &models.CAPsMANProvisioningRule{
+			ID:                  m[".id"],
+			RadioMAC:            m["radio-mac"],
+			Action:              m["action"],
+			MasterConfiguration: m["master-configuration"],
+			SlaveConfigurations: m["slave-configurations"],
+			NameFormat:          m["name-format"],
+			IdentityRegexp:      m["identity-regexp"],
+			Comment:             m["comment"],
+			Disabled:            m["disabled"] == "true",
+		})
+	}
+	return status, nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// externalChangePattern - Log config RouterOS (topic system,info) yang diterima syslog receiver:
+// v6 "address changed by admin", v7 "ip address changed by winbox-3.41/tcp-msg(winbox):admin@10.0.0.5 (/ip address set *1 ...)"
+var externalChangePattern = regexp.MustCompile(`^(.+?) (added|changed|removed|moved) by (\S+)(?: \((.*)\))?$`)
+
+// changelogScanLimit - Jumlah pesan syslog kandidat yang diperiksa per request
+const changelogScanLimit = 1000
+
+// RouterChangelog - Gabungkan perubahan lewat layer (audit log) dan perubahan langsung di router
+// (log config dari syslog) dalam rentang [from, to], terbaru dulu. Log config oleh user API layer
+// dilewati karena sudah tercatat di audit log. Perubahan external hanya terlihat jika remote logging
+// router mengirim topic system.
+func RouterChangelog(audit *repository.AuditRepository, events *repository.EventRepository, router *models.Router, from, to time.Time, limit int) (*models.RouterChangelog, error) {
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	entries, err := audit.List(models.AuditFilter{RouterID: &router.ID, From: &from, To: &to, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	logs, err := events.List(models.EventFilter{RouterID: &router.ID, Type: "syslog", Query: " by ",
+		From: &from, To: &to, Limit: changelogScanLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*models.RouterChange, 0, len(entries)+len(logs))
+	for _, entry := range entries {
+		changes = append(changes, &models.RouterChange{
+			Time:    entry.CreatedAt,
+			Source:  models.ChangeSourceLayer,
+			Actor:   entry.Actor,
+			Action:  entry.Action,
+			Target:  entry.Target,
+			Success: entry.Success,
+			Error:   entry.Error,
+			Details: entry.Details,
+			AuditID: &entry.ID,
+		})
+	}
+	for _, event := range logs {
+		if change, ok := parseExternalChange(event, router.Username); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Time.After(changes[j].Time) })
+
+	changelog := &models.RouterChangelog{RouterID: router.ID, From: from, To: to, Changes: changes}
+	if len(changes) > limit {
+		changelog.Changes = changes[:limit]
+		changelog.Truncated = true
+	}
+	for _, change := range changelog.Changes {
+		if change.Source == models.ChangeSourceLayer {
+			changelog.Layer++
+		} else {
+			changelog.External++
+		}
+	}
+	return changelog, nil
+}
+
+// parseExternalChange - Perubahan dari satu pesan syslog; false jika bukan log config atau
+// dilakukan oleh layerUser (login API layer ke router)
+func parseExternalChange(event *models.Event, layerUser string) (*models.RouterChange, bool) {
+	m := externalChangePattern.FindStringSubmatch(event.Message)
+	if m == nil {
+		return nil, false
+	}
+	if changeUser(m[3]) == layerUser {
+		return nil, false
+	}
+	return &models.RouterChange{
+		Time:    event.CreatedAt,
+		Source:  models.ChangeSourceExternal,
+		Actor:   m[3],
+		Action:  m[2],
+		Target:  m[1],
+		Success: true,
+		Command: m[4],
+		EventID: &event.ID,
+	}, true
+}
+
+// changeUser - Nama user dari pelaku di log config: "admin", "api:admin@10.0.0.5",
+// "winbox-3.41/tcp-msg(winbox):admin@10.0.0.5"
+func changeUser(by string) string {
+	if i := strings.Index(by, "@"); i >= 0 {
+		by = by[:i]
+	}
+	if i := strings.LastIndex(by, ":"); i >= 0 {
+		by = by[i+1:]
+	}
+	return by
+}
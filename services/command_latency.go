@@ -0,0 +1,78 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// commandLatencyWindow - Jumlah command terakhir yang dihitung persentilnya
+const commandLatencyWindow = 200
+
+// latencyExcluded - Command yang durasinya ditentukan parameter (mis. /ping count=N),
+// tidak mencerminkan responsivitas API sehingga tidak ikut dihitung
+var latencyExcluded = map[string]bool{
+	"/ping": true,
+}
+
+// latencyRing - Ring buffer durasi command per koneksi
+type latencyRing struct {
+	mu      sync.Mutex
+	samples [commandLatencyWindow]time.Duration
+	next    int
+	count   int
+	last    time.Duration
+}
+
+func (l *latencyRing) add(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.samples[l.next] = d
+	l.next = (l.next + 1) % commandLatencyWindow
+	if l.count < commandLatencyWindow {
+		l.count++
+	}
+	l.last = d
+}
+
+// stats - Persentil nearest-rank dari isi window; nil jika belum ada command
+func (l *latencyRing) stats() *models.CommandLatency {
+	l.mu.Lock()
+	sorted := make([]time.Duration, l.count)
+	copy(sorted, l.samples[:l.count])
+	last := l.last
+	l.mu.Unlock()
+
+	if len(sorted) == 0 {
+		return nil
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p int) float64 {
+		idx := (len(sorted)*p + 99) / 100
+		if idx > 0 {
+			idx--
+		}
+		return durationMs(sorted[idx])
+	}
+	return &models.CommandLatency{
+		Samples: len(sorted),
+		LastMs:  durationMs(last),
+		P50Ms:   percentile(50),
+		P90Ms:   percentile(90),
+		P99Ms:   percentile(99),
+		MaxMs:   durationMs(sorted[len(sorted)-1]),
+	}
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// CommandLatency - Persentil latency command RouterOS koneksi ini (nil jika belum ada command)
+func (c *MikrotikConnection) CommandLatency() *models.CommandLatency {
+	return c.latency.stats()
+}
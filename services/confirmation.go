@@ -0,0 +1,80 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// pendingConfirmation - Token yang sudah diterbitkan, terikat ke aksi, target dan user
+type pendingConfirmation struct {
+	action   string
+	target   string // identitas objek + isi request (mis. "router:5", hash body job)
+	username string
+	expires  time.Time
+}
+
+// ConfirmationStore - Token konfirmasi dua langkah untuk aksi destruktif (hapus router,
+// job fleet yang mengubah / reboot router). Token sekali pakai dan kedaluwarsa setelah TTL,
+// sehingga request mentah yang salah ketik tidak langsung dieksekusi.
+type ConfirmationStore struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	tokens map[string]*pendingConfirmation
+}
+
+func NewConfirmationStore(ttl time.Duration) *ConfirmationStore {
+	return &ConfirmationStore{
+		ttl:    ttl,
+		tokens: make(map[string]*pendingConfirmation),
+	}
+}
+
+// Issue - Terbitkan token untuk aksi + target milik user (username kosong jika auth nonaktif)
+func (s *ConfirmationStore) Issue(action, target, username string, impact interface{}) (*models.ConfirmationRequired, error) {
+	id, err := newEventID()
+	if err != nil {
+		return nil, err
+	}
+	token := "cfm_" + id
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for t, c := range s.tokens {
+		if now.After(c.expires) {
+			delete(s.tokens, t)
+		}
+	}
+	s.tokens[token] = &pendingConfirmation{
+		action:   action,
+		target:   target,
+		username: username,
+		expires:  now.Add(s.ttl),
+	}
+
+	return &models.ConfirmationRequired{
+		ConfirmToken: token,
+		Action:       action,
+		ExpiresAt:    now.Add(s.ttl),
+		Impact:       impact,
+	}, nil
+}
+
+// Consume - True jika token valid untuk aksi, target dan user yang sama. Token langsung
+// hangus (juga saat tidak cocok) supaya tidak bisa ditebak ulang.
+func (s *ConfirmationStore) Consume(token, action, target, username string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.tokens[token]
+	if !ok {
+		return false
+	}
+	delete(s.tokens, token)
+
+	return time.Now().Before(c.expires) && c.action == action && c.target == target && c.username == username
+}
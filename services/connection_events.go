@@ -0,0 +1,39 @@
+package services
+
+import (
+	"errors"
+
+	"Mikrotik-Layer/models"
+)
+
+// errLoginFailed - Dial berhasil tapi RouterOS menolak login
+var errLoginFailed = errors.New("login failed")
+
+// publishConnection - Kirim perubahan state koneksi router ke hub topic "connections"
+// supaya dashboard tidak perlu polling /api/connections/status
+func (ms *MikrotikService) publishConnection(routerID int, router *models.Router, state, reason string) {
+	event := &models.ConnectionEvent{RouterID: routerID, State: state, Reason: reason}
+	if router != nil {
+		event.RouterName = router.Name
+	}
+	GetHub().Publish("connections", &routerID, event)
+}
+
+// publishConnected - Event connected beserta path / alamat yang dipakai
+func (ms *MikrotikService) publishConnected(conn *MikrotikConnection) {
+	GetHub().Publish("connections", &conn.RouterID, &models.ConnectionEvent{
+		RouterID:      conn.RouterID,
+		RouterName:    conn.Router.Name,
+		State:         models.ConnectionConnected,
+		ActivePath:    conn.ActivePath,
+		ActiveAddress: conn.ActiveAddress,
+	})
+}
+
+// connectFailedState - auth_failed jika login ditolak, selain itu disconnected
+func connectFailedState(err error) string {
+	if errors.Is(err, errLoginFailed) {
+		return models.ConnectionAuthFailed
+	}
+	return models.ConnectionDisconnected
+}
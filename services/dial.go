@@ -0,0 +1,209 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/proxy"
+
+	"Mikrotik-Layer/models"
+)
+
+// netDialer - Dialer TCP ke router: langsung, lewat SOCKS5, atau lewat tunnel SSH
+type netDialer interface {
+	Dial(network, address string) (net.Conn, error)
+}
+
+// DialConfig - Default alamat sumber koneksi keluar ke router (API & FTP), untuk host layer
+// dengan VRF management atau beberapa uplink
+type DialConfig struct {
+	SourceAddress string // IP lokal sumber koneksi, kosong = dipilih kernel
+	BindInterface string // interface / device VRF (SO_BINDTODEVICE, Linux), kosong = tidak di-bind
+
+	// Hostname DNS: family yang didahulukan ("ipv4" / "ipv6", kosong = urutan DNS) dan interval
+	// resolve ulang (0 = resolve hanya saat dial)
+	PreferFamily    string
+	ResolveInterval time.Duration
+
+	// Interval tes path primary untuk koneksi lewat alamat tambahan (0 = tanpa failback)
+	FailbackInterval time.Duration
+}
+
+// SetDialConfig - Ganti default dial; berlaku untuk koneksi berikutnya
+func (ms *MikrotikService) SetDialConfig(c DialConfig) {
+	ms.dial.Store(&c)
+}
+
+func (ms *MikrotikService) dialSettings() DialConfig {
+	if p := ms.dial.Load(); p != nil {
+		return *p
+	}
+	return DialConfig{}
+}
+
+// dialConfig - Default dari konfigurasi ditimpa override per router
+func (ms *MikrotikService) dialConfig(router *models.Router) DialConfig {
+	c := ms.dialSettings()
+	if router.SourceAddress != nil && *router.SourceAddress != "" {
+		c.SourceAddress = *router.SourceAddress
+	}
+	if router.BindInterface != nil && *router.BindInterface != "" {
+		c.BindInterface = *router.BindInterface
+	}
+	return c
+}
+
+// routerDialer - Dialer ke router dengan source address / interface sesuai dialConfig, lewat
+// jump host jika router mengisinya (source address berlaku untuk koneksi ke jump host)
+func (ms *MikrotikService) routerDialer(router *models.Router, timeout time.Duration) (netDialer, error) {
+	c := ms.dialConfig(router)
+	dialer := &net.Dialer{Timeout: timeout}
+
+	if c.SourceAddress != "" {
+		ip := net.ParseIP(c.SourceAddress)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid source address %q", c.SourceAddress)
+		}
+		dialer.LocalAddr = &net.TCPAddr{IP: ip}
+	}
+	if c.BindInterface != "" {
+		control, err := bindToDevice(c.BindInterface)
+		if err != nil {
+			return nil, err
+		}
+		dialer.Control = control
+	}
+
+	jumpType := ""
+	if router.JumpType != nil {
+		jumpType = *router.JumpType
+	}
+	switch jumpType {
+	case "", models.JumpNone:
+		return dialer, nil
+	case models.JumpSOCKS5:
+		return socksDialer(router, dialer)
+	case models.JumpSSH:
+		return sshDialer(router, dialer, timeout)
+	default:
+		return nil, fmt.Errorf("unknown jump type %s", jumpType)
+	}
+}
+
+func jumpAddress(router *models.Router) (string, error) {
+	if router.JumpAddress == nil || *router.JumpAddress == "" {
+		return "", fmt.Errorf("jump address not set for router %s", router.Name)
+	}
+	return *router.JumpAddress, nil
+}
+
+// socksDialer - Proxy SOCKS5, auth username/password jika jump_username diisi
+func socksDialer(router *models.Router, forward *net.Dialer) (netDialer, error) {
+	address, err := jumpAddress(router)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth *proxy.Auth
+	if router.JumpUsername != nil && *router.JumpUsername != "" {
+		auth = &proxy.Auth{User: *router.JumpUsername}
+		if router.JumpPassword != nil {
+			auth.Password = *router.JumpPassword
+		}
+	}
+	return proxy.SOCKS5("tcp", address, auth, forward)
+}
+
+// sshJumpDialer - Koneksi ke router di-forward lewat direct-tcpip server SSH. Satu sesi SSH per
+// koneksi, ditutup bersama koneksinya.
+type sshJumpDialer struct {
+	forward *net.Dialer
+	address string
+	config  *ssh.ClientConfig
+}
+
+// sshDialer - Tunnel SSH dengan auth private key dan/atau password
+func sshDialer(router *models.Router, forward *net.Dialer, timeout time.Duration) (netDialer, error) {
+	address, err := jumpAddress(router)
+	if err != nil {
+		return nil, err
+	}
+	if router.JumpUsername == nil || *router.JumpUsername == "" {
+		return nil, fmt.Errorf("jump username not set for router %s", router.Name)
+	}
+
+	var methods []ssh.AuthMethod
+	if router.JumpPrivateKey != nil && *router.JumpPrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(*router.JumpPrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid jump private key: %w", err)
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if router.JumpPassword != nil && *router.JumpPassword != "" {
+		methods = append(methods, ssh.Password(*router.JumpPassword))
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("jump password or private key not set for router %s", router.Name)
+	}
+
+	hostKey := ssh.InsecureIgnoreHostKey()
+	if router.JumpHostKey != nil && *router.JumpHostKey != "" {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(*router.JumpHostKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid jump host key: %w", err)
+		}
+		hostKey = ssh.FixedHostKey(key)
+	} else {
+		log.Printf("⚠ Router %s: jump host key not set, %s host key not verified", router.Name, address)
+	}
+
+	return &sshJumpDialer{
+		forward: forward,
+		address: address,
+		config: &ssh.ClientConfig{
+			User:            *router.JumpUsername,
+			Auth:            methods,
+			HostKeyCallback: hostKey,
+			Timeout:         timeout,
+		},
+	}, nil
+}
+
+func (d *sshJumpDialer) Dial(network, address string) (net.Conn, error) {
+	conn, err := d.forward.Dial("tcp", d.address)
+	if err != nil {
+		return nil, fmt.Errorf("ssh jump connect failed: %w", err)
+	}
+
+	conn.SetDeadline(time.Now().Add(d.config.Timeout))
+	c, chans, reqs, err := ssh.NewClientConn(conn, d.address, d.config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ssh jump handshake failed: %w", err)
+	}
+	conn.SetDeadline(time.Time{})
+
+	client := ssh.NewClient(c, chans, reqs)
+	target, err := client.Dial(network, address)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("ssh jump forward failed: %w", err)
+	}
+	return &jumpConn{Conn: target, client: client}, nil
+}
+
+// jumpConn - Koneksi lewat tunnel SSH; Close ikut menutup sesi SSH
+type jumpConn struct {
+	net.Conn
+	client *ssh.Client
+}
+
+func (c *jumpConn) Close() error {
+	err := c.Conn.Close()
+	c.client.Close()
+	return err
+}
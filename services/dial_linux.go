@@ -0,0 +1,19 @@
+//go:build linux
+
+package services
+
+import "syscall"
+
+// bindToDevice - Ikat socket ke interface / device VRF sebelum connect
+func bindToDevice(iface string) (func(network, address string, c syscall.RawConn) error, error) {
+	return func(network, address string, c syscall.RawConn) error {
+		var sockErr error
+		err := c.Control(func(fd uintptr) {
+			sockErr = syscall.SetsockoptString(int(fd), syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, iface)
+		})
+		if err != nil {
+			return err
+		}
+		return sockErr
+	}, nil
+}
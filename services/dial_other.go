@@ -0,0 +1,13 @@
+//go:build !linux
+
+package services
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// bindToDevice - SO_BINDTODEVICE hanya ada di Linux; pakai source address di OS lain
+func bindToDevice(iface string) (func(network, address string, c syscall.RawConn) error, error) {
+	return nil, fmt.Errorf("bind interface %s not supported on this OS, use source address", iface)
+}
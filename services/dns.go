@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/models"
+
+	"github.com/go-routeros/routeros/v3"
+)
+
+const (
+	// routerDialTimeout - Batas waktu dial + login ke router (dibagi rata jika ada beberapa alamat)
+	routerDialTimeout = 20 * time.Second
+
+	// minAddressDialTimeout - Batas bawah waktu dial per alamat saat failover antar record DNS
+	minAddressDialTimeout = 5 * time.Second
+
+	dnsLookupTimeout = 5 * time.Second
+)
+
+// dnsEntry - Hasil resolve hostname router, urut sesuai preferensi address family
+type dnsEntry struct {
+	addrs      []string
+	resolvedAt time.Time
+}
+
+// resolveHost - Alamat IP untuk hostname router. Cache dipakai selama belum lebih tua dari
+// ResolveInterval (0 = selalu resolve ulang saat dial); jika lookup gagal, cache lama tetap dipakai.
+func (ms *MikrotikService) resolveHost(host string, force bool) ([]string, error) {
+	if net.ParseIP(host) != nil {
+		return []string{host}, nil
+	}
+
+	c := ms.dialSettings()
+	cached, ok := ms.dnsCache.Load(host)
+	if ok && !force && c.ResolveInterval > 0 && time.Since(cached.(*dnsEntry).resolvedAt) < c.ResolveInterval {
+		return cached.(*dnsEntry).addrs, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err == nil && len(ips) == 0 {
+		err = fmt.Errorf("no address for %s", host)
+	}
+	if err != nil {
+		if ok {
+			log.Printf("⚠ Resolve %s failed, using cached addresses: %v", host, err)
+			return cached.(*dnsEntry).addrs, nil
+		}
+		return nil, fmt.Errorf("resolve %s failed: %w", host, err)
+	}
+
+	// Family yang dipilih di depan, family lain tetap dicoba sebagai failover
+	sort.SliceStable(ips, func(i, j int) bool {
+		return familyRank(ips[i].IP, c.PreferFamily) < familyRank(ips[j].IP, c.PreferFamily)
+	})
+	addrs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, ip.IP.String())
+	}
+
+	ms.dnsCache.Store(host, &dnsEntry{addrs: addrs, resolvedAt: time.Now()})
+	return addrs, nil
+}
+
+func familyRank(ip net.IP, prefer string) int {
+	isV4 := ip.To4() != nil
+	switch {
+	case prefer == "ipv4" && !isV4, prefer == "ipv6" && isV4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// primaryPath - Path koneksi lewat hostname router (alamat tambahan memakai label masing-masing)
+const primaryPath = "primary"
+
+// dialTarget - Satu kandidat dial: path, alamat management terdaftar, dan IP hasil resolve
+type dialTarget struct {
+	path    string
+	host    string
+	address string
+}
+
+// dialTargets - Kandidat dial berurutan: hostname lalu alamat tambahan (urut priority), masing-masing
+// diperluas ke semua IP hasil resolve. Dengan jump host alamat diteruskan apa adanya supaya
+// di-resolve dari sisi proxy / server SSH.
+func (ms *MikrotikService) dialTargets(router *models.Router) ([]dialTarget, error) {
+	hosts := []dialTarget{{path: primaryPath, host: router.Hostname}}
+	extra, err := ms.repo.ListAddresses(router.ID)
+	if err != nil {
+		log.Printf("⚠ Router %s: error loading secondary addresses: %v", router.Name, err)
+	}
+	for _, a := range extra {
+		hosts = append(hosts, dialTarget{path: a.Label, host: a.Address})
+	}
+
+	jump := router.JumpType != nil && *router.JumpType != "" && *router.JumpType != models.JumpNone
+	var (
+		targets []dialTarget
+		lastErr error
+	)
+	for _, h := range hosts {
+		if jump {
+			targets = append(targets, dialTarget{path: h.path, host: h.host, address: h.host})
+			continue
+		}
+		addrs, err := ms.resolveHost(h.host, false)
+		if err != nil {
+			log.Printf("⚠ Router %s: %v", router.Name, err)
+			lastErr = err
+			continue
+		}
+		for _, addr := range addrs {
+			targets = append(targets, dialTarget{path: h.path, host: h.host, address: addr})
+		}
+	}
+	if len(targets) == 0 {
+		return nil, lastErr
+	}
+	return targets, nil
+}
+
+// dialRouter - Dial + login ke kandidat pertama yang berhasil; return client dan kandidat yang dipakai
+func (ms *MikrotikService) dialRouter(router *models.Router) (*routeros.Client, dialTarget, error) {
+	targets, err := ms.dialTargets(router)
+	if err != nil {
+		return nil, dialTarget{}, err
+	}
+
+	timeout := routerDialTimeout / time.Duration(len(targets))
+	if timeout < minAddressDialTimeout {
+		timeout = minAddressDialTimeout
+	}
+	dialer, err := ms.routerDialer(router, timeout)
+	if err != nil {
+		return nil, dialTarget{}, err
+	}
+
+	var lastErr error
+	for _, t := range targets {
+		address := net.JoinHostPort(t.address, strconv.Itoa(router.Port))
+		log.Printf("Dialing %s via %s path (timeout: %v)...", address, t.path, timeout)
+
+		client, err := dialWithTimeout(dialer, address, router.Username, router.Password, timeout)
+		if err == nil {
+			return client, t, nil
+		}
+		log.Printf("Dial %s for router %s failed: %v", address, router.Name, err)
+		// Login ditolak lebih informatif daripada timeout alamat lain
+		if lastErr == nil || !errors.Is(lastErr, errLoginFailed) {
+			lastErr = err
+		}
+	}
+	return nil, dialTarget{}, lastErr
+}
+
+// resolveRoutine - Resolve ulang hostname router yang terhubung tiap ResolveInterval. Jika alamat
+// yang sedang dipakai tidak lagi ada di DNS, koneksi ditandai unhealthy dan di-reconnect.
+func (ms *MikrotikService) resolveRoutine() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		interval := ms.dialSettings().ResolveInterval
+		if interval <= 0 {
+			continue
+		}
+
+		for routerID, conn := range ms.GetAllConnections() {
+			host, current := conn.ActiveAddress, conn.ResolvedAddress
+			if current == "" || current == host {
+				continue
+			}
+			if cached, ok := ms.dnsCache.Load(host); ok && time.Since(cached.(*dnsEntry).resolvedAt) < interval {
+				continue
+			}
+
+			addrs, err := ms.resolveHost(host, true)
+			if err != nil {
+				log.Printf("⚠ Re-resolve %s failed: %v", host, err)
+				continue
+			}
+			if containsString(addrs, current) {
+				continue
+			}
+
+			log.Printf("⚠ Router %s: %s no longer resolves to %s (now %v), reconnecting", conn.Router.Name, host, current, addrs)
+			conn.IsHealthy = false
+			go ms.ConnectRouter(routerID)
+		}
+	}
+}
+
+// failbackRoutine - Tiap FailbackInterval, koneksi yang berjalan lewat alamat tambahan dipindah
+// kembali ke hostname (path primary) begitu port API-nya bisa dijangkau lagi
+func (ms *MikrotikService) failbackRoutine() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	var lastRun time.Time
+	for range ticker.C {
+		interval := ms.dialSettings().FailbackInterval
+		if interval <= 0 || time.Since(lastRun) < interval {
+			continue
+		}
+		lastRun = time.Now()
+
+		for routerID, conn := range ms.GetAllConnections() {
+			if conn.IsVirtual() || !conn.IsHealthy || conn.ActivePath == "" || conn.ActivePath == primaryPath {
+				continue
+			}
+			if !ms.primaryReachable(conn.Router) {
+				continue
+			}
+
+			log.Printf("Router %s: primary address %s reachable again, failing back from %s path",
+				conn.Router.Name, conn.Router.Hostname, conn.ActivePath)
+			conn.IsHealthy = false
+			go ms.ConnectRouter(routerID)
+		}
+	}
+}
+
+// SetEventRecorder - Recorder event failover / failback path koneksi; dipasang sebelum Start
+func (ms *MikrotikService) SetEventRecorder(recorder *EventRecorder) {
+	ms.events = recorder
+}
+
+// recordPathChange - Event saat alamat management yang dipakai berganti: failover (warning) jika
+// koneksi lewat alamat tambahan, failback (info, me-resolve alert failover) jika kembali ke hostname
+// dari alamat tambahan. Koneksi pertama lewat hostname tidak dicatat.
+func (ms *MikrotikService) recordPathChange(router *models.Router, target dialTarget) {
+	if ms.events == nil {
+		return
+	}
+
+	previous := ""
+	if router.ActiveAddress != nil {
+		previous = *router.ActiveAddress
+	}
+	event := &models.Event{
+		RouterID: &router.ID,
+		Data: mustJSON(map[string]interface{}{
+			"path":     target.path,
+			"address":  target.host,
+			"previous": previous,
+			"primary":  router.Hostname,
+		}),
+	}
+
+	if target.path != primaryPath {
+		event.Type, event.Severity = "connection_path_failover", "warning"
+		event.Message = fmt.Sprintf("Koneksi API lewat path %s (%s), hostname %s tidak terjangkau",
+			target.path, target.host, router.Hostname)
+	} else {
+		if previous == "" || !ms.isSecondaryAddress(router.ID, previous) {
+			return
+		}
+		event.Type, event.Severity = "connection_path_failback", "info"
+		event.Message = fmt.Sprintf("Koneksi API kembali ke hostname %s (sebelumnya %s)", router.Hostname, previous)
+	}
+	ms.events.Record(event)
+}
+
+// isSecondaryAddress - True jika address terdaftar sebagai alamat tambahan router
+func (ms *MikrotikService) isSecondaryAddress(routerID int, address string) bool {
+	extra, err := ms.repo.ListAddresses(routerID)
+	if err != nil {
+		return false
+	}
+	for _, a := range extra {
+		if a.Address == address {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryReachable - True jika port API di hostname router menerima koneksi TCP
+func (ms *MikrotikService) primaryReachable(router *models.Router) bool {
+	dialer, err := ms.routerDialer(router, minAddressDialTimeout)
+	if err != nil {
+		return false
+	}
+	host := router.Hostname
+	if router.JumpType == nil || *router.JumpType == "" || *router.JumpType == models.JumpNone {
+		addrs, err := ms.resolveHost(host, false)
+		if err != nil {
+			return false
+		}
+		host = addrs[0]
+	}
+
+	c, err := dialer.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(router.Port)))
+	if err != nil {
+		return false
+	}
+	c.Close()
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
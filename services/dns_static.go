@@ -0,0 +1,209 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// GetDNSStatic - Semua entry /ip/dns/static
+func (ms *MikrotikService) GetDNSStatic(routerID int) ([]*models.DNSStaticEntry, error) {
+	r, err := ms.runRead(routerID, "/ip/dns/static/print",
+		"=.proplist=.id,name,regexp,type,address,cname,ttl,comment,disabled,dynamic")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*models.DNSStaticEntry{}
+	for _, re := range r.Re {
+		recordType := re.Map["type"]
+		if recordType == "" {
+			recordType = "A"
+		}
+		entries = append(entries, &models.DNSStaticEntry{
+			ID:       re.Map[".id"],
+			Name:     re.Map["name"],
+			Regexp:   re.Map["regexp"],
+			Type:     recordType,
+			Address:  re.Map["address"],
+			CNAME:    re.Map["cname"],
+			TTL:      re.Map["ttl"],
+			Comment:  re.Map["comment"],
+			Disabled: re.Map["disabled"] == "true",
+			Dynamic:  re.Map["dynamic"] == "true",
+		})
+	}
+	return entries, nil
+}
+
+// AddDNSStatic - Tambah entry DNS static. Idempotent: entry dengan name, type dan nilai yang
+// sama tidak ditambah ulang (beberapa A record untuk satu name tetap boleh).
+func (ms *MikrotikService) AddDNSStatic(routerID int, req *models.DNSStaticRequest, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	attr, value := "address", derefString(req.Address)
+	if req.Type == "CNAME" {
+		attr, value = "cname", derefString(req.CNAME)
+	}
+
+	r, err := conn.Run("/ip/dns/static/print", fmt.Sprintf("?name=%s", req.Name), "=.proplist=.id,type,address,cname")
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "add_dns_static", dryRun)
+	for _, re := range r.Re {
+		recordType := re.Map["type"]
+		if recordType == "" {
+			recordType = "A"
+		}
+		if recordType == req.Type && re.Map[attr] == value {
+			plan.Checks = append(plan.Checks, fmt.Sprintf("%s %s %s already exists (%s)", req.Name, req.Type, value, re.Map[".id"]))
+			return plan, nil
+		}
+	}
+
+	add := []string{
+		"/ip/dns/static/add",
+		fmt.Sprintf("=name=%s", req.Name),
+		fmt.Sprintf("=%s=%s", attr, value),
+	}
+	// type hanya dikirim untuk selain A supaya tetap kompatibel dengan RouterOS lama tanpa atribut type
+	if req.Type != "A" {
+		add = append(add, fmt.Sprintf("=type=%s", req.Type))
+	}
+	if req.TTL != nil && *req.TTL != "" {
+		add = append(add, fmt.Sprintf("=ttl=%s", *req.TTL))
+	}
+	if req.Comment != nil && *req.Comment != "" {
+		add = append(add, fmt.Sprintf("=comment=%s", *req.Comment))
+	}
+	if req.Disabled != nil && *req.Disabled {
+		add = append(add, "=disabled=yes")
+	}
+	plan.Commands = append(plan.Commands, add)
+
+	return plan, executePlan(conn, plan)
+}
+
+// RemoveDNSStatic - Hapus entry DNS static by .id (entry dynamic ditolak)
+func (ms *MikrotikService) RemoveDNSStatic(routerID int, id string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	entry, err := dnsStaticByID(conn, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "remove_dns_static", dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("entry %s exists (%s)", id, entry["name"]))
+	plan.Commands = append(plan.Commands, []string{
+		"/ip/dns/static/remove",
+		fmt.Sprintf("=.id=%s", id),
+	})
+
+	return plan, executePlan(conn, plan)
+}
+
+// SetDNSStaticDisabled - Enable/disable entry DNS static. Idempotent: entry yang sudah di
+// status tujuan tidak di-set ulang.
+func (ms *MikrotikService) SetDNSStaticDisabled(routerID int, id string, disabled, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	entry, err := dnsStaticByID(conn, id)
+	if err != nil {
+		return nil, err
+	}
+
+	action, state, value := "enable_dns_static", "enabled", "no"
+	if disabled {
+		action, state, value = "disable_dns_static", "disabled", "yes"
+	}
+	plan := newCommandPlan(routerID, action, dryRun)
+
+	if (entry["disabled"] == "true") == disabled {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("entry %s already %s", id, state))
+	} else {
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/dns/static/set",
+			fmt.Sprintf("=.id=%s", id),
+			fmt.Sprintf("=disabled=%s", value),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// GetDNSSettings - Server upstream dan pemakaian cache /ip/dns
+func (ms *MikrotikService) GetDNSSettings(routerID int) (*models.DNSSettings, error) {
+	r, err := ms.runRead(routerID, "/ip/dns/print")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("no data from /ip/dns")
+	}
+
+	m := r.Re[0].Map
+	return &models.DNSSettings{
+		Servers:             splitList(m["servers"]),
+		DynamicServers:      splitList(m["dynamic-servers"]),
+		AllowRemoteRequests: m["allow-remote-requests"] == "true",
+		CacheSizeKiB:        parseKiB(m["cache-size"]),
+		CacheUsedKiB:        parseKiB(m["cache-used"]),
+		CacheMaxTTL:         m["cache-max-ttl"],
+	}, nil
+}
+
+// dnsStaticByID - Atribut entry DNS static by .id; entry dynamic tidak bisa diubah lewat API.
+// Caller wajib sudah memegang conn.mu.
+func dnsStaticByID(conn *MikrotikConnection, id string) (map[string]string, error) {
+	r, err := conn.Run("/ip/dns/static/print", fmt.Sprintf("?.id=%s", id), "=.proplist=.id,name,disabled,dynamic")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("dns static entry %s not found", id)
+	}
+	if r.Re[0].Map["dynamic"] == "true" {
+		return nil, fmt.Errorf("dns static entry %s is dynamic and cannot be changed", id)
+	}
+	return r.Re[0].Map, nil
+}
+
+// splitList - Nilai list RouterOS (comma-separated) ke slice, kosong = slice kosong
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseKiB - Ukuran cache RouterOS ("2048" atau "2048KiB") dalam KiB
+func parseKiB(value string) int64 {
+	n, _ := strconv.ParseInt(strings.TrimSuffix(value, "KiB"), 10, 64)
+	return n
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// newCommandPlan - Buat plan kosong untuk sebuah operasi mutasi
+func newCommandPlan(routerID int, action string, dryRun bool) *models.CommandPlan {
+	return &models.CommandPlan{
+		RouterID: routerID,
+		Action:   action,
+		DryRun:   dryRun,
+		Commands: make([][]string, 0),
+	}
+}
+
+// executePlan - Jalankan semua sentence di plan secara berurutan.
+// Untuk dry run tidak ada yang dieksekusi; caller wajib sudah memegang conn.mu.
+func executePlan(conn *MikrotikConnection, plan *models.CommandPlan) error {
+	if plan.DryRun {
+		return nil
+	}
+
+	for i, sentence := range plan.Commands {
+		if _, err := conn.RunArgs(sentence); err != nil {
+			return fmt.Errorf("command %d/%d (%s) failed: %w", i+1, len(plan.Commands), sentence[0], err)
+		}
+	}
+
+	return nil
+}
+
+// executePlanAtomic - Seperti executePlan, tetapi jika satu command gagal semua command sebelumnya
+// dibatalkan (urutan terbalik): item hasil .../add dihapus lagi, command lain memakai restore[i]
+// (mis. add ulang item yang dihapus plan). Command non-add tanpa restore tidak dipulihkan, jadi
+// taruh command add setelah semua pengecekan konflik.
+func executePlanAtomic(conn *MikrotikConnection, plan *models.CommandPlan, restore map[int][]string) error {
+	if plan.DryRun {
+		return nil
+	}
+
+	var undo [][]string
+	for i, sentence := range plan.Commands {
+		r, err := conn.RunArgs(sentence)
+		if err != nil {
+			rolledBack := 0
+			for j := len(undo) - 1; j >= 0; j-- {
+				if _, rbErr := conn.RunArgs(undo[j]); rbErr != nil {
+					log.Printf("[PLAN] Router %d: rollback %s failed: %v", plan.RouterID, strings.Join(undo[j], " "), rbErr)
+				} else {
+					rolledBack++
+				}
+			}
+			return fmt.Errorf("command %d/%d (%s) failed, %d/%d item(s) rolled back: %w",
+				i+1, len(plan.Commands), sentence[0], rolledBack, len(undo), err)
+		}
+		if path, ok := strings.CutSuffix(sentence[0], "/add"); ok && r != nil && r.Done != nil && r.Done.Map["ret"] != "" {
+			undo = append(undo, []string{path + "/remove", "=.id=" + r.Done.Map["ret"]})
+		} else if cmd, ok := restore[i]; ok {
+			undo = append(undo, cmd)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"log"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// alertRecoveries - Event pemulihan yang otomatis me-resolve alert type lain pada router yang sama
+var alertRecoveries = map[string]string{
+	"path_recovered":           "path_degraded",
+	"ospf_neighbor_up":         "ospf_neighbor_down",
+	"bgp_peer_up":              "bgp_peer_down",
+	"route_recovered":          "route_failover",
+	"interface_errors_cleared": "interface_errors",
+	"topology_link_up":         "topology_link_down",
+	"connection_path_failback": "connection_path_failover",
+	"router_up":                "router_down",
+	"router_api_recovered":     "router_api_unreachable",
+	"router_health_recovered":  "router_degraded",
+}
+
+// EventRecorder - Simpan event ke DB lalu publish ke hub topic "events".
+// Event warning ke atas juga membuka / memperbarui alert (topic "alerts").
+type EventRecorder struct {
+	repo    *repository.EventRepository
+	alerts  *repository.AlertRepository
+	hub     *Hub
+	routing *AlertRouter
+}
+
+func NewEventRecorder(repo *repository.EventRepository, alerts *repository.AlertRepository, hub *Hub) *EventRecorder {
+	return &EventRecorder{repo: repo, alerts: alerts, hub: hub}
+}
+
+// WithAlertRouting - Alert yang baru terbuka juga dikirim ke channel notifikasi
+func (r *EventRecorder) WithAlertRouting(routing *AlertRouter) *EventRecorder {
+	r.routing = routing
+	return r
+}
+
+// Record - Catat event; error DB hanya di-log supaya worker tidak berhenti
+func (r *EventRecorder) Record(event *models.Event) {
+	if err := r.repo.Create(event); err != nil {
+		log.Printf("[EVENT] Error storing %s event: %v", event.Type, err)
+	}
+	if r.hub != nil {
+		r.hub.Publish("events", event.RouterID, event)
+	}
+
+	if r.alerts == nil {
+		return
+	}
+	if isAlertSeverity(event.Severity) {
+		alert, err := r.alerts.Raise(event)
+		if err != nil {
+			log.Printf("[ALERT] Error raising %s alert: %v", event.Type, err)
+			return
+		}
+		r.publishAlert(alert)
+		// Kemunculan berikutnya hanya memperbarui alert yang sama, tidak dinotifikasi ulang
+		if alert.Occurrences == 1 {
+			r.routing.Route(alert)
+		}
+	}
+	if target, ok := alertRecoveries[event.Type]; ok {
+		r.autoResolve(event.RouterID, target)
+	}
+}
+
+// autoResolve - Resolve alert type tertentu atas nama "system"
+func (r *EventRecorder) autoResolve(routerID *int, alertType string) {
+	ids, err := r.alerts.OpenIDs(routerID, alertType)
+	if err != nil {
+		log.Printf("[ALERT] Error loading open %s alerts: %v", alertType, err)
+		return
+	}
+	for _, id := range ids {
+		if err := r.alerts.Resolve(id, "system", nil); err != nil {
+			log.Printf("[ALERT] Error resolving alert %d: %v", id, err)
+			continue
+		}
+		if alert, err := r.alerts.GetByID(id); err == nil {
+			r.publishAlert(alert)
+		}
+	}
+}
+
+func (r *EventRecorder) publishAlert(alert *models.Alert) {
+	if r.hub != nil {
+		r.hub.Publish("alerts", alert.RouterID, alert)
+	}
+}
+
+func isAlertSeverity(severity string) bool {
+	for _, s := range models.AlertSeverities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
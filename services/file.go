@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"Mikrotik-Layer/models"
+)
+
+// apiFileContentsLimit - Batas atribut contents /file lewat API; file lebih besar / biner lewat FTP
+const apiFileContentsLimit = 4095
+
+// GetFiles - Daftar entry /file router
+func (ms *MikrotikService) GetFiles(routerID int) ([]*models.RouterFile, error) {
+	r, err := ms.runRead(routerID, "/file/print", "=.proplist=.id,name,type,size,creation-time")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*models.RouterFile, 0, len(r.Re))
+	for _, re := range r.Re {
+		size, _ := strconv.ParseInt(re.Map["size"], 10, 64)
+		files = append(files, &models.RouterFile{
+			ID:           re.Map[".id"],
+			Name:         re.Map["name"],
+			Type:         re.Map["type"],
+			Size:         size,
+			CreationTime: re.Map["creation-time"],
+		})
+	}
+
+	return files, nil
+}
+
+// FileService - Upload/download/hapus file router. File teks kecil lewat atribut contents
+// API, selebihnya (firmware, backup, halaman hotspot besar) lewat FTP router.
+type FileService struct {
+	ms      *MikrotikService
+	ftpPort int
+}
+
+func NewFileService(ms *MikrotikService, ftpPort int) *FileService {
+	return &FileService{ms: ms, ftpPort: ftpPort}
+}
+
+// Download - Isi file router
+func (s *FileService) Download(routerID int, name string) ([]byte, error) {
+	conn, err := s.ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	r, err := conn.Run("/file/print", fmt.Sprintf("?name=%s", name), "=.proplist=type,size,contents")
+	conn.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("file %s not found", name)
+	}
+	if r.Re[0].Map["type"] == "directory" {
+		return nil, fmt.Errorf("file %s is a directory", name)
+	}
+
+	size, _ := strconv.ParseInt(r.Re[0].Map["size"], 10, 64)
+	if contents, ok := r.Re[0].Map["contents"]; ok && int64(len(contents)) == size {
+		return []byte(contents), nil
+	}
+
+	ftp, err := s.dial(conn)
+	if err != nil {
+		return nil, err
+	}
+	defer ftp.Close()
+	return ftp.Retrieve(name)
+}
+
+// Upload - Tulis file ke router (ditimpa jika sudah ada). Dry run hanya menampilkan metode transfer.
+func (s *FileService) Upload(routerID int, name string, content []byte, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := s.ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/file/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id,type")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) > 0 && r.Re[0].Map["type"] == "directory" {
+		return nil, fmt.Errorf("file %s is a directory", name)
+	}
+
+	plan := newCommandPlan(routerID, "upload_file", dryRun)
+	if len(content) > apiFileContentsLimit || !utf8.Valid(content) {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("%d bytes uploaded via ftp", len(content)))
+		if dryRun {
+			return plan, nil
+		}
+		ftp, err := s.dial(conn)
+		if err != nil {
+			return nil, err
+		}
+		defer ftp.Close()
+		return plan, ftp.Store(name, content)
+	}
+
+	plan.Checks = append(plan.Checks, fmt.Sprintf("%d bytes uploaded via api", len(content)))
+	if len(r.Re) > 0 {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("file %s exists, overwriting", name))
+		plan.Commands = append(plan.Commands, []string{
+			"/file/set",
+			fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+			fmt.Sprintf("=contents=%s", content),
+		})
+	} else {
+		plan.Commands = append(plan.Commands, []string{
+			"/file/add",
+			fmt.Sprintf("=name=%s", name),
+			fmt.Sprintf("=contents=%s", content),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// Remove - Hapus file / direktori router
+func (s *FileService) Remove(routerID int, name string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := s.ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/file/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id,type,size")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("file %s not found", name)
+	}
+
+	plan := newCommandPlan(routerID, "remove_file", dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("file %s exists (%s, %s bytes)", name, r.Re[0].Map["type"], r.Re[0].Map["size"]))
+	plan.Commands = append(plan.Commands, []string{
+		"/file/remove",
+		fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+	})
+
+	return plan, executePlan(conn, plan)
+}
+
+// dial - Login FTP memakai kredensial API router
+func (s *FileService) dial(conn *MikrotikConnection) (*ftpConn, error) {
+	if conn.IsVirtual() {
+		return nil, fmt.Errorf("file transfer not supported on virtual router")
+	}
+	dialer, err := s.ms.routerDialer(conn.Router, ftpTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return dialFTP(dialer, conn.Router, conn.dialHost(), s.ftpPort)
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+
+	"Mikrotik-Layer/models"
+)
+
+// firewallRuleProplist - Atribut /ip/firewall/filter yang dibaca ke models.FirewallRule
+const firewallRuleProplist = "=.proplist=.id,chain,action,src-address,dst-address,src-address-list,dst-address-list," +
+	"protocol,src-port,dst-port,in-interface,out-interface,connection-state,jump-target,comment,disabled,dynamic,invalid,bytes,packets"
+
+// GetFirewallRules - Rule /ip/firewall/filter sesuai urutan evaluasi (chain kosong = semua chain)
+func (ms *MikrotikService) GetFirewallRules(routerID int, chain string) ([]*models.FirewallRule, error) {
+	sentence := []string{"/ip/firewall/filter/print", firewallRuleProplist}
+	if chain != "" {
+		sentence = append(sentence, fmt.Sprintf("?chain=%s", chain))
+	}
+	r, err := ms.runRead(routerID, sentence...)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*models.FirewallRule{}
+	for _, re := range r.Re {
+		bytes, _ := strconv.ParseInt(re.Map["bytes"], 10, 64)
+		packets, _ := strconv.ParseInt(re.Map["packets"], 10, 64)
+		rules = append(rules, &models.FirewallRule{
+			ID:              re.Map[".id"],
+			Chain:           re.Map["chain"],
+			Action:          re.Map["action"],
+			SrcAddress:      re.Map["src-address"],
+			DstAddress:      re.Map["dst-address"],
+			SrcAddressList:  re.Map["src-address-list"],
+			DstAddressList:  re.Map["dst-address-list"],
+			Protocol:        re.Map["protocol"],
+			SrcPort:         re.Map["src-port"],
+			DstPort:         re.Map["dst-port"],
+			InInterface:     re.Map["in-interface"],
+			OutInterface:    re.Map["out-interface"],
+			ConnectionState: re.Map["connection-state"],
+			JumpTarget:      re.Map["jump-target"],
+			Comment:         re.Map["comment"],
+			Disabled:        re.Map["disabled"] == "true",
+			Dynamic:         re.Map["dynamic"] == "true",
+			Invalid:         re.Map["invalid"] == "true",
+			Bytes:           bytes,
+			Packets:         packets,
+		})
+	}
+	return rules, nil
+}
+
+// AddFirewallRule - Tambah rule filter; dengan place_before rule disisipkan sebelum rule tsb
+// (harus ada), tanpa itu ditaruh di akhir daftar
+func (ms *MikrotikService) AddFirewallRule(routerID int, req *models.FirewallRuleRequest, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	plan := newCommandPlan(routerID, "add_firewall_rule", dryRun)
+	add := []string{
+		"/ip/firewall/filter/add",
+		fmt.Sprintf("=chain=%s", req.Chain),
+		fmt.Sprintf("=action=%s", req.Action),
+	}
+	for _, field := range []struct {
+		attr  string
+		value *string
+	}{
+		{"src-address", req.SrcAddress},
+		{"dst-address", req.DstAddress},
+		{"src-address-list", req.SrcAddressList},
+		{"dst-address-list", req.DstAddressList},
+		{"protocol", req.Protocol},
+		{"src-port", req.SrcPort},
+		{"dst-port", req.DstPort},
+		{"in-interface", req.InInterface},
+		{"out-interface", req.OutInterface},
+		{"connection-state", req.ConnectionState},
+		{"jump-target", req.JumpTarget},
+		{"comment", req.Comment},
+	} {
+		if field.value != nil && *field.value != "" {
+			add = append(add, fmt.Sprintf("=%s=%s", field.attr, *field.value))
+		}
+	}
+	if req.Disabled != nil && *req.Disabled {
+		add = append(add, "=disabled=yes")
+	}
+
+	if req.PlaceBefore != nil && *req.PlaceBefore != "" {
+		target, err := firewallRuleByID(conn, *req.PlaceBefore)
+		if err != nil {
+			return nil, err
+		}
+		plan.Checks = append(plan.Checks, fmt.Sprintf("placed before rule %s (chain %s)", *req.PlaceBefore, target["chain"]))
+		add = append(add, fmt.Sprintf("=place-before=%s", *req.PlaceBefore))
+	} else {
+		plan.Checks = append(plan.Checks, "placed at the end of the filter list")
+	}
+	plan.Commands = append(plan.Commands, add)
+
+	return plan, executePlan(conn, plan)
+}
+
+// RemoveFirewallRule - Hapus rule filter by .id (rule dynamic ditolak)
+func (ms *MikrotikService) RemoveFirewallRule(routerID int, id string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	rule, err := firewallRuleByID(conn, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "remove_firewall_rule", dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("rule %s exists (chain %s, action %s)", id, rule["chain"], rule["action"]))
+	plan.Commands = append(plan.Commands, []string{
+		"/ip/firewall/filter/remove",
+		fmt.Sprintf("=.id=%s", id),
+	})
+
+	return plan, executePlan(conn, plan)
+}
+
+// SetFirewallRuleDisabled - Enable/disable rule filter. Idempotent: rule yang sudah di status
+// tujuan tidak di-set ulang.
+func (ms *MikrotikService) SetFirewallRuleDisabled(routerID int, id string, disabled, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	rule, err := firewallRuleByID(conn, id)
+	if err != nil {
+		return nil, err
+	}
+
+	action, state, value := "enable_firewall_rule", "enabled", "no"
+	if disabled {
+		action, state, value = "disable_firewall_rule", "disabled", "yes"
+	}
+	plan := newCommandPlan(routerID, action, dryRun)
+
+	if (rule["disabled"] == "true") == disabled {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("rule %s already %s", id, state))
+	} else {
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/firewall/filter/set",
+			fmt.Sprintf("=.id=%s", id),
+			fmt.Sprintf("=disabled=%s", value),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// MoveFirewallRule - Pindahkan rule ke posisi sebelum rule before (kosong = ke akhir daftar)
+func (ms *MikrotikService) MoveFirewallRule(routerID int, id, before string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if _, err := firewallRuleByID(conn, id); err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "move_firewall_rule", dryRun)
+	move := []string{"/ip/firewall/filter/move", fmt.Sprintf("=numbers=%s", id)}
+	if before != "" {
+		if before == id {
+			return nil, fmt.Errorf("firewall rule %s cannot be moved before itself", id)
+		}
+		if _, err := firewallRuleByID(conn, before); err != nil {
+			return nil, err
+		}
+		plan.Checks = append(plan.Checks, fmt.Sprintf("rule %s moved before rule %s", id, before))
+		move = append(move, fmt.Sprintf("=destination=%s", before))
+	} else {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("rule %s moved to the end of the filter list", id))
+	}
+	plan.Commands = append(plan.Commands, move)
+
+	return plan, executePlan(conn, plan)
+}
+
+// firewallRuleByID - Atribut rule filter by .id; rule dynamic tidak bisa diubah lewat API.
+// Caller wajib sudah memegang conn.mu.
+func firewallRuleByID(conn *MikrotikConnection, id string) (map[string]string, error) {
+	r, err := conn.Run("/ip/firewall/filter/print", fmt.Sprintf("?.id=%s", id), "=.proplist=.id,chain,action,disabled,dynamic")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("firewall rule %s not found", id)
+	}
+	if r.Re[0].Map["dynamic"] == "true" {
+		return nil, fmt.Errorf("firewall rule %s is dynamic and cannot be changed", id)
+	}
+	return r.Re[0].Map, nil
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"Mikrotik-Layer/models"
+)
+
+// ftpTimeout - Batas waktu dial & transfer FTP ke router
+const ftpTimeout = 2 * time.Minute
+
+// ftpConn - Client FTP minimal (passive mode, binary) untuk transfer file yang
+// melebihi batas contents API RouterOS
+type ftpConn struct {
+	host   string
+	dialer netDialer
+	conn   net.Conn
+	text   *textproto.Conn
+}
+
+func dialFTP(dialer netDialer, router *models.Router, host string, port int) (*ftpConn, error) {
+	conn, err := dialer.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("ftp connect failed: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(ftpTimeout))
+
+	c := &ftpConn{host: host, dialer: dialer, conn: conn, text: textproto.NewConn(conn)}
+	if _, _, err := c.text.ReadResponse(220); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("ftp greeting failed: %w", err)
+	}
+	if _, err := c.cmd(331, "USER %s", router.Username); err != nil {
+		c.Close()
+		return nil, err
+	}
+	if _, err := c.cmd(230, "PASS %s", router.Password); err != nil {
+		c.Close()
+		return nil, err
+	}
+	if _, err := c.cmd(200, "TYPE I"); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// ftpArg - Tolak argumen perintah FTP dengan CR/LF atau karakter kontrol lain
+// (mencegah injeksi perintah tambahan di control connection)
+func ftpArg(arg string) error {
+	if strings.ContainsFunc(arg, unicode.IsControl) {
+		return fmt.Errorf("ftp argument contains control characters")
+	}
+	return nil
+}
+
+func (c *ftpConn) cmd(expect int, format string, args ...interface{}) (string, error) {
+	for _, arg := range args {
+		if s, ok := arg.(string); ok {
+			if err := ftpArg(s); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := c.text.PrintfLine(format, args...); err != nil {
+		return "", err
+	}
+	_, msg, err := c.text.ReadResponse(expect)
+	if err != nil {
+		verb := strings.Fields(format)[0]
+		return "", fmt.Errorf("ftp %s failed: %w", verb, err)
+	}
+	return msg, nil
+}
+
+// passive - Buka koneksi data PASV. Alamat dari reply diabaikan dan diganti host router
+// supaya tetap jalan di belakang NAT.
+func (c *ftpConn) passive() (net.Conn, error) {
+	msg, err := c.cmd(227, "PASV")
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := strings.Index(msg, "("), strings.Index(msg, ")")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("ftp PASV reply invalid: %s", msg)
+	}
+	fields := strings.Split(msg[start+1:end], ",")
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("ftp PASV reply invalid: %s", msg)
+	}
+	p1, err1 := strconv.Atoi(strings.TrimSpace(fields[4]))
+	p2, err2 := strconv.Atoi(strings.TrimSpace(fields[5]))
+	if err1 != nil || err2 != nil {
+		return nil, fmt.Errorf("ftp PASV reply invalid: %s", msg)
+	}
+
+	data, err := c.dialer.Dial("tcp", net.JoinHostPort(c.host, strconv.Itoa(p1*256+p2)))
+	if err != nil {
+		return nil, fmt.Errorf("ftp data connect failed: %w", err)
+	}
+	data.SetDeadline(time.Now().Add(ftpTimeout))
+	return data, nil
+}
+
+// Retrieve - Download file
+func (c *ftpConn) Retrieve(name string) ([]byte, error) {
+	if err := ftpArg(name); err != nil {
+		return nil, err
+	}
+	data, err := c.passive()
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+
+	if err := c.text.PrintfLine("RETR %s", name); err != nil {
+		return nil, err
+	}
+	if _, _, err := c.text.ReadResponse(1); err != nil {
+		return nil, fmt.Errorf("ftp RETR failed: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, data); err != nil {
+		return nil, err
+	}
+	data.Close()
+
+	if _, _, err := c.text.ReadResponse(2); err != nil {
+		return nil, fmt.Errorf("ftp RETR failed: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Store - Upload file (ditimpa jika sudah ada)
+func (c *ftpConn) Store(name string, content []byte) error {
+	if err := ftpArg(name); err != nil {
+		return err
+	}
+	data, err := c.passive()
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+
+	if err := c.text.PrintfLine("STOR %s", name); err != nil {
+		return err
+	}
+	if _, _, err := c.text.ReadResponse(1); err != nil {
+		return fmt.Errorf("ftp STOR failed: %w", err)
+	}
+
+	if _, err := data.Write(content); err != nil {
+		return err
+	}
+	data.Close()
+
+	if _, _, err := c.text.ReadResponse(2); err != nil {
+		return fmt.Errorf("ftp STOR failed: %w", err)
+	}
+	return nil
+}
+
+func (c *ftpConn) Close() error {
+	c.text.PrintfLine("QUIT")
+	return c.conn.Close()
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// GetGraphingRules - Rule /tool/graphing (interface, queue, resource) router; target "all" di-expand
+func (ms *MikrotikService) GetGraphingRules(routerID int) ([]*models.GraphingImportItem, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	var items []*models.GraphingImportItem
+	menus := []struct {
+		kind, path, attr string
+	}{
+		{models.GraphingInterface, "/tool/graphing/interface/print", "interface"},
+		{models.GraphingQueue, "/tool/graphing/queue/print", "simple-queue"},
+		{models.GraphingResource, "/tool/graphing/resource/print", ""},
+	}
+	for _, menu := range menus {
+		r, err := conn.Run(menu.path)
+		if err != nil {
+			return nil, err
+		}
+		for _, re := range r.Re {
+			target := "system"
+			if menu.attr != "" {
+				target = re.Map[menu.attr]
+			}
+			items = append(items, &models.GraphingImportItem{
+				Type:         menu.kind,
+				Target:       target,
+				AllowAddress: re.Map["allow-address"],
+				StoreOnDisk:  re.Map["store-on-disk"] == "true",
+				Disabled:     re.Map["disabled"] == "true",
+			})
+		}
+	}
+
+	// interface=all -> satu item per interface
+	var expanded []*models.GraphingImportItem
+	for _, item := range items {
+		if item.Type != models.GraphingInterface || item.Target != "all" {
+			expanded = append(expanded, item)
+			continue
+		}
+		r, err := conn.Run("/interface/print", "=.proplist=name")
+		if err != nil {
+			return nil, err
+		}
+		for _, re := range r.Re {
+			entry := *item
+			entry.Target = re.Map["name"]
+			expanded = append(expanded, &entry)
+		}
+	}
+
+	return expanded, nil
+}
+
+// ImportGraphing - Jalankan padanan rule /tool/graphing router di layer: interface -> traffic
+// sampler (traffic_history), queue -> sudah dicatat queue usage sampler, resource -> belum ada.
+// Dry run hanya melaporkan aksi yang akan dilakukan.
+func (ts *TrafficSampler) ImportGraphing(routerID int, interval time.Duration, dryRun bool) ([]*models.GraphingImportItem, error) {
+	items, err := ts.ms.GetGraphingRules(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	imported := make([]*models.GraphingImportItem, 0, len(items))
+	for _, item := range items {
+		key := item.Type + "/" + item.Target
+		if item.Disabled || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		switch item.Type {
+		case models.GraphingInterface:
+			ts.mu.Lock()
+			_, running := ts.samplers[samplerKey(routerID, item.Target)]
+			ts.mu.Unlock()
+
+			if running {
+				item.Action = models.GraphingSamplerRunning
+			} else if dryRun {
+				item.Action = models.GraphingSamplerStarted
+			} else if err := ts.Start(routerID, item.Target, interval); err != nil {
+				item.Action = models.GraphingUnsupported
+				item.Note = err.Error()
+			} else {
+				item.Action = models.GraphingSamplerStarted
+			}
+		case models.GraphingQueue:
+			item.Action = models.GraphingCovered
+			item.Note = "simple queues are sampled by the queue usage sampler"
+		default:
+			item.Action = models.GraphingUnsupported
+			item.Note = fmt.Sprintf("%s graphing has no layer equivalent", item.Type)
+		}
+		imported = append(imported, item)
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// Window observasi flap & error rate untuk skor kesehatan
+const healthWindow = time.Hour
+
+// healthLossWindow - Jumlah health check terakhir untuk menghitung loss
+const healthLossWindow = 10
+
+// HealthThresholds - Ambang level degraded: router terkoneksi tapi latency health check (rata-rata
+// bergerak) atau persen check gagal melewati ambang. 0 = ambang tsb tidak dipakai.
+type HealthThresholds struct {
+	Latency time.Duration
+	LossPct float64
+}
+
+// Bobot komponen skor (total 1)
+const (
+	healthWeightFlaps   = 0.25
+	healthWeightCPU     = 0.2
+	healthWeightMemory  = 0.15
+	healthWeightErrors  = 0.2
+	healthWeightLatency = 0.2
+)
+
+// HealthScorer - Skor kesehatan komposit per router dari health check berkala
+// (flap, CPU, memory, error rate command, latency). Disimpan di memori, diperbarui tiap check.
+type HealthScorer struct {
+	mu         sync.RWMutex
+	routers    map[int]*routerHealthState
+	thresholds HealthThresholds
+}
+
+type routerHealthState struct {
+	healthy   bool
+	seen      bool
+	downs     []time.Time          // waktu transisi up -> down
+	checks    []bool               // hasil health check terakhir (maks healthLossWindow)
+	commands  []commandCountSample // delta counter command per check
+	cpu       float64
+	memUsed   float64
+	latencyMs float64
+	lastTotal uint64
+	lastErr   uint64
+	score     *models.RouterHealth
+}
+
+type commandCountSample struct {
+	at     time.Time
+	total  uint64
+	errors uint64
+}
+
+var (
+	healthScorerInstance *HealthScorer
+	healthScorerOnce     sync.Once
+)
+
+// GetHealthScorer - Singleton scorer
+func GetHealthScorer() *HealthScorer {
+	healthScorerOnce.Do(func() {
+		healthScorerInstance = &HealthScorer{
+			routers:    make(map[int]*routerHealthState),
+			thresholds: HealthThresholds{Latency: time.Second, LossPct: 20},
+		}
+	})
+	return healthScorerInstance
+}
+
+// SetThresholds - Ambang level degraded; dipasang sebelum MikrotikService.Start
+func (h *HealthScorer) SetThresholds(t HealthThresholds) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.thresholds = t
+}
+
+// observe - Catat hasil satu health check (resource nil jika check gagal). Mengembalikan level
+// sebelumnya ("" untuk check pertama) dan skor baru.
+func (h *HealthScorer) observe(conn *MikrotikConnection, healthy bool, latency time.Duration, resource map[string]string) (string, *models.RouterHealth) {
+	now := time.Now()
+	total, errs := conn.commandCounts()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	st, ok := h.routers[conn.RouterID]
+	if !ok {
+		st = &routerHealthState{}
+		h.routers[conn.RouterID] = st
+	}
+
+	if st.seen && st.healthy && !healthy {
+		st.downs = append(st.downs, now)
+	}
+	st.healthy, st.seen = healthy, true
+	st.checks = append(st.checks, healthy)
+	if len(st.checks) > healthLossWindow {
+		st.checks = st.checks[len(st.checks)-healthLossWindow:]
+	}
+
+	// Counter di-reset saat reconnect (koneksi baru): mulai ulang baseline
+	if total < st.lastTotal || errs < st.lastErr {
+		st.lastTotal, st.lastErr = 0, 0
+	}
+	st.commands = append(st.commands, commandCountSample{at: now, total: total - st.lastTotal, errors: errs - st.lastErr})
+	st.lastTotal, st.lastErr = total, errs
+
+	if healthy {
+		ms := float64(latency.Microseconds()) / 1000
+		if st.latencyMs == 0 {
+			st.latencyMs = ms
+		} else {
+			st.latencyMs = 0.3*ms + 0.7*st.latencyMs
+		}
+	}
+	if resource != nil {
+		if v, err := strconv.ParseFloat(resource["cpu-load"], 64); err == nil {
+			st.cpu = v
+		}
+		free, errFree := strconv.ParseFloat(resource["free-memory"], 64)
+		totalMem, errTotal := strconv.ParseFloat(resource["total-memory"], 64)
+		if errFree == nil && errTotal == nil && totalMem > 0 {
+			st.memUsed = (1 - free/totalMem) * 100
+		}
+	}
+
+	prev := ""
+	if st.score != nil {
+		prev = st.score.Level
+	}
+	st.prune(now)
+	st.score = st.compute(conn.RouterID, now, h.thresholds)
+	copied := *st.score
+	return prev, &copied
+}
+
+// prune - Buang observasi di luar window
+func (st *routerHealthState) prune(now time.Time) {
+	cutoff := now.Add(-healthWindow)
+	for len(st.downs) > 0 && st.downs[0].Before(cutoff) {
+		st.downs = st.downs[1:]
+	}
+	for len(st.commands) > 0 && st.commands[0].at.Before(cutoff) {
+		st.commands = st.commands[1:]
+	}
+}
+
+// compute - Skor 0-100: rata-rata berbobot komponen; router yang sedang down selalu 0.
+// Level: down jika check terakhir gagal, degraded jika latency / loss melewati ambang.
+func (st *routerHealthState) compute(routerID int, now time.Time, thresholds HealthThresholds) *models.RouterHealth {
+	var total, errs uint64
+	for _, c := range st.commands {
+		total += c.total
+		errs += c.errors
+	}
+	errorRate := 0.0
+	if total > 0 {
+		errorRate = float64(errs) / float64(total)
+	}
+
+	score := 0.0
+	if st.healthy {
+		score = healthWeightFlaps*clampScore(100-20*float64(len(st.downs))) +
+			healthWeightCPU*clampScore(100-2*(st.cpu-50)) +
+			healthWeightMemory*clampScore(100-2.5*(st.memUsed-60)) +
+			healthWeightErrors*clampScore(100-200*errorRate) +
+			healthWeightLatency*clampScore(100-(st.latencyMs-50)*100/950)
+	}
+
+	failed := 0
+	for _, ok := range st.checks {
+		if !ok {
+			failed++
+		}
+	}
+	lossPct := 0.0
+	if len(st.checks) > 0 {
+		lossPct = float64(failed) / float64(len(st.checks)) * 100
+	}
+
+	health := &models.RouterHealth{
+		RouterID:      routerID,
+		Score:         math.Round(score*10) / 10,
+		Healthy:       st.healthy,
+		Level:         models.HealthLevelHealthy,
+		Flaps:         len(st.downs),
+		CPULoad:       st.cpu,
+		MemoryUsedPct: math.Round(st.memUsed*10) / 10,
+		ErrorRate:     math.Round(errorRate*1000) / 1000,
+		LatencyMs:     math.Round(st.latencyMs*10) / 10,
+		LossPct:       math.Round(lossPct*10) / 10,
+		UpdatedAt:     now,
+	}
+
+	switch {
+	case !st.healthy:
+		health.Level = models.HealthLevelDown
+	default:
+		if limit := durationMs(thresholds.Latency); limit > 0 && st.latencyMs > limit {
+			health.Reasons = append(health.Reasons, fmt.Sprintf("latency %.0fms above %.0fms", st.latencyMs, limit))
+		}
+		if thresholds.LossPct > 0 && lossPct > thresholds.LossPct {
+			health.Reasons = append(health.Reasons, fmt.Sprintf("loss %.0f%% above %.0f%%", lossPct, thresholds.LossPct))
+		}
+		if len(health.Reasons) > 0 {
+			health.Level = models.HealthLevelDegraded
+		}
+	}
+	return health
+}
+
+// recordHealthLevel - Event perubahan level: masuk degraded (warning, bisa di-route sebagai alert)
+// dan kembali healthy dari degraded (me-resolve alert degraded). Transisi ke / dari down sudah
+// tercermin di status router (router_down / router_up), jadi down -> healthy tidak dicatat.
+func (ms *MikrotikService) recordHealthLevel(conn *MikrotikConnection, prev string, health *models.RouterHealth) {
+	if ms.events == nil || prev == "" || prev == health.Level {
+		return
+	}
+
+	var eventType, severity, message string
+	switch health.Level {
+	case models.HealthLevelDegraded:
+		eventType, severity = "router_degraded", "warning"
+		message = fmt.Sprintf("Kesehatan router %s menurun: %s", conn.Router.Name, strings.Join(health.Reasons, ", "))
+	case models.HealthLevelHealthy:
+		if prev != models.HealthLevelDegraded {
+			return
+		}
+		eventType, severity = "router_health_recovered", "info"
+		message = fmt.Sprintf("Router %s sehat kembali (sebelumnya %s)", conn.Router.Name, prev)
+	default:
+		return
+	}
+
+	log.Printf("[HEALTH] %s", message)
+	routerID := conn.RouterID
+	ms.events.Record(&models.Event{
+		RouterID: &routerID,
+		Type:     eventType,
+		Severity: severity,
+		Message:  message,
+		Data: mustJSON(map[string]interface{}{
+			"level":      health.Level,
+			"previous":   prev,
+			"latency_ms": health.LatencyMs,
+			"loss_pct":   health.LossPct,
+			"reasons":    health.Reasons,
+		}),
+	})
+}
+
+// clampScore - Batasi komponen ke 0-100
+func clampScore(v float64) float64 {
+	return math.Max(0, math.Min(100, v))
+}
+
+// Get - Skor terakhir router, nil jika belum pernah dicek
+func (h *HealthScorer) Get(routerID int) *models.RouterHealth {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if st, ok := h.routers[routerID]; ok && st.score != nil {
+		copied := *st.score
+		return &copied
+	}
+	return nil
+}
+
+// Worst - Skor semua router yang dipantau, terburuk dulu (limit <= 0 = semua)
+func (h *HealthScorer) Worst(limit int) []*models.RouterHealth {
+	h.mu.RLock()
+	scores := make([]*models.RouterHealth, 0, len(h.routers))
+	for _, st := range h.routers {
+		if st.score != nil {
+			copied := *st.score
+			scores = append(scores, &copied)
+		}
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score < scores[j].Score
+		}
+		return scores[i].RouterID < scores[j].RouterID
+	})
+	if limit > 0 && len(scores) > limit {
+		scores = scores[:limit]
+	}
+	return scores
+}
+
+// Forget - Hapus state router (disconnect/suspend/hapus); skor dihitung ulang saat terkoneksi lagi
+func (h *HealthScorer) Forget(routerID int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.routers, routerID)
+}
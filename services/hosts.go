@@ -0,0 +1,86 @@
+package services
+
+import (
+	"Mikrotik-Layer/models"
+)
+
+// GetARP - Tabel ARP router dengan vendor MAC
+func (ms *MikrotikService) GetARP(routerID int) ([]*models.ARPEntry, error) {
+	r, err := ms.runRead(routerID, "/ip/arp/print", "=.proplist=.id,address,mac-address,interface,dynamic,complete")
+	if err != nil {
+		return nil, err
+	}
+
+	oui := GetOUIResolver()
+	entries := make([]*models.ARPEntry, 0, len(r.Re))
+	for _, re := range r.Re {
+		entries = append(entries, &models.ARPEntry{
+			ID:         re.Map[".id"],
+			Address:    re.Map["address"],
+			MacAddress: re.Map["mac-address"],
+			Interface:  re.Map["interface"],
+			Vendor:     oui.Lookup(re.Map["mac-address"]),
+			Dynamic:    re.Map["dynamic"] == "true",
+			Complete:   re.Map["complete"] == "true",
+		})
+	}
+
+	return entries, nil
+}
+
+// GetDHCPLeases - Lease DHCP server router dengan vendor MAC
+func (ms *MikrotikService) GetDHCPLeases(routerID int) ([]*models.DHCPLease, error) {
+	r, err := ms.runRead(routerID, "/ip/dhcp-server/lease/print",
+		"=.proplist=.id,address,mac-address,host-name,server,status,expires-after,dynamic")
+	if err != nil {
+		return nil, err
+	}
+
+	oui := GetOUIResolver()
+	leases := make([]*models.DHCPLease, 0, len(r.Re))
+	for _, re := range r.Re {
+		leases = append(leases, &models.DHCPLease{
+			ID:           re.Map[".id"],
+			Address:      re.Map["address"],
+			MacAddress:   re.Map["mac-address"],
+			HostName:     re.Map["host-name"],
+			Server:       re.Map["server"],
+			Status:       re.Map["status"],
+			ExpiresAfter: re.Map["expires-after"],
+			Vendor:       oui.Lookup(re.Map["mac-address"]),
+			Dynamic:      re.Map["dynamic"] == "true",
+		})
+	}
+
+	return leases, nil
+}
+
+// GetBridgeHosts - Host yang dipelajari bridge router dengan vendor MAC
+func (ms *MikrotikService) GetBridgeHosts(routerID int) ([]*models.BridgeHost, error) {
+	// RouterOS v6 memakai "interface", v7 "on-interface"
+	r, err := ms.runRead(routerID, "/interface/bridge/host/print",
+		"=.proplist=.id,mac-address,bridge,on-interface,interface,dynamic,local")
+	if err != nil {
+		return nil, err
+	}
+
+	oui := GetOUIResolver()
+	hosts := make([]*models.BridgeHost, 0, len(r.Re))
+	for _, re := range r.Re {
+		onInterface := re.Map["on-interface"]
+		if onInterface == "" {
+			onInterface = re.Map["interface"]
+		}
+		hosts = append(hosts, &models.BridgeHost{
+			ID:          re.Map[".id"],
+			MacAddress:  re.Map["mac-address"],
+			Bridge:      re.Map["bridge"],
+			OnInterface: onInterface,
+			Vendor:      oui.Lookup(re.Map["mac-address"]),
+			Dynamic:     re.Map["dynamic"] == "true",
+			Local:       re.Map["local"] == "true",
+		})
+	}
+
+	return hosts, nil
+}
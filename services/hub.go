@@ -0,0 +1,118 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// HubMessage - Pesan yang didistribusikan hub ke subscriber WebSocket
+type HubMessage struct {
+	Topic     string      `json:"topic"`
+	RouterID  *int        `json:"router_id,omitempty"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// HubTopics - Topic yang dipublish layer (diumumkan ke client WebSocket saat handshake)
+var HubTopics = []string{"events", "alerts", "syslog", "jobs", "connections"}
+
+// Subscription - Langganan ke satu atau beberapa topic hub
+type Subscription struct {
+	topics   map[string]bool
+	routerID int          // 0 = semua router
+	allowed  map[int]bool // nil = tanpa batasan scope user
+	C        chan HubMessage
+}
+
+// Hub - Pub/sub in-memory untuk event real-time (syslog, status, dsb).
+// Publish tidak pernah blocking: subscriber yang lambat kehilangan pesan.
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+var (
+	hubInstance *Hub
+	hubOnce     sync.Once
+)
+
+// GetHub - Singleton hub yang dipakai bersama REST, WS dan background worker
+func GetHub() *Hub {
+	hubOnce.Do(func() {
+		hubInstance = &Hub{subs: make(map[*Subscription]struct{})}
+	})
+	return hubInstance
+}
+
+// Subscribe - Daftar ke topics (kosong = semua topic), opsional filter router
+func (h *Hub) Subscribe(topics []string, routerID int, buffer int) *Subscription {
+	sub := &Subscription{
+		topics:   make(map[string]bool),
+		routerID: routerID,
+		C:        make(chan HubMessage, buffer),
+	}
+	for _, topic := range topics {
+		sub.topics[topic] = true
+	}
+
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+// SubscribeScoped - Seperti Subscribe, tapi hanya menerima pesan dari router dalam scope
+// (pesan tanpa router_id tidak dikirim). routerIDs nil = tanpa batasan.
+func (h *Hub) SubscribeScoped(topics []string, routerID int, routerIDs []int, buffer int) *Subscription {
+	sub := h.Subscribe(topics, routerID, buffer)
+	if routerIDs != nil {
+		allowed := make(map[int]bool, len(routerIDs))
+		for _, id := range routerIDs {
+			allowed[id] = true
+		}
+		h.mu.Lock()
+		sub.allowed = allowed
+		h.mu.Unlock()
+	}
+	return sub
+}
+
+// Unsubscribe - Lepas subscription dan tutup channel-nya
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.C)
+	}
+}
+
+// Publish - Kirim pesan ke semua subscriber yang cocok
+func (h *Hub) Publish(topic string, routerID *int, data interface{}) {
+	msg := HubMessage{
+		Topic:     topic,
+		RouterID:  routerID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs {
+		if len(sub.topics) > 0 && !sub.topics[topic] {
+			continue
+		}
+		if sub.routerID != 0 && (routerID == nil || *routerID != sub.routerID) {
+			continue
+		}
+		if sub.allowed != nil && (routerID == nil || !sub.allowed[*routerID]) {
+			continue
+		}
+		select {
+		case sub.C <- msg:
+		default:
+		}
+	}
+}
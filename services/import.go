@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// ExistingNames - Nama entry yang sudah ada di menu RouterOS (mis. /queue/simple), untuk
+// validasi import sebelum job dimulai
+func (ms *MikrotikService) ExistingNames(routerID int, menu string) (map[string]bool, error) {
+	r, err := ms.runRead(routerID, menu+"/print", "=.proplist=name")
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(r.Re))
+	for _, re := range r.Re {
+		names[re.Map["name"]] = true
+	}
+	return names, nil
+}
+
+// AddPlanQueue - Tambah simple queue dengan limit dari plan (max-limit + burst)
+func (ms *MikrotikService) AddPlanQueue(routerID int, name, target string, plan *models.Plan, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/queue/simple/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) > 0 {
+		return nil, fmt.Errorf("queue %s already exists", name)
+	}
+
+	cmdPlan := newCommandPlan(routerID, "add_queue", dryRun)
+	cmdPlan.Checks = append(cmdPlan.Checks, fmt.Sprintf("queue name %s is unique", name))
+	add := []string{
+		"/queue/simple/add",
+		fmt.Sprintf("=name=%s", name),
+		fmt.Sprintf("=target=%s", target),
+	}
+	cmdPlan.Commands = append(cmdPlan.Commands, append(add, planQueueLimits(plan)...))
+
+	return cmdPlan, executePlan(conn, cmdPlan)
+}
+
+// EnsurePlanProfile - Buat / update /ppp/profile plan (rate-limit plan)
+func (ms *MikrotikService) EnsurePlanProfile(routerID int, plan *models.Plan, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	cmdPlan := newCommandPlan(routerID, "ensure_ppp_profile", dryRun)
+	if err := planProfileCommand(conn, cmdPlan, plan); err != nil {
+		return nil, err
+	}
+	return cmdPlan, executePlan(conn, cmdPlan)
+}
+
+// AddPPPSecret - Tambah PPP secret dengan profile tertentu. Password disamarkan di plan
+// yang dikembalikan (plan masuk audit log dan output job).
+func (ms *MikrotikService) AddPPPSecret(routerID int, name, password, profile string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/ppp/secret/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) > 0 {
+		return nil, fmt.Errorf("ppp secret %s already exists", name)
+	}
+
+	cmdPlan := newCommandPlan(routerID, "add_ppp_secret", dryRun)
+	cmdPlan.Checks = append(cmdPlan.Checks, fmt.Sprintf("ppp secret name %s is unique", name))
+	cmdPlan.Commands = append(cmdPlan.Commands, []string{
+		"/ppp/secret/add",
+		fmt.Sprintf("=name=%s", name),
+		fmt.Sprintf("=password=%s", password),
+		fmt.Sprintf("=profile=%s", profile),
+	})
+
+	err = executePlan(conn, cmdPlan)
+	for _, sentence := range cmdPlan.Commands {
+		for i, word := range sentence {
+			if strings.HasPrefix(word, "=password=") {
+				sentence[i] = "=password=***"
+			}
+		}
+	}
+	return cmdPlan, err
+}
+
+// ImportService - Provisioning hasil import CSV (queue / PPP secret) sebagai job, satu task per
+// baris. Baris dijalankan berurutan sesuai file; baris gagal tidak menghentikan baris lain.
+type ImportService struct {
+	ms       *MikrotikService
+	runner   *JobRunner
+	audit    *AuditLogger
+	webhooks *WebhookDispatcher
+}
+
+func NewImportService(ms *MikrotikService, runner *JobRunner, audit *AuditLogger, webhooks *WebhookDispatcher) *ImportService {
+	return &ImportService{ms: ms, runner: runner, audit: audit, webhooks: webhooks}
+}
+
+// Start - Mulai job import untuk baris yang sudah divalidasi; plans berisi semua plan yang dirujuk
+func (s *ImportService) Start(action string, router *models.Router, rows []*models.ImportRow, plans map[int]*models.Plan, createdBy string) (*models.Job, error) {
+	tasks := make([]*models.JobTask, len(rows))
+	byTask := make(map[*models.JobTask]*models.ImportRow, len(rows))
+	for i, row := range rows {
+		tasks[i] = &models.JobTask{RouterID: router.ID, RouterName: router.Name, Target: row.Name}
+		byTask[tasks[i]] = row
+	}
+
+	// Profile PPP cukup dipastikan sekali per plan (task berjalan berurutan)
+	profiles := make(map[int]bool)
+	fn := func(t *models.JobTask) (string, error) {
+		row := byTask[t]
+		plan := plans[row.PlanID]
+		if action == models.JobActionPPPSecretImport && !profiles[plan.ID] {
+			cmdPlan, err := s.ms.EnsurePlanProfile(router.ID, plan, false)
+			s.audit.LogPlan(createdBy, "import_ppp_profile", router.ID, plan.ProfileName(), cmdPlan, err)
+			if err != nil {
+				return "", err
+			}
+			profiles[plan.ID] = true
+		}
+		return s.provision(action, router.ID, row, plan, createdBy)
+	}
+
+	return s.runner.start(newJob(action, createdBy, tasks), 1, fn)
+}
+
+// Preview - Command plan dry run import, dibangun lewat method provisioning yang sama dengan
+// job (termasuk pengecekan nama di router) tanpa menjalankan apa pun
+func (s *ImportService) Preview(action string, routerID int, rows []*models.ImportRow, plans map[int]*models.Plan) ([]*models.CommandPlan, error) {
+	previews := make([]*models.CommandPlan, 0, len(rows))
+	profiles := make(map[int]bool)
+	for _, row := range rows {
+		plan := plans[row.PlanID]
+		var (
+			cmdPlan *models.CommandPlan
+			err     error
+		)
+		if action == models.JobActionQueueImport {
+			cmdPlan, err = s.ms.AddPlanQueue(routerID, row.Name, row.Target, plan, true)
+		} else {
+			if !profiles[plan.ID] {
+				profile, err := s.ms.EnsurePlanProfile(routerID, plan, true)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", row.Line, err)
+				}
+				previews = append(previews, profile)
+				profiles[plan.ID] = true
+			}
+			cmdPlan, err = s.ms.AddPPPSecret(routerID, row.Name, row.Password, plan.ProfileName(), true)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", row.Line, err)
+		}
+		previews = append(previews, cmdPlan)
+	}
+	return previews, nil
+}
+
+// provision - Provisioning satu baris, diaudit dan di-emit ke webhook jika berhasil
+func (s *ImportService) provision(action string, routerID int, row *models.ImportRow, plan *models.Plan, actor string) (string, error) {
+	var (
+		cmdPlan *models.CommandPlan
+		err     error
+	)
+	if action == models.JobActionQueueImport {
+		cmdPlan, err = s.ms.AddPlanQueue(routerID, row.Name, row.Target, plan, false)
+		s.audit.LogPlan(actor, "import_queue", routerID, row.Name, cmdPlan, err)
+		if err != nil {
+			return "", err
+		}
+		s.webhooks.Emit(models.WebhookQueueCreated, &routerID, map[string]interface{}{
+			"name":      row.Name,
+			"target":    row.Target,
+			"max_limit": plan.RateLimit,
+			"plan_id":   plan.ID,
+		})
+	} else {
+		cmdPlan, err = s.ms.AddPPPSecret(routerID, row.Name, row.Password, plan.ProfileName(), false)
+		s.audit.LogPlan(actor, "import_ppp_secret", routerID, row.Name, cmdPlan, err)
+		if err != nil {
+			return "", err
+		}
+		s.webhooks.Emit(models.WebhookPPPSecretCreated, &routerID, map[string]interface{}{
+			"name":    row.Name,
+			"plan_id": plan.ID,
+			"profile": plan.ProfileName(),
+		})
+	}
+	return string(mustJSON(cmdPlan)), nil
+}
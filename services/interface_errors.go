@@ -0,0 +1,194 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// getEthernetFCSErrors - Counter rx-fcs-error per interface ethernet (name -> counter).
+// Best effort: router / interface tanpa stats ethernet cukup tidak punya nilai FCS.
+func (ms *MikrotikService) getEthernetFCSErrors(routerID int) map[string]string {
+	r, err := ms.runRead(routerID, "/interface/ethernet/print", "=stats=", "=.proplist=name,rx-fcs-error")
+	if err != nil {
+		return nil
+	}
+
+	fcs := make(map[string]string, len(r.Re))
+	for _, re := range r.Re {
+		fcs[re.Map["name"]] = re.Map["rx-fcs-error"]
+	}
+	return fcs
+}
+
+// GetInterfaceErrors - Counter error/drop satu interface (FCS hanya untuk ethernet)
+func (ms *MikrotikService) GetInterfaceErrors(routerID int, name string) (*models.InterfaceErrors, error) {
+	r, err := ms.runRead(routerID,
+		"/interface/print",
+		fmt.Sprintf("?name=%s", name),
+		"=.proplist=name,rx-error,tx-error,rx-drop,tx-drop",
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("interface %s not found", name)
+	}
+
+	re := r.Re[0].Map
+	return &models.InterfaceErrors{
+		RxErrors:  parseCounter(re["rx-error"]),
+		TxErrors:  parseCounter(re["tx-error"]),
+		RxDrops:   parseCounter(re["rx-drop"]),
+		TxDrops:   parseCounter(re["tx-drop"]),
+		FCSErrors: parseCounter(ms.getEthernetFCSErrors(routerID)[name]),
+	}, nil
+}
+
+// InterfaceErrorConfig - Ambang alert kenaikan error interface
+type InterfaceErrorConfig struct {
+	Interval time.Duration // 0 = nonaktif
+	Rate     float64       // error (rx/tx error + FCS) per detik yang memicu alert
+}
+
+// InterfaceErrorMonitor - Poll counter error semua interface router online dan buka alert
+// "interface_errors" jika laju error naik melewati ambang (link kotor tidak terlihat dari bps).
+// Alert di-resolve lewat "interface_errors_cleared" saat semua interface router kembali bersih.
+type InterfaceErrorMonitor struct {
+	cfg      InterfaceErrorConfig
+	ms       *MikrotikService
+	recorder *EventRecorder
+
+	mu      sync.Mutex
+	last    map[int]map[string]uint64 // routerID -> interface -> total error terakhir
+	lastAt  map[int]time.Time
+	failing map[int]map[string]bool // routerID -> interface yang sedang melewati ambang
+}
+
+func NewInterfaceErrorMonitor(cfg InterfaceErrorConfig, ms *MikrotikService, recorder *EventRecorder) *InterfaceErrorMonitor {
+	return &InterfaceErrorMonitor{
+		cfg:      cfg,
+		ms:       ms,
+		recorder: recorder,
+		last:     make(map[int]map[string]uint64),
+		lastAt:   make(map[int]time.Time),
+		failing:  make(map[int]map[string]bool),
+	}
+}
+
+// Run - Loop monitor (blocking)
+func (m *InterfaceErrorMonitor) Run() {
+	if m.cfg.Interval <= 0 || m.cfg.Rate <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		for routerID, conn := range m.ms.GetAllConnections() {
+			if !conn.IsHealthy {
+				continue
+			}
+			m.check(routerID)
+		}
+	}
+}
+
+// check - Hitung laju error sejak poll sebelumnya; poll pertama hanya jadi baseline
+func (m *InterfaceErrorMonitor) check(routerID int) {
+	interfaces, err := m.ms.GetInterfaces(routerID)
+	if err != nil {
+		log.Printf("[IFACE-ERRORS] Router %d: %v", routerID, err)
+		return
+	}
+	now := time.Now()
+
+	m.mu.Lock()
+	prev, prevAt := m.last[routerID], m.lastAt[routerID]
+	current := make(map[string]uint64, len(interfaces))
+	for _, iface := range interfaces {
+		current[iface.Name] = uint64(parseCounter(iface.RxErrors) + parseCounter(iface.TxErrors) + parseCounter(iface.FCSErrors))
+	}
+	m.last[routerID], m.lastAt[routerID] = current, now
+	wasFailing := m.failing[routerID]
+	m.mu.Unlock()
+
+	if prev == nil {
+		return
+	}
+	seconds := now.Sub(prevAt).Seconds()
+	if seconds <= 0 {
+		return
+	}
+
+	failing := make(map[string]bool)
+	var raised []string
+	for _, iface := range interfaces {
+		before, ok := prev[iface.Name]
+		if !ok {
+			continue
+		}
+		delta := counterDelta(before, current[iface.Name])
+		rate := float64(delta) / seconds
+		if rate < m.cfg.Rate {
+			continue
+		}
+		failing[iface.Name] = true
+		if wasFailing[iface.Name] {
+			continue
+		}
+		raised = append(raised, iface.Name)
+
+		id := routerID
+		m.recorder.Record(&models.Event{
+			RouterID: &id,
+			Type:     "interface_errors",
+			Severity: "warning",
+			Message:  fmt.Sprintf("Interface %s error rate %.2f/s (threshold %.2f/s)", iface.Name, rate, m.cfg.Rate),
+			Data: mustJSON(map[string]interface{}{
+				"interface":  iface.Name,
+				"errors":     delta,
+				"rate":       rate,
+				"threshold":  m.cfg.Rate,
+				"rx_errors":  parseCounter(iface.RxErrors),
+				"tx_errors":  parseCounter(iface.TxErrors),
+				"rx_drops":   parseCounter(iface.RxDrops),
+				"tx_drops":   parseCounter(iface.TxDrops),
+				"fcs_errors": parseCounter(iface.FCSErrors),
+			}),
+		})
+	}
+
+	m.mu.Lock()
+	m.failing[routerID] = failing
+	m.mu.Unlock()
+
+	if len(raised) > 0 {
+		log.Printf("[IFACE-ERRORS] Router %d: error rate above threshold on %s", routerID, strings.Join(raised, ", "))
+	}
+	if len(wasFailing) > 0 && len(failing) == 0 {
+		cleared := make([]string, 0, len(wasFailing))
+		for name := range wasFailing {
+			cleared = append(cleared, name)
+		}
+		sort.Strings(cleared)
+
+		id := routerID
+		m.recorder.Record(&models.Event{
+			RouterID: &id,
+			Type:     "interface_errors_cleared",
+			Severity: "info",
+			Message:  fmt.Sprintf("Interface error rate back below threshold on %s", strings.Join(cleared, ", ")),
+			Data:     mustJSON(map[string]interface{}{"interfaces": cleared}),
+		})
+	}
+}
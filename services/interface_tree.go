@@ -0,0 +1,108 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// GetInterfaceTree - Hierarki interface: bridge→port, bonding→slave, parent→VLAN (bisa bersarang,
+// mis. bridge→bonding→ether). Interface yang tidak menempel ke master mana pun jadi root.
+func (ms *MikrotikService) GetInterfaceTree(routerID int) (*models.InterfaceTree, error) {
+	r, err := ms.runRead(routerID, "/interface/print", "=.proplist=name,type,running,disabled,comment")
+	if err != nil {
+		return nil, err
+	}
+	nodes := make(map[string]*models.InterfaceNode, len(r.Re))
+	for _, re := range r.Re {
+		nodes[re.Map["name"]] = &models.InterfaceNode{
+			Name:     re.Map["name"],
+			Type:     re.Map["type"],
+			Running:  re.Map["running"] == "true",
+			Disabled: re.Map["disabled"] == "true",
+			Comment:  re.Map["comment"],
+			Children: []*models.InterfaceNode{},
+		}
+	}
+
+	// masters: child -> master. Satu interface hanya bisa punya satu master; entri pertama menang.
+	masters := make(map[string]string)
+	link := func(child, master, relation string) {
+		c, ok := nodes[child]
+		if !ok || nodes[master] == nil || child == master || masters[child] != "" {
+			return
+		}
+		c.Relation = relation
+		masters[child] = master
+	}
+
+	if r, err = ms.runRead(routerID, "/interface/bonding/print", "=.proplist=name,slaves"); err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		for _, slave := range strings.Split(re.Map["slaves"], ",") {
+			link(strings.TrimSpace(slave), re.Map["name"], models.InterfaceRelationSlave)
+		}
+	}
+
+	if r, err = ms.runRead(routerID, "/interface/bridge/port/print", "=.proplist=interface,bridge"); err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		link(re.Map["interface"], re.Map["bridge"], models.InterfaceRelationPort)
+	}
+
+	if r, err = ms.runRead(routerID, "/interface/vlan/print", "=.proplist=name,vlan-id,interface"); err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		if n := nodes[re.Map["name"]]; n != nil {
+			n.VLANID, _ = strconv.Atoi(re.Map["vlan-id"])
+		}
+		link(re.Map["name"], re.Map["interface"], models.InterfaceRelationVLAN)
+	}
+
+	return BuildInterfaceTree(routerID, nodes, masters), nil
+}
+
+// BuildInterfaceTree - Susun node (by nama) jadi tree dari relasi child→master. Relasi yang
+// membentuk siklus diputus: node pada siklus dijadikan root.
+func BuildInterfaceTree(routerID int, nodes map[string]*models.InterfaceNode, masters map[string]string) *models.InterfaceTree {
+	for child := range masters {
+		seen := map[string]bool{child: true}
+		for m := masters[child]; m != ""; m = masters[m] {
+			if seen[m] {
+				delete(masters, child)
+				nodes[child].Relation = ""
+				break
+			}
+			seen[m] = true
+		}
+	}
+
+	tree := &models.InterfaceTree{RouterID: routerID, Roots: []*models.InterfaceNode{}}
+	for name, n := range nodes {
+		if master, ok := masters[name]; ok {
+			nodes[master].Children = append(nodes[master].Children, n)
+		} else {
+			tree.Roots = append(tree.Roots, n)
+		}
+	}
+
+	var sortNodes func([]*models.InterfaceNode)
+	sortNodes = func(list []*models.InterfaceNode) {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].VLANID != list[j].VLANID {
+				return list[i].VLANID < list[j].VLANID
+			}
+			return list[i].Name < list[j].Name
+		})
+		for _, n := range list {
+			sortNodes(n.Children)
+		}
+	}
+	sortNodes(tree.Roots)
+	return tree
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// IPConflictOptions - Pola yang sengaja dikecualikan dari audit konflik IP
+type IPConflictOptions struct {
+	Ignore         []*net.IPNet // alamat di dalam prefix ini tidak diperiksa (mis. VRRP, anycast)
+	IncludeDynamic bool         // sertakan alamat dinamis (DHCP client, PPP)
+}
+
+// fleetEntry - Alamat yang sudah di-parse untuk perbandingan
+type fleetEntry struct {
+	addr    *models.FleetAddress
+	ip      net.IP
+	network *net.IPNet
+}
+
+// DetectIPConflicts - Kumpulkan /ip/address semua router terhubung lalu cari host address
+// duplikat dan subnet yang tumpang tindih antar router. Dua router di subnet yang sama
+// dengan prefix sama (link point-to-point / LAN bersama) dianggap disengaja.
+func (ms *MikrotikService) DetectIPConflicts(opts IPConflictOptions) *models.IPConflictReport {
+	report := &models.IPConflictReport{
+		Conflicts: []*models.IPConflict{},
+		Skipped:   make(map[int]string),
+		CheckedAt: time.Now(),
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		entries []*fleetEntry
+	)
+
+	for routerID, conn := range ms.GetAllConnections() {
+		if !conn.IsHealthy {
+			report.Skipped[routerID] = "router connection unhealthy"
+			continue
+		}
+
+		wg.Add(1)
+		go func(routerID int, name string) {
+			defer Recover("ip-conflict")
+			defer wg.Done()
+
+			addresses, err := ms.GetAddresses(routerID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.Skipped[routerID] = err.Error()
+				return
+			}
+
+			report.RoutersChecked++
+			for _, a := range addresses {
+				if e := newFleetEntry(routerID, name, a, opts); e != nil {
+					entries = append(entries, e)
+				}
+			}
+		}(routerID, conn.Router.Name)
+	}
+	wg.Wait()
+
+	// Urutan stabil supaya hasil audit bisa dibandingkan antar pemanggilan
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].addr.RouterID != entries[j].addr.RouterID {
+			return entries[i].addr.RouterID < entries[j].addr.RouterID
+		}
+		return entries[i].addr.Address < entries[j].addr.Address
+	})
+
+	report.Addresses = len(entries)
+	report.Conflicts = append(report.Conflicts, duplicateAddresses(entries)...)
+	report.Conflicts = append(report.Conflicts, overlappingSubnets(entries)...)
+
+	return report
+}
+
+// newFleetEntry - Parse address router; nil jika tidak ikut diaudit
+func newFleetEntry(routerID int, routerName string, a *models.Address, opts IPConflictOptions) *fleetEntry {
+	if a.Disabled || (a.Dynamic && !opts.IncludeDynamic) {
+		return nil
+	}
+
+	ip, network, err := net.ParseCIDR(a.Address)
+	if err != nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+		return nil
+	}
+	for _, ignore := range opts.Ignore {
+		if ignore.Contains(ip) {
+			return nil
+		}
+	}
+
+	return &fleetEntry{
+		addr: &models.FleetAddress{
+			RouterID:   routerID,
+			RouterName: routerName,
+			Address:    a.Address,
+			Interface:  a.Interface,
+		},
+		ip:      ip,
+		network: network,
+	}
+}
+
+// duplicateAddresses - Host address yang sama dipasang di lebih dari satu router
+func duplicateAddresses(entries []*fleetEntry) []*models.IPConflict {
+	byIP := make(map[string][]*fleetEntry)
+	var order []string
+	for _, e := range entries {
+		key := e.ip.String()
+		if _, ok := byIP[key]; !ok {
+			order = append(order, key)
+		}
+		byIP[key] = append(byIP[key], e)
+	}
+
+	var conflicts []*models.IPConflict
+	for _, key := range order {
+		group := byIP[key]
+		if !multipleRouters(group) {
+			continue
+		}
+
+		conflict := &models.IPConflict{Type: models.ConflictDuplicateAddress, Subject: key}
+		for _, e := range group {
+			conflict.Entries = append(conflict.Entries, e.addr)
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// overlappingSubnets - Subnet router berbeda yang saling tumpang tindih dengan prefix berbeda
+// (indikasi netmask salah atau alokasi ganda)
+func overlappingSubnets(entries []*fleetEntry) []*models.IPConflict {
+	var conflicts []*models.IPConflict
+	seen := make(map[string]bool)
+
+	for i, a := range entries {
+		for _, b := range entries[i+1:] {
+			if a.addr.RouterID == b.addr.RouterID {
+				continue
+			}
+			aOnes, _ := a.network.Mask.Size()
+			bOnes, _ := b.network.Mask.Size()
+			if aOnes == bOnes || !(a.network.Contains(b.network.IP) || b.network.Contains(a.network.IP)) {
+				continue
+			}
+
+			subject := fmt.Sprintf("%s <> %s", a.network, b.network)
+			if aOnes > bOnes {
+				subject = fmt.Sprintf("%s <> %s", b.network, a.network)
+			}
+			key := subject + fmt.Sprintf("|%d|%d", a.addr.RouterID, b.addr.RouterID)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			conflicts = append(conflicts, &models.IPConflict{
+				Type:    models.ConflictOverlappingSubnet,
+				Subject: subject,
+				Entries: []*models.FleetAddress{a.addr, b.addr},
+			})
+		}
+	}
+	return conflicts
+}
+
+func multipleRouters(group []*fleetEntry) bool {
+	for _, e := range group[1:] {
+		if e.addr.RouterID != group[0].addr.RouterID {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCIDRList - Parse daftar CIDR comma-separated (IP tanpa prefix dianggap host)
+func ParseCIDRList(v string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			if ip := net.ParseIP(item); ip != nil && ip.To4() != nil {
+				item += "/32"
+			} else {
+				item += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %s", item)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+
+	"Mikrotik-Layer/models"
+)
+
+// ListRoutes - Entry /ip/route sesuai filter. Routing table difilter di sini karena v6 memakai
+// routing-mark (route di table main tidak punya atribut tsb).
+func (ms *MikrotikService) ListRoutes(routerID int, filter models.RouteFilter) ([]*models.RouteEntry, error) {
+	args := []string{"/ip/route/print"}
+	if filter.DstAddress != "" {
+		args = append(args, fmt.Sprintf("?dst-address=%s", filter.DstAddress))
+	}
+	if filter.Dynamic != nil {
+		args = append(args, fmt.Sprintf("?dynamic=%t", *filter.Dynamic))
+	}
+	if filter.Active != nil {
+		args = append(args, fmt.Sprintf("?active=%t", *filter.Active))
+	}
+	r, err := ms.runRead(routerID, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := []*models.RouteEntry{}
+	for _, re := range r.Re {
+		route := parseRouteEntry(re.Map)
+		if filter.RoutingTable != "" && routeTable(route) != filter.RoutingTable {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// AddRoute - Tambah route static. Idempotent: route dengan dst-address, gateway dan routing table
+// yang sama tidak ditambah ulang (distance / comment yang berbeda tidak diubah).
+func (ms *MikrotikService) AddRoute(routerID int, req *models.RouteRequest, dryRun bool) (*models.CommandPlan, error) {
+	v7, err := ms.isRouterOSv7(routerID)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/ip/route/print", fmt.Sprintf("?dst-address=%s", req.DstAddress),
+		"=.proplist=.id,dst-address,gateway,routing-table,routing-mark,dynamic")
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "add_route", dryRun)
+	for _, re := range r.Re {
+		route := parseRouteEntry(re.Map)
+		if !route.Dynamic && route.Gateway == req.Gateway && routeTable(route) == req.Table() {
+			plan.Checks = append(plan.Checks, fmt.Sprintf("route %s via %s in table %s already exists (%s)",
+				req.DstAddress, req.Gateway, req.Table(), route.ID))
+			return plan, nil
+		}
+	}
+
+	add := []string{
+		"/ip/route/add",
+		fmt.Sprintf("=dst-address=%s", req.DstAddress),
+		fmt.Sprintf("=gateway=%s", req.Gateway),
+	}
+	if req.Distance != nil {
+		add = append(add, fmt.Sprintf("=distance=%d", *req.Distance))
+	}
+	if table := req.Table(); table != "main" {
+		if v7 {
+			add = append(add, fmt.Sprintf("=routing-table=%s", table))
+		} else {
+			add = append(add, fmt.Sprintf("=routing-mark=%s", table))
+		}
+	}
+	if req.Comment != nil && *req.Comment != "" {
+		add = append(add, fmt.Sprintf("=comment=%s", *req.Comment))
+	}
+	if req.Disabled != nil && *req.Disabled {
+		add = append(add, "=disabled=yes")
+	}
+	plan.Commands = append(plan.Commands, add)
+
+	return plan, executePlan(conn, plan)
+}
+
+// RemoveRoute - Hapus route static by .id (route dynamic ditolak)
+func (ms *MikrotikService) RemoveRoute(routerID int, id string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	route, err := staticRouteByID(conn, id)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "remove_route", dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("route %s exists (%s via %s)", id, route.DstAddress, route.Gateway))
+	plan.Commands = append(plan.Commands, []string{
+		"/ip/route/remove",
+		fmt.Sprintf("=.id=%s", id),
+	})
+
+	return plan, executePlan(conn, plan)
+}
+
+// SetRouteDisabled - Enable/disable route static. Idempotent: route yang sudah di status tujuan
+// tidak di-set ulang.
+func (ms *MikrotikService) SetRouteDisabled(routerID int, id string, disabled, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	route, err := staticRouteByID(conn, id)
+	if err != nil {
+		return nil, err
+	}
+
+	action, state, value := "enable_route", "enabled", "no"
+	if disabled {
+		action, state, value = "disable_route", "disabled", "yes"
+	}
+	plan := newCommandPlan(routerID, action, dryRun)
+
+	if route.Disabled == disabled {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("route %s already %s", id, state))
+	} else {
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/route/set",
+			fmt.Sprintf("=.id=%s", id),
+			fmt.Sprintf("=disabled=%s", value),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// staticRouteByID - Route by .id; route dynamic (connected, DHCP, protokol routing) tidak bisa
+// diubah lewat API. Caller wajib sudah memegang conn.mu.
+func staticRouteByID(conn *MikrotikConnection, id string) (*models.RouteEntry, error) {
+	r, err := conn.Run("/ip/route/print", fmt.Sprintf("?.id=%s", id),
+		"=.proplist=.id,dst-address,gateway,disabled,dynamic")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("route %s not found", id)
+	}
+	route := parseRouteEntry(r.Re[0].Map)
+	if route.Dynamic {
+		return nil, fmt.Errorf("route %s is dynamic and cannot be changed", id)
+	}
+	return route, nil
+}
+
+func parseRouteEntry(m map[string]string) *models.RouteEntry {
+	distance, _ := strconv.Atoi(m["distance"])
+	table := m["routing-table"]
+	if table == "" {
+		table = m["routing-mark"]
+	}
+	return &models.RouteEntry{
+		ID:           m[".id"],
+		DstAddress:   m["dst-address"],
+		Gateway:      m["gateway"],
+		Distance:     distance,
+		CheckGateway: m["check-gateway"],
+		RoutingTable: table,
+		PrefSrc:      m["pref-src"],
+		ImmediateGW:  m["immediate-gw"],
+		Comment:      m["comment"],
+		Active:       m["active"] == "true",
+		Disabled:     m["disabled"] == "true",
+		Dynamic:      m["dynamic"] == "true",
+		Static:       m["static"] == "true",
+	}
+}
+
+// routeTable - Routing table route, "main" untuk route v6 tanpa routing-mark
+func routeTable(route *models.RouteEntry) string {
+	if route.RoutingTable == "" {
+		return "main"
+	}
+	return route.RoutingTable
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// GetPools - Pool alamat router (/ip/pool) beserta jumlah alamat terpakai (/ip/pool/used)
+func (ms *MikrotikService) GetPools(routerID int) ([]*models.IPPool, error) {
+	r, err := ms.runRead(routerID, "/ip/pool/print", "=.proplist=name,ranges")
+	if err != nil {
+		return nil, err
+	}
+	used, err := ms.runRead(routerID, "/ip/pool/used/print", "=.proplist=pool")
+	if err != nil {
+		return nil, err
+	}
+
+	usedByPool := make(map[string]int)
+	for _, re := range used.Re {
+		usedByPool[re.Map["pool"]]++
+	}
+
+	pools := []*models.IPPool{}
+	for _, re := range r.Re {
+		pool := &models.IPPool{
+			Name:   re.Map["name"],
+			Ranges: []string{},
+			Used:   usedByPool[re.Map["name"]],
+		}
+		for _, item := range strings.Split(re.Map["ranges"], ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			pool.Ranges = append(pool.Ranges, item)
+			if start, end, err := parsePoolRange(item); err == nil {
+				pool.Size = saturatingAdd(pool.Size, addrSpan(start, end))
+			}
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+// parsePoolRange - Range pool RouterOS: "10.0.0.2-10.0.0.254", "10.0.1.0/24" atau satu alamat
+func parsePoolRange(v string) (netip.Addr, netip.Addr, error) {
+	if from, to, ok := strings.Cut(v, "-"); ok {
+		start, err := netip.ParseAddr(strings.TrimSpace(from))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(to))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		if end.Less(start) || start.Is4() != end.Is4() {
+			return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid pool range %s", v)
+		}
+		return start, end, nil
+	}
+	if strings.Contains(v, "/") {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, err
+		}
+		prefix = prefix.Masked()
+		return prefix.Addr(), lastAddr(prefix), nil
+	}
+	addr, err := netip.ParseAddr(v)
+	return addr, addr, err
+}
+
+// lastAddr - Alamat terakhir (broadcast untuk IPv4) prefix
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Masked().Addr().As16()
+	hostBits := p.Addr().BitLen() - p.Bits()
+	for i := 15; i >= 0 && hostBits > 0; i-- {
+		n := hostBits
+		if n > 8 {
+			n = 8
+		}
+		b[i] |= byte(1<<n - 1)
+		hostBits -= n
+	}
+	addr := netip.AddrFrom16(b)
+	if p.Addr().Is4() {
+		addr = addr.Unmap()
+	}
+	return addr
+}
+
+// addrSpan - Jumlah alamat start..end (maksimal MaxInt64 untuk range IPv6 besar)
+func addrSpan(start, end netip.Addr) int64 {
+	s, e := start.As16(), end.As16()
+	n := new(big.Int).Sub(new(big.Int).SetBytes(e[:]), new(big.Int).SetBytes(s[:]))
+	n.Add(n, big.NewInt(1))
+	if !n.IsInt64() {
+		return math.MaxInt64
+	}
+	return n.Int64()
+}
+
+func saturatingAdd(a, b int64) int64 {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
+
+// subnetHosts - Jumlah host yang bisa dipakai: IPv4 tanpa network & broadcast kecuali /31 dan /32
+func subnetHosts(p netip.Prefix) int64 {
+	hostBits := p.Addr().BitLen() - p.Bits()
+	if hostBits >= 63 {
+		return math.MaxInt64
+	}
+	hosts := int64(1) << hostBits
+	if p.Addr().Is4() && hostBits >= 2 {
+		hosts -= 2
+	}
+	return hosts
+}
+
+// ipamSubnet - Subnet rencana IP yang sudah di-parse
+type ipamSubnet struct {
+	usage  *models.SubnetUsage
+	prefix netip.Prefix
+}
+
+// IPAMReport - Cocokkan subnet rencana IP dengan /ip/address dan /ip/pool semua router terhubung.
+// Alamat dan pool dihitung ke subnet paling spesifik yang memuatnya; yang tidak masuk subnet
+// mana pun dilaporkan sebagai pemakaian tidak terdokumentasi.
+func (ms *MikrotikService) IPAMReport(subnets []*models.Subnet) *models.IPAMReport {
+	report := &models.IPAMReport{
+		Subnets:      []*models.SubnetUsage{},
+		Undocumented: []*models.UndocumentedUsage{},
+		Skipped:      make(map[int]string),
+		CheckedAt:    time.Now(),
+	}
+
+	var parsed []*ipamSubnet
+	for _, s := range subnets {
+		usage := &models.SubnetUsage{Subnet: s, Addresses: []*models.FleetAddress{}, Pools: []*models.IPAMPoolUsage{}}
+		report.Subnets = append(report.Subnets, usage)
+		if prefix, err := netip.ParsePrefix(s.Prefix); err == nil {
+			usage.Size = subnetHosts(prefix)
+			parsed = append(parsed, &ipamSubnet{usage: usage, prefix: prefix.Masked()})
+		}
+	}
+	// Prefix terpanjang dulu: pencarian pertama yang cocok = subnet paling spesifik
+	sort.SliceStable(parsed, func(i, j int) bool { return parsed[i].prefix.Bits() > parsed[j].prefix.Bits() })
+	find := func(addr netip.Addr) *ipamSubnet {
+		for _, s := range parsed {
+			if s.prefix.Contains(addr) {
+				return s
+			}
+		}
+		return nil
+	}
+
+	type routerData struct {
+		id        int
+		name      string
+		addresses []*models.Address
+		pools     []*models.IPPool
+	}
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []*routerData
+	)
+	for routerID, conn := range ms.GetAllConnections() {
+		if !conn.IsHealthy {
+			report.Skipped[routerID] = "router connection unhealthy"
+			continue
+		}
+
+		wg.Add(1)
+		go func(routerID int, name string) {
+			defer Recover("ipam")
+			defer wg.Done()
+
+			data := &routerData{id: routerID, name: name}
+			addresses, err := ms.GetAddresses(routerID)
+			if err == nil {
+				data.addresses = addresses
+				data.pools, err = ms.GetPools(routerID)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.Skipped[routerID] = err.Error()
+				return
+			}
+			results = append(results, data)
+		}(routerID, conn.Router.Name)
+	}
+	wg.Wait()
+
+	// Urutan stabil supaya laporan bisa dibandingkan antar pemanggilan
+	sort.Slice(results, func(i, j int) bool { return results[i].id < results[j].id })
+	report.RoutersChecked = len(results)
+
+	for _, data := range results {
+		for _, a := range data.addresses {
+			if a.Disabled || a.Dynamic {
+				continue
+			}
+			prefix, err := netip.ParsePrefix(a.Address)
+			if err != nil || prefix.Addr().IsLoopback() || prefix.Addr().IsLinkLocalUnicast() {
+				continue
+			}
+
+			entry := &models.FleetAddress{RouterID: data.id, RouterName: data.name, Address: a.Address, Interface: a.Interface}
+			if s := find(prefix.Addr()); s != nil {
+				s.usage.Addresses = append(s.usage.Addresses, entry)
+				s.usage.Used++
+				continue
+			}
+			report.Undocumented = append(report.Undocumented, &models.UndocumentedUsage{
+				Type:       models.UndocumentedAddress,
+				RouterID:   data.id,
+				RouterName: data.name,
+				Subject:    a.Address,
+				Interface:  a.Interface,
+			})
+		}
+
+		for _, pool := range data.pools {
+			var s *ipamSubnet
+			if len(pool.Ranges) > 0 {
+				if start, _, err := parsePoolRange(pool.Ranges[0]); err == nil {
+					s = find(start)
+				}
+			}
+			if s != nil {
+				s.usage.Pools = append(s.usage.Pools, &models.IPAMPoolUsage{RouterID: data.id, RouterName: data.name, IPPool: pool})
+				s.usage.Used += int64(pool.Used)
+				continue
+			}
+			report.Undocumented = append(report.Undocumented, &models.UndocumentedUsage{
+				Type:       models.UndocumentedPool,
+				RouterID:   data.id,
+				RouterName: data.name,
+				Subject:    pool.Name,
+				Ranges:     strings.Join(pool.Ranges, ","),
+			})
+		}
+	}
+
+	for _, s := range report.Subnets {
+		if s.Size > 0 {
+			s.UtilizationPct = math.Round(float64(s.Used)/float64(s.Size)*10000) / 100
+		}
+	}
+	return report
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// jobOutputLimit - Output task lebih dari ini dipotong supaya baris job_tasks tetap kecil
+const jobOutputLimit = 64 << 10
+
+// RunCommand - Eksekusi satu sentence RouterOS; output berupa JSON array item reply.
+// Sentence dijalankan langsung (bukan lewat executePlan) karena reply-nya adalah output task;
+// dry run memakai CommandJobPlan.
+func (ms *MikrotikService) RunCommand(routerID int, sentence []string) (string, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return "", err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.RunArgs(sentence)
+	if err != nil {
+		return "", err
+	}
+
+	items := make([]map[string]string, 0, len(r.Re))
+	for _, re := range r.Re {
+		items = append(items, re.Map)
+	}
+	// Command seperti /add hanya mengembalikan !done dengan ret
+	if len(items) == 0 && r.Done != nil && len(r.Done.Map) > 0 {
+		items = append(items, r.Done.Map)
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// CommandJobPlan - Plan dry run job command: sentence yang akan dijalankan apa adanya
+func (ms *MikrotikService) CommandJobPlan(routerID int, sentence []string) (*models.CommandPlan, error) {
+	if _, err := ms.GetConnection(routerID); err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, models.JobActionCommand, true)
+	plan.Commands = append(plan.Commands, sentence)
+	return plan, nil
+}
+
+// UpgradePackages - Cek update RouterOS di channel terpasang lalu install jika ada versi baru.
+// Router reboot setelah install, sehingga koneksi API ikut terputus. Dry run tetap menjalankan
+// check-for-updates (tidak mengubah konfigurasi) supaya plan hanya berisi install jika perlu.
+func (ms *MikrotikService) UpgradePackages(routerID int, dryRun bool) (*models.CommandPlan, string, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/system/package/update/check-for-updates")
+	if err != nil {
+		return nil, "", err
+	}
+	if len(r.Re) == 0 {
+		return nil, "", fmt.Errorf("check-for-updates returned no status")
+	}
+	// Reply berisi progress; status akhir ada di item terakhir
+	status := r.Re[len(r.Re)-1].Map
+	installed, latest := status["installed-version"], status["latest-version"]
+	if latest == "" {
+		return nil, "", fmt.Errorf("update check failed: %s", status["status"])
+	}
+
+	plan := newCommandPlan(routerID, models.JobActionUpgrade, dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("installed %s, latest %s", installed, latest))
+	if latest == installed {
+		return plan, fmt.Sprintf("already up to date (%s)", installed), nil
+	}
+	plan.Commands = append(plan.Commands, []string{"/system/package/update/install"})
+
+	if err := executePlan(conn, plan); err != nil {
+		return plan, "", err
+	}
+	return plan, fmt.Sprintf("upgrading %s -> %s, router is rebooting", installed, latest), nil
+}
+
+// jobTaskFunc - Eksekusi satu task job, mengembalikan output untuk task
+type jobTaskFunc func(t *models.JobTask) (string, error)
+
+// JobRunner - Jalankan job ke banyak router secara paralel (dibatasi concurrency), simpan status
+// tiap task ke DB dan publish perubahannya ke hub (topic "jobs")
+type JobRunner struct {
+	ms          *MikrotikService
+	repo        *repository.JobRepository
+	hub         *Hub
+	concurrency int
+
+	mu      sync.Mutex
+	cancels map[int64]context.CancelFunc // job yang sedang berjalan
+}
+
+func NewJobRunner(ms *MikrotikService, repo *repository.JobRepository, concurrency int) *JobRunner {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &JobRunner{
+		ms:          ms,
+		repo:        repo,
+		hub:         GetHub(),
+		concurrency: concurrency,
+		cancels:     make(map[int64]context.CancelFunc),
+	}
+}
+
+// Start - Buat job untuk routers lalu jalankan di background; job yang dikembalikan berstatus running
+func (jr *JobRunner) Start(req *models.JobRequest, routers []*models.Router, createdBy string) (*models.Job, error) {
+	var fn jobTaskFunc
+	switch req.Action {
+	case models.JobActionCommand:
+		if len(req.Command) == 0 {
+			return nil, fmt.Errorf("command is required for action %s", req.Action)
+		}
+		sentence := req.Command
+		fn = func(t *models.JobTask) (string, error) { return jr.ms.RunCommand(t.RouterID, sentence) }
+	case models.JobActionUpgrade:
+		fn = func(t *models.JobTask) (string, error) {
+			_, output, err := jr.ms.UpgradePackages(t.RouterID, false)
+			return output, err
+		}
+	case models.JobActionRouteAdd:
+		if req.Route == nil {
+			return nil, fmt.Errorf("route is required for action %s", req.Action)
+		}
+		route := req.Route
+		fn = func(t *models.JobTask) (string, error) {
+			plan, err := jr.ms.AddRoute(t.RouterID, route, false)
+			if err != nil {
+				return "", err
+			}
+			return string(mustJSON(plan)), nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown job action %s", req.Action)
+	}
+
+	tasks := make([]*models.JobTask, len(routers))
+	for i, router := range routers {
+		tasks[i] = &models.JobTask{RouterID: router.ID, RouterName: router.Name}
+	}
+	job := newJob(req.Action, createdBy, tasks)
+	if req.Action == models.JobActionCommand {
+		job.Command = req.Command
+	}
+
+	concurrency := jr.concurrency
+	if req.Concurrency > 0 {
+		concurrency = req.Concurrency
+	}
+	return jr.start(job, concurrency, fn)
+}
+
+// Preview - Dry run job: command plan per router tanpa membuat job. Error satu router
+// dicatat di preview router itu, router lain tetap diproses.
+func (jr *JobRunner) Preview(req *models.JobRequest, routers []*models.Router) ([]*models.JobPreview, error) {
+	previews := make([]*models.JobPreview, 0, len(routers))
+	for _, router := range routers {
+		preview := &models.JobPreview{RouterID: router.ID, RouterName: router.Name}
+		var err error
+		switch req.Action {
+		case models.JobActionCommand:
+			preview.Plan, err = jr.ms.CommandJobPlan(router.ID, req.Command)
+		case models.JobActionUpgrade:
+			preview.Plan, _, err = jr.ms.UpgradePackages(router.ID, true)
+		case models.JobActionRouteAdd:
+			preview.Plan, err = jr.ms.AddRoute(router.ID, req.Route, true)
+		default:
+			return nil, fmt.Errorf("unknown job action %s", req.Action)
+		}
+		if err != nil {
+			preview.Error = err.Error()
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
+
+// newJob - Job running dengan semua task pending
+func newJob(action, createdBy string, tasks []*models.JobTask) *models.Job {
+	job := &models.Job{
+		Action:    action,
+		Status:    models.JobRunning,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		Tasks:     tasks,
+	}
+	for _, t := range tasks {
+		t.Status = models.TaskPending
+	}
+	job.Summary.Add(models.TaskPending, len(tasks))
+	return job
+}
+
+// start - Simpan job beserta task-nya lalu jalankan di background
+func (jr *JobRunner) start(job *models.Job, concurrency int, fn jobTaskFunc) (*models.Job, error) {
+	if err := jr.repo.Create(job); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	jr.mu.Lock()
+	jr.cancels[job.ID] = cancel
+	jr.mu.Unlock()
+
+	log.Printf("[JOB] Job %d (%s) started by %s with %d tasks", job.ID, job.Action, job.CreatedBy, len(job.Tasks))
+
+	// Snapshot untuk response; goroutine job memegang task-nya sendiri
+	snapshot := *job
+	snapshot.Tasks = make([]*models.JobTask, len(job.Tasks))
+	for i, t := range job.Tasks {
+		copied := *t
+		snapshot.Tasks[i] = &copied
+	}
+
+	go jr.run(ctx, job, concurrency, fn)
+	return &snapshot, nil
+}
+
+// Cancel - Hentikan job: task pending dilewati (cancelled), task yang sedang berjalan
+// diselesaikan karena command RouterOS tidak bisa diinterupsi. False jika job tidak berjalan.
+func (jr *JobRunner) Cancel(id int64) bool {
+	jr.mu.Lock()
+	cancel, ok := jr.cancels[id]
+	jr.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+func (jr *JobRunner) run(ctx context.Context, job *models.Job, concurrency int, fn jobTaskFunc) {
+	tasks := make(chan *models.JobTask)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(job.Tasks); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				jr.runTask(t, fn)
+			}
+		}()
+	}
+
+dispatch:
+	for _, t := range job.Tasks {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case tasks <- t:
+		}
+	}
+	close(tasks)
+	wg.Wait()
+
+	cancelled := ctx.Err() != nil
+	jr.mu.Lock()
+	jr.cancels[job.ID]()
+	delete(jr.cancels, job.ID)
+	jr.mu.Unlock()
+
+	var summary models.JobSummary
+	for _, t := range job.Tasks {
+		if t.Status == models.TaskPending {
+			jr.finishTask(t, models.TaskCancelled, "", fmt.Errorf("job cancelled"))
+		}
+		summary.Add(t.Status, 1)
+	}
+
+	status := models.JobCompleted
+	if cancelled {
+		status = models.JobCancelled
+	} else if summary.Failed > 0 {
+		status = models.JobFailed
+	}
+	now := time.Now()
+	if err := jr.repo.Finish(job.ID, status, now); err != nil {
+		log.Printf("[JOB] Error finishing job %d: %v", job.ID, err)
+	}
+
+	log.Printf("[JOB] Job %d (%s) %s: %d ok, %d failed, %d cancelled",
+		job.ID, job.Action, status, summary.OK, summary.Failed, summary.Cancelled)
+	jr.hub.Publish("jobs", nil, map[string]interface{}{
+		"job_id":      job.ID,
+		"action":      job.Action,
+		"status":      status,
+		"summary":     summary,
+		"finished_at": now,
+	})
+}
+
+func (jr *JobRunner) runTask(t *models.JobTask, fn jobTaskFunc) {
+	now := time.Now()
+	t.Status, t.StartedAt = models.TaskRunning, &now
+	if err := jr.repo.StartTask(t.ID, now); err != nil {
+		log.Printf("[JOB] Error updating task %d: %v", t.ID, err)
+	}
+	jr.publishTask(t)
+
+	output, err := callTask(t, fn)
+	if err != nil {
+		jr.finishTask(t, models.TaskFailed, output, err)
+		return
+	}
+	jr.finishTask(t, models.TaskOK, output, nil)
+}
+
+// callTask - Panic di satu task dicatat sebagai task gagal, worker dan task lain tetap jalan
+func callTask(t *models.JobTask, fn jobTaskFunc) (output string, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			ReportPanic("job-task", v, debug.Stack(), map[string]string{"router_id": strconv.Itoa(t.RouterID)})
+			err = fmt.Errorf("panic: %v", v)
+		}
+	}()
+	return fn(t)
+}
+
+func (jr *JobRunner) finishTask(t *models.JobTask, status, output string, err error) {
+	now := time.Now()
+	t.Status, t.FinishedAt = status, &now
+	if output != "" {
+		if len(output) > jobOutputLimit {
+			output = output[:jobOutputLimit]
+		}
+		t.Output = &output
+	}
+	if err != nil {
+		msg := err.Error()
+		if len(msg) > 500 {
+			msg = msg[:500]
+		}
+		t.Error = &msg
+	}
+
+	if err := jr.repo.FinishTask(t); err != nil {
+		log.Printf("[JOB] Error updating task %d: %v", t.ID, err)
+	}
+	jr.publishTask(t)
+}
+
+// publishTask - Kirim salinan task (task terus berubah selama job berjalan)
+func (jr *JobRunner) publishTask(t *models.JobTask) {
+	copied := *t
+	jr.hub.Publish("jobs", &copied.RouterID, &copied)
+}
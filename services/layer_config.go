@@ -0,0 +1,218 @@
+package services
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// configKDFIterations - Iterasi PBKDF2-SHA256 untuk passphrase bundle
+const configKDFIterations = 600000
+
+// routerSecrets - Kredensial router yang dienkripsi di bundle
+type routerSecrets struct {
+	Password       string  `json:"password"`
+	JumpPassword   *string `json:"jump_password,omitempty"`
+	JumpPrivateKey *string `json:"jump_private_key,omitempty"`
+}
+
+// ExportLayerConfig - Susun bundle konfigurasi layer. passphrase kosong = tanpa kredensial router;
+// selain itu kredensial tiap router dienkripsi AES-256-GCM dengan kunci turunan passphrase.
+func ExportLayerConfig(routers *repository.RouterRepository, plans *repository.PlanRepository, channels *repository.NotificationChannelRepository, passphrase string) (*models.LayerConfigBundle, error) {
+	bundle := &models.LayerConfigBundle{
+		Version:       models.LayerConfigVersion,
+		ExportedAt:    time.Now(),
+		Secrets:       models.ConfigSecretsNone,
+		Routers:       []*models.ConfigRouter{},
+		Plans:         []*models.PlanCreateRequest{},
+		AlertChannels: []*models.NotificationChannelRequest{},
+	}
+
+	var aead cipher.AEAD
+	if passphrase != "" {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		bundle.Secrets = models.ConfigSecretsEncrypted
+		bundle.Encryption = &models.ConfigEncryption{
+			KDF:        "pbkdf2-sha256",
+			Iterations: configKDFIterations,
+			Salt:       base64.StdEncoding.EncodeToString(salt),
+			Cipher:     "aes-256-gcm",
+		}
+		var err error
+		if aead, err = configCipher(bundle.Encryption, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	all, err := routers.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, router := range all {
+		rt := &models.ConfigRouter{
+			RouterCreateRequest: models.RouterCreateRequest{
+				Name:          router.Name,
+				Hostname:      router.Hostname,
+				Username:      router.Username,
+				Keepalive:     &router.Keepalive,
+				Timeout:       &router.Timeout,
+				Port:          &router.Port,
+				Location:      router.Location,
+				Description:   router.Description,
+				WANInterfaces: router.WANInterfaces,
+				Tags:          router.Tags,
+				IsVirtual:     &router.IsVirtual,
+				AutoConnect:   &router.AutoConnect,
+				RouterContact: router.RouterContact,
+				RouterDial:    router.RouterDial,
+			},
+			IsActive: router.IsActive,
+		}
+		if aead != nil {
+			if rt.Secrets, err = sealRouterSecrets(aead, router); err != nil {
+				return nil, err
+			}
+		}
+		bundle.Routers = append(bundle.Routers, rt)
+	}
+
+	allPlans, err := plans.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range allPlans {
+		bundle.Plans = append(bundle.Plans, &models.PlanCreateRequest{
+			Name:           p.Name,
+			RateLimit:      p.RateLimit,
+			BurstLimit:     p.BurstLimit,
+			BurstThreshold: p.BurstThreshold,
+			BurstTime:      p.BurstTime,
+			QuotaBytes:     p.QuotaBytes,
+			PPPProfile:     p.PPPProfile,
+			Description:    p.Description,
+		})
+	}
+
+	allChannels, err := channels.List(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range allChannels {
+		bundle.AlertChannels = append(bundle.AlertChannels, &models.NotificationChannelRequest{
+			Name:        c.Name,
+			Type:        c.Type,
+			Target:      c.Target,
+			RouterTags:  &c.RouterTags,
+			MinSeverity: c.MinSeverity,
+			QuietStart:  c.QuietStart,
+			QuietEnd:    c.QuietEnd,
+			Enabled:     &c.Enabled,
+		})
+	}
+	return bundle, nil
+}
+
+// OpenLayerConfigSecrets - Cek versi bundle lalu dekripsi kredensial router (bundle encrypted)
+// ke field password / jump host masing-masing
+func OpenLayerConfigSecrets(bundle *models.LayerConfigBundle, passphrase string) error {
+	if bundle.Version != models.LayerConfigVersion {
+		return i18n.NewError(i18n.ConfigVersionUnsupported, bundle.Version, models.LayerConfigVersion)
+	}
+	if bundle.Secrets != models.ConfigSecretsEncrypted {
+		return nil
+	}
+	if passphrase == "" {
+		return i18n.NewError(i18n.ConfigPassphraseRequired)
+	}
+	if bundle.Encryption == nil {
+		return fmt.Errorf("encrypted bundle has no encryption parameters")
+	}
+
+	aead, err := configCipher(bundle.Encryption, passphrase)
+	if err != nil {
+		return err
+	}
+	for _, rt := range bundle.Routers {
+		if rt.Secrets == "" {
+			continue
+		}
+		secrets, err := openRouterSecrets(aead, rt)
+		if err != nil {
+			return err
+		}
+		rt.Password = secrets.Password
+		rt.JumpPassword = secrets.JumpPassword
+		rt.JumpPrivateKey = secrets.JumpPrivateKey
+		rt.Secrets = ""
+	}
+	return nil
+}
+
+// configCipher - AES-256-GCM dengan kunci PBKDF2 dari passphrase dan salt bundle
+func configCipher(enc *models.ConfigEncryption, passphrase string) (cipher.AEAD, error) {
+	if enc.KDF != "pbkdf2-sha256" || enc.Cipher != "aes-256-gcm" || enc.Iterations <= 0 {
+		return nil, fmt.Errorf("unsupported bundle encryption %s/%s", enc.KDF, enc.Cipher)
+	}
+	salt, err := base64.StdEncoding.DecodeString(enc.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle salt: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, enc.Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealRouterSecrets - base64(nonce || ciphertext); nama router jadi data tambahan GCM supaya
+// kredensial tidak bisa dipindah ke router lain di bundle
+func sealRouterSecrets(aead cipher.AEAD, router *models.Router) (string, error) {
+	plain, err := json.Marshal(routerSecrets{
+		Password:       router.Password,
+		JumpPassword:   router.JumpPassword,
+		JumpPrivateKey: router.JumpPrivateKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plain)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, plain, []byte(router.Name))), nil
+}
+
+func openRouterSecrets(aead cipher.AEAD, rt *models.ConfigRouter) (*routerSecrets, error) {
+	sealed, err := base64.StdEncoding.DecodeString(rt.Secrets)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("secrets of router %s are corrupted", rt.Name)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, []byte(rt.Name))
+	if err != nil {
+		return nil, i18n.NewError(i18n.ConfigPassphraseInvalid, rt.Name)
+	}
+
+	secrets := &routerSecrets{}
+	if err := json.Unmarshal(plain, secrets); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// leaderLeaseName - Satu lease untuk semua worker background
+const leaderLeaseName = "background-workers"
+
+// LeaderElector - Lease leader di tabel leader_leases supaya worker background (sampler, health
+// check status, monitor, scheduler) hanya menulis dari satu instance saat layer di-deploy lebih
+// dari satu. Lease diperpanjang tiap ttl/3; jika leader mati, instance lain mengambil alih
+// setelah lease kedaluwarsa.
+type LeaderElector struct {
+	ttl      time.Duration
+	instance string
+	repo     *repository.LeaderRepository
+	leader   atomic.Bool
+}
+
+// activeElector - nil = leader election nonaktif, instance ini selalu leader
+var activeElector *LeaderElector
+
+// NewLeaderElector - ttl <= 0 menonaktifkan election. Elector yang aktif langsung dipakai
+// IsLeader(), jadi buat sebelum worker background dijalankan.
+func NewLeaderElector(ttl time.Duration, repo *repository.LeaderRepository) *LeaderElector {
+	hostname, _ := os.Hostname()
+	suffix, _ := newEventID()
+	if len(suffix) > 8 {
+		suffix = suffix[:8]
+	}
+
+	e := &LeaderElector{
+		ttl:      ttl,
+		instance: fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), suffix),
+		repo:     repo,
+	}
+	if ttl > 0 {
+		activeElector = e
+	}
+	return e
+}
+
+// Run - Loop ambil/perpanjang lease (blocking)
+func (e *LeaderElector) Run() {
+	if e.ttl <= 0 {
+		return
+	}
+
+	log.Printf("[LEADER] Instance %s joining election (lease %v)", e.instance, e.ttl)
+	e.renew()
+
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		e.renew()
+	}
+}
+
+func (e *LeaderElector) renew() {
+	held, err := e.repo.Acquire(leaderLeaseName, e.instance, e.ttl)
+	if err != nil {
+		// Tanpa DB lease tidak bisa dipastikan: mundur supaya tidak ada dua leader
+		log.Printf("[LEADER] Error renewing lease: %v", err)
+		held = false
+	}
+
+	if was := e.leader.Swap(held); was == held {
+		return
+	}
+	if held {
+		log.Printf("[LEADER] Instance %s elected leader", e.instance)
+	} else {
+		log.Printf("[LEADER] Instance %s lost leadership", e.instance)
+	}
+}
+
+// IsLeader - True jika worker background boleh berjalan di instance ini
+func IsLeader() bool {
+	if activeElector == nil {
+		return true
+	}
+	return activeElector.leader.Load()
+}
+
+// LeaderStatus - Status election instance ini
+func LeaderStatus() *models.LeaderStatus {
+	if activeElector == nil {
+		return &models.LeaderStatus{Leader: true}
+	}
+	return &models.LeaderStatus{
+		Enabled:  true,
+		Instance: activeElector.instance,
+		Leader:   activeElector.leader.Load(),
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// LeaseHistoryTracker - Poll lease DHCP bound tiap interval dan simpan riwayat IP <-> MAC
+// (tabel dhcp_lease_history) untuk lookup IP historis
+type LeaseHistoryTracker struct {
+	interval  time.Duration
+	retention time.Duration
+	ms        *MikrotikService
+	repo      *repository.LeaseHistoryRepository
+}
+
+func NewLeaseHistoryTracker(interval, retention time.Duration, ms *MikrotikService, repo *repository.LeaseHistoryRepository) *LeaseHistoryTracker {
+	return &LeaseHistoryTracker{
+		interval:  interval,
+		retention: retention,
+		ms:        ms,
+		repo:      repo,
+	}
+}
+
+// Run - Loop polling (blocking)
+func (t *LeaseHistoryTracker) Run() {
+	if t.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		now := time.Now()
+		for routerID, conn := range t.ms.GetAllConnections() {
+			if !conn.IsHealthy {
+				continue
+			}
+			t.poll(routerID, now)
+		}
+
+		if t.retention > 0 && now.Sub(lastPurge) >= time.Hour {
+			lastPurge = now
+			if n, err := t.repo.Purge(now.Add(-t.retention)); err != nil {
+				log.Printf("[LEASE] Error purging lease history: %v", err)
+			} else if n > 0 {
+				log.Printf("[LEASE] Purged %d old leases", n)
+			}
+		}
+	}
+}
+
+func (t *LeaseHistoryTracker) poll(routerID int, now time.Time) {
+	leases, err := t.ms.GetDHCPLeases(routerID)
+	if err != nil {
+		log.Printf("[LEASE] Error reading leases router %d: %v", routerID, err)
+		return
+	}
+
+	open, err := t.repo.Open(routerID)
+	if err != nil {
+		log.Printf("[LEASE] Error loading open leases router %d: %v", routerID, err)
+		return
+	}
+	byKey := make(map[string]*models.DHCPLeaseRecord, len(open))
+	for _, l := range open {
+		byKey[l.Address+"/"+l.MacAddress] = l
+	}
+
+	for _, lease := range leases {
+		if lease.Status != "bound" || lease.Address == "" {
+			continue
+		}
+		key := lease.Address + "/" + lease.MacAddress
+		if l, ok := byKey[key]; ok {
+			delete(byKey, key)
+			if err := t.repo.Touch(l.ID, lease.HostName, now); err != nil {
+				log.Printf("[LEASE] Error updating lease %d: %v", l.ID, err)
+			}
+			continue
+		}
+
+		err := t.repo.Start(&models.DHCPLeaseRecord{
+			RouterID:    routerID,
+			Address:     lease.Address,
+			MacAddress:  lease.MacAddress,
+			HostName:    lease.HostName,
+			Server:      lease.Server,
+			FirstSeenAt: now,
+			LastSeenAt:  now,
+		})
+		if err != nil {
+			log.Printf("[LEASE] Error recording lease %s router %d: %v", lease.Address, routerID, err)
+		}
+	}
+
+	// Lease yang tidak lagi bound sudah berakhir (lihat catatan waktu di PPPSessionTracker.poll)
+	for _, l := range byKey {
+		ended := now
+		if now.Sub(l.LastSeenAt) > 2*t.interval {
+			ended = l.LastSeenAt
+		}
+		if err := t.repo.End(l.ID, ended); err != nil {
+			log.Printf("[LEASE] Error closing lease %d: %v", l.ID, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+
+	"Mikrotik-Layer/models"
+)
+
+// RemoteLoggingAction - Nama /system/logging action yang dikelola layer
+const RemoteLoggingAction = "mikrotik-layer"
+
+// ConfigureRemoteLogging - Arahkan log router ke syslog target (idempotent).
+// Action RemoteLoggingAction dibuat/diupdate, lalu rule logging-nya diganti sesuai topics.
+func (ms *MikrotikService) ConfigureRemoteLogging(routerID int, remote string, port int, topics []string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	plan := newCommandPlan(routerID, "configure_remote_logging", dryRun)
+
+	r, err := conn.Run("/system/logging/action/print", fmt.Sprintf("?name=%s", RemoteLoggingAction), "=.proplist=.id")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.Re) > 0 {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("logging action %s exists, updating", RemoteLoggingAction))
+		plan.Commands = append(plan.Commands, []string{
+			"/system/logging/action/set",
+			fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+			fmt.Sprintf("=remote=%s", remote),
+			fmt.Sprintf("=remote-port=%d", port),
+			"=bsd-syslog=yes",
+		})
+	} else {
+		plan.Commands = append(plan.Commands, []string{
+			"/system/logging/action/add",
+			fmt.Sprintf("=name=%s", RemoteLoggingAction),
+			"=target=remote",
+			fmt.Sprintf("=remote=%s", remote),
+			fmt.Sprintf("=remote-port=%d", port),
+			"=bsd-syslog=yes",
+		})
+	}
+
+	r, err = conn.Run("/system/logging/print", fmt.Sprintf("?action=%s", RemoteLoggingAction), "=.proplist=.id,topics")
+	if err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		plan.Commands = append(plan.Commands, []string{
+			"/system/logging/remove",
+			fmt.Sprintf("=.id=%s", re.Map[".id"]),
+		})
+	}
+
+	for _, topic := range topics {
+		plan.Commands = append(plan.Commands, []string{
+			"/system/logging/add",
+			fmt.Sprintf("=action=%s", RemoteLoggingAction),
+			fmt.Sprintf("=topics=%s", topic),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// DefaultPingCount - Jumlah paket per putaran jika path tidak menentukan ping_count
+const DefaultPingCount = 5
+
+// Ping - Jalankan /ping dari router ke address, return ringkasan rtt & loss
+func (ms *MikrotikService) Ping(routerID int, address string, count int) (*models.PingResult, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	r, err := conn.Run("/ping", fmt.Sprintf("=address=%s", address), fmt.Sprintf("=count=%d", count))
+	if err != nil {
+		return nil, err
+	}
+
+	// Setiap !re membawa total kumulatif; yang terakhir adalah ringkasan akhir
+	result := &models.PingResult{Sent: count, LossPct: 100}
+	for _, re := range r.Re {
+		if re.Map["sent"] == "" {
+			continue
+		}
+		result.Sent, _ = strconv.Atoi(re.Map["sent"])
+		result.Received, _ = strconv.Atoi(re.Map["received"])
+		result.LossPct, _ = strconv.ParseFloat(re.Map["packet-loss"], 64)
+		result.MinRttMs = parseRtt(re.Map["min-rtt"])
+		result.AvgRttMs = parseRtt(re.Map["avg-rtt"])
+		result.MaxRttMs = parseRtt(re.Map["max-rtt"])
+	}
+
+	return result, nil
+}
+
+// parseRtt - Durasi RouterOS ("12ms", "1ms512us") ke milidetik
+func parseRtt(v string) float64 {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0
+	}
+	return float64(d.Microseconds()) / 1000
+}
+
+// MeshMonitor - Ping periodik antar router sesuai monitored_paths, simpan latency/loss
+// dan catat event saat jalur berubah degraded / pulih.
+type MeshMonitor struct {
+	interval   time.Duration
+	retention  time.Duration
+	ms         *MikrotikService
+	routerRepo *repository.RouterRepository
+	repo       *repository.MeshRepository
+	recorder   *EventRecorder
+}
+
+func NewMeshMonitor(interval, retention time.Duration, ms *MikrotikService, routerRepo *repository.RouterRepository, repo *repository.MeshRepository, recorder *EventRecorder) *MeshMonitor {
+	return &MeshMonitor{
+		interval:   interval,
+		retention:  retention,
+		ms:         ms,
+		routerRepo: routerRepo,
+		repo:       repo,
+		recorder:   recorder,
+	}
+}
+
+// Run - Loop monitor (blocking)
+func (m *MeshMonitor) Run() {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		m.probe()
+		m.cleanup()
+	}
+}
+
+func (m *MeshMonitor) probe() {
+	paths, err := m.repo.ListPaths(true)
+	if err != nil {
+		log.Printf("[MESH] Error loading paths: %v", err)
+		return
+	}
+
+	connections := m.ms.GetAllConnections()
+
+	// Ping tiap path paralel: satu putaran butuh ~count detik
+	var wg sync.WaitGroup
+	for _, path := range paths {
+		if conn, ok := connections[path.SourceRouterID]; !ok || !conn.IsHealthy {
+			continue
+		}
+		wg.Add(1)
+		go func(path *models.MonitoredPath) {
+			defer Recover("mesh")
+			defer wg.Done()
+			m.sample(path)
+		}(path)
+	}
+	wg.Wait()
+}
+
+func (m *MeshMonitor) sample(path *models.MonitoredPath) {
+	target, err := m.resolveTarget(path)
+	if err != nil {
+		log.Printf("[MESH] Path %d (%s): %v", path.ID, path.Name, err)
+		return
+	}
+
+	count := path.PingCount
+	if count <= 0 {
+		count = DefaultPingCount
+	}
+
+	result, err := m.ms.Ping(path.SourceRouterID, target, count)
+	if err != nil {
+		log.Printf("[MESH] Ping %s from router %d failed: %v", target, path.SourceRouterID, err)
+		return
+	}
+
+	sample := &models.PathSample{PathID: path.ID, PingResult: *result, SampledAt: time.Now()}
+	if err := m.repo.InsertSample(sample); err != nil {
+		log.Printf("[MESH] Error saving sample path %d: %v", path.ID, err)
+	}
+
+	m.evaluate(path, target, result)
+}
+
+// resolveTarget - Alamat tujuan ping: target_address, atau hostname router tujuan
+func (m *MeshMonitor) resolveTarget(path *models.MonitoredPath) (string, error) {
+	if path.TargetAddress != nil && *path.TargetAddress != "" {
+		return *path.TargetAddress, nil
+	}
+	if path.TargetRouterID == nil {
+		return "", fmt.Errorf("path has no target")
+	}
+
+	router, err := m.routerRepo.GetByID(*path.TargetRouterID)
+	if err != nil {
+		return "", err
+	}
+	return router.Hostname, nil
+}
+
+// evaluate - Bandingkan hasil dengan threshold; event hanya saat state berubah
+func (m *MeshMonitor) evaluate(path *models.MonitoredPath, target string, result *models.PingResult) {
+	state := models.PathStateOK
+	var reasons []string
+	if result.Received == 0 || (path.LossThresholdPct > 0 && result.LossPct >= path.LossThresholdPct) {
+		state = models.PathStateDegraded
+		reasons = append(reasons, fmt.Sprintf("loss %.0f%%", result.LossPct))
+	}
+	if path.LatencyThresholdMs > 0 && result.Received > 0 && result.AvgRttMs > path.LatencyThresholdMs {
+		state = models.PathStateDegraded
+		reasons = append(reasons, fmt.Sprintf("avg rtt %.1fms", result.AvgRttMs))
+	}
+
+	if state == path.State {
+		return
+	}
+	if err := m.repo.SetPathState(path.ID, state); err != nil {
+		log.Printf("[MESH] Error saving state path %d: %v", path.ID, err)
+	}
+
+	// Transisi pertama dari unknown ke ok tidak perlu event
+	if path.State == models.PathStateUnknown && state == models.PathStateOK {
+		return
+	}
+
+	routerID := path.SourceRouterID
+	event := &models.Event{
+		RouterID: &routerID,
+		Type:     "path_recovered",
+		Severity: "info",
+		Message:  fmt.Sprintf("Jalur %s (router %d -> %s) pulih", path.Name, path.SourceRouterID, target),
+		Data:     mustJSON(map[string]interface{}{"path_id": path.ID, "target": target, "result": result}),
+	}
+	if state == models.PathStateDegraded {
+		event.Type = "path_degraded"
+		event.Severity = "warning"
+		event.Message = fmt.Sprintf("Jalur %s (router %d -> %s) degraded: %s", path.Name, path.SourceRouterID, target, strings.Join(reasons, ", "))
+	}
+
+	log.Printf("[MESH] Path %d (%s) %s -> %s", path.ID, path.Name, path.State, state)
+	m.recorder.Record(event)
+}
+
+func (m *MeshMonitor) cleanup() {
+	if m.retention <= 0 {
+		return
+	}
+
+	deleted, err := m.repo.DeleteSamplesOlderThan(time.Now().Add(-m.retention))
+	if err != nil {
+		log.Printf("[MESH] Retention cleanup failed: %v", err)
+	} else if deleted > 0 {
+		log.Printf("[MESH] Retention cleanup removed %d samples", deleted)
+	}
+}
@@ -3,14 +3,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"Mikrotik-Layer/models"
 	"Mikrotik-Layer/repository"
+	"Mikrotik-Layer/tracing"
 
 	"github.com/go-routeros/routeros/v3"
 )
@@ -20,9 +23,88 @@ type MikrotikConnection struct {
 	RouterID   int
 	Router     *models.Router
 	Client     *routeros.Client
-	mu         sync.RWMutex
+	mu         connMutex
 	LastPing   time.Time
 	IsHealthy  bool
+
+	// Path koneksi: "primary" (hostname) atau label alamat tambahan, alamat management yang
+	// dipakai, dan IP hasil resolve jika alamat berupa nama DNS (kosong = tidak di-resolve)
+	ActivePath      string
+	ActiveAddress   string
+	ResolvedAddress string
+
+	sim  *trafficSimulator          // non-nil untuk router virtual (Client nil)
+	caps *models.RouterCapabilities // nil sampai deteksi berhasil
+
+	// Counter command untuk error rate skor kesehatan
+	commands      atomic.Uint64
+	commandErrors atomic.Uint64
+
+	// Durasi command terakhir untuk persentil latency API
+	latency latencyRing
+}
+
+// RunArgs - Eksekusi sentence via client RouterOS, atau simulator untuk router virtual
+func (c *MikrotikConnection) RunArgs(sentence []string) (*routeros.Reply, error) {
+	var (
+		reply *routeros.Reply
+		err   error
+	)
+	span := tracing.StartChild("routeros "+commandName(sentence), tracing.KindClient)
+	span.SetAttr("router_id", c.RouterID)
+	start := time.Now()
+	if c.sim != nil {
+		reply, err = c.sim.run(sentence)
+	} else {
+		reply, err = c.Client.RunArgs(sentence)
+	}
+	span.SetError(err)
+	span.End()
+	if len(sentence) > 0 && !latencyExcluded[sentence[0]] {
+		c.latency.add(time.Since(start))
+	}
+
+	c.commands.Add(1)
+	if err != nil {
+		c.commandErrors.Add(1)
+	}
+	return reply, err
+}
+
+// commandCounts - Total command dan command gagal sejak koneksi dibuka
+func (c *MikrotikConnection) commandCounts() (uint64, uint64) {
+	return c.commands.Load(), c.commandErrors.Load()
+}
+
+// Run - Variadic shortcut untuk RunArgs
+func (c *MikrotikConnection) Run(sentence ...string) (*routeros.Reply, error) {
+	return c.RunArgs(sentence)
+}
+
+// dialHost - Alamat untuk koneksi tambahan ke router (mis. FTP): IP hasil resolve, alamat
+// management yang sedang dipakai, atau hostname
+func (c *MikrotikConnection) dialHost() string {
+	switch {
+	case c.ResolvedAddress != "":
+		return c.ResolvedAddress
+	case c.ActiveAddress != "":
+		return c.ActiveAddress
+	default:
+		return c.Router.Hostname
+	}
+}
+
+// IsVirtual - True jika koneksi dilayani simulator
+func (c *MikrotikConnection) IsVirtual() bool {
+	return c.sim != nil
+}
+
+// close - Tutup client RouterOS (no-op untuk router virtual)
+func (c *MikrotikConnection) close() error {
+	if c.Client == nil {
+		return nil
+	}
+	return c.Client.Close()
 }
 
 // MikrotikService - Manages multiple router connections
@@ -30,6 +112,12 @@ type MikrotikService struct {
 	connections map[int]*MikrotikConnection // RouterID -> Connection
 	repo        *repository.RouterRepository
 	mu          sync.RWMutex
+	streams     *streamRegistry             // stream WebSocket monitor aktif
+	retry       atomic.Pointer[RetryPolicy] // nil = defaultRetryPolicy
+	dial        atomic.Pointer[DialConfig]  // nil = tanpa source address / bind interface
+	dnsCache    sync.Map                    // hostname -> *dnsEntry
+	events      *EventRecorder              // event failover / failback path, nil = hanya log
+	power       sync.Map                    // routerID -> waktu mulai reboot / shutdown lewat layer
 }
 
 // TrafficStats untuk menyimpan statistik traffic
@@ -45,27 +133,29 @@ type TrafficStats struct {
 	Timestamp     time.Time
 }
 
-var (
-	serviceInstance *MikrotikService
-	serviceOnce     sync.Once
-)
+// NewMikrotikService - Service dengan repository; koneksi belum dibuka sampai Start dipanggil
+// supaya retry policy / dial config sudah diterapkan sebelum auto-connect
+func NewMikrotikService(repo *repository.RouterRepository) *MikrotikService {
+	return &MikrotikService{
+		connections: make(map[int]*MikrotikConnection),
+		repo:        repo,
+		streams:     newStreamRegistry(),
+	}
+}
 
-// GetMikrotikService - Initialize service dengan repository
-func GetMikrotikService(repo *repository.RouterRepository) *MikrotikService {
-	serviceOnce.Do(func() {
-		serviceInstance = &MikrotikService{
-			connections: make(map[int]*MikrotikConnection),
-			repo:        repo,
-		}
+// Start - Jalankan auto-connect dan routine background koneksi (sekali per service)
+func (ms *MikrotikService) Start() {
+	// Auto-connect ke semua active routers
+	go Supervise("auto-connect", ms.autoConnectActiveRouters)
 
-		// Auto-connect ke semua active routers
-		go serviceInstance.autoConnectActiveRouters()
-		
-		// Health check routine
-		go serviceInstance.healthCheckRoutine()
-	})
+	// Health check routine
+	go Supervise("health-check", ms.healthCheckRoutine)
 
-	return serviceInstance
+	// Resolve ulang hostname DNS router yang terhubung
+	go Supervise("dns-resolve", ms.resolveRoutine)
+
+	// Kembali ke alamat primary setelah failover ke alamat tambahan
+	go Supervise("failback", ms.failbackRoutine)
 }
 
 // autoConnectActiveRouters - Connect ke semua router yang aktif
@@ -77,6 +167,14 @@ func (ms *MikrotikService) autoConnectActiveRouters() {
 	}
 
 	for _, router := range routers {
+		if router.Status == "suspended" {
+			log.Printf("Skipping suspended router %s (%d)", router.Name, router.ID)
+			continue
+		}
+		if !router.AutoConnect {
+			log.Printf("Skipping router %s (%d): auto_connect disabled", router.Name, router.ID)
+			continue
+		}
 		if err := ms.ConnectRouter(router.ID); err != nil {
 			log.Printf("Error auto-connecting to router %s (%d): %v", router.Name, router.ID, err)
 		} else {
@@ -86,7 +184,7 @@ func (ms *MikrotikService) autoConnectActiveRouters() {
 }
 
 // dialWithTimeout - Dial dengan timeout menggunakan context
-func dialWithTimeout(address, username, password string, timeout time.Duration) (*routeros.Client, error) {
+func dialWithTimeout(dialer netDialer, address, username, password string, timeout time.Duration) (*routeros.Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -99,11 +197,6 @@ func dialWithTimeout(address, username, password string, timeout time.Duration)
 
 	// Dial di goroutine
 	go func() {
-		// Create custom dialer dengan timeout
-		dialer := &net.Dialer{
-			Timeout: timeout,
-		}
-		
 		// Dial TCP connection dulu
 		conn, err := dialer.Dial("tcp", address)
 		if err != nil {
@@ -122,7 +215,7 @@ func dialWithTimeout(address, username, password string, timeout time.Duration)
 		// Login
 		if err := client.Login(username, password); err != nil {
 			client.Close()
-			resultChan <- result{nil, fmt.Errorf("login failed: %w", err)}
+			resultChan <- result{nil, fmt.Errorf("%w: %w", errLoginFailed, err)}
 			return
 		}
 
@@ -139,7 +232,14 @@ func dialWithTimeout(address, username, password string, timeout time.Duration)
 }
 
 // ConnectRouter - Connect ke router berdasarkan ID dari database (WITH TIMEOUT)
-func (ms *MikrotikService) ConnectRouter(routerID int) error {
+func (ms *MikrotikService) ConnectRouter(routerID int) (err error) {
+	span := tracing.StartChild("routeros.connect", tracing.KindClient)
+	span.SetAttr("router_id", routerID)
+	defer func() {
+		span.SetError(err)
+		span.End()
+	}()
+
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 
@@ -153,8 +253,9 @@ func (ms *MikrotikService) ConnectRouter(routerID int) error {
 		}
 		// Close unhealthy connection
 		log.Printf("Closing unhealthy connection for router ID %d", routerID)
-		conn.Client.Close()
+		conn.close()
 		delete(ms.connections, routerID)
+		ms.publishConnection(routerID, conn.Router, models.ConnectionReconnecting, "connection unhealthy")
 	}
 
 	// Load router config from database
@@ -169,25 +270,59 @@ func (ms *MikrotikService) ConnectRouter(routerID int) error {
 		return fmt.Errorf("router is not active")
 	}
 
-	// Create connection WITH TIMEOUT
-	address := fmt.Sprintf("%s:%d", router.Hostname, router.Port)
-	log.Printf("Dialing %s (timeout: 10s)...", address)
-	
-	client, err := dialWithTimeout(address, router.Username, router.Password, 20*time.Second)
-	if err != nil {
-		log.Printf("Failed to connect to router %s: %v", router.Name, err)
-		// Update status to error
-		ms.repo.UpdateStatus(routerID, &models.RouterStatusUpdate{
-			Status: "error",
-		})
-		return fmt.Errorf("failed to connect: %v", err)
+	if router.Status == "suspended" {
+		return fmt.Errorf("router is suspended")
+	}
+
+	conn := &MikrotikConnection{
+		mu:        connMutex{routerID: routerID},
+		RouterID:  routerID,
+		Router:    router,
+		LastPing:  time.Now(),
+		IsHealthy: true,
+	}
+
+	if router.IsVirtual {
+		// Router virtual: tidak ada dial, semua data dari simulator
+		log.Printf("Router %s is virtual, attaching traffic simulator", router.Name)
+		conn.sim = newTrafficSimulator(routerID)
+	} else {
+		// Create connection WITH TIMEOUT, failover antar alamat management / hasil resolve
+		client, target, err := ms.dialRouter(router)
+		if err != nil {
+			log.Printf("Failed to connect to router %s: %v", router.Name, err)
+			// Update status to error (kecuali sedang reboot / shutdown lewat layer)
+			if !ms.powerPending(routerID) {
+				ms.repo.UpdateStatus(routerID, &models.RouterStatusUpdate{
+					Status: "error",
+				})
+			}
+			ms.publishConnection(routerID, router, connectFailedState(err), err.Error())
+			return fmt.Errorf("failed to connect: %v", err)
+		}
+		conn.Client = client
+		conn.ActivePath, conn.ActiveAddress = target.path, target.host
+		if target.address != target.host {
+			conn.ResolvedAddress = target.address
+		}
+
+		if router.ActiveAddress == nil || *router.ActiveAddress != target.host {
+			if target.path != primaryPath {
+				log.Printf("⚠ Router %s connected via %s path (%s)", router.Name, target.path, target.host)
+			}
+			if err := ms.repo.SetActiveAddress(routerID, target.host); err != nil {
+				log.Printf("Error recording active address for router %s: %v", router.Name, err)
+			}
+			ms.recordPathChange(router, target)
+		}
 	}
 
 	log.Printf("Connected to %s, getting system info...", router.Name)
 
 	// Get system info
-	systemInfo, _ := ms.getSystemInfo(client)
-	
+	systemInfo, _ := ms.getSystemInfo(conn)
+	cacheCapabilities(conn)
+
 	// Update router status to online
 	statusUpdate := &models.RouterStatusUpdate{
 		Status: "online",
@@ -199,13 +334,9 @@ func (ms *MikrotikService) ConnectRouter(routerID int) error {
 	ms.repo.UpdateStatus(routerID, statusUpdate)
 
 	// Store connection
-	ms.connections[routerID] = &MikrotikConnection{
-		RouterID:  routerID,
-		Router:    router,
-		Client:    client,
-		LastPing:  time.Now(),
-		IsHealthy: true,
-	}
+	ms.connections[routerID] = conn
+	ms.power.Delete(routerID)
+	ms.publishConnected(conn)
 
 	log.Printf("✓ Successfully connected to router: %s (%s)", router.Name, router.Hostname)
 	return nil
@@ -221,8 +352,10 @@ func (ms *MikrotikService) DisconnectRouter(routerID int) error {
 		return fmt.Errorf("router not connected")
 	}
 
-	conn.Client.Close()
+	conn.close()
 	delete(ms.connections, routerID)
+	GetHealthScorer().Forget(routerID)
+	ms.publishConnection(routerID, conn.Router, models.ConnectionDisconnected, "disconnected by user")
 
 	// Update status to offline
 	ms.repo.UpdateStatus(routerID, &models.RouterStatusUpdate{
@@ -233,6 +366,142 @@ func (ms *MikrotikService) DisconnectRouter(routerID int) error {
 	return nil
 }
 
+// SuspendRouter - Putus koneksi dan tandai "suspended": health check dan reconnect berhenti
+// sampai ResumeRouter dipanggil. Berbeda dengan is_active, router tetap terdaftar normal.
+func (ms *MikrotikService) SuspendRouter(routerID int) error {
+	if _, err := ms.repo.GetByID(routerID); err != nil {
+		return err
+	}
+
+	// Status disimpan dulu supaya health check / reconnect tidak menyambung ulang router
+	// di sela drop; jika gagal, koneksi dibiarkan
+	if err := ms.repo.SetStatus(routerID, "suspended"); err != nil {
+		return err
+	}
+
+	ms.dropConnection(routerID, "router suspended")
+
+	log.Printf("✓ Router ID %d suspended", routerID)
+	return nil
+}
+
+// ResumeRouter - Cabut status suspended lalu reconnect (jika router aktif dan auto_connect)
+func (ms *MikrotikService) ResumeRouter(routerID int) error {
+	router, err := ms.repo.GetByID(routerID)
+	if err != nil {
+		return err
+	}
+
+	if router.Status != "suspended" {
+		return fmt.Errorf("router is not suspended")
+	}
+
+	if err := ms.repo.SetStatus(routerID, "offline"); err != nil {
+		return err
+	}
+
+	log.Printf("✓ Router ID %d resumed", routerID)
+
+	if router.IsActive && router.AutoConnect {
+		go func() {
+			if err := ms.ConnectRouter(routerID); err != nil {
+				log.Printf("Error reconnecting resumed router %d: %v", routerID, err)
+			}
+		}()
+	}
+
+	return nil
+}
+
+// ReloadRouter - Terapkan konfigurasi router yang baru disimpan ke koneksi aktif.
+// Jika alamat, port, kredensial atau mode virtual berubah, koneksi lama dilepas dari registry,
+// koneksi baru di-dial, lalu koneksi lama ditutup; monitor traffic yang berjalan otomatis
+// pindah ke koneksi baru (lihat waitListenResume).
+func (ms *MikrotikService) ReloadRouter(router *models.Router) {
+	ms.mu.Lock()
+	old, exists := ms.connections[router.ID]
+	if !exists {
+		ms.mu.Unlock()
+		return
+	}
+	if !connectionChanged(old.Router, router) {
+		old.Router = router
+		ms.mu.Unlock()
+		return
+	}
+	delete(ms.connections, router.ID)
+	ms.mu.Unlock()
+	GetHealthScorer().Forget(router.ID)
+
+	log.Printf("Connection settings of router %s changed, reconnecting...", router.Name)
+
+	if !router.IsActive || router.Status == "suspended" {
+		old.close()
+		ms.publishConnection(router.ID, router, models.ConnectionDisconnected, "router deactivated")
+		if !router.IsActive {
+			ms.repo.UpdateStatus(router.ID, &models.RouterStatusUpdate{Status: "offline"})
+		}
+		return
+	}
+
+	ms.publishConnection(router.ID, router, models.ConnectionReconnecting, "connection settings changed")
+	go func() {
+		// Koneksi lama tetap melayani stream yang berjalan sampai dial ulang selesai
+		if err := ms.ConnectRouter(router.ID); err != nil {
+			log.Printf("Error reconnecting router %d after update: %v", router.ID, err)
+		}
+		if err := old.close(); err != nil {
+			log.Printf("Error closing previous connection to router %d: %v", router.ID, err)
+		}
+	}()
+}
+
+// connectionChanged - True jika perubahan router butuh koneksi baru (termasuk source address / VRF
+// dan jump host)
+func connectionChanged(old, updated *models.Router) bool {
+	return old.Hostname != updated.Hostname ||
+		old.Port != updated.Port ||
+		old.Username != updated.Username ||
+		old.Password != updated.Password ||
+		old.IsVirtual != updated.IsVirtual ||
+		derefString(old.SourceAddress) != derefString(updated.SourceAddress) ||
+		derefString(old.BindInterface) != derefString(updated.BindInterface) ||
+		derefString(old.JumpType) != derefString(updated.JumpType) ||
+		derefString(old.JumpAddress) != derefString(updated.JumpAddress) ||
+		derefString(old.JumpUsername) != derefString(updated.JumpUsername) ||
+		derefString(old.JumpPassword) != derefString(updated.JumpPassword) ||
+		derefString(old.JumpPrivateKey) != derefString(updated.JumpPrivateKey) ||
+		derefString(old.JumpHostKey) != derefString(updated.JumpHostKey)
+}
+
+// RemoveRouter - Bersihkan semua state runtime router sebelum dihapus: stream monitor
+// ditutup dan koneksi dilepas tanpa menulis status (baris router akan dihapus)
+func (ms *MikrotikService) RemoveRouter(routerID int) {
+	ms.CloseStreams(routerID, "router deleted")
+	ms.dropConnection(routerID, "router deleted")
+	log.Printf("✓ Router ID %d removed from service", routerID)
+}
+
+// dropConnection - Tutup dan lepas koneksi tanpa mengubah status di DB
+func (ms *MikrotikService) dropConnection(routerID int, reason string) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if conn, exists := ms.connections[routerID]; exists {
+		conn.close()
+		delete(ms.connections, routerID)
+		ms.publishConnection(routerID, conn.Router, models.ConnectionDisconnected, reason)
+	}
+	GetHealthScorer().Forget(routerID)
+}
+
+// isRegistered - Cek apakah conn masih koneksi aktif untuk routernya
+func (ms *MikrotikService) isRegistered(conn *MikrotikConnection) bool {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.connections[conn.RouterID] == conn
+}
+
 // GetConnection - Get connection untuk router tertentu
 func (ms *MikrotikService) GetConnection(routerID int) (*MikrotikConnection, error) {
 	ms.mu.RLock()
@@ -240,6 +509,11 @@ func (ms *MikrotikService) GetConnection(routerID int) (*MikrotikConnection, err
 	ms.mu.RUnlock()
 
 	if !exists {
+		// Router dengan auto_connect=false hanya di-connect lewat permintaan eksplisit
+		if router, err := ms.repo.GetByID(routerID); err == nil && !router.AutoConnect {
+			return nil, fmt.Errorf("router not connected: auto_connect disabled, connect it explicitly first")
+		}
+
 		// Try to connect
 		if err := ms.ConnectRouter(routerID); err != nil {
 			return nil, fmt.Errorf("router not connected: %v", err)
@@ -250,7 +524,7 @@ func (ms *MikrotikService) GetConnection(routerID int) (*MikrotikConnection, err
 	}
 
 	if !conn.IsHealthy {
-		return nil, fmt.Errorf("router connection unhealthy")
+		return nil, errConnectionUnhealthy
 	}
 
 	return conn, nil
@@ -294,14 +568,34 @@ func (ms *MikrotikService) checkConnection(conn *MikrotikConnection) {
 	defer conn.mu.Unlock()
 
 	// Try to ping
-	_, err := conn.Client.RunArgs([]string{"/system/resource/print"})
+	start := time.Now()
+	reply, err := conn.RunArgs([]string{"/system/resource/print"})
+	latency := time.Since(start)
+
+	// Koneksi sudah di-drop (suspend/deactivate) selama check berjalan: jangan timpa status
+	if !ms.isRegistered(conn) {
+		return
+	}
+
+	var resource map[string]string
+	if err == nil && len(reply.Re) > 0 {
+		resource = reply.Re[0].Map
+	}
+	prevLevel, health := GetHealthScorer().observe(conn, err == nil, latency, resource)
+	if IsLeader() {
+		ms.recordHealthLevel(conn, prevLevel, health)
+	}
+
 	if err != nil {
 		conn.IsHealthy = false
 		log.Printf("✗ Router %s unhealthy: %v", conn.Router.Name, err)
 		
-		ms.repo.UpdateStatus(conn.RouterID, &models.RouterStatusUpdate{
-			Status: "error",
-		})
+		// Multi instance: koneksi dicek di semua instance, status router hanya ditulis leader
+		if IsLeader() {
+			ms.repo.UpdateStatus(conn.RouterID, &models.RouterStatusUpdate{
+				Status: "error",
+			})
+		}
 		
 		// Try to reconnect
 		go ms.ConnectRouter(conn.RouterID)
@@ -312,7 +606,7 @@ func (ms *MikrotikService) checkConnection(conn *MikrotikConnection) {
 	conn.LastPing = time.Now()
 
 	// Get system info and update status
-	systemInfo, _ := ms.getSystemInfo(conn.Client)
+	systemInfo, _ := ms.getSystemInfo(conn)
 	statusUpdate := &models.RouterStatusUpdate{
 		Status: "online",
 	}
@@ -320,7 +614,9 @@ func (ms *MikrotikService) checkConnection(conn *MikrotikConnection) {
 		statusUpdate.Version = &systemInfo.Version
 		statusUpdate.Uptime = &systemInfo.Uptime
 	}
-	ms.repo.UpdateStatus(conn.RouterID, statusUpdate)
+	if IsLeader() {
+		ms.repo.UpdateStatus(conn.RouterID, statusUpdate)
+	}
 }
 
 // SystemInfo struct
@@ -330,8 +626,8 @@ type SystemInfo struct {
 }
 
 // getSystemInfo - Get system resource info
-func (ms *MikrotikService) getSystemInfo(client *routeros.Client) (*SystemInfo, error) {
-	r, err := client.RunArgs([]string{"/system/resource/print"})
+func (ms *MikrotikService) getSystemInfo(conn *MikrotikConnection) (*SystemInfo, error) {
+	r, err := conn.RunArgs([]string{"/system/resource/print"})
 	if err != nil {
 		return nil, err
 	}
@@ -346,36 +642,70 @@ func (ms *MikrotikService) getSystemInfo(client *routeros.Client) (*SystemInfo,
 	}, nil
 }
 
-// ==================== Interface Methods ====================
-
-func (ms *MikrotikService) GetInterfaces(routerID int) ([]*models.Interface, error) {
-	conn, err := ms.GetConnection(routerID)
+// GetSystemResource - Snapshot /system/resource (CPU, memory, uptime)
+func (ms *MikrotikService) GetSystemResource(routerID int) (*models.SystemResource, error) {
+	r, err := ms.runRead(routerID, "/system/resource/print")
 	if err != nil {
 		return nil, err
 	}
 
-	conn.mu.RLock()
-	defer conn.mu.RUnlock()
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("no system info")
+	}
+
+	re := r.Re[0]
+	return &models.SystemResource{
+		Version:      re.Map["version"],
+		Uptime:       re.Map["uptime"],
+		CPULoad:      re.Map["cpu-load"],
+		FreeMemory:   re.Map["free-memory"],
+		TotalMemory:  re.Map["total-memory"],
+		BoardName:    re.Map["board-name"],
+		Architecture: re.Map["architecture-name"],
+	}, nil
+}
+
+// ==================== Interface Methods ====================
+
+func (ms *MikrotikService) GetInterfaces(routerID int) ([]*models.Interface, error) {
+	return ms.GetInterfacesFiltered(routerID, nil)
+}
 
-	r, err := conn.Client.Run(
+// GetInterfacesFiltered - GetInterfaces dengan filter yang dijalankan di router (nil = semua)
+func (ms *MikrotikService) GetInterfacesFiltered(routerID int, filter *QueryFilter) ([]*models.Interface, error) {
+	sentence := append([]string{
 		"/interface/print",
-		"=.proplist=.id,name,type,running,disabled,rx-bytes,tx-bytes,rx-packets,tx-packets",
-	)
+		"=.proplist=.id,name,type,running,disabled,rx-bytes,tx-bytes,rx-packets,tx-packets,rx-error,tx-error,rx-drop,tx-drop,comment,mac-address,mtu,last-link-up-time",
+	}, filter.Words()...)
+	r, err := ms.runRead(routerID, sentence...)
 	if err != nil {
 		return nil, err
 	}
+	fcs := ms.getEthernetFCSErrors(routerID)
 
 	var interfaces []*models.Interface
 	for _, re := range r.Re {
+		if !filter.Match(re.Map) {
+			continue
+		}
 		iface := &models.Interface{
-			Name:      re.Map["name"],
-			Type:      re.Map["type"],
-			Running:   re.Map["running"] == "true",
-			Disabled:  re.Map["disabled"] == "true",
-			RxBytes:   re.Map["rx-bytes"],
-			TxBytes:   re.Map["tx-bytes"],
-			RxPackets: re.Map["rx-packets"],
-			TxPackets: re.Map["tx-packets"],
+			Name:       re.Map["name"],
+			Type:       re.Map["type"],
+			Running:    re.Map["running"] == "true",
+			Disabled:   re.Map["disabled"] == "true",
+			RxBytes:    re.Map["rx-bytes"],
+			TxBytes:    re.Map["tx-bytes"],
+			RxPackets:  re.Map["rx-packets"],
+			TxPackets:  re.Map["tx-packets"],
+			RxErrors:   re.Map["rx-error"],
+			TxErrors:   re.Map["tx-error"],
+			RxDrops:    re.Map["rx-drop"],
+			TxDrops:    re.Map["tx-drop"],
+			FCSErrors:  fcs[re.Map["name"]],
+			Comment:    re.Map["comment"],
+			MacAddress: re.Map["mac-address"],
+			MTU:        re.Map["mtu"],
+			LastLinkUp: re.Map["last-link-up-time"],
 		}
 		interfaces = append(interfaces, iface)
 	}
@@ -383,85 +713,79 @@ func (ms *MikrotikService) GetInterfaces(routerID int) ([]*models.Interface, err
 	return interfaces, nil
 }
 
-func (ms *MikrotikService) EnableInterface(routerID int, name string) error {
-	conn, err := ms.GetConnection(routerID)
-	if err != nil {
-		return err
-	}
-
-	conn.mu.Lock()
-	defer conn.mu.Unlock()
-
-	r, err := conn.Client.Run("/interface/print", fmt.Sprintf("?name=%s", name))
-	if err != nil {
-		return err
-	}
-
-	if len(r.Re) == 0 {
-		return fmt.Errorf("interface %s not found", name)
-	}
-
-	id := r.Re[0].Map[".id"]
-	_, err = conn.Client.Run("/interface/set",
-		fmt.Sprintf("=.id=%s", id),
-		"=disabled=false")
+func (ms *MikrotikService) EnableInterface(routerID int, name string, dryRun bool) (*models.CommandPlan, error) {
+	return ms.setInterfaceDisabled(routerID, name, false, dryRun)
+}
 
-	return err
+func (ms *MikrotikService) DisableInterface(routerID int, name string, dryRun bool) (*models.CommandPlan, error) {
+	return ms.setInterfaceDisabled(routerID, name, true, dryRun)
 }
 
-func (ms *MikrotikService) DisableInterface(routerID int, name string) error {
+// setInterfaceDisabled - Resolve interface by name lalu set disabled (atau hanya plan jika dryRun)
+func (ms *MikrotikService) setInterfaceDisabled(routerID int, name string, disabled bool, dryRun bool) (*models.CommandPlan, error) {
 	conn, err := ms.GetConnection(routerID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
-	r, err := conn.Client.Run("/interface/print", fmt.Sprintf("?name=%s", name))
+	r, err := conn.Run("/interface/print", fmt.Sprintf("?name=%s", name))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(r.Re) == 0 {
-		return fmt.Errorf("interface %s not found", name)
+		return nil, fmt.Errorf("interface %s not found", name)
+	}
+
+	action := "enable_interface"
+	if disabled {
+		action = "disable_interface"
 	}
 
 	id := r.Re[0].Map[".id"]
-	_, err = conn.Client.Run("/interface/set",
+	plan := newCommandPlan(routerID, action, dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("interface %s exists (%s)", name, id))
+	plan.Commands = append(plan.Commands, []string{
+		"/interface/set",
 		fmt.Sprintf("=.id=%s", id),
-		"=disabled=true")
+		fmt.Sprintf("=disabled=%t", disabled),
+	})
 
-	return err
+	return plan, executePlan(conn, plan)
 }
 
 // ==================== Address Methods ====================
 
 func (ms *MikrotikService) GetAddresses(routerID int) ([]*models.Address, error) {
-	conn, err := ms.GetConnection(routerID)
-	if err != nil {
-		return nil, err
-	}
-
-	conn.mu.RLock()
-	defer conn.mu.RUnlock()
+	return ms.GetAddressesFiltered(routerID, nil)
+}
 
-	r, err := conn.Client.Run(
+// GetAddressesFiltered - GetAddresses dengan filter yang dijalankan di router (nil = semua)
+func (ms *MikrotikService) GetAddressesFiltered(routerID int, filter *QueryFilter) ([]*models.Address, error) {
+	sentence := append([]string{
 		"/ip/address/print",
-		"=.proplist=.id,address,interface,network,disabled",
-	)
+		"=.proplist=.id,address,interface,network,disabled,dynamic",
+	}, filter.Words()...)
+	r, err := ms.runRead(routerID, sentence...)
 	if err != nil {
 		return nil, err
 	}
 
 	var addresses []*models.Address
 	for _, re := range r.Re {
+		if !filter.Match(re.Map) {
+			continue
+		}
 		addr := &models.Address{
 			ID:        re.Map[".id"],
 			Address:   re.Map["address"],
 			Interface: re.Map["interface"],
 			Network:   re.Map["network"],
 			Disabled:  re.Map["disabled"] == "true",
+			Dynamic:   re.Map["dynamic"] == "true",
 		}
 		addresses = append(addresses, addr)
 	}
@@ -469,58 +793,156 @@ func (ms *MikrotikService) GetAddresses(routerID int) ([]*models.Address, error)
 	return addresses, nil
 }
 
-func (ms *MikrotikService) AddAddress(routerID int, iface, address string) error {
+func (ms *MikrotikService) AddAddress(routerID int, iface, address string, dryRun bool) (*models.CommandPlan, error) {
+	if _, _, err := net.ParseCIDR(address); err != nil {
+		return nil, fmt.Errorf("invalid address %s: must be in CIDR notation", address)
+	}
+
 	conn, err := ms.GetConnection(routerID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
-	_, err = conn.Client.Run("/ip/address/add",
+	r, err := conn.Run("/interface/print", fmt.Sprintf("?name=%s", iface), "=.proplist=.id")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("interface %s not found", iface)
+	}
+
+	r, err = conn.Run("/ip/address/print", fmt.Sprintf("?address=%s", address), "=.proplist=.id,interface")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) > 0 {
+		return nil, fmt.Errorf("address %s already exists on interface %s", address, r.Re[0].Map["interface"])
+	}
+
+	plan := newCommandPlan(routerID, "add_address", dryRun)
+	plan.Checks = append(plan.Checks,
+		fmt.Sprintf("interface %s exists", iface),
+		fmt.Sprintf("address %s not yet assigned", address))
+	plan.Commands = append(plan.Commands, []string{
+		"/ip/address/add",
 		fmt.Sprintf("=address=%s", address),
-		fmt.Sprintf("=interface=%s", iface))
+		fmt.Sprintf("=interface=%s", iface),
+	})
 
-	return err
+	return plan, executePlan(conn, plan)
 }
 
-func (ms *MikrotikService) RemoveAddress(routerID int, id string) error {
+func (ms *MikrotikService) RemoveAddress(routerID int, id string, dryRun bool) (*models.CommandPlan, error) {
 	conn, err := ms.GetConnection(routerID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
-	_, err = conn.Client.Run("/ip/address/remove",
-		fmt.Sprintf("=.id=%s", id))
+	r, err := conn.Run("/ip/address/print", fmt.Sprintf("?.id=%s", id), "=.proplist=.id,address,interface")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("address %s not found", id)
+	}
+
+	plan := newCommandPlan(routerID, "remove_address", dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("address %s exists (%s on %s)",
+		id, r.Re[0].Map["address"], r.Re[0].Map["interface"]))
+	plan.Commands = append(plan.Commands, []string{
+		"/ip/address/remove",
+		fmt.Sprintf("=.id=%s", id),
+	})
+
+	return plan, executePlan(conn, plan)
+}
+
+// ==================== Firewall Address List Methods ====================
 
-	return err
+// GetAddressListEntries - Entry /ip/firewall/address-list dengan filter di router (nil = semua)
+func (ms *MikrotikService) GetAddressListEntries(routerID int, filter *QueryFilter) ([]*models.AddressListEntry, error) {
+	sentence := append([]string{
+		"/ip/firewall/address-list/print",
+		"=.proplist=.id,list,address,comment,timeout,disabled,dynamic",
+	}, filter.Words()...)
+	r, err := ms.runRead(routerID, sentence...)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*models.AddressListEntry{}
+	for _, re := range r.Re {
+		if !filter.Match(re.Map) {
+			continue
+		}
+		entries = append(entries, &models.AddressListEntry{
+			ID:       re.Map[".id"],
+			List:     re.Map["list"],
+			Address:  re.Map["address"],
+			Comment:  re.Map["comment"],
+			Timeout:  re.Map["timeout"],
+			Disabled: re.Map["disabled"] == "true",
+			Dynamic:  re.Map["dynamic"] == "true",
+		})
+	}
+	return entries, nil
 }
 
 // ==================== Queue Methods ====================
 
 func (ms *MikrotikService) GetQueues(routerID int) ([]*models.Queue, error) {
-	conn, err := ms.GetConnection(routerID)
+	return ms.GetQueuesFiltered(routerID, nil)
+}
+
+// GetQueuesFiltered - GetQueues dengan filter yang dijalankan di router (nil = semua)
+func (ms *MikrotikService) GetQueuesFiltered(routerID int, filter *QueryFilter) ([]*models.Queue, error) {
+	sentence := append([]string{
+		"/queue/simple/print",
+		"=.proplist=.id,name,target,max-limit,burst-limit,disabled",
+	}, filter.Words()...)
+	r, err := ms.runRead(routerID, sentence...)
 	if err != nil {
 		return nil, err
 	}
+	return queuesFromReply(r, filter), nil
+}
 
-	conn.mu.RLock()
-	defer conn.mu.RUnlock()
+// GetQueuesByTarget - Simple queue dengan target tertentu, difilter di RouterOS (?target=)
+// supaya router dengan ribuan queue tidak perlu mengirim seluruh daftar. IP tanpa prefix
+// dicocokkan sebagai /32 (/128 untuk IPv6) sesuai format target RouterOS. Queue multi-target
+// hanya cocok jika target-nya persis sama.
+func (ms *MikrotikService) GetQueuesByTarget(routerID int, target string) ([]*models.Queue, error) {
+	if ip := net.ParseIP(target); ip != nil {
+		if ip.To4() != nil {
+			target = ip.String() + "/32"
+		} else {
+			target = ip.String() + "/128"
+		}
+	}
 
-	r, err := conn.Client.Run(
+	r, err := ms.runRead(routerID,
 		"/queue/simple/print",
 		"=.proplist=.id,name,target,max-limit,burst-limit,disabled",
+		fmt.Sprintf("?target=%s", target),
 	)
 	if err != nil {
 		return nil, err
 	}
+	return queuesFromReply(r, nil), nil
+}
 
+func queuesFromReply(r *routeros.Reply, filter *QueryFilter) []*models.Queue {
 	var queues []*models.Queue
 	for _, re := range r.Re {
+		if !filter.Match(re.Map) {
+			continue
+		}
 		queue := &models.Queue{
 			ID:         re.Map[".id"],
 			Name:       re.Map["name"],
@@ -531,40 +953,64 @@ func (ms *MikrotikService) GetQueues(routerID int) ([]*models.Queue, error) {
 		}
 		queues = append(queues, queue)
 	}
-
-	return queues, nil
+	return queues
 }
 
-func (ms *MikrotikService) AddQueue(routerID int, name, target, maxLimit string) error {
+func (ms *MikrotikService) AddQueue(routerID int, name, target, maxLimit string, dryRun bool) (*models.CommandPlan, error) {
 	conn, err := ms.GetConnection(routerID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
-	_, err = conn.Client.Run("/queue/simple/add",
+	r, err := conn.Run("/queue/simple/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) > 0 {
+		return nil, fmt.Errorf("queue %s already exists", name)
+	}
+
+	plan := newCommandPlan(routerID, "add_queue", dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("queue name %s is unique", name))
+	plan.Commands = append(plan.Commands, []string{
+		"/queue/simple/add",
 		fmt.Sprintf("=name=%s", name),
 		fmt.Sprintf("=target=%s", target),
-		fmt.Sprintf("=max-limit=%s", maxLimit))
+		fmt.Sprintf("=max-limit=%s", maxLimit),
+	})
 
-	return err
+	return plan, executePlan(conn, plan)
 }
 
-func (ms *MikrotikService) RemoveQueue(routerID int, id string) error {
+// RemoveQueue - Hapus simple queue by .id, return juga nama queue yang dihapus
+func (ms *MikrotikService) RemoveQueue(routerID int, id string, dryRun bool) (*models.CommandPlan, string, error) {
 	conn, err := ms.GetConnection(routerID)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
 
-	_, err = conn.Client.Run("/queue/simple/remove",
-		fmt.Sprintf("=.id=%s", id))
+	r, err := conn.Run("/queue/simple/print", fmt.Sprintf("?.id=%s", id), "=.proplist=.id,name")
+	if err != nil {
+		return nil, "", err
+	}
+	if len(r.Re) == 0 {
+		return nil, "", fmt.Errorf("queue %s not found", id)
+	}
+
+	plan := newCommandPlan(routerID, "remove_queue", dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("queue %s exists (%s)", id, r.Re[0].Map["name"]))
+	plan.Commands = append(plan.Commands, []string{
+		"/queue/simple/remove",
+		fmt.Sprintf("=.id=%s", id),
+	})
 
-	return err
+	return plan, r.Re[0].Map["name"], executePlan(conn, plan)
 }
 
 // ==================== Traffic Monitoring ====================
@@ -581,6 +1027,12 @@ func (ms *MikrotikService) MonitorInterfaceTraffic(routerID int, interfaceName s
 		return err
 	}
 
+	if conn.IsVirtual() {
+		go conn.sim.stream(context.Background(), interfaceName, callback)
+		log.Printf("[MONITOR] Simulated monitor started for virtual router %d, interface %s", routerID, interfaceName)
+		return nil
+	}
+
 	// ✅ JANGAN LOCK DI SINI - Listen() akan handle concurrent access
 	log.Printf("[MONITOR] Calling RouterOS Listen command...")
 	
@@ -596,6 +1048,7 @@ func (ms *MikrotikService) MonitorInterfaceTraffic(routerID int, interfaceName s
 	log.Printf("[MONITOR] Listen command successful, starting goroutine...")
 
 	go func() {
+		defer Recover("traffic-monitor")
 		defer func() {
 			log.Printf("[MONITOR] Goroutine stopping, canceling listener...")
 			listen.Cancel()
@@ -684,7 +1137,7 @@ func (ms *MikrotikService) GetInterfaceTrafficOnce(routerID int, interfaceName s
 	defer conn.mu.Unlock()
 
 	log.Printf("[TRAFFIC-ONCE] Executing monitor-traffic command...")
-	r, err := conn.Client.RunArgs([]string{
+	r, err := conn.RunArgs([]string{
 		"/interface/monitor-traffic",
 		fmt.Sprintf("=interface=%s", interfaceName),
 		"=once=",
@@ -701,7 +1154,7 @@ func (ms *MikrotikService) GetInterfaceTrafficOnce(routerID int, interfaceName s
 		
 		// Try to list available interfaces
 		log.Printf("[TRAFFIC-ONCE] Attempting to list available interfaces...")
-		ifaceResult, ifaceErr := conn.Client.Run("/interface/print", "=.proplist=name")
+		ifaceResult, ifaceErr := conn.Run("/interface/print", "=.proplist=name")
 		if ifaceErr == nil && len(ifaceResult.Re) > 0 {
 			var names []string
 			for _, re := range ifaceResult.Re {
@@ -742,7 +1195,25 @@ func (ms *MikrotikService) GetInterfaceTrafficOnce(routerID int, interfaceName s
 // ==================== ADD TO mikrotik_service.go ====================
 // Replace MonitorInterfaceTraffic with this version that supports context
 
-func (ms *MikrotikService) MonitorInterfaceTrafficWithContext(ctx context.Context, routerID int, interfaceName string, callback func(TrafficStats)) error {
+// Status lifecycle stream monitor, diteruskan ke client WebSocket
+const (
+	StreamStarted = "stream_started"
+	StreamError   = "stream_error"
+	StreamEnded   = "stream_ended"
+	StreamResumed = "stream_resumed"
+	RouterOffline = "router_offline"
+	StreamQueued  = "stream_queued" // batas stream router penuh, menunggu slot
+)
+
+// MonitorInterfaceTrafficWithContext - Stream monitor-traffic sampai ctx selesai. Jika stream
+// putus karena koneksi router drop, monitor menunggu reconnect lalu mulai ulang otomatis.
+// onStatus (boleh nil) menerima status lifecycle stream beserta alasannya.
+func (ms *MikrotikService) MonitorInterfaceTrafficWithContext(ctx context.Context, routerID int, interfaceName string, callback func(TrafficStats), onStatus func(status, reason string)) error {
+	if onStatus == nil {
+		onStatus = func(string, string) {}
+	}
+
+
 	log.Printf("[MONITOR] Starting monitor for router %d, interface %s", routerID, interfaceName)
 	
 	conn, err := ms.GetConnection(routerID)
@@ -751,105 +1222,202 @@ func (ms *MikrotikService) MonitorInterfaceTrafficWithContext(ctx context.Contex
 		return err
 	}
 
+	if conn.IsVirtual() {
+		go conn.sim.stream(ctx, interfaceName, callback)
+		log.Printf("[MONITOR] Simulated monitor started for virtual router %d, interface %s", routerID, interfaceName)
+		onStatus(StreamStarted, "")
+		return nil
+	}
+
+	release, err := ms.acquireListen(ctx, routerID, func() { onStatus(StreamQueued, "") })
+	if err != nil {
+		return err
+	}
+
 	log.Printf("[MONITOR] Calling RouterOS Listen command...")
 	
-	listen, err := conn.Client.Listen(
-		"/interface/monitor-traffic",
-		fmt.Sprintf("=interface=%s", interfaceName),
-	)
+	listen, err := listenTraffic(conn, interfaceName)
 	if err != nil {
+		release()
 		log.Printf("[MONITOR] Listen command failed: %v", err)
 		return fmt.Errorf("failed to start monitoring: %v", err)
 	}
 
 	log.Printf("[MONITOR] Listen command successful, starting goroutine...")
 
-	go func() {
-		defer func() {
-			log.Printf("[MONITOR] Canceling listener for router %d, interface %s", routerID, interfaceName)
-			listen.Cancel()
-		}()
+	onStatus(StreamStarted, "")
 
-		updateCount := 0
-		log.Printf("[MONITOR] Waiting for data from RouterOS...")
-		
-		for {
-			select {
-			case <-ctx.Done():
-				log.Printf("[MONITOR] Context canceled for router %d, interface %s - stopping monitoring", routerID, interfaceName)
+	go func() {
+		defer Recover("traffic-monitor")
+		defer release()
+		for consumeTraffic(ctx, listen, routerID, interfaceName, callback) {
+			// Command diakhiri router (trap / !done): stream selesai, tidak di-resume
+			var deviceErr *routeros.DeviceError
+			if errors.As(listen.Err(), &deviceErr) {
+				onStatus(StreamError, deviceErr.Error())
+				onStatus(StreamEnded, "")
 				return
-				
-			case sentence, more := <-listen.Chan():
-				if !more {
-					log.Printf("[MONITOR] Channel closed for router %d, interface %s", routerID, interfaceName)
-					return
-				}
+			}
+			if listen.Err() == nil && listen.Done != nil {
+				onStatus(StreamEnded, "command finished by router")
+				return
+			}
 
-				updateCount++
-				
-				// Debug: Log first few sentences
-				// if updateCount <= 5 {
-				// 	log.Printf("[MONITOR] Update #%d - Received sentence: Word=%s", updateCount, sentence.Word)
-				// 	if sentence.Word == "!re" {
-				// 		log.Printf("[MONITOR]   Data: rx-bytes=%s, tx-bytes=%s, rx-bps=%s, tx-bps=%s",
-				// 			sentence.Map["rx-bytes"],
-				// 			sentence.Map["tx-bytes"],
-				// 			sentence.Map["rx-bits-per-second"],
-				// 			sentence.Map["tx-bits-per-second"])
-				// 	}
-				// }
-
-				if sentence.Word == "!trap" {
-					log.Printf("[MONITOR] RouterOS trap/error: %+v", sentence.Map)
-					continue
+			// Koneksi putus tapi subscriber masih ada: tunggu reconnect
+			reason := "connection lost"
+			if listen.Err() != nil {
+				reason = listen.Err().Error()
+			}
+			onStatus(RouterOffline, reason)
+
+			conn, listen = ms.waitListenResume(ctx, conn, "interface "+interfaceName,
+				func(conn *MikrotikConnection) (*routeros.ListenReply, error) { return listenTraffic(conn, interfaceName) })
+			if listen == nil {
+				if conn != nil && conn.IsVirtual() {
+					// Router kini virtual: lanjut dari simulator, slot Listen tidak dipakai lagi
+					release()
+					log.Printf("[MONITOR] Router %d is now virtual, switching interface %s to simulator", routerID, interfaceName)
+					onStatus(StreamResumed, "")
+					conn.sim.stream(ctx, interfaceName, callback)
 				}
+				return
+			}
+			log.Printf("[MONITOR] ✓ Stream resumed for router %d, interface %s", routerID, interfaceName)
+			onStatus(StreamResumed, "")
+		}
+	}()
 
-				if sentence.Word == "!done" {
-					log.Printf("[MONITOR] RouterOS sent !done")
-					continue
-				}
+	log.Printf("[MONITOR] Monitor setup complete for router %d, interface %s", routerID, interfaceName)
+	return nil
+}
 
-				if sentence.Word != "!re" {
-					if updateCount <= 5 {
-						log.Printf("[MONITOR] Skipping sentence with word: %s", sentence.Word)
-					}
-					continue
-				}
+// listenTraffic - Mulai /interface/monitor-traffic via Listen (tanpa lock, lihat catatan di bawah)
+func listenTraffic(conn *MikrotikConnection, interfaceName string) (*routeros.ListenReply, error) {
+	return conn.Client.Listen(
+		"/interface/monitor-traffic",
+		fmt.Sprintf("=interface=%s", interfaceName),
+	)
+}
 
-				stats := TrafficStats{
-					RouterID:      routerID,
-					InterfaceName: interfaceName,
-					RxBytes:       sentence.Map["rx-bytes"],
-					TxBytes:       sentence.Map["tx-bytes"],
-					RxPackets:     sentence.Map["rx-packets"],
-					TxPackets:     sentence.Map["tx-packets"],
-					RxBitsPerSec:  sentence.Map["rx-bits-per-second"],
-					TxBitsPerSec:  sentence.Map["tx-bits-per-second"],
-					Timestamp:     time.Now(),
-				}
+// consumeTraffic - Teruskan sentence monitor-traffic ke callback. Return true jika
+// channel tertutup sementara ctx masih aktif (stream putus, perlu di-resume).
+func consumeTraffic(ctx context.Context, listen *routeros.ListenReply, routerID int, interfaceName string, callback func(TrafficStats)) bool {
+	defer func() {
+		log.Printf("[MONITOR] Canceling listener for router %d, interface %s", routerID, interfaceName)
+		listen.Cancel()
+	}()
 
-				if updateCount <= 3 {
-					log.Printf("[MONITOR] Calling callback with stats...")
-				}
+	updateCount := 0
+	log.Printf("[MONITOR] Waiting for data from RouterOS...")
+	
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[MONITOR] Context canceled for router %d, interface %s - stopping monitoring", routerID, interfaceName)
+			return false
+			
+		case sentence, more := <-listen.Chan():
+			if !more {
+				log.Printf("[MONITOR] Channel closed for router %d, interface %s", routerID, interfaceName)
+				return ctx.Err() == nil
+			}
 
-				// Check context before calling callback
-				select {
-				case <-ctx.Done():
-					log.Printf("[MONITOR] Context canceled before callback")
-					return
-				default:
-					callback(stats)
-				}
+			updateCount++
+			
+			// Debug: Log first few sentences
+			// if updateCount <= 5 {
+			// 	log.Printf("[MONITOR] Update #%d - Received sentence: Word=%s", updateCount, sentence.Word)
+			// 	if sentence.Word == "!re" {
+			// 		log.Printf("[MONITOR]   Data: rx-bytes=%s, tx-bytes=%s, rx-bps=%s, tx-bps=%s",
+			// 			sentence.Map["rx-bytes"],
+			// 			sentence.Map["tx-bytes"],
+			// 			sentence.Map["rx-bits-per-second"],
+			// 			sentence.Map["tx-bits-per-second"])
+			// 	}
+			// }
+
+			if sentence.Word == "!trap" {
+				log.Printf("[MONITOR] RouterOS trap/error: %+v", sentence.Map)
+				continue
+			}
 
-				if updateCount == 5 {
-					log.Printf("[MONITOR] (Further detailed logs suppressed, monitoring continues...)")
+			if sentence.Word == "!done" {
+				log.Printf("[MONITOR] RouterOS sent !done")
+				continue
+			}
+
+			if sentence.Word != "!re" {
+				if updateCount <= 5 {
+					log.Printf("[MONITOR] Skipping sentence with word: %s", sentence.Word)
 				}
+				continue
+			}
+
+			stats := TrafficStats{
+				RouterID:      routerID,
+				InterfaceName: interfaceName,
+				RxBytes:       sentence.Map["rx-bytes"],
+				TxBytes:       sentence.Map["tx-bytes"],
+				RxPackets:     sentence.Map["rx-packets"],
+				TxPackets:     sentence.Map["tx-packets"],
+				RxBitsPerSec:  sentence.Map["rx-bits-per-second"],
+				TxBitsPerSec:  sentence.Map["tx-bits-per-second"],
+				Timestamp:     time.Now(),
+			}
+
+			if updateCount <= 3 {
+				log.Printf("[MONITOR] Calling callback with stats...")
+			}
+
+			// Check context before calling callback
+			select {
+			case <-ctx.Done():
+				log.Printf("[MONITOR] Context canceled before callback")
+				return false
+			default:
+				callback(stats)
+			}
+
+			if updateCount == 5 {
+				log.Printf("[MONITOR] (Further detailed logs suppressed, monitoring continues...)")
 			}
 		}
-	}()
+	}
+}
 
-	log.Printf("[MONITOR] Monitor setup complete for router %d, interface %s", routerID, interfaceName)
-	return nil
+// waitListenResume - Tunggu sampai router punya koneksi sehat yang baru (hasil reconnect),
+// lalu buka ulang stream lewat listen. Return nil listen jika ctx selesai lebih dulu (conn nil)
+// atau jika koneksi baru dilayani simulator (conn virtual, tanpa Client untuk Listen).
+func (ms *MikrotikService) waitListenResume(ctx context.Context, old *MikrotikConnection, stream string, listen func(*MikrotikConnection) (*routeros.ListenReply, error)) (*MikrotikConnection, *routeros.ListenReply) {
+	log.Printf("[MONITOR] Waiting for router %d to reconnect (%s)...", old.RouterID, stream)
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		case <-ticker.C:
+		}
+
+		ms.mu.RLock()
+		conn := ms.connections[old.RouterID]
+		ms.mu.RUnlock()
+		if conn == nil || conn == old || !conn.IsHealthy {
+			continue
+		}
+		if conn.IsVirtual() {
+			return conn, nil
+		}
+
+		reply, err := listen(conn)
+		if err != nil {
+			log.Printf("[MONITOR] Resume listen failed for router %d (%s): %v", old.RouterID, stream, err)
+			continue
+		}
+		return conn, reply
+	}
 }
 
 // Keep the old method for backward compatibility
@@ -867,7 +1435,7 @@ func (ms *MikrotikService) Close() error {
 	defer ms.mu.Unlock()
 
 	for routerID, conn := range ms.connections {
-		if err := conn.Client.Close(); err != nil {
+		if err := conn.close(); err != nil {
 			log.Printf("Error closing connection to router %d: %v", routerID, err)
 		}
 	}
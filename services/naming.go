@@ -0,0 +1,123 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// Tipe objek yang bisa diberi naming policy
+const (
+	NamingQueue           = "queue"
+	NamingPPPSecret       = "ppp_secret"
+	NamingFirewallComment = "firewall_comment"
+)
+
+// layerCommentPrefix - Comment firewall yang dibangkitkan layer sendiri (suspend, quota);
+// formatnya tetap sehingga tidak ikut dicek naming policy
+const layerCommentPrefix = "mikrotik-layer:"
+
+// namingFirewallMenus - Menu firewall yang comment-nya dicek report naming policy
+var namingFirewallMenus = []string{
+	"/ip/firewall/filter",
+	"/ip/firewall/nat",
+	"/ip/firewall/mangle",
+	"/ip/firewall/address-list",
+}
+
+// NamingPolicy - Regex nama per tipe objek. Tipe tanpa regex bebas; nil policy = tanpa policy.
+type NamingPolicy struct {
+	patterns map[string]*regexp.Regexp
+}
+
+// NewNamingPolicy - Compile regex per tipe objek (regex kosong dilewati)
+func NewNamingPolicy(patterns map[string]string) (*NamingPolicy, error) {
+	p := &NamingPolicy{patterns: make(map[string]*regexp.Regexp)}
+	for kind, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", kind, err)
+		}
+		p.patterns[kind] = re
+	}
+	return p, nil
+}
+
+// Pattern - Regex policy tipe objek ("" = tanpa policy)
+func (p *NamingPolicy) Pattern(kind string) string {
+	if p == nil || p.patterns[kind] == nil {
+		return ""
+	}
+	return p.patterns[kind].String()
+}
+
+// Allows - True jika nama sesuai policy tipe objek (atau tipe tsb tanpa policy).
+// Comment firewall buatan layer selalu lolos.
+func (p *NamingPolicy) Allows(kind, name string) bool {
+	if p == nil || p.patterns[kind] == nil {
+		return true
+	}
+	if kind == NamingFirewallComment && strings.HasPrefix(name, layerCommentPrefix) {
+		return true
+	}
+	return p.patterns[kind].MatchString(name)
+}
+
+// NamingReport - Queue, PPP secret dan comment firewall (yang tidak kosong) di router yang
+// tidak sesuai policy. Tipe tanpa policy tidak dibaca dari router.
+func (ms *MikrotikService) NamingReport(routerID int, policy *NamingPolicy) (*models.NamingReport, error) {
+	report := &models.NamingReport{
+		RouterID:   routerID,
+		Policies:   make(map[string]string),
+		Violations: []*models.NamingViolation{},
+	}
+	for _, kind := range []string{NamingQueue, NamingPPPSecret, NamingFirewallComment} {
+		if pattern := policy.Pattern(kind); pattern != "" {
+			report.Policies[kind] = pattern
+		}
+	}
+
+	check := func(kind, menu, field string) error {
+		if policy.Pattern(kind) == "" {
+			return nil
+		}
+		r, err := ms.runRead(routerID, menu+"/print", "=.proplist=.id,"+field)
+		if err != nil {
+			return err
+		}
+		for _, re := range r.Re {
+			value := re.Map[field]
+			if kind == NamingFirewallComment && value == "" {
+				continue
+			}
+			report.Checked++
+			if policy.Allows(kind, value) {
+				continue
+			}
+			violation := &models.NamingViolation{Type: kind, ID: re.Map[".id"], Value: value, Pattern: policy.Pattern(kind)}
+			if kind == NamingFirewallComment {
+				violation.Menu = menu
+			}
+			report.Violations = append(report.Violations, violation)
+		}
+		return nil
+	}
+
+	if err := check(NamingQueue, "/queue/simple", "name"); err != nil {
+		return nil, err
+	}
+	if err := check(NamingPPPSecret, "/ppp/secret", "name"); err != nil {
+		return nil, err
+	}
+	for _, menu := range namingFirewallMenus {
+		if err := check(NamingFirewallComment, menu, "comment"); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
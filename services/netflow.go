@@ -0,0 +1,418 @@
+package services
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// FlowBucket - Resolusi agregasi flow records di database
+const FlowBucket = 5 * time.Minute
+
+// ephemeralPortStart - Port >= nilai ini dianggap port klien dan disimpan sebagai 0
+// supaya jumlah baris per bucket tidak meledak
+const ephemeralPortStart = 32768
+
+// Information element NetFlow v9 / IPFIX yang dipakai (ID sama di kedua versi)
+const (
+	ieBytes       = 1
+	iePackets     = 2
+	ieProtocol    = 4
+	ieSrcPort     = 7
+	ieSrcIPv4     = 8
+	ieDstPort     = 11
+	ieDstIPv4     = 12
+	ieSrcIPv6     = 27
+	ieDstIPv6     = 28
+	ipfixVarLen   = 65535
+	ipfixEntBit   = 0x8000
+	netflowV9     = 9
+	ipfixVersion  = 10
+	v9HeaderLen   = 20
+	ipfixHdrLen   = 16
+	setHeaderLen  = 4
+	minDataSetID  = 256
+	v9TemplateID  = 0
+	ipfixTemplate = 2
+)
+
+// Batas state template supaya exporter (termasuk yang baru dihapus dari inventory)
+// tidak bisa menumbuhkan memori collector tanpa batas
+const (
+	maxTemplatesPerExporter = 128
+	exporterExpiry          = time.Hour
+	maxUnknownLogged        = 256
+)
+
+// exporterState - Jumlah template tersimpan dan kapan exporter terakhir mengirim
+type exporterState struct {
+	templates int
+	lastSeen  time.Time
+}
+
+type templateKey struct {
+	exporter string
+	domain   uint32
+	id       uint16
+}
+
+type templateField struct {
+	id     uint16
+	length uint16
+}
+
+type flowKey struct {
+	routerID int
+	bucket   int64
+	src, dst string
+	protocol int
+	srcPort  int
+	dstPort  int
+}
+
+// FlowCollector - Collector NetFlow v9/IPFIX embedded. Record di-agregasi di memori
+// per bucket 5 menit lalu di-flush periodik ke tabel flow_records.
+type FlowCollector struct {
+	addr      string
+	resolver  *routerResolver
+	repo      *repository.FlowRepository
+	retention time.Duration
+
+	mu        sync.Mutex
+	templates map[templateKey][]templateField
+	exporters map[string]*exporterState
+	pending   map[flowKey]*models.FlowRecord
+	unknown   map[string]bool // exporter tak dikenal yang sudah di-log
+}
+
+func NewFlowCollector(addr string, routerRepo *repository.RouterRepository, repo *repository.FlowRepository, retention time.Duration) *FlowCollector {
+	return &FlowCollector{
+		addr:      addr,
+		resolver:  newRouterResolver(routerRepo, "NETFLOW"),
+		repo:      repo,
+		retention: retention,
+		templates: make(map[templateKey][]templateField),
+		exporters: make(map[string]*exporterState),
+		pending:   make(map[flowKey]*models.FlowRecord),
+		unknown:   make(map[string]bool),
+	}
+}
+
+// Run - Terima datagram flow sampai listener error (blocking)
+func (c *FlowCollector) Run() error {
+	pc, err := net.ListenPacket("udp", c.addr)
+	if err != nil {
+		return err
+	}
+	defer pc.Close()
+
+	log.Printf("[NETFLOW] Listening on udp %s", c.addr)
+	c.resolver.start()
+
+	go Supervise("netflow-flush", c.flushRoutine)
+
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		host, _, _ := net.SplitHostPort(addr.String())
+		if err := c.handle(host, buf[:n]); err != nil {
+			log.Printf("[NETFLOW] Invalid packet from %s: %v", host, err)
+		}
+	}
+}
+
+// handle - Parse satu datagram export (v9 atau IPFIX); panic di-recover per datagram
+func (c *FlowCollector) handle(exporter string, packet []byte) error {
+	// Bug parser cukup membuang satu datagram, bukan menghentikan collector
+	defer Recover("netflow-handle")
+
+	if len(packet) < 2 {
+		return fmt.Errorf("packet too short")
+	}
+
+	var (
+		domain    uint32
+		offset    int
+		templates uint16
+	)
+
+	switch version := binary.BigEndian.Uint16(packet); version {
+	case netflowV9:
+		if len(packet) < v9HeaderLen {
+			return fmt.Errorf("v9 header too short")
+		}
+		domain = binary.BigEndian.Uint32(packet[16:20])
+		offset = v9HeaderLen
+		templates = v9TemplateID
+	case ipfixVersion:
+		if len(packet) < ipfixHdrLen {
+			return fmt.Errorf("ipfix header too short")
+		}
+		length := int(binary.BigEndian.Uint16(packet[2:4]))
+		if length < ipfixHdrLen || length > len(packet) {
+			return fmt.Errorf("invalid ipfix message length %d", length)
+		}
+		packet = packet[:length]
+		domain = binary.BigEndian.Uint32(packet[12:16])
+		offset = ipfixHdrLen
+		templates = ipfixTemplate
+	default:
+		return fmt.Errorf("unsupported version %d", version)
+	}
+
+	routerID, known := c.resolver.match(exporter)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !known {
+		// Template dari exporter tak dikenal juga tidak disimpan
+		if !c.unknown[exporter] && len(c.unknown) < maxUnknownLogged {
+			c.unknown[exporter] = true
+			log.Printf("[NETFLOW] Ignoring flows from unknown exporter %s", exporter)
+		}
+		return nil
+	}
+
+	state, ok := c.exporters[exporter]
+	if !ok {
+		state = &exporterState{}
+		c.exporters[exporter] = state
+	}
+	state.lastSeen = time.Now()
+
+	bucket := time.Now().Truncate(FlowBucket).Unix()
+	ipfix := templates == ipfixTemplate
+
+	for offset+setHeaderLen <= len(packet) {
+		setID := binary.BigEndian.Uint16(packet[offset:])
+		setLen := int(binary.BigEndian.Uint16(packet[offset+2:]))
+		if setLen < setHeaderLen || offset+setLen > len(packet) {
+			return fmt.Errorf("invalid set length %d", setLen)
+		}
+		body := packet[offset+setHeaderLen : offset+setLen]
+		offset += setLen
+
+		switch {
+		case setID == templates:
+			c.parseTemplates(state, exporter, domain, body, ipfix)
+		case setID >= minDataSetID:
+			fields, ok := c.templates[templateKey{exporter, domain, setID}]
+			if !ok {
+				continue // template belum diterima
+			}
+			c.parseData(routerID, bucket, fields, body)
+		}
+		// options template / options data diabaikan
+	}
+
+	return nil
+}
+
+// parseTemplates - Simpan definisi template dari template set
+// (maksimal maxTemplatesPerExporter template per exporter)
+func (c *FlowCollector) parseTemplates(state *exporterState, exporter string, domain uint32, body []byte, ipfix bool) {
+	for len(body) >= 4 {
+		id := binary.BigEndian.Uint16(body)
+		count := int(binary.BigEndian.Uint16(body[2:]))
+		body = body[4:]
+
+		key := templateKey{exporter, domain, id}
+		_, exists := c.templates[key]
+		if count == 0 {
+			if exists {
+				delete(c.templates, key) // IPFIX template withdrawal
+				state.templates--
+			}
+			continue
+		}
+
+		fields := make([]templateField, 0, count)
+		for i := 0; i < count; i++ {
+			if len(body) < 4 {
+				return
+			}
+			field := templateField{
+				id:     binary.BigEndian.Uint16(body),
+				length: binary.BigEndian.Uint16(body[2:]),
+			}
+			body = body[4:]
+
+			if ipfix && field.id&ipfixEntBit != 0 {
+				// Enterprise-specific: lewati enterprise number, field tidak dipakai
+				if len(body) < 4 {
+					return
+				}
+				body = body[4:]
+				field.id = 0
+			}
+			fields = append(fields, field)
+		}
+		if !exists {
+			if state.templates >= maxTemplatesPerExporter {
+				continue
+			}
+			state.templates++
+		}
+		c.templates[key] = fields
+	}
+}
+
+// parseData - Decode record data dan tambahkan ke agregat pending
+func (c *FlowCollector) parseData(routerID int, bucket int64, fields []templateField, body []byte) {
+	for len(body) > 0 {
+		rec := &models.FlowRecord{RouterID: routerID, Flows: 1}
+		consumed, ok := decodeRecord(fields, body, rec)
+		if !ok {
+			return // sisa = padding
+		}
+		body = body[consumed:]
+
+		if rec.SrcAddress == "" || rec.DstAddress == "" {
+			continue
+		}
+		if rec.SrcPort >= ephemeralPortStart {
+			rec.SrcPort = 0
+		}
+		if rec.DstPort >= ephemeralPortStart {
+			rec.DstPort = 0
+		}
+
+		key := flowKey{routerID, bucket, rec.SrcAddress, rec.DstAddress, rec.Protocol, rec.SrcPort, rec.DstPort}
+		if agg, ok := c.pending[key]; ok {
+			agg.Bytes += rec.Bytes
+			agg.Packets += rec.Packets
+			agg.Flows++
+			continue
+		}
+		rec.BucketStart = time.Unix(bucket, 0)
+		c.pending[key] = rec
+	}
+}
+
+// decodeRecord - Decode satu record sesuai template, return jumlah byte terpakai
+func decodeRecord(fields []templateField, body []byte, rec *models.FlowRecord) (int, bool) {
+	offset := 0
+	for _, field := range fields {
+		length := int(field.length)
+		if field.length == ipfixVarLen {
+			if offset >= len(body) {
+				return 0, false
+			}
+			length = int(body[offset])
+			offset++
+			if length == 255 {
+				if offset+2 > len(body) {
+					return 0, false
+				}
+				length = int(binary.BigEndian.Uint16(body[offset:]))
+				offset += 2
+			}
+		}
+		if offset+length > len(body) {
+			return 0, false
+		}
+		value := body[offset : offset+length]
+		offset += length
+
+		switch field.id {
+		case ieBytes:
+			rec.Bytes = readUint(value)
+		case iePackets:
+			rec.Packets = readUint(value)
+		case ieProtocol:
+			rec.Protocol = int(readUint(value))
+		case ieSrcPort:
+			rec.SrcPort = int(readUint(value))
+		case ieDstPort:
+			rec.DstPort = int(readUint(value))
+		case ieSrcIPv4, ieSrcIPv6:
+			rec.SrcAddress = net.IP(value).String()
+		case ieDstIPv4, ieDstIPv6:
+			rec.DstAddress = net.IP(value).String()
+		}
+	}
+	return offset, offset > 0
+}
+
+// readUint - Integer big-endian dengan panjang variabel (reduced-size encoding)
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, x := range b {
+		v = v<<8 | uint64(x)
+	}
+	return v
+}
+
+// flushRoutine - Tulis agregat pending ke database tiap 30 detik, housekeeping tiap jam
+func (c *FlowCollector) flushRoutine() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	lastCleanup := time.Now()
+	for range ticker.C {
+		c.flush()
+
+		if time.Since(lastCleanup) < time.Hour {
+			continue
+		}
+		lastCleanup = time.Now()
+
+		c.mu.Lock()
+		c.unknown = make(map[string]bool) // log ulang exporter tak dikenal tiap jam
+		c.expireExporters(time.Now().Add(-exporterExpiry))
+		c.mu.Unlock()
+
+		if c.retention > 0 {
+			deleted, err := c.repo.DeleteOlderThan(time.Now().Add(-c.retention))
+			if err != nil {
+				log.Printf("[NETFLOW] Retention cleanup failed: %v", err)
+			} else if deleted > 0 {
+				log.Printf("[NETFLOW] Retention cleanup removed %d rows", deleted)
+			}
+		}
+	}
+}
+
+// expireExporters - Buang template exporter yang tidak mengirim sejak cutoff
+// (c.mu harus dipegang)
+func (c *FlowCollector) expireExporters(cutoff time.Time) {
+	for exporter, state := range c.exporters {
+		if state.lastSeen.Before(cutoff) {
+			delete(c.exporters, exporter)
+		}
+	}
+	for key := range c.templates {
+		if _, ok := c.exporters[key.exporter]; !ok {
+			delete(c.templates, key)
+		}
+	}
+}
+
+func (c *FlowCollector) flush() {
+	c.mu.Lock()
+	if len(c.pending) == 0 {
+		c.mu.Unlock()
+		return
+	}
+	records := make([]*models.FlowRecord, 0, len(c.pending))
+	for _, rec := range c.pending {
+		records = append(records, rec)
+	}
+	c.pending = make(map[flowKey]*models.FlowRecord)
+	c.mu.Unlock()
+
+	if err := c.repo.UpsertBatch(records); err != nil {
+		log.Printf("[NETFLOW] Error saving %d flow aggregates: %v", len(records), err)
+	}
+}
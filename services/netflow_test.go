@@ -0,0 +1,243 @@
+package services
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+const testExporter = "192.0.2.1"
+
+func newTestFlowCollector() *FlowCollector {
+	return &FlowCollector{
+		resolver:  &routerResolver{byIP: map[string]int{testExporter: 7}},
+		templates: make(map[templateKey][]templateField),
+		exporters: make(map[string]*exporterState),
+		pending:   make(map[flowKey]*models.FlowRecord),
+		unknown:   make(map[string]bool),
+	}
+}
+
+func be16(v uint16) []byte { return binary.BigEndian.AppendUint16(nil, v) }
+func be32(v uint32) []byte { return binary.BigEndian.AppendUint32(nil, v) }
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// flowSet - Set header (id, length) diikuti body
+func flowSet(id uint16, body ...[]byte) []byte {
+	b := concat(body...)
+	return concat(be16(id), be16(uint16(setHeaderLen+len(b))), b)
+}
+
+// flowTemplate - Template record: id, jumlah field, lalu pasangan (ie, length)
+func flowTemplate(id uint16, fields ...uint16) []byte {
+	return concat(be16(id), be16(uint16(len(fields)/2)), fieldSpecs(fields...))
+}
+
+func fieldSpecs(fields ...uint16) []byte {
+	var b []byte
+	for _, f := range fields {
+		b = append(b, be16(f)...)
+	}
+	return b
+}
+
+func v9Packet(sets ...[]byte) []byte {
+	// version, count, sysUptime, unixSecs, sequence, source ID
+	return concat(be16(netflowV9), be16(uint16(len(sets))), be32(0), be32(0), be32(1), be32(0), concat(sets...))
+}
+
+func ipfixPacket(sets ...[]byte) []byte {
+	body := concat(sets...)
+	// version, length, export time, sequence, observation domain
+	return concat(be16(ipfixVersion), be16(uint16(ipfixHdrLen+len(body))), be32(0), be32(1), be32(0), body)
+}
+
+// tcpTemplate - src/dst IPv4, protocol, port, bytes, packets
+var tcpTemplate = []uint16{
+	ieSrcIPv4, 4, ieDstIPv4, 4, ieProtocol, 1, ieSrcPort, 2, ieDstPort, 2, ieBytes, 4, iePackets, 4,
+}
+
+func tcpRecord(src, dst [4]byte, srcPort, dstPort uint16, bytes, packets uint32) []byte {
+	return concat(src[:], dst[:], []byte{6}, be16(srcPort), be16(dstPort), be32(bytes), be32(packets))
+}
+
+var (
+	hostA = [4]byte{10, 0, 0, 1}
+	hostB = [4]byte{10, 0, 0, 2}
+)
+
+func TestFlowCollectorHandle(t *testing.T) {
+	record := tcpRecord(hostA, hostB, 443, 50000, 1500, 3)
+	want := []models.FlowRecord{{
+		RouterID: 7, SrcAddress: "10.0.0.1", DstAddress: "10.0.0.2",
+		Protocol: 6, SrcPort: 443, DstPort: 0, Bytes: 1500, Packets: 3, Flows: 1,
+	}}
+
+	truncated := ipfixPacket(flowSet(ipfixTemplate, flowTemplate(256, tcpTemplate...)))
+	binary.BigEndian.PutUint16(truncated[2:], uint16(len(truncated)+8))
+
+	trailing := append(ipfixPacket(flowSet(ipfixTemplate, flowTemplate(256, tcpTemplate...))),
+		flowSet(256, record)...)
+
+	badSet := v9Packet(flowSet(v9TemplateID, flowTemplate(256, tcpTemplate...)))
+	binary.BigEndian.PutUint16(badSet[v9HeaderLen+2:], 200)
+
+	// Field enterprise (bit 0x8000) diikuti enterprise number 4 byte
+	enterprise := concat(be16(256), be16(uint16(len(tcpTemplate)/2+1)),
+		fieldSpecs(ipfixEntBit|1, 4), be32(9), fieldSpecs(tcpTemplate...))
+
+	shortSet := v9Packet(flowSet(v9TemplateID, flowTemplate(256, tcpTemplate...)))
+	binary.BigEndian.PutUint16(shortSet[v9HeaderLen+2:], 2)
+
+	tests := []struct {
+		name          string
+		exporter      string
+		packet        []byte
+		wantErr       bool
+		wantRecords   []models.FlowRecord
+		wantTemplates int
+	}{
+		{
+			name:          "v9 template and data set",
+			exporter:      testExporter,
+			packet:        v9Packet(flowSet(v9TemplateID, flowTemplate(256, tcpTemplate...)), flowSet(256, record, record)),
+			wantRecords:   []models.FlowRecord{{RouterID: 7, SrcAddress: "10.0.0.1", DstAddress: "10.0.0.2", Protocol: 6, SrcPort: 443, Bytes: 3000, Packets: 6, Flows: 2}},
+			wantTemplates: 1,
+		},
+		{
+			name:          "ipfix template and data set",
+			exporter:      testExporter,
+			packet:        ipfixPacket(flowSet(ipfixTemplate, flowTemplate(300, tcpTemplate...)), flowSet(300, record)),
+			wantRecords:   want,
+			wantTemplates: 1,
+		},
+		{
+			name:          "ipfix enterprise field is skipped",
+			exporter:      testExporter,
+			packet:        ipfixPacket(flowSet(ipfixTemplate, enterprise), flowSet(256, be32(0xffffffff), record)),
+			wantRecords:   want,
+			wantTemplates: 1,
+		},
+		{
+			name:          "data set before template is ignored",
+			exporter:      testExporter,
+			packet:        v9Packet(flowSet(256, record)),
+			wantTemplates: 0,
+		},
+		{
+			name:          "data padding is ignored",
+			exporter:      testExporter,
+			packet:        v9Packet(flowSet(v9TemplateID, flowTemplate(256, tcpTemplate...)), flowSet(256, record, []byte{0, 0, 0})),
+			wantRecords:   want,
+			wantTemplates: 1,
+		},
+		{
+			// data set di luar panjang message tidak diproses
+			name:          "bytes after ipfix message length are ignored",
+			exporter:      testExporter,
+			packet:        trailing,
+			wantTemplates: 1,
+		},
+		{
+			name:     "unknown exporter stores nothing",
+			exporter: "203.0.113.9",
+			packet:   v9Packet(flowSet(v9TemplateID, flowTemplate(256, tcpTemplate...)), flowSet(256, record)),
+		},
+		{name: "empty packet", exporter: testExporter, packet: nil, wantErr: true},
+		{name: "unsupported version", exporter: testExporter, packet: concat(be16(5), make([]byte, 22)), wantErr: true},
+		{name: "v9 header too short", exporter: testExporter, packet: v9Packet()[:v9HeaderLen-1], wantErr: true},
+		{name: "ipfix header too short", exporter: testExporter, packet: ipfixPacket()[:ipfixHdrLen-1], wantErr: true},
+		{name: "ipfix length beyond datagram", exporter: testExporter, packet: truncated, wantErr: true},
+		{
+			name:     "ipfix length shorter than header",
+			exporter: testExporter,
+			packet:   concat(be16(ipfixVersion), be16(ipfixHdrLen-1), make([]byte, 12)),
+			wantErr:  true,
+		},
+		{name: "set length beyond datagram", exporter: testExporter, packet: badSet, wantErr: true},
+		{name: "set length shorter than header", exporter: testExporter, packet: shortSet, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestFlowCollector()
+			err := c.handle(tt.exporter, tt.packet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("handle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []models.FlowRecord
+			for _, rec := range c.pending {
+				r := *rec
+				r.BucketStart = time.Time{}
+				got = append(got, r)
+			}
+			if !reflect.DeepEqual(got, tt.wantRecords) {
+				t.Errorf("records = %+v, want %+v", got, tt.wantRecords)
+			}
+			if n := len(c.templates); n != tt.wantTemplates {
+				t.Errorf("templates = %d, want %d", n, tt.wantTemplates)
+			}
+		})
+	}
+}
+
+func TestFlowCollectorTemplateLimit(t *testing.T) {
+	templates := func(first, n int) []byte {
+		var body []byte
+		for i := 0; i < n; i++ {
+			body = append(body, flowTemplate(uint16(first+i), tcpTemplate...)...)
+		}
+		return ipfixPacket(flowSet(ipfixTemplate, body))
+	}
+	withdraw := func(id uint16) []byte {
+		return ipfixPacket(flowSet(ipfixTemplate, concat(be16(id), be16(0))))
+	}
+
+	tests := []struct {
+		name    string
+		packets [][]byte
+		want    int
+	}{
+		{name: "below limit", packets: [][]byte{templates(256, 10)}, want: 10},
+		{name: "capped at limit", packets: [][]byte{templates(256, maxTemplatesPerExporter+5)}, want: maxTemplatesPerExporter},
+		{
+			name:    "refreshing known templates does not count twice",
+			packets: [][]byte{templates(256, maxTemplatesPerExporter), templates(256, maxTemplatesPerExporter)},
+			want:    maxTemplatesPerExporter,
+		},
+		{
+			name:    "withdrawal frees a slot",
+			packets: [][]byte{templates(256, maxTemplatesPerExporter), withdraw(256), templates(1000, 2)},
+			want:    maxTemplatesPerExporter,
+		},
+		{name: "withdrawal of unknown template", packets: [][]byte{templates(256, 3), withdraw(999)}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestFlowCollector()
+			for _, packet := range tt.packets {
+				if err := c.handle(testExporter, packet); err != nil {
+					t.Fatalf("handle() error = %v", err)
+				}
+			}
+			if n := len(c.templates); n != tt.want {
+				t.Errorf("templates = %d, want %d", n, tt.want)
+			}
+			if n := c.exporters[testExporter].templates; n != tt.want {
+				t.Errorf("exporter template count = %d, want %d", n, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// Notifier - Kirim event ke webhook eksternal (JSON POST), mis. bridge ke Telegram / chat ops.
+// URL kosong = nonaktif.
+type Notifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewNotifier(url string) *Notifier {
+	return &Notifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify - POST event secara async; kegagalan hanya di-log
+func (n *Notifier) Notify(event *models.Event) {
+	if n == nil || n.url == "" {
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[NOTIFY] Error encoding %s event: %v", event.Type, err)
+		return
+	}
+
+	go func() {
+		defer Recover("notifier")
+		resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Printf("[NOTIFY] Error sending %s event: %v", event.Type, err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("[NOTIFY] Webhook returned %s for %s event", resp.Status, event.Type)
+		}
+	}()
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ouiSeed - Subset oui.txt IEEE (vendor perangkat jaringan umum) yang dipakai sebelum /
+// tanpa refresh dari sumber eksternal
+//
+//go:embed data/oui.txt
+var ouiSeed string
+
+// OUIResolver - Resolusi vendor dari prefix MAC (MA-L, 24 bit)
+type OUIResolver struct {
+	mu      sync.RWMutex
+	vendors map[string]string // "4C5E0C" -> vendor
+}
+
+var (
+	ouiInstance *OUIResolver
+	ouiOnce     sync.Once
+)
+
+// GetOUIResolver - Singleton resolver, diisi dari database embedded
+func GetOUIResolver() *OUIResolver {
+	ouiOnce.Do(func() {
+		vendors, _ := parseOUI(strings.NewReader(ouiSeed))
+		ouiInstance = &OUIResolver{vendors: vendors}
+	})
+	return ouiInstance
+}
+
+// Lookup - Nama vendor untuk MAC (format apa pun: ':', '-', '.'), kosong jika tidak dikenal
+func (o *OUIResolver) Lookup(mac string) string {
+	hex := strings.ToUpper(strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac))
+	if len(hex) < 6 {
+		return ""
+	}
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.vendors[hex[:6]]
+}
+
+// Run - Refresh periodik dari source (URL http(s) atau path file format oui.txt IEEE).
+// Entry hasil refresh ditimpakan ke database embedded; refresh gagal tidak menghapus data lama.
+func (o *OUIResolver) Run(source string, interval time.Duration) {
+	o.refresh(source)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		o.refresh(source)
+	}
+}
+
+func (o *OUIResolver) refresh(source string) {
+	body, err := openOUISource(source)
+	if err != nil {
+		log.Printf("[OUI] Refresh from %s failed: %v", source, err)
+		return
+	}
+	defer body.Close()
+
+	vendors, err := parseOUI(body)
+	if err != nil || len(vendors) == 0 {
+		log.Printf("[OUI] Refresh from %s failed: no entries parsed (%v)", source, err)
+		return
+	}
+
+	o.mu.Lock()
+	for prefix, vendor := range vendors {
+		o.vendors[prefix] = vendor
+	}
+	total := len(o.vendors)
+	o.mu.Unlock()
+
+	log.Printf("[OUI] ✓ Loaded %d entries from %s (%d total)", len(vendors), source, total)
+}
+
+func openOUISource(source string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.Open(source)
+	}
+
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// parseOUI - Parse baris "XX-XX-XX   (hex)\t\tVendor" dari oui.txt IEEE
+func parseOUI(r io.Reader) (map[string]string, error) {
+	vendors := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		idx := strings.Index(line, "(hex)")
+		if idx < 0 {
+			continue
+		}
+
+		prefix := strings.ReplaceAll(strings.TrimSpace(line[:idx]), "-", "")
+		vendor := strings.TrimSpace(line[idx+len("(hex)"):])
+		if len(prefix) != 6 || vendor == "" {
+			continue
+		}
+		vendors[strings.ToUpper(prefix)] = vendor
+	}
+
+	return vendors, scanner.Err()
+}
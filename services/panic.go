@@ -0,0 +1,60 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+)
+
+// supervisorRestartDelay - Jeda sebelum loop worker yang panic dijalankan ulang
+const supervisorRestartDelay = 10 * time.Second
+
+var panicReporter atomic.Pointer[SentryReporter]
+
+// SetPanicReporter - Kirim panic yang di-recover ke Sentry (nil = hanya log)
+func SetPanicReporter(r *SentryReporter) {
+	panicReporter.Store(r)
+}
+
+// ReportPanic - Log panic beserta stack trace lalu laporkan ke reporter (jika dikonfigurasi).
+// tags berisi konteks tambahan, mis. method/path request.
+func ReportPanic(name string, value interface{}, stack []byte, tags map[string]string) {
+	log.Printf("[PANIC] %s: %v\n%s", name, value, stack)
+	if r := panicReporter.Load(); r != nil {
+		r.Report(name, fmt.Sprint(value), stack, tags)
+	}
+}
+
+// Recover - Dipanggil dengan defer di awal goroutine: panic dicatat dan dilaporkan,
+// bukan menghentikan seluruh proses
+func Recover(name string) {
+	if v := recover(); v != nil {
+		ReportPanic(name, v, debug.Stack(), nil)
+	}
+}
+
+// Supervise - Jalankan loop worker (blocking); jika panic, dicatat lalu dijalankan ulang
+// setelah jeda. Return normal dari fn (mis. context dibatalkan) menghentikan supervisi.
+func Supervise(name string, fn func()) {
+	for {
+		if !runRecovered(name, fn) {
+			return
+		}
+		log.Printf("[PANIC] %s restarting in %v", name, supervisorRestartDelay)
+		time.Sleep(supervisorRestartDelay)
+	}
+}
+
+// runRecovered - Jalankan fn, true jika berakhir karena panic
+func runRecovered(name string, fn func()) (panicked bool) {
+	defer func() {
+		if v := recover(); v != nil {
+			ReportPanic(name, v, debug.Stack(), nil)
+			panicked = true
+		}
+	}()
+	fn()
+	return false
+}
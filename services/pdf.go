@@ -0,0 +1,125 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Ukuran halaman A4 dalam point
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+)
+
+// pdfDocument - Penulis PDF minimal (teks Helvetica + garis/kotak) untuk laporan.
+// Koordinat API dari kiri-atas halaman; dikonversi ke sistem PDF (kiri-bawah) saat menulis.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	cur   *bytes.Buffer
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.AddPage()
+	return d
+}
+
+// AddPage - Mulai halaman baru; operasi berikutnya ditulis ke halaman ini
+func (d *pdfDocument) AddPage() {
+	d.cur = &bytes.Buffer{}
+	d.pages = append(d.pages, d.cur)
+}
+
+// Text - Tulis teks dengan baseline di (x, y)
+func (d *pdfDocument) Text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.cur, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, pdfPageHeight-y, pdfEscape(s))
+}
+
+// SetFillColor - Warna isi (teks & kotak), komponen 0-1
+func (d *pdfDocument) SetFillColor(r, g, b float64) {
+	fmt.Fprintf(d.cur, "%.3f %.3f %.3f rg\n", r, g, b)
+}
+
+// SetStrokeColor - Warna garis, komponen 0-1
+func (d *pdfDocument) SetStrokeColor(r, g, b float64) {
+	fmt.Fprintf(d.cur, "%.3f %.3f %.3f RG\n", r, g, b)
+}
+
+// Line - Garis lurus; dashed untuk garis putus-putus (mis. 95th percentile)
+func (d *pdfDocument) Line(x1, y1, x2, y2, width float64, dashed bool) {
+	dash := "[] 0 d"
+	if dashed {
+		dash = "[4 3] 0 d"
+	}
+	fmt.Fprintf(d.cur, "%s %.2f w %.2f %.2f m %.2f %.2f l S\n", dash, width, x1, pdfPageHeight-y1, x2, pdfPageHeight-y2)
+}
+
+// FillRect - Kotak terisi dengan sudut kiri-atas (x, y)
+func (d *pdfDocument) FillRect(x, y, w, h float64) {
+	fmt.Fprintf(d.cur, "%.2f %.2f %.2f %.2f re f\n", x, pdfPageHeight-y-h, w, h)
+}
+
+// Bytes - Serialisasi dokumen (catalog, pages, font base-14, konten per halaman, xref)
+func (d *pdfDocument) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	writeObj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objek 1-4 tetap; halaman ke-i memakai objek 5+2i (page) dan 6+2i (content)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	writeObj("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		writeObj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		writeObj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// pdfEscape - Escape string literal PDF; karakter di luar Latin-1 diganti '?'
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x80:
+			b.WriteRune(r)
+		case r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// permissionProbes - Command print yang tidak mengubah apa pun; gagal = kredensial tidak
+// bisa membaca path tsb
+var permissionProbes = []struct {
+	name     string
+	sentence []string
+}{
+	{"read_system", []string{"/system/resource/print"}},
+	{"read_interfaces", []string{"/interface/print", "=.proplist=.id"}},
+	{"read_addresses", []string{"/ip/address/print", "=.proplist=.id"}},
+	{"read_queues", []string{"/queue/simple/print", "=.proplist=.id"}},
+	{"read_firewall", []string{"/ip/firewall/filter/print", "=.proplist=.id"}},
+	{"read_ppp_secrets", []string{"/ppp/secret/print", "=.proplist=.id"}},
+	{"read_files", []string{"/file/print", "=.proplist=.id"}},
+	{"read_logs", []string{"/log/print", "=.proplist=.id"}},
+}
+
+// permissionPolicies - Aksi yang tidak bisa di-probe tanpa efek samping; dinilai dari
+// policy user group kredensial
+var permissionPolicies = []struct {
+	name   string
+	policy string
+}{
+	{"write_config", "write"},       // queue, firewall, PPP, suspend, job command
+	{"reboot", "reboot"},            // reboot & upgrade package
+	{"file_transfer", "ftp"},        // upload / download file via FTP
+	{"read_sensitive", "sensitive"}, // password PPP secret, private key WireGuard
+	{"run_tools", "test"},           // ping, torch
+	{"manage_users", "policy"},
+}
+
+// CheckPermissions - Jalankan probe read yang aman lalu nilai aksi tulis dari policy group
+// user API, sehingga kegagalan permission bisa diketahui sebelum operasi sebenarnya.
+func (ms *MikrotikService) CheckPermissions(routerID int) (*models.RouterPermissions, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.RouterPermissions{
+		RouterID:  routerID,
+		Username:  conn.Router.Username,
+		Policies:  []string{},
+		Checks:    []*models.PermissionCheck{},
+		CheckedAt: time.Now(),
+	}
+
+	for _, probe := range permissionProbes {
+		check := &models.PermissionCheck{Name: probe.name, Command: strings.Join(probe.sentence, " ")}
+		if _, err := ms.runRead(routerID, probe.sentence...); err != nil {
+			if probeAborted(err) {
+				return nil, err
+			}
+			check.Error = err.Error()
+		} else {
+			check.Allowed = true
+		}
+		result.Checks = append(result.Checks, check)
+	}
+
+	policies, policyErr := ms.userPolicies(routerID, result)
+	if probeAborted(policyErr) {
+		return nil, policyErr
+	}
+	for _, p := range permissionPolicies {
+		check := &models.PermissionCheck{Name: p.name, Policy: p.policy}
+		if policyErr != nil {
+			check.Error = fmt.Sprintf("group policy unavailable: %v", policyErr)
+		} else {
+			check.Allowed = policies[p.policy]
+		}
+		result.Checks = append(result.Checks, check)
+	}
+
+	return result, nil
+}
+
+// userPolicies - Policy group user API (/user -> /user/group); policy berawalan "!" = ditolak
+func (ms *MikrotikService) userPolicies(routerID int, result *models.RouterPermissions) (map[string]bool, error) {
+	r, err := ms.runRead(routerID, "/user/print", fmt.Sprintf("?name=%s", result.Username), "=.proplist=name,group")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("user %s not found", result.Username)
+	}
+	result.Group = r.Re[0].Map["group"]
+
+	r, err = ms.runRead(routerID, "/user/group/print", fmt.Sprintf("?name=%s", result.Group), "=.proplist=name,policy")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("user group %s not found", result.Group)
+	}
+
+	policies := make(map[string]bool)
+	for _, p := range strings.Split(r.Re[0].Map["policy"], ",") {
+		p = strings.TrimSpace(p)
+		if p == "" || strings.HasPrefix(p, "!") {
+			continue
+		}
+		policies[p] = true
+		result.Policies = append(result.Policies, p)
+	}
+	return policies, nil
+}
+
+// probeAborted - Error koneksi / transient menggagalkan seluruh pengecekan (bukan hasil "ditolak")
+func probeAborted(err error) bool {
+	if err == nil {
+		return false
+	}
+	var transient *TransientError
+	return errors.As(err, &transient) || IsTransient(err) || errors.Is(err, errConnectionUnhealthy)
+}
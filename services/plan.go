@@ -0,0 +1,216 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// ApplyPlan - Terapkan parameter plan ke simple queue dan PPP secret di satu router.
+// PPP secret dipindah ke profile plan (dibuat/diupdate dengan rate-limit plan).
+func (ms *MikrotikService) ApplyPlan(routerID int, plan *models.Plan, queues, secrets []string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	cmdPlan := newCommandPlan(routerID, "apply_plan", dryRun)
+
+	for _, name := range queues {
+		r, err := conn.Run("/queue/simple/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id")
+		if err != nil {
+			return nil, err
+		}
+		if len(r.Re) == 0 {
+			cmdPlan.Checks = append(cmdPlan.Checks, fmt.Sprintf("queue %s not found, skipped", name))
+			continue
+		}
+
+		set := []string{
+			"/queue/simple/set",
+			fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+		}
+		cmdPlan.Commands = append(cmdPlan.Commands, append(set, planQueueLimits(plan)...))
+	}
+
+	if len(secrets) > 0 {
+		profile := plan.ProfileName()
+		if err := planProfileCommand(conn, cmdPlan, plan); err != nil {
+			return nil, err
+		}
+
+		for _, name := range secrets {
+			r, err := conn.Run("/ppp/secret/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id,profile")
+			if err != nil {
+				return nil, err
+			}
+			if len(r.Re) == 0 {
+				cmdPlan.Checks = append(cmdPlan.Checks, fmt.Sprintf("ppp secret %s not found, skipped", name))
+				continue
+			}
+			if r.Re[0].Map["profile"] == profile {
+				continue
+			}
+			cmdPlan.Commands = append(cmdPlan.Commands, []string{
+				"/ppp/secret/set",
+				fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+				fmt.Sprintf("=profile=%s", profile),
+			})
+		}
+	}
+
+	return cmdPlan, executePlan(conn, cmdPlan)
+}
+
+// planQueueLimits - Parameter limit simple queue dari plan (max-limit dan burst jika diset)
+func planQueueLimits(plan *models.Plan) []string {
+	limits := []string{fmt.Sprintf("=max-limit=%s", plan.RateLimit)}
+	if plan.BurstLimit != nil {
+		limits = append(limits, fmt.Sprintf("=burst-limit=%s", *plan.BurstLimit))
+	}
+	if plan.BurstThreshold != nil {
+		limits = append(limits, fmt.Sprintf("=burst-threshold=%s", *plan.BurstThreshold))
+	}
+	if plan.BurstTime != nil {
+		limits = append(limits, fmt.Sprintf("=burst-time=%s", *plan.BurstTime))
+	}
+	return limits
+}
+
+// planProfileCommand - Tambahkan ke cmdPlan command untuk membuat / mengupdate /ppp/profile plan.
+// Caller wajib sudah memegang conn.mu.
+func planProfileCommand(conn *MikrotikConnection, cmdPlan *models.CommandPlan, plan *models.Plan) error {
+	profile := plan.ProfileName()
+	r, err := conn.Run("/ppp/profile/print", fmt.Sprintf("?name=%s", profile), "=.proplist=.id")
+	if err != nil {
+		return err
+	}
+	if len(r.Re) > 0 {
+		cmdPlan.Checks = append(cmdPlan.Checks, fmt.Sprintf("ppp profile %s exists, updating", profile))
+		cmdPlan.Commands = append(cmdPlan.Commands, []string{
+			"/ppp/profile/set",
+			fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+			fmt.Sprintf("=rate-limit=%s", plan.PPPRateLimit()),
+		})
+	} else {
+		cmdPlan.Commands = append(cmdPlan.Commands, []string{
+			"/ppp/profile/add",
+			fmt.Sprintf("=name=%s", profile),
+			fmt.Sprintf("=rate-limit=%s", plan.PPPRateLimit()),
+		})
+	}
+	return nil
+}
+
+// PlanService - Propagasi perubahan plan ke semua router yang punya customer dengan plan tsb
+type PlanService struct {
+	ms        *MikrotikService
+	customers *repository.CustomerRepository
+	usage     *repository.UsageRepository
+	audit     *AuditLogger
+	webhooks  *WebhookDispatcher
+}
+
+func NewPlanService(ms *MikrotikService, customers *repository.CustomerRepository, usage *repository.UsageRepository, audit *AuditLogger, webhooks *WebhookDispatcher) *PlanService {
+	return &PlanService{
+		ms:        ms,
+		customers: customers,
+		usage:     usage,
+		audit:     audit,
+		webhooks:  webhooks,
+	}
+}
+
+// Propagate - Terapkan plan ke semua customer yang memakainya, dikelompokkan per router
+func (s *PlanService) Propagate(plan *models.Plan, dryRun bool) ([]*models.PlanApplyResult, error) {
+	customers, err := s.customers.List(models.CustomerFilter{PlanID: &plan.ID})
+	if err != nil {
+		return nil, err
+	}
+	return s.Apply(plan, customers, dryRun), nil
+}
+
+// Apply - Terapkan plan ke daftar customer tertentu
+func (s *PlanService) Apply(plan *models.Plan, customers []*models.Customer, dryRun bool) []*models.PlanApplyResult {
+	byRouter := make(map[int][]*models.Customer)
+	for _, c := range customers {
+		byRouter[c.RouterID] = append(byRouter[c.RouterID], c)
+	}
+
+	routerIDs := make([]int, 0, len(byRouter))
+	for routerID := range byRouter {
+		routerIDs = append(routerIDs, routerID)
+	}
+	sort.Ints(routerIDs)
+
+	results := make([]*models.PlanApplyResult, 0, len(routerIDs))
+	for _, routerID := range routerIDs {
+		var queues, secrets []string
+		for _, c := range byRouter[routerID] {
+			// Queue customer yang di-suspend dengan throttle tidak dinaikkan lagi; rate limit plan
+			// diterapkan saat unsuspend
+			if c.QueueName != nil && *c.QueueName != "" && !c.Throttled() {
+				queues = append(queues, *c.QueueName)
+			}
+			if c.PPPSecret != nil && *c.PPPSecret != "" {
+				secrets = append(secrets, *c.PPPSecret)
+			}
+		}
+
+		result := &models.PlanApplyResult{RouterID: routerID, Customers: len(byRouter[routerID])}
+		cmdPlan, err := s.ms.ApplyPlan(routerID, plan, queues, secrets, dryRun)
+		result.Plan = cmdPlan
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+
+		if dryRun {
+			continue
+		}
+		s.audit.LogPlan("api", "apply_plan", routerID, plan.Name, cmdPlan, err)
+		if err == nil {
+			s.emitApplied(routerID, plan, byRouter[routerID])
+		}
+
+		// Kuota plan ikut disinkronkan ke queue_quotas
+		if err == nil && plan.QuotaBytes != nil {
+			for _, queue := range queues {
+				if err := s.usage.SetQuotaBytes(routerID, queue, *plan.QuotaBytes); err != nil {
+					log.Printf("[PLAN] Error syncing quota %s router %d: %v", queue, routerID, err)
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// emitApplied - Webhook queue.changed / ppp_secret.changed untuk setiap customer yang plannya diterapkan
+func (s *PlanService) emitApplied(routerID int, plan *models.Plan, customers []*models.Customer) {
+	for _, c := range customers {
+		if c.QueueName != nil && *c.QueueName != "" {
+			s.webhooks.Emit(models.WebhookQueueChanged, &routerID, map[string]interface{}{
+				"customer_id": c.ID,
+				"name":        *c.QueueName,
+				"plan_id":     plan.ID,
+				"max_limit":   plan.RateLimit,
+				"reason":      "plan_applied",
+			})
+		}
+		if c.PPPSecret != nil && *c.PPPSecret != "" {
+			s.webhooks.Emit(models.WebhookPPPSecretChanged, &routerID, map[string]interface{}{
+				"customer_id": c.ID,
+				"name":        *c.PPPSecret,
+				"plan_id":     plan.ID,
+				"profile":     plan.ProfileName(),
+			})
+		}
+	}
+}
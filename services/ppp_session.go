@@ -0,0 +1,118 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// PPPSessionTracker - Poll /ppp/active tiap interval dan simpan riwayat start/stop sesi
+// (user, caller-id, IP, byte in/out) ke tabel ppp_sessions
+type PPPSessionTracker struct {
+	interval  time.Duration
+	retention time.Duration
+	ms        *MikrotikService
+	repo      *repository.PPPSessionRepository
+}
+
+func NewPPPSessionTracker(interval, retention time.Duration, ms *MikrotikService, repo *repository.PPPSessionRepository) *PPPSessionTracker {
+	return &PPPSessionTracker{
+		interval:  interval,
+		retention: retention,
+		ms:        ms,
+		repo:      repo,
+	}
+}
+
+// Run - Loop polling (blocking)
+func (t *PPPSessionTracker) Run() {
+	if t.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		now := time.Now()
+		for routerID, conn := range t.ms.GetAllConnections() {
+			// Simulator tidak punya PPP server
+			if !conn.IsHealthy || conn.IsVirtual() {
+				continue
+			}
+			t.poll(routerID, now)
+		}
+
+		if t.retention > 0 && now.Sub(lastPurge) >= time.Hour {
+			lastPurge = now
+			if n, err := t.repo.Purge(now.Add(-t.retention)); err != nil {
+				log.Printf("[PPP] Error purging sessions: %v", err)
+			} else if n > 0 {
+				log.Printf("[PPP] Purged %d old sessions", n)
+			}
+		}
+	}
+}
+
+func (t *PPPSessionTracker) poll(routerID int, now time.Time) {
+	active, err := t.ms.GetPPPActiveSessions(routerID)
+	if err != nil {
+		log.Printf("[PPP] Error reading active sessions router %d: %v", routerID, err)
+		return
+	}
+
+	open, err := t.repo.Open(routerID)
+	if err != nil {
+		log.Printf("[PPP] Error loading open sessions router %d: %v", routerID, err)
+		return
+	}
+	byRef := make(map[string]*models.PPPSession, len(open))
+	for _, s := range open {
+		byRef[s.SessionRef+"/"+s.Username] = s
+	}
+
+	for _, a := range active {
+		key := a.ID + "/" + a.User
+		if s, ok := byRef[key]; ok {
+			delete(byRef, key)
+			if err := t.repo.Touch(s.ID, a.Address, a.BytesIn, a.BytesOut, now); err != nil {
+				log.Printf("[PPP] Error updating session %d: %v", s.ID, err)
+			}
+			continue
+		}
+
+		err := t.repo.Start(&models.PPPSession{
+			RouterID:   routerID,
+			SessionRef: a.ID,
+			Username:   a.User,
+			Service:    a.Service,
+			CallerID:   a.CallerID,
+			Address:    a.Address,
+			BytesIn:    a.BytesIn,
+			BytesOut:   a.BytesOut,
+			StartedAt:  now.Add(-a.Uptime),
+			LastSeenAt: now,
+		})
+		if err != nil {
+			log.Printf("[PPP] Error recording session %s router %d: %v", a.User, routerID, err)
+		}
+	}
+
+	// Sesi yang hilang dari /ppp/active sudah berakhir. Jika terakhir terlihat jauh sebelum
+	// polling ini (layer sempat mati / router offline), waktu terakhir terlihat lebih akurat.
+	for _, s := range byRef {
+		ended := now
+		if now.Sub(s.LastSeenAt) > 2*t.interval {
+			ended = s.LastSeenAt
+		}
+		if err := t.repo.End(s.ID, ended); err != nil {
+			log.Printf("[PPP] Error closing session %d: %v", s.ID, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// ProbeConnections - Liveness probe paralel ke semua koneksi, masing-masing dibatasi timeout.
+// Status cache (IsHealthy/LastPing) tidak diubah; itu tetap urusan health check.
+func (ms *MikrotikService) ProbeConnections(timeout time.Duration) map[int]*models.ProbeResult {
+	connections := ms.GetAllConnections()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[int]*models.ProbeResult, len(connections))
+	)
+
+	for routerID, conn := range connections {
+		wg.Add(1)
+		go func(routerID int, conn *MikrotikConnection) {
+			defer Recover("connection-probe")
+			defer wg.Done()
+			result := probeConnection(conn, timeout)
+
+			mu.Lock()
+			results[routerID] = result
+			mu.Unlock()
+		}(routerID, conn)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// probeConnection - Satu round-trip /system/resource/print; command yang macet ditinggal
+// setelah timeout (goroutine selesai sendiri saat router menjawab / koneksi ditutup)
+func probeConnection(conn *MikrotikConnection, timeout time.Duration) *models.ProbeResult {
+	start := time.Now()
+	done := make(chan error, 1)
+
+	go func() {
+		conn.mu.RLock()
+		defer conn.mu.RUnlock()
+		_, err := conn.Run("/system/resource/print")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		result := &models.ProbeResult{
+			Alive:     err == nil,
+			LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		return result
+
+	case <-time.After(timeout):
+		return &models.ProbeResult{
+			LatencyMs: float64(timeout.Milliseconds()),
+			Error:     fmt.Sprintf("probe timeout after %v", timeout),
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QueryFilter - Filter list endpoint (?filter=name~"wlan",running=true) yang diterjemahkan ke
+// query word RouterOS (?name=..., ?<mtu=..., ?#!) supaya penyaringan terjadi di router.
+// Semua term di-AND. Operator: = != < > dan ~ (mengandung, case-insensitive). API RouterOS
+// tidak punya query substring/regex, jadi ~ diterapkan di layer atas hasil yang sudah
+// disaring router oleh term lain.
+type QueryFilter struct {
+	words    []string
+	contains []containsTerm
+}
+
+type containsTerm struct {
+	field string
+	value string
+}
+
+// Field yang boleh difilter per list (property RouterOS yang ada di proplist)
+var (
+	InterfaceFilterFields   = []string{"name", "type", "running", "disabled", "comment", "mac-address", "mtu"}
+	AddressFilterFields     = []string{"address", "interface", "network", "disabled", "dynamic"}
+	QueueFilterFields       = []string{"name", "target", "max-limit", "burst-limit", "disabled"}
+	AddressListFilterFields = []string{"list", "address", "comment", "disabled", "dynamic", "timeout"}
+)
+
+// ParseQueryFilter - Parse ekspresi filter; field di luar allowed ditolak supaya klien tidak bisa
+// menyusupkan query word lain. Ekspresi kosong menghasilkan nil (tanpa filter).
+func ParseQueryFilter(expr string, allowed []string) (*QueryFilter, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+
+	terms, err := splitFilterTerms(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &QueryFilter{}
+	for _, term := range terms {
+		field, op, value, err := parseFilterTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		if !containsString(allowed, field) {
+			return nil, fmt.Errorf("field %q cannot be filtered, allowed: %s", field, strings.Join(allowed, ", "))
+		}
+		if strings.ContainsAny(value, "\x00\r\n") {
+			return nil, fmt.Errorf("value for %q contains control characters", field)
+		}
+
+		switch op {
+		case "=":
+			f.words = append(f.words, fmt.Sprintf("?%s=%s", field, value))
+		case "!=":
+			f.words = append(f.words, fmt.Sprintf("?%s=%s", field, value), "?#!")
+		case "<":
+			f.words = append(f.words, fmt.Sprintf("?<%s=%s", field, value))
+		case ">":
+			f.words = append(f.words, fmt.Sprintf("?>%s=%s", field, value))
+		case "~":
+			f.contains = append(f.contains, containsTerm{field: field, value: strings.ToLower(value)})
+		}
+	}
+	return f, nil
+}
+
+// Words - Query word untuk ditambahkan ke command print (nil-safe)
+func (f *QueryFilter) Words() []string {
+	if f == nil {
+		return nil
+	}
+	return f.words
+}
+
+// Match - Term ~ terhadap satu entry hasil print (nil-safe)
+func (f *QueryFilter) Match(entry map[string]string) bool {
+	if f == nil {
+		return true
+	}
+	for _, term := range f.contains {
+		if !strings.Contains(strings.ToLower(entry[term.field]), term.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitFilterTerms - Pisah term dengan koma, kecuali di dalam tanda kutip
+func splitFilterTerms(expr string) ([]string, error) {
+	var (
+		terms   []string
+		current strings.Builder
+		quoted  bool
+	)
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == '\\' && quoted && i+1 < len(expr):
+			current.WriteByte(c)
+			current.WriteByte(expr[i+1])
+			i++
+		case c == '"':
+			quoted = !quoted
+			current.WriteByte(c)
+		case c == ',' && !quoted:
+			terms = append(terms, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	terms = append(terms, current.String())
+
+	for i, term := range terms {
+		if terms[i] = strings.TrimSpace(term); terms[i] == "" {
+			return nil, fmt.Errorf("empty filter term")
+		}
+	}
+	return terms, nil
+}
+
+// parseFilterTerm - field op value; value boleh dikutip ("...") dengan escape \" dan \\
+func parseFilterTerm(term string) (field, op, value string, err error) {
+	idx := strings.IndexAny(term, "=!<>~")
+	if idx <= 0 {
+		return "", "", "", fmt.Errorf("term %q must be in the form field<op>value", term)
+	}
+	field = strings.TrimSpace(term[:idx])
+
+	rest := term[idx:]
+	switch {
+	case strings.HasPrefix(rest, "!="):
+		op = "!="
+	case rest[0] == '!':
+		return "", "", "", fmt.Errorf("term %q: unknown operator", term)
+	default:
+		op = rest[:1]
+	}
+	value = strings.TrimSpace(rest[len(op):])
+
+	if strings.HasPrefix(value, `"`) {
+		if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+			return "", "", "", fmt.Errorf("term %q: malformed quoted value", term)
+		}
+		value = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+	}
+	return field, op, value, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQueryFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		expr         string
+		wantWords    []string
+		wantContains []containsTerm
+		wantErr      bool
+	}{
+		{name: "empty expression", expr: "  "},
+		{name: "equals", expr: "running=true", wantWords: []string{"?running=true"}},
+		{name: "less and greater", expr: "mtu>1400,mtu<1600", wantWords: []string{"?>mtu=1400", "?<mtu=1600"}},
+		{name: "contains is lowered and kept out of words", expr: `name~"WLAN"`, wantContains: []containsTerm{{field: "name", value: "wlan"}}},
+		{name: "spaces around field and operator", expr: " name = ether1 , disabled = false ", wantWords: []string{"?name=ether1", "?disabled=false"}},
+		// ?#! menegasikan term tepat sebelumnya, term lain tetap di-AND
+		{name: "negation binds to its own term", expr: "name!=ether1,running=true", wantWords: []string{"?name=ether1", "?#!", "?running=true"}},
+		{name: "negation after other terms", expr: "running=true,name!=ether1", wantWords: []string{"?running=true", "?name=ether1", "?#!"}},
+		{
+			name:         "mixed router and layer terms",
+			expr:         `type=ether,comment~uplink,disabled!=true`,
+			wantWords:    []string{"?type=ether", "?disabled=true", "?#!"},
+			wantContains: []containsTerm{{field: "comment", value: "uplink"}},
+		},
+		{name: "quoted comma stays in value", expr: `comment="a,b",running=true`, wantWords: []string{"?comment=a,b", "?running=true"}},
+		{name: "quoted escapes", expr: `comment="say \"hi\" \\o/"`, wantWords: []string{`?comment=say "hi" \o/`}},
+		{name: "operators inside value are literal", expr: `comment="x=1,?#|"`, wantWords: []string{"?comment=x=1,?#|"}},
+		{name: "empty value", expr: "comment=", wantWords: []string{"?comment="}},
+
+		{name: "field not allowed", expr: "password=x", wantErr: true},
+		{name: "internal id not allowed", expr: ".id=*1", wantErr: true},
+		{name: "quoted field name", expr: `"name"=x`, wantErr: true},
+		{name: "raw query word", expr: "name=x,?#|", wantErr: true},
+		{name: "query operator smuggled as field", expr: "?name=x", wantErr: true},
+		{name: "newline starts a new api word", expr: "name=x\n/system/reboot", wantErr: true},
+		{name: "carriage return in quoted value", expr: "name=\"x\r\"", wantErr: true},
+		{name: "nul byte", expr: "name=x\x00", wantErr: true},
+		{name: "missing operator", expr: "running", wantErr: true},
+		{name: "missing field", expr: "=true", wantErr: true},
+		{name: "unknown bang operator", expr: "name!ether1", wantErr: true},
+		{name: "empty term", expr: "name=x,,running=true", wantErr: true},
+		{name: "trailing comma", expr: "name=x,", wantErr: true},
+		{name: "unterminated quote", expr: `comment="abc`, wantErr: true},
+		{name: "escaped closing quote", expr: `comment="abc\"`, wantErr: true},
+		{name: "quote inside unquoted value", expr: `comment=ab"c`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseQueryFilter(tt.expr, InterfaceFilterFields)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQueryFilter(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := f.Words(); !reflect.DeepEqual(got, tt.wantWords) {
+				t.Errorf("Words() = %q, want %q", got, tt.wantWords)
+			}
+			var contains []containsTerm
+			if f != nil {
+				contains = f.contains
+			}
+			if !reflect.DeepEqual(contains, tt.wantContains) {
+				t.Errorf("contains = %+v, want %+v", contains, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestQueryFilterMatch(t *testing.T) {
+	entry := map[string]string{"name": "wlan1-Guest", "comment": "Uplink ISP"}
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "no filter", expr: "", want: true},
+		{name: "router-side terms only", expr: "running=true", want: true},
+		{name: "case-insensitive contains", expr: "name~GUEST", want: true},
+		{name: "all contains terms must match", expr: "name~wlan,comment~isp", want: true},
+		{name: "one contains term misses", expr: "name~wlan,comment~backup", want: false},
+		{name: "missing property", expr: "type~ether", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseQueryFilter(tt.expr, InterfaceFilterFields)
+			if err != nil {
+				t.Fatalf("ParseQueryFilter(%q) error = %v", tt.expr, err)
+			}
+			if got := f.Match(entry); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// GetQueueStats - Counter byte semua simple queue router
+func (ms *MikrotikService) GetQueueStats(routerID int) ([]*models.QueueStats, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	r, err := conn.Run("/queue/simple/print", "=.proplist=.id,name,target,bytes")
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*models.QueueStats, 0, len(r.Re))
+	for _, re := range r.Re {
+		upload, download := parseRatePair(re.Map["bytes"])
+		stats = append(stats, &models.QueueStats{
+			ID:            re.Map[".id"],
+			Name:          re.Map["name"],
+			Target:        re.Map["target"],
+			UploadBytes:   upload,
+			DownloadBytes: download,
+		})
+	}
+
+	return stats, nil
+}
+
+// parseRatePair - Parse nilai "upload/download" RouterOS (mis. bytes simple queue)
+func parseRatePair(v string) (uint64, uint64) {
+	parts := strings.SplitN(v, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0
+	}
+	up, _ := strconv.ParseUint(parts[0], 10, 64)
+	down, _ := strconv.ParseUint(parts[1], 10, 64)
+	return up, down
+}
+
+// queueCounter - Counter terakhir satu queue untuk hitung delta
+type queueCounter struct {
+	upload   uint64
+	download uint64
+}
+
+// QueueUsageSampler - Baca counter simple queue periodik, simpan delta ke total
+// harian/bulanan (plus rate per interval untuk 95th percentile laporan), lalu
+// serahkan pengecekan kuota ke QuotaEnforcer.
+type QueueUsageSampler struct {
+	interval  time.Duration
+	retention time.Duration
+	ms        *MikrotikService
+	repo      *repository.UsageRepository
+	enforcer  *QuotaEnforcer
+
+	mu          sync.Mutex
+	last        map[string]queueCounter // "routerID/.id" -> counter terakhir
+	lastCleanup time.Time
+}
+
+func NewQueueUsageSampler(interval, retention time.Duration, ms *MikrotikService, repo *repository.UsageRepository, enforcer *QuotaEnforcer) *QueueUsageSampler {
+	return &QueueUsageSampler{
+		interval:    interval,
+		retention:   retention,
+		ms:          ms,
+		repo:        repo,
+		enforcer:    enforcer,
+		last:        make(map[string]queueCounter),
+		lastCleanup: time.Now(),
+	}
+}
+
+// Run - Loop sampling (blocking)
+func (s *QueueUsageSampler) Run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		now := time.Now()
+		for routerID, conn := range s.ms.GetAllConnections() {
+			if !conn.IsHealthy {
+				continue
+			}
+			s.sample(routerID, now)
+		}
+		s.enforcer.Check(now)
+		s.cleanup(now)
+	}
+}
+
+func (s *QueueUsageSampler) sample(routerID int, now time.Time) {
+	stats, err := s.ms.GetQueueStats(routerID)
+	if err != nil {
+		log.Printf("[USAGE] Error reading queues router %d: %v", routerID, err)
+		return
+	}
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, q := range stats {
+		key := fmt.Sprintf("%d/%s", routerID, q.ID)
+		prev, seen := s.last[key]
+		s.last[key] = queueCounter{upload: q.UploadBytes, download: q.DownloadBytes}
+		if !seen {
+			continue // baseline pertama, belum ada delta
+		}
+
+		upload := counterDelta(prev.upload, q.UploadBytes)
+		download := counterDelta(prev.download, q.DownloadBytes)
+		if upload == 0 && download == 0 {
+			continue
+		}
+
+		if err := s.repo.AddUsage(routerID, q.Name, day, month, upload, download); err != nil {
+			log.Printf("[USAGE] Error saving usage router %d queue %s: %v", routerID, q.Name, err)
+		}
+
+		seconds := uint64(s.interval.Seconds())
+		if seconds == 0 {
+			continue
+		}
+		if err := s.repo.InsertQueueSample(routerID, q.Name, &models.QueueRateSample{
+			UploadBps:   upload * 8 / seconds,
+			DownloadBps: download * 8 / seconds,
+			SampledAt:   now,
+		}); err != nil {
+			log.Printf("[USAGE] Error saving rate sample router %d queue %s: %v", routerID, q.Name, err)
+		}
+	}
+}
+
+// cleanup - Hapus sample rate queue lebih tua dari retention (maksimal sekali per jam)
+func (s *QueueUsageSampler) cleanup(now time.Time) {
+	if s.retention <= 0 || now.Sub(s.lastCleanup) < time.Hour {
+		return
+	}
+	s.lastCleanup = now
+
+	deleted, err := s.repo.DeleteQueueSamplesOlderThan(now.Add(-s.retention))
+	if err != nil {
+		log.Printf("[USAGE] Retention cleanup failed: %v", err)
+	} else if deleted > 0 {
+		log.Printf("[USAGE] Retention cleanup removed %d rate samples", deleted)
+	}
+}
+
+// counterDelta - Selisih counter; jika counter turun (reset/reboot) nilai baru dianggap delta
+func counterDelta(prev, cur uint64) uint64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
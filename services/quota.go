@@ -0,0 +1,272 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// quotaCommentPrefix - Comment address-list entry yang dibuat enforcement kuota
+const quotaCommentPrefix = "mikrotik-layer:quota:"
+
+// DefaultOverQuotaList - Address-list default untuk pelanggan yang melewati kuota
+const DefaultOverQuotaList = "over-quota"
+
+// SetQueueMaxLimit - Ubah max-limit simple queue berdasarkan nama, return max-limit sebelumnya
+func (ms *MikrotikService) SetQueueMaxLimit(routerID int, queueName, maxLimit string, dryRun bool) (*models.CommandPlan, string, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/queue/simple/print", fmt.Sprintf("?name=%s", queueName), "=.proplist=.id,max-limit")
+	if err != nil {
+		return nil, "", err
+	}
+	if len(r.Re) == 0 {
+		return nil, "", fmt.Errorf("queue %s not found", queueName)
+	}
+	previous := r.Re[0].Map["max-limit"]
+
+	plan := newCommandPlan(routerID, "set_queue_max_limit", dryRun)
+	plan.Checks = append(plan.Checks, fmt.Sprintf("queue %s found, current max-limit %s", queueName, previous))
+	plan.Commands = append(plan.Commands, []string{
+		"/queue/simple/set",
+		fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+		fmt.Sprintf("=max-limit=%s", maxLimit),
+	})
+
+	return plan, previous, executePlan(conn, plan)
+}
+
+// AddQuotaAddressList - Masukkan target queue ke address-list (entry lama milik queue diganti)
+func (ms *MikrotikService) AddQuotaAddressList(routerID int, queueName, list string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/queue/simple/print", fmt.Sprintf("?name=%s", queueName), "=.proplist=target")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("queue %s not found", queueName)
+	}
+
+	plan := newCommandPlan(routerID, "add_quota_address_list", dryRun)
+	if err := appendQuotaListRemovals(conn, plan, queueName); err != nil {
+		return nil, err
+	}
+
+	for _, target := range strings.Split(r.Re[0].Map["target"], ",") {
+		if target = strings.TrimSpace(target); target == "" {
+			continue
+		}
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/firewall/address-list/add",
+			fmt.Sprintf("=list=%s", list),
+			fmt.Sprintf("=address=%s", target),
+			fmt.Sprintf("=comment=%s%s", quotaCommentPrefix, queueName),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// RemoveQuotaAddressList - Hapus entry address-list yang dibuat enforcement untuk queue
+func (ms *MikrotikService) RemoveQuotaAddressList(routerID int, queueName string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	plan := newCommandPlan(routerID, "remove_quota_address_list", dryRun)
+	if err := appendQuotaListRemovals(conn, plan, queueName); err != nil {
+		return nil, err
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// appendQuotaListRemovals - Tambahkan remove untuk entry address-list milik queue; caller memegang conn.mu
+func appendQuotaListRemovals(conn *MikrotikConnection, plan *models.CommandPlan, queueName string) error {
+	r, err := conn.Run("/ip/firewall/address-list/print",
+		fmt.Sprintf("?comment=%s%s", quotaCommentPrefix, queueName), "=.proplist=.id,list,address")
+	if err != nil {
+		return err
+	}
+
+	for _, re := range r.Re {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("existing entry %s in %s removed", re.Map["address"], re.Map["list"]))
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/firewall/address-list/remove",
+			fmt.Sprintf("=.id=%s", re.Map[".id"]),
+		})
+	}
+	return nil
+}
+
+// QuotaEnforcer - Alert + aksi otomatis (throttle / address-list) saat kuota bulanan
+// terlampaui, dan revert saat pergantian bulan. Semua aksi ke router diaudit.
+type QuotaEnforcer struct {
+	ms       *MikrotikService
+	repo     *repository.UsageRepository
+	recorder *EventRecorder
+	audit    *AuditLogger
+	webhooks *WebhookDispatcher
+}
+
+func NewQuotaEnforcer(ms *MikrotikService, repo *repository.UsageRepository, recorder *EventRecorder, audit *AuditLogger, webhooks *WebhookDispatcher) *QuotaEnforcer {
+	return &QuotaEnforcer{
+		ms:       ms,
+		repo:     repo,
+		recorder: recorder,
+		audit:    audit,
+		webhooks: webhooks,
+	}
+}
+
+// Check - Revert enforcement yang kedaluwarsa lalu proses kuota yang terlampaui bulan ini
+func (e *QuotaEnforcer) Check(now time.Time) {
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	enforced, err := e.repo.EnforcedQuotas(month)
+	if err != nil {
+		log.Printf("[QUOTA] Error loading enforced quotas: %v", err)
+	}
+	for _, q := range enforced {
+		// Tetap aktif selama masih di bulan yang sama dan masih di atas kuota
+		if samePeriod(q.EnforcedPeriod, month) && q.UsedBytes > q.MonthlyQuotaBytes {
+			continue
+		}
+		e.revert(q)
+	}
+
+	exceeded, err := e.repo.ExceededQuotas(month)
+	if err != nil {
+		log.Printf("[QUOTA] Error checking quotas: %v", err)
+		return
+	}
+	for _, q := range exceeded {
+		if !samePeriod(q.AlertedPeriod, month) {
+			e.alert(q, month)
+		}
+		if q.Action != models.QuotaActionAlert && !samePeriod(q.EnforcedPeriod, month) {
+			e.enforce(q, month)
+		}
+	}
+}
+
+func (e *QuotaEnforcer) alert(q *models.QuotaStatus, month time.Time) {
+	routerID := q.RouterID
+	e.recorder.Record(&models.Event{
+		RouterID: &routerID,
+		Type:     "quota_exceeded",
+		Severity: "warning",
+		Message: fmt.Sprintf("Queue %s melewati kuota bulanan (%d / %d bytes)",
+			q.QueueName, q.UsedBytes, q.MonthlyQuotaBytes),
+		Data: mustJSON(q),
+	})
+
+	if err := e.repo.MarkQuotaAlerted(q.ID, month); err != nil {
+		log.Printf("[QUOTA] Error marking quota %d alerted: %v", q.ID, err)
+	}
+}
+
+func (e *QuotaEnforcer) enforce(q *models.QuotaStatus, month time.Time) {
+	var original *string
+
+	switch q.Action {
+	case models.QuotaActionThrottle:
+		if q.ThrottleLimit == nil || *q.ThrottleLimit == "" {
+			log.Printf("[QUOTA] Quota %d (%s): throttle_limit kosong, throttle dilewati", q.ID, q.QueueName)
+			return
+		}
+		plan, previous, err := e.ms.SetQueueMaxLimit(q.RouterID, q.QueueName, *q.ThrottleLimit, false)
+		e.audit.LogPlan("system", "quota_throttle", q.RouterID, q.QueueName, plan, err)
+		if err != nil {
+			log.Printf("[QUOTA] Throttle %s on router %d failed: %v", q.QueueName, q.RouterID, err)
+			return
+		}
+		original = &previous
+		e.emitQueueChanged(q, *q.ThrottleLimit, "quota_throttle")
+
+	case models.QuotaActionAddressList:
+		plan, err := e.ms.AddQuotaAddressList(q.RouterID, q.QueueName, quotaAddressList(q), false)
+		e.audit.LogPlan("system", "quota_address_list_add", q.RouterID, q.QueueName, plan, err)
+		if err != nil {
+			log.Printf("[QUOTA] Address-list %s on router %d failed: %v", q.QueueName, q.RouterID, err)
+			return
+		}
+
+	default:
+		return
+	}
+
+	log.Printf("[QUOTA] ✓ %s enforced on %s (router %d)", q.Action, q.QueueName, q.RouterID)
+	if err := e.repo.MarkQuotaEnforced(q.ID, month, original); err != nil {
+		log.Printf("[QUOTA] Error marking quota %d enforced: %v", q.ID, err)
+	}
+}
+
+// revert - Kembalikan max-limit asli dan hapus entry address-list milik queue
+func (e *QuotaEnforcer) revert(q *models.QuotaStatus) {
+	if q.OriginalMaxLimit != nil {
+		plan, _, err := e.ms.SetQueueMaxLimit(q.RouterID, q.QueueName, *q.OriginalMaxLimit, false)
+		e.audit.LogPlan("system", "quota_throttle_revert", q.RouterID, q.QueueName, plan, err)
+		if err != nil {
+			log.Printf("[QUOTA] Revert throttle %s on router %d failed: %v", q.QueueName, q.RouterID, err)
+			return
+		}
+		e.emitQueueChanged(q, *q.OriginalMaxLimit, "quota_throttle_revert")
+	}
+
+	plan, err := e.ms.RemoveQuotaAddressList(q.RouterID, q.QueueName, false)
+	if err != nil || len(plan.Commands) > 0 {
+		e.audit.LogPlan("system", "quota_address_list_remove", q.RouterID, q.QueueName, plan, err)
+	}
+	if err != nil {
+		log.Printf("[QUOTA] Revert address-list %s on router %d failed: %v", q.QueueName, q.RouterID, err)
+		return
+	}
+
+	log.Printf("[QUOTA] ✓ Enforcement reverted on %s (router %d)", q.QueueName, q.RouterID)
+	if err := e.repo.ClearQuotaEnforcement(q.ID); err != nil {
+		log.Printf("[QUOTA] Error clearing quota %d enforcement: %v", q.ID, err)
+	}
+}
+
+func (e *QuotaEnforcer) emitQueueChanged(q *models.QuotaStatus, maxLimit, reason string) {
+	routerID := q.RouterID
+	e.webhooks.Emit(models.WebhookQueueChanged, &routerID, map[string]interface{}{
+		"name":      q.QueueName,
+		"max_limit": maxLimit,
+		"reason":    reason,
+	})
+}
+
+func quotaAddressList(q *models.QuotaStatus) string {
+	if q.AddressList != nil && *q.AddressList != "" {
+		return *q.AddressList
+	}
+	return DefaultOverQuotaList
+}
+
+// samePeriod - Apakah tanggal periode (DATE) jatuh di bulan yang sama
+func samePeriod(period *time.Time, month time.Time) bool {
+	return period != nil && period.Year() == month.Year() && period.Month() == month.Month()
+}
@@ -0,0 +1,627 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// ReportGenerator - Render laporan PDF bulanan (availability, grafik pemakaian harian,
+// 95th percentile) per router atau per customer lalu simpan ke tabel reports.
+type ReportGenerator struct {
+	routerRepo   *repository.RouterRepository
+	customerRepo *repository.CustomerRepository
+	usageRepo    *repository.UsageRepository
+	trafficRepo  *repository.TrafficRepository
+	availRepo    *repository.AvailabilityRepository
+	reportRepo   *repository.ReportRepository
+}
+
+func NewReportGenerator(routerRepo *repository.RouterRepository, customerRepo *repository.CustomerRepository,
+	usageRepo *repository.UsageRepository, trafficRepo *repository.TrafficRepository,
+	availRepo *repository.AvailabilityRepository, reportRepo *repository.ReportRepository) *ReportGenerator {
+	return &ReportGenerator{
+		routerRepo:   routerRepo,
+		customerRepo: customerRepo,
+		usageRepo:    usageRepo,
+		trafficRepo:  trafficRepo,
+		availRepo:    availRepo,
+		reportRepo:   reportRepo,
+	}
+}
+
+// Generate - Buat (atau buat ulang) laporan satu target untuk bulan tertentu.
+// Target tidak ditemukan dikembalikan sebagai error; kegagalan baca data disimpan
+// sebagai laporan berstatus failed.
+func (g *ReportGenerator) Generate(scope string, targetID int, month time.Time) (*models.Report, error) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	to := from.AddDate(0, 1, 0)
+
+	report := &models.Report{Scope: scope, TargetID: targetID, Period: from}
+
+	var doc *pdfDocument
+	var err error
+	switch scope {
+	case models.ReportScopeRouter:
+		router, lookupErr := g.routerRepo.GetByID(targetID)
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		report.TargetName = router.Name
+		doc, err = g.routerReport(router, from, to)
+
+	case models.ReportScopeCustomer:
+		customer, lookupErr := g.customerRepo.GetByID(targetID)
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		report.TargetName = customer.Name
+		doc, err = g.customerReport(customer, from, to)
+
+	default:
+		return nil, fmt.Errorf("invalid report scope %s", scope)
+	}
+
+	var content []byte
+	if err != nil {
+		msg := err.Error()
+		report.Status = models.ReportStatusFailed
+		report.Error = &msg
+		log.Printf("[REPORT] ❌ %s %d (%s) failed: %v", scope, targetID, from.Format("2006-01"), err)
+	} else {
+		report.Status = models.ReportStatusReady
+		content = doc.Bytes()
+	}
+
+	return g.reportRepo.Save(report, content)
+}
+
+// routerReport - Availability router + traffic interface WAN (rata-rata harian & 95th percentile)
+func (g *ReportGenerator) routerReport(router *models.Router, from, to time.Time) (*pdfDocument, error) {
+	w := newReportWriter()
+	w.title("Laporan Bulanan Router", fmt.Sprintf("%s (%s)", router.Name, router.Hostname), from, to)
+
+	if err := g.writeAvailability(w, router, from, to); err != nil {
+		return nil, err
+	}
+
+	interfaces := splitInterfaces(router.WANInterfaces)
+	if len(interfaces) == 0 {
+		sampled, err := g.trafficRepo.ListSampledInterfaces(from)
+		if err != nil {
+			return nil, err
+		}
+		for _, si := range sampled {
+			if si.RouterID == router.ID {
+				interfaces = append(interfaces, si.Interface)
+			}
+		}
+		sort.Strings(interfaces)
+	}
+
+	if len(interfaces) == 0 {
+		w.section("Traffic")
+		w.field("Data", "Tidak ada history traffic untuk periode ini")
+		return w.doc, nil
+	}
+
+	for _, iface := range interfaces {
+		days := newDailySeries(from, to, 2)
+		var rx, tx []float64
+		var rxBytes, txBytes uint64
+		var prev *models.TrafficSample
+
+		err := g.trafficRepo.EachHistory(router.ID, iface, from, to, func(s *models.TrafficSample) error {
+			rx = append(rx, float64(s.RxBps))
+			tx = append(tx, float64(s.TxBps))
+			days.add(s.SampledAt, float64(s.RxBps), float64(s.TxBps))
+			if prev != nil {
+				rxBytes += counterDelta(uint64(prev.RxBytes), uint64(s.RxBytes))
+				txBytes += counterDelta(uint64(prev.TxBytes), uint64(s.TxBytes))
+			}
+			prev = s
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		w.section("Interface " + iface)
+		if len(rx) == 0 {
+			w.field("Data", "Tidak ada sample traffic untuk periode ini")
+			continue
+		}
+
+		p95Rx, p95Tx := percentile95(rx), percentile95(tx)
+		w.field("Sample", strconv.Itoa(len(rx)))
+		w.field("Rata-rata Rx / Tx", formatBps(mean(rx))+" / "+formatBps(mean(tx)))
+		w.field("95th percentile Rx / Tx", formatBps(p95Rx)+" / "+formatBps(p95Tx))
+		w.field("Maksimum Rx / Tx", formatBps(maxOf(rx))+" / "+formatBps(maxOf(tx)))
+		w.field("Total Rx / Tx", formatBytes(rxBytes)+" / "+formatBytes(txBytes))
+
+		averages := days.averages()
+		w.chart("Rata-rata harian "+iface, days.labels(), []chartSeries{
+			{name: "Rx", color: colorDownload, values: averages[0]},
+			{name: "Tx", color: colorUpload, values: averages[1]},
+		}, []chartLine{
+			{name: "95th Rx", color: colorP95, value: p95Rx},
+			{name: "95th Tx", color: colorP95Tx, value: p95Tx},
+		}, formatBps)
+	}
+
+	return w.doc, nil
+}
+
+// customerReport - Availability router customer + pemakaian queue (harian, kuota, 95th percentile)
+func (g *ReportGenerator) customerReport(customer *models.Customer, from, to time.Time) (*pdfDocument, error) {
+	router, err := g.routerRepo.GetByID(customer.RouterID)
+	if err != nil {
+		return nil, err
+	}
+
+	w := newReportWriter()
+	w.title("Laporan Bulanan Pelanggan", fmt.Sprintf("%s - router %s", customer.Name, router.Name), from, to)
+
+	if err := g.writeAvailability(w, router, from, to); err != nil {
+		return nil, err
+	}
+
+	w.section("Pemakaian")
+	if customer.QueueName == nil || *customer.QueueName == "" {
+		w.field("Data", "Pelanggan tidak terhubung ke simple queue")
+		return w.doc, nil
+	}
+	queue := *customer.QueueName
+	w.field("Queue", queue)
+
+	monthly, err := g.usageRepo.List(&models.UsageFilter{
+		RouterID: router.ID, QueueName: queue, Period: "month", From: from, To: from,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(monthly) == 0 {
+		w.field("Total", "Tidak ada pemakaian tercatat")
+	} else {
+		u := monthly[0]
+		w.field("Upload / Download", formatBytes(u.UploadBytes)+" / "+formatBytes(u.DownloadBytes))
+		w.field("Total", formatBytes(u.TotalBytes))
+		if u.QuotaBytes != nil && *u.QuotaBytes > 0 {
+			w.field("Kuota", fmt.Sprintf("%s (%.1f%% terpakai)", formatBytes(*u.QuotaBytes),
+				float64(u.TotalBytes)/float64(*u.QuotaBytes)*100))
+		}
+	}
+
+	var up, down []float64
+	err = g.usageRepo.EachQueueSample(router.ID, queue, from, to, func(s *models.QueueRateSample) error {
+		up = append(up, float64(s.UploadBps))
+		down = append(down, float64(s.DownloadBps))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(up) > 0 {
+		w.field("95th percentile Up / Down", formatBps(percentile95(up))+" / "+formatBps(percentile95(down)))
+	}
+
+	daily, err := g.usageRepo.List(&models.UsageFilter{
+		RouterID: router.ID, QueueName: queue, Period: "day", From: from, To: to.AddDate(0, 0, -1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	days := newDailySeries(from, to, 2)
+	for _, u := range daily {
+		days.add(u.PeriodStart, float64(u.DownloadBytes), float64(u.UploadBytes))
+	}
+	totals := days.totals()
+	w.chart("Pemakaian harian", days.labels(), []chartSeries{
+		{name: "Download", color: colorDownload, values: totals[0]},
+		{name: "Upload", color: colorUpload, values: totals[1]},
+	}, nil, func(v float64) string { return formatBytes(uint64(v)) })
+
+	return w.doc, nil
+}
+
+func (g *ReportGenerator) writeAvailability(w *reportWriter, router *models.Router, from, to time.Time) error {
+	checks, upChecks, err := g.availRepo.Summary(router.ID, from, to)
+	if err != nil {
+		return err
+	}
+
+	w.section("Availability")
+	if checks == 0 {
+		w.field("Availability", "Tidak ada data pengecekan")
+	} else {
+		w.field("Availability", fmt.Sprintf("%.2f%%", float64(upChecks)/float64(checks)*100))
+		w.field("Pengecekan (up / total)", fmt.Sprintf("%d / %d", upChecks, checks))
+	}
+
+	// Durasi per status dari timeline transisi
+	history, err := StatusTimeline(g.routerRepo, router, from, to)
+	if err != nil {
+		return err
+	}
+	// Insiden = transisi dari status sehat ke offline/error (offline -> error tidak dihitung dua kali)
+	down := func(status string) bool { return status == "offline" || status == "error" }
+	incidents := 0
+	for _, c := range history.Transitions {
+		if down(c.Status) && (c.FromStatus == nil || !down(*c.FromStatus)) {
+			incidents++
+		}
+	}
+	for _, status := range []string{"online", "offline", "error", "suspended"} {
+		if seconds, ok := history.Durations[status]; ok {
+			w.field("Durasi "+status, formatDuration(time.Duration(seconds)*time.Second))
+		}
+	}
+	w.field("Insiden (offline / error)", strconv.Itoa(incidents))
+	return nil
+}
+
+// formatDuration - Durasi ringkas untuk laporan, mis. 3h 12m
+func formatDuration(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, d/time.Hour, (d%time.Hour)/time.Minute)
+	}
+	return fmt.Sprintf("%dh %dm", d/time.Hour, (d%time.Hour)/time.Minute)
+}
+
+// ReportScheduler - Generate laporan bulan lalu untuk semua router dan customer
+// di awal bulan (laporan yang sudah ada tidak dibuat ulang).
+type ReportScheduler struct {
+	gen          *ReportGenerator
+	routerRepo   *repository.RouterRepository
+	customerRepo *repository.CustomerRepository
+	reportRepo   *repository.ReportRepository
+	lastPeriod   time.Time
+}
+
+func NewReportScheduler(gen *ReportGenerator, routerRepo *repository.RouterRepository,
+	customerRepo *repository.CustomerRepository, reportRepo *repository.ReportRepository) *ReportScheduler {
+	return &ReportScheduler{
+		gen:          gen,
+		routerRepo:   routerRepo,
+		customerRepo: customerRepo,
+		reportRepo:   reportRepo,
+	}
+}
+
+// Run - Cek tiap jam apakah laporan bulan lalu sudah lengkap (blocking)
+func (s *ReportScheduler) Run() {
+	s.generateMonthly(time.Now())
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		s.generateMonthly(now)
+	}
+}
+
+func (s *ReportScheduler) generateMonthly(now time.Time) {
+	period := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+	if period.Equal(s.lastPeriod) {
+		return
+	}
+
+	routers, err := s.routerRepo.GetAll()
+	if err != nil {
+		log.Printf("[REPORT] Error loading routers: %v", err)
+		return
+	}
+	customers, err := s.customerRepo.List(models.CustomerFilter{})
+	if err != nil {
+		log.Printf("[REPORT] Error loading customers: %v", err)
+		return
+	}
+
+	generated := 0
+	for _, router := range routers {
+		if s.generateMissing(models.ReportScopeRouter, router.ID, period) {
+			generated++
+		}
+	}
+	for _, customer := range customers {
+		if s.generateMissing(models.ReportScopeCustomer, customer.ID, period) {
+			generated++
+		}
+	}
+
+	s.lastPeriod = period
+	if generated > 0 {
+		log.Printf("[REPORT] ✓ Generated %d reports for %s", generated, period.Format("2006-01"))
+	}
+}
+
+func (s *ReportScheduler) generateMissing(scope string, targetID int, period time.Time) bool {
+	exists, err := s.reportRepo.Exists(scope, targetID, period)
+	if err != nil {
+		log.Printf("[REPORT] Error checking %s %d: %v", scope, targetID, err)
+		return false
+	}
+	if exists {
+		return false
+	}
+
+	if _, err := s.gen.Generate(scope, targetID, period); err != nil {
+		log.Printf("[REPORT] Error generating %s %d: %v", scope, targetID, err)
+		return false
+	}
+	return true
+}
+
+// dailySeries - Akumulasi nilai per hari dalam satu bulan untuk grafik
+type dailySeries struct {
+	from   time.Time
+	sums   [][]float64
+	counts []int
+}
+
+func newDailySeries(from, to time.Time, series int) *dailySeries {
+	days := int(math.Round(to.Sub(from).Hours() / 24))
+	d := &dailySeries{from: from, sums: make([][]float64, series), counts: make([]int, days)}
+	for i := range d.sums {
+		d.sums[i] = make([]float64, days)
+	}
+	return d
+}
+
+func (d *dailySeries) add(t time.Time, values ...float64) {
+	day := t.In(d.from.Location()).Day() - 1
+	if day < 0 || day >= len(d.counts) {
+		return
+	}
+	d.counts[day]++
+	for i, v := range values {
+		d.sums[i][day] += v
+	}
+}
+
+func (d *dailySeries) totals() [][]float64 {
+	return d.sums
+}
+
+func (d *dailySeries) averages() [][]float64 {
+	avg := make([][]float64, len(d.sums))
+	for i, sums := range d.sums {
+		avg[i] = make([]float64, len(sums))
+		for day, sum := range sums {
+			if d.counts[day] > 0 {
+				avg[i][day] = sum / float64(d.counts[day])
+			}
+		}
+	}
+	return avg
+}
+
+func (d *dailySeries) labels() []string {
+	labels := make([]string, len(d.counts))
+	for i := range labels {
+		labels[i] = strconv.Itoa(i + 1)
+	}
+	return labels
+}
+
+// percentile95 - Nilai 95th percentile (nearest-rank) seperti billing burstable
+func percentile95(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	idx := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func maxOf(values []float64) float64 {
+	var m float64
+	for _, v := range values {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
+// formatBytes - Ukuran dalam satuan desimal (KB = 1000 B)
+func formatBytes(b uint64) string {
+	return formatUnit(float64(b), []string{"B", "KB", "MB", "GB", "TB"})
+}
+
+// formatBps - Rate bit per detik dalam satuan desimal
+func formatBps(bps float64) string {
+	return formatUnit(bps, []string{"bps", "kbps", "Mbps", "Gbps", "Tbps"})
+}
+
+func formatUnit(v float64, units []string) string {
+	i := 0
+	for v >= 1000 && i < len(units)-1 {
+		v /= 1000
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", v, units[i])
+	}
+	return fmt.Sprintf("%.2f %s", v, units[i])
+}
+
+// Warna grafik laporan (RGB 0-1)
+var (
+	colorDownload = [3]float64{0.20, 0.45, 0.80}
+	colorUpload   = [3]float64{0.95, 0.55, 0.15}
+	colorP95      = [3]float64{0.85, 0.15, 0.15}
+	colorP95Tx    = [3]float64{0.55, 0.25, 0.05}
+	colorMuted    = [3]float64{0.45, 0.45, 0.45}
+)
+
+type chartSeries struct {
+	name   string
+	color  [3]float64
+	values []float64
+}
+
+// chartLine - Garis referensi horizontal (mis. 95th percentile)
+type chartLine struct {
+	name  string
+	color [3]float64
+	value float64
+}
+
+// reportWriter - Layout laporan vertikal di atas pdfDocument, pindah halaman otomatis
+type reportWriter struct {
+	doc *pdfDocument
+	y   float64
+}
+
+const (
+	reportMargin      = 50.0
+	reportChartHeight = 150.0
+)
+
+func newReportWriter() *reportWriter {
+	return &reportWriter{doc: newPDFDocument(), y: reportMargin}
+}
+
+func (w *reportWriter) ensure(height float64) {
+	if w.y+height > pdfPageHeight-reportMargin {
+		w.doc.AddPage()
+		w.y = reportMargin
+	}
+}
+
+func (w *reportWriter) title(title, subtitle string, from, to time.Time) {
+	w.doc.SetFillColor(0, 0, 0)
+	w.doc.Text(reportMargin, w.y+10, 18, true, title)
+	w.doc.Text(reportMargin, w.y+30, 12, false, subtitle)
+
+	w.doc.SetFillColor(colorMuted[0], colorMuted[1], colorMuted[2])
+	w.doc.Text(reportMargin, w.y+46, 9, false, fmt.Sprintf("Periode %s (%s s/d %s) - dibuat %s",
+		from.Format("January 2006"), from.Format("2006-01-02"), to.AddDate(0, 0, -1).Format("2006-01-02"),
+		time.Now().Format("2006-01-02 15:04")))
+
+	w.doc.SetStrokeColor(0.8, 0.8, 0.8)
+	w.doc.Line(reportMargin, w.y+54, pdfPageWidth-reportMargin, w.y+54, 0.5, false)
+	w.y += 70
+}
+
+func (w *reportWriter) section(name string) {
+	w.ensure(40)
+	w.y += 10
+	w.doc.SetFillColor(0, 0, 0)
+	w.doc.Text(reportMargin, w.y, 12, true, name)
+	w.y += 16
+}
+
+func (w *reportWriter) field(label, value string) {
+	w.ensure(14)
+	w.doc.SetFillColor(colorMuted[0], colorMuted[1], colorMuted[2])
+	w.doc.Text(reportMargin, w.y, 9, false, label)
+	w.doc.SetFillColor(0, 0, 0)
+	w.doc.Text(reportMargin+170, w.y, 9, false, value)
+	w.y += 14
+}
+
+// chart - Grafik batang per hari (satu batang per series) dengan garis referensi opsional
+func (w *reportWriter) chart(title string, labels []string, series []chartSeries, lines []chartLine, format func(float64) string) {
+	w.ensure(reportChartHeight + 60)
+	w.y += 8
+	w.doc.SetFillColor(0, 0, 0)
+	w.doc.Text(reportMargin, w.y, 10, true, title)
+	w.y += 10
+
+	left := reportMargin + 55
+	width := pdfPageWidth - reportMargin - left
+	top := w.y
+	bottom := top + reportChartHeight
+
+	maxValue := 0.0
+	for _, s := range series {
+		maxValue = math.Max(maxValue, maxOf(s.values))
+	}
+	for _, l := range lines {
+		maxValue = math.Max(maxValue, l.value)
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+
+	// Sumbu dan label skala
+	w.doc.SetStrokeColor(0.6, 0.6, 0.6)
+	w.doc.Line(left, top, left, bottom, 0.5, false)
+	w.doc.Line(left, bottom, left+width, bottom, 0.5, false)
+	w.doc.SetFillColor(colorMuted[0], colorMuted[1], colorMuted[2])
+	w.doc.Text(reportMargin, top+6, 7, false, format(maxValue))
+	w.doc.Text(reportMargin, bottom, 7, false, format(0))
+
+	if len(labels) > 0 && len(series) > 0 {
+		group := width / float64(len(labels))
+		bar := group * 0.8 / float64(len(series))
+		for i, label := range labels {
+			x := left + float64(i)*group + group*0.1
+			for _, s := range series {
+				if i < len(s.values) && s.values[i] > 0 {
+					h := s.values[i] / maxValue * reportChartHeight
+					w.doc.SetFillColor(s.color[0], s.color[1], s.color[2])
+					w.doc.FillRect(x, bottom-h, bar, h)
+				}
+				x += bar
+			}
+			if len(labels) <= 15 || i%5 == 0 {
+				w.doc.SetFillColor(colorMuted[0], colorMuted[1], colorMuted[2])
+				w.doc.Text(left+float64(i)*group+group*0.1, bottom+10, 7, false, label)
+			}
+		}
+	}
+
+	for _, l := range lines {
+		y := bottom - l.value/maxValue*reportChartHeight
+		w.doc.SetStrokeColor(l.color[0], l.color[1], l.color[2])
+		w.doc.Line(left, y, left+width, y, 0.8, true)
+	}
+
+	// Legend
+	x := left
+	legendY := bottom + 24
+	for _, s := range series {
+		w.doc.SetFillColor(s.color[0], s.color[1], s.color[2])
+		w.doc.FillRect(x, legendY-7, 8, 8)
+		w.doc.SetFillColor(0, 0, 0)
+		w.doc.Text(x+12, legendY, 8, false, s.name)
+		x += 80
+	}
+	for _, l := range lines {
+		w.doc.SetStrokeColor(l.color[0], l.color[1], l.color[2])
+		w.doc.Line(x, legendY-3, x+10, legendY-3, 0.8, true)
+		w.doc.SetFillColor(0, 0, 0)
+		w.doc.Text(x+14, legendY, 8, false, fmt.Sprintf("%s (%s)", l.name, format(l.value)))
+		x += 140
+	}
+
+	w.y = legendY + 16
+}
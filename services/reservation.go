@@ -0,0 +1,291 @@
+package services
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// reservationCommentPrefix - Comment lease, ARP dan queue yang dibuat provisioning IP tetap customer
+const reservationCommentPrefix = "mikrotik-layer:customer:"
+
+// ReservationConflictError - IP / MAC / queue sudah dipakai pihak lain di router atau customer lain (409)
+type ReservationConflictError struct {
+	Reason string
+}
+
+func (e *ReservationConflictError) Error() string {
+	return "static reservation conflict: " + e.Reason
+}
+
+// ErrorCode - Code machine-readable untuk response API
+func (e *ReservationConflictError) ErrorCode() string {
+	return i18n.ReservationConflict
+}
+
+func reservationConflict(format string, args ...interface{}) error {
+	return &ReservationConflictError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// ProvisionStaticReservation - Buat static lease, ARP statis dan simple queue untuk satu IP tetap.
+// Semua konflik dicek dulu sebelum ada yang diubah; item yang sudah persis sama dilewati
+// (idempoten) dan lease dinamis MAC tsb diganti lease statis. res.Interface diisi dari subnet
+// router yang memuat alamat. Queue dibuat lebih dulu; jika command berikutnya gagal semua command
+// dibatalkan, termasuk lease / ARP dinamis yang sudah dihapus (ditambahkan ulang sebagai entri
+// statis dengan alamat, MAC dan server / interface yang sama).
+func (ms *MikrotikService) ProvisionStaticReservation(routerID int, res *models.StaticReservation, dryRun bool) (*models.CommandPlan, error) {
+	addr, err := netip.ParseAddr(res.Address)
+	if err != nil || !addr.Is4() {
+		return nil, fmt.Errorf("invalid IPv4 address %s", res.Address)
+	}
+
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	plan := newCommandPlan(routerID, "provision_static_reservation", dryRun)
+
+	r, err := conn.Run("/ip/address/print", "=.proplist=address,interface,disabled")
+	if err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		prefix, err := netip.ParsePrefix(re.Map["address"])
+		if err != nil || re.Map["disabled"] == "true" || !prefix.Contains(addr) {
+			continue
+		}
+		if prefix.Addr() == addr {
+			return nil, reservationConflict("%s is an address of router interface %s", addr, re.Map["interface"])
+		}
+		res.Interface = re.Map["interface"]
+		plan.Checks = append(plan.Checks, fmt.Sprintf("%s is in subnet %s on %s", addr, prefix.Masked(), res.Interface))
+		break
+	}
+	if res.Interface == "" {
+		return nil, reservationConflict("%s is not in any subnet of router %d", addr, routerID)
+	}
+
+	if res.Server != "" {
+		r, err := conn.Run("/ip/dhcp-server/print", fmt.Sprintf("?name=%s", res.Server), "=.proplist=name")
+		if err != nil {
+			return nil, err
+		}
+		if len(r.Re) == 0 {
+			return nil, reservationConflict("dhcp server %s not found", res.Server)
+		}
+	}
+
+	// Lease: IP tidak boleh dipegang MAC lain, MAC tidak boleh sudah punya static lease lain
+	r, err = conn.Run("/ip/dhcp-server/lease/print", fmt.Sprintf("?address=%s", addr),
+		fmt.Sprintf("?mac-address=%s", res.MacAddress), "?#|", "=.proplist=.id,address,mac-address,server,dynamic")
+	if err != nil {
+		return nil, err
+	}
+	// Lease / ARP dinamis yang diganti, beserta command untuk menambahkannya lagi saat rollback
+	var removals, restores [][]string
+	leaseExists := false
+	for _, re := range r.Re {
+		sameAddr := re.Map["address"] == res.Address
+		sameMAC := strings.EqualFold(re.Map["mac-address"], res.MacAddress)
+		dynamic := re.Map["dynamic"] == "true"
+		switch {
+		case sameAddr && sameMAC && !dynamic:
+			leaseExists = true
+			plan.Checks = append(plan.Checks, fmt.Sprintf("static lease %s -> %s already exists", res.MacAddress, addr))
+		case sameAddr && !sameMAC:
+			return nil, reservationConflict("%s is leased to %s", addr, re.Map["mac-address"])
+		case sameMAC && dynamic:
+			plan.Checks = append(plan.Checks, fmt.Sprintf("dynamic lease %s of %s replaced", re.Map["address"], res.MacAddress))
+			removals = append(removals, []string{"/ip/dhcp-server/lease/remove", fmt.Sprintf("=.id=%s", re.Map[".id"])})
+			restore := []string{
+				"/ip/dhcp-server/lease/add",
+				fmt.Sprintf("=address=%s", re.Map["address"]),
+				fmt.Sprintf("=mac-address=%s", re.Map["mac-address"]),
+			}
+			if server := re.Map["server"]; server != "" && server != "all" {
+				restore = append(restore, fmt.Sprintf("=server=%s", server))
+			}
+			restores = append(restores, restore)
+		case sameMAC:
+			return nil, reservationConflict("%s already has a static lease for %s", res.MacAddress, re.Map["address"])
+		}
+	}
+
+	// ARP: IP tidak boleh terlihat dari MAC lain, MAC tidak boleh sudah punya ARP statis lain
+	r, err = conn.Run("/ip/arp/print", fmt.Sprintf("?address=%s", addr),
+		fmt.Sprintf("?mac-address=%s", res.MacAddress), "?#|", "=.proplist=.id,address,mac-address,interface,dynamic")
+	if err != nil {
+		return nil, err
+	}
+	arpExists := false
+	for _, re := range r.Re {
+		sameAddr := re.Map["address"] == res.Address
+		sameMAC := strings.EqualFold(re.Map["mac-address"], res.MacAddress)
+		dynamic := re.Map["dynamic"] == "true"
+		switch {
+		case sameAddr && sameMAC && !dynamic:
+			arpExists = true
+			plan.Checks = append(plan.Checks, fmt.Sprintf("static arp %s -> %s already exists", addr, res.MacAddress))
+		case sameAddr && sameMAC:
+			removals = append(removals, []string{"/ip/arp/remove", fmt.Sprintf("=.id=%s", re.Map[".id"])})
+			restores = append(restores, []string{
+				"/ip/arp/add",
+				fmt.Sprintf("=address=%s", re.Map["address"]),
+				fmt.Sprintf("=mac-address=%s", re.Map["mac-address"]),
+				fmt.Sprintf("=interface=%s", re.Map["interface"]),
+			})
+		case sameAddr && re.Map["mac-address"] != "":
+			return nil, reservationConflict("%s is in use by %s (arp)", addr, re.Map["mac-address"])
+		case sameMAC && !dynamic:
+			return nil, reservationConflict("%s already has a static arp entry for %s", res.MacAddress, re.Map["address"])
+		}
+	}
+
+	// Queue: nama yang sama harus sudah menargetkan IP ini, IP tidak boleh ditarget queue lain
+	r, err = conn.Run("/queue/simple/print", "=.proplist=name,target")
+	if err != nil {
+		return nil, err
+	}
+	queueExists := false
+	for _, re := range r.Re {
+		targeted := false
+		for _, target := range strings.Split(re.Map["target"], ",") {
+			target = strings.TrimSpace(target)
+			if target == res.Address || target == res.Address+"/32" {
+				targeted = true
+			}
+		}
+		switch {
+		case re.Map["name"] == res.QueueName && targeted:
+			queueExists = true
+			plan.Checks = append(plan.Checks, fmt.Sprintf("queue %s already targets %s", res.QueueName, addr))
+		case re.Map["name"] == res.QueueName:
+			return nil, reservationConflict("queue %s already exists with target %s", res.QueueName, re.Map["target"])
+		case targeted:
+			return nil, reservationConflict("%s is already targeted by queue %s", addr, re.Map["name"])
+		}
+	}
+
+	// Queue tidak bergantung pada lease / ARP, jadi dibuat sebelum ada yang dihapus
+	if !queueExists {
+		add := []string{
+			"/queue/simple/add",
+			fmt.Sprintf("=name=%s", res.QueueName),
+			fmt.Sprintf("=target=%s/32", addr),
+			fmt.Sprintf("=comment=%s", res.Comment),
+		}
+		plan.Commands = append(plan.Commands, append(add, res.Limits...))
+	}
+	restore := make(map[int][]string, len(removals))
+	for i, remove := range removals {
+		restore[len(plan.Commands)] = restores[i]
+		plan.Commands = append(plan.Commands, remove)
+	}
+	if !leaseExists {
+		add := []string{
+			"/ip/dhcp-server/lease/add",
+			fmt.Sprintf("=address=%s", addr),
+			fmt.Sprintf("=mac-address=%s", res.MacAddress),
+			fmt.Sprintf("=comment=%s", res.Comment),
+		}
+		if res.Server != "" {
+			add = append(add, fmt.Sprintf("=server=%s", res.Server))
+		}
+		plan.Commands = append(plan.Commands, add)
+	}
+	if !arpExists {
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/arp/add",
+			fmt.Sprintf("=address=%s", addr),
+			fmt.Sprintf("=mac-address=%s", res.MacAddress),
+			fmt.Sprintf("=interface=%s", res.Interface),
+			fmt.Sprintf("=comment=%s", res.Comment),
+		})
+	}
+
+	return plan, executePlanAtomic(conn, plan, restore)
+}
+
+// ReservationService - Provisioning customer IP tetap (static lease + ARP + queue) dari data
+// customer; hasilnya disimpan kembali ke customer (address & queue_name)
+type ReservationService struct {
+	ms        *MikrotikService
+	customers *repository.CustomerRepository
+	plans     *repository.PlanRepository
+	audit     *AuditLogger
+}
+
+func NewReservationService(ms *MikrotikService, customers *repository.CustomerRepository, plans *repository.PlanRepository, audit *AuditLogger) *ReservationService {
+	return &ReservationService{
+		ms:        ms,
+		customers: customers,
+		plans:     plans,
+		audit:     audit,
+	}
+}
+
+// Provision - Reservasi IP tetap customer. Address dan queue diambil dari request atau data
+// customer; limit queue dari plan customer, atau rate_limit request jika customer tanpa plan.
+func (s *ReservationService) Provision(c *models.Customer, req *models.StaticReservationRequest, dryRun bool) (*models.StaticReservationResult, error) {
+	res := &models.StaticReservation{
+		CustomerID: c.ID,
+		Comment:    fmt.Sprintf("%s%d %s", reservationCommentPrefix, c.ID, c.Name),
+		Address:    derefString(req.Address),
+		MacAddress: strings.ToUpper(req.MacAddress),
+		Server:     derefString(req.Server),
+		QueueName:  derefString(req.QueueName),
+	}
+	if res.Address == "" {
+		res.Address = derefString(c.Address)
+	}
+	if res.QueueName == "" {
+		res.QueueName = derefString(c.QueueName)
+	}
+	if res.QueueName == "" {
+		res.QueueName = c.Name
+	}
+
+	if c.PlanID != nil {
+		plan, err := s.plans.GetByID(*c.PlanID)
+		if err != nil {
+			return nil, err
+		}
+		res.Limits = planQueueLimits(plan)
+	} else if req.RateLimit != nil && *req.RateLimit != "" {
+		res.Limits = []string{fmt.Sprintf("=max-limit=%s", *req.RateLimit)}
+	} else {
+		return nil, fmt.Errorf("customer %d has no plan and no rate_limit was given", c.ID)
+	}
+
+	if other, err := s.customers.GetByAddress(c.RouterID, res.Address); err == nil && other.ID != c.ID {
+		return nil, reservationConflict("%s belongs to customer %s", res.Address, other.Name)
+	}
+
+	plan, err := s.ms.ProvisionStaticReservation(c.RouterID, res, dryRun)
+	result := &models.StaticReservationResult{Customer: c, Reservation: res, Plan: plan}
+	if dryRun {
+		return result, err
+	}
+	if plan != nil || err != nil {
+		s.audit.LogPlan("api", "customer_reservation", c.RouterID, c.Name, plan, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Customer, err = s.customers.Update(c.ID, &models.CustomerUpdateRequest{
+		Address:   &res.Address,
+		QueueName: &res.QueueName,
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"log"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+const (
+	resolverInterval   = time.Minute
+	resolverMinBackoff = 5 * time.Second
+	resolverMaxBackoff = 5 * time.Minute
+)
+
+// routerResolver - Cocokkan source IP datagram (syslog, flow export) dengan router
+// terdaftar. Index dibangun di goroutine background (hostname, alamat management
+// tambahan, DNS di-resolve di sana); match sendiri hanya lookup map sehingga flood
+// datagram tidak ikut membanjiri database atau DNS.
+type routerResolver struct {
+	repo      *repository.RouterRepository
+	logPrefix string
+	lookup    func(host string) ([]string, error)
+	once      sync.Once
+
+	mu        sync.RWMutex
+	byIP      map[string]int   // source IP -> RouterID (hanya yang unik)
+	ambiguous map[string][]int // source IP dipakai beberapa router (mis. NAT bersama), datagram ditolak
+}
+
+func newRouterResolver(repo *repository.RouterRepository, logPrefix string) *routerResolver {
+	return &routerResolver{
+		repo:      repo,
+		logPrefix: logPrefix,
+		lookup:    net.LookupHost,
+		byIP:      make(map[string]int),
+		ambiguous: make(map[string][]int),
+	}
+}
+
+// start - Jalankan refresh background (sekali per resolver)
+func (r *routerResolver) start() {
+	r.once.Do(func() {
+		go Supervise(r.logPrefix+"-resolver", r.refreshRoutine)
+	})
+}
+
+// match - RouterID untuk source IP, false jika tidak dikenal atau dipakai lebih dari satu router
+func (r *routerResolver) match(ip string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	routerID, ok := r.byIP[ip]
+	return routerID, ok
+}
+
+// refreshRoutine - Refresh index tiap menit; saat gagal dicoba ulang dengan backoff
+func (r *routerResolver) refreshRoutine() {
+	backoff := resolverMinBackoff
+	for {
+		if err := r.refresh(); err != nil {
+			log.Printf("[%s] Error loading routers: %v (retry in %v)", r.logPrefix, err, backoff)
+			time.Sleep(backoff)
+			backoff = min(backoff*2, resolverMaxBackoff)
+			continue
+		}
+		backoff = resolverMinBackoff
+		time.Sleep(resolverInterval)
+	}
+}
+
+func (r *routerResolver) refresh() error {
+	routers, err := r.repo.GetAll()
+	if err != nil {
+		return err
+	}
+	addresses, err := r.repo.ListAllAddresses()
+	if err != nil {
+		return err
+	}
+
+	byIP, ambiguous := buildSourceIndex(routers, addresses, r.lookup)
+
+	r.mu.Lock()
+	previous := r.ambiguous
+	r.byIP, r.ambiguous = byIP, ambiguous
+	r.mu.Unlock()
+
+	for ip, ids := range ambiguous {
+		if !slices.Equal(previous[ip], ids) {
+			log.Printf("[%s] Source %s is shared by routers %v; datagrams from it are dropped", r.logPrefix, ip, ids)
+		}
+	}
+	return nil
+}
+
+// buildSourceIndex - Petakan IP (hostname + alamat tambahan, DNS di-resolve) ke router.
+// IP yang dipakai lebih dari satu router dipisah ke ambiguous.
+func buildSourceIndex(routers []*models.Router, addresses []*models.RouterAddress,
+	lookup func(string) ([]string, error)) (map[string]int, map[string][]int) {
+
+	owners := make(map[string][]int)
+	add := func(host string, routerID int) {
+		ips := []string{host}
+		if net.ParseIP(host) == nil {
+			var err error
+			if ips, err = lookup(host); err != nil {
+				return
+			}
+		}
+		for _, ip := range ips {
+			if !slices.Contains(owners[ip], routerID) {
+				owners[ip] = append(owners[ip], routerID)
+			}
+		}
+	}
+
+	known := make(map[int]bool, len(routers))
+	for _, router := range routers {
+		if router.IsVirtual {
+			continue
+		}
+		known[router.ID] = true
+		add(router.Hostname, router.ID)
+	}
+	for _, a := range addresses {
+		if known[a.RouterID] {
+			add(a.Address, a.RouterID)
+		}
+	}
+
+	byIP := make(map[string]int, len(owners))
+	ambiguous := make(map[string][]int)
+	for ip, ids := range owners {
+		if len(ids) > 1 {
+			slices.Sort(ids)
+			ambiguous[ip] = ids
+			continue
+		}
+		byIP[ip] = ids[0]
+	}
+	return byIP, ambiguous
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"Mikrotik-Layer/models"
+)
+
+func TestBuildSourceIndex(t *testing.T) {
+	lookup := func(host string) ([]string, error) {
+		switch host {
+		case "edge.example.net":
+			return []string{"198.51.100.7", "2001:db8::7"}, nil
+		case "nat.example.net":
+			return []string{"203.0.113.1"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	tests := []struct {
+		name          string
+		routers       []*models.Router
+		addresses     []*models.RouterAddress
+		wantByIP      map[string]int
+		wantAmbiguous map[string][]int
+	}{
+		{
+			name:          "literal and dns hostnames",
+			routers:       []*models.Router{{ID: 1, Hostname: "192.0.2.1"}, {ID: 2, Hostname: "edge.example.net"}},
+			wantByIP:      map[string]int{"192.0.2.1": 1, "198.51.100.7": 2, "2001:db8::7": 2},
+			wantAmbiguous: map[string][]int{},
+		},
+		{
+			name:          "secondary addresses",
+			routers:       []*models.Router{{ID: 1, Hostname: "192.0.2.1"}},
+			addresses:     []*models.RouterAddress{{RouterID: 1, Address: "10.0.0.1"}, {RouterID: 1, Address: "192.0.2.1"}},
+			wantByIP:      map[string]int{"192.0.2.1": 1, "10.0.0.1": 1},
+			wantAmbiguous: map[string][]int{},
+		},
+		{
+			name:          "shared nat address is ambiguous",
+			routers:       []*models.Router{{ID: 3, Hostname: "203.0.113.1"}, {ID: 2, Hostname: "nat.example.net"}, {ID: 4, Hostname: "192.0.2.4"}},
+			wantByIP:      map[string]int{"192.0.2.4": 4},
+			wantAmbiguous: map[string][]int{"203.0.113.1": {2, 3}},
+		},
+		{
+			name:          "virtual routers, unresolvable hosts and orphan addresses are skipped",
+			routers:       []*models.Router{{ID: 1, Hostname: "192.0.2.1", IsVirtual: true}, {ID: 2, Hostname: "gone.example.net"}},
+			addresses:     []*models.RouterAddress{{RouterID: 1, Address: "10.0.0.1"}, {RouterID: 9, Address: "10.0.0.9"}},
+			wantByIP:      map[string]int{},
+			wantAmbiguous: map[string][]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byIP, ambiguous := buildSourceIndex(tt.routers, tt.addresses, lookup)
+			if !reflect.DeepEqual(byIP, tt.wantByIP) {
+				t.Errorf("byIP = %v, want %v", byIP, tt.wantByIP)
+			}
+			if !reflect.DeepEqual(ambiguous, tt.wantAmbiguous) {
+				t.Errorf("ambiguous = %v, want %v", ambiguous, tt.wantAmbiguous)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+
+	"github.com/go-routeros/routeros/v3"
+)
+
+// RetryPolicy - Retry otomatis untuk read idempotent yang gagal karena error transient.
+// Attempts termasuk percobaan pertama (1 = tanpa retry); backoff berlipat dua per attempt.
+type RetryPolicy struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// defaultRetryPolicy - Dipakai sampai SetRetryPolicy dipanggil
+var defaultRetryPolicy = RetryPolicy{Attempts: 3, Backoff: 500 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+// errConnectionUnhealthy - Koneksi ditandai unhealthy (health check / error koneksi), reconnect berjalan
+var errConnectionUnhealthy = errors.New("router connection unhealthy")
+
+// transientTrapMessages - Potongan pesan !trap RouterOS yang menandakan router sedang sibuk,
+// bukan kesalahan parameter / permission
+var transientTrapMessages = []string{"timeout", "timed out", "busy", "try again", "interrupted", "not ready"}
+
+// TransientError - Error transient yang masih gagal setelah semua retry
+type TransientError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *TransientError) Error() string {
+	return fmt.Sprintf("router temporarily unavailable after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode - Code machine-readable untuk response API
+func (e *TransientError) ErrorCode() string {
+	return i18n.Unavailable
+}
+
+// SetRetryPolicy - Ganti policy retry read (Attempts < 1 diperlakukan sebagai 1)
+func (ms *MikrotikService) SetRetryPolicy(p RetryPolicy) {
+	if p.Attempts < 1 {
+		p.Attempts = 1
+	}
+	ms.retry.Store(&p)
+}
+
+func (ms *MikrotikService) retryPolicy() RetryPolicy {
+	if p := ms.retry.Load(); p != nil {
+		return *p
+	}
+	return defaultRetryPolicy
+}
+
+// backoff - Jeda sebelum attempt berikutnya (attempt dimulai dari 1)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// IsTransient - True untuk error yang kemungkinan hilang jika diulang: timeout, koneksi putus /
+// di-reset, atau router sibuk. Parameter salah, permission dan command tidak dikenal permanen.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if isConnectionError(err) || errors.Is(err, errConnectionUnhealthy) {
+		return true
+	}
+
+	var deviceErr *routeros.DeviceError
+	if errors.As(err, &deviceErr) {
+		message := strings.ToLower(deviceErr.Sentence.Map["message"])
+		for _, m := range transientTrapMessages {
+			if strings.Contains(message, m) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isConnectionError - Error di level koneksi TCP; client RouterOS tidak bisa dipakai lagi
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE)
+}
+
+// runRead - Eksekusi sentence read-only (print) dengan retry sesuai policy. Error koneksi
+// menandai koneksi unhealthy lalu reconnect sebelum attempt berikutnya.
+// Jangan dipakai untuk command yang mengubah konfigurasi router.
+func (ms *MikrotikService) runRead(routerID int, sentence ...string) (*routeros.Reply, error) {
+	policy := ms.retryPolicy()
+	for attempt := 1; ; attempt++ {
+		reply, err := ms.runReadOnce(routerID, sentence)
+		if err == nil || !IsTransient(err) {
+			return reply, err
+		}
+		if attempt >= policy.Attempts {
+			if policy.Attempts == 1 {
+				return nil, err
+			}
+			return nil, &TransientError{Attempts: attempt, Err: err}
+		}
+
+		wait := policy.backoff(attempt)
+		log.Printf("[RETRY] Router %d %s failed (attempt %d/%d), retry in %v: %v",
+			routerID, sentence[0], attempt, policy.Attempts, wait, err)
+		time.Sleep(wait)
+
+		if isConnectionError(err) || errors.Is(err, errConnectionUnhealthy) {
+			if err := ms.ConnectRouter(routerID); err != nil {
+				log.Printf("[RETRY] Router %d reconnect failed: %v", routerID, err)
+			}
+		}
+	}
+}
+
+func (ms *MikrotikService) runReadOnce(routerID int, sentence []string) (*routeros.Reply, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	reply, err := conn.RunArgs(sentence)
+	conn.mu.RUnlock()
+
+	if isConnectionError(err) {
+		conn.IsHealthy = false
+	}
+	return reply, err
+}
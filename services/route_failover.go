@@ -0,0 +1,345 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// GetRoutes - Semua route /ip/route dengan dst-address tertentu
+func (ms *MikrotikService) GetRoutes(routerID int, dstAddress string) ([]*models.RouteEntry, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	r, err := conn.Run("/ip/route/print", fmt.Sprintf("?dst-address=%s", dstAddress),
+		"=.proplist=.id,dst-address,gateway,distance,check-gateway,active,disabled")
+	if err != nil {
+		return nil, err
+	}
+
+	routes := []*models.RouteEntry{}
+	for _, re := range r.Re {
+		distance, _ := strconv.Atoi(re.Map["distance"])
+		routes = append(routes, &models.RouteEntry{
+			ID:           re.Map[".id"],
+			DstAddress:   re.Map["dst-address"],
+			Gateway:      re.Map["gateway"],
+			Distance:     distance,
+			CheckGateway: re.Map["check-gateway"],
+			Active:       re.Map["active"] == "true",
+			Disabled:     re.Map["disabled"] == "true",
+		})
+	}
+	return routes, nil
+}
+
+// GetNetwatch - Semua entry /tool/netwatch
+func (ms *MikrotikService) GetNetwatch(routerID int) ([]*models.NetwatchEntry, error) {
+	r, err := ms.runRead(routerID, "/tool/netwatch/print", "=.proplist=.id,host,status,since,comment,disabled")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*models.NetwatchEntry{}
+	for _, re := range r.Re {
+		entries = append(entries, &models.NetwatchEntry{
+			ID:       re.Map[".id"],
+			Host:     re.Map["host"],
+			Status:   re.Map["status"],
+			Since:    re.Map["since"],
+			Comment:  re.Map["comment"],
+			Disabled: re.Map["disabled"] == "true",
+		})
+	}
+	return entries, nil
+}
+
+// GetWANStatus - Default route & netwatch live dari router, digabung dengan state route
+// failover (hasil watchdog) milik router tsb
+func (ms *MikrotikService) GetWANStatus(routerID int, failovers []*models.RouteFailover) (*models.WANStatus, error) {
+	routes, err := ms.GetRoutes(routerID, "0.0.0.0/0")
+	if err != nil {
+		return nil, err
+	}
+	netwatch, err := ms.GetNetwatch(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.WANStatus{
+		RouterID:      routerID,
+		DefaultRoutes: routes,
+		Failovers:     []*models.RouteFailover{},
+		Netwatch:      netwatch,
+		CheckedAt:     time.Now(),
+	}
+	for _, route := range routes {
+		if route.Active && !route.Disabled {
+			gateway := route.Gateway
+			status.ActiveGateway = &gateway
+			break
+		}
+	}
+	for _, f := range failovers {
+		if f.RouterID == routerID {
+			status.Failovers = append(status.Failovers, f)
+		}
+	}
+	return status, nil
+}
+
+// SetPrimaryRouteDisabled - Disable route primary supaya trafik pindah ke backup (switchover)
+// atau enable lagi (restore). Switchover ditolak jika tidak ada route backup yang enabled
+// supaya dst-address tidak kehilangan route sama sekali.
+func (ms *MikrotikService) SetPrimaryRouteDisabled(routerID int, f *models.RouteFailover, disabled, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/ip/route/print", fmt.Sprintf("?dst-address=%s", f.DstAddress), "=.proplist=.id,gateway,disabled")
+	if err != nil {
+		return nil, err
+	}
+
+	action, state, value := "route_restore", "enabled", "no"
+	if disabled {
+		action, state, value = "route_switchover", "disabled", "yes"
+	}
+	plan := newCommandPlan(routerID, action, dryRun)
+
+	var primaries []map[string]string
+	backupReady := false
+	for _, re := range r.Re {
+		switch {
+		case sameGateway(re.Map["gateway"], f.PrimaryGateway):
+			primaries = append(primaries, re.Map)
+		case sameGateway(re.Map["gateway"], f.BackupGateway) && re.Map["disabled"] != "true":
+			backupReady = true
+		}
+	}
+	if len(primaries) == 0 {
+		return nil, fmt.Errorf("primary route %s via %s not found", f.DstAddress, f.PrimaryGateway)
+	}
+	if disabled && !backupReady {
+		return nil, fmt.Errorf("no enabled backup route %s via %s", f.DstAddress, f.BackupGateway)
+	}
+
+	for _, route := range primaries {
+		if (route["disabled"] == "true") == disabled {
+			plan.Checks = append(plan.Checks, fmt.Sprintf("primary route via %s already %s", route["gateway"], state))
+			continue
+		}
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/route/set",
+			fmt.Sprintf("=.id=%s", route[".id"]),
+			fmt.Sprintf("=disabled=%s", value),
+		})
+	}
+	if disabled {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("traffic to %s moves to backup %s", f.DstAddress, f.BackupGateway))
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// sameGateway - Bandingkan gateway tanpa suffix interface ("10.0.0.1%ether1" di v7)
+func sameGateway(a, b string) bool {
+	a, _, _ = strings.Cut(a, "%")
+	b, _, _ = strings.Cut(b, "%")
+	return a == b
+}
+
+// FailoverWatchdog - Cek route primary/backup (route_failovers) di setiap router terhubung,
+// catat event saat trafik pindah ke backup / route hilang dan saat primary aktif kembali.
+type FailoverWatchdog struct {
+	interval time.Duration
+	ms       *MikrotikService
+	repo     *repository.RouteFailoverRepository
+	recorder *EventRecorder
+	notifier *Notifier
+	audit    *AuditLogger
+}
+
+func NewFailoverWatchdog(interval time.Duration, ms *MikrotikService, repo *repository.RouteFailoverRepository, recorder *EventRecorder, notifier *Notifier, audit *AuditLogger) *FailoverWatchdog {
+	return &FailoverWatchdog{
+		interval: interval,
+		ms:       ms,
+		repo:     repo,
+		recorder: recorder,
+		notifier: notifier,
+		audit:    audit,
+	}
+}
+
+// Run - Loop watchdog (blocking)
+func (w *FailoverWatchdog) Run() {
+	if w.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		failovers, err := w.repo.List(true)
+		if err != nil {
+			log.Printf("[FAILOVER] Error loading route failovers: %v", err)
+			continue
+		}
+
+		connections := w.ms.GetAllConnections()
+		for _, f := range failovers {
+			// Router putus: state terakhir dipertahankan, bukan dianggap route down
+			if conn, ok := connections[f.RouterID]; !ok || !conn.IsHealthy {
+				continue
+			}
+			w.check(f)
+		}
+	}
+}
+
+// check - Tentukan state dari route aktif lalu simpan & catat event jika berubah
+func (w *FailoverWatchdog) check(f *models.RouteFailover) {
+	routes, err := w.ms.GetRoutes(f.RouterID, f.DstAddress)
+	if err != nil {
+		log.Printf("[FAILOVER] Router %d: error reading routes %s: %v", f.RouterID, f.DstAddress, err)
+		return
+	}
+
+	state := models.RouteStateDown
+	var activeGateway *string
+	for _, route := range routes {
+		if !route.Active || route.Disabled {
+			continue
+		}
+		gateway := route.Gateway
+		activeGateway = &gateway
+		if sameGateway(gateway, f.PrimaryGateway) {
+			state = models.RouteStatePrimary
+		} else {
+			state = models.RouteStateBackup
+		}
+		break
+	}
+
+	sameActive := activeGateway == nil && f.ActiveGateway == nil ||
+		activeGateway != nil && f.ActiveGateway != nil && *activeGateway == *f.ActiveGateway
+	if state == f.State && sameActive {
+		return
+	}
+
+	if err := w.repo.SetState(f.ID, state, activeGateway); err != nil {
+		log.Printf("[FAILOVER] Error saving state of %s: %v", f.Name, err)
+	}
+	if state == f.State {
+		return
+	}
+
+	previous := f.State
+	f.State, f.ActiveGateway = state, activeGateway
+
+	// Poll pertama yang sudah di primary hanya baseline
+	if previous == models.RouteStateUnknown && state == models.RouteStatePrimary {
+		return
+	}
+	w.record(f, previous, false)
+}
+
+// Switch - Paksa trafik ke backup (restore=false) atau kembalikan ke primary (restore=true).
+// State & event langsung dicatat sebagai perubahan manual sehingga poll watchdog berikutnya
+// tidak menganggapnya failover otomatis.
+func (w *FailoverWatchdog) Switch(f *models.RouteFailover, restore, dryRun bool) (*models.CommandPlan, error) {
+	plan, err := w.ms.SetPrimaryRouteDisabled(f.RouterID, f, !restore, dryRun)
+	if dryRun {
+		return plan, err
+	}
+	if plan != nil || err != nil {
+		action := "route_switchover"
+		if restore {
+			action = "route_restore"
+		}
+		w.audit.LogPlan("api", action, f.RouterID, f.Name, plan, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Commands) == 0 {
+		return plan, nil
+	}
+
+	previous := f.State
+	state, gateway := models.RouteStateBackup, f.BackupGateway
+	if restore {
+		state, gateway = models.RouteStatePrimary, f.PrimaryGateway
+	}
+	if err := w.repo.SetState(f.ID, state, &gateway); err != nil {
+		log.Printf("[FAILOVER] Error saving state of %s: %v", f.Name, err)
+	}
+	f.State, f.ActiveGateway = state, &gateway
+	w.record(f, previous, true)
+
+	return plan, nil
+}
+
+func (w *FailoverWatchdog) record(f *models.RouteFailover, previous string, manual bool) {
+	label := fmt.Sprintf("Route %s (%s)", f.Name, f.DstAddress)
+	gateway := ""
+	if f.ActiveGateway != nil {
+		gateway = *f.ActiveGateway
+	}
+
+	var eventType, severity, message string
+	switch f.State {
+	case models.RouteStatePrimary:
+		eventType, severity = "route_recovered", "info"
+		message = fmt.Sprintf("%s kembali ke primary %s", label, f.PrimaryGateway)
+	case models.RouteStateBackup:
+		eventType, severity = "route_failover", "warning"
+		message = fmt.Sprintf("%s failover ke gateway %s", label, gateway)
+	default:
+		eventType, severity = "route_failover", "error"
+		message = fmt.Sprintf("%s tidak memiliki route aktif", label)
+	}
+	if manual {
+		message += " (manual)"
+	}
+	log.Printf("[FAILOVER] Router %d: %s", f.RouterID, message)
+
+	event := &models.Event{
+		RouterID: &f.RouterID,
+		Type:     eventType,
+		Severity: severity,
+		Message:  message,
+		Data: mustJSON(map[string]interface{}{
+			"failover_id":     f.ID,
+			"dst_address":     f.DstAddress,
+			"previous_state":  previous,
+			"state":           f.State,
+			"active_gateway":  f.ActiveGateway,
+			"primary_gateway": f.PrimaryGateway,
+			"backup_gateway":  f.BackupGateway,
+			"manual":          manual,
+		}),
+	}
+	w.recorder.Record(event)
+	if f.Notify {
+		w.notifier.Notify(event)
+	}
+}
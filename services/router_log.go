@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+
+	"Mikrotik-Layer/models"
+
+	"github.com/go-routeros/routeros/v3"
+)
+
+// routerLogProplist - Atribut /log/print yang dipakai
+const routerLogProplist = "=.proplist=.id,time,topics,message"
+
+// GetRouterLogs - limit entry terakhir memory log router; topics kosong = semua. Entry cocok jika
+// salah satu topic-nya ada di filter (RouterOS menyimpan topics sebagai daftar, mis. "firewall,info").
+func (ms *MikrotikService) GetRouterLogs(routerID int, topics []string, limit int) (*models.RouterLogs, error) {
+	r, err := ms.runRead(routerID, "/log/print", routerLogProplist)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*models.RouterLogEntry{}
+	for _, re := range r.Re {
+		if entry := parseRouterLog(re.Map); matchLogTopics(entry, topics) {
+			entries = append(entries, entry)
+		}
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return &models.RouterLogs{RouterID: routerID, Topics: topics, Entries: entries}, nil
+}
+
+// FollowRouterLogs - Stream entry log baru (/log/print follow-only) sampai ctx selesai. Seperti
+// monitor traffic, stream yang putus karena koneksi drop di-resume setelah router reconnect.
+// Router virtual tidak menghasilkan log baru: stream hanya dimulai.
+func (ms *MikrotikService) FollowRouterLogs(ctx context.Context, routerID int, topics []string, callback func(*models.RouterLogEntry), onStatus func(status, reason string)) error {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return err
+	}
+	if conn.IsVirtual() {
+		onStatus(StreamStarted, "")
+		return nil
+	}
+
+	release, err := ms.acquireListen(ctx, routerID, func() { onStatus(StreamQueued, "") })
+	if err != nil {
+		return err
+	}
+	listen, err := listenRouterLogs(conn)
+	if err != nil {
+		release()
+		return err
+	}
+	onStatus(StreamStarted, "")
+
+	go func() {
+		defer Recover("log-follow")
+		defer release()
+		for consumeRouterLogs(ctx, listen, topics, callback) {
+			var deviceErr *routeros.DeviceError
+			if errors.As(listen.Err(), &deviceErr) {
+				onStatus(StreamError, deviceErr.Error())
+				onStatus(StreamEnded, "")
+				return
+			}
+
+			reason := "connection lost"
+			if listen.Err() != nil {
+				reason = listen.Err().Error()
+			}
+			onStatus(RouterOffline, reason)
+
+			conn, listen = ms.waitListenResume(ctx, conn, "log follow", listenRouterLogs)
+			if listen == nil {
+				if conn != nil && conn.IsVirtual() {
+					// Router virtual tidak punya log untuk diikuti
+					onStatus(StreamEnded, "router is now virtual")
+				}
+				return
+			}
+			log.Printf("[LOG] ✓ Log follow resumed for router %d", routerID)
+			onStatus(StreamResumed, "")
+		}
+	}()
+	return nil
+}
+
+// listenRouterLogs - Listen /log/print follow-only (tanpa lock, lihat catatan di mikrotik.go)
+func listenRouterLogs(conn *MikrotikConnection) (*routeros.ListenReply, error) {
+	return conn.Client.Listen("/log/print", "=follow-only=", routerLogProplist)
+}
+
+// consumeRouterLogs - Teruskan entry yang cocok ke callback. Return true jika channel tertutup
+// sementara ctx masih aktif (stream putus, perlu di-resume).
+func consumeRouterLogs(ctx context.Context, listen *routeros.ListenReply, topics []string, callback func(*models.RouterLogEntry)) bool {
+	defer listen.Cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case sentence, more := <-listen.Chan():
+			if !more {
+				return ctx.Err() == nil
+			}
+			// follow mengirim !re dengan .dead=true saat entry lama dibuang dari buffer
+			if sentence.Word != "!re" || sentence.Map[".dead"] == "true" {
+				continue
+			}
+			if entry := parseRouterLog(sentence.Map); matchLogTopics(entry, topics) {
+				callback(entry)
+			}
+		}
+	}
+}
+
+func parseRouterLog(m map[string]string) *models.RouterLogEntry {
+	entry := &models.RouterLogEntry{
+		ID:      m[".id"],
+		Time:    m["time"],
+		Topics:  []string{},
+		Message: m["message"],
+	}
+	for _, topic := range strings.Split(m["topics"], ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			entry.Topics = append(entry.Topics, topic)
+		}
+	}
+	return entry
+}
+
+// matchLogTopics - True jika filter kosong atau salah satu topic entry ada di filter
+func matchLogTopics(entry *models.RouterLogEntry, topics []string) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, topic := range entry.Topics {
+		if slices.Contains(topics, topic) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// isRouterOSv7 - Menu routing berubah di RouterOS v7 (mis. bgp peer -> connection/session)
+func (ms *MikrotikService) isRouterOSv7(routerID int) (bool, error) {
+	caps, err := ms.GetCapabilities(routerID, false)
+	if err != nil {
+		return false, err
+	}
+	return caps.VersionMajor >= 7, nil
+}
+
+// GetOSPFNeighbors - Neighbor OSPF semua instance
+func (ms *MikrotikService) GetOSPFNeighbors(routerID int) ([]*models.OSPFNeighbor, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	r, err := conn.Run("/routing/ospf/neighbor/print")
+	if err != nil {
+		return nil, err
+	}
+
+	neighbors := []*models.OSPFNeighbor{}
+	for _, re := range r.Re {
+		changes, _ := strconv.Atoi(re.Map["state-changes"])
+		neighbors = append(neighbors, &models.OSPFNeighbor{
+			Instance:     re.Map["instance"],
+			Area:         re.Map["area"],
+			Interface:    re.Map["interface"],
+			Address:      re.Map["address"],
+			NeighborID:   re.Map["router-id"],
+			State:        re.Map["state"],
+			StateChanges: changes,
+			Adjacency:    re.Map["adjacency"],
+		})
+	}
+	return neighbors, nil
+}
+
+// GetOSPFLSDB - Ringkasan LSDB: jumlah LSA per type dan area
+func (ms *MikrotikService) GetOSPFLSDB(routerID int) (*models.OSPFLSDBSummary, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	r, err := conn.Run("/routing/ospf/lsa/print", "=.proplist=type,area")
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &models.OSPFLSDBSummary{ByType: make(map[string]int), ByArea: make(map[string]int)}
+	for _, re := range r.Re {
+		summary.Total++
+		summary.ByType[re.Map["type"]]++
+		if area := re.Map["area"]; area != "" {
+			summary.ByArea[area]++
+		}
+	}
+	return summary, nil
+}
+
+// GetBGPPeers - Peer BGP beserta state dan jumlah prefix.
+// v6: /routing/bgp/peer; v7: /routing/bgp/connection digabung dengan /routing/bgp/session
+// (connection tanpa session aktif dilaporkan sebagai "idle").
+func (ms *MikrotikService) GetBGPPeers(routerID int) ([]*models.BGPPeer, error) {
+	v7, err := ms.isRouterOSv7(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	peers := []*models.BGPPeer{}
+	if !v7 {
+		r, err := conn.Run("/routing/bgp/peer/print")
+		if err != nil {
+			return nil, err
+		}
+		for _, re := range r.Re {
+			prefixes, _ := strconv.Atoi(re.Map["prefix-count"])
+			peers = append(peers, &models.BGPPeer{
+				Name:          re.Map["name"],
+				RemoteAddress: re.Map["remote-address"],
+				RemoteAS:      re.Map["remote-as"],
+				State:         re.Map["state"],
+				Established:   re.Map["state"] == "established",
+				PrefixCount:   prefixes,
+				Uptime:        re.Map["uptime"],
+				Disabled:      re.Map["disabled"] == "true",
+			})
+		}
+		return peers, nil
+	}
+
+	r, err := conn.Run("/routing/bgp/connection/print")
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := conn.Run("/routing/bgp/session/print")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, re := range r.Re {
+		peer := &models.BGPPeer{
+			Name:          re.Map["name"],
+			RemoteAddress: re.Map["remote.address"],
+			RemoteAS:      re.Map["remote.as"],
+			State:         "idle",
+			Disabled:      re.Map["disabled"] == "true",
+		}
+		// Session v7 bernama "<connection>-<n>"
+		for _, s := range sessions.Re {
+			name := s.Map["name"]
+			if name != peer.Name && !strings.HasPrefix(name, peer.Name+"-") {
+				continue
+			}
+			peer.Established = s.Map["established"] == "true"
+			peer.PrefixCount, _ = strconv.Atoi(s.Map["prefix-count"])
+			peer.Uptime = s.Map["uptime"]
+			if peer.Established {
+				peer.State = "established"
+			} else if state := s.Map["state"]; state != "" {
+				peer.State = state
+			} else {
+				peer.State = "connecting"
+			}
+			break
+		}
+		peers = append(peers, peer)
+	}
+	return peers, nil
+}
+
+// GetRoutingStatus - OSPF + BGP; kegagalan satu section (mis. package routing tidak ada) tidak menggagalkan yang lain
+func (ms *MikrotikService) GetRoutingStatus(routerID int) (*models.RoutingStatus, error) {
+	if _, err := ms.GetConnection(routerID); err != nil {
+		return nil, err
+	}
+
+	status := &models.RoutingStatus{RouterID: routerID, CollectedAt: time.Now()}
+	addError := func(section string, err error) {
+		if status.Errors == nil {
+			status.Errors = make(map[string]string)
+		}
+		status.Errors[section] = err.Error()
+	}
+
+	if neighbors, err := ms.GetOSPFNeighbors(routerID); err != nil {
+		addError("ospf", err)
+	} else if lsdb, err := ms.GetOSPFLSDB(routerID); err != nil {
+		addError("ospf", err)
+	} else {
+		status.OSPF = &models.OSPFStatus{Neighbors: neighbors, LSDB: lsdb}
+	}
+
+	if peers, err := ms.GetBGPPeers(routerID); err != nil {
+		addError("bgp", err)
+	} else {
+		status.BGP = peers
+	}
+
+	return status, nil
+}
+
+// RoutingMonitor - Poll neighbor OSPF & peer BGP semua router terhubung, catat event
+// saat adjacency / session putus dan saat pulih kembali.
+type RoutingMonitor struct {
+	interval time.Duration
+	ms       *MikrotikService
+	recorder *EventRecorder
+
+	mu    sync.Mutex
+	state map[int]map[string]bool // routerID -> "ospf:<address>" / "bgp:<name>" -> up
+}
+
+func NewRoutingMonitor(interval time.Duration, ms *MikrotikService, recorder *EventRecorder) *RoutingMonitor {
+	return &RoutingMonitor{
+		interval: interval,
+		ms:       ms,
+		recorder: recorder,
+		state:    make(map[int]map[string]bool),
+	}
+}
+
+// Run - Loop monitor (blocking)
+func (m *RoutingMonitor) Run() {
+	if m.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		for routerID, conn := range m.ms.GetAllConnections() {
+			if !conn.IsHealthy {
+				continue
+			}
+			m.check(routerID)
+		}
+	}
+}
+
+// check - Bandingkan state sekarang dengan poll sebelumnya; poll pertama hanya jadi baseline
+func (m *RoutingMonitor) check(routerID int) {
+	current := make(map[string]bool)
+	labels := make(map[string]string)
+	disabled := make(map[string]bool) // peer yang sengaja di-disable tidak dianggap putus
+	ospfOK, bgpOK := false, false
+
+	if neighbors, err := m.ms.GetOSPFNeighbors(routerID); err == nil {
+		ospfOK = true
+		for _, n := range neighbors {
+			key := "ospf:" + n.Address
+			current[key] = n.Up()
+			labels[key] = fmt.Sprintf("OSPF neighbor %s (%s)", n.NeighborID, n.Address)
+		}
+	}
+	if peers, err := m.ms.GetBGPPeers(routerID); err == nil {
+		bgpOK = true
+		for _, p := range peers {
+			key := "bgp:" + p.Name
+			if p.Disabled {
+				disabled[key] = true
+				continue
+			}
+			current[key] = p.Established
+			labels[key] = fmt.Sprintf("BGP peer %s (%s AS%s)", p.Name, p.RemoteAddress, p.RemoteAS)
+		}
+	}
+
+	m.mu.Lock()
+	previous, known := m.state[routerID]
+	next := make(map[string]bool, len(current))
+	for key, up := range current {
+		next[key] = up
+	}
+	for key, up := range previous {
+		if _, ok := next[key]; ok || disabled[key] {
+			continue
+		}
+		// Section yang gagal dibaca: pertahankan state lama supaya tidak dianggap putus;
+		// neighbor / peer yang hilang dari daftar dianggap down
+		protocol, _, _ := strings.Cut(key, ":")
+		if protocol == "ospf" && !ospfOK || protocol == "bgp" && !bgpOK {
+			next[key] = up
+		} else {
+			next[key] = false
+		}
+	}
+	m.state[routerID] = next
+	m.mu.Unlock()
+
+	if !known {
+		return
+	}
+
+	for key, up := range next {
+		wasUp, ok := previous[key]
+		if !ok || wasUp == up {
+			continue
+		}
+		label, ok := labels[key]
+		if !ok {
+			label = strings.Replace(key, ":", " ", 1)
+		}
+		if up {
+			m.record(routerID, key, "up", label)
+		} else {
+			m.record(routerID, key, "down", label)
+		}
+	}
+}
+
+func (m *RoutingMonitor) record(routerID int, key, transition, label string) {
+	protocol, peer, _ := strings.Cut(key, ":")
+	eventType := "ospf_neighbor_" + transition
+	if protocol == "bgp" {
+		eventType = "bgp_peer_" + transition
+	}
+
+	severity, message := "info", label+" kembali up"
+	if transition == "down" {
+		severity, message = "warning", label+" down"
+	}
+	log.Printf("[ROUTING] Router %d: %s", routerID, message)
+
+	m.recorder.Record(&models.Event{
+		RouterID: &routerID,
+		Type:     eventType,
+		Severity: severity,
+		Message:  message,
+		Data:     mustJSON(map[string]string{"protocol": protocol, "peer": peer}),
+	})
+}
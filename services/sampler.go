@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// interfaceSampler - Goroutine yang menyimpan traffic satu interface secara periodik
+type interfaceSampler struct {
+	info   models.SamplerInfo
+	cancel context.CancelFunc
+}
+
+// TrafficSampler - Registry background sampler yang mengisi traffic_history
+type TrafficSampler struct {
+	ms   *MikrotikService
+	repo *repository.TrafficRepository
+
+	mu       sync.Mutex
+	samplers map[string]*interfaceSampler // "routerID/interface" -> sampler
+}
+
+var (
+	samplerInstance *TrafficSampler
+	samplerOnce     sync.Once
+)
+
+// GetTrafficSampler - Singleton sampler + routine retention history. Sampler yang aktif sebelum
+// layer restart (tabel traffic_samplers) dijalankan lagi saat instance dibuat.
+func GetTrafficSampler(ms *MikrotikService, repo *repository.TrafficRepository, retention time.Duration) *TrafficSampler {
+	samplerOnce.Do(func() {
+		samplerInstance = &TrafficSampler{
+			ms:       ms,
+			repo:     repo,
+			samplers: make(map[string]*interfaceSampler),
+		}
+		samplerInstance.Reload()
+		go Supervise("traffic-retention", func() { samplerInstance.retentionRoutine(retention) })
+		go Supervise("sampler-sync", samplerInstance.syncRoutine)
+	})
+	return samplerInstance
+}
+
+// Reload - Samakan registry dengan sampler tersimpan: yang belum jalan dimulai, yang sudah tidak
+// tersimpan (dihentikan lewat instance lain) dihentikan. Router yang belum terkoneksi tidak
+// menghalangi: tick yang gagal hanya dicatat di LastError sampai koneksi pulih.
+func (ts *TrafficSampler) Reload() {
+	saved, err := ts.repo.ListSamplers()
+	if err != nil {
+		log.Printf("[SAMPLER] Error loading saved samplers: %v", err)
+		return
+	}
+
+	wanted := make(map[string]bool, len(saved))
+	restored := 0
+	for _, info := range saved {
+		key := samplerKey(info.RouterID, info.Interface)
+		wanted[key] = true
+
+		ts.mu.Lock()
+		_, running := ts.samplers[key]
+		ts.mu.Unlock()
+
+		if err := ts.start(info.RouterID, info.Interface, time.Duration(info.IntervalSeconds)*time.Second, &info.Monitored); err != nil {
+			log.Printf("[SAMPLER] Error restoring router %d interface %s: %v", info.RouterID, info.Interface, err)
+			continue
+		}
+		if !running {
+			restored++
+		}
+	}
+	if restored > 0 {
+		log.Printf("[SAMPLER] Restored %d saved sampler(s)", restored)
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for key, sampler := range ts.samplers {
+		if !wanted[key] {
+			sampler.cancel()
+			delete(ts.samplers, key)
+		}
+	}
+}
+
+// syncRoutine - Multi instance: sampler bisa dimulai/dihentikan lewat instance mana saja,
+// jadi setiap instance memuat ulang traffic_samplers tiap menit. Tick sampling sendiri
+// hanya dijalankan leader (lihat run), sehingga registry semua instance tetap sama dan
+// instance mana pun bisa mengambil alih saat leader berganti.
+func (ts *TrafficSampler) syncRoutine() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ts.Reload()
+	}
+}
+
+func samplerKey(routerID int, iface string) string {
+	return fmt.Sprintf("%d/%s", routerID, iface)
+}
+
+// Start - Mulai sampling interface (restart jika interval berubah)
+func (ts *TrafficSampler) Start(routerID int, iface string, interval time.Duration) error {
+	return ts.start(routerID, iface, interval, nil)
+}
+
+// start - Start dengan status monitored dari traffic_samplers (nil = pertahankan status sampler
+// yang sedang berjalan)
+func (ts *TrafficSampler) start(routerID int, iface string, interval time.Duration, monitored *bool) error {
+	if interval < 5*time.Second {
+		return fmt.Errorf("interval minimal 5s")
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	key := samplerKey(routerID, iface)
+	isMonitored := monitored != nil && *monitored
+	if existing, ok := ts.samplers[key]; ok {
+		if monitored == nil {
+			isMonitored = existing.info.Monitored
+		}
+		existing.info.Monitored = isMonitored
+		if existing.info.IntervalSeconds == int(interval.Seconds()) {
+			return nil
+		}
+		existing.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sampler := &interfaceSampler{
+		info: models.SamplerInfo{
+			RouterID:        routerID,
+			Interface:       iface,
+			IntervalSeconds: int(interval.Seconds()),
+			Monitored:       isMonitored,
+			StartedAt:       time.Now(),
+		},
+		cancel: cancel,
+	}
+	ts.samplers[key] = sampler
+
+	if err := ts.repo.SaveSampler(routerID, iface, sampler.info.IntervalSeconds); err != nil {
+		log.Printf("[SAMPLER] Error saving router %d interface %s: %v", routerID, iface, err)
+	}
+
+	go Supervise("sampler "+key, func() { ts.run(ctx, sampler, interval) })
+	log.Printf("[SAMPLER] Started router %d, interface %s (every %v)", routerID, iface, interval)
+	return nil
+}
+
+// Stop - Hentikan sampler interface. Monitored interface tidak bisa dihentikan di sini,
+// harus dikeluarkan dari daftar monitored interface router. traffic_samplers jadi acuan
+// karena sampler bisa dimulai lewat instance lain yang registry-nya belum tersinkron ke sini.
+func (ts *TrafficSampler) Stop(routerID int, iface string) error {
+	saved, err := ts.repo.GetSampler(routerID, iface)
+	if err != nil {
+		return err
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	key := samplerKey(routerID, iface)
+	sampler, running := ts.samplers[key]
+	if !running && saved == nil {
+		return fmt.Errorf("sampler for router %d interface %s not running", routerID, iface)
+	}
+	if (saved != nil && saved.Monitored) || (running && sampler.info.Monitored) {
+		return i18n.NewError(i18n.SamplerMonitored, iface)
+	}
+
+	if running {
+		sampler.cancel()
+		delete(ts.samplers, key)
+	}
+	if err := ts.repo.DeleteSampler(routerID, iface); err != nil {
+		log.Printf("[SAMPLER] Error removing saved router %d interface %s: %v", routerID, iface, err)
+	}
+	log.Printf("[SAMPLER] Stopped router %d, interface %s", routerID, iface)
+	return nil
+}
+
+// StopRouter - Hentikan semua sampler milik router, return jumlah yang dihentikan
+func (ts *TrafficSampler) StopRouter(routerID int) int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	stopped := 0
+	for key, sampler := range ts.samplers {
+		if sampler.info.RouterID != routerID {
+			continue
+		}
+		sampler.cancel()
+		delete(ts.samplers, key)
+		stopped++
+	}
+	if err := ts.repo.DeleteRouterSamplers(routerID); err != nil {
+		log.Printf("[SAMPLER] Error removing saved samplers for router %d: %v", routerID, err)
+	}
+	if stopped > 0 {
+		log.Printf("[SAMPLER] Stopped %d sampler(s) for router %d", stopped, routerID)
+	}
+	return stopped
+}
+
+// SetMonitored - Ganti daftar monitored interface router (warm standby): sampler-nya selalu
+// berjalan walau tidak ada klien WebSocket, supaya history dan alert uplink penting tidak
+// bolong. Interface yang dikeluarkan dari daftar ikut dihentikan.
+func (ts *TrafficSampler) SetMonitored(routerID int, ifaces []string, interval time.Duration) error {
+	if interval < 5*time.Second {
+		return fmt.Errorf("interval minimal 5s")
+	}
+	if err := ts.repo.SetMonitoredInterfaces(routerID, ifaces, int(interval.Seconds())); err != nil {
+		return err
+	}
+	ts.Reload()
+	return nil
+}
+
+// Monitored - Status sampler monitored interface milik router
+func (ts *TrafficSampler) Monitored(routerID int) []models.SamplerInfo {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	result := []models.SamplerInfo{}
+	for _, sampler := range ts.samplers {
+		if sampler.info.RouterID == routerID && sampler.info.Monitored {
+			result = append(result, sampler.info)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Interface < result[j].Interface })
+	return result
+}
+
+// CountRouter - Jumlah sampler aktif milik router
+func (ts *TrafficSampler) CountRouter(routerID int) int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	count := 0
+	for _, sampler := range ts.samplers {
+		if sampler.info.RouterID == routerID {
+			count++
+		}
+	}
+	return count
+}
+
+// List - Snapshot status semua sampler
+func (ts *TrafficSampler) List() []models.SamplerInfo {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	result := make([]models.SamplerInfo, 0, len(ts.samplers))
+	for _, sampler := range ts.samplers {
+		result = append(result, sampler.info)
+	}
+	return result
+}
+
+func (ts *TrafficSampler) run(ctx context.Context, sampler *interfaceSampler, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	routerID, iface := sampler.info.RouterID, sampler.info.Interface
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !IsLeader() {
+			continue
+		}
+
+		sample, err := ts.sampleOnce(routerID, iface)
+		if err == nil {
+			err = ts.repo.InsertSample(sample)
+		}
+
+		ts.mu.Lock()
+		if err != nil {
+			sampler.info.LastError = err.Error()
+		} else {
+			sampler.info.LastError = ""
+			sampler.info.LastSampleAt = &sample.SampledAt
+			sampler.info.Samples++
+		}
+		ts.mu.Unlock()
+
+		if err != nil {
+			log.Printf("[SAMPLER] Router %d interface %s: %v", routerID, iface, err)
+		}
+	}
+}
+
+// sampleOnce - Ambil satu sample traffic dan konversi ke angka
+func (ts *TrafficSampler) sampleOnce(routerID int, iface string) (*models.TrafficSample, error) {
+	stats, err := ts.ms.GetInterfaceTrafficOnce(routerID, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &models.TrafficSample{
+		RouterID:  routerID,
+		Interface: iface,
+		RxBps:     parseCounter(stats.RxBitsPerSec),
+		TxBps:     parseCounter(stats.TxBitsPerSec),
+		RxBytes:   parseCounter(stats.RxBytes),
+		TxBytes:   parseCounter(stats.TxBytes),
+		SampledAt: stats.Timestamp,
+	}
+
+	// Counter error tidak menggagalkan sample traffic; tanpa data tetap 0
+	if errs, err := ts.ms.GetInterfaceErrors(routerID, iface); err == nil {
+		sample.RxErrors = errs.RxErrors
+		sample.TxErrors = errs.TxErrors
+		sample.RxDrops = errs.RxDrops
+		sample.TxDrops = errs.TxDrops
+		sample.FCSErrors = errs.FCSErrors
+	}
+	return sample, nil
+}
+
+// retentionRoutine - Hapus history lebih tua dari retention tiap jam
+func (ts *TrafficSampler) retentionRoutine(retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		deleted, err := ts.repo.DeleteOlderThan(time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("[SAMPLER] Retention cleanup failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("[SAMPLER] Retention cleanup removed %d samples", deleted)
+		}
+	}
+}
+
+// parseCounter - Parse counter RouterOS (string) ke int64, 0 jika kosong/invalid
+func parseCounter(v string) int64 {
+	n, _ := strconv.ParseInt(v, 10, 64)
+	return n
+}
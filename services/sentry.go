@@ -0,0 +1,124 @@
+package services
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// SentryReporter - Pengirim event panic ke Sentry lewat store API (tanpa SDK). Pengiriman
+// asynchronous dan best-effort: gagal kirim hanya di-log.
+type SentryReporter struct {
+	endpoint    string
+	auth        string
+	environment string
+	serverName  string
+	client      *http.Client
+}
+
+// NewSentryReporter - Parse DSN https://<key>@<host>/<project>
+func NewSentryReporter(dsn, environment string) (*SentryReporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentry dsn: %w", err)
+	}
+	project := strings.Trim(u.Path, "/")
+	if u.User == nil || u.User.Username() == "" || project == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid sentry dsn: expected scheme://key@host/project")
+	}
+
+	// Sentry self-hosted di sub-path: project ID adalah segmen terakhir
+	prefix := ""
+	if i := strings.LastIndex(project, "/"); i >= 0 {
+		prefix, project = "/"+project[:i], project[i+1:]
+	}
+
+	hostname, _ := os.Hostname()
+	return &SentryReporter{
+		endpoint: fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, prefix, project),
+		auth: fmt.Sprintf("Sentry sentry_version=7, sentry_client=mikrotik-layer/1.0, sentry_key=%s",
+			u.User.Username()),
+		environment: environment,
+		serverName:  hostname,
+		client:      &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// sentryEvent - Subset payload event Sentry yang dipakai
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Message     string            `json:"message"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+}
+
+// Report - Kirim satu panic (non-blocking)
+func (s *SentryReporter) Report(name, message string, stack []byte, tags map[string]string) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		log.Printf("[SENTRY] Error generating event id: %v", err)
+		return
+	}
+
+	allTags := map[string]string{"component": name}
+	for k, v := range tags {
+		allTags[k] = v
+	}
+	event := &sentryEvent{
+		EventID:     hex.EncodeToString(buf),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		Level:       "fatal",
+		Platform:    "go",
+		Logger:      "panic",
+		ServerName:  s.serverName,
+		Environment: s.environment,
+		Message:     fmt.Sprintf("panic in %s: %s", name, message),
+		Tags:        allTags,
+		Extra:       map[string]string{"stacktrace": string(stack)},
+	}
+
+	go s.send(event)
+}
+
+func (s *SentryReporter) send(event *sentryEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[SENTRY] Error encoding event: %v", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("[SENTRY] Error building request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Printf("[SENTRY] Error sending event %s: %v", event.EventID, err)
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Printf("[SENTRY] Event %s rejected: %s", event.EventID, resp.Status)
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// sessionMenu - Path menu sesi aktif & user per jenis layanan
+type sessionMenu struct {
+	active   string // menu sesi aktif
+	users    string // menu user (untuk filter profile)
+	userAttr string // nama atribut user pada entry sesi aktif
+}
+
+var (
+	pppSessions     = sessionMenu{active: "/ppp/active", users: "/ppp/secret", userAttr: "name"}
+	hotspotSessions = sessionMenu{active: "/ip/hotspot/active", users: "/ip/hotspot/user", userAttr: "user"}
+)
+
+// KickPPPSessions - Putus sesi PPP aktif yang cocok dengan filter; client akan reconnect
+// (mis. supaya profile baru langsung berlaku)
+func (ms *MikrotikService) KickPPPSessions(routerID int, filter models.SessionFilter, dryRun bool) (*models.CommandPlan, error) {
+	return ms.kickSessions(routerID, pppSessions, "kick_ppp_sessions", filter, dryRun)
+}
+
+// KickHotspotSessions - Putus sesi hotspot aktif yang cocok dengan filter
+func (ms *MikrotikService) KickHotspotSessions(routerID int, filter models.SessionFilter, dryRun bool) (*models.CommandPlan, error) {
+	return ms.kickSessions(routerID, hotspotSessions, "kick_hotspot_sessions", filter, dryRun)
+}
+
+func (ms *MikrotikService) kickSessions(routerID int, menu sessionMenu, action string, filter models.SessionFilter, dryRun bool) (*models.CommandPlan, error) {
+	if filter.Empty() {
+		return nil, fmt.Errorf("session filter is required")
+	}
+
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	// Profile: user yang memakai profile tsb
+	var users map[string]bool
+	if filter.Profile != "" {
+		r, err := conn.Run(menu.users+"/print", fmt.Sprintf("?profile=%s", filter.Profile), "=.proplist=name")
+		if err != nil {
+			return nil, err
+		}
+		users = make(map[string]bool, len(r.Re))
+		for _, re := range r.Re {
+			users[re.Map["name"]] = true
+		}
+	}
+
+	// Address-list: IP yang ada di list (entry host /32 dianggap sama dengan IP-nya)
+	var addresses map[string]bool
+	if filter.AddressList != "" {
+		r, err := conn.Run("/ip/firewall/address-list/print", fmt.Sprintf("?list=%s", filter.AddressList), "=.proplist=address")
+		if err != nil {
+			return nil, err
+		}
+		addresses = make(map[string]bool, len(r.Re))
+		for _, re := range r.Re {
+			addresses[strings.TrimSuffix(re.Map["address"], "/32")] = true
+		}
+	}
+
+	args := []string{menu.active + "/print", "=.proplist=.id," + menu.userAttr + ",address"}
+	if filter.ID != "" {
+		args = append(args, fmt.Sprintf("?.id=%s", filter.ID))
+	}
+	if filter.User != "" {
+		args = append(args, fmt.Sprintf("?%s=%s", menu.userAttr, filter.User))
+	}
+	r, err := conn.RunArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, action, dryRun)
+	for _, re := range r.Re {
+		user, address := re.Map[menu.userAttr], re.Map["address"]
+		if users != nil && !users[user] {
+			continue
+		}
+		if addresses != nil && !addresses[address] {
+			continue
+		}
+		plan.Checks = append(plan.Checks, fmt.Sprintf("session %s (%s) disconnected", user, address))
+		plan.Commands = append(plan.Commands, []string{
+			menu.active + "/remove",
+			fmt.Sprintf("=.id=%s", re.Map[".id"]),
+		})
+	}
+	if len(plan.Commands) == 0 {
+		plan.Checks = append(plan.Checks, "no active session matched")
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// GetPPPActiveSessions - Sesi PPP aktif beserta counter byte interface dinamis "<service-user>"
+func (ms *MikrotikService) GetPPPActiveSessions(routerID int) ([]*models.ActivePPPSession, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	r, err := conn.Run("/ppp/active/print", "=.proplist=.id,name,service,caller-id,address,uptime")
+	if err != nil {
+		return nil, err
+	}
+
+	ifaces, err := conn.Run("/interface/print", "?dynamic=true", "=.proplist=name,rx-byte,tx-byte")
+	if err != nil {
+		return nil, err
+	}
+	counters := make(map[string]map[string]string, len(ifaces.Re))
+	for _, re := range ifaces.Re {
+		counters[re.Map["name"]] = re.Map
+	}
+
+	sessions := make([]*models.ActivePPPSession, 0, len(r.Re))
+	for _, re := range r.Re {
+		s := &models.ActivePPPSession{
+			ID:       re.Map[".id"],
+			User:     re.Map["name"],
+			Service:  re.Map["service"],
+			CallerID: re.Map["caller-id"],
+			Address:  re.Map["address"],
+			Uptime:   parseRouterOSDuration(re.Map["uptime"]),
+		}
+		s.UptimeText = re.Map["uptime"]
+		if c, ok := counters[fmt.Sprintf("<%s-%s>", s.Service, s.User)]; ok {
+			s.BytesIn, _ = strconv.ParseUint(c["rx-byte"], 10, 64)
+			s.BytesOut, _ = strconv.ParseUint(c["tx-byte"], 10, 64)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, nil
+}
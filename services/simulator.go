@@ -0,0 +1,655 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-routeros/routeros/v3"
+	"github.com/go-routeros/routeros/v3/proto"
+)
+
+// simInterface - State satu interface virtual
+type simInterface struct {
+	id         string
+	name       string
+	ifaceType  string
+	comment    string
+	baseBps    float64 // rata-rata traffic rx
+	txRatio    float64 // tx relatif terhadap rx
+	phase      float64
+	rxBytes    float64
+	txBytes    float64
+	burstUntil time.Time
+	burstGain  float64
+	rxBps      float64
+	txBps      float64
+}
+
+// simQueue - Simple queue virtual; counter mengikuti porsi traffic ether1
+type simQueue struct {
+	id       string
+	name     string
+	target   string
+	maxLimit string
+	share    float64
+	upload   float64
+	download float64
+}
+
+// trafficSimulator - Generator data sintetis untuk router virtual (staging/dev).
+// Menjawab subset sentence RouterOS sehingga pipeline normal tetap dipakai.
+type trafficSimulator struct {
+	routerID  int
+	startedAt time.Time
+	rnd       *rand.Rand
+	mu        sync.Mutex
+	ifaces    []*simInterface
+	queues    []*simQueue
+	lastTick  time.Time
+}
+
+func newTrafficSimulator(routerID int) *trafficSimulator {
+	now := time.Now()
+	return &trafficSimulator{
+		routerID:  routerID,
+		startedAt: now,
+		lastTick:  now,
+		rnd:       rand.New(rand.NewSource(now.UnixNano() + int64(routerID))),
+		ifaces: []*simInterface{
+			{id: "*1", name: "ether1", ifaceType: "ether", comment: "Uplink", baseBps: 80e6, txRatio: 0.25, phase: 0},
+			{id: "*2", name: "ether2", ifaceType: "ether", comment: "LAN", baseBps: 40e6, txRatio: 1.8, phase: 1.3},
+			{id: "*3", name: "ether3", ifaceType: "ether", comment: "Server", baseBps: 15e6, txRatio: 0.6, phase: 2.1},
+			{id: "*4", name: "wlan1", ifaceType: "wlan", comment: "Hotspot AP", baseBps: 8e6, txRatio: 0.4, phase: 3.7},
+		},
+		queues: []*simQueue{
+			{id: "*1", name: "cust-1", target: fmt.Sprintf("10.%d.0.10/32", routerID%256), maxLimit: "20M/50M", share: 0.4},
+			{id: "*2", name: "cust-2", target: fmt.Sprintf("10.%d.0.11/32", routerID%256), maxLimit: "10M/20M", share: 0.25},
+			{id: "*3", name: "cust-3", target: fmt.Sprintf("10.%d.0.12/32", routerID%256), maxLimit: "10M/20M", share: 0.2},
+			{id: "*4", name: "cust-4", target: fmt.Sprintf("10.%d.0.13/32", routerID%256), maxLimit: "5M/10M", share: 0.15},
+		},
+	}
+}
+
+// advance - Update rate & counter semua interface sampai waktu sekarang
+func (s *trafficSimulator) advance(now time.Time) {
+	dt := now.Sub(s.lastTick).Seconds()
+	if dt <= 0 {
+		return
+	}
+	s.lastTick = now
+
+	elapsed := now.Sub(s.startedAt).Seconds()
+	hour := float64(now.Hour()) + float64(now.Minute())/60
+
+	for _, iface := range s.ifaces {
+		// Pola harian (puncak malam) + gelombang pendek 5 menit + noise
+		daily := 0.6 + 0.4*math.Sin((hour-14)/24*2*math.Pi)
+		short := 0.15 * math.Sin(elapsed/300*2*math.Pi+iface.phase)
+		noise := (s.rnd.Float64() - 0.5) * 0.1
+
+		// Burst acak: ~2% peluang per detik, berlangsung 5-20 detik
+		if now.After(iface.burstUntil) && s.rnd.Float64() < 0.02*dt {
+			iface.burstUntil = now.Add(time.Duration(5+s.rnd.Intn(15)) * time.Second)
+			iface.burstGain = 1.5 + s.rnd.Float64()
+		}
+		gain := 1.0
+		if now.Before(iface.burstUntil) {
+			gain = iface.burstGain
+		}
+
+		factor := math.Max(0.02, (daily+short+noise)*gain)
+		iface.rxBps = iface.baseBps * factor
+		iface.txBps = iface.baseBps * iface.txRatio * factor * (0.9 + 0.2*s.rnd.Float64())
+		iface.rxBytes += iface.rxBps / 8 * dt
+		iface.txBytes += iface.txBps / 8 * dt
+	}
+
+	// ether1 = uplink: rx jadi download pelanggan, tx jadi upload
+	if wan := s.find("ether1"); wan != nil {
+		for _, q := range s.queues {
+			q.download += wan.rxBps * q.share / 8 * dt
+			q.upload += wan.txBps * q.share / 8 * dt
+		}
+	}
+}
+
+func (s *trafficSimulator) find(name string) *simInterface {
+	for _, iface := range s.ifaces {
+		if iface.name == name || iface.id == name {
+			return iface
+		}
+	}
+	return nil
+}
+
+// run - Jawab sentence RouterOS yang didukung simulator
+func (s *trafficSimulator) run(sentence []string) (*routeros.Reply, error) {
+	if len(sentence) == 0 {
+		return nil, fmt.Errorf("empty sentence")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance(time.Now())
+
+	args, queries := parseSentence(sentence[1:])
+
+	switch sentence[0] {
+	case "/interface/print":
+		reply := &routeros.Reply{}
+		for i, iface := range s.ifaces {
+			if name, ok := queries["name"]; ok && name != iface.name {
+				continue
+			}
+			if id, ok := queries[".id"]; ok && id != iface.id {
+				continue
+			}
+			reply.Re = append(reply.Re, simSentence(map[string]string{
+				".id":               iface.id,
+				"name":              iface.name,
+				"type":              iface.ifaceType,
+				"running":           "true",
+				"disabled":          "false",
+				"rx-bytes":          formatCounter(iface.rxBytes),
+				"tx-bytes":          formatCounter(iface.txBytes),
+				"rx-packets":        formatCounter(iface.rxBytes / 800),
+				"tx-packets":        formatCounter(iface.txBytes / 800),
+				"rx-error":          "0",
+				"tx-error":          "0",
+				"rx-drop":           "0",
+				"tx-drop":           "0",
+				"comment":           iface.comment,
+				"mac-address":       fmt.Sprintf("02:00:00:%02X:00:%02X", s.routerID%256, i+1),
+				"mtu":               "1500",
+				"last-link-up-time": s.startedAt.Format("2006-01-02 15:04:05"),
+			}))
+		}
+		return reply, nil
+
+	case "/interface/monitor-traffic":
+		// interface=a,b,c: satu item per interface seperti RouterOS
+		reply := &routeros.Reply{}
+		for _, name := range strings.Split(args["interface"], ",") {
+			if iface := s.find(name); iface != nil {
+				reply.Re = append(reply.Re, simSentence(s.trafficMap(iface)))
+			}
+		}
+		return reply, nil
+
+	case "/interface/ethernet/print":
+		// stats ethernet: link virtual selalu bersih
+		reply := &routeros.Reply{}
+		for _, iface := range s.ifaces {
+			if iface.ifaceType == "ether" {
+				reply.Re = append(reply.Re, simSentence(map[string]string{
+					"name":         iface.name,
+					"rx-fcs-error": "0",
+				}))
+			}
+		}
+		return reply, nil
+
+	case "/interface/wireless/print":
+		reply := &routeros.Reply{}
+		for _, iface := range s.ifaces {
+			if iface.ifaceType == "wlan" {
+				reply.Re = append(reply.Re, simSentence(map[string]string{
+					".id":              iface.id,
+					"name":             iface.name,
+					"mode":             "station",
+					"ssid":             fmt.Sprintf("backhaul-%d", s.routerID),
+					"band":             "5ghz-a/n",
+					"frequency":        "5180",
+					"security-profile": "default",
+					"running":          "true",
+					"disabled":         "false",
+				}))
+			}
+		}
+		return reply, nil
+
+	case "/interface/wireless/registration-table/print":
+		// Mode station: satu entry, AP backhaul di seberang link
+		reply := &routeros.Reply{}
+		for _, iface := range s.ifaces {
+			if iface.ifaceType != "wlan" {
+				continue
+			}
+			m := s.wirelessMap(iface)
+			m[".id"] = iface.id
+			m["interface"] = iface.name
+			m["mac-address"] = fmt.Sprintf("02:AA:00:%02X:00:01", s.routerID%256)
+			m["last-ip"] = "10.255.0.1"
+			m["uptime"] = time.Since(s.startedAt).Round(time.Second).String()
+			if mac, ok := queries["mac-address"]; ok && mac != m["mac-address"] {
+				continue
+			}
+			reply.Re = append(reply.Re, simSentence(m))
+		}
+		return reply, nil
+
+	case "/caps-man/manager/print":
+		// Router virtual bukan controller CAPsMAN
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(map[string]string{"enabled": "false"})}}, nil
+
+	case "/caps-man/remote-cap/print", "/caps-man/registration-table/print", "/caps-man/radio/print",
+		"/caps-man/provisioning/print":
+		return &routeros.Reply{}, nil
+
+	case "/interface/wireless/security-profiles/print":
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(map[string]string{".id": "*0", "name": "default"})}}, nil
+
+	case "/interface/wireless/monitor":
+		iface := s.find(args["numbers"])
+		if iface == nil || iface.ifaceType != "wlan" {
+			return nil, fmt.Errorf("no such item")
+		}
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(s.wirelessMap(iface))}}, nil
+
+	case "/interface/vlan/print", "/interface/bonding/print", "/interface/bridge/port/print":
+		// Router virtual tidak punya VLAN, bonding maupun port bridge
+		return &routeros.Reply{}, nil
+
+	case "/queue/simple/print":
+		reply := &routeros.Reply{}
+		for _, q := range s.queues {
+			if name, ok := queries["name"]; ok && name != q.name {
+				continue
+			}
+			if id, ok := queries[".id"]; ok && id != q.id {
+				continue
+			}
+			reply.Re = append(reply.Re, simSentence(map[string]string{
+				".id":         q.id,
+				"name":        q.name,
+				"target":      q.target,
+				"max-limit":   q.maxLimit,
+				"burst-limit": "0/0",
+				"disabled":    "false",
+				"bytes":       formatCounter(q.upload) + "/" + formatCounter(q.download),
+			}))
+		}
+		return reply, nil
+
+	case "/tool/torch":
+		iface := s.find(args["interface"])
+		if iface == nil {
+			return &routeros.Reply{}, nil
+		}
+		return &routeros.Reply{Re: s.torchFlows(iface)}, nil
+
+	case "/ip/address/print":
+		// Uplink bersama (prefix sama antar router virtual) + LAN per router
+		reply := &routeros.Reply{}
+		for i, a := range []struct{ address, network, iface string }{
+			{fmt.Sprintf("172.16.0.%d/24", 1+s.routerID%254), "172.16.0.0", "ether1"},
+			{fmt.Sprintf("10.%d.0.1/24", s.routerID%256), fmt.Sprintf("10.%d.0.0", s.routerID%256), "ether2"},
+		} {
+			reply.Re = append(reply.Re, simSentence(map[string]string{
+				".id":       fmt.Sprintf("*%d", i+1),
+				"address":   a.address,
+				"network":   a.network,
+				"interface": a.iface,
+				"disabled":  "false",
+				"dynamic":   "false",
+			}))
+		}
+		return reply, nil
+
+	case "/ip/pool/print":
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(map[string]string{
+			"name":   "dhcp-lan",
+			"ranges": fmt.Sprintf("10.%d.0.10-10.%d.0.200", s.routerID%256, s.routerID%256),
+		})}}, nil
+
+	case "/ip/pool/used/print":
+		// Lease klien LAN virtual
+		reply := &routeros.Reply{}
+		for i := range simClientPrefixes {
+			reply.Re = append(reply.Re, simSentence(map[string]string{
+				"pool":    "dhcp-lan",
+				"address": fmt.Sprintf("10.%d.0.%d", s.routerID%256, 10+i),
+			}))
+		}
+		return reply, nil
+
+	case "/ip/route/print":
+		// Satu WAN: default route lewat gateway uplink
+		reply := &routeros.Reply{}
+		dst, ok := queries["dst-address"]
+		id, hasID := queries[".id"]
+		if (!ok || dst == "0.0.0.0/0") && (!hasID || id == "*1") && queries["dynamic"] != "true" {
+			reply.Re = append(reply.Re, simSentence(map[string]string{
+				".id":           "*1",
+				"dst-address":   "0.0.0.0/0",
+				"gateway":       "172.16.0.254",
+				"distance":      "1",
+				"routing-table": "main",
+				"active":        "true",
+				"disabled":      "false",
+				"dynamic":       "false",
+				"static":        "true",
+			}))
+		}
+		return reply, nil
+
+	case "/tool/netwatch/print":
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(map[string]string{
+			".id":      "*1",
+			"host":     "172.16.0.254",
+			"status":   "up",
+			"since":    s.startedAt.Format("2006-01-02 15:04:05"),
+			"comment":  "WAN gateway",
+			"disabled": "false",
+		})}}, nil
+
+	case "/user/print":
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(map[string]string{
+			"name":  queries["name"],
+			"group": "full",
+		})}}, nil
+
+	case "/user/group/print":
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(map[string]string{
+			"name":   "full",
+			"policy": "local,telnet,ssh,ftp,reboot,read,write,policy,test,winbox,password,web,sniff,sensitive,api,romon,rest-api",
+		})}}, nil
+
+	case "/ping":
+		return &routeros.Reply{Re: s.pingReplies(args["address"], args["count"])}, nil
+
+	case "/ppp/secret/print", "/ip/firewall/filter/print", "/ip/firewall/nat/print", "/ip/firewall/mangle/print",
+		"/ip/firewall/address-list/print":
+		// Router virtual tanpa PPP secret dan rule firewall
+		return &routeros.Reply{}, nil
+
+	case "/ip/dns/static/print", "/system/scheduler/print":
+		return &routeros.Reply{}, nil
+
+	case "/ip/dns/print":
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(map[string]string{
+			"servers":               "1.1.1.1,8.8.8.8",
+			"dynamic-servers":       "",
+			"allow-remote-requests": "true",
+			"cache-size":            "2048KiB",
+			"cache-used":            "180KiB",
+			"cache-max-ttl":         "1w",
+		})}}, nil
+
+	case "/ip/arp/print", "/ip/dhcp-server/lease/print", "/interface/bridge/host/print":
+		return &routeros.Reply{Re: s.hostEntries(sentence[0])}, nil
+
+	case "/system/package/print":
+		reply := &routeros.Reply{}
+		for _, name := range []string{"routeros", "wireless"} {
+			reply.Re = append(reply.Re, simSentence(map[string]string{
+				"name":     name,
+				"version":  "7.15",
+				"disabled": "false",
+			}))
+		}
+		return reply, nil
+
+	case "/system/resource/print":
+		uptime := time.Since(s.startedAt).Truncate(time.Second)
+		cpu := 20 + 15*math.Sin(float64(time.Now().Unix())/600*2*math.Pi) + s.rnd.Float64()*10
+		total := 1024 * 1024 * 1024.0
+		free := total * (0.55 + 0.1*math.Sin(float64(time.Now().Unix())/1800*2*math.Pi))
+		return &routeros.Reply{Re: []*proto.Sentence{simSentence(map[string]string{
+			"version":           "7.15 (virtual)",
+			"uptime":            uptime.String(),
+			"cpu-load":          strconv.Itoa(int(cpu)),
+			"free-memory":       formatCounter(free),
+			"total-memory":      formatCounter(total),
+			"board-name":        "CHR-virtual",
+			"architecture-name": "x86_64",
+		})}}, nil
+
+	case "/system/reboot", "/system/shutdown", "/execute":
+		// Router virtual "reboot" saat koneksi dibuat ulang (simulator baru, uptime mulai dari nol)
+		return &routeros.Reply{}, nil
+
+	case "/log/print":
+		// Log boot router virtual
+		started := s.startedAt.Format("15:04:05")
+		reply := &routeros.Reply{}
+		for i, entry := range [][2]string{
+			{"system,info", "router rebooted"},
+			{"interface,info", "ether1 link up (speed 1G, full duplex)"},
+			{"dhcp,info", "dhcp-lan assigned lease to B8:27:EB:00:00:01"},
+			{"system,info,account", "user admin logged in via api"},
+		} {
+			reply.Re = append(reply.Re, simSentence(map[string]string{
+				".id":     fmt.Sprintf("*%X", i+1),
+				"time":    started,
+				"topics":  entry[0],
+				"message": entry[1],
+			}))
+		}
+		return reply, nil
+	}
+
+	return nil, fmt.Errorf("command %s not supported on virtual router", sentence[0])
+}
+
+// simClientPrefixes - Prefix vendor MAC klien LAN virtual (urutan sama dengan queue cust-N)
+var simClientPrefixes = []string{"B8:27:EB", "74:83:C2", "50:C7:BF", "08:00:27"}
+
+// hostEntries - ARP, DHCP lease dan bridge host untuk klien LAN (target queue) router virtual
+func (s *trafficSimulator) hostEntries(command string) []*proto.Sentence {
+	var entries []*proto.Sentence
+	for i, prefix := range simClientPrefixes {
+		mac := fmt.Sprintf("%s:%02X:%02X:%02X", prefix, s.routerID%256, 0x10, i+1)
+		address := fmt.Sprintf("10.%d.0.%d", s.routerID%256, 10+i)
+		m := map[string]string{".id": fmt.Sprintf("*%d", i+1), "mac-address": mac}
+
+		switch command {
+		case "/ip/arp/print":
+			m["address"] = address
+			m["interface"] = "ether2"
+			m["dynamic"] = "true"
+			m["complete"] = "true"
+		case "/ip/dhcp-server/lease/print":
+			m["address"] = address
+			m["host-name"] = fmt.Sprintf("cust-%d", i+1)
+			m["server"] = "dhcp-lan"
+			m["status"] = "bound"
+			m["dynamic"] = "true"
+			m["expires-after"] = "9m30s"
+		default:
+			m["bridge"] = "bridge-lan"
+			m["interface"] = "ether2"
+			m["on-interface"] = "ether2"
+			m["dynamic"] = "true"
+			m["local"] = "false"
+		}
+		entries = append(entries, simSentence(m))
+	}
+	return entries
+}
+
+// wirelessMap - Monitor wireless link PtP virtual: signal berayun pelan ikut waktu + noise
+func (s *trafficSimulator) wirelessMap(iface *simInterface) map[string]string {
+	elapsed := time.Since(s.startedAt).Seconds()
+	signal := -62 + 4*math.Sin(elapsed/900*2*math.Pi+iface.phase) + (s.rnd.Float64()-0.5)*2
+	noise := -105.0
+	ccq := math.Min(100, 75+(signal+66)*3+s.rnd.Float64()*5)
+	return map[string]string{
+		"status":             "connected-to-ess",
+		"frequency":          "5180MHz",
+		"ssid":               fmt.Sprintf("backhaul-%d", s.routerID),
+		"radio-name":         fmt.Sprintf("virtual-ap-%d", s.routerID),
+		"signal-strength":    fmt.Sprintf("%ddBm@HT20-7", int(signal)),
+		"tx-signal-strength": fmt.Sprintf("%ddBm", int(signal)-2),
+		"noise-floor":        fmt.Sprintf("%ddBm", int(noise)),
+		"signal-to-noise":    fmt.Sprintf("%ddB", int(signal-noise)),
+		"tx-rate":            "130Mbps-20MHz/2S/SGI",
+		"rx-rate":            "117Mbps-20MHz/2S",
+		"tx-ccq":             strconv.Itoa(int(ccq)),
+		"rx-ccq":             strconv.Itoa(int(ccq) - 3),
+	}
+}
+
+// trafficMap - Representasi monitor-traffic untuk satu interface
+func (s *trafficSimulator) trafficMap(iface *simInterface) map[string]string {
+	return map[string]string{
+		"name":                  iface.name,
+		"rx-bits-per-second":    formatCounter(iface.rxBps),
+		"tx-bits-per-second":    formatCounter(iface.txBps),
+		"rx-packets-per-second": formatCounter(iface.rxBps / 8 / 800),
+		"tx-packets-per-second": formatCounter(iface.txBps / 8 / 800),
+		"rx-bytes":              formatCounter(iface.rxBytes),
+		"tx-bytes":              formatCounter(iface.txBytes),
+		"rx-packets":            formatCounter(iface.rxBytes / 800),
+		"tx-packets":            formatCounter(iface.txBytes / 800),
+	}
+}
+
+// torchFlows - Satu section torch: beberapa flow klien lokal <-> host publik yang
+// membagi rate interface saat ini (distribusi miring, satu klien dominan)
+func (s *trafficSimulator) torchFlows(iface *simInterface) []*proto.Sentence {
+	const flows = 8
+	weights := make([]float64, flows)
+	total := 0.0
+	for i := range weights {
+		weights[i] = (1 + s.rnd.Float64()) / float64(i+1)
+		total += weights[i]
+	}
+
+	sentences := make([]*proto.Sentence, 0, flows)
+	for i, w := range weights {
+		share := w / total
+		sentences = append(sentences, simSentence(map[string]string{
+			".section":    "0",
+			"src-address": fmt.Sprintf("10.%d.0.%d", s.routerID%256, 10+i),
+			"dst-address": fmt.Sprintf("203.0.113.%d", 1+(i*37)%254),
+			"rx":          formatCounter(iface.rxBps * share),
+			"tx":          formatCounter(iface.txBps * share),
+		}))
+	}
+	return sentences
+}
+
+// stream - Emit TrafficStats tiap detik seperti /interface/monitor-traffic, sampai ctx selesai
+func (s *trafficSimulator) stream(ctx context.Context, interfaceName string, callback func(TrafficStats)) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			s.advance(now)
+			iface := s.find(interfaceName)
+			var m map[string]string
+			if iface != nil {
+				m = s.trafficMap(iface)
+			}
+			s.mu.Unlock()
+
+			if m == nil {
+				return
+			}
+
+			callback(TrafficStats{
+				RouterID:      s.routerID,
+				InterfaceName: interfaceName,
+				RxBytes:       m["rx-bytes"],
+				TxBytes:       m["tx-bytes"],
+				RxPackets:     m["rx-packets"],
+				TxPackets:     m["tx-packets"],
+				RxBitsPerSec:  m["rx-bits-per-second"],
+				TxBitsPerSec:  m["tx-bits-per-second"],
+				Timestamp:     now,
+			})
+		}
+	}
+}
+
+// parseSentence - Pisahkan attribute (=key=value) dan query (?key=value)
+func parseSentence(words []string) (map[string]string, map[string]string) {
+	args := make(map[string]string)
+	queries := make(map[string]string)
+	for _, word := range words {
+		switch {
+		case strings.HasPrefix(word, "="):
+			if kv := strings.SplitN(word[1:], "=", 2); len(kv) == 2 {
+				args[kv[0]] = kv[1]
+			}
+		case strings.HasPrefix(word, "?"):
+			if kv := strings.SplitN(word[1:], "=", 2); len(kv) == 2 {
+				queries[kv[0]] = kv[1]
+			}
+		}
+	}
+	return args, queries
+}
+
+func simSentence(m map[string]string) *proto.Sentence {
+	sen := &proto.Sentence{Word: "!re", Map: m}
+	for k, v := range m {
+		sen.List = append(sen.List, proto.Pair{Key: k, Value: v})
+	}
+	return sen
+}
+
+func formatCounter(v float64) string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+// pingReplies - Balasan /ping sintetis: rtt dasar stabil per alamat + jitter, sesekali loss.
+// Seperti RouterOS, tiap !re membawa total kumulatif sent/received/rtt.
+func (s *trafficSimulator) pingReplies(address, countArg string) []*proto.Sentence {
+	count, err := strconv.Atoi(countArg)
+	if err != nil || count <= 0 {
+		count = 4
+	}
+
+	hash := 0
+	for _, c := range address {
+		hash = hash*31 + int(c)
+	}
+	base := 2 + float64(hash%40)
+
+	var sentences []*proto.Sentence
+	received := 0
+	minRtt, maxRtt, sumRtt := math.MaxFloat64, 0.0, 0.0
+	for seq := 0; seq < count; seq++ {
+		m := map[string]string{"seq": strconv.Itoa(seq), "host": address}
+		if s.rnd.Float64() >= 0.02 {
+			rtt := base * (0.8 + 0.5*s.rnd.Float64())
+			received++
+			sumRtt += rtt
+			minRtt = math.Min(minRtt, rtt)
+			maxRtt = math.Max(maxRtt, rtt)
+			m["time"] = simRtt(rtt)
+		} else {
+			m["status"] = "timeout"
+		}
+
+		m["sent"] = strconv.Itoa(seq + 1)
+		m["received"] = strconv.Itoa(received)
+		m["packet-loss"] = strconv.Itoa((seq + 1 - received) * 100 / (seq + 1))
+		if received > 0 {
+			m["min-rtt"] = simRtt(minRtt)
+			m["avg-rtt"] = simRtt(sumRtt / float64(received))
+			m["max-rtt"] = simRtt(maxRtt)
+		}
+		sentences = append(sentences, simSentence(m))
+	}
+	return sentences
+}
+
+// simRtt - Format rtt milidetik seperti RouterOS 7 ("12ms345us")
+func simRtt(ms float64) string {
+	us := int(ms * 1000)
+	return fmt.Sprintf("%dms%dus", us/1000, us%1000)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// Warna garis sparkline: rx hijau, tx biru (sama dengan grafik RouterOS)
+var (
+	sparkRxColor = color.RGBA{R: 0x2e, G: 0xa0, B: 0x43, A: 0xff}
+	sparkTxColor = color.RGBA{R: 0x1f, G: 0x6f, B: 0xd0, A: 0xff}
+)
+
+// sparkSeries - Rata-rata rx/tx bps per kolom piksel
+func sparkSeries(samples []*models.TrafficSample, width int) (rx, tx []float64, peak float64) {
+	rx, tx = make([]float64, width), make([]float64, width)
+	if len(samples) == 0 {
+		return rx, tx, 0
+	}
+
+	counts := make([]int, width)
+	for i, s := range samples {
+		col := i * width / len(samples)
+		rx[col] += float64(s.RxBps)
+		tx[col] += float64(s.TxBps)
+		counts[col]++
+	}
+
+	// Kolom tanpa sample (data lebih sedikit dari lebar) memakai nilai kolom sebelumnya
+	for col := range rx {
+		if counts[col] > 0 {
+			rx[col] /= float64(counts[col])
+			tx[col] /= float64(counts[col])
+		} else if col > 0 {
+			rx[col], tx[col] = rx[col-1], tx[col-1]
+		}
+		if rx[col] > peak {
+			peak = rx[col]
+		}
+		if tx[col] > peak {
+			peak = tx[col]
+		}
+	}
+	return rx, tx, peak
+}
+
+// sparkY - Posisi y (0 = atas) nilai v dengan padding 1 piksel
+func sparkY(v, peak float64, height int) int {
+	if peak <= 0 {
+		return height - 1
+	}
+	return height - 1 - int(v/peak*float64(height-2))
+}
+
+// RenderSparklineSVG - Sparkline rx/tx traffic interface sebagai SVG
+func RenderSparklineSVG(samples []*models.TrafficSample, width, height int) []byte {
+	rx, tx, peak := sparkSeries(samples, width)
+
+	points := func(values []float64) string {
+		var b strings.Builder
+		for x, v := range values {
+			fmt.Fprintf(&b, "%d,%d ", x, sparkY(v, peak, height))
+		}
+		return strings.TrimSpace(b.String())
+	}
+	hex := func(c color.RGBA) string { return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B) }
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, width, height, width, height)
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="1" points="%s"/>`, hex(sparkRxColor), points(rx))
+	fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="1" points="%s"/>`, hex(sparkTxColor), points(tx))
+	b.WriteString(`</svg>`)
+	return b.Bytes()
+}
+
+// RenderSparklinePNG - Sparkline rx/tx traffic interface sebagai PNG (latar transparan)
+func RenderSparklinePNG(samples []*models.TrafficSample, width, height int) ([]byte, error) {
+	rx, tx, peak := sparkSeries(samples, width)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for _, line := range []struct {
+		values []float64
+		c      color.RGBA
+	}{{rx, sparkRxColor}, {tx, sparkTxColor}} {
+		for x := 0; x < width; x++ {
+			y := sparkY(line.values[x], peak, height)
+			prev := y
+			if x > 0 {
+				prev = sparkY(line.values[x-1], peak, height)
+			}
+			// Garis vertikal dari titik sebelumnya supaya kurva tersambung
+			from, to := prev, y
+			if from > to {
+				from, to = to, from
+			}
+			for py := from; py <= to; py++ {
+				img.SetRGBA(x, py, line.c)
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
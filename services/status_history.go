@@ -0,0 +1,83 @@
+package services
+
+import (
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// StatusTimeline - Susun periode status router dalam rentang [from, to) dari router_status_history.
+// Status di awal rentang diambil dari transisi terakhir sebelum from; jika belum ada history
+// dipakai from_status transisi pertama atau status router saat ini.
+func StatusTimeline(repo *repository.RouterRepository, router *models.Router, from, to time.Time) (*models.RouterStatusHistory, error) {
+	changes, err := repo.StatusChanges(router.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := repo.LastStatusChange(router.ID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &models.RouterStatusHistory{
+		RouterID:    router.ID,
+		From:        from,
+		To:          to,
+		Periods:     []models.StatusPeriod{},
+		Durations:   make(map[string]int64),
+		Transitions: changes,
+	}
+
+	// Router belum ada sebelum from: timeline dimulai saat router dibuat
+	start := from
+	if router.CreatedAt.After(start) {
+		start = router.CreatedAt
+	}
+
+	var status string
+	switch {
+	case prev != nil:
+		status = prev.Status
+	case len(changes) > 0 && changes[0].FromStatus != nil:
+		status = *changes[0].FromStatus
+	case len(changes) > 0:
+		// Tanpa status awal yang diketahui, timeline dimulai dari transisi pertama
+		start = changes[0].ChangedAt
+		status = changes[0].Status
+	default:
+		status = router.Status
+	}
+
+	now := time.Now()
+	end := to
+	if now.Before(end) {
+		end = now
+	}
+
+	addPeriod := func(status string, startedAt, endedAt time.Time, ongoing bool) {
+		if !endedAt.After(startedAt) {
+			return
+		}
+		seconds := int64(endedAt.Sub(startedAt) / time.Second)
+		history.Periods = append(history.Periods, models.StatusPeriod{
+			Status:          status,
+			StartedAt:       startedAt,
+			EndedAt:         endedAt,
+			DurationSeconds: seconds,
+			Ongoing:         ongoing,
+		})
+		history.Durations[status] += seconds
+	}
+
+	for _, c := range changes {
+		if c.ChangedAt.Before(start) {
+			continue
+		}
+		addPeriod(status, start, c.ChangedAt, false)
+		status, start = c.Status, c.ChangedAt
+	}
+	addPeriod(status, start, end, !to.Before(now))
+
+	return history, nil
+}
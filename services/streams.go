@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+)
+
+// streamRegistry - Stream WebSocket monitor yang sedang aktif per router, supaya bisa
+// ditutup dari sisi server (mis. router dihapus), plus slot Listen RouterOS yang terpakai
+type streamRegistry struct {
+	mu      sync.Mutex
+	next    int64
+	streams map[int]map[int64]func(reason string)
+
+	limit    StreamLimit
+	listens  map[int]int   // router -> Listen stream aktif
+	released chan struct{} // ditutup (lalu diganti) tiap slot dilepas, membangunkan antrean
+}
+
+func newStreamRegistry() *streamRegistry {
+	return &streamRegistry{
+		streams:  make(map[int]map[int64]func(reason string)),
+		listens:  make(map[int]int),
+		released: make(chan struct{}),
+	}
+}
+
+// StreamLimit - Batas Listen stream (monitor-traffic, log follow, torch) bersamaan per router.
+// Session / command API router terbatas; stream yang melebihi batas ditolak, atau menunggu
+// slot kosong selama QueueTimeout.
+type StreamLimit struct {
+	PerRouter    int           // 0 = tanpa batas
+	QueueTimeout time.Duration // 0 = langsung ditolak
+}
+
+// StreamLimitError - Slot Listen stream router penuh (429 di REST, stream_error di WebSocket)
+type StreamLimitError struct {
+	RouterID int
+	Limit    int
+}
+
+func (e *StreamLimitError) Error() string {
+	return fmt.Sprintf("router %d already has %d active streams", e.RouterID, e.Limit)
+}
+
+// ErrorCode - Code machine-readable untuk response API
+func (e *StreamLimitError) ErrorCode() string {
+	return i18n.StreamLimitReached
+}
+
+// SetStreamLimit - Ganti batas stream per router; stream yang sudah berjalan tidak diputus
+func (ms *MikrotikService) SetStreamLimit(limit StreamLimit) {
+	ms.streams.mu.Lock()
+	defer ms.streams.mu.Unlock()
+	ms.streams.limit = limit
+}
+
+// StreamLimitPerRouter - Batas Listen stream per router (0 = tanpa batas)
+func (ms *MikrotikService) StreamLimitPerRouter() int {
+	ms.streams.mu.Lock()
+	defer ms.streams.mu.Unlock()
+	return ms.streams.limit.PerRouter
+}
+
+// ListenStreams - Jumlah Listen stream RouterOS yang sedang berjalan ke router
+func (ms *MikrotikService) ListenStreams(routerID int) int {
+	ms.streams.mu.Lock()
+	defer ms.streams.mu.Unlock()
+	return ms.streams.listens[routerID]
+}
+
+// acquireListen - Ambil satu slot Listen stream router. Jika penuh dan antrean aktif, tunggu
+// slot kosong (onQueued dipanggil sekali, boleh nil) sampai QueueTimeout atau ctx selesai.
+// Fungsi yang dikembalikan wajib dipanggil saat stream berhenti.
+func (ms *MikrotikService) acquireListen(ctx context.Context, routerID int, onQueued func()) (func(), error) {
+	reg := ms.streams
+	var deadline <-chan time.Time
+
+	for {
+		reg.mu.Lock()
+		limit := reg.limit
+		if limit.PerRouter <= 0 || reg.listens[routerID] < limit.PerRouter {
+			reg.listens[routerID]++
+			reg.mu.Unlock()
+
+			var once sync.Once
+			return func() { once.Do(func() { reg.release(routerID) }) }, nil
+		}
+		released := reg.released
+		reg.mu.Unlock()
+
+		if limit.QueueTimeout <= 0 {
+			return nil, &StreamLimitError{RouterID: routerID, Limit: limit.PerRouter}
+		}
+		if deadline == nil {
+			timer := time.NewTimer(limit.QueueTimeout)
+			defer timer.Stop()
+			deadline = timer.C
+			log.Printf("[MONITOR] Router %d stream limit (%d) reached, queueing", routerID, limit.PerRouter)
+			if onQueued != nil {
+				onQueued()
+			}
+		}
+
+		select {
+		case <-released:
+		case <-deadline:
+			return nil, &StreamLimitError{RouterID: routerID, Limit: limit.PerRouter}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// release - Lepas satu slot Listen lalu bangunkan stream yang mengantre
+func (reg *streamRegistry) release(routerID int) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if reg.listens[routerID]--; reg.listens[routerID] <= 0 {
+		delete(reg.listens, routerID)
+	}
+	close(reg.released)
+	reg.released = make(chan struct{})
+}
+
+// RegisterStream - Daftarkan stream router; closeFn dipanggil saat stream harus ditutup server.
+// Fungsi yang dikembalikan wajib dipanggil saat stream selesai.
+func (ms *MikrotikService) RegisterStream(routerID int, closeFn func(reason string)) func() {
+	reg := ms.streams
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.next++
+	id := reg.next
+	if reg.streams[routerID] == nil {
+		reg.streams[routerID] = make(map[int64]func(reason string))
+	}
+	reg.streams[routerID][id] = closeFn
+
+	return func() {
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+		delete(reg.streams[routerID], id)
+		if len(reg.streams[routerID]) == 0 {
+			delete(reg.streams, routerID)
+		}
+	}
+}
+
+// ActiveStreams - Jumlah stream WebSocket aktif untuk router
+func (ms *MikrotikService) ActiveStreams(routerID int) int {
+	ms.streams.mu.Lock()
+	defer ms.streams.mu.Unlock()
+	return len(ms.streams.streams[routerID])
+}
+
+// CloseStreams - Tutup semua stream router, return jumlah stream yang ditutup
+func (ms *MikrotikService) CloseStreams(routerID int, reason string) int {
+	ms.streams.mu.Lock()
+	closers := ms.streams.streams[routerID]
+	delete(ms.streams.streams, routerID)
+	ms.streams.mu.Unlock()
+
+	// Dipanggil di luar lock: closeFn menjalankan unregister milik stream
+	for _, closeFn := range closers {
+		closeFn(reason)
+	}
+	if len(closers) > 0 {
+		log.Printf("[MONITOR] Closed %d stream(s) for router %d: %s", len(closers), routerID, reason)
+	}
+	return len(closers)
+}
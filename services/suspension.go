@@ -0,0 +1,318 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// suspendCommentPrefix - Comment address-list entry yang dibuat suspend customer
+const suspendCommentPrefix = "mikrotik-layer:suspend:"
+
+// SetPPPSecretDisabled - Disable/enable PPP secret; saat disable sesi aktif secret tsb ikut diputus.
+// Idempotent: secret yang sudah di status tujuan tidak di-set ulang.
+func (ms *MikrotikService) SetPPPSecretDisabled(routerID int, name string, disabled, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/ppp/secret/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id,disabled")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("ppp secret %s not found", name)
+	}
+
+	action, state, value := "enable_ppp_secret", "enabled", "no"
+	if disabled {
+		action, state, value = "disable_ppp_secret", "disabled", "yes"
+	}
+	plan := newCommandPlan(routerID, action, dryRun)
+
+	if (r.Re[0].Map["disabled"] == "true") == disabled {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("ppp secret %s already %s", name, state))
+	} else {
+		plan.Commands = append(plan.Commands, []string{
+			"/ppp/secret/set",
+			fmt.Sprintf("=.id=%s", r.Re[0].Map[".id"]),
+			fmt.Sprintf("=disabled=%s", value),
+		})
+	}
+
+	if disabled {
+		active, err := conn.Run("/ppp/active/print", fmt.Sprintf("?name=%s", name), "=.proplist=.id,address")
+		if err != nil {
+			return nil, err
+		}
+		for _, re := range active.Re {
+			plan.Checks = append(plan.Checks, fmt.Sprintf("active session %s (%s) disconnected", name, re.Map["address"]))
+			plan.Commands = append(plan.Commands, []string{
+				"/ppp/active/remove",
+				fmt.Sprintf("=.id=%s", re.Map[".id"]),
+			})
+		}
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// AddSuspendAddressList - Masukkan alamat customer ke address-list blokir. Tanpa address,
+// target queue customer yang dipakai. Entry yang sudah ada di list tidak ditambah ulang.
+func (ms *MikrotikService) AddSuspendAddressList(routerID, customerID int, list string, addresses []string, queueName string, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if len(addresses) == 0 && queueName != "" {
+		r, err := conn.Run("/queue/simple/print", fmt.Sprintf("?name=%s", queueName), "=.proplist=target")
+		if err != nil {
+			return nil, err
+		}
+		if len(r.Re) == 0 {
+			return nil, fmt.Errorf("queue %s not found", queueName)
+		}
+		for _, target := range strings.Split(r.Re[0].Map["target"], ",") {
+			if target = strings.TrimSpace(target); target != "" {
+				addresses = append(addresses, target)
+			}
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("customer %d has no address to suspend", customerID)
+	}
+
+	comment := fmt.Sprintf("%s%d", suspendCommentPrefix, customerID)
+	r, err := conn.Run("/ip/firewall/address-list/print", fmt.Sprintf("?comment=%s", comment), "=.proplist=list,address")
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, re := range r.Re {
+		if re.Map["list"] == list {
+			existing[re.Map["address"]] = true
+		}
+	}
+
+	plan := newCommandPlan(routerID, "add_suspend_address_list", dryRun)
+	for _, address := range addresses {
+		if existing[address] {
+			plan.Checks = append(plan.Checks, fmt.Sprintf("%s already in %s", address, list))
+			continue
+		}
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/firewall/address-list/add",
+			fmt.Sprintf("=list=%s", list),
+			fmt.Sprintf("=address=%s", address),
+			fmt.Sprintf("=comment=%s", comment),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// RemoveSuspendAddressList - Hapus semua entry address-list yang dibuat suspend customer
+func (ms *MikrotikService) RemoveSuspendAddressList(routerID, customerID int, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/ip/firewall/address-list/print",
+		fmt.Sprintf("?comment=%s%d", suspendCommentPrefix, customerID), "=.proplist=.id,list,address")
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newCommandPlan(routerID, "remove_suspend_address_list", dryRun)
+	for _, re := range r.Re {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("entry %s in %s removed", re.Map["address"], re.Map["list"]))
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/firewall/address-list/remove",
+			fmt.Sprintf("=.id=%s", re.Map[".id"]),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// SuspendConfig - Default strategi suspend customer
+type SuspendConfig struct {
+	Strategy      string // strategi jika request tidak menyebutkan
+	AddressList   string // address-list blokir untuk strategi address_list
+	ThrottleLimit string // max-limit queue untuk strategi throttle
+}
+
+// SuspensionService - Suspend/unsuspend customer di router sesuai strategi, diaudit dan idempotent
+// (customer yang sudah di status tujuan tidak menyentuh router)
+type SuspensionService struct {
+	config    SuspendConfig
+	ms        *MikrotikService
+	customers *repository.CustomerRepository
+	plans     *repository.PlanRepository
+	audit     *AuditLogger
+	webhooks  *WebhookDispatcher
+}
+
+func NewSuspensionService(config SuspendConfig, ms *MikrotikService, customers *repository.CustomerRepository, plans *repository.PlanRepository, audit *AuditLogger, webhooks *WebhookDispatcher) *SuspensionService {
+	return &SuspensionService{
+		config:    config,
+		ms:        ms,
+		customers: customers,
+		plans:     plans,
+		audit:     audit,
+		webhooks:  webhooks,
+	}
+}
+
+// DefaultStrategy - Strategi suspend dari konfigurasi
+func (s *SuspensionService) DefaultStrategy() string {
+	return s.config.Strategy
+}
+
+// Suspend - Jalankan strategi suspend untuk customer (strategy kosong = default)
+func (s *SuspensionService) Suspend(c *models.Customer, strategy string, dryRun bool) (*models.CustomerSuspendResult, error) {
+	if strategy == "" {
+		strategy = s.config.Strategy
+	}
+	if c.Status == models.CustomerSuspended {
+		return &models.CustomerSuspendResult{Customer: c, Strategy: derefString(c.SuspendStrategy)}, nil
+	}
+
+	var (
+		plan     *models.CommandPlan
+		original *string
+		err      error
+	)
+	switch strategy {
+	case models.SuspendDisableSecret:
+		if c.PPPSecret == nil || *c.PPPSecret == "" {
+			return nil, fmt.Errorf("customer %d has no ppp secret", c.ID)
+		}
+		plan, err = s.ms.SetPPPSecretDisabled(c.RouterID, *c.PPPSecret, true, dryRun)
+	case models.SuspendAddressList:
+		var addresses []string
+		if c.Address != nil && *c.Address != "" {
+			addresses = []string{*c.Address}
+		}
+		plan, err = s.ms.AddSuspendAddressList(c.RouterID, c.ID, s.config.AddressList, addresses, derefString(c.QueueName), dryRun)
+	case models.SuspendThrottle:
+		if c.QueueName == nil || *c.QueueName == "" {
+			return nil, fmt.Errorf("customer %d has no queue", c.ID)
+		}
+		var previous string
+		plan, previous, err = s.ms.SetQueueMaxLimit(c.RouterID, *c.QueueName, s.config.ThrottleLimit, dryRun)
+		original = &previous
+	default:
+		return nil, fmt.Errorf("unknown suspend strategy %s", strategy)
+	}
+
+	result := &models.CustomerSuspendResult{Customer: c, Strategy: strategy, Changed: true, Plan: plan}
+	if dryRun {
+		return result, err
+	}
+	s.audit.LogPlan("api", "customer_suspend", c.RouterID, c.Name, plan, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.customers.MarkSuspended(c.ID, strategy, original); err != nil {
+		return nil, err
+	}
+	if result.Customer, err = s.customers.GetByID(c.ID); err != nil {
+		return nil, err
+	}
+
+	s.webhooks.Emit(models.WebhookCustomerSuspended, &c.RouterID, map[string]interface{}{
+		"customer_id": c.ID,
+		"name":        c.Name,
+		"strategy":    strategy,
+	})
+	return result, nil
+}
+
+// Unsuspend - Balikkan strategi yang dipakai saat suspend. Throttle dikembalikan ke rate limit
+// plan customer (jika ada), selain itu ke max-limit sebelum suspend.
+func (s *SuspensionService) Unsuspend(c *models.Customer, dryRun bool) (*models.CustomerSuspendResult, error) {
+	if c.Status != models.CustomerSuspended {
+		return &models.CustomerSuspendResult{Customer: c}, nil
+	}
+
+	strategy := derefString(c.SuspendStrategy)
+	var (
+		plan *models.CommandPlan
+		err  error
+	)
+	switch strategy {
+	case models.SuspendDisableSecret:
+		if c.PPPSecret == nil || *c.PPPSecret == "" {
+			return nil, fmt.Errorf("customer %d has no ppp secret", c.ID)
+		}
+		plan, err = s.ms.SetPPPSecretDisabled(c.RouterID, *c.PPPSecret, false, dryRun)
+	case models.SuspendAddressList:
+		plan, err = s.ms.RemoveSuspendAddressList(c.RouterID, c.ID, dryRun)
+	case models.SuspendThrottle:
+		if c.QueueName == nil || *c.QueueName == "" {
+			return nil, fmt.Errorf("customer %d has no queue", c.ID)
+		}
+		limit := derefString(c.SuspendOriginal)
+		if c.PlanID != nil {
+			p, err := s.plans.GetByID(*c.PlanID)
+			if err != nil {
+				return nil, err
+			}
+			limit = p.RateLimit
+		}
+		if limit == "" {
+			return nil, fmt.Errorf("customer %d has no max-limit to restore", c.ID)
+		}
+		plan, _, err = s.ms.SetQueueMaxLimit(c.RouterID, *c.QueueName, limit, dryRun)
+	default:
+		// Status suspended tanpa strategi (diset di luar layer): cukup kembalikan status
+	}
+
+	result := &models.CustomerSuspendResult{Customer: c, Strategy: strategy, Changed: true, Plan: plan}
+	if dryRun {
+		return result, err
+	}
+	if plan != nil || err != nil {
+		s.audit.LogPlan("api", "customer_unsuspend", c.RouterID, c.Name, plan, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.customers.MarkActive(c.ID); err != nil {
+		return nil, err
+	}
+	if result.Customer, err = s.customers.GetByID(c.ID); err != nil {
+		return nil, err
+	}
+
+	s.webhooks.Emit(models.WebhookCustomerUnsuspended, &c.RouterID, map[string]interface{}{
+		"customer_id": c.ID,
+		"name":        c.Name,
+		"strategy":    strategy,
+	})
+	return result, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
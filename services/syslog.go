@@ -0,0 +1,178 @@
+package services
+
+import (
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// SyslogReceiver - Listener UDP syslog; pesan dari router di-parse, disimpan ke
+// tabel events dan dipublish ke hub topic "syslog"
+type SyslogReceiver struct {
+	addr     string
+	resolver *routerResolver
+	events   *repository.EventRepository
+	hub      *Hub
+
+	// Datagram dari source tak dikenal dibuang; jumlahnya di-log paling sering tiap menit
+	dropped       int
+	droppedLogged time.Time
+}
+
+// SyslogMessage - Hasil parse satu datagram syslog
+type SyslogMessage struct {
+	Facility int      `json:"facility"`
+	Severity string   `json:"severity"`
+	Hostname string   `json:"hostname,omitempty"`
+	Topics   []string `json:"topics,omitempty"`
+	Message  string   `json:"message"`
+}
+
+var (
+	// <PRI>Mmm dd hh:mm:ss hostname rest  (RFC3164 / bsd-syslog=yes)
+	bsdSyslogPattern = regexp.MustCompile(`^([A-Z][a-z]{2} +\d{1,2} \d{2}:\d{2}:\d{2}) (\S+) (.*)$`)
+	// <PRI>1 timestamp hostname app-name procid msgid structured-data [msg]  (RFC5424)
+	ietfSyslogPattern = regexp.MustCompile(`^1 (\S+) (\S+) \S+ \S+ \S+ (?:-|(?:\[(?:[^\]\\]|\\.)*\])+)(?: (.*))?$`)
+	// topics RouterOS: "firewall,info" / "system,error,critical"
+	topicsPattern = regexp.MustCompile(`^[a-z0-9-]+(,[a-z0-9-]+)*$`)
+)
+
+// syslogSeverities - Severity syslog (0-7) ke level event layer
+var syslogSeverities = []string{"critical", "critical", "critical", "error", "warning", "info", "info", "debug"}
+
+func NewSyslogReceiver(addr string, repo *repository.RouterRepository, events *repository.EventRepository, hub *Hub) *SyslogReceiver {
+	return &SyslogReceiver{
+		addr:     addr,
+		resolver: newRouterResolver(repo, "SYSLOG"),
+		events:   events,
+		hub:      hub,
+	}
+}
+
+// Run - Terima datagram syslog sampai listener error (blocking)
+func (s *SyslogReceiver) Run() error {
+	pc, err := net.ListenPacket("udp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer pc.Close()
+
+	log.Printf("[SYSLOG] Listening on udp %s", s.addr)
+	s.resolver.start()
+
+	buf := make([]byte, 8192)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		host, _, _ := net.SplitHostPort(addr.String())
+		s.handle(host, strings.TrimSpace(string(buf[:n])))
+	}
+}
+
+// handle - Parse, simpan dan publish satu pesan; panic di-recover per pesan
+func (s *SyslogReceiver) handle(sourceIP, raw string) {
+	// Bug parser cukup membuang satu pesan, bukan menghentikan receiver
+	defer Recover("syslog-handle")
+
+	if raw == "" {
+		return
+	}
+
+	routerID, ok := s.resolver.match(sourceIP)
+	if !ok {
+		s.dropUnknown(sourceIP)
+		return
+	}
+
+	msg := ParseSyslog(raw)
+
+	event := &models.Event{
+		Type:     "syslog",
+		Severity: msg.Severity,
+		Source:   &sourceIP,
+		Message:  msg.Message,
+		RouterID: &routerID,
+	}
+	event.Data = mustJSON(map[string]interface{}{
+		"facility": msg.Facility,
+		"hostname": msg.Hostname,
+		"topics":   msg.Topics,
+	})
+
+	if err := s.events.Create(event); err != nil {
+		log.Printf("[SYSLOG] Error storing message from %s: %v", sourceIP, err)
+	}
+
+	if s.hub != nil {
+		s.hub.Publish("syslog", event.RouterID, event)
+	}
+}
+
+// dropUnknown - Hitung datagram dari source yang bukan router terdaftar (spoofed /
+// tidak diminta); tidak disimpan dan tidak dipublish
+func (s *SyslogReceiver) dropUnknown(sourceIP string) {
+	s.dropped++
+	if time.Since(s.droppedLogged) < time.Minute {
+		return
+	}
+	log.Printf("[SYSLOG] Dropped %d message(s) from unknown sources (latest %s)", s.dropped, sourceIP)
+	s.dropped = 0
+	s.droppedLogged = time.Now()
+}
+
+// ParseSyslog - Parse format RouterOS remote logging (dengan/ tanpa bsd-syslog)
+func ParseSyslog(raw string) SyslogMessage {
+	msg := SyslogMessage{Facility: -1, Severity: "info", Message: raw}
+	rest := raw
+
+	// <PRI>
+	if strings.HasPrefix(rest, "<") {
+		if end := strings.Index(rest, ">"); end > 1 && end <= 4 {
+			// PRI valid 0-191 (facility 0-23); selain itu pesan diperlakukan raw
+			if pri, err := strconv.ParseUint(rest[1:end], 10, 8); err == nil && pri <= 191 {
+				msg.Facility = int(pri / 8)
+				msg.Severity = syslogSeverities[pri%8]
+				rest = rest[end+1:]
+			}
+		}
+	}
+
+	// Header RFC5424 (hanya setelah PRI valid), selain itu timestamp + hostname RFC3164
+	if m := ietfSyslogPattern.FindStringSubmatch(rest); m != nil && msg.Facility >= 0 {
+		if m[2] != "-" {
+			msg.Hostname = m[2]
+		}
+		rest = strings.TrimPrefix(m[3], "\ufeff")
+	} else if m := bsdSyslogPattern.FindStringSubmatch(rest); m != nil {
+		msg.Hostname = m[2]
+		rest = m[3]
+	}
+
+	// Topics RouterOS di token pertama
+	if fields := strings.SplitN(rest, " ", 2); len(fields) == 2 && topicsPattern.MatchString(fields[0]) {
+		msg.Topics = strings.Split(fields[0], ",")
+		rest = fields[1]
+
+		// Tanpa PRI, severity diambil dari topics
+		if msg.Facility < 0 {
+			for _, topic := range msg.Topics {
+				switch topic {
+				case "critical", "error", "warning", "debug":
+					msg.Severity = topic
+				}
+			}
+		}
+	}
+
+	msg.Message = strings.TrimSpace(rest)
+	return msg
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSyslog(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want SyslogMessage
+	}{
+		{
+			name: "rfc3164 with routeros topics",
+			raw:  "<30>Oct 16 10:00:01 core-1 system,info user admin logged in",
+			want: SyslogMessage{Facility: 3, Severity: "info", Hostname: "core-1", Topics: []string{"system", "info"}, Message: "user admin logged in"},
+		},
+		{
+			name: "rfc3164 single digit day",
+			raw:  "<131>Oct  6 09:15:00 edge firewall,error input: in:ether1 out:(unknown 0)",
+			want: SyslogMessage{Facility: 16, Severity: "error", Hostname: "edge", Topics: []string{"firewall", "error"}, Message: "input: in:ether1 out:(unknown 0)"},
+		},
+		{
+			name: "rfc5424 without structured data",
+			raw:  "<134>1 2026-10-16T10:00:01.003Z core-1 routeros - - - dhcp,info lease assigned",
+			want: SyslogMessage{Facility: 16, Severity: "info", Hostname: "core-1", Topics: []string{"dhcp", "info"}, Message: "lease assigned"},
+		},
+		{
+			name: "rfc5424 with structured data and bom",
+			raw:  `<11>1 2026-10-16T10:00:01+07:00 core-1 app 42 ID7 [origin ip="192.0.2.1"][meta note="a\]b"] ` + "\ufeffDisk full",
+			want: SyslogMessage{Facility: 1, Severity: "error", Hostname: "core-1", Message: "Disk full"},
+		},
+		{
+			name: "rfc5424 nil hostname and empty message",
+			raw:  "<165>1 - - - - - -",
+			want: SyslogMessage{Facility: 20, Severity: "info"},
+		},
+		{
+			name: "rfc5424 header needs a valid pri",
+			raw:  "<192>1 2026-10-16T10:00:01Z core-1 - - - - hello",
+			want: SyslogMessage{Facility: -1, Severity: "info", Message: "<192>1 2026-10-16T10:00:01Z core-1 - - - - hello"},
+		},
+		{
+			name: "routeros default format without pri",
+			raw:  "wireless,warning wlan1: signal strength low",
+			want: SyslogMessage{Facility: -1, Severity: "warning", Topics: []string{"wireless", "warning"}, Message: "wlan1: signal strength low"},
+		},
+		{
+			name: "pri severity wins over topics",
+			raw:  "<15>system,critical reboot requested",
+			want: SyslogMessage{Facility: 1, Severity: "debug", Topics: []string{"system", "critical"}, Message: "reboot requested"},
+		},
+		{
+			name: "plain message",
+			raw:  "Hello World",
+			want: SyslogMessage{Facility: -1, Severity: "info", Message: "Hello World"},
+		},
+		{name: "pri lower bound", raw: "<0>boot", want: SyslogMessage{Facility: 0, Severity: "critical", Message: "boot"}},
+		{name: "pri upper bound", raw: "<191>x y", want: SyslogMessage{Facility: 23, Severity: "debug", Topics: []string{"x"}, Message: "y"}},
+		{name: "pri above 191", raw: "<192>x y", want: SyslogMessage{Facility: -1, Severity: "info", Message: "<192>x y"}},
+		{name: "pri overflows uint8", raw: "<256>x y", want: SyslogMessage{Facility: -1, Severity: "info", Message: "<256>x y"}},
+		{name: "negative pri", raw: "<-1>x y", want: SyslogMessage{Facility: -1, Severity: "info", Message: "<-1>x y"}},
+		{name: "empty pri", raw: "<>x y", want: SyslogMessage{Facility: -1, Severity: "info", Message: "<>x y"}},
+		{name: "pri too long", raw: "<0001>x y", want: SyslogMessage{Facility: -1, Severity: "info", Message: "<0001>x y"}},
+		{name: "unterminated pri", raw: "<13 hello", want: SyslogMessage{Facility: -1, Severity: "info", Message: "<13 hello"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseSyslog(tt.raw); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSyslog(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// Aksi power router
+const (
+	PowerReboot   = "reboot"
+	PowerShutdown = "shutdown"
+)
+
+const (
+	// rebootTimeout - Batas tunggu router kembali setelah reboot sebelum ditandai error
+	rebootTimeout = 10 * time.Minute
+
+	// powerGrace - Jeda setelah delay sebelum koneksi dilepas (router butuh waktu memproses reboot)
+	powerGrace = 5 * time.Second
+)
+
+// PowerRouter - Reboot / shutdown router lewat /system/reboot|shutdown. delay > 0 dijadwalkan di
+// router (:delay lewat /execute) supaya tetap jalan walau layer restart. Setelah delay koneksi
+// dilepas; reboot menandai status "rebooting" sampai router ter-connect lagi, shutdown "offline".
+func (ms *MikrotikService) PowerRouter(routerID int, action string, delay time.Duration, dryRun bool) (*models.CommandPlan, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	plan := newCommandPlan(routerID, "system_"+action, dryRun)
+	if delay > 0 {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("%s scheduled on router in %v", action, delay))
+		plan.Commands = append(plan.Commands, []string{
+			"/execute",
+			fmt.Sprintf("=script=:delay %ds; /system %s", int(delay.Seconds()), action),
+		})
+	} else {
+		plan.Commands = append(plan.Commands, []string{"/system/" + action})
+	}
+
+	// Router memutus koneksi API saat reboot / shutdown: error koneksi berarti command diterima
+	if err := executePlan(conn, plan); err != nil && !isConnectionError(err) {
+		return plan, err
+	}
+	if dryRun {
+		return plan, nil
+	}
+
+	status := "rebooting"
+	if action == PowerShutdown {
+		status = "offline"
+	}
+	log.Printf("[POWER] Router %s: %s in %v", conn.Router.Name, action, delay)
+	go ms.awaitPower(routerID, action, status, delay)
+	return plan, nil
+}
+
+// awaitPower - Setelah delay lepas koneksi dan tandai status; reboot lalu dicoba connect ulang
+// sampai router kembali (status online dari ConnectRouter) atau rebootTimeout lewat
+func (ms *MikrotikService) awaitPower(routerID int, action, status string, delay time.Duration) {
+	defer Recover("power-" + action)
+
+	time.Sleep(delay + powerGrace)
+	ms.power.Store(routerID, time.Now())
+	ms.dropConnection(routerID, "router "+action)
+	if err := ms.repo.SetStatus(routerID, status); err != nil {
+		log.Printf("[POWER] Error setting router %d status %s: %v", routerID, status, err)
+	}
+	if action != PowerReboot {
+		return
+	}
+
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+	deadline := time.Now().Add(rebootTimeout)
+	for range ticker.C {
+		if !ms.powerPending(routerID) {
+			return // sudah ter-connect lewat jalur lain (GetConnection / connect manual)
+		}
+		if time.Now().After(deadline) {
+			ms.power.Delete(routerID)
+			log.Printf("[POWER] Router %d not back %v after reboot", routerID, rebootTimeout)
+			ms.repo.UpdateStatus(routerID, &models.RouterStatusUpdate{Status: "error"})
+			return
+		}
+		if err := ms.ConnectRouter(routerID); err == nil {
+			log.Printf("[POWER] ✓ Router %d back online after reboot", routerID)
+			return
+		}
+	}
+}
+
+// powerPending - True selama reboot / shutdown lewat layer belum selesai (router belum kembali);
+// selama itu dial yang gagal tidak menimpa status rebooting / offline dengan "error"
+func (ms *MikrotikService) powerPending(routerID int) bool {
+	_, ok := ms.power.Load(routerID)
+	return ok
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// taskScheduleOutputLimit - Output run lebih dari ini dipotong (kolom last_output TEXT)
+const taskScheduleOutputLimit = 4 << 10
+
+// TaskScheduler - Jalankan jadwal task (task_schedules) berdasarkan ekspresi cron: backup, reboot,
+// sinkronisasi queue. Tiap tick jadwal yang jatuh tempo dimajukan dulu ke waktu berikutnya lalu
+// dijalankan di background, sehingga run yang lama atau gagal tidak diulang sampai jadwal berikutnya.
+type TaskScheduler struct {
+	interval  time.Duration
+	location  *time.Location
+	ms        *MikrotikService
+	repo      *repository.TaskScheduleRepository
+	backups   *BackupService
+	plans     *repository.PlanRepository
+	customers *repository.CustomerRepository
+	planSvc   *PlanService
+	audit     *AuditLogger
+
+	running sync.Map // schedule ID -> struct{}: cegah run ganda (tick + run-now)
+}
+
+func NewTaskScheduler(interval time.Duration, location *time.Location, ms *MikrotikService, repo *repository.TaskScheduleRepository, backups *BackupService, plans *repository.PlanRepository, customers *repository.CustomerRepository, planSvc *PlanService, audit *AuditLogger) *TaskScheduler {
+	if location == nil {
+		location = time.Local
+	}
+	return &TaskScheduler{
+		interval:  interval,
+		location:  location,
+		ms:        ms,
+		repo:      repo,
+		backups:   backups,
+		plans:     plans,
+		customers: customers,
+		planSvc:   planSvc,
+		audit:     audit,
+	}
+}
+
+// Run - Loop scheduler (blocking)
+func (s *TaskScheduler) Run() {
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		now := time.Now()
+		schedules, err := s.repo.Due(now)
+		if err != nil {
+			log.Printf("[TASK] Error loading due task schedules: %v", err)
+			continue
+		}
+
+		for _, sch := range schedules {
+			if err := s.repo.SetNextRun(sch.ID, s.NextRun(sch, now)); err != nil {
+				log.Printf("[TASK] Error scheduling next run of %s: %v", sch.Name, err)
+				continue
+			}
+			go func(sch *models.TaskSchedule) {
+				defer Recover("task-schedule")
+				if _, err := s.RunNow(sch, "scheduler"); err != nil {
+					log.Printf("[TASK] %s skipped: %v", sch.Name, err)
+				}
+			}(sch)
+		}
+	}
+}
+
+// NextRun - Waktu run berikutnya setelah after menurut cron di zona waktu scheduler;
+// nil jika jadwal nonaktif atau cron tidak punya waktu cocok
+func (s *TaskScheduler) NextRun(sch *models.TaskSchedule, after time.Time) *time.Time {
+	if !sch.Enabled {
+		return nil
+	}
+	expr, err := models.ParseCron(sch.Cron)
+	if err != nil {
+		return nil
+	}
+	next := expr.Next(after.In(s.location))
+	if next.IsZero() {
+		return nil
+	}
+	return &next
+}
+
+// RunNow - Eksekusi jadwal sekarang (sinkron) lalu simpan hasilnya. Error hanya jika jadwal yang
+// sama sedang berjalan; kegagalan action dikembalikan sebagai run berstatus failed.
+func (s *TaskScheduler) RunNow(sch *models.TaskSchedule, actor string) (*models.TaskScheduleRun, error) {
+	if _, busy := s.running.LoadOrStore(sch.ID, struct{}{}); busy {
+		return nil, i18n.NewError(i18n.TaskScheduleBusy)
+	}
+	defer s.running.Delete(sch.ID)
+
+	run := &models.TaskScheduleRun{ScheduleID: sch.ID, Status: models.TaskScheduleOK, StartedAt: time.Now()}
+	output, err := s.execute(sch, actor)
+	run.FinishedAt = time.Now()
+	if len(output) > taskScheduleOutputLimit {
+		output = output[:taskScheduleOutputLimit]
+	}
+	run.Output = output
+	if err != nil {
+		run.Status, run.Error = models.TaskScheduleFailed, err.Error()
+		log.Printf("[TASK] %s (%s router %d) failed: %v", sch.Name, sch.Action, sch.RouterID, err)
+	} else {
+		log.Printf("[TASK] %s (%s router %d) done in %s", sch.Name, sch.Action, sch.RouterID,
+			run.FinishedAt.Sub(run.StartedAt).Round(time.Millisecond))
+	}
+
+	if err := s.repo.SetResult(sch.ID, run); err != nil {
+		log.Printf("[TASK] Error saving result of %s: %v", sch.Name, err)
+	}
+	return run, nil
+}
+
+func (s *TaskScheduler) execute(sch *models.TaskSchedule, actor string) (string, error) {
+	switch sch.Action {
+	case models.TaskScheduleBackup:
+		req := sch.Backup
+		if req == nil {
+			req = &models.BackupRequest{Kind: models.BackupExport}
+		}
+		backup, err := s.backups.Create(sch.RouterID, req, actor)
+		if err != nil {
+			return "", err
+		}
+		return string(mustJSON(backup)), nil
+
+	case models.TaskScheduleReboot:
+		plan, err := s.ms.PowerRouter(sch.RouterID, PowerReboot, 0, false)
+		s.audit.LogPlan(actor, "system_reboot", sch.RouterID, sch.Name, plan, err)
+		if err != nil {
+			return "", err
+		}
+		return string(mustJSON(plan)), nil
+
+	case models.TaskScheduleQueueSync:
+		results, err := s.syncQueues(sch.RouterID)
+		if results == nil {
+			return "", err
+		}
+		return string(mustJSON(results)), err
+	}
+	return "", fmt.Errorf("unknown task schedule action %s", sch.Action)
+}
+
+// syncQueues - Terapkan ulang plan ke queue / secret semua customer router (per plan), memperbaiki
+// rate limit yang diubah manual di router
+func (s *TaskScheduler) syncQueues(routerID int) ([]*models.PlanApplyResult, error) {
+	customers, err := s.customers.List(models.CustomerFilter{RouterID: &routerID})
+	if err != nil {
+		return nil, err
+	}
+
+	byPlan := make(map[int][]*models.Customer)
+	var planIDs []int
+	for _, c := range customers {
+		if c.PlanID == nil {
+			continue
+		}
+		if _, ok := byPlan[*c.PlanID]; !ok {
+			planIDs = append(planIDs, *c.PlanID)
+		}
+		byPlan[*c.PlanID] = append(byPlan[*c.PlanID], c)
+	}
+
+	results := []*models.PlanApplyResult{}
+	var errs []error
+	for _, planID := range planIDs {
+		plan, err := s.plans.GetByID(planID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("plan %d: %w", planID, err))
+			continue
+		}
+		for _, result := range s.planSvc.Apply(plan, byPlan[planID], false) {
+			if result.Error != "" {
+				errs = append(errs, fmt.Errorf("plan %s: %s", plan.Name, result.Error))
+			}
+			results = append(results, result)
+		}
+	}
+	return results, errors.Join(errs...)
+}
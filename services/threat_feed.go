@@ -0,0 +1,303 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+const (
+	// feedCommentPrefix - Comment entry address-list milik feed; entry lain di list yang sama
+	// (mis. blokir manual) tidak pernah disentuh sync
+	feedCommentPrefix = "mikrotik-layer:feed:"
+
+	// feedMaxBody - Batas ukuran unduhan feed
+	feedMaxBody = 32 << 20
+)
+
+// ThreatFeedSyncer - Ambil threat feed (tabel threat_feeds) sesuai interval masing-masing lalu
+// sinkronkan ke address-list router tujuan: entry baru ditambah, entry yang hilang dari feed dihapus
+type ThreatFeedSyncer struct {
+	interval   time.Duration
+	maxEntries int // batas global, max_entries feed tidak boleh melebihi ini
+	ms         *MikrotikService
+	repo       *repository.ThreatFeedRepository
+	audit      *AuditLogger
+	client     *http.Client
+}
+
+func NewThreatFeedSyncer(interval time.Duration, maxEntries int, ms *MikrotikService, repo *repository.ThreatFeedRepository, audit *AuditLogger) *ThreatFeedSyncer {
+	return &ThreatFeedSyncer{
+		interval:   interval,
+		maxEntries: maxEntries,
+		ms:         ms,
+		repo:       repo,
+		audit:      audit,
+		client:     &http.Client{Timeout: time.Minute},
+	}
+}
+
+// MaxEntries - Batas global entry per feed
+func (s *ThreatFeedSyncer) MaxEntries() int {
+	return s.maxEntries
+}
+
+// Run - Loop pengecekan feed yang jatuh tempo (blocking)
+func (s *ThreatFeedSyncer) Run() {
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		feeds, err := s.repo.List(true)
+		if err != nil {
+			log.Printf("[FEED] Error loading threat feeds: %v", err)
+			continue
+		}
+
+		now := time.Now()
+		for _, feed := range feeds {
+			if !feed.Due(now) {
+				continue
+			}
+			if _, err := s.Sync(feed, false); err != nil {
+				log.Printf("[FEED] Error syncing %s: %v", feed.Name, err)
+			}
+		}
+	}
+}
+
+// Sync - Ambil feed lalu sinkronkan ke semua router tujuan. Error hanya untuk kegagalan feed
+// (unduhan / batas entry); kegagalan per router ada di hasil dan last_error.
+func (s *ThreatFeedSyncer) Sync(feed *models.ThreatFeed, dryRun bool) (*models.ThreatFeedSyncResult, error) {
+	entries, skipped, err := s.fetch(feed)
+	if err == nil && len(entries) > s.limit(feed) {
+		err = fmt.Errorf("feed %s has %d entries, exceeds limit %d", feed.Name, len(entries), s.limit(feed))
+	}
+	if err != nil {
+		if !dryRun {
+			message := err.Error()
+			if err := s.repo.SetSynced(feed.ID, feed.LastEntryCount, &message); err != nil {
+				log.Printf("[FEED] Error saving state of %s: %v", feed.Name, err)
+			}
+		}
+		return nil, err
+	}
+
+	result := &models.ThreatFeedSyncResult{
+		FeedID:   feed.ID,
+		Entries:  len(entries),
+		Skipped:  skipped,
+		DryRun:   dryRun,
+		Routers:  make([]*models.ThreatFeedRouterResult, 0, len(feed.RouterIDs)),
+		SyncedAt: time.Now(),
+	}
+
+	var failed []string
+	for _, routerID := range feed.RouterIDs {
+		res := s.syncRouter(feed, routerID, entries, dryRun)
+		if res.Error != "" {
+			failed = append(failed, fmt.Sprintf("router %d: %s", routerID, res.Error))
+		}
+		result.Routers = append(result.Routers, res)
+	}
+
+	if !dryRun {
+		var lastError *string
+		if len(failed) > 0 {
+			joined := strings.Join(failed, "; ")
+			lastError = &joined
+		}
+		if err := s.repo.SetSynced(feed.ID, len(entries), lastError); err != nil {
+			log.Printf("[FEED] Error saving state of %s: %v", feed.Name, err)
+		}
+		log.Printf("[FEED] %s synced: %d entries to %d router(s), %d failed", feed.Name, len(entries),
+			len(feed.RouterIDs), len(failed))
+	}
+	return result, nil
+}
+
+// Purge - Hapus semua entry feed dari address-list di router tsb (sebelum feed dihapus atau
+// router / address-list tujuannya diganti)
+func (s *ThreatFeedSyncer) Purge(feed *models.ThreatFeed, routerIDs []int) error {
+	for _, routerID := range routerIDs {
+		if res := s.syncRouter(feed, routerID, nil, false); res.Error != "" {
+			return fmt.Errorf("router %d: %s", routerID, res.Error)
+		}
+	}
+	return nil
+}
+
+func (s *ThreatFeedSyncer) syncRouter(feed *models.ThreatFeed, routerID int, entries []string, dryRun bool) *models.ThreatFeedRouterResult {
+	res := &models.ThreatFeedRouterResult{RouterID: routerID}
+	plan, unchanged, err := s.ms.SyncAddressList(routerID, feed.AddressList, fmt.Sprintf("%s%d", feedCommentPrefix, feed.ID), entries, dryRun)
+	if plan != nil {
+		for _, cmd := range plan.Commands {
+			if cmd[0] == "/ip/firewall/address-list/add" {
+				res.Added++
+			} else {
+				res.Removed++
+			}
+		}
+		res.Unchanged = unchanged
+		if dryRun {
+			res.Plan = plan
+		} else if err != nil || len(plan.Commands) > 0 {
+			s.audit.LogPlan("feed-sync", "threat_feed_sync", routerID, feed.Name, plan, err)
+		}
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// limit - max_entries feed, dibatasi batas global
+func (s *ThreatFeedSyncer) limit(feed *models.ThreatFeed) int {
+	if s.maxEntries > 0 && (feed.MaxEntries <= 0 || feed.MaxEntries > s.maxEntries) {
+		return s.maxEntries
+	}
+	return feed.MaxEntries
+}
+
+// fetch - Unduh feed dan ambil IP/CIDR IPv4 unik (dinormalisasi seperti tampilan RouterOS)
+func (s *ThreatFeedSyncer) fetch(feed *models.ThreatFeed) ([]string, int, error) {
+	req, err := http.NewRequest(http.MethodGet, feed.URL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("User-Agent", "Mikrotik-Layer-FeedSync/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("feed %s returned %s", feed.Name, resp.Status)
+	}
+
+	entries, skipped, err := parseFeed(io.LimitReader(resp.Body, feedMaxBody))
+	if err != nil {
+		return nil, 0, fmt.Errorf("read feed %s: %w", feed.Name, err)
+	}
+	return entries, skipped, nil
+}
+
+// parseFeed - Satu IP/CIDR di awal tiap baris; komentar setelah ; atau # diabaikan
+// (format Spamhaus DROP, FireHOL, daftar IP polos)
+func parseFeed(r io.Reader) ([]string, int, error) {
+	seen := make(map[string]bool)
+	entries := []string{}
+	skipped := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexAny(line, ";#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		entry, ok := normalizeFeedEntry(fields[0])
+		if !ok {
+			skipped++
+			continue
+		}
+		if !seen[entry] {
+			seen[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+	return entries, skipped, scanner.Err()
+}
+
+// normalizeFeedEntry - IPv4 atau CIDR IPv4 (bit host di-nol-kan, /32 jadi alamat polos);
+// false untuk IPv6 dan teks yang bukan alamat
+func normalizeFeedEntry(value string) (string, bool) {
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil || ip.To4() == nil {
+			return "", false
+		}
+		return ip.To4().String(), true
+	}
+
+	_, network, err := net.ParseCIDR(value)
+	if err != nil || network.IP.To4() == nil {
+		return "", false
+	}
+	if ones, _ := network.Mask.Size(); ones == 32 {
+		return network.IP.String(), true
+	}
+	return network.String(), true
+}
+
+// SyncAddressList - Samakan entry address-list ber-comment tsb dengan entries: yang belum ada
+// ditambah, yang tidak ada di entries (atau duplikat) dihapus. Entry tanpa comment tsb tidak
+// disentuh. Mengembalikan juga jumlah entry yang sudah sesuai.
+func (ms *MikrotikService) SyncAddressList(routerID int, list, comment string, entries []string, dryRun bool) (*models.CommandPlan, int, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/ip/firewall/address-list/print", fmt.Sprintf("?list=%s", list),
+		fmt.Sprintf("?comment=%s", comment), "=.proplist=.id,address")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	desired := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		desired[entry] = true
+	}
+
+	plan := newCommandPlan(routerID, "sync_address_list", dryRun)
+	existing := make(map[string]bool, len(r.Re))
+	for _, re := range r.Re {
+		address, ok := normalizeFeedEntry(re.Map["address"])
+		if ok && desired[address] && !existing[address] {
+			existing[address] = true
+			continue
+		}
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/firewall/address-list/remove",
+			fmt.Sprintf("=.id=%s", re.Map[".id"]),
+		})
+	}
+	for _, entry := range entries {
+		if existing[entry] {
+			continue
+		}
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/firewall/address-list/add",
+			fmt.Sprintf("=list=%s", list),
+			fmt.Sprintf("=address=%s", entry),
+			fmt.Sprintf("=comment=%s", comment),
+		})
+	}
+	plan.Checks = append(plan.Checks, fmt.Sprintf("%d entries in %s already in sync", len(existing), list))
+
+	return plan, len(existing), executePlan(conn, plan)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// TopTalkersBucket - Resolusi agregasi top-talkers di database
+const TopTalkersBucket = 5 * time.Minute
+
+// TopTalkersConfig - Parameter collector top-talkers
+type TopTalkersConfig struct {
+	Interval  time.Duration // jarak antar sesi torch per interface WAN
+	Duration  time.Duration // lama satu sesi torch
+	Limit     int           // jumlah alamat teratas (per arah) yang disimpan
+	Retention time.Duration // umur maksimal bucket (0 = simpan selamanya)
+}
+
+// TopTalkersCollector - Jalankan torch periodik pada interface WAN router yang
+// terhubung (routers.wan_interfaces) dan simpan top-N alamat ke bucket 5 menit.
+type TopTalkersCollector struct {
+	cfg        TopTalkersConfig
+	ms         *MikrotikService
+	routerRepo *repository.RouterRepository
+	repo       *repository.TopTalkersRepository
+}
+
+func NewTopTalkersCollector(cfg TopTalkersConfig, ms *MikrotikService, routerRepo *repository.RouterRepository, repo *repository.TopTalkersRepository) *TopTalkersCollector {
+	return &TopTalkersCollector{
+		cfg:        cfg,
+		ms:         ms,
+		routerRepo: routerRepo,
+		repo:       repo,
+	}
+}
+
+// Run - Loop collector (blocking)
+func (c *TopTalkersCollector) Run() {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		c.collect()
+		c.cleanup()
+	}
+}
+
+func (c *TopTalkersCollector) collect() {
+	routers, err := c.routerRepo.GetActiveRouters()
+	if err != nil {
+		log.Printf("[TOP-TALKERS] Error loading routers: %v", err)
+		return
+	}
+
+	connections := c.ms.GetAllConnections()
+
+	var wg sync.WaitGroup
+	for _, router := range routers {
+		wans := splitInterfaces(router.WANInterfaces)
+		if len(wans) == 0 {
+			continue
+		}
+		if conn, ok := connections[router.ID]; !ok || !conn.IsHealthy {
+			continue
+		}
+
+		// Torch per router berjalan paralel, per interface berurutan
+		wg.Add(1)
+		go func(routerID int, wans []string) {
+			defer Recover("top-talkers")
+			defer wg.Done()
+			for _, iface := range wans {
+				c.sample(routerID, iface)
+			}
+		}(router.ID, wans)
+	}
+	wg.Wait()
+}
+
+func (c *TopTalkersCollector) sample(routerID int, iface string) {
+	result, err := c.ms.Torch(routerID, iface, c.cfg.Duration)
+	if err != nil {
+		log.Printf("[TOP-TALKERS] Torch router %d %s failed: %v", routerID, iface, err)
+		return
+	}
+
+	bucket := time.Now().Truncate(TopTalkersBucket)
+	talkers := append(topN(result.Sources, c.cfg.Limit), topN(result.Destinations, c.cfg.Limit)...)
+	if err := c.repo.Upsert(routerID, iface, bucket, talkers); err != nil {
+		log.Printf("[TOP-TALKERS] Error saving router %d %s: %v", routerID, iface, err)
+	}
+}
+
+func (c *TopTalkersCollector) cleanup() {
+	if c.cfg.Retention <= 0 {
+		return
+	}
+
+	deleted, err := c.repo.DeleteOlderThan(time.Now().Add(-c.cfg.Retention))
+	if err != nil {
+		log.Printf("[TOP-TALKERS] Retention cleanup failed: %v", err)
+	} else if deleted > 0 {
+		log.Printf("[TOP-TALKERS] Retention cleanup removed %d rows", deleted)
+	}
+}
+
+// splitInterfaces - Parse daftar interface comma-separated
+func splitInterfaces(v *string) []string {
+	if v == nil {
+		return nil
+	}
+
+	var names []string
+	for _, name := range strings.Split(*v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func topN(items []*models.TopTalker, n int) []*models.TopTalker {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
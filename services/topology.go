@@ -0,0 +1,327 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// GetNeighbors - Tetangga hasil discovery MNDP/CDP/LLDP (/ip/neighbor)
+func (ms *MikrotikService) GetNeighbors(routerID int) ([]*models.Neighbor, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+
+	r, err := conn.Run("/ip/neighbor/print")
+	if err != nil {
+		return nil, err
+	}
+
+	neighbors := []*models.Neighbor{}
+	for _, re := range r.Re {
+		address := re.Map["address"]
+		if address == "" {
+			address = re.Map["address4"]
+		}
+		// v7 bisa melaporkan "ether1,bridge" (port lalu bridge-nya): port fisik yang dipakai
+		iface, _, _ := strings.Cut(re.Map["interface"], ",")
+		neighbors = append(neighbors, &models.Neighbor{
+			Interface:         iface,
+			Address:           address,
+			MACAddress:        strings.ToUpper(re.Map["mac-address"]),
+			Identity:          re.Map["identity"],
+			Platform:          re.Map["platform"],
+			Board:             re.Map["board"],
+			Version:           re.Map["version"],
+			NeighborInterface: re.Map["interface-name"],
+			DiscoveredBy:      re.Map["discovered-by"],
+		})
+	}
+	return neighbors, nil
+}
+
+// TopologyBuilder - Poll /ip/neighbor semua router terhubung, simpan link ke topology_links dan
+// catat event saat link muncul / hilang. Poll pertama router hanya jadi baseline tanpa event.
+type TopologyBuilder struct {
+	interval time.Duration
+	ms       *MikrotikService
+	routers  *repository.RouterRepository
+	repo     *repository.TopologyRepository
+	recorder *EventRecorder
+}
+
+func NewTopologyBuilder(interval time.Duration, ms *MikrotikService, routers *repository.RouterRepository, repo *repository.TopologyRepository, recorder *EventRecorder) *TopologyBuilder {
+	return &TopologyBuilder{
+		interval: interval,
+		ms:       ms,
+		routers:  routers,
+		repo:     repo,
+		recorder: recorder,
+	}
+}
+
+// Run - Loop builder (blocking)
+func (b *TopologyBuilder) Run() {
+	if b.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		routers, err := b.routers.GetAll()
+		if err != nil {
+			log.Printf("[TOPOLOGY] Error loading routers: %v", err)
+			continue
+		}
+		resolve := neighborResolver(routers)
+
+		for routerID, conn := range b.ms.GetAllConnections() {
+			if !conn.IsHealthy {
+				continue
+			}
+			if err := b.refresh(routerID, resolve); err != nil {
+				log.Printf("[TOPOLOGY] Router %d: %v", routerID, err)
+			}
+		}
+	}
+}
+
+// refresh - Sinkronkan link satu router dengan isi /ip/neighbor sekarang
+func (b *TopologyBuilder) refresh(routerID int, resolve func(*models.Neighbor) *int) error {
+	neighbors, err := b.ms.GetNeighbors(routerID)
+	if err != nil {
+		return err
+	}
+	existing, err := b.repo.List(routerID, false)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]*models.TopologyLink, len(existing))
+	for _, l := range existing {
+		known[topologyLinkKey(l.Interface, l.NeighborMAC, l.NeighborIdentity)] = l
+	}
+	baseline := len(existing) == 0
+
+	seen := make(map[string]bool, len(neighbors))
+	for _, n := range neighbors {
+		key := topologyLinkKey(n.Interface, n.MACAddress, n.Identity)
+		if n.Interface == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		link := &models.TopologyLink{
+			RouterID:          routerID,
+			Interface:         n.Interface,
+			NeighborMAC:       n.MACAddress,
+			NeighborIdentity:  n.Identity,
+			NeighborAddress:   n.Address,
+			NeighborInterface: n.NeighborInterface,
+			NeighborRouterID:  resolve(n),
+			Platform:          n.Platform,
+			Board:             n.Board,
+			Version:           n.Version,
+			DiscoveredBy:      n.DiscoveredBy,
+		}
+		if link.NeighborRouterID != nil && *link.NeighborRouterID == routerID {
+			link.NeighborRouterID = nil
+		}
+		if err := b.repo.Save(link); err != nil {
+			return err
+		}
+
+		if previous, ok := known[key]; !baseline && (!ok || !previous.Active) {
+			b.record(routerID, link, true, !ok)
+		}
+	}
+
+	var gone []int
+	for key, l := range known {
+		if !l.Active || seen[key] {
+			continue
+		}
+		gone = append(gone, l.ID)
+		b.record(routerID, l, false, false)
+	}
+	return b.repo.MarkInactive(gone)
+}
+
+func (b *TopologyBuilder) record(routerID int, l *models.TopologyLink, up, added bool) {
+	label := fmt.Sprintf("Link %s -> %s", l.Interface, topologyNeighborLabel(l))
+	eventType, severity, message := "topology_link_up", "info", label+" muncul"
+	if !up {
+		eventType, severity, message = "topology_link_down", "warning", label+" hilang"
+	} else if !added {
+		message = label + " kembali terlihat"
+	}
+	log.Printf("[TOPOLOGY] Router %d: %s", routerID, message)
+
+	b.recorder.Record(&models.Event{
+		RouterID: &routerID,
+		Type:     eventType,
+		Severity: severity,
+		Message:  message,
+		Data: mustJSON(map[string]interface{}{
+			"interface":          l.Interface,
+			"neighbor_identity":  l.NeighborIdentity,
+			"neighbor_mac":       l.NeighborMAC,
+			"neighbor_address":   l.NeighborAddress,
+			"neighbor_interface": l.NeighborInterface,
+			"neighbor_router_id": l.NeighborRouterID,
+		}),
+	})
+}
+
+func topologyLinkKey(iface, mac, identity string) string {
+	return iface + "|" + mac + "|" + identity
+}
+
+func topologyNeighborLabel(l *models.TopologyLink) string {
+	label := l.NeighborIdentity
+	if label == "" {
+		label = l.NeighborMAC
+	}
+	if l.NeighborInterface != "" {
+		label += " (" + l.NeighborInterface + ")"
+	}
+	return label
+}
+
+// neighborResolver - Cocokkan tetangga ke router terkelola lewat alamat (hostname / alamat
+// management terakhir), fallback identity = nama router
+func neighborResolver(routers []*models.Router) func(*models.Neighbor) *int {
+	byAddress := make(map[string]int)
+	byName := make(map[string]int)
+	for _, r := range routers {
+		byAddress[r.Hostname] = r.ID
+		if r.ActiveAddress != nil && *r.ActiveAddress != "" {
+			byAddress[*r.ActiveAddress] = r.ID
+		}
+		byName[strings.ToLower(r.Name)] = r.ID
+	}
+
+	return func(n *models.Neighbor) *int {
+		if id, ok := byAddress[n.Address]; ok && n.Address != "" {
+			return &id
+		}
+		if id, ok := byName[strings.ToLower(n.Identity)]; ok && n.Identity != "" {
+			return &id
+		}
+		return nil
+	}
+}
+
+// BuildTopology - Susun graf dari router dan link tersimpan. Link dua router terkelola yang
+// terlihat dari kedua sisi (port cocok) digabung jadi satu edge bidirectional.
+func BuildTopology(routers []*models.Router, links []*models.TopologyLink) *models.Topology {
+	topology := &models.Topology{
+		Nodes:       []*models.TopologyNode{},
+		Links:       []*models.TopologyEdge{},
+		GeneratedAt: time.Now(),
+	}
+
+	nodes := make(map[string]*models.TopologyNode)
+	for _, r := range routers {
+		id := r.ID
+		node := &models.TopologyNode{
+			ID:       routerNodeID(r.ID),
+			Type:     models.TopologyNodeRouter,
+			RouterID: &id,
+			Label:    r.Name,
+			Address:  r.Hostname,
+			Status:   r.Status,
+		}
+		nodes[node.ID] = node
+		topology.Nodes = append(topology.Nodes, node)
+	}
+
+	edges := make(map[string]*models.TopologyEdge)
+	for _, l := range links {
+		source := routerNodeID(l.RouterID)
+		if nodes[source] == nil {
+			continue
+		}
+
+		// Router tetangga di luar daftar (mis. di luar scope user) tampil sebagai perangkat biasa
+		var target string
+		if l.NeighborRouterID != nil && nodes[routerNodeID(*l.NeighborRouterID)] != nil {
+			target = routerNodeID(*l.NeighborRouterID)
+		} else {
+			target = deviceNodeID(l)
+			node, ok := nodes[target]
+			if !ok {
+				node = &models.TopologyNode{ID: target, Type: models.TopologyNodeDevice}
+				nodes[target] = node
+				topology.Nodes = append(topology.Nodes, node)
+			}
+			if l.Active || node.Label == "" {
+				node.Label = l.NeighborIdentity
+				if node.Label == "" {
+					node.Label = l.NeighborMAC
+				}
+				node.Address, node.MAC = l.NeighborAddress, l.NeighborMAC
+				node.Platform, node.Board = l.Platform, l.Board
+			}
+		}
+
+		key := edgeKey(source, l.Interface, target, l.NeighborInterface)
+		if edge, ok := edges[key]; ok {
+			if edge.Source != source {
+				edge.Bidirectional = true
+			}
+			edge.Active = edge.Active || l.Active
+			if l.LastSeen.After(edge.LastSeen) {
+				edge.LastSeen = l.LastSeen
+			}
+			continue
+		}
+
+		edge := &models.TopologyEdge{
+			Source:          source,
+			Target:          target,
+			SourceInterface: l.Interface,
+			TargetInterface: l.NeighborInterface,
+			DiscoveredBy:    l.DiscoveredBy,
+			Active:          l.Active,
+			LastSeen:        l.LastSeen,
+		}
+		edges[key] = edge
+		topology.Links = append(topology.Links, edge)
+	}
+
+	return topology
+}
+
+func routerNodeID(routerID int) string {
+	return fmt.Sprintf("router-%d", routerID)
+}
+
+func deviceNodeID(l *models.TopologyLink) string {
+	if l.NeighborMAC != "" {
+		return "mac-" + l.NeighborMAC
+	}
+	return "device-" + l.NeighborIdentity
+}
+
+// edgeKey - Key yang sama untuk kedua arah link (node+port diurutkan)
+func edgeKey(a, aIface, b, bIface string) string {
+	left, right := a+"|"+aIface, b+"|"+bIface
+	if left > right {
+		left, right = right, left
+	}
+	return left + "|" + right
+}
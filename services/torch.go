@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// Torch - Jalankan /tool/torch pada interface selama duration, lalu hitung
+// rata-rata bps per alamat sumber dan tujuan (urut dari yang terbesar).
+func (ms *MikrotikService) Torch(routerID int, iface string, duration time.Duration) (*models.TorchResult, error) {
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	seconds := int(duration.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	sentence := []string{
+		"/tool/torch",
+		fmt.Sprintf("=interface=%s", iface),
+		"=src-address=0.0.0.0/0",
+		"=dst-address=0.0.0.0/0",
+		fmt.Sprintf("=duration=%ds", seconds),
+	}
+
+	var rows []map[string]string
+	if conn.IsVirtual() {
+		r, err := conn.RunArgs(sentence)
+		if err != nil {
+			return nil, err
+		}
+		for _, re := range r.Re {
+			rows = append(rows, re.Map)
+		}
+	} else {
+		release, err := ms.acquireListen(context.Background(), routerID, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		// Listen tidak perlu lock koneksi (lihat catatan di MonitorInterfaceTrafficWithContext)
+		listen, err := conn.Client.Listen(sentence...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start torch: %v", err)
+		}
+
+		timeout := time.NewTimer(time.Duration(seconds+5) * time.Second)
+		defer timeout.Stop()
+
+	collect:
+		for {
+			select {
+			case sentence, more := <-listen.Chan():
+				if !more {
+					break collect
+				}
+				if sentence.Word == "!re" {
+					rows = append(rows, sentence.Map)
+				}
+			case <-timeout.C:
+				log.Printf("[TORCH] Router %d %s: timeout, membatalkan torch", routerID, iface)
+				break collect
+			}
+		}
+		listen.Cancel()
+
+		if err := listen.Err(); err != nil && len(rows) == 0 {
+			return nil, fmt.Errorf("torch failed: %v", err)
+		}
+	}
+
+	sources, destinations := aggregateTorch(rows)
+	return &models.TorchResult{
+		RouterID:     routerID,
+		Interface:    iface,
+		Duration:     seconds,
+		Sources:      sources,
+		Destinations: destinations,
+	}, nil
+}
+
+// aggregateTorch - Jumlahkan rx/tx per alamat lalu bagi dengan jumlah section (≈ detik)
+func aggregateTorch(rows []map[string]string) ([]*models.TopTalker, []*models.TopTalker) {
+	sections := make(map[string]bool)
+	src := make(map[string]*models.TopTalker)
+	dst := make(map[string]*models.TopTalker)
+
+	add := func(m map[string]*models.TopTalker, address, direction string, rx, tx float64) {
+		if address == "" {
+			return
+		}
+		t, ok := m[address]
+		if !ok {
+			t = &models.TopTalker{Address: address, Direction: direction}
+			m[address] = t
+		}
+		t.RxBps += rx
+		t.TxBps += tx
+	}
+
+	for _, row := range rows {
+		sections[row[".section"]] = true
+		rx, _ := strconv.ParseFloat(row["rx"], 64)
+		tx, _ := strconv.ParseFloat(row["tx"], 64)
+		add(src, row["src-address"], "src", rx, tx)
+		add(dst, row["dst-address"], "dst", rx, tx)
+	}
+
+	n := float64(len(sections))
+	if n == 0 {
+		n = 1
+	}
+
+	return sortTalkers(src, n), sortTalkers(dst, n)
+}
+
+func sortTalkers(m map[string]*models.TopTalker, n float64) []*models.TopTalker {
+	talkers := make([]*models.TopTalker, 0, len(m))
+	for _, t := range m {
+		t.RxBps /= n
+		t.TxBps /= n
+		talkers = append(talkers, t)
+	}
+	sort.Slice(talkers, func(i, j int) bool {
+		return talkers[i].RxBps+talkers[i].TxBps > talkers[j].RxBps+talkers[j].TxBps
+	})
+	return talkers
+}
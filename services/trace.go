@@ -0,0 +1,40 @@
+package services
+
+import (
+	"sync"
+
+	"Mikrotik-Layer/tracing"
+)
+
+// connMutex - Mutex koneksi router yang mencatat waktu tunggu lock sebagai span
+// (request lambat karena antre di belakang command lain di router yang sama terlihat di trace)
+type connMutex struct {
+	sync.RWMutex
+	routerID int
+}
+
+func (m *connMutex) Lock() {
+	span := tracing.StartChild("routeros.lock_wait", tracing.KindInternal)
+	m.RWMutex.Lock()
+	m.endWait(span, "write")
+}
+
+func (m *connMutex) RLock() {
+	span := tracing.StartChild("routeros.lock_wait", tracing.KindInternal)
+	m.RWMutex.RLock()
+	m.endWait(span, "read")
+}
+
+func (m *connMutex) endWait(span *tracing.Span, mode string) {
+	span.SetAttr("router_id", m.routerID)
+	span.SetAttr("lock.mode", mode)
+	span.End()
+}
+
+// commandName - Path command RouterOS untuk nama span (tanpa argumen yang bisa berisi data sensitif)
+func commandName(sentence []string) string {
+	if len(sentence) == 0 {
+		return ""
+	}
+	return sentence[0]
+}
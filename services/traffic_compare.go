@@ -0,0 +1,98 @@
+package services
+
+import (
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+// TrafficCompareMaxPoints - Batas jumlah bucket per series
+const TrafficCompareMaxPoints = 2000
+
+// trafficCompareSteps - Kandidat resolusi otomatis, dipilih yang terkecil dengan <= 500 bucket
+var trafficCompareSteps = []time.Duration{
+	10 * time.Second, 30 * time.Second, time.Minute, 5 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+// TrafficCompareStep - Resolusi otomatis untuk rentang waktu
+func TrafficCompareStep(from, to time.Time) time.Duration {
+	span := to.Sub(from)
+	for _, step := range trafficCompareSteps {
+		if span/step <= 500 {
+			return step
+		}
+	}
+	return trafficCompareSteps[len(trafficCompareSteps)-1]
+}
+
+// CompareTraffic - Rata-rata rx/tx tiap interface per bucket step di grid yang sama. Bucket
+// disejajarkan ke kelipatan step sehingga series dengan interval sampling berbeda tetap sebanding.
+func CompareTraffic(repo *repository.TrafficRepository, pairs []models.SampledInterface, from, to time.Time, step time.Duration) (*models.TrafficComparison, error) {
+	start := from.Truncate(step)
+	buckets := int(to.Sub(start)/step) + 1
+
+	result := &models.TrafficComparison{
+		From:        from,
+		To:          to,
+		StepSeconds: int(step / time.Second),
+		Timestamps:  make([]time.Time, buckets),
+		Series:      make([]*models.TrafficComparisonSeries, 0, len(pairs)),
+	}
+	for i := range result.Timestamps {
+		result.Timestamps[i] = start.Add(time.Duration(i) * step)
+	}
+
+	for _, pair := range pairs {
+		series := &models.TrafficComparisonSeries{
+			RouterID:  pair.RouterID,
+			Interface: pair.Interface,
+			RxBps:     make([]*float64, buckets),
+			TxBps:     make([]*float64, buckets),
+		}
+
+		rxSum := make([]float64, buckets)
+		txSum := make([]float64, buckets)
+		counts := make([]int, buckets)
+		var rxTotal, txTotal float64
+		err := repo.EachHistory(pair.RouterID, pair.Interface, from, to, func(s *models.TrafficSample) error {
+			i := int(s.SampledAt.Sub(start) / step)
+			if i < 0 || i >= buckets {
+				return nil
+			}
+			rxSum[i] += float64(s.RxBps)
+			txSum[i] += float64(s.TxBps)
+			counts[i]++
+
+			series.Samples++
+			rxTotal += float64(s.RxBps)
+			txTotal += float64(s.TxBps)
+			if s.RxBps > series.MaxRxBps {
+				series.MaxRxBps = s.RxBps
+			}
+			if s.TxBps > series.MaxTxBps {
+				series.MaxTxBps = s.TxBps
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i, n := range counts {
+			if n == 0 {
+				continue
+			}
+			rx, tx := rxSum[i]/float64(n), txSum[i]/float64(n)
+			series.RxBps[i], series.TxBps[i] = &rx, &tx
+		}
+		if series.Samples > 0 {
+			series.AvgRxBps = rxTotal / float64(series.Samples)
+			series.AvgTxBps = txTotal / float64(series.Samples)
+		}
+		result.Series = append(result.Series, series)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"fmt"
+
+	"Mikrotik-Layer/models"
+)
+
+// ConfigureTrafficFlow - Aktifkan /ip/traffic-flow dan arahkan export ke collector (idempotent).
+// Target lama dengan dst-address yang sama diganti; version "9" atau "ipfix".
+func (ms *MikrotikService) ConfigureTrafficFlow(routerID int, collector string, port int, version, interfaces string, dryRun bool) (*models.CommandPlan, error) {
+	if version != "9" && version != "ipfix" {
+		return nil, fmt.Errorf("unsupported traffic-flow version %q (use 9 or ipfix)", version)
+	}
+
+	if version == "ipfix" {
+		if err := ms.RequireFeature(routerID, FeatureIPFIX); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	plan := newCommandPlan(routerID, "configure_traffic_flow", dryRun)
+
+	plan.Commands = append(plan.Commands, []string{
+		"/ip/traffic-flow/set",
+		"=enabled=yes",
+		fmt.Sprintf("=interfaces=%s", interfaces),
+		"=active-flow-timeout=1m",
+		"=inactive-flow-timeout=15s",
+	})
+
+	r, err := conn.Run("/ip/traffic-flow/target/print", fmt.Sprintf("?dst-address=%s", collector), "=.proplist=.id,port,version")
+	if err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("existing target %s:%s (v%s) replaced", collector, re.Map["port"], re.Map["version"]))
+		plan.Commands = append(plan.Commands, []string{
+			"/ip/traffic-flow/target/remove",
+			fmt.Sprintf("=.id=%s", re.Map[".id"]),
+		})
+	}
+
+	plan.Commands = append(plan.Commands, []string{
+		"/ip/traffic-flow/target/add",
+		fmt.Sprintf("=dst-address=%s", collector),
+		fmt.Sprintf("=port=%d", port),
+		fmt.Sprintf("=version=%s", version),
+	})
+
+	return plan, executePlan(conn, plan)
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+const (
+	// tunnelInterfaceName - Nama interface WireGuard yang dibuat script di router
+	tunnelInterfaceName = "layer-tunnel"
+
+	// tunnelHandshakeMaxAge - Peer WireGuard dianggap terhubung jika handshake terakhir lebih
+	// baru dari ini (rekey 2 menit + keepalive 25 detik)
+	tunnelHandshakeMaxAge = 3 * time.Minute
+)
+
+// RegisterTunnel - Daftarkan router di belakang CGNAT ke concentrator. WireGuard: peer dibuat di
+// concentrator dan script untuk router dikembalikan; PPP: secret yang sudah ada di concentrator
+// dipakai sebagai penanda. Alamat tunnel dipasang ke hostname oleh TunnelWatcher begitu router
+// terhubung.
+func (ms *MikrotikService) RegisterTunnel(routerID int, req *models.TunnelRegisterRequest, dryRun bool) (*models.TunnelRegistration, error) {
+	if req.ConcentratorRouterID == routerID {
+		return nil, fmt.Errorf("router cannot be its own tunnel concentrator")
+	}
+	router, err := ms.repo.GetByID(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TunnelRegistration{
+		RouterID:       routerID,
+		ConcentratorID: req.ConcentratorRouterID,
+		Type:           req.Type,
+	}
+
+	switch req.Type {
+	case models.TunnelWireGuard:
+		if req.Interface == "" {
+			return nil, fmt.Errorf("interface is required for wireguard tunnel")
+		}
+		keepalive := defaultWireGuardKeepalive
+		peer, err := ms.CreateWireGuardPeer(req.ConcentratorRouterID, &models.WireGuardPeerRequest{
+			Interface: req.Interface,
+			Name:      fmt.Sprintf("mikrotik-layer:tunnel:%s", router.Name),
+			Endpoint:  req.Endpoint,
+			Keepalive: &keepalive,
+		}, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Peer = peer.Peer.PublicKey
+		result.Address = peer.Peer.Address
+		result.WireGuard = peer
+		if result.RouterScript, err = wireGuardTunnelScript(peer); err != nil {
+			return nil, err
+		}
+	case models.TunnelPPP:
+		if req.PPPName == "" {
+			return nil, fmt.Errorf("ppp_name is required for ppp tunnel")
+		}
+		r, err := ms.runRead(req.ConcentratorRouterID, "/ppp/secret/print", fmt.Sprintf("?name=%s", req.PPPName), "=.proplist=name")
+		if err != nil {
+			return nil, err
+		}
+		if len(r.Re) == 0 {
+			return nil, fmt.Errorf("ppp secret %s not found on concentrator", req.PPPName)
+		}
+		result.Peer = req.PPPName
+	default:
+		return nil, fmt.Errorf("unknown tunnel type %s", req.Type)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	err = ms.repo.SetTunnel(routerID, &models.RouterTunnel{
+		TunnelConcentratorID: &result.ConcentratorID,
+		TunnelType:           &result.Type,
+		TunnelPeer:           &result.Peer,
+	})
+	return result, err
+}
+
+// wireGuardTunnelScript - Perintah RouterOS untuk router client: interface + peer ke concentrator,
+// alamat tunnel dan route ke subnet concentrator
+func wireGuardTunnelScript(peer *models.WireGuardPeerResult) ([]string, error) {
+	host, port, err := net.SplitHostPort(peer.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	script := []string{
+		fmt.Sprintf(`/interface/wireguard/add name=%s private-key="%s"`, tunnelInterfaceName, peer.PrivateKey),
+		fmt.Sprintf(`/interface/wireguard/peers/add interface=%s public-key="%s" endpoint-address=%s endpoint-port=%s allowed-address=%s persistent-keepalive=%ds`,
+			tunnelInterfaceName, peer.ServerKey, host, port, peer.AllowedIPs, defaultWireGuardKeepalive),
+		fmt.Sprintf("/ip/address/add address=%s interface=%s", peer.Peer.Address, tunnelInterfaceName),
+	}
+	for _, allowed := range strings.Split(peer.AllowedIPs, ",") {
+		if allowed = strings.TrimSpace(allowed); allowed != "" {
+			script = append(script, fmt.Sprintf("/ip/route/add dst-address=%s gateway=%s", allowed, tunnelInterfaceName))
+		}
+	}
+	return script, nil
+}
+
+// UnregisterTunnel - Hapus registrasi tunnel (peer di concentrator dan hostname tidak diubah)
+func (ms *MikrotikService) UnregisterTunnel(routerID int) error {
+	if _, err := ms.repo.GetByID(routerID); err != nil {
+		return err
+	}
+	return ms.repo.SetTunnel(routerID, &models.RouterTunnel{})
+}
+
+// TunnelAddress - Alamat tunnel router di concentrator dan apakah tunnel sedang terhubung
+func (ms *MikrotikService) TunnelAddress(concentratorID int, tunnelType, peer string) (string, bool, error) {
+	switch tunnelType {
+	case models.TunnelWireGuard:
+		r, err := ms.runRead(concentratorID, "/interface/wireguard/peers/print",
+			fmt.Sprintf("?public-key=%s", peer), "=.proplist=allowed-address,last-handshake")
+		if err != nil {
+			return "", false, err
+		}
+		if len(r.Re) == 0 {
+			return "", false, fmt.Errorf("wireguard peer not found on concentrator %d", concentratorID)
+		}
+		address, _, _ := strings.Cut(strings.Split(r.Re[0].Map["allowed-address"], ",")[0], "/")
+		handshake := r.Re[0].Map["last-handshake"]
+		online := handshake != "" && parseRouterOSDuration(handshake) <= tunnelHandshakeMaxAge
+		return address, online, nil
+	case models.TunnelPPP:
+		r, err := ms.runRead(concentratorID, "/ppp/active/print", fmt.Sprintf("?name=%s", peer), "=.proplist=address")
+		if err != nil {
+			return "", false, err
+		}
+		if len(r.Re) == 0 {
+			return "", false, nil
+		}
+		return r.Re[0].Map["address"], true, nil
+	default:
+		return "", false, fmt.Errorf("unknown tunnel type %s", tunnelType)
+	}
+}
+
+// TunnelWatcher - Poll concentrator untuk router yang terdaftar tunnel; jika alamat tunnel yang
+// terhubung berbeda dari hostname, hostname diganti dan router di-reconnect lewat alamat baru
+type TunnelWatcher struct {
+	interval time.Duration
+	ms       *MikrotikService
+	repo     *repository.RouterRepository
+	recorder *EventRecorder
+}
+
+func NewTunnelWatcher(interval time.Duration, ms *MikrotikService, repo *repository.RouterRepository, recorder *EventRecorder) *TunnelWatcher {
+	return &TunnelWatcher{interval: interval, ms: ms, repo: repo, recorder: recorder}
+}
+
+// Run - Loop pengecekan alamat tunnel (blocking)
+func (w *TunnelWatcher) Run() {
+	if w.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		w.check()
+	}
+}
+
+func (w *TunnelWatcher) check() {
+	routers, err := w.repo.GetTunneled()
+	if err != nil {
+		log.Printf("[TUNNEL] Error loading tunneled routers: %v", err)
+		return
+	}
+
+	for _, router := range routers {
+		if router.TunnelType == nil || router.TunnelPeer == nil {
+			continue
+		}
+		address, online, err := w.ms.TunnelAddress(*router.TunnelConcentratorID, *router.TunnelType, *router.TunnelPeer)
+		if err != nil {
+			log.Printf("[TUNNEL] Router %s: %v", router.Name, err)
+			continue
+		}
+		if !online || address == "" || address == router.Hostname {
+			continue
+		}
+
+		if err := w.repo.UpdateHostname(router.ID, address); err != nil {
+			log.Printf("[TUNNEL] Error updating router %s hostname: %v", router.Name, err)
+			continue
+		}
+
+		message := fmt.Sprintf("Alamat tunnel %s dipakai untuk API (sebelumnya %s)", address, router.Hostname)
+		log.Printf("[TUNNEL] Router %s: %s", router.Name, message)
+		routerID := router.ID
+		w.recorder.Record(&models.Event{
+			RouterID: &routerID,
+			Type:     "tunnel_address_learned",
+			Severity: "info",
+			Message:  message,
+			Data: mustJSON(map[string]interface{}{
+				"concentrator_router_id": *router.TunnelConcentratorID,
+				"tunnel_type":            *router.TunnelType,
+				"address":                address,
+				"previous":               router.Hostname,
+			}),
+		})
+
+		// Koneksi lama (alamat lama) diganti koneksi lewat tunnel
+		w.ms.DisconnectRouter(routerID)
+		go w.ms.ConnectRouter(routerID)
+	}
+}
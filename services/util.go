@@ -0,0 +1,47 @@
+package services
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mustJSON - Marshal data kecil untuk kolom JSON (error diabaikan, nil jika gagal)
+func mustJSON(v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// parseRouterOSDuration - Parse durasi RouterOS ("1w2d03:04:05" v6 atau "1w2d3h4m5s" v7); 0 jika tidak valid
+func parseRouterOSDuration(v string) time.Duration {
+	var total time.Duration
+	for _, unit := range []struct {
+		suffix byte
+		d      time.Duration
+	}{{'w', 7 * 24 * time.Hour}, {'d', 24 * time.Hour}} {
+		if i := strings.IndexByte(v, unit.suffix); i >= 0 {
+			n, err := strconv.Atoi(v[:i])
+			if err != nil {
+				return 0
+			}
+			total += time.Duration(n) * unit.d
+			v = v[i+1:]
+		}
+	}
+	if v == "" {
+		return total
+	}
+
+	if parts := strings.Split(v, ":"); len(parts) == 3 {
+		v = parts[0] + "h" + parts[1] + "m" + parts[2] + "s"
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0
+	}
+	return total + d
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+)
+
+// GetVLANInterfaces - Interface VLAN yang parent-nya interface tsb
+func (ms *MikrotikService) GetVLANInterfaces(routerID int, parent string) ([]*models.VLANInterface, error) {
+	r, err := ms.runRead(routerID, "/interface/vlan/print", fmt.Sprintf("?interface=%s", parent),
+		"=.proplist=name,vlan-id,interface,running,disabled")
+	if err != nil {
+		return nil, err
+	}
+
+	vlans := make([]*models.VLANInterface, 0, len(r.Re))
+	for _, re := range r.Re {
+		vlanID, _ := strconv.Atoi(re.Map["vlan-id"])
+		vlans = append(vlans, &models.VLANInterface{
+			Name:     re.Map["name"],
+			VLANID:   vlanID,
+			Parent:   re.Map["interface"],
+			Running:  re.Map["running"] == "true",
+			Disabled: re.Map["disabled"] == "true",
+		})
+	}
+	sort.Slice(vlans, func(i, j int) bool { return vlans[i].VLANID < vlans[j].VLANID })
+	return vlans, nil
+}
+
+// GetVLANBreakdown - Snapshot rate parent dan semua VLAN-nya dari satu monitor-traffic once
+func (ms *MikrotikService) GetVLANBreakdown(routerID int, parent string) (*models.VLANBreakdown, error) {
+	vlans, err := ms.GetVLANInterfaces(routerID, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{parent}
+	for _, v := range vlans {
+		if !v.Disabled {
+			names = append(names, v.Name)
+		}
+	}
+
+	r, err := ms.runRead(routerID, "/interface/monitor-traffic",
+		fmt.Sprintf("=interface=%s", strings.Join(names, ",")), "=once=")
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*TrafficStats, len(r.Re))
+	for _, re := range r.Re {
+		stats[re.Map["name"]] = &TrafficStats{
+			RouterID:      routerID,
+			InterfaceName: re.Map["name"],
+			RxBitsPerSec:  re.Map["rx-bits-per-second"],
+			TxBitsPerSec:  re.Map["tx-bits-per-second"],
+		}
+	}
+	if stats[parent] == nil {
+		return nil, fmt.Errorf("interface %s not found or no data", parent)
+	}
+	return BuildVLANBreakdown(routerID, parent, vlans, stats), nil
+}
+
+// BuildVLANBreakdown - Susun breakdown dari rate terakhir per interface (stats by nama interface).
+// VLAN tanpa data dihitung 0; untagged tidak pernah negatif walau sampel parent & VLAN tidak serentak.
+func BuildVLANBreakdown(routerID int, parent string, vlans []*models.VLANInterface, stats map[string]*TrafficStats) *models.VLANBreakdown {
+	b := &models.VLANBreakdown{
+		RouterID:  routerID,
+		Parent:    parent,
+		VLANs:     make([]*models.VLANTraffic, 0, len(vlans)),
+		Timestamp: time.Now(),
+	}
+	if s := stats[parent]; s != nil {
+		b.RxBps, _ = strconv.ParseInt(s.RxBitsPerSec, 10, 64)
+		b.TxBps, _ = strconv.ParseInt(s.TxBitsPerSec, 10, 64)
+	}
+
+	var rxTotal, txTotal int64
+	for _, v := range vlans {
+		t := &models.VLANTraffic{Interface: v.Name, VLANID: v.VLANID, Running: v.Running}
+		if s := stats[v.Name]; s != nil {
+			t.RxBps, _ = strconv.ParseInt(s.RxBitsPerSec, 10, 64)
+			t.TxBps, _ = strconv.ParseInt(s.TxBitsPerSec, 10, 64)
+		}
+		if b.RxBps > 0 {
+			t.RxShare = float64(t.RxBps) / float64(b.RxBps)
+		}
+		if b.TxBps > 0 {
+			t.TxShare = float64(t.TxBps) / float64(b.TxBps)
+		}
+		rxTotal += t.RxBps
+		txTotal += t.TxBps
+		b.VLANs = append(b.VLANs, t)
+	}
+	sort.SliceStable(b.VLANs, func(i, j int) bool { return b.VLANs[i].RxBps > b.VLANs[j].RxBps })
+
+	b.UntaggedRxBps = max(b.RxBps-rxTotal, 0)
+	b.UntaggedTxBps = max(b.TxBps-txTotal, 0)
+	return b
+}
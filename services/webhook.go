@@ -0,0 +1,236 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+)
+
+const (
+	webhookBatchSize   = 50
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = time.Hour
+)
+
+// WebhookDispatcher - Antrikan event provisioning ke webhook (tabel webhook_deliveries) lalu kirim
+// dengan signature HMAC-SHA256 dan retry exponential backoff. Antrian di DB supaya event tidak
+// hilang saat layer restart atau endpoint billing sedang down.
+type WebhookDispatcher struct {
+	interval    time.Duration
+	maxAttempts int
+	retention   time.Duration
+	repo        *repository.WebhookRepository
+	client      *http.Client
+}
+
+func NewWebhookDispatcher(interval time.Duration, maxAttempts int, retention time.Duration, repo *repository.WebhookRepository) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		retention:   retention,
+		repo:        repo,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Emit - Antrikan event untuk semua webhook aktif yang subscribe; error hanya di-log
+// supaya operasi provisioning tidak gagal karena webhook
+func (d *WebhookDispatcher) Emit(event string, routerID *int, data interface{}) {
+	if d == nil {
+		return
+	}
+
+	webhooks, err := d.repo.List(true)
+	if err != nil {
+		log.Printf("[WEBHOOK] Error loading webhooks for %s: %v", event, err)
+		return
+	}
+
+	var (
+		eventID string
+		payload []byte
+	)
+	for _, w := range webhooks {
+		if !w.Subscribed(event) {
+			continue
+		}
+		if payload == nil {
+			if eventID, err = newEventID(); err != nil {
+				log.Printf("[WEBHOOK] Error generating event id: %v", err)
+				return
+			}
+			payload = mustJSON(&models.WebhookPayload{
+				ID:         eventID,
+				Event:      event,
+				RouterID:   routerID,
+				OccurredAt: time.Now(),
+				Data:       data,
+			})
+		}
+		if err := d.repo.Enqueue(w.ID, eventID, event, payload); err != nil {
+			log.Printf("[WEBHOOK] Error queueing %s for webhook %d: %v", event, w.ID, err)
+		}
+	}
+}
+
+// Run - Loop pengiriman delivery yang jatuh tempo (blocking)
+func (d *WebhookDispatcher) Run() {
+	if d.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		d.deliverDue()
+
+		if d.retention > 0 && time.Since(lastPurge) >= time.Hour {
+			lastPurge = time.Now()
+			if n, err := d.repo.PurgeDeliveries(time.Now().Add(-d.retention)); err != nil {
+				log.Printf("[WEBHOOK] Error purging deliveries: %v", err)
+			} else if n > 0 {
+				log.Printf("[WEBHOOK] Purged %d old deliveries", n)
+			}
+		}
+	}
+}
+
+func (d *WebhookDispatcher) deliverDue() {
+	deliveries, err := d.repo.DueDeliveries(webhookBatchSize)
+	if err != nil {
+		log.Printf("[WEBHOOK] Error loading due deliveries: %v", err)
+		return
+	}
+
+	webhooks := make(map[int]*models.Webhook)
+	for _, delivery := range deliveries {
+		w, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			if w, err = d.repo.GetByID(delivery.WebhookID); err != nil {
+				log.Printf("[WEBHOOK] Error loading webhook %d: %v", delivery.WebhookID, err)
+				continue
+			}
+			webhooks[w.ID] = w
+		}
+		// Webhook dinonaktifkan: delivery ditahan sampai diaktifkan lagi
+		if !w.Enabled {
+			continue
+		}
+		d.deliver(w, delivery)
+	}
+}
+
+func (d *WebhookDispatcher) deliver(w *models.Webhook, delivery *models.WebhookDelivery) {
+	statusCode, err := d.post(w, delivery)
+	if err == nil {
+		if err := d.repo.MarkDelivered(delivery.ID, statusCode); err != nil {
+			log.Printf("[WEBHOOK] Error marking delivery %d delivered: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+
+	attempt := delivery.Attempts + 1
+	var next *time.Time
+	if attempt < d.maxAttempts {
+		at := time.Now().Add(webhookBackoff(attempt))
+		next = &at
+		log.Printf("[WEBHOOK] Delivery %d (%s) to %s failed (attempt %d/%d), retry at %s: %v",
+			delivery.ID, delivery.Event, w.Name, attempt, d.maxAttempts, at.Format(time.RFC3339), err)
+	} else {
+		log.Printf("[WEBHOOK] Delivery %d (%s) to %s failed permanently after %d attempts: %v",
+			delivery.ID, delivery.Event, w.Name, attempt, err)
+	}
+
+	if err := d.repo.MarkAttemptFailed(delivery.ID, code, err.Error(), next); err != nil {
+		log.Printf("[WEBHOOK] Error updating delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// post - Kirim payload bertanda tangan; error untuk kegagalan jaringan dan respons non-2xx
+func (d *WebhookDispatcher) post(w *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Mikrotik-Layer-Webhook/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-ID", delivery.EventID)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhook(w.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook - HMAC-SHA256 hex atas "<timestamp>.<body>". Penerima menghitung ulang dengan
+// secret yang sama dan menolak timestamp yang terlalu lama (proteksi replay).
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff - 30s, 1m, 2m, ... maksimal 1 jam
+func webhookBackoff(attempt int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempt && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > webhookMaxBackoff {
+		backoff = webhookMaxBackoff
+	}
+	return backoff
+}
+
+// NewWebhookSecret - Secret acak untuk webhook yang dibuat tanpa secret
+func NewWebhookSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+func newEventID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"Mikrotik-Layer/models"
+)
+
+// defaultWireGuardKeepalive - PersistentKeepalive client jika tidak diisi (detik)
+const defaultWireGuardKeepalive = 25
+
+// GenerateWireGuardKeyPair - Keypair Curve25519 baru (base64, format wg genkey / wg pubkey)
+func GenerateWireGuardKeyPair() (privateKey, publicKey string, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(key.Bytes()),
+		base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// CreateWireGuardPeer - Buat peer di interface WireGuard router dengan keypair yang dibangkitkan di sini.
+// Tunnel IP diambil dari alamat bebas pertama di pool (default subnet address interface).
+// Private key tidak disimpan; hanya dikembalikan di hasil (juga untuk dry run, sebagai preview).
+func (ms *MikrotikService) CreateWireGuardPeer(routerID int, req *models.WireGuardPeerRequest, dryRun bool) (*models.WireGuardPeerResult, error) {
+	if err := ms.RequireFeature(routerID, FeatureWireGuard); err != nil {
+		return nil, err
+	}
+
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/interface/wireguard/print", fmt.Sprintf("?name=%s", req.Interface), "=.proplist=public-key,listen-port")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("wireguard interface %s not found", req.Interface)
+	}
+	serverKey, listenPort := r.Re[0].Map["public-key"], r.Re[0].Map["listen-port"]
+
+	plan := newCommandPlan(routerID, "create_wireguard_peer", dryRun)
+
+	// Alamat yang sudah terpakai: address interface + allowed-address peer yang ada
+	used := make(map[netip.Addr]bool)
+	var pool netip.Prefix
+	r, err = conn.Run("/ip/address/print", fmt.Sprintf("?interface=%s", req.Interface), "=.proplist=address")
+	if err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		if prefix, err := netip.ParsePrefix(re.Map["address"]); err == nil {
+			used[prefix.Addr()] = true
+			if !pool.IsValid() && prefix.Addr().Is4() {
+				pool = prefix.Masked()
+			}
+		}
+	}
+
+	if req.Pool != nil && *req.Pool != "" {
+		if pool, err = netip.ParsePrefix(*req.Pool); err != nil {
+			return nil, fmt.Errorf("invalid pool %s: %v", *req.Pool, err)
+		}
+		pool = pool.Masked()
+	}
+	if !pool.IsValid() {
+		return nil, fmt.Errorf("interface %s has no IPv4 address, pool is required", req.Interface)
+	}
+	if !pool.Addr().Is4() {
+		return nil, fmt.Errorf("only IPv4 pools are supported")
+	}
+	plan.Checks = append(plan.Checks, fmt.Sprintf("allocating from pool %s", pool))
+
+	r, err = conn.Run("/interface/wireguard/peers/print", fmt.Sprintf("?interface=%s", req.Interface), "=.proplist=allowed-address")
+	if err != nil {
+		return nil, err
+	}
+	for _, re := range r.Re {
+		for _, allowed := range strings.Split(re.Map["allowed-address"], ",") {
+			if prefix, err := netip.ParsePrefix(strings.TrimSpace(allowed)); err == nil && prefix.Bits() == 32 {
+				used[prefix.Addr()] = true
+			}
+		}
+	}
+	plan.Checks = append(plan.Checks, fmt.Sprintf("%d existing peer(s) on %s", len(r.Re), req.Interface))
+
+	address, ok := freeAddress(pool, used)
+	if !ok {
+		return nil, fmt.Errorf("no free address in pool %s", pool)
+	}
+
+	privateKey, publicKey, err := GenerateWireGuardKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Commands = append(plan.Commands, []string{
+		"/interface/wireguard/peers/add",
+		fmt.Sprintf("=interface=%s", req.Interface),
+		fmt.Sprintf("=public-key=%s", publicKey),
+		fmt.Sprintf("=allowed-address=%s/32", address),
+		fmt.Sprintf("=comment=%s", req.Name),
+	})
+	if err := executePlan(conn, plan); err != nil {
+		return nil, err
+	}
+
+	endpoint := conn.Router.Hostname
+	if req.Endpoint != nil && *req.Endpoint != "" {
+		endpoint = *req.Endpoint
+	}
+	allowedIPs := pool.String()
+	if req.AllowedIPs != nil && *req.AllowedIPs != "" {
+		allowedIPs = *req.AllowedIPs
+	}
+	keepalive := defaultWireGuardKeepalive
+	if req.Keepalive != nil {
+		keepalive = *req.Keepalive
+	}
+
+	var config strings.Builder
+	config.WriteString("[Interface]\n")
+	fmt.Fprintf(&config, "PrivateKey = %s\n", privateKey)
+	fmt.Fprintf(&config, "Address = %s/32\n", address)
+	if req.DNS != nil && *req.DNS != "" {
+		fmt.Fprintf(&config, "DNS = %s\n", *req.DNS)
+	}
+	config.WriteString("\n[Peer]\n")
+	fmt.Fprintf(&config, "PublicKey = %s\n", serverKey)
+	fmt.Fprintf(&config, "Endpoint = %s\n", net.JoinHostPort(endpoint, listenPort))
+	fmt.Fprintf(&config, "AllowedIPs = %s\n", allowedIPs)
+	if keepalive > 0 {
+		fmt.Fprintf(&config, "PersistentKeepalive = %d\n", keepalive)
+	}
+
+	return &models.WireGuardPeerResult{
+		Plan: plan,
+		Peer: models.WireGuardPeer{
+			Interface: req.Interface,
+			Name:      req.Name,
+			Address:   address.String() + "/32",
+			PublicKey: publicKey,
+		},
+		PrivateKey:   privateKey,
+		ServerKey:    serverKey,
+		Endpoint:     net.JoinHostPort(endpoint, listenPort),
+		AllowedIPs:   allowedIPs,
+		ClientConfig: config.String(),
+		QRPayload:    config.String(),
+	}, nil
+}
+
+// freeAddress - Host pertama di pool IPv4 yang belum terpakai (network & broadcast dilewati)
+func freeAddress(pool netip.Prefix, used map[netip.Addr]bool) (netip.Addr, bool) {
+	first := pool.Addr().As4()
+	start := binary.BigEndian.Uint32(first[:])
+	end := start | (1<<(32-pool.Bits()) - 1)
+	if pool.Bits() < 31 {
+		start, end = start+1, end-1
+	}
+
+	for n := uint64(start); n <= uint64(end); n++ {
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(n))
+		if addr := netip.AddrFrom4(b); !used[addr] {
+			return addr, true
+		}
+	}
+	return netip.Addr{}, false
+}
@@ -0,0 +1,414 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"Mikrotik-Layer/models"
+	"Mikrotik-Layer/repository"
+
+	"github.com/go-routeros/routeros/v3"
+)
+
+var (
+	leadingIntPattern   = regexp.MustCompile(`^-?\d+`)
+	wirelessRatePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)([kMG])bps`)
+)
+
+// parseLeadingInt - Angka di awal nilai RouterOS (mis. "-62dBm@6Mbps" -> -62, "87%" -> 87)
+func parseLeadingInt(v string) int {
+	n, _ := strconv.Atoi(leadingIntPattern.FindString(v))
+	return n
+}
+
+// parseWirelessRate - Rate wireless RouterOS ke Mbps (mis. "130Mbps-20MHz/2S/SGI" -> 130)
+func parseWirelessRate(v string) float64 {
+	m := wirelessRatePattern.FindStringSubmatch(v)
+	if m == nil {
+		return 0
+	}
+	rate, _ := strconv.ParseFloat(m[1], 64)
+	switch m[2] {
+	case "k":
+		rate /= 1000
+	case "G":
+		rate *= 1000
+	}
+	return rate
+}
+
+// GetWirelessMonitor - Snapshot /interface/wireless/monitor satu interface
+func (ms *MikrotikService) GetWirelessMonitor(routerID int, iface string) (*models.WirelessMonitor, error) {
+	if err := ms.RequireFeature(routerID, FeatureWireless); err != nil {
+		return nil, err
+	}
+
+	r, err := ms.runRead(routerID, "/interface/wireless/monitor", fmt.Sprintf("=numbers=%s", iface), "=once=")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("wireless interface %s not found", iface)
+	}
+
+	re := r.Re[0].Map
+	txCCQ := re["tx-ccq"]
+	if txCCQ == "" {
+		txCCQ = re["overall-tx-ccq"]
+	}
+	return &models.WirelessMonitor{
+		RouterID:          routerID,
+		Interface:         iface,
+		Status:            re["status"],
+		Frequency:         re["frequency"],
+		SSID:              re["ssid"],
+		RadioName:         re["radio-name"],
+		SignalStrength:    parseLeadingInt(re["signal-strength"]),
+		TxSignalStrength:  parseLeadingInt(re["tx-signal-strength"]),
+		NoiseFloor:        parseLeadingInt(re["noise-floor"]),
+		SignalToNoise:     parseLeadingInt(re["signal-to-noise"]),
+		TxRate:            re["tx-rate"],
+		RxRate:            re["rx-rate"],
+		TxRateMbps:        parseWirelessRate(re["tx-rate"]),
+		RxRateMbps:        parseWirelessRate(re["rx-rate"]),
+		TxCCQ:             parseLeadingInt(txCCQ),
+		RxCCQ:             parseLeadingInt(re["rx-ccq"]),
+		RegisteredClients: parseLeadingInt(re["registered-clients"]),
+		Timestamp:         time.Now(),
+	}, nil
+}
+
+// GetWirelessInterfaces - Nama interface wireless yang aktif (tidak disabled)
+func (ms *MikrotikService) GetWirelessInterfaces(routerID int) ([]string, error) {
+	if err := ms.RequireFeature(routerID, FeatureWireless); err != nil {
+		return nil, err
+	}
+
+	r, err := ms.runRead(routerID, "/interface/wireless/print", "=.proplist=name,disabled")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(r.Re))
+	for _, re := range r.Re {
+		if re.Map["disabled"] != "true" {
+			names = append(names, re.Map["name"])
+		}
+	}
+	return names, nil
+}
+
+// MonitorWirelessWithContext - Poll monitor wireless tiap interval sampai ctx selesai.
+// Lifecycle dikirim lewat onStatus seperti monitor traffic: router_offline saat koneksi
+// putus (poll diteruskan sampai pulih lalu stream_resumed), error RouterOS mengakhiri stream.
+func (ms *MikrotikService) MonitorWirelessWithContext(ctx context.Context, routerID int, iface string, interval time.Duration, callback func(*models.WirelessMonitor), onStatus func(status, reason string)) error {
+	stats, err := ms.GetWirelessMonitor(routerID, iface)
+	if err != nil {
+		return err
+	}
+	onStatus(StreamStarted, "")
+	callback(stats)
+
+	go func() {
+		defer Recover("wireless-monitor")
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		offline := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			stats, err := ms.GetWirelessMonitor(routerID, iface)
+			if err != nil {
+				var deviceErr *routeros.DeviceError
+				if errors.As(err, &deviceErr) {
+					onStatus(StreamError, deviceErr.Error())
+					onStatus(StreamEnded, "")
+					return
+				}
+				if !offline {
+					offline = true
+					onStatus(RouterOffline, err.Error())
+				}
+				continue
+			}
+			if offline {
+				offline = false
+				log.Printf("[WIRELESS] ✓ Monitor resumed for router %d, interface %s", routerID, iface)
+				onStatus(StreamResumed, "")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				callback(stats)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// WirelessSampler - Simpan kualitas link semua interface wireless router online secara
+// periodik (wireless_history) untuk tren jangka panjang backhaul
+type WirelessSampler struct {
+	interval  time.Duration
+	retention time.Duration
+	ms        *MikrotikService
+	repo      *repository.WirelessRepository
+
+	lastCleanup time.Time
+}
+
+func NewWirelessSampler(interval, retention time.Duration, ms *MikrotikService, repo *repository.WirelessRepository) *WirelessSampler {
+	return &WirelessSampler{
+		interval:    interval,
+		retention:   retention,
+		ms:          ms,
+		repo:        repo,
+		lastCleanup: time.Now(),
+	}
+}
+
+// Run - Loop sampling (blocking)
+func (s *WirelessSampler) Run() {
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !IsLeader() {
+			continue
+		}
+		for routerID, conn := range s.ms.GetAllConnections() {
+			if !conn.IsHealthy {
+				continue
+			}
+			s.sample(routerID)
+		}
+		s.cleanup(time.Now())
+	}
+}
+
+func (s *WirelessSampler) sample(routerID int) {
+	interfaces, err := s.ms.GetWirelessInterfaces(routerID)
+	if err != nil {
+		// Router tanpa package wireless bukan error
+		var unsupported *UnsupportedFeatureError
+		if !errors.As(err, &unsupported) {
+			log.Printf("[WIRELESS] Error listing interfaces router %d: %v", routerID, err)
+		}
+		return
+	}
+
+	for _, iface := range interfaces {
+		stats, err := s.ms.GetWirelessMonitor(routerID, iface)
+		if err != nil {
+			log.Printf("[WIRELESS] Router %d interface %s: %v", routerID, iface, err)
+			continue
+		}
+		err = s.repo.InsertSample(&models.WirelessSample{
+			RouterID:       routerID,
+			Interface:      iface,
+			SignalStrength: stats.SignalStrength,
+			SignalToNoise:  stats.SignalToNoise,
+			TxRateMbps:     stats.TxRateMbps,
+			RxRateMbps:     stats.RxRateMbps,
+			TxCCQ:          stats.TxCCQ,
+			RxCCQ:          stats.RxCCQ,
+			SampledAt:      stats.Timestamp,
+		})
+		if err != nil {
+			log.Printf("[WIRELESS] Error saving sample router %d interface %s: %v", routerID, iface, err)
+		}
+	}
+}
+
+// cleanup - Hapus history lebih tua dari retention (maksimal sekali per jam)
+func (s *WirelessSampler) cleanup(now time.Time) {
+	if s.retention <= 0 || now.Sub(s.lastCleanup) < time.Hour {
+		return
+	}
+	s.lastCleanup = now
+
+	deleted, err := s.repo.DeleteOlderThan(now.Add(-s.retention))
+	if err != nil {
+		log.Printf("[WIRELESS] Retention cleanup failed: %v", err)
+	} else if deleted > 0 {
+		log.Printf("[WIRELESS] Retention cleanup removed %d samples", deleted)
+	}
+}
+
+// GetWirelessRegistrations - Client terdaftar di /interface/wireless/registration-table
+// (iface kosong = semua interface)
+func (ms *MikrotikService) GetWirelessRegistrations(routerID int, iface string) ([]*models.WirelessRegistration, error) {
+	if err := ms.RequireFeature(routerID, FeatureWireless); err != nil {
+		return nil, err
+	}
+
+	args := []string{"/interface/wireless/registration-table/print"}
+	if iface != "" {
+		args = append(args, fmt.Sprintf("?interface=%s", iface))
+	}
+	r, err := ms.runRead(routerID, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]*models.WirelessRegistration, 0, len(r.Re))
+	for _, re := range r.Re {
+		m := re.Map
+		clients = append(clients, &models.WirelessRegistration{
+			ID:             m[".id"],
+			Interface:      m["interface"],
+			MACAddress:     m["mac-address"],
+			RadioName:      m["radio-name"],
+			LastIP:         m["last-ip"],
+			SignalStrength: parseLeadingInt(m["signal-strength"]),
+			SignalToNoise:  parseLeadingInt(m["signal-to-noise"]),
+			TxCCQ:          parseLeadingInt(m["tx-ccq"]),
+			RxCCQ:          parseLeadingInt(m["rx-ccq"]),
+			TxRate:         m["tx-rate"],
+			RxRate:         m["rx-rate"],
+			TxRateMbps:     parseWirelessRate(m["tx-rate"]),
+			RxRateMbps:     parseWirelessRate(m["rx-rate"]),
+			Uptime:         m["uptime"],
+		})
+	}
+	return clients, nil
+}
+
+// ListWirelessInterfaces - Konfigurasi semua interface wireless (termasuk yang disabled)
+func (ms *MikrotikService) ListWirelessInterfaces(routerID int) ([]*models.WirelessInterface, error) {
+	if err := ms.RequireFeature(routerID, FeatureWireless); err != nil {
+		return nil, err
+	}
+
+	r, err := ms.runRead(routerID, "/interface/wireless/print")
+	if err != nil {
+		return nil, err
+	}
+
+	ifaces := make([]*models.WirelessInterface, 0, len(r.Re))
+	for _, re := range r.Re {
+		m := re.Map
+		ifaces = append(ifaces, &models.WirelessInterface{
+			ID:              m[".id"],
+			Name:            m["name"],
+			Mode:            m["mode"],
+			SSID:            m["ssid"],
+			Band:            m["band"],
+			Frequency:       m["frequency"],
+			SecurityProfile: m["security-profile"],
+			MACAddress:      m["mac-address"],
+			Running:         m["running"] == "true",
+			Disabled:        m["disabled"] == "true",
+		})
+	}
+	return ifaces, nil
+}
+
+// SetWirelessInterface - Ganti SSID dan/atau security profile interface wireless.
+// Security profile harus sudah ada; field yang sudah sama tidak dikirim.
+func (ms *MikrotikService) SetWirelessInterface(routerID int, req *models.WirelessInterfaceRequest, dryRun bool) (*models.CommandPlan, error) {
+	if err := ms.RequireFeature(routerID, FeatureWireless); err != nil {
+		return nil, err
+	}
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	r, err := conn.Run("/interface/wireless/print", fmt.Sprintf("?name=%s", req.Interface),
+		"=.proplist=.id,ssid,security-profile")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("wireless interface %s not found", req.Interface)
+	}
+	current := r.Re[0].Map
+
+	plan := newCommandPlan(routerID, "set_wireless_interface", dryRun)
+	set := []string{"/interface/wireless/set", fmt.Sprintf("=.id=%s", current[".id"])}
+	if req.SSID != nil {
+		if *req.SSID == current["ssid"] {
+			plan.Checks = append(plan.Checks, fmt.Sprintf("ssid of %s already %s", req.Interface, *req.SSID))
+		} else {
+			set = append(set, fmt.Sprintf("=ssid=%s", *req.SSID))
+		}
+	}
+	if req.SecurityProfile != nil {
+		if *req.SecurityProfile == current["security-profile"] {
+			plan.Checks = append(plan.Checks, fmt.Sprintf("security profile of %s already %s", req.Interface, *req.SecurityProfile))
+		} else {
+			p, err := conn.Run("/interface/wireless/security-profiles/print",
+				fmt.Sprintf("?name=%s", *req.SecurityProfile), "=.proplist=.id")
+			if err != nil {
+				return nil, err
+			}
+			if len(p.Re) == 0 {
+				return nil, fmt.Errorf("security profile %s not found", *req.SecurityProfile)
+			}
+			set = append(set, fmt.Sprintf("=security-profile=%s", *req.SecurityProfile))
+		}
+	}
+	if len(set) > 2 {
+		plan.Commands = append(plan.Commands, set)
+	}
+
+	return plan, executePlan(conn, plan)
+}
+
+// KickWirelessClient - Putus client dari registration table (client biasanya langsung
+// reconnect kecuali diblokir access-list)
+func (ms *MikrotikService) KickWirelessClient(routerID int, mac string, dryRun bool) (*models.CommandPlan, error) {
+	if err := ms.RequireFeature(routerID, FeatureWireless); err != nil {
+		return nil, err
+	}
+	conn, err := ms.GetConnection(routerID)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	mac = strings.ToUpper(mac)
+	r, err := conn.Run("/interface/wireless/registration-table/print", fmt.Sprintf("?mac-address=%s", mac),
+		"=.proplist=.id,interface")
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Re) == 0 {
+		return nil, fmt.Errorf("wireless client %s not registered", mac)
+	}
+
+	plan := newCommandPlan(routerID, "kick_wireless_client", dryRun)
+	for _, re := range r.Re {
+		plan.Checks = append(plan.Checks, fmt.Sprintf("%s registered on %s", mac, re.Map["interface"]))
+		plan.Commands = append(plan.Commands, []string{
+			"/interface/wireless/registration-table/remove",
+			fmt.Sprintf("=.id=%s", re.Map[".id"]),
+		})
+	}
+
+	return plan, executePlan(conn, plan)
+}
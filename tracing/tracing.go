@@ -0,0 +1,229 @@
+package tracing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind span (nilai sesuai enum SpanKind OTLP)
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+)
+
+// Span - Satu operasi yang diukur (request HTTP, command RouterOS, query DB, tunggu mutex).
+// Semua method nil-safe: Start mengembalikan nil saat tracing nonaktif atau tidak di-sample.
+type Span struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	kind     int
+	start    time.Time
+	end      time.Time
+	attrs    map[string]interface{}
+	errMsg   string
+
+	gid  uint64
+	prev *Span // span aktif goroutine sebelum span ini dimulai
+}
+
+var (
+	exporter atomic.Pointer[otlpExporter]
+	sampling atomic.Uint64 // ambang sampling (dari ratio) atas 8 byte pertama trace ID
+
+	// Span aktif per goroutine: service yang belum menerima context tetap ter-parent ke span
+	// request HTTP / worker yang memanggilnya
+	activeMu sync.Mutex
+	active   = make(map[uint64]*Span)
+)
+
+// Init - Aktifkan export OTLP/HTTP (JSON) ke endpoint, mis. http://otel-collector:4318.
+// ratio = porsi trace baru yang di-sample (0..1).
+func Init(endpoint, serviceName string, ratio float64) {
+	if ratio <= 0 {
+		return
+	}
+	if ratio >= 1 {
+		sampling.Store(math.MaxUint64)
+	} else {
+		sampling.Store(uint64(ratio * math.MaxUint64))
+	}
+	e := newOTLPExporter(endpoint, serviceName)
+	exporter.Store(e)
+	go e.run()
+}
+
+// Enabled - True jika exporter dikonfigurasi
+func Enabled() bool {
+	return exporter.Load() != nil
+}
+
+// Start - Span anak dari span aktif goroutine ini; tanpa span aktif dimulai trace baru
+// (kena sampling). Span menjadi span aktif goroutine sampai End.
+func Start(name string, kind int) *Span {
+	if !Enabled() {
+		return nil
+	}
+	if parent := current(); parent != nil {
+		return begin(name, kind, parent.traceID, parent.spanID)
+	}
+	return startRoot(name, kind)
+}
+
+// StartChild - Seperti Start tapi hanya jika ada span aktif (tidak memulai trace baru),
+// untuk operasi yang hanya berarti di dalam request/worker yang di-trace
+func StartChild(name string, kind int) *Span {
+	if !Enabled() {
+		return nil
+	}
+	parent := current()
+	if parent == nil {
+		return nil
+	}
+	return begin(name, kind, parent.traceID, parent.spanID)
+}
+
+// StartRemote - Span root request masuk; traceparent W3C dari klien (jika valid) dipakai
+// sebagai parent beserta keputusan sampling-nya
+func StartRemote(name string, kind int, traceparent string) *Span {
+	if !Enabled() {
+		return nil
+	}
+	if traceID, parentID, sampled, ok := parseTraceparent(traceparent); ok {
+		if !sampled {
+			return nil
+		}
+		return begin(name, kind, traceID, parentID)
+	}
+	return startRoot(name, kind)
+}
+
+func startRoot(name string, kind int) *Span {
+	var traceID [16]byte
+	rand.Read(traceID[:])
+	if uint64FromBytes(traceID[:8]) > sampling.Load() {
+		return nil
+	}
+	return begin(name, kind, traceID, [8]byte{})
+}
+
+func begin(name string, kind int, traceID [16]byte, parentID [8]byte) *Span {
+	s := &Span{
+		traceID:  traceID,
+		parentID: parentID,
+		name:     name,
+		kind:     kind,
+		start:    time.Now(),
+		gid:      goroutineID(),
+	}
+	rand.Read(s.spanID[:])
+
+	activeMu.Lock()
+	s.prev = active[s.gid]
+	active[s.gid] = s
+	activeMu.Unlock()
+	return s
+}
+
+// SetAttr - Tambah atribut (string, int, int64, float64, bool)
+func (s *Span) SetAttr(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	if s.attrs == nil {
+		s.attrs = make(map[string]interface{})
+	}
+	s.attrs[key] = value
+}
+
+// SetError - Tandai span gagal (err nil diabaikan)
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.errMsg = err.Error()
+}
+
+// End - Selesaikan span, kembalikan span aktif goroutine ke parent lalu antrikan untuk export
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+
+	activeMu.Lock()
+	if active[s.gid] == s {
+		if s.prev != nil {
+			active[s.gid] = s.prev
+		} else {
+			delete(active, s.gid)
+		}
+	}
+	activeMu.Unlock()
+
+	if e := exporter.Load(); e != nil {
+		e.enqueue(s)
+	}
+}
+
+// TraceID - Trace ID hex (kosong untuk span nil), untuk korelasi di log / header response
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.traceID[:])
+}
+
+func current() *Span {
+	gid := goroutineID()
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	return active[gid]
+}
+
+// parseTraceparent - Header W3C "00-<trace-id>-<parent-id>-<flags>"
+func parseTraceparent(header string) (traceID [16]byte, parentID [8]byte, sampled, ok bool) {
+	if len(header) != 55 || header[2] != '-' || header[35] != '-' || header[52] != '-' {
+		return traceID, parentID, false, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(header[3:35])); err != nil {
+		return traceID, parentID, false, false
+	}
+	if _, err := hex.Decode(parentID[:], []byte(header[36:52])); err != nil {
+		return traceID, parentID, false, false
+	}
+	flags, err := strconv.ParseUint(header[53:55], 16, 8)
+	if err != nil || traceID == [16]byte{} || parentID == [8]byte{} {
+		return traceID, parentID, false, false
+	}
+	return traceID, parentID, flags&1 == 1, true
+}
+
+// goroutineID - ID goroutine dari header runtime.Stack ("goroutine 123 [running]:")
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+func uint64FromBytes(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
@@ -0,0 +1,263 @@
+// Package validation - Validasi request struct lewat tag `validate` (sintaks mirip go-playground/validator):
+//
+//	RateLimit string `json:"rate_limit" validate:"required,rate_limit"`
+//	Port      *int   `json:"port" validate:"min=1,max=65535"`
+//
+// Field kosong (zero value / pointer nil) hanya dicek oleh "required"; rule lain dilewati.
+// Nama field pada error memakai nama JSON.
+package validation
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"Mikrotik-Layer/i18n"
+	"Mikrotik-Layer/models"
+)
+
+// Rule yang didukung tag `validate`
+const (
+	RuleRequired  = "required"
+	RuleMin       = "min"
+	RuleMax       = "max"
+	RuleOneOf     = "oneof"
+	RuleRateLimit = "rate_limit" // upload/download RouterOS, mis. 10M/20M
+	RuleIP        = "ip"
+	RuleCIDR      = "cidr" // alamat dengan prefix, mis. 10.0.0.1/24
+	RuleHost      = "host" // IP atau hostname
+	RuleMonth     = "month"
+	RuleClock     = "clock"     // jam HH:MM, mis. 22:00
+	RuleURL       = "url"       // URL absolut http/https
+	RuleEmail     = "email"     // alamat email tanpa nama, mis. noc@isp.net
+	RuleMAC       = "mac"       // MAC address 48-bit, mis. AA:BB:CC:DD:EE:FF
+	RuleCron      = "cron"      // ekspresi cron 5 field, mis. 0 2 * * *
+	RulePrintable = "printable" // tanpa karakter kontrol (CR/LF dll.)
+	RuleDiffers   = "differs"   // hanya dipakai validasi manual (beda dengan field lain)
+	RuleExists    = "exists"    // hanya dipakai validasi manual (referensi ke data yang ada)
+	RuleUnique    = "unique"    // hanya dipakai validasi manual (nilai sudah dipakai data lain)
+	RulePattern   = "pattern"   // hanya dipakai validasi manual (naming policy regex)
+)
+
+var (
+	rateLimitPattern = regexp.MustCompile(`^\d+(\.\d+)?[kKMG]?/\d+(\.\d+)?[kKMG]?$`)
+	hostnamePattern  = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+)
+
+// FieldError - Satu pelanggaran rule pada satu field
+type FieldError struct {
+	Field string
+	Rule  string
+	Param string
+}
+
+// Errors - Kumpulan error per field; nil/kosong berarti valid
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fmt.Sprintf("%s: %s", fe.Field, Message(i18n.LangEN, fe))
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// ErrorCode - Code machine-readable untuk response API
+func (e Errors) ErrorCode() string {
+	return i18n.ValidationFailed
+}
+
+// Add - Tambah error field (untuk validasi manual / lintas field)
+func (e *Errors) Add(field, rule, param string) {
+	*e = append(*e, FieldError{Field: field, Rule: rule, Param: param})
+}
+
+// Message - Pesan rule dalam bahasa tertentu
+func Message(lang string, fe FieldError) string {
+	if fe.Param == "" {
+		return i18n.Translate(lang, "rule_"+fe.Rule)
+	}
+	param := fe.Param
+	if fe.Rule == RuleOneOf {
+		param = strings.ReplaceAll(param, " ", ", ")
+	}
+	return i18n.Translate(lang, "rule_"+fe.Rule, param)
+}
+
+// Struct - Validasi semua field bertag `validate` (termasuk embedded struct)
+func Struct(v interface{}) Errors {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs Errors
+	validateStruct(rv, &errs)
+	return errs
+}
+
+// Var - Validasi satu nilai (mis. query parameter) dengan tag rule
+func Var(field string, value interface{}, tag string) Errors {
+	var errs Errors
+	validateValue(field, reflect.ValueOf(value), tag, &errs)
+	return errs
+}
+
+func validateStruct(rv reflect.Value, errs *Errors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+
+		if sf.Anonymous && fv.Kind() == reflect.Struct {
+			validateStruct(fv, errs)
+			continue
+		}
+
+		tag := sf.Tag.Get("validate")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		validateValue(jsonName(sf), fv, tag, errs)
+	}
+}
+
+func validateValue(field string, fv reflect.Value, tag string, errs *Errors) {
+	rules := strings.Split(tag, ",")
+
+	if !fv.IsValid() || fv.IsZero() {
+		for _, rule := range rules {
+			if rule == RuleRequired {
+				errs.Add(field, RuleRequired, "")
+			}
+		}
+		return
+	}
+	for fv.Kind() == reflect.Ptr {
+		fv = fv.Elem()
+	}
+
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == RuleRequired {
+			// Pointer ke string kosong tetap dianggap kosong
+			if fv.Kind() == reflect.String && strings.TrimSpace(fv.String()) == "" {
+				errs.Add(field, RuleRequired, "")
+				return
+			}
+			continue
+		}
+		if !check(name, param, fv) {
+			errs.Add(field, name, param)
+		}
+	}
+}
+
+// check - Evaluasi satu rule; rule yang tidak dikenal dianggap lolos
+func check(rule, param string, fv reflect.Value) bool {
+	switch rule {
+	case RuleMin, RuleMax:
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return true
+		}
+		n, ok := measure(fv)
+		if !ok {
+			return true
+		}
+		if rule == RuleMin {
+			return n >= limit
+		}
+		return n <= limit
+
+	case RuleOneOf:
+		s := fmt.Sprint(fv.Interface())
+		for _, opt := range strings.Fields(param) {
+			if s == opt {
+				return true
+			}
+		}
+		return false
+
+	case RuleRateLimit:
+		return rateLimitPattern.MatchString(fv.String())
+
+	case RuleIP:
+		return net.ParseIP(fv.String()) != nil
+
+	case RuleCIDR:
+		_, _, err := net.ParseCIDR(fv.String())
+		return err == nil
+
+	case RuleHost:
+		s := fv.String()
+		return net.ParseIP(s) != nil || (len(s) <= 253 && hostnamePattern.MatchString(s))
+
+	case RuleMonth:
+		_, err := time.Parse("2006-01", fv.String())
+		return err == nil
+
+	case RuleClock:
+		t, err := time.Parse("15:04", fv.String())
+		return err == nil && t.Format("15:04") == fv.String()
+
+	case RuleURL:
+		u, err := url.Parse(fv.String())
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+
+	case RuleEmail:
+		addr, err := mail.ParseAddress(fv.String())
+		return err == nil && addr.Address == fv.String()
+
+	case RuleMAC:
+		mac, err := net.ParseMAC(fv.String())
+		return err == nil && len(mac) == 6
+
+	case RuleCron:
+		_, err := models.ParseCron(fv.String())
+		return err == nil
+
+	case RulePrintable:
+		return !strings.ContainsFunc(fv.String(), unicode.IsControl)
+	}
+	return true
+}
+
+// measure - Nilai pembanding min/max: angka apa adanya, panjang untuk string/slice/map
+func measure(fv reflect.Value) (float64, bool) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(fv.String())), true
+	case reflect.Slice, reflect.Map:
+		return float64(fv.Len()), true
+	}
+	return 0, false
+}
+
+// jsonName - Nama field sesuai tag json (fallback nama Go)
+func jsonName(sf reflect.StructField) string {
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return sf.Name
+}